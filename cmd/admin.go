@@ -66,6 +66,8 @@ func subcmdAuth() *cli.Command {
 			microcmdAuthUpdateLdapSimpleAuth(),
 			microcmdAuthAddPAM(),
 			microcmdAuthUpdatePAM(),
+			microcmdAuthAddSAML(),
+			microcmdAuthUpdateSAML(),
 			microcmdAuthAddSMTP(),
 			microcmdAuthUpdateSMTP(),
 			microcmdAuthList(),
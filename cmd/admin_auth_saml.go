@@ -0,0 +1,264 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/services/auth/source/saml"
+
+	"github.com/urfave/cli/v3"
+)
+
+func samlCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Application Name",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-url",
+			Value: "",
+			Usage: "URL of the identity provider metadata",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-file",
+			Value: "",
+			Usage: "Path to a file containing the identity provider metadata",
+		},
+		&cli.StringFlag{
+			Name:  "sp-certificate-file",
+			Value: "",
+			Usage: "Path to a PEM encoded certificate of the service provider",
+		},
+		&cli.StringFlag{
+			Name:  "sp-private-key-file",
+			Value: "",
+			Usage: "Path to a PEM encoded RSA private key of the service provider",
+		},
+		&cli.BoolFlag{
+			Name:  "sign-requests",
+			Usage: "Sign authentication requests with the service provider key",
+		},
+		&cli.StringFlag{
+			Name:  "name-id-format",
+			Value: "",
+			Usage: "NameID format to request, defaults to persistent",
+		},
+		&cli.StringFlag{
+			Name:  "username-attribute",
+			Value: "",
+			Usage: "Attribute containing the username",
+		},
+		&cli.StringFlag{
+			Name:  "email-attribute",
+			Value: "",
+			Usage: "Attribute containing the email address",
+		},
+		&cli.StringFlag{
+			Name:  "full-name-attribute",
+			Value: "",
+			Usage: "Attribute containing the full name",
+		},
+		&cli.StringFlag{
+			Name:  "group-attribute",
+			Value: "",
+			Usage: "Attribute containing the groups of the user",
+		},
+		&cli.StringFlag{
+			Name:  "admin-group",
+			Value: "",
+			Usage: "Group value for administrator users",
+		},
+		&cli.StringFlag{
+			Name:  "restricted-group",
+			Value: "",
+			Usage: "Group value for restricted users",
+		},
+		&cli.StringFlag{
+			Name:  "group-team-map",
+			Value: "",
+			Usage: "JSON mapping between groups and org teams",
+		},
+		&cli.BoolFlag{
+			Name:  "group-team-map-removal",
+			Usage: "Activate automatic team membership removal depending on groups",
+		},
+		&cli.StringFlag{
+			Name:  "icon-url",
+			Value: "",
+			Usage: "Custom icon URL for the sign-in button",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-local-2fa",
+			Usage: "Skip 2FA to log on.",
+		},
+		&cli.BoolFlag{
+			Name:  "active",
+			Usage: "This Authentication Source is Activated.",
+			Value: true,
+		},
+	}
+}
+
+func microcmdAuthAddSAML() *cli.Command {
+	return &cli.Command{
+		Name:   "add-saml",
+		Usage:  "Add new SAML authentication source",
+		Before: noDanglingArgs,
+		Action: newAuthService().addSAML,
+		Flags:  samlCLIFlags(),
+	}
+}
+
+func microcmdAuthUpdateSAML() *cli.Command {
+	return &cli.Command{
+		Name:   "update-saml",
+		Usage:  "Update existing SAML authentication source",
+		Before: noDanglingArgs,
+		Action: newAuthService().updateSAML,
+		Flags:  append(samlCLIFlags()[:1], append([]cli.Flag{idFlag()}, samlCLIFlags()[1:]...)...),
+	}
+}
+
+// readSAMLFile returns the trimmed content of the file given by the flag
+func readSAMLFile(c *cli.Command, flag string) (string, error) {
+	data, err := os.ReadFile(c.String(flag))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// applySAMLFlags sets the options of the config for which a flag was given
+func applySAMLFlags(c *cli.Command, config *saml.Source) error {
+	var err error
+	if c.IsSet("idp-metadata-url") {
+		config.IdentityProviderMetadataURL = c.String("idp-metadata-url")
+	}
+	if c.IsSet("idp-metadata-file") {
+		if config.IdentityProviderMetadata, err = readSAMLFile(c, "idp-metadata-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-certificate-file") {
+		if config.ServiceProviderCertificate, err = readSAMLFile(c, "sp-certificate-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-private-key-file") {
+		if config.ServiceProviderPrivateKey, err = readSAMLFile(c, "sp-private-key-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sign-requests") {
+		config.SignRequests = c.Bool("sign-requests")
+	}
+	if c.IsSet("name-id-format") {
+		config.NameIDFormat = c.String("name-id-format")
+	}
+	if c.IsSet("username-attribute") {
+		config.UsernameAttribute = c.String("username-attribute")
+	}
+	if c.IsSet("email-attribute") {
+		config.EmailAttribute = c.String("email-attribute")
+	}
+	if c.IsSet("full-name-attribute") {
+		config.FullNameAttribute = c.String("full-name-attribute")
+	}
+	if c.IsSet("group-attribute") {
+		config.GroupAttribute = c.String("group-attribute")
+	}
+	if c.IsSet("admin-group") {
+		config.AdminGroup = c.String("admin-group")
+	}
+	if c.IsSet("restricted-group") {
+		config.RestrictedGroup = c.String("restricted-group")
+	}
+	if c.IsSet("group-team-map") {
+		config.GroupTeamMap = c.String("group-team-map")
+	}
+	if c.IsSet("group-team-map-removal") {
+		config.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
+	}
+	if c.IsSet("icon-url") {
+		config.IconURL = c.String("icon-url")
+	}
+	if c.IsSet("skip-local-2fa") {
+		config.SkipLocalTwoFA = c.Bool("skip-local-2fa")
+	}
+	return nil
+}
+
+func (a *authService) addSAML(ctx context.Context, c *cli.Command) error {
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	if !c.IsSet("name") || len(c.String("name")) == 0 {
+		return errors.New("name must be set")
+	}
+	if c.String("idp-metadata-url") == "" && c.String("idp-metadata-file") == "" {
+		return errors.New("idp-metadata-url or idp-metadata-file must be set")
+	}
+
+	config := &saml.Source{}
+	if err := applySAMLFlags(c, config); err != nil {
+		return err
+	}
+	if config.SignRequests && config.ServiceProviderPrivateKey == "" {
+		return errors.New("sp-certificate-file and sp-private-key-file must be set to sign requests")
+	}
+
+	return a.createAuthSource(ctx, &auth_model.Source{
+		Type:     auth_model.SAML,
+		Name:     c.String("name"),
+		IsActive: c.Bool("active"),
+		Cfg:      config,
+	})
+}
+
+func (a *authService) updateSAML(ctx context.Context, c *cli.Command) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := a.getAuthSource(ctx, c, auth_model.SAML)
+	if err != nil {
+		return err
+	}
+
+	samlConfig := source.Cfg.(*saml.Source)
+
+	if c.IsSet("name") {
+		source.Name = c.String("name")
+	}
+
+	if err := applySAMLFlags(c, samlConfig); err != nil {
+		return err
+	}
+
+	if c.IsSet("active") {
+		source.IsActive = c.Bool("active")
+	}
+
+	source.Cfg = samlConfig
+
+	return a.updateAuthSource(ctx, source)
+}
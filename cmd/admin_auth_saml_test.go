@@ -0,0 +1,254 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/test"
+	"forgejo.org/services/auth/source/saml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestAddSAML(t *testing.T) {
+	// Mock cli functions to do not exit on error
+	defer test.MockVariableValue(&cli.OsExiter, func(code int) {})()
+
+	metadataFile := filepath.Join(t.TempDir(), "metadata.xml")
+	require.NoError(t, os.WriteFile(metadataFile, []byte("<EntityDescriptor/>\n"), 0o600))
+
+	// Test cases
+	cases := []struct {
+		args   []string
+		source *auth.Source
+		errMsg string
+	}{
+		// case 0
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Corporate IdP",
+				"--idp-metadata-url", "https://idp.example.com/metadata",
+				"--email-attribute", "mail",
+				"--group-attribute", "memberOf",
+				"--admin-group", "admins",
+				"--group-team-map", `{"developers": {"org": ["team"]}}`,
+				"--group-team-map-removal",
+			},
+			source: &auth.Source{
+				Type:     auth.SAML,
+				Name:     "Corporate IdP",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadataURL: "https://idp.example.com/metadata",
+					EmailAttribute:              "mail",
+					GroupAttribute:              "memberOf",
+					AdminGroup:                  "admins",
+					GroupTeamMap:                `{"developers": {"org": ["team"]}}`,
+					GroupTeamMapRemoval:         true,
+				},
+			},
+		},
+		// case 1
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Corporate IdP",
+				"--idp-metadata-file", metadataFile,
+				"--active=false",
+			},
+			source: &auth.Source{
+				Type:     auth.SAML,
+				Name:     "Corporate IdP",
+				IsActive: false,
+				Cfg: &saml.Source{
+					IdentityProviderMetadata: "<EntityDescriptor/>",
+				},
+			},
+		},
+		// case 2
+		{
+			args: []string{
+				"saml-test",
+				"--idp-metadata-url", "https://idp.example.com/metadata",
+			},
+			errMsg: "name must be set",
+		},
+		// case 3
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Corporate IdP",
+			},
+			errMsg: "idp-metadata-url or idp-metadata-file must be set",
+		},
+		// case 4
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Corporate IdP",
+				"--idp-metadata-url", "https://idp.example.com/metadata",
+				"--sign-requests",
+			},
+			errMsg: "sp-certificate-file and sp-private-key-file must be set to sign requests",
+		},
+	}
+
+	for n, c := range cases {
+		// Mock functions.
+		var createdAuthSource *auth.Source
+		service := &authService{
+			initDB: func(context.Context) error {
+				return nil
+			},
+			createAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				createdAuthSource = authSource
+				return nil
+			},
+			updateAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				assert.FailNow(t, "should not call updateAuthSource", "case: %d", n)
+				return nil
+			},
+			getAuthSourceByID: func(ctx context.Context, id int64) (*auth.Source, error) {
+				assert.FailNow(t, "should not call getAuthSourceByID", "case: %d", n)
+				return nil, nil
+			},
+		}
+
+		// Create a copy of command to test
+		app := cli.Command{}
+		app.Flags = microcmdAuthAddSAML().Flags
+		app.Action = service.addSAML
+
+		// Run it
+		err := app.Run(t.Context(), c.args)
+		if c.errMsg != "" {
+			assert.EqualError(t, err, c.errMsg, "case %d: error should match", n)
+		} else {
+			require.NoError(t, err, "case %d: should have no errors", n)
+			assert.Equal(t, c.source, createdAuthSource, "case %d: wrong authSource", n)
+		}
+	}
+}
+
+func TestUpdateSAML(t *testing.T) {
+	// Mock cli functions to do not exit on error
+	defer test.MockVariableValue(&cli.OsExiter, func(code int) {})()
+
+	// Test cases
+	cases := []struct {
+		args               []string
+		id                 int64
+		existingAuthSource *auth.Source
+		authSource         *auth.Source
+		errMsg             string
+	}{
+		// case 0
+		{
+			args: []string{
+				"saml-test",
+				"--id", "23",
+				"--name", "Corporate IdP",
+				"--username-attribute", "uid",
+			},
+			id: 23,
+			existingAuthSource: &auth.Source{
+				Type:     auth.SAML,
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadataURL: "https://idp.example.com/metadata",
+				},
+			},
+			authSource: &auth.Source{
+				Type:     auth.SAML,
+				Name:     "Corporate IdP",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadataURL: "https://idp.example.com/metadata",
+					UsernameAttribute:           "uid",
+				},
+			},
+		},
+		// case 1
+		{
+			args: []string{
+				"saml-test",
+				"--id", "1",
+				"--active=false",
+				"--skip-local-2fa",
+			},
+			existingAuthSource: &auth.Source{
+				Type:     auth.SAML,
+				IsActive: true,
+				Cfg:      &saml.Source{},
+			},
+			authSource: &auth.Source{
+				Type:     auth.SAML,
+				IsActive: false,
+				Cfg: &saml.Source{
+					SkipLocalTwoFA: true,
+				},
+			},
+		},
+		// case 2
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Corporate IdP",
+			},
+			errMsg: "--id flag is missing",
+		},
+	}
+
+	for n, c := range cases {
+		// Mock functions.
+		var updatedAuthSource *auth.Source
+		service := &authService{
+			initDB: func(context.Context) error {
+				return nil
+			},
+			createAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				assert.FailNow(t, "should not call createAuthSource", "case: %d", n)
+				return nil
+			},
+			updateAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				updatedAuthSource = authSource
+				return nil
+			},
+			getAuthSourceByID: func(ctx context.Context, id int64) (*auth.Source, error) {
+				if c.id != 0 {
+					assert.Equal(t, c.id, id, "case %d: wrong id", n)
+				}
+				if c.existingAuthSource != nil {
+					return c.existingAuthSource, nil
+				}
+				return &auth.Source{
+					Type: auth.SAML,
+					Cfg:  &saml.Source{},
+				}, nil
+			},
+		}
+
+		// Create a copy of command to test
+		app := cli.Command{}
+		app.Flags = microcmdAuthUpdateSAML().Flags
+		app.Action = service.updateSAML
+
+		// Run it
+		err := app.Run(t.Context(), c.args)
+		if c.errMsg != "" {
+			assert.EqualError(t, err, c.errMsg, "case %d: error should match", n)
+		} else {
+			require.NoError(t, err, "case %d: should have no errors", n)
+			assert.Equal(t, c.authSource, updatedAuthSource, "case %d: wrong authSource", n)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
+	user_service "forgejo.org/services/user"
 
 	"github.com/urfave/cli/v3"
 )
@@ -69,6 +70,11 @@ func runResetMFA(ctx context.Context, c *cli.Command) error {
 		}
 	}
 
+	// sign the user out everywhere, as the sessions may have been opened by whoever had the second factor
+	if err := user_service.SignOutSessions(ctx, user, ""); err != nil {
+		return err
+	}
+
 	fmt.Printf("%s's two-factor authentication settings have been removed!\n", user.Name)
 	return nil
 }
@@ -163,25 +163,33 @@ func runServ(ctx context.Context, c *cli.Command) error {
 		}
 	}()
 
+	// "key-<id>" for public keys, "cert-<serial>" for SSH certificates issued by this instance
 	keys := strings.Split(c.Args().First(), "-")
-	if len(keys) != 2 || keys[0] != "key" {
+	if len(keys) != 2 || (keys[0] != "key" && keys[0] != "cert") {
 		return fail(ctx, "Key ID format error", "Invalid key argument: %s", c.Args().First())
 	}
 	keyID, err := strconv.ParseInt(keys[1], 10, 64)
 	if err != nil {
 		return fail(ctx, "Key ID parsing error", "Invalid key argument: %s", c.Args().Get(1))
 	}
+	isCert := keys[0] == "cert"
+	servNoCommand, servCommand := private.ServNoCommand, private.ServCommand
+	if isCert {
+		servNoCommand, servCommand = private.ServNoCommandForCertificate, private.ServCommandForCertificate
+	}
 
 	cmd := os.Getenv("SSH_ORIGINAL_COMMAND")
 	if len(cmd) == 0 {
-		key, user, err := private.ServNoCommand(ctx, keyID)
+		key, user, err := servNoCommand(ctx, keyID)
 		if err != nil {
 			return fail(ctx, "Key check failed", "Failed to check provided key: %v", err)
 		}
-		switch key.Type {
-		case asymkey_model.KeyTypeDeploy:
+		switch {
+		case isCert:
+			fmt.Println("Hi there, " + user.Name + "! You've successfully authenticated with the certificate " + key.Name + ", but Forgejo does not provide shell access.")
+		case key.Type == asymkey_model.KeyTypeDeploy:
 			fmt.Println("Hi there! You've successfully authenticated with the deploy key named " + key.Name + ", but Forgejo does not provide shell access.")
-		case asymkey_model.KeyTypePrincipal:
+		case key.Type == asymkey_model.KeyTypePrincipal:
 			fmt.Println("Hi there! You've successfully authenticated with the principal " + key.Content + ", but Forgejo does not provide shell access.")
 		default:
 			fmt.Println("Hi there, " + user.Name + "! You've successfully authenticated with the key named " + key.Name + ", but Forgejo does not provide shell access.")
@@ -271,7 +279,7 @@ func runServ(ctx context.Context, c *cli.Command) error {
 		}
 	}
 
-	results, extra := private.ServCommand(ctx, keyID, username, reponame, requestedMode, verb, lfsVerb)
+	results, extra := servCommand(ctx, keyID, username, reponame, requestedMode, verb, lfsVerb)
 	if extra.HasError() {
 		return fail(ctx, extra.UserMsg, "ServCommand failed: %s", extra.Error)
 	}
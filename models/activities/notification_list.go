@@ -23,6 +23,7 @@ type FindNotificationOptions struct {
 	db.ListOptions
 	UserID            int64
 	RepoID            int64
+	ExcludedRepoIDs   []int64
 	IssueID           int64
 	Status            []NotificationStatus
 	Source            []NotificationSource
@@ -39,6 +40,9 @@ func (opts FindNotificationOptions) ToConds() builder.Cond {
 	if opts.RepoID != 0 {
 		cond = cond.And(builder.Eq{"notification.repo_id": opts.RepoID})
 	}
+	if len(opts.ExcludedRepoIDs) > 0 {
+		cond = cond.And(builder.NotIn("notification.repo_id", opts.ExcludedRepoIDs))
+	}
 	if opts.IssueID != 0 {
 		cond = cond.And(builder.Eq{"notification.issue_id": opts.IssueID})
 	}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrAdvisoryNotExist indicates an advisory not exist error
+var ErrAdvisoryNotExist = util.NewNotExistErrorf("advisory does not exist")
+
+func init() {
+	db.RegisterModel(new(Advisory))
+	db.RegisterModel(new(AdvisoryPackage))
+}
+
+// Advisory is an imported vulnerability advisory
+type Advisory struct {
+	ID int64 `xorm:"pk autoincr"`
+	// Identifier is the id of the advisory in its database, like GHSA-xxxx-xxxx-xxxx
+	Identifier    string            `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	Aliases       []string          `xorm:"JSON TEXT"`
+	Summary       string            `xorm:"TEXT"`
+	Severity      osv.SeverityLevel `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Content       string            `xorm:"LONGTEXT NOT NULL"`
+	PublishedUnix timeutil.TimeStamp
+	ModifiedUnix  timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+
+	entry *osv.Entry
+}
+
+// AdvisoryPackage is a package an advisory affects
+type AdvisoryPackage struct {
+	ID         int64  `xorm:"pk autoincr"`
+	AdvisoryID int64  `xorm:"INDEX NOT NULL"`
+	Ecosystem  string `xorm:"VARCHAR(50) INDEX(s) NOT NULL"`
+	// Name is the normalized name of the package
+	Name string `xorm:"VARCHAR(255) INDEX(s) NOT NULL"`
+}
+
+// Entry returns the parsed OSV entry of the advisory
+func (a *Advisory) Entry() (*osv.Entry, error) {
+	if a.entry == nil {
+		e, err := osv.Parse([]byte(a.Content))
+		if err != nil {
+			return nil, err
+		}
+		a.entry = e
+	}
+	return a.entry, nil
+}
+
+// NewAdvisory creates an advisory from an OSV entry
+func NewAdvisory(e *osv.Entry) (*Advisory, error) {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Advisory{
+		Identifier:   e.ID,
+		Aliases:      e.Aliases,
+		Summary:      e.Summary,
+		Severity:     e.SeverityLevel(),
+		Content:      string(content),
+		ModifiedUnix: timeutil.TimeStamp(e.Modified.Unix()),
+		entry:        e,
+	}
+	if e.Published != nil {
+		a.PublishedUnix = timeutil.TimeStamp(e.Published.Unix())
+	}
+	return a, nil
+}
+
+// GetAdvisoryByID gets an advisory by id
+func GetAdvisoryByID(ctx context.Context, id int64) (*Advisory, error) {
+	a := &Advisory{}
+	has, err := db.GetEngine(ctx).ID(id).Get(a)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrAdvisoryNotExist
+	}
+	return a, nil
+}
+
+// GetAdvisoryByIdentifier gets an advisory by its id in its database
+func GetAdvisoryByIdentifier(ctx context.Context, identifier string) (*Advisory, error) {
+	a := &Advisory{}
+	has, err := db.GetEngine(ctx).Where("identifier = ?", identifier).Get(a)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrAdvisoryNotExist
+	}
+	return a, nil
+}
+
+// GetAdvisoriesByIDs gets the advisories with the ids
+func GetAdvisoriesByIDs(ctx context.Context, ids []int64) (map[int64]*Advisory, error) {
+	advisories := make(map[int64]*Advisory, len(ids))
+	if len(ids) == 0 {
+		return advisories, nil
+	}
+	return advisories, db.GetEngine(ctx).In("id", ids).Find(&advisories)
+}
+
+// GetAdvisoriesForPackage gets the advisories affecting any version of the package
+func GetAdvisoriesForPackage(ctx context.Context, ecosystem, name string) ([]*Advisory, error) {
+	advisories := make([]*Advisory, 0, 5)
+	return advisories, db.GetEngine(ctx).
+		Where(builder.In("id", builder.Select("advisory_id").From("advisory_package").Where(builder.Eq{
+			"ecosystem": ecosystem,
+			"name":      osv.NormalizeName(ecosystem, name),
+		}))).
+		OrderBy("id").
+		Find(&advisories)
+}
+
+// SaveAdvisory inserts or updates an advisory and the packages it affects
+func SaveAdvisory(ctx context.Context, a *Advisory) error {
+	e, err := a.Entry()
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if a.ID == 0 {
+			if err := db.Insert(ctx, a); err != nil {
+				return err
+			}
+		} else {
+			if _, err := db.GetEngine(ctx).ID(a.ID).AllCols().Update(a); err != nil {
+				return err
+			}
+			if _, err := db.GetEngine(ctx).Where("advisory_id = ?", a.ID).Delete(&AdvisoryPackage{}); err != nil {
+				return err
+			}
+		}
+
+		seen := make(map[AdvisoryPackage]bool, len(e.Affected))
+		for _, affected := range e.Affected {
+			ecosystem := osv.NormalizeEcosystem(affected.Package.Ecosystem)
+			ap := AdvisoryPackage{
+				AdvisoryID: a.ID,
+				Ecosystem:  ecosystem,
+				Name:       osv.NormalizeName(ecosystem, affected.Package.Name),
+			}
+			if ap.Name == "" || seen[ap] {
+				continue
+			}
+			seen[ap] = true
+			if err := db.Insert(ctx, &ap); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteAdvisory deletes an advisory, its packages and its alerts
+func DeleteAdvisory(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("advisory_id = ?", id).Delete(&AdvisoryPackage{}); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("advisory_id = ?", id).Delete(&Alert{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(&Advisory{})
+		return err
+	})
+}
+
+// CountAdvisories counts the imported advisories
+func CountAdvisories(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(&Advisory{})
+}
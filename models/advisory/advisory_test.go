@@ -0,0 +1,126 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/osv"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAdvisory(t *testing.T, id, ecosystem, name string) *Advisory {
+	a, err := NewAdvisory(&osv.Entry{
+		ID:       id,
+		Modified: time.Now(),
+		Summary:  "summary of " + id,
+		Affected: []osv.Affected{
+			{Package: osv.Package{Ecosystem: ecosystem, Name: name}},
+			{Package: osv.Package{Ecosystem: ecosystem + ":1", Name: name}},
+		},
+		DatabaseSpecific: map[string]any{"severity": "CRITICAL"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, SaveAdvisory(db.DefaultContext, a))
+	return a
+}
+
+func TestAdvisory(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	a := createTestAdvisory(t, "GHSA-1", osv.EcosystemPyPI, "Some_Package")
+	assert.Equal(t, osv.SeverityCritical, a.Severity)
+	unittest.AssertCount(t, &AdvisoryPackage{AdvisoryID: a.ID}, 1)
+
+	advisories, err := GetAdvisoriesForPackage(db.DefaultContext, osv.EcosystemPyPI, "some.package")
+	require.NoError(t, err)
+	require.Len(t, advisories, 1)
+	assert.Equal(t, "GHSA-1", advisories[0].Identifier)
+
+	e, err := advisories[0].Entry()
+	require.NoError(t, err)
+	assert.Equal(t, "summary of GHSA-1", e.Summary)
+
+	advisories, err = GetAdvisoriesForPackage(db.DefaultContext, osv.EcosystemNpm, "some-package")
+	require.NoError(t, err)
+	assert.Empty(t, advisories)
+
+	require.NoError(t, DeleteAdvisory(db.DefaultContext, a.ID))
+	unittest.AssertNotExistsBean(t, &Advisory{ID: a.ID})
+	unittest.AssertCount(t, &AdvisoryPackage{AdvisoryID: a.ID}, 0)
+}
+
+func TestSyncAlerts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	a1 := createTestAdvisory(t, "GHSA-1", osv.EcosystemNpm, "a")
+	a2 := createTestAdvisory(t, "GHSA-2", osv.EcosystemNpm, "b")
+
+	newAlert := func(a *Advisory, name, version string) *Alert {
+		return &Alert{
+			AdvisoryID:   a.ID,
+			ManifestPath: "package-lock.json",
+			Ecosystem:    osv.EcosystemNpm,
+			PackageName:  name,
+			Version:      version,
+			Severity:     a.Severity,
+			Advisory:     a,
+		}
+	}
+
+	opened, err := SyncAlerts(db.DefaultContext, 1, 0, []*Alert{newAlert(a1, "a", "1.0.0"), newAlert(a2, "b", "2.0.0")})
+	require.NoError(t, err)
+	assert.Len(t, opened, 2)
+
+	alerts, err := db.Find[Alert](db.DefaultContext, FindAlertsOptions{RepoID: 1, Status: optional.Some(AlertStatusOpen)})
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+
+	// a known alert is not opened again
+	opened, err = SyncAlerts(db.DefaultContext, 1, 0, []*Alert{newAlert(a1, "a", "1.0.0"), newAlert(a2, "b", "2.0.0")})
+	require.NoError(t, err)
+	assert.Empty(t, opened)
+
+	// a dismissed alert stays dismissed, a missing alert is fixed
+	dismissed := alerts[0]
+	require.NoError(t, SetAlertStatus(db.DefaultContext, dismissed, AlertStatusDismissed, 2))
+	kept := newAlert(a1, "a", "1.0.0")
+	if dismissed.AdvisoryID != a1.ID {
+		kept = newAlert(a2, "b", "2.0.0")
+	}
+	opened, err = SyncAlerts(db.DefaultContext, 1, 0, []*Alert{kept})
+	require.NoError(t, err)
+	assert.Empty(t, opened)
+
+	counts, err := CountAlertsBySeverity(db.DefaultContext, 1, AlertStatusFixed)
+	require.NoError(t, err)
+	assert.Equal(t, map[osv.SeverityLevel]int64{osv.SeverityCritical: 1}, counts)
+
+	// a fixed alert is reopened
+	opened, err = SyncAlerts(db.DefaultContext, 1, 0, []*Alert{newAlert(a1, "a", "1.0.0"), newAlert(a2, "b", "2.0.0")})
+	require.NoError(t, err)
+	require.Len(t, opened, 1)
+	assert.NotEqual(t, dismissed.ID, opened[0].ID)
+
+	// alerts of package versions are synced separately
+	opened, err = SyncAlerts(db.DefaultContext, 1, 5, []*Alert{newAlert(a1, "a", "1.0.0")})
+	require.NoError(t, err)
+	assert.Len(t, opened, 1)
+	count, err := db.Count[Alert](db.DefaultContext, FindAlertsOptions{RepoID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+
+	require.NoError(t, DeleteAlertsByRepoID(db.DefaultContext, 1))
+	unittest.AssertCount(t, &Alert{}, 1)
+	unittest.AssertExistsAndLoadBean(t, &Alert{PackageVersionID: 5, RepoID: 0})
+
+	require.NoError(t, DeleteAlertsByPackageVersionID(db.DefaultContext, 5))
+	unittest.AssertCount(t, &Alert{}, 0)
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrAlertNotExist indicates an alert not exist error
+var ErrAlertNotExist = util.NewNotExistErrorf("alert does not exist")
+
+func init() {
+	db.RegisterModel(new(Alert))
+}
+
+// AlertStatus is the state of an alert
+type AlertStatus int
+
+const (
+	AlertStatusOpen AlertStatus = iota
+	AlertStatusFixed
+	AlertStatusDismissed
+)
+
+func (s AlertStatus) String() string {
+	switch s {
+	case AlertStatusFixed:
+		return "fixed"
+	case AlertStatusDismissed:
+		return "dismissed"
+	}
+	return "open"
+}
+
+// ParseAlertStatus parses the name of a status
+func ParseAlertStatus(s string) optional.Option[AlertStatus] {
+	switch s {
+	case "open":
+		return optional.Some(AlertStatusOpen)
+	case "fixed":
+		return optional.Some(AlertStatusFixed)
+	case "dismissed":
+		return optional.Some(AlertStatusDismissed)
+	}
+	return optional.None[AlertStatus]()
+}
+
+// Alert is a dependency of a repository or a package version affected by an advisory.
+// Alerts of the dependencies of a package version also belong to the repository the package is linked to.
+type Alert struct {
+	ID               int64 `xorm:"pk autoincr"`
+	AdvisoryID       int64 `xorm:"INDEX NOT NULL"`
+	RepoID           int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageVersionID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// ManifestPath is the path of the lockfile pinning the dependency
+	ManifestPath string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Ecosystem    string             `xorm:"VARCHAR(50) NOT NULL"`
+	PackageName  string             `xorm:"VARCHAR(255) NOT NULL"`
+	Version      string             `xorm:"VARCHAR(255) NOT NULL"`
+	FixedVersion string             `xorm:"VARCHAR(255)"`
+	Severity     osv.SeverityLevel  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Status       AlertStatus        `xorm:"INDEX NOT NULL DEFAULT 0"`
+	DismisserID  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created INDEX"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	FixedUnix    timeutil.TimeStamp
+
+	Advisory *Advisory `xorm:"-"`
+}
+
+// TableName sets the table name of the alerts
+func (*Alert) TableName() string {
+	return "advisory_alert"
+}
+
+type alertKey struct {
+	AdvisoryID   int64
+	ManifestPath string
+	PackageName  string
+	Version      string
+}
+
+func (a *Alert) key() alertKey {
+	return alertKey{a.AdvisoryID, a.ManifestPath, a.PackageName, a.Version}
+}
+
+// LoadAdvisory loads the advisory of the alert
+func (a *Alert) LoadAdvisory(ctx context.Context) (err error) {
+	if a.Advisory == nil {
+		a.Advisory, err = GetAdvisoryByID(ctx, a.AdvisoryID)
+	}
+	return err
+}
+
+// AlertList is a list of alerts
+type AlertList []*Alert
+
+// LoadAdvisories loads the advisories of the alerts
+func (alerts AlertList) LoadAdvisories(ctx context.Context) error {
+	ids := make([]int64, 0, len(alerts))
+	for _, a := range alerts {
+		ids = append(ids, a.AdvisoryID)
+	}
+	advisories, err := GetAdvisoriesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, a := range alerts {
+		a.Advisory = advisories[a.AdvisoryID]
+	}
+	return nil
+}
+
+// FindAlertsOptions are the options to find alerts
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID           int64
+	PackageVersionID int64
+	Severity         optional.Option[osv.SeverityLevel]
+	Status           optional.Option[AlertStatus]
+}
+
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.PackageVersionID != 0 {
+		cond = cond.And(builder.Eq{"package_version_id": opts.PackageVersionID})
+	}
+	if opts.Severity.Has() {
+		cond = cond.And(builder.Eq{"severity": opts.Severity.Value()})
+	}
+	if opts.Status.Has() {
+		cond = cond.And(builder.Eq{"status": opts.Status.Value()})
+	}
+	return cond
+}
+
+func (opts FindAlertsOptions) ToOrders() string {
+	return "severity DESC, id DESC"
+}
+
+// GetAlertByID gets an alert of a repository
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	a := &Alert{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(a)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrAlertNotExist
+	}
+	return a, nil
+}
+
+// CountAlertsBySeverity counts the alerts of a repository with the status per severity
+func CountAlertsBySeverity(ctx context.Context, repoID int64, status AlertStatus) (map[osv.SeverityLevel]int64, error) {
+	var rows []struct {
+		Severity osv.SeverityLevel
+		Count    int64
+	}
+	if err := db.GetEngine(ctx).Table("advisory_alert").
+		Select("severity, COUNT(*) AS count").
+		Where("repo_id = ? AND status = ?", repoID, status).
+		GroupBy("severity").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[osv.SeverityLevel]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Severity] = row.Count
+	}
+	return counts, nil
+}
+
+// SetAlertStatus dismisses or reopens an alert
+func SetAlertStatus(ctx context.Context, a *Alert, status AlertStatus, dismisserID int64) error {
+	a.Status = status
+	a.DismisserID = dismisserID
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("status", "dismisser_id").Update(a)
+	return err
+}
+
+// SyncAlerts replaces the alerts of a repository or of a package version with the found ones.
+// Alerts which are not found anymore are fixed, dismissed alerts stay dismissed.
+// It returns the alerts which were opened or reopened.
+func SyncAlerts(ctx context.Context, repoID, packageVersionID int64, found []*Alert) ([]*Alert, error) {
+	var opened []*Alert
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		opened = nil
+
+		cond := builder.Eq{"package_version_id": packageVersionID}
+		if packageVersionID == 0 {
+			cond["repo_id"] = repoID
+		}
+		existing := make([]*Alert, 0, len(found))
+		if err := db.GetEngine(ctx).Where(cond).Find(&existing); err != nil {
+			return err
+		}
+		existingByKey := make(map[alertKey]*Alert, len(existing))
+		for _, a := range existing {
+			existingByKey[a.key()] = a
+		}
+
+		foundKeys := make(map[alertKey]bool, len(found))
+		for _, a := range found {
+			key := a.key()
+			if foundKeys[key] {
+				continue
+			}
+			foundKeys[key] = true
+
+			e, ok := existingByKey[key]
+			if !ok {
+				a.RepoID = repoID
+				a.PackageVersionID = packageVersionID
+				a.Status = AlertStatusOpen
+				if err := db.Insert(ctx, a); err != nil {
+					return err
+				}
+				opened = append(opened, a)
+				continue
+			}
+
+			e.Advisory = a.Advisory
+			e.RepoID = repoID
+			e.Severity = a.Severity
+			e.FixedVersion = a.FixedVersion
+			cols := []string{"repo_id", "severity", "fixed_version"}
+			if e.Status == AlertStatusFixed {
+				e.Status = AlertStatusOpen
+				e.FixedUnix = 0
+				cols = append(cols, "status", "fixed_unix")
+				opened = append(opened, e)
+			}
+			if _, err := db.GetEngine(ctx).ID(e.ID).Cols(cols...).Update(e); err != nil {
+				return err
+			}
+		}
+
+		for _, e := range existing {
+			if foundKeys[e.key()] || e.Status != AlertStatusOpen {
+				continue
+			}
+			e.Status = AlertStatusFixed
+			e.FixedUnix = timeutil.TimeStampNow()
+			if _, err := db.GetEngine(ctx).ID(e.ID).Cols("status", "fixed_unix").Update(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return opened, err
+}
+
+// DeleteAlertsByRepoID deletes the alerts of a repository
+func DeleteAlertsByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ? AND package_version_id = 0", repoID).Delete(&Alert{})
+	if err != nil {
+		return err
+	}
+	// the package versions outlive the repository they are linked to
+	_, err = db.GetEngine(ctx).Table("advisory_alert").Where("repo_id = ?", repoID).Update(map[string]any{"repo_id": 0})
+	return err
+}
+
+// DeleteAlertsByPackageVersionID deletes the alerts of a package version
+func DeleteAlertsByPackageVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("package_version_id = ?", versionID).Delete(&Alert{})
+	return err
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"hash"
+	"io"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/42wim/sshsig"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrNoSigningKey indicates that no verified key of a user made a detached signature
+var ErrNoSigningKey = util.NewNotExistErrorf("the signature was not made by a verified key")
+
+// readDetachedGPGSignature reads an armored or binary signature packet
+func readDetachedGPGSignature(signature []byte) (*packet.Signature, error) {
+	var r io.Reader = bytes.NewReader(signature)
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		body, err := readArmoredSign(bytes.NewReader(bytes.TrimSpace(signature)))
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("failed to read signature armor: %v", err)
+		}
+		r = body
+	}
+	p, err := packet.Read(r)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("failed to read signature packet: %v", err)
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("packet is not a signature")
+	}
+	return sig, nil
+}
+
+// VerifyDetachedGPGSignature checks a detached GPG signature of the content
+// and returns the verified key of the user which made it
+func VerifyDetachedGPGSignature(ctx context.Context, content io.Reader, signature []byte) (*GPGKey, error) {
+	sig, err := readDetachedGPGSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := tryGetKeyIDFromSignature(sig)
+	if keyID == "" {
+		return nil, ErrNoSigningKey
+	}
+	keys, err := db.Find[GPGKey](ctx, FindGPGKeyOptions{
+		KeyID:          keyID,
+		IncludeSubKeys: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := timeutil.TimeStampNow()
+	candidates := make([]*GPGKey, 0, len(keys))
+	hashes := make([]hash.Hash, 0, len(keys))
+	writers := make([]io.Writer, 0, len(keys))
+	for _, k := range keys {
+		if !k.Verified || !k.CanSign || (k.ExpiredUnix > 0 && k.ExpiredUnix < now) {
+			continue
+		}
+		h := sig.Hash.New()
+		candidates = append(candidates, k)
+		hashes = append(hashes, h)
+		writers = append(writers, h)
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoSigningKey
+	}
+
+	// the content is read once, the hash of every candidate is computed at the same time
+	if _, err := io.Copy(io.MultiWriter(writers...), content); err != nil {
+		return nil, err
+	}
+
+	for i, k := range candidates {
+		pkey, err := base64DecPubKey(k.Content)
+		if err != nil {
+			return nil, err
+		}
+		if err := pkey.VerifySignature(hashes[i], sig); err == nil {
+			return k, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// VerifyDetachedSSHSignature checks a detached SSH signature of the content made
+// for the namespace and returns the verified key of the user which made it
+func VerifyDetachedSSHSignature(ctx context.Context, content io.Reader, signature []byte, namespace string) (*PublicKey, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(signature))
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return nil, util.NewInvalidArgumentErrorf("failed to read signature armor")
+	}
+	var wrapped sshsig.WrappedSig
+	if err := ssh.Unmarshal(block.Bytes, &wrapped); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("failed to read signature: %v", err)
+	}
+	pk, err := ssh.ParsePublicKey([]byte(wrapped.PublicKey))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("failed to read signature key: %v", err)
+	}
+
+	keys, err := db.Find[PublicKey](ctx, FindPublicKeyOptions{
+		Fingerprint: ssh.FingerprintSHA256(pk),
+		KeyTypes:    []KeyType{KeyTypeUser},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if !k.Verified {
+			continue
+		}
+		if err := sshsig.Verify(content, signature, []byte(k.Content), namespace); err != nil {
+			return nil, ErrNoSigningKey
+		}
+		return k, nil
+	}
+	return nil, ErrNoSigningKey
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/keying"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"golang.org/x/crypto/ssh"
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(SSHCertificateAuthority))
+	db.RegisterModel(new(SSHCertificate))
+}
+
+// sshCertificateClockSkew is how long before their issuance certificates are valid,
+// so they are accepted by servers whose clocks are slightly behind
+const sshCertificateClockSkew = time.Minute
+
+// SSHCertificateAuthority is a key pair which the SSH certificates of users are signed with.
+// Only the active authority signs new certificates. Rotating it retires the active authority,
+// a retired authority stays trusted until the certificates it signed have expired.
+type SSHCertificateAuthority struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Fingerprint string             `xorm:"UNIQUE NOT NULL"`
+	PublicKey   string             `xorm:"TEXT NOT NULL"`
+	PrivateKey  []byte             `xorm:"BLOB"`
+	IsActive    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	RetiredUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// IsTrusted returns whether certificates signed by the authority are accepted
+func (ca *SSHCertificateAuthority) IsTrusted() bool {
+	return ca.IsActive || ca.RetiredUnix.AddDuration(setting.SSH.CertificateValidity) > timeutil.TimeStampNow()
+}
+
+// signer returns the signer of the private key of the authority
+func (ca *SSHCertificateAuthority) signer() (ssh.Signer, error) {
+	key, err := keying.SSHCertificateAuthority.Decrypt(ca.PrivateKey, keying.ColumnAndID("private_key", ca.ID))
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(key)
+}
+
+// RotateSSHCertificateAuthority creates a new active certificate authority and retires the active one, if any
+func RotateSSHCertificateAuthority(ctx context.Context) (*SSHCertificateAuthority, error) {
+	publicKey, privateKey, err := util.GenerateSSHKeypair()
+	if err != nil {
+		return nil, err
+	}
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &SSHCertificateAuthority{
+		Fingerprint: ssh.FingerprintSHA256(parsed),
+		PublicKey:   strings.TrimSpace(string(publicKey)),
+		IsActive:    true,
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"is_active": true}).Cols("is_active", "retired_unix").
+			Update(&SSHCertificateAuthority{IsActive: false, RetiredUnix: timeutil.TimeStampNow()}); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, ca); err != nil {
+			return err
+		}
+		// the ID is part of the additional data of the encryption, so the key is stored after the insert
+		ca.PrivateKey = keying.SSHCertificateAuthority.Encrypt(privateKey, keying.ColumnAndID("private_key", ca.ID))
+		_, err := db.GetEngine(ctx).ID(ca.ID).Cols("private_key").Update(ca)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// GetActiveSSHCertificateAuthority returns the certificate authority which signs new certificates,
+// it returns nil if none has been created yet
+func GetActiveSSHCertificateAuthority(ctx context.Context) (*SSHCertificateAuthority, error) {
+	ca, has, err := db.Get[SSHCertificateAuthority](ctx, builder.Eq{"is_active": true})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return ca, nil
+}
+
+// GetSSHCertificateAuthorities returns all certificate authorities, the newest first
+func GetSSHCertificateAuthorities(ctx context.Context) ([]*SSHCertificateAuthority, error) {
+	cas := make([]*SSHCertificateAuthority, 0, 2)
+	return cas, db.GetEngine(ctx).Desc("id").Find(&cas)
+}
+
+// GetTrustedSSHCertificateAuthorities returns the certificate authorities whose certificates are accepted
+func GetTrustedSSHCertificateAuthorities(ctx context.Context) ([]*SSHCertificateAuthority, error) {
+	cas, err := GetSSHCertificateAuthorities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trusted := make([]*SSHCertificateAuthority, 0, len(cas))
+	for _, ca := range cas {
+		if ca.IsTrusted() {
+			trusted = append(trusted, ca)
+		}
+	}
+	return trusted, nil
+}
+
+// GetSSHCertificateAuthorityByID returns a certificate authority by its ID
+func GetSSHCertificateAuthorityByID(ctx context.Context, id int64) (*SSHCertificateAuthority, error) {
+	ca, has, err := db.GetByID[SSHCertificateAuthority](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("SSH certificate authority does not exist [id: %d]", id)
+	}
+	return ca, nil
+}
+
+// DeleteSSHCertificateAuthority deletes a retired certificate authority, the certificates it signed are no longer accepted
+func DeleteSSHCertificateAuthority(ctx context.Context, id int64) error {
+	ca, err := GetSSHCertificateAuthorityByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ca.IsActive {
+		return util.NewInvalidArgumentErrorf("the active SSH certificate authority cannot be deleted")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"authority_id": ca.ID}).Delete(new(SSHCertificate)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[SSHCertificateAuthority](ctx, ca.ID)
+		return err
+	})
+}
+
+// SSHCertificate is a short-lived SSH certificate which was issued to a user.
+// The ID is the serial of the certificate, the certificate itself is not stored.
+type SSHCertificate struct {
+	ID              int64              `xorm:"pk autoincr"`
+	OwnerID         int64              `xorm:"INDEX NOT NULL"`
+	AuthorityID     int64              `xorm:"INDEX NOT NULL"`
+	Fingerprint     string             `xorm:"NOT NULL"`
+	Principal       string             `xorm:"NOT NULL"`
+	ValidAfterUnix  timeutil.TimeStamp `xorm:"NOT NULL"`
+	ValidBeforeUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	RevokedUnix     timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"created"`
+}
+
+// KeyID returns the key ID of the certificate, which SSH servers log when it is used
+func (c *SSHCertificate) KeyID() string {
+	return fmt.Sprintf("%s-%d", c.Principal, c.ID)
+}
+
+// IsValid returns whether the certificate has neither expired nor been revoked
+func (c *SSHCertificate) IsValid() bool {
+	now := timeutil.TimeStampNow()
+	return c.RevokedUnix == 0 && c.ValidAfterUnix <= now && now < c.ValidBeforeUnix
+}
+
+// IssueSSHCertificate signs a certificate for a public key of a user with the active certificate authority,
+// which is created on first use. The principal of the certificate is the name of the user.
+// It returns the certificate in the authorized keys format.
+func IssueSSHCertificate(ctx context.Context, owner *user_model.User, content string) (*SSHCertificate, string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, "", util.NewInvalidArgumentErrorf("invalid SSH public key: %v", err)
+	}
+	if _, ok := key.(*ssh.Certificate); ok {
+		return nil, "", util.NewInvalidArgumentErrorf("a certificate cannot be certified")
+	}
+
+	ca, err := GetActiveSSHCertificateAuthority(ctx)
+	if err != nil {
+		return nil, "", err
+	} else if ca == nil {
+		if ca, err = RotateSSHCertificateAuthority(ctx); err != nil {
+			return nil, "", err
+		}
+	}
+	signer, err := ca.signer()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	c := &SSHCertificate{
+		OwnerID:         owner.ID,
+		AuthorityID:     ca.ID,
+		Fingerprint:     ssh.FingerprintSHA256(key),
+		Principal:       owner.Name,
+		ValidAfterUnix:  timeutil.TimeStamp(now.Add(-sshCertificateClockSkew).Unix()),
+		ValidBeforeUnix: timeutil.TimeStamp(now.Add(setting.SSH.CertificateValidity).Unix()),
+	}
+	var authorized string
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, c); err != nil {
+			return err
+		}
+		cert := &ssh.Certificate{
+			Key:             key,
+			Serial:          uint64(c.ID),
+			CertType:        ssh.UserCert,
+			KeyId:           c.KeyID(),
+			ValidPrincipals: []string{c.Principal},
+			ValidAfter:      uint64(c.ValidAfterUnix),
+			ValidBefore:     uint64(c.ValidBeforeUnix),
+		}
+		if err := cert.SignCert(rand.Reader, signer); err != nil {
+			return err
+		}
+		authorized = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert)))
+		return nil
+	}); err != nil {
+		return nil, "", err
+	}
+	return c, authorized, nil
+}
+
+// GetSSHCertificateByID returns an issued certificate by its serial
+func GetSSHCertificateByID(ctx context.Context, id int64) (*SSHCertificate, error) {
+	c, has, err := db.GetByID[SSHCertificate](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("SSH certificate does not exist [id: %d]", id)
+	}
+	return c, nil
+}
+
+// GetValidSSHCertificateByID returns an issued certificate by its serial if it has neither expired nor been revoked
+func GetValidSSHCertificateByID(ctx context.Context, id int64) (*SSHCertificate, error) {
+	c, err := GetSSHCertificateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsValid() {
+		return nil, util.NewPermissionDeniedErrorf("SSH certificate has expired or been revoked [id: %d]", id)
+	}
+	return c, nil
+}
+
+// FindValidSSHCertificates returns the certificates which have neither expired nor been revoked, the newest first.
+// If ownerID is 0, the certificates of all users are returned.
+func FindValidSSHCertificates(ctx context.Context, ownerID int64) ([]*SSHCertificate, error) {
+	cond := builder.Eq{"revoked_unix": 0}.And(builder.Gt{"valid_before_unix": timeutil.TimeStampNow()})
+	if ownerID != 0 {
+		cond = cond.And(builder.Eq{"owner_id": ownerID})
+	}
+	certs := make([]*SSHCertificate, 0, 5)
+	return certs, db.GetEngine(ctx).Where(cond).Desc("id").Find(&certs)
+}
+
+// GetRevokedSSHCertificates returns the revoked certificates which have not expired yet, ordered by their serials
+func GetRevokedSSHCertificates(ctx context.Context) ([]*SSHCertificate, error) {
+	certs := make([]*SSHCertificate, 0, 5)
+	return certs, db.GetEngine(ctx).
+		Where(builder.Gt{"revoked_unix": 0}.And(builder.Gt{"valid_before_unix": timeutil.TimeStampNow()})).
+		Asc("id").
+		Find(&certs)
+}
+
+// RevokeSSHCertificate revokes an issued certificate, so it is no longer accepted
+func RevokeSSHCertificate(ctx context.Context, c *SSHCertificate) error {
+	c.RevokedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("revoked_unix").Update(c)
+	return err
+}
+
+// VerifySSHCertificate checks a certificate which is presented for authentication and returns its record.
+// It returns nil if the certificate was not signed by a certificate authority of this instance.
+func VerifySSHCertificate(ctx context.Context, cert *ssh.Certificate) (*SSHCertificate, error) {
+	ca, has, err := db.Get[SSHCertificateAuthority](ctx, builder.Eq{"fingerprint": ssh.FingerprintSHA256(cert.SignatureKey)})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	if !ca.IsTrusted() {
+		return nil, util.NewPermissionDeniedErrorf("SSH certificate authority is no longer trusted [fingerprint: %s]", ca.Fingerprint)
+	}
+	if cert.CertType != ssh.UserCert {
+		return nil, util.NewInvalidArgumentErrorf("not a user certificate")
+	}
+
+	c, err := GetValidSSHCertificateByID(ctx, int64(cert.Serial))
+	if err != nil {
+		return nil, err
+	}
+	if c.AuthorityID != ca.ID || c.Fingerprint != ssh.FingerprintSHA256(cert.Key) {
+		return nil, util.NewPermissionDeniedErrorf("SSH certificate does not match the issued one [id: %d]", c.ID)
+	}
+
+	// the signature key is the one of the authority, CheckCert verifies the signature, the principal and the validity period
+	if err := new(ssh.CertChecker).CheckCert(c.Principal, cert); err != nil {
+		return nil, util.NewPermissionDeniedErrorf("invalid SSH certificate [id: %d]: %v", c.ID, err)
+	}
+	return c, nil
+}
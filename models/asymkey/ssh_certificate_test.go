@@ -0,0 +1,139 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHCertificate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.SSH.CertificateValidity, time.Hour)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	publicKey, _, err := util.GenerateSSHKeypair()
+	require.NoError(t, err)
+
+	issue := func(t *testing.T) (*SSHCertificate, *ssh.Certificate) {
+		t.Helper()
+		c, authorized, err := IssueSSHCertificate(t.Context(), user, string(publicKey))
+		require.NoError(t, err)
+		parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorized))
+		require.NoError(t, err)
+		cert, ok := parsed.(*ssh.Certificate)
+		require.True(t, ok)
+		return c, cert
+	}
+
+	t.Run("Issue", func(t *testing.T) {
+		c, cert := issue(t)
+		assert.EqualValues(t, c.ID, cert.Serial)
+		assert.Equal(t, ssh.UserCert, cert.CertType)
+		assert.Equal(t, []string{"user2"}, cert.ValidPrincipals)
+		assert.Equal(t, c.KeyID(), cert.KeyId)
+
+		ca, err := GetActiveSSHCertificateAuthority(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, ca)
+		assert.Equal(t, ca.ID, c.AuthorityID)
+		assert.Equal(t, ca.Fingerprint, ssh.FingerprintSHA256(cert.SignatureKey))
+
+		verified, err := VerifySSHCertificate(t.Context(), cert)
+		require.NoError(t, err)
+		require.NotNil(t, verified)
+		assert.Equal(t, c.ID, verified.ID)
+
+		certs, err := FindValidSSHCertificates(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, certs)
+	})
+
+	t.Run("Certificate as input", func(t *testing.T) {
+		_, cert := issue(t)
+		_, _, err := IssueSSHCertificate(t.Context(), user, string(ssh.MarshalAuthorizedKey(cert)))
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Unknown authority", func(t *testing.T) {
+		_, cert := issue(t)
+		_, signerKey, err := util.GenerateSSHKeypair()
+		require.NoError(t, err)
+		signer, err := ssh.ParsePrivateKey(signerKey)
+		require.NoError(t, err)
+		require.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		verified, err := VerifySSHCertificate(t.Context(), cert)
+		require.NoError(t, err)
+		assert.Nil(t, verified)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		c, cert := issue(t)
+		require.NoError(t, RevokeSSHCertificate(t.Context(), c))
+
+		_, err := VerifySSHCertificate(t.Context(), cert)
+		require.ErrorIs(t, err, util.ErrPermissionDenied)
+
+		revoked, err := GetRevokedSSHCertificates(t.Context())
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(revoked))
+		for _, r := range revoked {
+			ids = append(ids, r.ID)
+		}
+		assert.Contains(t, ids, c.ID)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.SSH.CertificateValidity, -time.Hour)()
+		c, cert := issue(t)
+
+		_, err := VerifySSHCertificate(t.Context(), cert)
+		require.ErrorIs(t, err, util.ErrPermissionDenied)
+		_, err = GetValidSSHCertificateByID(t.Context(), c.ID)
+		require.ErrorIs(t, err, util.ErrPermissionDenied)
+	})
+
+	t.Run("Rotate and delete", func(t *testing.T) {
+		c, cert := issue(t)
+		old, err := GetActiveSSHCertificateAuthority(t.Context())
+		require.NoError(t, err)
+
+		ca, err := RotateSSHCertificateAuthority(t.Context())
+		require.NoError(t, err)
+		assert.NotEqual(t, old.ID, ca.ID)
+
+		// certificates of the retired authority stay valid until they expire
+		verified, err := VerifySSHCertificate(t.Context(), cert)
+		require.NoError(t, err)
+		require.NotNil(t, verified)
+
+		trusted, err := GetTrustedSSHCertificateAuthorities(t.Context())
+		require.NoError(t, err)
+		require.Len(t, trusted, 2)
+		assert.Equal(t, ca.ID, trusted[0].ID)
+
+		_, newCert := issue(t)
+		assert.Equal(t, ca.Fingerprint, ssh.FingerprintSHA256(newCert.SignatureKey))
+
+		require.ErrorIs(t, DeleteSSHCertificateAuthority(t.Context(), ca.ID), util.ErrInvalidArgument)
+		require.NoError(t, DeleteSSHCertificateAuthority(t.Context(), old.ID))
+		unittest.AssertNotExistsBean(t, &SSHCertificate{ID: c.ID})
+
+		verified, err = VerifySSHCertificate(t.Context(), cert)
+		require.NoError(t, err)
+		assert.Nil(t, verified)
+	})
+}
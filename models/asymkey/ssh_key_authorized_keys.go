@@ -42,6 +42,7 @@ import (
 const (
 	tplCommentPrefix = `# gitea public key`
 	tplPublicKey     = tplCommentPrefix + "\n" + `command=%s,no-port-forwarding,no-X11-forwarding,no-agent-forwarding,no-pty,no-user-rc,restrict %s` + "\n"
+	tplCertificate   = tplCommentPrefix + "\n" + `cert-authority,principals="%s",command=%s,no-port-forwarding,no-X11-forwarding,no-agent-forwarding,no-pty,no-user-rc,restrict %s` + "\n"
 )
 
 var sshOpLocker sync.Mutex
@@ -60,6 +61,13 @@ func AuthorizedStringForKey(key *PublicKey) string {
 	return fmt.Sprintf(tplPublicKey, util.ShellEscape(sb.String()), key.Content)
 }
 
+// AuthorizedStringForCertificate creates the authorized keys string which lets OpenSSH accept an issued certificate,
+// it trusts the certificate authority for the principal of the certificate and runs serv for it
+func AuthorizedStringForCertificate(c *SSHCertificate, ca *SSHCertificateAuthority) string {
+	command := fmt.Sprintf("%s --config=%s serv cert-%d", util.ShellEscape(setting.AppPath), util.ShellEscape(setting.CustomConf), c.ID)
+	return fmt.Sprintf(tplCertificate, c.Principal, util.ShellEscape(command), ca.PublicKey)
+}
+
 // appendAuthorizedKeysToFile appends new SSH keys' content to authorized_keys file.
 func appendAuthorizedKeysToFile(keys ...*PublicKey) error {
 	// Don't need to rewrite this file if builtin SSH server is enabled.
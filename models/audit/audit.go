@@ -0,0 +1,239 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// Action is the kind of change an event records, like "repo.branch_protection.update".
+// The part before the first dot is the area of the change.
+type Action string
+
+const (
+	ActionRepoCreate                 Action = "repo.create"
+	ActionRepoDelete                 Action = "repo.delete"
+	ActionRepoTransfer               Action = "repo.transfer"
+	ActionRepoVisibility             Action = "repo.visibility"
+	ActionRepoCollaboratorAdd        Action = "repo.collaborator.add"
+	ActionRepoCollaboratorUpdate     Action = "repo.collaborator.update"
+	ActionRepoCollaboratorRemove     Action = "repo.collaborator.remove"
+	ActionRepoBranchProtectionCreate Action = "repo.branch_protection.create"
+	ActionRepoBranchProtectionUpdate Action = "repo.branch_protection.update"
+	ActionRepoBranchProtectionDelete Action = "repo.branch_protection.delete"
+	ActionRepoDeployKeyAdd           Action = "repo.deploy_key.add"
+	ActionRepoDeployKeyRemove        Action = "repo.deploy_key.remove"
+
+	ActionOrgMemberRemove      Action = "org.member.remove"
+	ActionOrgTeamCreate        Action = "org.team.create"
+	ActionOrgTeamUpdate        Action = "org.team.update"
+	ActionOrgTeamDelete        Action = "org.team.delete"
+	ActionOrgTeamMemberAdd     Action = "org.team.member.add"
+	ActionOrgTeamMemberRemove  Action = "org.team.member.remove"
+	ActionOrgTwoFactorRequire  Action = "org.two_factor.require"
+	ActionOrgTwoFactorRemove   Action = "org.two_factor.remove"
+	ActionOrgIPAllowlistUpdate Action = "org.ip_allowlist.update"
+	ActionOrgIPAllowlistRemove Action = "org.ip_allowlist.remove"
+
+	ActionWebhookCreate Action = "webhook.create"
+	ActionWebhookUpdate Action = "webhook.update"
+	ActionWebhookDelete Action = "webhook.delete"
+
+	ActionUserAccessTokenCreate    Action = "user.access_token.create"
+	ActionUserAccessTokenDelete    Action = "user.access_token.delete"
+	ActionUserSSHKeyAdd            Action = "user.ssh_key.add"
+	ActionUserSSHKeyDelete         Action = "user.ssh_key.delete"
+	ActionUserGPGKeyAdd            Action = "user.gpg_key.add"
+	ActionUserGPGKeyDelete         Action = "user.gpg_key.delete"
+	ActionUserSSHCertificateCreate Action = "user.ssh_certificate.create"
+	ActionUserSSHCertificateRevoke Action = "user.ssh_certificate.revoke"
+
+	ActionAdminUserCreate                    Action = "admin.user.create"
+	ActionAdminUserUpdate                    Action = "admin.user.update"
+	ActionAdminUserDelete                    Action = "admin.user.delete"
+	ActionAdminUserSignOut                   Action = "admin.user.sign_out"
+	ActionAdminAuthSourceCreate              Action = "admin.auth_source.create"
+	ActionAdminAuthSourceUpdate              Action = "admin.auth_source.update"
+	ActionAdminAuthSourceDelete              Action = "admin.auth_source.delete"
+	ActionAdminSSHCertificateAuthorityRotate Action = "admin.ssh_certificate_authority.rotate"
+	ActionAdminSSHCertificateAuthorityDelete Action = "admin.ssh_certificate_authority.delete"
+)
+
+// Actions lists all actions in the order they are offered as filters
+var Actions = []Action{
+	ActionRepoCreate, ActionRepoDelete, ActionRepoTransfer, ActionRepoVisibility,
+	ActionRepoCollaboratorAdd, ActionRepoCollaboratorUpdate, ActionRepoCollaboratorRemove,
+	ActionRepoBranchProtectionCreate, ActionRepoBranchProtectionUpdate, ActionRepoBranchProtectionDelete,
+	ActionRepoDeployKeyAdd, ActionRepoDeployKeyRemove,
+	ActionOrgMemberRemove, ActionOrgTeamCreate, ActionOrgTeamUpdate, ActionOrgTeamDelete,
+	ActionOrgTeamMemberAdd, ActionOrgTeamMemberRemove, ActionOrgTwoFactorRequire, ActionOrgTwoFactorRemove,
+	ActionOrgIPAllowlistUpdate, ActionOrgIPAllowlistRemove,
+	ActionWebhookCreate, ActionWebhookUpdate, ActionWebhookDelete,
+	ActionUserAccessTokenCreate, ActionUserAccessTokenDelete,
+	ActionUserSSHKeyAdd, ActionUserSSHKeyDelete, ActionUserGPGKeyAdd, ActionUserGPGKeyDelete,
+	ActionUserSSHCertificateCreate, ActionUserSSHCertificateRevoke,
+	ActionAdminUserCreate, ActionAdminUserUpdate, ActionAdminUserDelete, ActionAdminUserSignOut,
+	ActionAdminAuthSourceCreate, ActionAdminAuthSourceUpdate, ActionAdminAuthSourceDelete,
+	ActionAdminSSHCertificateAuthorityRotate, ActionAdminSSHCertificateAuthorityDelete,
+}
+
+// TargetType is the kind of object an event changed
+type TargetType string
+
+const (
+	TargetRepository              TargetType = "repository"
+	TargetBranchProtection        TargetType = "branch_protection"
+	TargetDeployKey               TargetType = "deploy_key"
+	TargetUser                    TargetType = "user"
+	TargetOrganization            TargetType = "organization"
+	TargetTeam                    TargetType = "team"
+	TargetWebhook                 TargetType = "webhook"
+	TargetAccessToken             TargetType = "access_token"
+	TargetSSHKey                  TargetType = "ssh_key"
+	TargetGPGKey                  TargetType = "gpg_key"
+	TargetAuthSource              TargetType = "auth_source"
+	TargetSSHCertificate          TargetType = "ssh_certificate"
+	TargetSSHCertificateAuthority TargetType = "ssh_certificate_authority"
+)
+
+// Change is the value of an attribute before and after an event
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Event records a security-relevant change and who made it.
+// Names are stored along with the IDs because actors and targets may be deleted later.
+type Event struct {
+	ID        int64  `xorm:"pk autoincr" json:"id"`
+	Action    Action `xorm:"VARCHAR(64) INDEX NOT NULL" json:"action"`
+	ActorID   int64  `xorm:"INDEX NOT NULL DEFAULT 0" json:"actor_id"`
+	ActorName string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''" json:"actor"`
+	IPAddress string `xorm:"VARCHAR(64) NOT NULL DEFAULT ''" json:"ip,omitempty"`
+	// OwnerID is the user or organization the target belongs to, it is 0 for instance-wide targets
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0" json:"owner_id,omitempty"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0" json:"repo_id,omitempty"`
+	TargetType  TargetType         `xorm:"VARCHAR(32) INDEX NOT NULL" json:"target_type"`
+	TargetID    int64              `xorm:"NOT NULL DEFAULT 0" json:"target_id,omitempty"`
+	TargetName  string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''" json:"target"`
+	Changes     map[string]*Change `xorm:"JSON TEXT" json:"changes,omitempty"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX" json:"created"`
+}
+
+// TableName sets the table name of the events
+func (Event) TableName() string {
+	return "audit_event"
+}
+
+// Area returns the part of the action before the first dot
+func (e *Event) Area() string {
+	area, _, _ := strings.Cut(string(e.Action), ".")
+	return area
+}
+
+// Insert stores a new event
+func Insert(ctx context.Context, e *Event) error {
+	return db.Insert(ctx, e)
+}
+
+// FindOptions filters events. Actions match if they are equal to or start with Action followed by a dot.
+type FindOptions struct {
+	db.ListOptions
+	OwnerID    int64
+	RepoID     int64
+	ActorID    int64
+	Action     string
+	TargetType TargetType
+	Since      timeutil.TimeStamp
+	Until      timeutil.TimeStamp
+}
+
+func (opts FindOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.ActorID > 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Or(
+			builder.Eq{"action": opts.Action},
+			builder.Like{"action", opts.Action + ".%"},
+		))
+	}
+	if opts.TargetType != "" {
+		cond = cond.And(builder.Eq{"target_type": opts.TargetType})
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Until > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Until})
+	}
+	return cond
+}
+
+func (opts FindOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// Diff returns the attributes which differ between the JSON representations of before and after.
+// Either of them may be nil if the target was created or deleted.
+func Diff(before, after any) (map[string]*Change, error) {
+	beforeMap, err := toMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := toMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]*Change)
+	for key, value := range beforeMap {
+		if !reflect.DeepEqual(value, afterMap[key]) {
+			changes[key] = &Change{Before: value, After: afterMap[key]}
+		}
+	}
+	for key, value := range afterMap {
+		if _, ok := beforeMap[key]; !ok && value != nil {
+			changes[key] = &Change{After: value}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes, nil
+}
+
+func toMap(v any) (map[string]any, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
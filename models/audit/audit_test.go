@@ -0,0 +1,79 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	type state struct {
+		Name    string   `json:"name"`
+		IsAdmin bool     `json:"is_admin"`
+		Scopes  []string `json:"scopes,omitempty"`
+	}
+
+	changes, err := Diff(&state{Name: "user2"}, &state{Name: "user2", IsAdmin: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Change{"is_admin": {Before: false, After: true}}, changes)
+
+	changes, err = Diff(nil, &state{Name: "token", Scopes: []string{"read:user"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Change{
+		"name":     {After: "token"},
+		"is_admin": {After: false},
+		"scopes":   {After: []any{"read:user"}},
+	}, changes)
+
+	changes, err = Diff((*state)(nil), nil)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	changes, err = Diff(&state{Name: "same"}, &state{Name: "same"})
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	events := []*Event{
+		{Action: ActionRepoBranchProtectionCreate, ActorID: 2, ActorName: "user2", OwnerID: 2, RepoID: 1, TargetType: TargetBranchProtection, TargetName: "user2/repo1:main"},
+		{Action: ActionRepoDelete, ActorID: 2, ActorName: "user2", OwnerID: 2, RepoID: 2, TargetType: TargetRepository, TargetName: "user2/repo2"},
+		{Action: ActionOrgTeamMemberAdd, ActorID: 1, ActorName: "user1", OwnerID: 3, TargetType: TargetTeam, TargetName: "org3/team1"},
+		{Action: ActionAdminUserUpdate, ActorID: 1, ActorName: "user1", TargetType: TargetUser, TargetName: "user5", Changes: map[string]*Change{"is_admin": {Before: false, After: true}}},
+	}
+	for _, e := range events {
+		require.NoError(t, Insert(db.DefaultContext, e))
+	}
+
+	find := func(opts FindOptions) []string {
+		found, err := db.Find[Event](db.DefaultContext, opts)
+		require.NoError(t, err)
+		names := make([]string, 0, len(found))
+		for _, e := range found {
+			names = append(names, e.TargetName)
+		}
+		return names
+	}
+
+	assert.Len(t, find(FindOptions{}), 4)
+	assert.ElementsMatch(t, []string{"user2/repo1:main", "user2/repo2"}, find(FindOptions{OwnerID: 2}))
+	assert.Equal(t, []string{"user2/repo1:main"}, find(FindOptions{RepoID: 1}))
+	assert.ElementsMatch(t, []string{"org3/team1", "user5"}, find(FindOptions{ActorID: 1}))
+	assert.ElementsMatch(t, []string{"user2/repo1:main", "user2/repo2"}, find(FindOptions{Action: "repo"}))
+	assert.Equal(t, []string{"user2/repo2"}, find(FindOptions{Action: string(ActionRepoDelete)}))
+	assert.Empty(t, find(FindOptions{Action: "rep"}))
+	assert.Equal(t, []string{"org3/team1"}, find(FindOptions{TargetType: TargetTeam}))
+
+	stored := unittest.AssertExistsAndLoadBean(t, &Event{TargetName: "user5"})
+	assert.Equal(t, "admin", stored.Area())
+	assert.Equal(t, map[string]*Change{"is_admin": {Before: false, After: true}}, stored.Changes)
+}
@@ -9,9 +9,13 @@ import (
 	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"time"
 
 	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
@@ -69,6 +73,20 @@ type AccessToken struct {
 	TokenLastEight string `xorm:"INDEX token_last_eight"`
 	Scope          AccessTokenScope
 
+	// Permissions are only set for fine-grained tokens. They are restricted to the repositories
+	// of the organization ResourceOwnerID or, if it is not set, to the repositories in RepoIDs.
+	Permissions     *AccessTokenPermissions `xorm:"JSON TEXT"`
+	ResourceOwnerID int64                   `xorm:"NOT NULL DEFAULT 0"`
+	RepoIDs         []int64                 `xorm:"-"`
+	RepoOwnerIDs    []int64                 `xorm:"-"`
+
+	// ExpiresUnix is zero if the token never expires
+	ExpiresUnix        timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ExpiryReminderSent bool               `xorm:"NOT NULL DEFAULT false"`
+
+	// AllowedIPs restricts the token to clients from these networks, separated by commas. Empty allows all clients.
+	AllowedIPs string `xorm:"TEXT"`
+
 	CreatedUnix       timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix       timeutil.TimeStamp `xorm:"INDEX updated"`
 	HasRecentActivity bool               `xorm:"-"`
@@ -81,7 +99,55 @@ func (t *AccessToken) AfterLoad() {
 	t.HasRecentActivity = t.UpdatedUnix.AddDuration(7*24*time.Hour) > timeutil.TimeStampNow()
 }
 
+// IsExpired returns true if the token has an expiry date in the past
+func (t *AccessToken) IsExpired() bool {
+	return t.ExpiresUnix != 0 && t.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// AllowsRemoteHost returns true if a client with the given address may use the token
+func (t *AccessToken) AllowsRemoteHost(host string) bool {
+	if t.AllowedIPs == "" {
+		return true
+	}
+	hl, err := hostmatcher.ParseIPNetList("", t.AllowedIPs)
+	if err != nil {
+		// the list is validated before it is stored
+		log.Error("Invalid IP allowlist of access token %d: %v", t.ID, err)
+		return false
+	}
+	return hl.MatchHostName(host)
+}
+
+// IsFineGrained returns true if the token is restricted to a set of repositories with per-permission levels
+func (t *AccessToken) IsFineGrained() bool {
+	return t.Permissions != nil
+}
+
+// AllowsRepository returns true if the token can be used for the repository
+func (t *AccessToken) AllowsRepository(repoID, ownerID int64) bool {
+	if !t.IsFineGrained() {
+		return true
+	}
+	if t.ResourceOwnerID != 0 {
+		return t.ResourceOwnerID == ownerID
+	}
+	return slices.Contains(t.RepoIDs, repoID)
+}
+
+// PackageAccessMode returns the highest access mode the token allows for the packages of the owner.
+// Fine-grained tokens can access the packages of their organization or of the owners of their repositories.
+func (t *AccessToken) PackageAccessMode(ownerID int64) perm.AccessMode {
+	if !t.IsFineGrained() {
+		return perm.AccessModeOwner
+	}
+	if t.ResourceOwnerID == ownerID || (t.ResourceOwnerID == 0 && slices.Contains(t.RepoOwnerIDs, ownerID)) {
+		return t.Permissions.Packages
+	}
+	return perm.AccessModeNone
+}
+
 func init() {
+	db.RegisterModel(new(AccessTokenRepository))
 	db.RegisterModel(new(AccessToken), func() error {
 		if setting.SuccessfulTokensCacheSize > 0 {
 			var err error
@@ -222,15 +288,18 @@ func (opts ListAccessTokensOptions) ToOrders() string {
 
 // DeleteAccessTokenByID deletes access token by given ID.
 func DeleteAccessTokenByID(ctx context.Context, id, userID int64) error {
-	cnt, err := db.GetEngine(ctx).ID(id).Delete(&AccessToken{
-		UID: userID,
-	})
-	if err != nil {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		cnt, err := db.GetEngine(ctx).ID(id).Delete(&AccessToken{
+			UID: userID,
+		})
+		if err != nil {
+			return err
+		} else if cnt != 1 {
+			return ErrAccessTokenNotExist{}
+		}
+		_, err = db.GetEngine(ctx).Where("token_id = ?", id).Delete(&AccessTokenRepository{})
 		return err
-	} else if cnt != 1 {
-		return ErrAccessTokenNotExist{}
-	}
-	return nil
+	})
 }
 
 // RegenerateAccessTokenByID regenerates access token by given ID.
@@ -0,0 +1,154 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// AccessTokenPermissions are the per-permission levels of a fine-grained access token
+type AccessTokenPermissions struct {
+	Contents perm.AccessMode `json:"contents"`
+	Issues   perm.AccessMode `json:"issues"`
+	Packages perm.AccessMode `json:"packages"`
+	Actions  perm.AccessMode `json:"actions"`
+}
+
+// IsEmpty returns true if no permission is granted
+func (p *AccessTokenPermissions) IsEmpty() bool {
+	return max(p.Contents, p.Issues, p.Packages, p.Actions) == perm.AccessModeNone
+}
+
+// UnitAccessMode returns the access mode granted to a repository unit
+func (p *AccessTokenPermissions) UnitAccessMode(unitType unit.Type) perm.AccessMode {
+	switch unitType {
+	case unit.TypeCode, unit.TypeReleases, unit.TypeWiki:
+		return p.Contents
+	case unit.TypeIssues, unit.TypePullRequests:
+		return p.Issues
+	case unit.TypePackages:
+		return p.Packages
+	case unit.TypeActions:
+		return p.Actions
+	default:
+		return perm.AccessModeNone
+	}
+}
+
+// Scope returns the scope covering the API routes of the permissions.
+// The metadata of the repositories can always be read.
+func (p *AccessTokenPermissions) Scope() (AccessTokenScope, error) {
+	scopes := []string{string(AccessTokenScopeReadRepository)}
+	if max(p.Contents, p.Actions) >= perm.AccessModeWrite {
+		scopes = append(scopes, string(AccessTokenScopeWriteRepository))
+	}
+	switch {
+	case p.Issues >= perm.AccessModeWrite:
+		scopes = append(scopes, string(AccessTokenScopeWriteIssue))
+	case p.Issues >= perm.AccessModeRead:
+		scopes = append(scopes, string(AccessTokenScopeReadIssue))
+	}
+	switch {
+	case p.Packages >= perm.AccessModeWrite:
+		scopes = append(scopes, string(AccessTokenScopeWritePackage))
+	case p.Packages >= perm.AccessModeRead:
+		scopes = append(scopes, string(AccessTokenScopeReadPackage))
+	}
+	return AccessTokenScope(strings.Join(scopes, ",")).Normalize()
+}
+
+// AccessTokenRepository is a repository a fine-grained access token is restricted to
+type AccessTokenRepository struct {
+	ID      int64 `xorm:"pk autoincr"`
+	TokenID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	RepoID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+// SetAccessTokenRepositories sets the repositories a fine-grained access token is restricted to
+func SetAccessTokenRepositories(ctx context.Context, tokenID int64, repoIDs []int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("token_id = ?", tokenID).Delete(&AccessTokenRepository{}); err != nil {
+			return err
+		}
+		for _, repoID := range repoIDs {
+			if err := db.Insert(ctx, &AccessTokenRepository{TokenID: tokenID, RepoID: repoID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LoadRepositories loads the repositories and their owners a fine-grained access token is restricted to
+func (t *AccessToken) LoadRepositories(ctx context.Context) error {
+	if !t.IsFineGrained() || t.ResourceOwnerID != 0 || t.RepoIDs != nil {
+		return nil
+	}
+
+	type repoOwner struct {
+		ID      int64
+		OwnerID int64
+	}
+	var rows []repoOwner
+	if err := db.GetEngine(ctx).Table("access_token_repository").
+		Join("INNER", "repository", "repository.id = access_token_repository.repo_id").
+		Where("access_token_repository.token_id = ?", t.ID).
+		Select("repository.id, repository.owner_id").
+		Find(&rows); err != nil {
+		return err
+	}
+
+	t.RepoIDs = make([]int64, 0, len(rows))
+	t.RepoOwnerIDs = make([]int64, 0, len(rows))
+	for _, r := range rows {
+		t.RepoIDs = append(t.RepoIDs, r.ID)
+		t.RepoOwnerIDs = append(t.RepoOwnerIDs, r.OwnerID)
+	}
+	return nil
+}
+
+// DeleteAccessTokenRepositoriesByUID removes the repositories of all access tokens of a user
+func DeleteAccessTokenRepositoriesByUID(ctx context.Context, uid int64) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.In("token_id", builder.Select("id").From("access_token").Where(builder.Eq{"uid": uid}))).
+		Delete(&AccessTokenRepository{})
+	return err
+}
+
+// GetAccessTokenByID returns the access token by id
+func GetAccessTokenByID(ctx context.Context, id int64) (*AccessToken, error) {
+	t := &AccessToken{}
+	has, err := db.GetEngine(ctx).ID(id).Get(t)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAccessTokenNotExist{}
+	}
+	return t, nil
+}
+
+// FindAccessTokensToRemind returns the tokens expiring before the deadline whose owners have not been reminded yet
+func FindAccessTokensToRemind(ctx context.Context, deadline timeutil.TimeStamp) ([]*AccessToken, error) {
+	tokens := make([]*AccessToken, 0, 10)
+	return tokens, db.GetEngine(ctx).
+		Where(builder.Gt{"expires_unix": timeutil.TimeStampNow()}.
+			And(builder.Lte{"expires_unix": deadline}).
+			And(builder.Eq{"expiry_reminder_sent": false})).
+		OrderBy("expires_unix").
+		Find(&tokens)
+}
+
+// SetAccessTokenReminderSent marks that the owner of the token has been reminded of the expiry
+func SetAccessTokenReminderSent(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("expiry_reminder_sent").NoAutoTime().Update(&AccessToken{ExpiryReminderSent: true})
+	return err
+}
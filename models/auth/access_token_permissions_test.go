@@ -0,0 +1,111 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokenPermissions(t *testing.T) {
+	p := &auth_model.AccessTokenPermissions{}
+	assert.True(t, p.IsEmpty())
+
+	p = &auth_model.AccessTokenPermissions{
+		Contents: perm.AccessModeRead,
+		Issues:   perm.AccessModeWrite,
+		Packages: perm.AccessModeRead,
+	}
+	assert.False(t, p.IsEmpty())
+	assert.Equal(t, perm.AccessModeRead, p.UnitAccessMode(unit.TypeCode))
+	assert.Equal(t, perm.AccessModeRead, p.UnitAccessMode(unit.TypeReleases))
+	assert.Equal(t, perm.AccessModeWrite, p.UnitAccessMode(unit.TypePullRequests))
+	assert.Equal(t, perm.AccessModeNone, p.UnitAccessMode(unit.TypeActions))
+	assert.Equal(t, perm.AccessModeNone, p.UnitAccessMode(unit.TypeProjects))
+
+	scope, err := p.Scope()
+	require.NoError(t, err)
+	assert.Equal(t, auth_model.AccessTokenScope("read:package,write:issue,read:repository"), scope)
+
+	p = &auth_model.AccessTokenPermissions{Actions: perm.AccessModeWrite}
+	scope, err = p.Scope()
+	require.NoError(t, err)
+	assert.Equal(t, auth_model.AccessTokenScope("write:repository"), scope)
+}
+
+func TestAccessTokenRestrictions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	classic := &auth_model.AccessToken{}
+	assert.False(t, classic.IsFineGrained())
+	assert.Equal(t, perm.AccessModeOwner, classic.PackageAccessMode(2))
+
+	token := &auth_model.AccessToken{
+		UID:         2,
+		Name:        "fine-grained",
+		Permissions: &auth_model.AccessTokenPermissions{Packages: perm.AccessModeWrite},
+		ExpiresUnix: timeutil.TimeStampNow().Add(3600),
+	}
+	require.NoError(t, auth_model.NewAccessToken(db.DefaultContext, token))
+	require.NoError(t, auth_model.SetAccessTokenRepositories(db.DefaultContext, token.ID, []int64{1}))
+
+	token, err := auth_model.GetAccessTokenByID(db.DefaultContext, token.ID)
+	require.NoError(t, err)
+	assert.True(t, token.IsFineGrained())
+	assert.False(t, token.IsExpired())
+	require.NoError(t, token.LoadRepositories(db.DefaultContext))
+	assert.Equal(t, []int64{1}, token.RepoIDs)
+	assert.Equal(t, []int64{2}, token.RepoOwnerIDs)
+
+	assert.True(t, token.AllowsRepository(1, 2))
+	assert.False(t, token.AllowsRepository(2, 2))
+	assert.Equal(t, perm.AccessModeWrite, token.PackageAccessMode(2))
+	assert.Equal(t, perm.AccessModeNone, token.PackageAccessMode(3))
+
+	org := &auth_model.AccessToken{
+		Permissions:     &auth_model.AccessTokenPermissions{Packages: perm.AccessModeRead},
+		ResourceOwnerID: 3,
+	}
+	assert.True(t, org.AllowsRepository(3, 3))
+	assert.False(t, org.AllowsRepository(1, 2))
+	assert.Equal(t, perm.AccessModeRead, org.PackageAccessMode(3))
+
+	require.NoError(t, auth_model.DeleteAccessTokenByID(db.DefaultContext, token.ID, 2))
+	unittest.AssertNotExistsBean(t, &auth_model.AccessTokenRepository{TokenID: token.ID})
+}
+
+func TestFindAccessTokensToRemind(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	expired := &auth_model.AccessToken{UID: 2, Name: "expired", ExpiresUnix: timeutil.TimeStampNow().Add(-3600)}
+	soon := &auth_model.AccessToken{UID: 2, Name: "soon", ExpiresUnix: timeutil.TimeStampNow().Add(3600)}
+	later := &auth_model.AccessToken{UID: 2, Name: "later", ExpiresUnix: timeutil.TimeStampNow().Add(30 * 24 * 3600)}
+	for _, token := range []*auth_model.AccessToken{expired, soon, later} {
+		require.NoError(t, auth_model.NewAccessToken(db.DefaultContext, token))
+	}
+	assert.True(t, expired.IsExpired())
+
+	deadline := timeutil.TimeStamp(time.Now().Add(7 * 24 * time.Hour).Unix())
+
+	tokens, err := auth_model.FindAccessTokensToRemind(db.DefaultContext, deadline)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, soon.ID, tokens[0].ID)
+
+	require.NoError(t, auth_model.SetAccessTokenReminderSent(db.DefaultContext, soon.ID))
+
+	tokens, err = auth_model.FindAccessTokensToRemind(db.DefaultContext, deadline)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
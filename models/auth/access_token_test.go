@@ -161,3 +161,15 @@ func TestRegenerateAccessTokenByID(t *testing.T) {
 	assert.Equal(t, token.Name, newToken.Name)
 	assert.Equal(t, token.Scope, newToken.Scope)
 }
+
+func TestAccessTokenAllowsRemoteHost(t *testing.T) {
+	token := &auth_model.AccessToken{}
+	assert.True(t, token.AllowsRemoteHost("192.0.2.1:1234"))
+	assert.True(t, token.AllowsRemoteHost(""))
+
+	token.AllowedIPs = "192.0.2.0/24, 2001:db8::1/128"
+	assert.True(t, token.AllowsRemoteHost("192.0.2.1:1234"))
+	assert.True(t, token.AllowsRemoteHost("2001:db8::1"))
+	assert.False(t, token.AllowsRemoteHost("198.51.100.1:1234"))
+	assert.False(t, token.AllowsRemoteHost(""))
+}
@@ -37,10 +37,12 @@ type OAuth2Application struct {
 	// https://datatracker.ietf.org/doc/html/rfc6749#section-2.1
 	// "Authorization servers MUST record the client type in the client registration details"
 	// https://datatracker.ietf.org/doc/html/rfc8252#section-8.4
-	ConfidentialClient bool               `xorm:"NOT NULL DEFAULT TRUE"`
-	RedirectURIs       []string           `xorm:"redirect_uris JSON TEXT"`
-	CreatedUnix        timeutil.TimeStamp `xorm:"INDEX created"`
-	UpdatedUnix        timeutil.TimeStamp `xorm:"INDEX updated"`
+	ConfidentialClient bool `xorm:"NOT NULL DEFAULT TRUE"`
+	// EnableDeviceFlow allows the device authorization grant (RFC 8628) for clients without a browser
+	EnableDeviceFlow bool               `xorm:"NOT NULL DEFAULT FALSE"`
+	RedirectURIs     []string           `xorm:"redirect_uris JSON TEXT"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"INDEX updated"`
 }
 
 func init() {
@@ -257,6 +259,7 @@ type CreateOAuth2ApplicationOptions struct {
 	Name               string
 	UserID             int64
 	ConfidentialClient bool
+	EnableDeviceFlow   bool
 	RedirectURIs       []string
 }
 
@@ -269,6 +272,7 @@ func CreateOAuth2Application(ctx context.Context, opts CreateOAuth2ApplicationOp
 		ClientID:           clientID,
 		RedirectURIs:       opts.RedirectURIs,
 		ConfidentialClient: opts.ConfidentialClient,
+		EnableDeviceFlow:   opts.EnableDeviceFlow,
 	}
 	if err := db.Insert(ctx, app); err != nil {
 		return nil, err
@@ -282,6 +286,7 @@ type UpdateOAuth2ApplicationOptions struct {
 	Name               string
 	UserID             int64
 	ConfidentialClient bool
+	EnableDeviceFlow   bool
 	RedirectURIs       []string
 }
 
@@ -308,6 +313,7 @@ func UpdateOAuth2Application(ctx context.Context, opts UpdateOAuth2ApplicationOp
 	app.Name = opts.Name
 	app.RedirectURIs = opts.RedirectURIs
 	app.ConfidentialClient = opts.ConfidentialClient
+	app.EnableDeviceFlow = opts.EnableDeviceFlow
 
 	if err = updateOAuth2Application(ctx, app); err != nil {
 		return nil, err
@@ -318,7 +324,7 @@ func UpdateOAuth2Application(ctx context.Context, opts UpdateOAuth2ApplicationOp
 }
 
 func updateOAuth2Application(ctx context.Context, app *OAuth2Application) error {
-	if _, err := db.GetEngine(ctx).ID(app.ID).UseBool("confidential_client").Update(app); err != nil {
+	if _, err := db.GetEngine(ctx).ID(app.ID).UseBool("confidential_client", "enable_device_flow").Update(app); err != nil {
 		return err
 	}
 	return nil
@@ -350,6 +356,10 @@ func deleteOAuth2Application(ctx context.Context, id, userid int64) error {
 	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2Grant)); err != nil {
 		return err
 	}
+
+	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2DeviceCode)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,204 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(OAuth2DeviceCode))
+}
+
+// OAuth2DeviceCodeStatus is the state of a device authorization request
+type OAuth2DeviceCodeStatus int
+
+const (
+	// OAuth2DeviceCodePending waits for the user to approve or deny the request
+	OAuth2DeviceCodePending OAuth2DeviceCodeStatus = iota
+	// OAuth2DeviceCodeApproved has been approved, the device can obtain an access token
+	OAuth2DeviceCodeApproved
+	// OAuth2DeviceCodeDenied has been denied by the user
+	OAuth2DeviceCodeDenied
+)
+
+// userCodeChars are the characters of user codes, without vowels to avoid words
+// and without characters which are easily confused, as recommended by RFC 8628
+const userCodeChars = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength is the number of characters of a user code without the separator
+const userCodeLength = 8
+
+// OAuth2DeviceCode is a pending authorization of a device which cannot open a browser itself (RFC 8628).
+// The device polls with the device code, which is only stored hashed, while the user enters the user code in a browser.
+type OAuth2DeviceCode struct {
+	ID              int64                  `xorm:"pk autoincr"`
+	ApplicationID   int64                  `xorm:"INDEX NOT NULL"`
+	DeviceCodeHash  string                 `xorm:"UNIQUE NOT NULL"`
+	UserCode        string                 `xorm:"UNIQUE NOT NULL"`
+	Scope           string                 `xorm:"TEXT"`
+	Status          OAuth2DeviceCodeStatus `xorm:"NOT NULL DEFAULT 0"`
+	UserID          int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Interval        int64                  `xorm:"NOT NULL"`
+	LastPolledUnix  timeutil.TimeStamp     `xorm:"NOT NULL DEFAULT 0"`
+	ExpiresUnix     timeutil.TimeStamp     `xorm:"INDEX NOT NULL"`
+	CreatedUnix     timeutil.TimeStamp     `xorm:"created"`
+	Application     *OAuth2Application     `xorm:"-"`
+	PlainDeviceCode string                 `xorm:"-"`
+}
+
+// TableName sets the table name to `oauth2_device_code`
+func (code *OAuth2DeviceCode) TableName() string {
+	return "oauth2_device_code"
+}
+
+func hashDeviceCode(deviceCode string) string {
+	h := sha256.Sum256([]byte(deviceCode))
+	return hex.EncodeToString(h[:])
+}
+
+func generateUserCode() string {
+	b := util.CryptoRandomBytes(userCodeLength)
+	code := make([]byte, 0, userCodeLength+1)
+	for i := range b {
+		if i == userCodeLength/2 {
+			code = append(code, '-')
+		}
+		code = append(code, userCodeChars[int(b[i])%len(userCodeChars)])
+	}
+	return string(code)
+}
+
+// NormalizeUserCode converts a user code as entered by a user to the form it is stored in,
+// the case and any separators are ignored
+func NormalizeUserCode(userCode string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(userCode) {
+		if strings.ContainsRune(userCodeChars, r) {
+			b.WriteRune(r)
+		}
+	}
+	normalized := b.String()
+	if len(normalized) != userCodeLength {
+		return ""
+	}
+	return normalized[:userCodeLength/2] + "-" + normalized[userCodeLength/2:]
+}
+
+// CreateDeviceCode starts a device authorization request. The plain device code is only available in the returned code.
+func (app *OAuth2Application) CreateDeviceCode(ctx context.Context, scope string) (*OAuth2DeviceCode, error) {
+	// Add a prefix to the base32, this is in order to make it easier
+	// for code scanners to grab sensitive tokens.
+	deviceCode := "gtd_" + base32Lower.EncodeToString(util.CryptoRandomBytes(32))
+
+	code := &OAuth2DeviceCode{
+		ApplicationID:   app.ID,
+		DeviceCodeHash:  hashDeviceCode(deviceCode),
+		UserCode:        generateUserCode(),
+		Scope:           scope,
+		Interval:        setting.OAuth2.DeviceCodePollingInterval,
+		ExpiresUnix:     timeutil.TimeStampNow().Add(setting.OAuth2.DeviceCodeExpirationTime),
+		Application:     app,
+		PlainDeviceCode: deviceCode,
+	}
+	if err := db.Insert(ctx, code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetOAuth2DeviceCodeByUserCode returns the pending request of a user code, it returns nil if none exists or it expired
+func GetOAuth2DeviceCodeByUserCode(ctx context.Context, userCode string) (*OAuth2DeviceCode, error) {
+	userCode = NormalizeUserCode(userCode)
+	if userCode == "" {
+		return nil, nil
+	}
+	code, has, err := db.Get[OAuth2DeviceCode](ctx, builder.Eq{"user_code": userCode, "status": OAuth2DeviceCodePending})
+	if err != nil {
+		return nil, err
+	} else if !has || code.IsExpired() {
+		return nil, nil
+	}
+	if code.Application, err = GetOAuth2ApplicationByID(ctx, code.ApplicationID); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetOAuth2DeviceCodeByDeviceCode returns the request of a device code of an application, it returns nil if none exists
+func GetOAuth2DeviceCodeByDeviceCode(ctx context.Context, applicationID int64, deviceCode string) (*OAuth2DeviceCode, error) {
+	code, has, err := db.Get[OAuth2DeviceCode](ctx, builder.Eq{"device_code_hash": hashDeviceCode(deviceCode), "application_id": applicationID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return code, nil
+}
+
+// IsExpired returns whether the user can no longer approve the request
+func (code *OAuth2DeviceCode) IsExpired() bool {
+	return code.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// Poll records that the device polled for an access token. It returns false if the device polled too fast,
+// in which case the interval is increased by 5 seconds as required by RFC 8628.
+func (code *OAuth2DeviceCode) Poll(ctx context.Context) (bool, error) {
+	now := timeutil.TimeStampNow()
+	tooFast := code.LastPolledUnix > 0 && now < code.LastPolledUnix.Add(code.Interval)
+	if tooFast {
+		code.Interval += 5
+	}
+	code.LastPolledUnix = now
+	if _, err := db.GetEngine(ctx).ID(code.ID).Cols("interval", "last_polled_unix").Update(code); err != nil {
+		return false, err
+	}
+	return !tooFast, nil
+}
+
+// Approve grants the request for a user, the device can obtain an access token with its next poll
+func (code *OAuth2DeviceCode) Approve(ctx context.Context, userID int64) error {
+	return code.setStatus(ctx, OAuth2DeviceCodeApproved, userID)
+}
+
+// Deny rejects the request
+func (code *OAuth2DeviceCode) Deny(ctx context.Context, userID int64) error {
+	return code.setStatus(ctx, OAuth2DeviceCodeDenied, userID)
+}
+
+func (code *OAuth2DeviceCode) setStatus(ctx context.Context, status OAuth2DeviceCodeStatus, userID int64) error {
+	// only pending requests can be decided, so two users cannot both decide the same request
+	updated, err := db.GetEngine(ctx).
+		Where(builder.Eq{"id": code.ID, "status": OAuth2DeviceCodePending}).
+		Cols("status", "user_id").
+		Update(&OAuth2DeviceCode{Status: status, UserID: userID})
+	if err != nil {
+		return err
+	} else if updated == 0 {
+		return util.NewNotExistErrorf("device authorization request is not pending")
+	}
+	code.Status, code.UserID = status, userID
+	return nil
+}
+
+// Invalidate deletes the request, so its device code cannot be used again
+func (code *OAuth2DeviceCode) Invalidate(ctx context.Context) error {
+	_, err := db.GetEngine(ctx).ID(code.ID).NoAutoCondition().Delete(code)
+	return err
+}
+
+// DeleteExpiredOAuth2DeviceCodes deletes the requests which expired before the given time
+func DeleteExpiredOAuth2DeviceCodes(ctx context.Context, olderThan timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Lt{"expires_unix": olderThan}).Delete(new(OAuth2DeviceCode))
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode("BCDF-GHJK"))
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode("bcdfghjk"))
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode(" bcdf ghjk "))
+	assert.Empty(t, auth_model.NormalizeUserCode("BCDF-GHJ"))
+	assert.Empty(t, auth_model.NormalizeUserCode("BCDF-GHJKL"))
+	assert.Empty(t, auth_model.NormalizeUserCode("ABCD-EFGH"))
+}
+
+func TestOAuth2DeviceCode(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 2})
+
+	code, err := app.CreateDeviceCode(db.DefaultContext, "read:user")
+	require.NoError(t, err)
+	assert.NotEmpty(t, code.PlainDeviceCode)
+	assert.Len(t, code.UserCode, 9)
+	assert.Equal(t, code.UserCode, auth_model.NormalizeUserCode(code.UserCode))
+	// the device code is only stored hashed
+	unittest.AssertNotExistsBean(t, &auth_model.OAuth2DeviceCode{DeviceCodeHash: code.PlainDeviceCode})
+
+	t.Run("Lookup", func(t *testing.T) {
+		byUser, err := auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, code.UserCode)
+		require.NoError(t, err)
+		require.NotNil(t, byUser)
+		assert.Equal(t, code.ID, byUser.ID)
+		assert.Equal(t, app.ID, byUser.Application.ID)
+
+		byDevice, err := auth_model.GetOAuth2DeviceCodeByDeviceCode(db.DefaultContext, app.ID, code.PlainDeviceCode)
+		require.NoError(t, err)
+		require.NotNil(t, byDevice)
+		assert.Equal(t, code.ID, byDevice.ID)
+
+		// the device code of one application cannot be used by another one
+		byDevice, err = auth_model.GetOAuth2DeviceCodeByDeviceCode(db.DefaultContext, 1, code.PlainDeviceCode)
+		require.NoError(t, err)
+		assert.Nil(t, byDevice)
+	})
+
+	t.Run("Poll", func(t *testing.T) {
+		ok, err := code.Poll(db.DefaultContext)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		interval := code.Interval
+		ok, err = code.Poll(db.DefaultContext)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, interval+5, code.Interval)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2DeviceCode{ID: code.ID, Interval: interval + 5})
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		require.NoError(t, code.Approve(db.DefaultContext, 2))
+		unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2DeviceCode{ID: code.ID, Status: auth_model.OAuth2DeviceCodeApproved, UserID: 2})
+
+		// a decided request cannot be decided again
+		require.Error(t, code.Deny(db.DefaultContext, 2))
+		byUser, err := auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, code.UserCode)
+		require.NoError(t, err)
+		assert.Nil(t, byUser)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired, err := app.CreateDeviceCode(db.DefaultContext, "")
+		require.NoError(t, err)
+		_, err = db.GetEngine(db.DefaultContext).ID(expired.ID).Cols("expires_unix").Update(&auth_model.OAuth2DeviceCode{ExpiresUnix: timeutil.TimeStampNow() - 1})
+		require.NoError(t, err)
+
+		byUser, err := auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, expired.UserCode)
+		require.NoError(t, err)
+		assert.Nil(t, byUser)
+
+		deleted, err := auth_model.DeleteExpiredOAuth2DeviceCodes(db.DefaultContext, timeutil.TimeStampNow())
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, deleted)
+		unittest.AssertNotExistsBean(t, &auth_model.OAuth2DeviceCode{ID: expired.ID})
+		unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2DeviceCode{ID: code.ID})
+	})
+}
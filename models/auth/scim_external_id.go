@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/util"
+)
+
+// SCIMResourceType is the type of a resource provisioned by SCIM
+type SCIMResourceType int
+
+const (
+	// SCIMResourceUser is a user
+	SCIMResourceUser SCIMResourceType = iota + 1
+	// SCIMResourceGroup is an organization team
+	SCIMResourceGroup
+)
+
+// SCIMExternalID is the identifier an identity provider assigned to a user or team
+type SCIMExternalID struct {
+	ID           int64            `xorm:"pk autoincr"`
+	ResourceType SCIMResourceType `xorm:"UNIQUE(s) UNIQUE(e) NOT NULL"`
+	ResourceID   int64            `xorm:"UNIQUE(s) NOT NULL"`
+	ExternalID   string           `xorm:"UNIQUE(e) NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SCIMExternalID))
+}
+
+// TableName provides the real table name
+func (SCIMExternalID) TableName() string {
+	return "scim_external_id"
+}
+
+// GetSCIMExternalIDs returns the external IDs of all resources of the type by resource ID
+func GetSCIMExternalIDs(ctx context.Context, resourceType SCIMResourceType) (map[int64]string, error) {
+	ids := make([]*SCIMExternalID, 0, 10)
+	if err := db.GetEngine(ctx).Where("resource_type = ?", resourceType).Find(&ids); err != nil {
+		return nil, err
+	}
+	m := make(map[int64]string, len(ids))
+	for _, id := range ids {
+		m[id.ResourceID] = id.ExternalID
+	}
+	return m, nil
+}
+
+// GetSCIMExternalID returns the external ID of a resource or an empty string if it has none
+func GetSCIMExternalID(ctx context.Context, resourceType SCIMResourceType, resourceID int64) (string, error) {
+	id := &SCIMExternalID{}
+	has, err := db.GetEngine(ctx).Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).Get(id)
+	if err != nil || !has {
+		return "", err
+	}
+	return id.ExternalID, nil
+}
+
+// GetSCIMResourceIDByExternalID returns the ID of the resource with the external ID
+func GetSCIMResourceIDByExternalID(ctx context.Context, resourceType SCIMResourceType, externalID string) (int64, error) {
+	id := &SCIMExternalID{}
+	has, err := db.GetEngine(ctx).Where("resource_type = ? AND external_id = ?", resourceType, externalID).Get(id)
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, util.NewNotExistErrorf("no resource with external id %q", externalID)
+	}
+	return id.ResourceID, nil
+}
+
+// SetSCIMExternalID sets or, if externalID is empty, removes the external ID of a resource
+func SetSCIMExternalID(ctx context.Context, resourceType SCIMResourceType, resourceID int64, externalID string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if existing, err := GetSCIMResourceIDByExternalID(ctx, resourceType, externalID); err == nil && existing != resourceID {
+			return util.NewAlreadyExistErrorf("external id %q is already used", externalID)
+		}
+		if err := DeleteSCIMExternalID(ctx, resourceType, resourceID); err != nil {
+			return err
+		}
+		if externalID == "" {
+			return nil
+		}
+		return db.Insert(ctx, &SCIMExternalID{
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			ExternalID:   externalID,
+		})
+	})
+}
+
+// DeleteSCIMExternalID removes the external ID of a resource
+func DeleteSCIMExternalID(ctx context.Context, resourceType SCIMResourceType, resourceID int64) error {
+	_, err := db.GetEngine(ctx).Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).Delete(&SCIMExternalID{})
+	return err
+}
@@ -34,6 +34,7 @@ const (
 	OAuth2      // 6
 	_           // 7 (was SSPI)
 	Remote      // 8
+	SAML        // 9
 )
 
 // String returns the string name of the LoginType
@@ -54,6 +55,7 @@ var Names = map[Type]string{
 	PAM:    "PAM",
 	OAuth2: "OAuth2",
 	Remote: "Remote",
+	SAML:   "SAML",
 }
 
 // Config represents login config as far as the db is concerned
@@ -181,6 +183,11 @@ func (source *Source) IsRemote() bool {
 	return source.Type == Remote
 }
 
+// IsSAML returns true of this source is of the SAML type.
+func (source *Source) IsSAML() bool {
+	return source.Type == SAML
+}
+
 // HasTLS returns true of this source supports TLS.
 func (source *Source) HasTLS() bool {
 	hasTLSer, ok := source.Cfg.(HasTLSer)
@@ -279,6 +286,30 @@ func GetSourceByID(ctx context.Context, id int64) (*Source, error) {
 	return source, nil
 }
 
+// GetSourceByName returns the login source with the given name
+func GetSourceByName(ctx context.Context, name string) (*Source, error) {
+	source := new(Source)
+	has, err := db.GetEngine(ctx).Where("name = ?", name).Get(source)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("login source not found, name: %q", name)
+	}
+	return source, nil
+}
+
+// GetActiveSAMLSourceByName returns the active SAML source with the given name
+func GetActiveSAMLSourceByName(ctx context.Context, name string) (*Source, error) {
+	source := new(Source)
+	has, err := db.GetEngine(ctx).Where("name = ? and type = ? and is_active = ?", name, SAML, true).Get(source)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("saml source not found, name: %q", name)
+	}
+	return source, nil
+}
+
 // UpdateSource updates a Source record in DB.
 func UpdateSource(ctx context.Context, source *Source) error {
 	var originalSource *Source
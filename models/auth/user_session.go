@@ -0,0 +1,125 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(UserSession))
+}
+
+// The methods a user used to sign in to a session
+const (
+	UserSessionAuthPassword     = "password"
+	UserSessionAuthWebAuthn     = "webauthn"
+	UserSessionAuthOAuth2       = "oauth2"
+	UserSessionAuthOpenID       = "openid"
+	UserSessionAuthSAML         = "saml"
+	UserSessionAuthRemember     = "remember"
+	UserSessionAuthReverseProxy = "reverse_proxy"
+)
+
+// UserSession is the metadata of a signed-in browser session of a user.
+// It is independent of the session provider: the session itself only holds the token of its UserSession,
+// a session whose UserSession has been deleted is signed out on its next request.
+type UserSession struct {
+	ID           int64              `xorm:"pk autoincr"`
+	UserID       int64              `xorm:"INDEX NOT NULL"`
+	Token        string             `xorm:"UNIQUE NOT NULL"`
+	AuthMethod   string             `xorm:"NOT NULL DEFAULT ''"`
+	IP           string             `xorm:"NOT NULL DEFAULT ''"`
+	UserAgent    string             `xorm:"TEXT"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	LastSeenUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+// CreateUserSession creates the metadata of a new session of a user with a random token
+func CreateUserSession(ctx context.Context, userID int64, authMethod, ip, userAgent string) (*UserSession, error) {
+	s := &UserSession{
+		UserID:       userID,
+		Token:        hex.EncodeToString(util.CryptoRandomBytes(20)),
+		AuthMethod:   authMethod,
+		IP:           ip,
+		UserAgent:    userAgent,
+		LastSeenUnix: timeutil.TimeStampNow(),
+	}
+	if err := db.Insert(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetUserSessionByToken returns the session of a token, it returns nil if none exists
+func GetUserSessionByToken(ctx context.Context, token string) (*UserSession, error) {
+	if token == "" {
+		return nil, nil
+	}
+	s, has, err := db.Get[UserSession](ctx, builder.Eq{"token": token})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// GetUserSessionsByUserID returns the sessions of a user, the most recently used first
+func GetUserSessionsByUserID(ctx context.Context, userID int64) ([]*UserSession, error) {
+	sessions := make([]*UserSession, 0, 5)
+	return sessions, db.GetEngine(ctx).Where("user_id = ?", userID).Desc("last_seen_unix").Find(&sessions)
+}
+
+// UpdateLastSeen records a request of the session
+func (s *UserSession) UpdateLastSeen(ctx context.Context, ip, userAgent string) error {
+	s.LastSeenUnix = timeutil.TimeStampNow()
+	s.IP = ip
+	s.UserAgent = userAgent
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols("last_seen_unix", "ip", "user_agent").Update(s)
+	return err
+}
+
+// DeleteUserSession revokes a session of a user
+func DeleteUserSession(ctx context.Context, userID, id int64) error {
+	deleted, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "user_id": userID}).Delete(new(UserSession))
+	if err != nil {
+		return err
+	} else if deleted == 0 {
+		return util.NewNotExistErrorf("session does not exist [id: %d]", id)
+	}
+	return nil
+}
+
+// DeleteUserSessionByToken deletes the session of a token, e.g. when it signs out
+func DeleteUserSessionByToken(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"token": token}).Delete(new(UserSession))
+	return err
+}
+
+// DeleteUserSessions revokes all sessions of a user except the one with the given token, which may be empty
+func DeleteUserSessions(ctx context.Context, userID int64, keepToken string) error {
+	cond := builder.Eq{"user_id": userID}
+	if keepToken != "" {
+		_, err := db.GetEngine(ctx).Where(cond.And(builder.Neq{"token": keepToken})).Delete(new(UserSession))
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(new(UserSession))
+	return err
+}
+
+// DeleteInactiveUserSessions deletes the sessions which have not been used since the given time
+func DeleteInactiveUserSessions(ctx context.Context, olderThan timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Lt{"last_seen_unix": olderThan}).Delete(new(UserSession))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserSession(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	first, err := auth_model.CreateUserSession(db.DefaultContext, 2, auth_model.UserSessionAuthPassword, "127.0.0.1", "Firefox")
+	require.NoError(t, err)
+	second, err := auth_model.CreateUserSession(db.DefaultContext, 2, auth_model.UserSessionAuthOAuth2, "127.0.0.2", "Chrome")
+	require.NoError(t, err)
+	other, err := auth_model.CreateUserSession(db.DefaultContext, 4, auth_model.UserSessionAuthPassword, "127.0.0.3", "Safari")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Token, second.Token)
+
+	t.Run("Lookup", func(t *testing.T) {
+		s, err := auth_model.GetUserSessionByToken(db.DefaultContext, first.Token)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		assert.Equal(t, first.ID, s.ID)
+		assert.Equal(t, "Firefox", s.UserAgent)
+
+		s, err = auth_model.GetUserSessionByToken(db.DefaultContext, "")
+		require.NoError(t, err)
+		assert.Nil(t, s)
+
+		sessions, err := auth_model.GetUserSessionsByUserID(db.DefaultContext, 2)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 2)
+	})
+
+	t.Run("UpdateLastSeen", func(t *testing.T) {
+		require.NoError(t, first.UpdateLastSeen(db.DefaultContext, "10.0.0.1", "Firefox 2"))
+		s := unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: first.ID})
+		assert.Equal(t, "10.0.0.1", s.IP)
+		assert.Equal(t, "Firefox 2", s.UserAgent)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// the session of another user cannot be deleted
+		err := auth_model.DeleteUserSession(db.DefaultContext, 2, other.ID)
+		require.Error(t, err)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: other.ID})
+
+		require.NoError(t, auth_model.DeleteUserSessions(db.DefaultContext, 2, first.Token))
+		unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: first.ID})
+		unittest.AssertNotExistsBean(t, &auth_model.UserSession{ID: second.ID})
+
+		require.NoError(t, auth_model.DeleteUserSession(db.DefaultContext, 2, first.ID))
+		unittest.AssertNotExistsBean(t, &auth_model.UserSession{ID: first.ID})
+		unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: other.ID})
+	})
+
+	t.Run("DeleteInactive", func(t *testing.T) {
+		_, err := auth_model.DeleteInactiveUserSessions(db.DefaultContext, other.LastSeenUnix)
+		require.NoError(t, err)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: other.ID})
+
+		_, err = auth_model.DeleteInactiveUserSessions(db.DefaultContext, timeutil.TimeStampNow().Add(1))
+		require.NoError(t, err)
+		unittest.AssertNotExistsBean(t, &auth_model.UserSession{ID: other.ID})
+	})
+}
@@ -55,7 +55,9 @@ type WebAuthnCredential struct {
 	BackupEligible  bool `xorm:"NOT NULL DEFAULT false"`
 	BackupState     bool `xorm:"NOT NULL DEFAULT false"`
 	// If legacy is set to true, backup_eligible and backup_state isn't set.
-	Legacy      bool               `xorm:"NOT NULL DEFAULT true"`
+	Legacy bool `xorm:"NOT NULL DEFAULT true"`
+	// A passkey is a discoverable credential which verifies the user, it can sign in without a password.
+	Passkey     bool               `xorm:"NOT NULL DEFAULT false"`
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 }
@@ -170,8 +172,28 @@ func GetWebAuthnCredentialByCredID(ctx context.Context, userID int64, credID []b
 	return cred, nil
 }
 
+// GetPasskeyByCredID returns the passkey of a user by credential ID
+func GetPasskeyByCredID(ctx context.Context, userID int64, credID []byte) (*WebAuthnCredential, error) {
+	cred := new(WebAuthnCredential)
+	if found, err := db.GetEngine(ctx).Where("user_id = ? AND credential_id = ? AND passkey = ?", userID, credID, true).Get(cred); err != nil {
+		return nil, err
+	} else if !found {
+		return nil, ErrWebAuthnCredentialNotExist{CredentialID: credID}
+	}
+	return cred, nil
+}
+
 // CreateCredential will create a new WebAuthnCredential from the given Credential
 func CreateCredential(ctx context.Context, userID int64, name string, cred *webauthn.Credential) (*WebAuthnCredential, error) {
+	return createCredential(ctx, userID, name, cred, false)
+}
+
+// CreatePasskey will create a new WebAuthnCredential from the given Credential which can be used to sign in without a password
+func CreatePasskey(ctx context.Context, userID int64, name string, cred *webauthn.Credential) (*WebAuthnCredential, error) {
+	return createCredential(ctx, userID, name, cred, true)
+}
+
+func createCredential(ctx context.Context, userID int64, name string, cred *webauthn.Credential, passkey bool) (*WebAuthnCredential, error) {
 	c := &WebAuthnCredential{
 		UserID:          userID,
 		Name:            name,
@@ -184,6 +206,7 @@ func CreateCredential(ctx context.Context, userID int64, name string, cred *weba
 		BackupEligible:  cred.Flags.BackupEligible,
 		BackupState:     cred.Flags.BackupState,
 		Legacy:          false,
+		Passkey:         passkey,
 	}
 
 	if err := db.Insert(ctx, c); err != nil {
@@ -76,3 +76,25 @@ func TestCreateCredential(t *testing.T) {
 
 	unittest.AssertExistsIf(t, true, &auth_model.WebAuthnCredential{Name: "WebAuthn Created Credential", UserID: 1, BackupEligible: true, BackupState: true}, "legacy = false")
 }
+
+func TestCreatePasskey(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	res, err := auth_model.CreatePasskey(db.DefaultContext, 1, "Passkey", &webauthn.Credential{ID: []byte("Passkey")})
+	require.NoError(t, err)
+	assert.True(t, res.Passkey)
+
+	cred, err := auth_model.GetPasskeyByCredID(db.DefaultContext, 1, []byte("Passkey"))
+	require.NoError(t, err)
+	assert.Equal(t, res.ID, cred.ID)
+
+	// security keys registered as second factor are not passkeys
+	_, err = auth_model.CreateCredential(db.DefaultContext, 1, "Security key", &webauthn.Credential{ID: []byte("Security key")})
+	require.NoError(t, err)
+	_, err = auth_model.GetPasskeyByCredID(db.DefaultContext, 1, []byte("Security key"))
+	assert.True(t, auth_model.IsErrWebAuthnCredentialNotExist(err))
+
+	// the passkey of another user is not found
+	_, err = auth_model.GetPasskeyByCredID(db.DefaultContext, 2, []byte("Passkey"))
+	assert.True(t, auth_model.IsErrWebAuthnCredentialNotExist(err))
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add advisory, advisory_package and advisory_alert tables",
+		Upgrade:     addAdvisories,
+	})
+}
+
+type advisory struct {
+	ID            int64    `xorm:"pk autoincr"`
+	Identifier    string   `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	Aliases       []string `xorm:"JSON TEXT"`
+	Summary       string   `xorm:"TEXT"`
+	Severity      int      `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Content       string   `xorm:"LONGTEXT NOT NULL"`
+	PublishedUnix timeutil.TimeStamp
+	ModifiedUnix  timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (advisory) TableName() string {
+	return "advisory"
+}
+
+type advisoryPackage struct {
+	ID         int64  `xorm:"pk autoincr"`
+	AdvisoryID int64  `xorm:"INDEX NOT NULL"`
+	Ecosystem  string `xorm:"VARCHAR(50) INDEX(s) NOT NULL"`
+	Name       string `xorm:"VARCHAR(255) INDEX(s) NOT NULL"`
+}
+
+func (advisoryPackage) TableName() string {
+	return "advisory_package"
+}
+
+type advisoryAlert struct {
+	ID               int64              `xorm:"pk autoincr"`
+	AdvisoryID       int64              `xorm:"INDEX NOT NULL"`
+	RepoID           int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageVersionID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ManifestPath     string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Ecosystem        string             `xorm:"VARCHAR(50) NOT NULL"`
+	PackageName      string             `xorm:"VARCHAR(255) NOT NULL"`
+	Version          string             `xorm:"VARCHAR(255) NOT NULL"`
+	FixedVersion     string             `xorm:"VARCHAR(255)"`
+	Severity         int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Status           int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	DismisserID      int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"updated"`
+	FixedUnix        timeutil.TimeStamp
+}
+
+func (advisoryAlert) TableName() string {
+	return "advisory_alert"
+}
+
+func addAdvisories(x *xorm.Engine) error {
+	return x.Sync(new(advisory), new(advisoryPackage), new(advisoryAlert)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add audit_event table",
+		Upgrade:     addAuditEvent,
+	})
+}
+
+type auditEvent struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Action      string             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	ActorID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ActorName   string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	IPAddress   string             `xorm:"VARCHAR(64) NOT NULL DEFAULT ''"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetType  string             `xorm:"VARCHAR(32) INDEX NOT NULL"`
+	TargetID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	TargetName  string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Changes     map[string]any     `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+func (auditEvent) TableName() string {
+	return "audit_event"
+}
+
+func addAuditEvent(x *xorm.Engine) error {
+	return x.Sync(new(auditEvent)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
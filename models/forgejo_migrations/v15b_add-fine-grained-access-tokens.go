@@ -0,0 +1,45 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add fine-grained permissions and expiry to access tokens",
+		Upgrade:     addFineGrainedAccessTokens,
+	})
+}
+
+type accessTokenWithPermissions struct {
+	Permissions        string             `xorm:"TEXT"`
+	ResourceOwnerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	ExpiresUnix        timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ExpiryReminderSent bool               `xorm:"NOT NULL DEFAULT false"`
+}
+
+func (accessTokenWithPermissions) TableName() string {
+	return "access_token"
+}
+
+type accessTokenRepository struct {
+	ID      int64 `xorm:"pk autoincr"`
+	TokenID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	RepoID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (accessTokenRepository) TableName() string {
+	return "access_token_repository"
+}
+
+func addFineGrainedAccessTokens(x *xorm.Engine) error {
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(accessTokenWithPermissions)); err != nil {
+		return err
+	}
+	return x.Sync(new(accessTokenRepository)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add org_ip_allowlist table and IP allowlists to access tokens",
+		Upgrade:     addIPAllowlists,
+	})
+}
+
+type orgIPAllowlist struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OrgID       int64              `xorm:"UNIQUE NOT NULL"`
+	AllowedIPs  string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (orgIPAllowlist) TableName() string {
+	return "org_ip_allowlist"
+}
+
+type accessTokenWithAllowedIPs struct {
+	AllowedIPs string `xorm:"TEXT"`
+}
+
+func (accessTokenWithAllowedIPs) TableName() string {
+	return "access_token"
+}
+
+func addIPAllowlists(x *xorm.Engine) error {
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(accessTokenWithAllowedIPs)); err != nil {
+		return err
+	}
+	return x.Sync(new(orgIPAllowlist)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
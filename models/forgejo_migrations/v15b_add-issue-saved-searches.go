@@ -0,0 +1,42 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add saved searches of issues and pull requests",
+		Upgrade:     addIssueSavedSearches,
+	})
+}
+
+type issueSavedSearch struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	TeamID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+	IsPinned    bool               `xorm:"NOT NULL DEFAULT false"`
+	Keyword     string             `xorm:"TEXT"`
+	ViewType    string             `xorm:"VARCHAR(30)"`
+	State       string             `xorm:"VARCHAR(10)"`
+	Labels      string             `xorm:"TEXT"`
+	SortType    string             `xorm:"VARCHAR(30)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func (issueSavedSearch) TableName() string {
+	return "issue_saved_search"
+}
+
+func addIssueSavedSearches(x *xorm.Engine) error {
+	return x.Sync(new(issueSavedSearch)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
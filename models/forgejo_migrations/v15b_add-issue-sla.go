@@ -0,0 +1,66 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add SLA policies of issues and pull requests",
+		Upgrade:     addIssueSLA,
+	})
+}
+
+type issueSLAPolicy struct {
+	ID                    int64              `xorm:"pk autoincr"`
+	RepoID                int64              `xorm:"INDEX NOT NULL"`
+	CreatorID             int64              `xorm:"NOT NULL"`
+	Name                  string             `xorm:"NOT NULL"`
+	IssueType             int                `xorm:"NOT NULL DEFAULT 0"`
+	LabelID               int64              `xorm:"NOT NULL DEFAULT 0"`
+	ResponseSeconds       int64              `xorm:"NOT NULL DEFAULT 0"`
+	ResolveSeconds        int64              `xorm:"NOT NULL DEFAULT 0"`
+	BusinessHours         bool               `xorm:"NOT NULL DEFAULT false"`
+	Workdays              int                `xorm:"NOT NULL DEFAULT 62"`
+	DayStartMinute        int                `xorm:"NOT NULL DEFAULT 540"`
+	DayEndMinute          int                `xorm:"NOT NULL DEFAULT 1020"`
+	Timezone              string             `xorm:"VARCHAR(64)"`
+	EscalateBeforeSeconds int64              `xorm:"NOT NULL DEFAULT 0"`
+	EscalationComment     bool               `xorm:"NOT NULL DEFAULT false"`
+	EscalationMentions    string             `xorm:"VARCHAR(255)"`
+	EscalationLabelID     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix           timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix           timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (issueSLAPolicy) TableName() string {
+	return "issue_sla_policy"
+}
+
+type issueWithSLA struct {
+	SLAPolicyID             int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLAResponseDeadlineUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLAResolveDeadlineUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLARespondedUnix        timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	SLAResponseBreached     bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResolveBreached      bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResponseEscalated    bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResolveEscalated     bool               `xorm:"NOT NULL DEFAULT false"`
+}
+
+func (issueWithSLA) TableName() string {
+	return "issue"
+}
+
+func addIssueSLA(x *xorm.Engine) error {
+	if err := x.Sync(new(issueSLAPolicy)); err != nil { // nosemgrep:xorm-sync-missing-ignore-drop-indices
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(issueWithSLA))
+	return err
+}
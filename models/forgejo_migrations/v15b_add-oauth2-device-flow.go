@@ -0,0 +1,50 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add oauth2_device_code table and enable_device_flow column to oauth2_application",
+		Upgrade:     addOAuth2DeviceFlow,
+	})
+}
+
+type oauth2ApplicationDeviceFlow struct {
+	EnableDeviceFlow bool `xorm:"NOT NULL DEFAULT FALSE"`
+}
+
+func (oauth2ApplicationDeviceFlow) TableName() string {
+	return "oauth2_application"
+}
+
+type oauth2DeviceCode struct {
+	ID             int64              `xorm:"pk autoincr"`
+	ApplicationID  int64              `xorm:"INDEX NOT NULL"`
+	DeviceCodeHash string             `xorm:"UNIQUE NOT NULL"`
+	UserCode       string             `xorm:"UNIQUE NOT NULL"`
+	Scope          string             `xorm:"TEXT"`
+	Status         int                `xorm:"NOT NULL DEFAULT 0"`
+	UserID         int64              `xorm:"NOT NULL DEFAULT 0"`
+	Interval       int64              `xorm:"NOT NULL"`
+	LastPolledUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	ExpiresUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func (oauth2DeviceCode) TableName() string {
+	return "oauth2_device_code"
+}
+
+func addOAuth2DeviceFlow(x *xorm.Engine) error {
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(oauth2ApplicationDeviceFlow)); err != nil {
+		return err
+	}
+	return x.Sync(new(oauth2DeviceCode)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add two_factor_requirement table",
+		Upgrade:     addTwoFactorRequirement,
+	})
+}
+
+type twoFactorRequirement struct {
+	ID           int64              `xorm:"pk autoincr"`
+	OrgID        int64              `xorm:"UNIQUE NOT NULL"`
+	DeadlineUnix timeutil.TimeStamp `xorm:"NOT NULL"`
+	RemindedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+func (twoFactorRequirement) TableName() string {
+	return "two_factor_requirement"
+}
+
+func addTwoFactorRequirement(x *xorm.Engine) error {
+	return x.Sync(new(twoFactorRequirement)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add package_access table",
+		Upgrade:     addPackageAccess,
+	})
+}
+
+type packageAccess struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	LowerName   string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	AccessMode  int                `xorm:"NOT NULL DEFAULT 1"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+}
+
+func (packageAccess) TableName() string {
+	return "package_access"
+}
+
+func addPackageAccess(x *xorm.Engine) error {
+	return x.Sync(new(packageAccess)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add package_attestation table",
+		Upgrade:     addPackageAttestations,
+	})
+}
+
+type packageAttestation struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"INDEX NOT NULL"`
+	FileID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Type        string             `xorm:"VARCHAR(20) NOT NULL"`
+	Content     string             `xorm:"LONGTEXT NOT NULL"`
+	Verified    bool               `xorm:"NOT NULL DEFAULT false"`
+	KeyID       string             `xorm:"VARCHAR(255)"`
+	SignerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	BuilderID   string             `xorm:"TEXT"`
+	RunID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (packageAttestation) TableName() string {
+	return "package_attestation"
+}
+
+func addPackageAttestations(x *xorm.Engine) error {
+	return x.Sync(new(packageAttestation)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
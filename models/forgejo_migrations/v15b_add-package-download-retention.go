@@ -0,0 +1,38 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add last download time of package files and remove_unused_days of package cleanup rules",
+		Upgrade:     addPackageDownloadRetention,
+	})
+}
+
+type packageFileWithLastDownload struct {
+	LastDownloadUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+func (packageFileWithLastDownload) TableName() string {
+	return "package_file"
+}
+
+type packageCleanupRuleWithUnusedDays struct {
+	RemoveUnusedDays int `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (packageCleanupRuleWithUnusedDays) TableName() string {
+	return "package_cleanup_rule"
+}
+
+func addPackageDownloadRetention(x *xorm.Engine) error {
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(packageFileWithLastDownload), new(packageCleanupRuleWithUnusedDays))
+	return err
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add upstream remotes of packages and the upstream_only flag of package versions",
+		Upgrade:     addPackageRemotes,
+	})
+}
+
+type packageRemote struct {
+	ID                int64              `xorm:"pk autoincr"`
+	OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type              string             `xorm:"UNIQUE(s) NOT NULL"`
+	URL               string             `xorm:"TEXT NOT NULL"`
+	Username          string             `xorm:"VARCHAR(255)"`
+	PasswordEncrypted []byte             `xorm:"BLOB"`
+	MetadataTTL       int64              `xorm:"'metadata_ttl' NOT NULL DEFAULT 3600"`
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+func (packageRemote) TableName() string {
+	return "package_remote"
+}
+
+type packageRemoteMetadata struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RemoteID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path        string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+	Content     string             `xorm:"LONGTEXT"`
+	FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (packageRemoteMetadata) TableName() string {
+	return "package_remote_metadata"
+}
+
+type packageVersionWithUpstream struct {
+	UpstreamOnly bool `xorm:"INDEX NOT NULL DEFAULT false"`
+}
+
+func (packageVersionWithUpstream) TableName() string {
+	return "package_version"
+}
+
+type packageCleanupRuleWithUpstream struct {
+	UpstreamOnly bool `xorm:"NOT NULL DEFAULT false"`
+}
+
+func (packageCleanupRuleWithUpstream) TableName() string {
+	return "package_cleanup_rule"
+}
+
+func addPackageRemotes(x *xorm.Engine) error {
+	if err := x.Sync(new(packageRemote), new(packageRemoteMetadata)); err != nil { // nosemgrep:xorm-sync-missing-ignore-drop-indices
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(packageVersionWithUpstream), new(packageCleanupRuleWithUpstream))
+	return err
+}
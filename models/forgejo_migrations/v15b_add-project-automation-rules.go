@@ -0,0 +1,36 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add project automation rules",
+		Upgrade:     addProjectAutomationRules,
+	})
+}
+
+type projectAutomationRule struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	Event       uint8              `xorm:"INDEX NOT NULL"`
+	ColumnID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"NOT NULL DEFAULT 0"`
+	LabelID     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func (projectAutomationRule) TableName() string {
+	return "project_automation_rule"
+}
+
+func addProjectAutomationRules(x *xorm.Engine) error {
+	return x.Sync(new(projectAutomationRule)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
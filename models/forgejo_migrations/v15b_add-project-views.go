@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add project views, custom fields and issue dates",
+		Upgrade:     addProjectViews,
+	})
+}
+
+type projectView struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	Title       string             `xorm:"NOT NULL"`
+	Type        uint8              `xorm:"NOT NULL DEFAULT 1"`
+	Filter      string             `xorm:"TEXT"`
+	GroupBy     string             `xorm:"VARCHAR(20)"`
+	SortBy      string             `xorm:"VARCHAR(50)"`
+	SortDesc    bool               `xorm:"NOT NULL DEFAULT false"`
+	Fields      []int64            `xorm:"JSON TEXT"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func (projectView) TableName() string {
+	return "project_view"
+}
+
+type projectField struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ProjectID   int64              `xorm:"INDEX NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	Type        uint8              `xorm:"NOT NULL DEFAULT 1"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func (projectField) TableName() string {
+	return "project_field"
+}
+
+type projectFieldValue struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	FieldID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string `xorm:"TEXT"`
+}
+
+func (projectFieldValue) TableName() string {
+	return "project_field_value"
+}
+
+func addProjectViews(x *xorm.Engine) error {
+	if err := x.Sync(new(projectView), new(projectField), new(projectFieldValue)); err != nil { // nosemgrep:xorm-sync-missing-ignore-drop-indices
+		return err
+	}
+
+	type ProjectIssue struct {
+		StartDateUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		TargetDateUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ProjectIssue))
+	return err
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add scim_external_id table",
+		Upgrade:     addSCIMExternalID,
+	})
+}
+
+type scimExternalID struct {
+	ID           int64  `xorm:"pk autoincr"`
+	ResourceType int    `xorm:"UNIQUE(s) UNIQUE(e) NOT NULL"`
+	ResourceID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	ExternalID   string `xorm:"UNIQUE(e) NOT NULL"`
+}
+
+func (scimExternalID) TableName() string {
+	return "scim_external_id"
+}
+
+func addSCIMExternalID(x *xorm.Engine) error {
+	return x.Sync(new(scimExternalID)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
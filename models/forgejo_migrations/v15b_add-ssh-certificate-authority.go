@@ -0,0 +1,51 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add ssh_certificate_authority and ssh_certificate tables",
+		Upgrade:     addSSHCertificateAuthority,
+	})
+}
+
+type sshCertificateAuthority struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Fingerprint string             `xorm:"UNIQUE NOT NULL"`
+	PublicKey   string             `xorm:"TEXT NOT NULL"`
+	PrivateKey  []byte             `xorm:"BLOB"`
+	IsActive    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	RetiredUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (sshCertificateAuthority) TableName() string {
+	return "ssh_certificate_authority"
+}
+
+type sshCertificate struct {
+	ID              int64              `xorm:"pk autoincr"`
+	OwnerID         int64              `xorm:"INDEX NOT NULL"`
+	AuthorityID     int64              `xorm:"INDEX NOT NULL"`
+	Fingerprint     string             `xorm:"NOT NULL"`
+	Principal       string             `xorm:"NOT NULL"`
+	ValidAfterUnix  timeutil.TimeStamp `xorm:"NOT NULL"`
+	ValidBeforeUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	RevokedUnix     timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"created"`
+}
+
+func (sshCertificate) TableName() string {
+	return "ssh_certificate"
+}
+
+func addSSHCertificateAuthority(x *xorm.Engine) error {
+	return x.Sync(new(sshCertificateAuthority), new(sshCertificate)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add sub_issue table",
+		Upgrade:     addSubIssueTable,
+	})
+}
+
+func addSubIssueTable(x *xorm.Engine) error {
+	type SubIssue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ParentID    int64              `xorm:"INDEX NOT NULL REFERENCES(issue, id)"`
+		IssueID     int64              `xorm:"UNIQUE NOT NULL REFERENCES(issue, id)"`
+		UserID      int64              `xorm:"NOT NULL"`
+		Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(SubIssue)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add terraform_state and terraform_state_version tables",
+		Upgrade:     addTerraformStates,
+	})
+}
+
+type terraformState struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name        string             `xorm:"UNIQUE(s) NOT NULL"`
+	LockID      string             `xorm:"NOT NULL DEFAULT ''"`
+	LockInfo    string             `xorm:"TEXT"`
+	LockerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	LockedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+func (terraformState) TableName() string {
+	return "terraform_state"
+}
+
+type terraformStateVersion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	StateID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Lineage     string             `xorm:"VARCHAR(255)"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	HashSHA256  string             `xorm:"hash_sha256 CHAR(64) NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (terraformStateVersion) TableName() string {
+	return "terraform_state_version"
+}
+
+func addTerraformStates(x *xorm.Engine) error {
+	return x.Sync(new(terraformState), new(terraformStateVersion)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
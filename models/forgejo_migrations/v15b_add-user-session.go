@@ -0,0 +1,36 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add user_session table",
+		Upgrade:     addUserSession,
+	})
+}
+
+type userSession struct {
+	ID           int64              `xorm:"pk autoincr"`
+	UserID       int64              `xorm:"INDEX NOT NULL"`
+	Token        string             `xorm:"UNIQUE NOT NULL"`
+	AuthMethod   string             `xorm:"NOT NULL DEFAULT ''"`
+	IP           string             `xorm:"NOT NULL DEFAULT ''"`
+	UserAgent    string             `xorm:"TEXT"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	LastSeenUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+func (userSession) TableName() string {
+	return "user_session"
+}
+
+func addUserSession(x *xorm.Engine) error {
+	return x.Sync(new(userSession)) // nosemgrep:xorm-sync-missing-ignore-drop-indices
+}
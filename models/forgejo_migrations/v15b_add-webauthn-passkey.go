@@ -0,0 +1,24 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add passkey to webauthn_credential",
+		Upgrade:     addWebAuthnPasskey,
+	})
+}
+
+func addWebAuthnPasskey(x *xorm.Engine) error {
+	type WebauthnCredential struct {
+		Passkey bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(WebauthnCredential))
+	return err
+}
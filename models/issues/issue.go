@@ -116,7 +116,17 @@ type Issue struct {
 
 	DeadlineUnix timeutil.TimeStamp `xorm:"INDEX"`
 
-	Created timeutil.TimeStampNano // more precise Created, but may not be populated for older issues
+	// SLAPolicyID is the SLA policy applied to the issue, its deadlines are kept if it is deleted
+	SLAPolicyID             int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLAResponseDeadlineUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLAResolveDeadlineUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SLARespondedUnix        timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	SLAResponseBreached     bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResolveBreached      bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResponseEscalated    bool               `xorm:"NOT NULL DEFAULT false"`
+	SLAResolveEscalated     bool               `xorm:"NOT NULL DEFAULT false"`
+
+	Createdtimeutil.TimeStampNano // more precise Created, but may not be populated for older issues
 
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
@@ -98,6 +98,22 @@ func LoadIssuesFromColumnList(ctx context.Context, bs project_model.ColumnList,
 	return issuesMap, nil
 }
 
+// LoadIssuesFromProject load all issues assigned to the project, whatever their column
+func LoadIssuesFromProject(ctx context.Context, p *project_model.Project, doer *user_model.User, org *org_model.Organization, isClosed optional.Option[bool]) (IssueList, error) {
+	issueOpts := &IssuesOptions{
+		ProjectID: p.ID,
+		SortType:  "project-column-sorting",
+		IsClosed:  isClosed,
+		AllPublic: true,
+	}
+	if doer != nil {
+		issueOpts.User = doer
+		issueOpts.Org = org
+	}
+
+	return Issues(ctx, issueOpts)
+}
+
 // IssueAssignOrRemoveProject changes the project associated with an issue
 // If newProjectID is 0, the issue is removed from the project
 func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_model.User, newProjectID, newColumnID int64) error {
@@ -129,6 +145,11 @@ func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_mo
 		if _, err := db.GetEngine(ctx).Where("project_issue.issue_id=?", issue.ID).Delete(&project_model.ProjectIssue{}); err != nil {
 			return err
 		}
+		if oldProjectID > 0 && oldProjectID != newProjectID {
+			if err := project_model.DeleteIssueFieldValues(ctx, oldProjectID, issue.ID); err != nil {
+				return err
+			}
+		}
 
 		if oldProjectID > 0 || newProjectID > 0 {
 			if _, err := CreateComment(ctx, &CreateCommentOptions{
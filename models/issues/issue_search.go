@@ -26,6 +26,7 @@ type IssuesOptions struct { //nolint
 	Paginator          *db.ListOptions
 	RepoIDs            []int64 // overwrites RepoCond if the length is not 0
 	AllPublic          bool    // include also all public repositories
+	ExcludedRepoIDs    []int64 // excludes these repositories, even if they are public
 	RepoCond           builder.Cond
 	AssigneeID         int64
 	PosterID           int64
@@ -33,6 +34,8 @@ type IssuesOptions struct { //nolint
 	ReviewRequestedID  int64
 	ReviewedID         int64
 	SubscriberID       int64
+	ParentID           int64                 // db.NoConditionID means issues without a parent
+	HasParent          optional.Option[bool] // if the issues are sub-issues of another issue
 	MilestoneIDs       []int64
 	ProjectID          int64
 	ProjectColumnID    int64
@@ -180,6 +183,22 @@ func applyMilestoneCondition(sess *xorm.Session, opts *IssuesOptions) {
 	}
 }
 
+func applyParentCondition(sess *xorm.Session, opts *IssuesOptions) {
+	if opts.ParentID > 0 {
+		sess.In("issue.id", builder.Select("issue_id").From("sub_issue").Where(builder.Eq{"parent_id": opts.ParentID}))
+	} else if opts.ParentID == db.NoConditionID {
+		sess.NotIn("issue.id", builder.Select("issue_id").From("sub_issue"))
+	}
+
+	if has, value := opts.HasParent.Get(); has {
+		if value {
+			sess.In("issue.id", builder.Select("issue_id").From("sub_issue"))
+		} else {
+			sess.NotIn("issue.id", builder.Select("issue_id").From("sub_issue"))
+		}
+	}
+}
+
 func applyProjectCondition(sess *xorm.Session, opts *IssuesOptions) {
 	if opts.ProjectID > 0 { // specific project
 		sess.Join("INNER", "project_issue", "issue.id = project_issue.issue_id").
@@ -217,6 +236,9 @@ func applyRepoConditions(sess *xorm.Session, opts *IssuesOptions) {
 	if opts.RepoCond != nil {
 		sess.And(opts.RepoCond)
 	}
+	if len(opts.ExcludedRepoIDs) > 0 {
+		sess.And(builder.NotIn("issue.repo_id", opts.ExcludedRepoIDs))
+	}
 }
 
 func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
@@ -258,6 +280,8 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyMilestoneCondition(sess, opts)
 
+	applyParentCondition(sess, opts)
+
 	if opts.UpdatedAfterUnix != 0 {
 		sess.And(builder.Gte{"issue.updated_unix": opts.UpdatedAfterUnix})
 	}
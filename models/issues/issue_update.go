@@ -709,6 +709,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		// Sub-issue relations, including those with issues in other repositories
+		if err = deleteSubIssuesByIssueIDs(ctx, issueIDs); err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
@@ -739,6 +744,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&project_model.FieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("dependent_issue_id", issueIDs).Delete(&Comment{})
 		if err != nil {
 			return nil, err
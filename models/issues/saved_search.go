@@ -0,0 +1,333 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SavedSearchViewTypes are the filters of the issues and pull requests dashboards a search can be saved with
+var SavedSearchViewTypes = []string{"created_by", "your_repositories", "assigned", "review_requested", "reviewed_by", "mentioned"}
+
+// SavedSearchSortTypes are the sort orders of the issues and pull requests dashboards a search can be saved with
+var SavedSearchSortTypes = []string{"recentupdate", "leastupdate", "latest", "oldest", "mostcomment", "leastcomment", "nearduedate", "farduedate"}
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SavedSearch is a named search of the issues or pull requests dashboard.
+// A search owned by a user is private, a search owned by an organization is shared
+// with its members, or with the members of one of its teams only.
+type SavedSearch struct {
+	ID        int64 `xorm:"pk autoincr"`
+	OwnerID   int64 `xorm:"INDEX NOT NULL"`
+	CreatorID int64 `xorm:"NOT NULL"`
+	// TeamID restricts the search of an organization to the members of the team, zero sharing it with all members
+	TeamID   int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name     string `xorm:"NOT NULL"`
+	IsPull   bool   `xorm:"NOT NULL DEFAULT false"`
+	IsPinned bool   `xorm:"NOT NULL DEFAULT false"`
+
+	Keyword  string `xorm:"TEXT"`
+	ViewType string `xorm:"VARCHAR(30)"`
+	State    string `xorm:"VARCHAR(10)"`
+	// Labels are the comma separated IDs of the labels, negative IDs excluding a label
+	Labels   string `xorm:"TEXT"`
+	SortType string `xorm:"VARCHAR(30)"`
+
+	Owner *user_model.User   `xorm:"-"`
+	Team  *organization.Team `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// TableName return the real table name
+func (SavedSearch) TableName() string {
+	return "issue_saved_search"
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+}
+
+// IsClosed returns true if the search lists the closed issues
+func (s *SavedSearch) IsClosed() bool {
+	return s.State == "closed"
+}
+
+// LabelIDs returns the IDs of the labels the search filters on
+func (s *SavedSearch) LabelIDs() ([]int64, error) {
+	if s.Labels == "" || s.Labels == "0" {
+		return nil, nil
+	}
+	ids := make([]int64, 0, strings.Count(s.Labels, ",")+1)
+	for _, label := range strings.Split(s.Labels, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(label), 10, 64)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid label id %q", label)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// SetLabelIDs sets the IDs of the labels the search filters on
+func (s *SavedSearch) SetLabelIDs(labelIDs []int64) {
+	labels := make([]string, 0, len(labelIDs))
+	for _, id := range labelIDs {
+		labels = append(labels, strconv.FormatInt(id, 10))
+	}
+	s.Labels = strings.Join(labels, ",")
+}
+
+// normalize fills the defaults of the search and checks its filters
+func (s *SavedSearch) normalize() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return util.NewInvalidArgumentErrorf("saved search name cannot be empty")
+	}
+	if len(s.Name) > 255 {
+		return util.NewInvalidArgumentErrorf("saved search name is too long")
+	}
+	s.Keyword = strings.TrimSpace(s.Keyword)
+
+	if s.ViewType != "" && !slices.Contains(SavedSearchViewTypes, s.ViewType) {
+		return util.NewInvalidArgumentErrorf("invalid saved search type %q", s.ViewType)
+	}
+	if s.SortType == "" {
+		s.SortType = "recentupdate"
+	} else if !slices.Contains(SavedSearchSortTypes, s.SortType) {
+		return util.NewInvalidArgumentErrorf("invalid saved search sort %q", s.SortType)
+	}
+	if s.State != "closed" {
+		s.State = "open"
+	}
+	if _, err := s.LabelIDs(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LoadOwner loads the user or organization owning the search
+func (s *SavedSearch) LoadOwner(ctx context.Context) (err error) {
+	if s.Owner != nil {
+		return nil
+	}
+	s.Owner, err = user_model.GetUserByID(ctx, s.OwnerID)
+	return err
+}
+
+// LoadTeam loads the team the search is shared with, if any
+func (s *SavedSearch) LoadTeam(ctx context.Context) (err error) {
+	if s.TeamID == 0 || s.Team != nil {
+		return nil
+	}
+	s.Team, err = organization.GetTeamByID(ctx, s.TeamID)
+	return err
+}
+
+// LoadAttributes loads the owner of the search and the team it is shared with
+func (s *SavedSearch) LoadAttributes(ctx context.Context) error {
+	if err := s.LoadOwner(ctx); err != nil {
+		return err
+	}
+	return s.LoadTeam(ctx)
+}
+
+// QueryString returns the query string of the dashboard listing the issues of the search
+func (s *SavedSearch) QueryString() string {
+	values := url.Values{}
+	if s.ViewType != "" {
+		values.Set("type", s.ViewType)
+	}
+	values.Set("sort", s.SortType)
+	values.Set("state", s.State)
+	if s.Labels != "" {
+		values.Set("labels", s.Labels)
+	}
+	if s.Keyword != "" {
+		values.Set("q", s.Keyword)
+	}
+	return values.Encode()
+}
+
+// dashboardPath returns the path of the dashboard listing the issues of the search
+func (s *SavedSearch) dashboardPath() string {
+	path := "issues"
+	if s.IsPull {
+		path = "pulls"
+	}
+	if s.Owner != nil && s.Owner.IsOrganization() {
+		path = "org/" + url.PathEscape(s.Owner.Name) + "/" + path
+		if s.Team != nil {
+			path += "/" + url.PathEscape(s.Team.LowerName)
+		}
+	}
+	return path + "?" + s.QueryString()
+}
+
+// Link returns the link to the dashboard listing the issues of the search.
+// The owner and the team must be loaded.
+func (s *SavedSearch) Link() string {
+	return setting.AppSubURL + "/" + s.dashboardPath()
+}
+
+// HTMLURL returns the absolute URL of the dashboard listing the issues of the search.
+// The owner and the team must be loaded.
+func (s *SavedSearch) HTMLURL() string {
+	return setting.AppURL + s.dashboardPath()
+}
+
+// FeedLink returns the link to the feed of the search, without its format extension
+func (s *SavedSearch) FeedLink() string {
+	return setting.AppSubURL + "/saved-searches/" + strconv.FormatInt(s.ID, 10)
+}
+
+// FeedURL returns the absolute URL of the feed of the search, without its format extension
+func (s *SavedSearch) FeedURL() string {
+	return setting.AppURL + "saved-searches/" + strconv.FormatInt(s.ID, 10)
+}
+
+// CanBeSeenBy checks if the doer can see and run the search
+func (s *SavedSearch) CanBeSeenBy(ctx context.Context, doer *user_model.User) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if s.OwnerID == doer.ID {
+		return true, nil
+	}
+	if s.TeamID > 0 {
+		return organization.IsTeamMember(ctx, s.OwnerID, s.TeamID, doer.ID)
+	}
+	return organization.IsOrganizationMember(ctx, s.OwnerID, doer.ID)
+}
+
+// CanBeChangedBy checks if the doer can rename, pin or delete the search: the user owning it,
+// or for a search of an organization the member who saved it and the owners of the organization
+func (s *SavedSearch) CanBeChangedBy(ctx context.Context, doer *user_model.User) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if s.OwnerID == doer.ID || doer.IsAdmin {
+		return true, nil
+	}
+	if s.CreatorID == doer.ID {
+		return s.CanBeSeenBy(ctx, doer)
+	}
+	return organization.IsOrganizationOwner(ctx, s.OwnerID, doer.ID)
+}
+
+// FindSavedSearchesOptions represents the options to list the saved searches a user can see
+type FindSavedSearchesOptions struct {
+	db.ListOptions
+	// Doer only lists the searches the user can see, their own and those shared with them
+	Doer *user_model.User
+	// OwnerID only lists the searches of the user or organization if not zero
+	OwnerID  int64
+	IsPull   optional.Option[bool]
+	IsPinned optional.Option[bool]
+}
+
+// ToConds implements db.FindOptions
+func (opts FindSavedSearchesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.Doer != nil {
+		cond = cond.And(builder.Eq{"owner_id": opts.Doer.ID}.Or(
+			builder.In("owner_id", builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": opts.Doer.ID})).
+				And(builder.Eq{"team_id": 0}.Or(
+					builder.In("team_id", builder.Select("team_id").From("team_user").Where(builder.Eq{"uid": opts.Doer.ID})),
+				)),
+		))
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if has, isPull := opts.IsPull.Get(); has {
+		cond = cond.And(builder.Eq{"is_pull": isPull})
+	}
+	if has, isPinned := opts.IsPinned.Get(); has {
+		cond = cond.And(builder.Eq{"is_pinned": isPinned})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptions
+func (opts FindSavedSearchesOptions) ToOrders() string {
+	return "is_pinned DESC, name ASC, id ASC"
+}
+
+// CreateSavedSearch saves a search of the issues or pull requests dashboard
+func CreateSavedSearch(ctx context.Context, search *SavedSearch) error {
+	if err := search.normalize(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, search)
+}
+
+// GetSavedSearchByID returns the saved search with the given ID
+func GetSavedSearchByID(ctx context.Context, id int64) (*SavedSearch, error) {
+	search := new(SavedSearch)
+	has, err := db.GetEngine(ctx).ID(id).Get(search)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedSearchNotExist{ID: id}
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch updates the name, the sharing and the filters of a saved search
+func UpdateSavedSearch(ctx context.Context, search *SavedSearch) error {
+	if err := search.normalize(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(search.ID).
+		Cols("name", "team_id", "is_pull", "is_pinned", "keyword", "view_type", "state", "labels", "sort_type").
+		Update(search)
+	return err
+}
+
+// DeleteSavedSearchByID deletes a saved search
+func DeleteSavedSearchByID(ctx context.Context, id int64) error {
+	affected, err := db.GetEngine(ctx).ID(id).Delete(&SavedSearch{})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSavedSearchNotExist{ID: id}
+	}
+	return nil
+}
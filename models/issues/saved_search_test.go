@@ -0,0 +1,118 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"errors"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// user2 and user4 are members of org3, user2 alone in its team 1, user5 is not a member
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	private := &issues_model.SavedSearch{OwnerID: 2, CreatorID: 2, Name: " Assigned bugs ", ViewType: "assigned", Labels: "1,-2"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, private))
+	assert.Equal(t, "Assigned bugs", private.Name)
+	assert.Equal(t, "open", private.State)
+	assert.Equal(t, "recentupdate", private.SortType)
+	labels, err := private.LabelIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, -2}, labels)
+	assert.Equal(t, "labels=1%2C-2&sort=recentupdate&state=open&type=assigned", private.QueryString())
+
+	shared := &issues_model.SavedSearch{OwnerID: 3, CreatorID: 4, Name: "Org pulls", IsPull: true, IsPinned: true, State: "closed"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, shared))
+	team := &issues_model.SavedSearch{OwnerID: 3, CreatorID: 2, Name: "Owners", TeamID: 1}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, team))
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, search := range []*issues_model.SavedSearch{
+			{OwnerID: 2, Name: "  "},
+			{OwnerID: 2, Name: "sort", SortType: "priority"},
+			{OwnerID: 2, Name: "type", ViewType: "everything"},
+			{OwnerID: 2, Name: "labels", Labels: "1,bug"},
+		} {
+			err := issues_model.CreateSavedSearch(db.DefaultContext, search)
+			assert.True(t, errors.Is(err, util.ErrInvalidArgument), search.Name)
+		}
+	})
+
+	t.Run("Visibility", func(t *testing.T) {
+		cases := []struct {
+			search  *issues_model.SavedSearch
+			doer    *user_model.User
+			canSee  bool
+			canEdit bool
+		}{
+			{private, user2, true, true},
+			{private, user4, false, false},
+			{shared, user2, true, true},
+			{shared, user4, true, true},
+			{shared, user5, false, false},
+			{team, user2, true, true},
+			{team, user4, false, false},
+		}
+		for _, c := range cases {
+			canSee, err := c.search.CanBeSeenBy(db.DefaultContext, c.doer)
+			require.NoError(t, err)
+			assert.Equal(t, c.canSee, canSee, "%s seen by %s", c.search.Name, c.doer.Name)
+			canEdit, err := c.search.CanBeChangedBy(db.DefaultContext, c.doer)
+			require.NoError(t, err)
+			assert.Equal(t, c.canEdit, canEdit, "%s changed by %s", c.search.Name, c.doer.Name)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		ids := func(opts issues_model.FindSavedSearchesOptions) []int64 {
+			searches, err := db.Find[issues_model.SavedSearch](db.DefaultContext, opts)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(searches))
+			for _, search := range searches {
+				ids = append(ids, search.ID)
+			}
+			return ids
+		}
+
+		// pinned searches come first, then by name
+		assert.Equal(t, []int64{shared.ID, private.ID, team.ID}, ids(issues_model.FindSavedSearchesOptions{Doer: user2}))
+		assert.Equal(t, []int64{shared.ID}, ids(issues_model.FindSavedSearchesOptions{Doer: user4}))
+		assert.Empty(t, ids(issues_model.FindSavedSearchesOptions{Doer: user5}))
+		assert.Equal(t, []int64{shared.ID, team.ID}, ids(issues_model.FindSavedSearchesOptions{Doer: user2, OwnerID: 3}))
+		assert.Equal(t, []int64{private.ID, team.ID}, ids(issues_model.FindSavedSearchesOptions{Doer: user2, IsPull: optional.Some(false)}))
+		assert.Equal(t, []int64{shared.ID}, ids(issues_model.FindSavedSearchesOptions{Doer: user2, IsPinned: optional.Some(true)}))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		private.IsPinned = true
+		private.SetLabelIDs([]int64{3})
+		require.NoError(t, issues_model.UpdateSavedSearch(db.DefaultContext, private))
+
+		search, err := issues_model.GetSavedSearchByID(db.DefaultContext, private.ID)
+		require.NoError(t, err)
+		assert.True(t, search.IsPinned)
+		assert.Equal(t, "3", search.Labels)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.DeleteSavedSearchByID(db.DefaultContext, private.ID))
+		_, err := issues_model.GetSavedSearchByID(db.DefaultContext, private.ID)
+		assert.True(t, issues_model.IsErrSavedSearchNotExist(err))
+		assert.True(t, issues_model.IsErrSavedSearchNotExist(issues_model.DeleteSavedSearchByID(db.DefaultContext, private.ID)))
+	})
+}
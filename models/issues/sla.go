@@ -0,0 +1,401 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SLAIssueType restricts an SLA policy to the issues or to the pull requests of a repository
+type SLAIssueType int
+
+const (
+	// SLAIssueTypeAll applies the policy to both issues and pull requests
+	SLAIssueTypeAll SLAIssueType = iota
+	// SLAIssueTypeIssues applies the policy to issues only
+	SLAIssueTypeIssues
+	// SLAIssueTypePulls applies the policy to pull requests only
+	SLAIssueTypePulls
+)
+
+// Name returns the name of the issue type, used for translations
+func (t SLAIssueType) Name() string {
+	switch t {
+	case SLAIssueTypeIssues:
+		return "issues"
+	case SLAIssueTypePulls:
+		return "pulls"
+	default:
+		return "all"
+	}
+}
+
+// SLAWorkdays is the set of the days of the week of a business calendar
+type SLAWorkdays int
+
+// SLAWorkdaysMondayToFriday is the default business calendar
+const SLAWorkdaysMondayToFriday SLAWorkdays = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+
+// NewSLAWorkdays returns the set of the given days of the week
+func NewSLAWorkdays(days ...time.Weekday) SLAWorkdays {
+	var w SLAWorkdays
+	for _, day := range days {
+		w |= 1 << day
+	}
+	return w
+}
+
+// Has checks if the day of the week is a workday
+func (w SLAWorkdays) Has(day time.Weekday) bool {
+	return w&(1<<day) != 0
+}
+
+// ErrSLAPolicyNotExist represents a "SLAPolicyNotExist" kind of error.
+type ErrSLAPolicyNotExist struct {
+	ID int64
+}
+
+// IsErrSLAPolicyNotExist checks if an error is a ErrSLAPolicyNotExist.
+func IsErrSLAPolicyNotExist(err error) bool {
+	_, ok := err.(ErrSLAPolicyNotExist)
+	return ok
+}
+
+func (err ErrSLAPolicyNotExist) Error() string {
+	return fmt.Sprintf("SLA policy does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSLAPolicyNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SLAPolicy defines the time the issues or pull requests of a repository must be responded to
+// and closed in. The issues are matched by label first, then by type.
+type SLAPolicy struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"INDEX NOT NULL"`
+	CreatorID int64  `xorm:"NOT NULL"`
+	Name      string `xorm:"NOT NULL"`
+
+	IssueType SLAIssueType `xorm:"NOT NULL DEFAULT 0"`
+	LabelID   int64        `xorm:"NOT NULL DEFAULT 0"`
+
+	// ResponseSeconds is the time to the first response of someone else than the poster, zero for none
+	ResponseSeconds int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ResolveSeconds is the time to close the issue, zero for none
+	ResolveSeconds int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	// BusinessHours only counts the time between DayStartMinute and DayEndMinute of the workdays
+	BusinessHours  bool        `xorm:"NOT NULL DEFAULT false"`
+	Workdays       SLAWorkdays `xorm:"NOT NULL DEFAULT 62"`
+	DayStartMinute int         `xorm:"NOT NULL DEFAULT 540"`
+	DayEndMinute   int         `xorm:"NOT NULL DEFAULT 1020"`
+	Timezone       string      `xorm:"VARCHAR(64)"`
+
+	// EscalateBeforeSeconds is how long before a deadline the issue is escalated
+	EscalateBeforeSeconds int64 `xorm:"NOT NULL DEFAULT 0"`
+	// EscalationComment posts a comment notifying the participants of the issue
+	EscalationComment bool `xorm:"NOT NULL DEFAULT false"`
+	// EscalationMentions are the users and teams mentioned by the comment
+	EscalationMentions string `xorm:"VARCHAR(255)"`
+	EscalationLabelID  int64  `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (SLAPolicy) TableName() string {
+	return "issue_sla_policy"
+}
+
+func init() {
+	db.RegisterModel(new(SLAPolicy))
+}
+
+// Location returns the time zone of the business calendar of the policy
+func (p *SLAPolicy) Location() *time.Location {
+	if p.Timezone != "" {
+		if loc, err := time.LoadLocation(p.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// DayStart returns the start of the business hours formatted as HH:MM
+func (p *SLAPolicy) DayStart() string {
+	return fmt.Sprintf("%02d:%02d", p.DayStartMinute/60, p.DayStartMinute%60)
+}
+
+// DayEnd returns the end of the business hours formatted as HH:MM
+func (p *SLAPolicy) DayEnd() string {
+	return fmt.Sprintf("%02d:%02d", p.DayEndMinute/60, p.DayEndMinute%60)
+}
+
+// HasEscalation checks if the policy escalates the issues about to breach it
+func (p *SLAPolicy) HasEscalation() bool {
+	return p.EscalationComment || p.EscalationMentions != "" || p.EscalationLabelID > 0
+}
+
+// Matches checks if the policy applies to the issue having the given labels
+func (p *SLAPolicy) Matches(issue *Issue, labelIDs []int64) bool {
+	if p.IssueType == SLAIssueTypeIssues && issue.IsPull || p.IssueType == SLAIssueTypePulls && !issue.IsPull {
+		return false
+	}
+	return p.LabelID == 0 || slices.Contains(labelIDs, p.LabelID)
+}
+
+// Deadline returns the time a target of the given duration expires at for an issue opened at start.
+// With business hours, only the time within the working hours of the workdays is counted.
+func (p *SLAPolicy) Deadline(start time.Time, seconds int64) time.Time {
+	left := time.Duration(seconds) * time.Second
+	if !p.BusinessHours || p.Workdays&(1<<7-1) == 0 || p.DayEndMinute <= p.DayStartMinute {
+		return start.Add(left)
+	}
+
+	t := start.In(p.Location())
+	for {
+		year, month, day := t.Date()
+		dayStart := time.Date(year, month, day, p.DayStartMinute/60, p.DayStartMinute%60, 0, 0, t.Location())
+		dayEnd := time.Date(year, month, day, p.DayEndMinute/60, p.DayEndMinute%60, 0, 0, t.Location())
+		if p.Workdays.Has(t.Weekday()) && t.Before(dayEnd) {
+			if t.Before(dayStart) {
+				t = dayStart
+			}
+			available := dayEnd.Sub(t)
+			if left <= available {
+				return t.Add(left)
+			}
+			left -= available
+		}
+		t = time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (p *SLAPolicy) validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return util.NewInvalidArgumentErrorf("SLA policy name cannot be empty")
+	}
+	if p.IssueType < SLAIssueTypeAll || p.IssueType > SLAIssueTypePulls {
+		return util.NewInvalidArgumentErrorf("invalid SLA issue type %d", p.IssueType)
+	}
+	if p.ResponseSeconds < 0 || p.ResolveSeconds < 0 || p.EscalateBeforeSeconds < 0 {
+		return util.NewInvalidArgumentErrorf("SLA durations cannot be negative")
+	}
+	if p.ResponseSeconds == 0 && p.ResolveSeconds == 0 {
+		return util.NewInvalidArgumentErrorf("SLA policy needs a response or a resolution target")
+	}
+	if p.BusinessHours {
+		if p.Workdays == 0 {
+			return util.NewInvalidArgumentErrorf("SLA business calendar needs a workday")
+		}
+		if p.DayStartMinute < 0 || p.DayEndMinute > 24*60 || p.DayEndMinute <= p.DayStartMinute {
+			return util.NewInvalidArgumentErrorf("invalid SLA business hours")
+		}
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid SLA time zone %q", p.Timezone)
+		}
+	}
+	return nil
+}
+
+// CreateSLAPolicy creates an SLA policy for a repository
+func CreateSLAPolicy(ctx context.Context, p *SLAPolicy) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, p)
+}
+
+// GetSLAPolicyByID returns the SLA policy with the given ID
+func GetSLAPolicyByID(ctx context.Context, id int64) (*SLAPolicy, error) {
+	p := new(SLAPolicy)
+	has, err := db.GetEngine(ctx).ID(id).Get(p)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSLAPolicyNotExist{ID: id}
+	}
+	return p, nil
+}
+
+// GetSLAPoliciesByRepoID returns the SLA policies of a repository
+func GetSLAPoliciesByRepoID(ctx context.Context, repoID int64) ([]*SLAPolicy, error) {
+	policies := make([]*SLAPolicy, 0, 5)
+	return policies, db.GetEngine(ctx).Where("repo_id = ?", repoID).OrderBy("id").Find(&policies)
+}
+
+// GetEscalatingSLAPolicies returns the SLA policies escalating the issues about to breach them
+func GetEscalatingSLAPolicies(ctx context.Context) ([]*SLAPolicy, error) {
+	policies := make([]*SLAPolicy, 0, 10)
+	return policies, db.GetEngine(ctx).
+		Where(builder.Eq{"escalation_comment": true}.
+			Or(builder.Neq{"escalation_mentions": ""}).
+			Or(builder.Gt{"escalation_label_id": 0})).
+		OrderBy("id").
+		Find(&policies)
+}
+
+// GetMatchingSLAPolicy returns the SLA policy of the repository applying to the issue having
+// the given labels, the policies matching a label first. It returns nil if none applies.
+func GetMatchingSLAPolicy(ctx context.Context, issue *Issue, labelIDs []int64) (*SLAPolicy, error) {
+	policies, err := GetSLAPoliciesByRepoID(ctx, issue.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, byLabel := range []bool{true, false} {
+		for _, p := range policies {
+			if (p.LabelID > 0) == byLabel && p.Matches(issue, labelIDs) {
+				return p, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// DeleteSLAPolicy deletes an SLA policy of a repository. The issues it applied to keep
+// their deadlines but are no longer escalated.
+func DeleteSLAPolicy(ctx context.Context, repoID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&SLAPolicy{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrSLAPolicyNotExist{ID: id}
+		}
+		_, err = db.GetEngine(ctx).Where("sla_policy_id = ?", id).Cols("sla_policy_id").NoAutoTime().Update(&Issue{})
+		return err
+	})
+}
+
+// SLADeadline returns the next deadline of the SLA of an open issue, zero if there is none
+func (issue *Issue) SLADeadline() timeutil.TimeStamp {
+	if issue.IsClosed {
+		return 0
+	}
+	if issue.SLARespondedUnix == 0 && issue.SLAResponseDeadlineUnix > 0 {
+		return issue.SLAResponseDeadlineUnix
+	}
+	return issue.SLAResolveDeadlineUnix
+}
+
+// IsSLABreached checks if the issue missed a target of its SLA
+func (issue *Issue) IsSLABreached() bool {
+	if issue.SLAResponseBreached || issue.SLAResolveBreached {
+		return true
+	}
+	deadline := issue.SLADeadline()
+	return deadline > 0 && deadline < timeutil.TimeStampNow()
+}
+
+// SetIssueSLA applies the SLA policy to the issue, its deadlines counting from the creation of the issue
+func SetIssueSLA(ctx context.Context, issue *Issue, p *SLAPolicy) error {
+	created := issue.CreatedUnix.AsTime()
+	issue.SLAPolicyID = p.ID
+	issue.SLAResponseDeadlineUnix, issue.SLAResolveDeadlineUnix = 0, 0
+	if p.ResponseSeconds > 0 {
+		issue.SLAResponseDeadlineUnix = timeutil.TimeStamp(p.Deadline(created, p.ResponseSeconds).Unix())
+	}
+	if p.ResolveSeconds > 0 {
+		issue.SLAResolveDeadlineUnix = timeutil.TimeStamp(p.Deadline(created, p.ResolveSeconds).Unix())
+	}
+	_, err := db.GetEngine(ctx).ID(issue.ID).
+		Cols("sla_policy_id", "sla_response_deadline_unix", "sla_resolve_deadline_unix").
+		NoAutoTime().
+		Update(issue)
+	return err
+}
+
+// SetIssueSLAResponded records the first response to the issue and whether it came too late
+func SetIssueSLAResponded(ctx context.Context, issue *Issue, at timeutil.TimeStamp) error {
+	if issue.SLAResponseDeadlineUnix == 0 || issue.SLARespondedUnix > 0 {
+		return nil
+	}
+	issue.SLARespondedUnix = at
+	issue.SLAResponseBreached = issue.SLAResponseBreached || at > issue.SLAResponseDeadlineUnix
+	_, err := db.GetEngine(ctx).ID(issue.ID).
+		Cols("sla_responded_unix", "sla_response_breached").
+		NoAutoTime().
+		Update(issue)
+	return err
+}
+
+// SetIssueSLAResolved records whether the issue was closed too late
+func SetIssueSLAResolved(ctx context.Context, issue *Issue, at timeutil.TimeStamp) error {
+	if issue.SLAResolveDeadlineUnix == 0 || at <= issue.SLAResolveDeadlineUnix {
+		return nil
+	}
+	issue.SLAResolveBreached = true
+	_, err := db.GetEngine(ctx).ID(issue.ID).Cols("sla_resolve_breached").NoAutoTime().Update(issue)
+	return err
+}
+
+// SetIssueSLAEscalated records that the issue was escalated for its pending target
+func SetIssueSLAEscalated(ctx context.Context, issue *Issue) error {
+	if issue.SLARespondedUnix == 0 && issue.SLAResponseDeadlineUnix > 0 {
+		issue.SLAResponseEscalated = true
+	} else {
+		issue.SLAResolveEscalated = true
+	}
+	_, err := db.GetEngine(ctx).ID(issue.ID).
+		Cols("sla_response_escalated", "sla_resolve_escalated").
+		NoAutoTime().
+		Update(issue)
+	return err
+}
+
+// MarkSLABreaches records the breaches of the open issues whose deadlines have passed
+func MarkSLABreaches(ctx context.Context, now timeutil.TimeStamp) error {
+	if _, err := db.GetEngine(ctx).
+		Where(builder.Eq{"is_closed": false, "sla_responded_unix": 0, "sla_response_breached": false}).
+		And(builder.Gt{"sla_response_deadline_unix": 0}).
+		And(builder.Lt{"sla_response_deadline_unix": now}).
+		Cols("sla_response_breached").
+		NoAutoTime().
+		Update(&Issue{SLAResponseBreached: true}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).
+		Where(builder.Eq{"is_closed": false, "sla_resolve_breached": false}).
+		And(builder.Gt{"sla_resolve_deadline_unix": 0}).
+		And(builder.Lt{"sla_resolve_deadline_unix": now}).
+		Cols("sla_resolve_breached").
+		NoAutoTime().
+		Update(&Issue{SLAResolveBreached: true})
+	return err
+}
+
+// GetIssuesToEscalate returns the open issues of the SLA policy whose pending target expires
+// before the given time and which have not been escalated for it yet
+func GetIssuesToEscalate(ctx context.Context, policyID int64, before timeutil.TimeStamp) (IssueList, error) {
+	responsePending := builder.Eq{"sla_responded_unix": 0, "sla_response_escalated": false}.
+		And(builder.Gt{"sla_response_deadline_unix": 0}).
+		And(builder.Lte{"sla_response_deadline_unix": before})
+	resolvePending := builder.Eq{"sla_resolve_escalated": false}.
+		And(builder.Gt{"sla_resolve_deadline_unix": 0}).
+		And(builder.Lte{"sla_resolve_deadline_unix": before}).
+		And(builder.Neq{"sla_responded_unix": 0}.Or(builder.Eq{"sla_response_deadline_unix": 0}))
+
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Where(builder.Eq{"sla_policy_id": policyID, "is_closed": false}).
+		And(responsePending.Or(resolvePending)).
+		OrderBy("id").
+		Find(&issues)
+}
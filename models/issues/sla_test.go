@@ -0,0 +1,133 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAPolicyDeadline(t *testing.T) {
+	policy := &issues_model.SLAPolicy{
+		BusinessHours:  true,
+		Workdays:       issues_model.SLAWorkdaysMondayToFriday,
+		DayStartMinute: 9 * 60,
+		DayEndMinute:   17 * 60,
+	}
+	friday := time.Date(2026, time.October, 16, 16, 0, 0, 0, time.UTC)
+
+	// one hour on friday, the rest from the start of monday
+	assert.Equal(t, time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC), policy.Deadline(friday, 4*3600))
+	// issues opened out of the business hours wait for the next workday
+	assert.Equal(t, time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC), policy.Deadline(friday.Add(3*time.Hour), 3600))
+	// a full business day ends the same day
+	assert.Equal(t, time.Date(2026, time.October, 20, 17, 0, 0, 0, time.UTC), policy.Deadline(time.Date(2026, time.October, 20, 9, 0, 0, 0, time.UTC), 8*3600))
+
+	policy.Timezone = "America/New_York"
+	// 16:00 UTC is noon in New York
+	assert.Equal(t, time.Date(2026, time.October, 16, 17, 0, 0, 0, time.UTC), policy.Deadline(friday, 3600))
+
+	policy.BusinessHours = false
+	assert.Equal(t, friday.Add(4*time.Hour), policy.Deadline(friday, 4*3600))
+}
+
+func TestSLAPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, policy := range []*issues_model.SLAPolicy{
+			{RepoID: 1, Name: " ", ResolveSeconds: 60},
+			{RepoID: 1, Name: "no target"},
+			{RepoID: 1, Name: "type", IssueType: 3, ResolveSeconds: 60},
+			{RepoID: 1, Name: "hours", ResolveSeconds: 60, BusinessHours: true, Workdays: 62, DayStartMinute: 600, DayEndMinute: 540},
+			{RepoID: 1, Name: "timezone", ResolveSeconds: 60, Timezone: "Mars/Olympus"},
+		} {
+			err := issues_model.CreateSLAPolicy(db.DefaultContext, policy)
+			assert.True(t, errors.Is(err, util.ErrInvalidArgument), policy.Name)
+		}
+	})
+
+	all := &issues_model.SLAPolicy{RepoID: 1, CreatorID: 2, Name: "Everything", ResolveSeconds: 86400}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, all))
+	bugs := &issues_model.SLAPolicy{RepoID: 1, CreatorID: 2, Name: "Bugs", IssueType: issues_model.SLAIssueTypeIssues, LabelID: 1, ResponseSeconds: 3600, ResolveSeconds: 86400, EscalationComment: true}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, bugs))
+
+	t.Run("Matching", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		policy, err := issues_model.GetMatchingSLAPolicy(db.DefaultContext, issue, []int64{1})
+		require.NoError(t, err)
+		assert.Equal(t, bugs.ID, policy.ID)
+
+		policy, err = issues_model.GetMatchingSLAPolicy(db.DefaultContext, &issues_model.Issue{RepoID: 1, IsPull: true}, []int64{1})
+		require.NoError(t, err)
+		assert.Equal(t, all.ID, policy.ID)
+
+		policy, err = issues_model.GetMatchingSLAPolicy(db.DefaultContext, &issues_model.Issue{RepoID: 2}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, policy)
+
+		policies, err := issues_model.GetEscalatingSLAPolicies(db.DefaultContext)
+		require.NoError(t, err)
+		assert.Len(t, policies, 1)
+	})
+
+	t.Run("Tracking", func(t *testing.T) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, issues_model.SetIssueSLA(db.DefaultContext, issue, bugs))
+		assert.Equal(t, issue.CreatedUnix+3600, issue.SLAResponseDeadlineUnix)
+		assert.Equal(t, issue.SLAResponseDeadlineUnix, issue.SLADeadline())
+
+		// the issue was created long ago, both of its targets are breached
+		now := timeutil.TimeStampNow()
+		require.NoError(t, issues_model.MarkSLABreaches(db.DefaultContext, now))
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.True(t, issue.SLAResponseBreached)
+		assert.True(t, issue.SLAResolveBreached)
+		assert.True(t, issue.IsSLABreached())
+
+		toEscalate, err := issues_model.GetIssuesToEscalate(db.DefaultContext, bugs.ID, now)
+		require.NoError(t, err)
+		assert.Len(t, toEscalate, 1)
+
+		// the resolution is only escalated once the issue has been responded to
+		require.NoError(t, issues_model.SetIssueSLAEscalated(db.DefaultContext, issue))
+		assert.True(t, issue.SLAResponseEscalated)
+		toEscalate, err = issues_model.GetIssuesToEscalate(db.DefaultContext, bugs.ID, now)
+		require.NoError(t, err)
+		assert.Empty(t, toEscalate)
+
+		require.NoError(t, issues_model.SetIssueSLAResponded(db.DefaultContext, issue, now))
+		assert.Equal(t, issue.SLAResolveDeadlineUnix, issue.SLADeadline())
+		toEscalate, err = issues_model.GetIssuesToEscalate(db.DefaultContext, bugs.ID, now)
+		require.NoError(t, err)
+		assert.Len(t, toEscalate, 1)
+
+		// only the first response counts
+		require.NoError(t, issues_model.SetIssueSLAResponded(db.DefaultContext, issue, now+60))
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.Equal(t, now, issue.SLARespondedUnix)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.DeleteSLAPolicy(db.DefaultContext, 1, bugs.ID))
+		_, err := issues_model.GetSLAPolicyByID(db.DefaultContext, bugs.ID)
+		assert.True(t, issues_model.IsErrSLAPolicyNotExist(err))
+		assert.True(t, issues_model.IsErrSLAPolicyNotExist(issues_model.DeleteSLAPolicy(db.DefaultContext, 1, bugs.ID)))
+
+		// the issue keeps its deadlines
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.EqualValues(t, 0, issue.SLAPolicyID)
+		assert.NotZero(t, issue.SLAResolveDeadlineUnix)
+	})
+}
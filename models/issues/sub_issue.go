@@ -0,0 +1,359 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSubIssueExists represents an error where the issue already has a parent.
+type ErrSubIssueExists struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrSubIssueExists checks if an error is a ErrSubIssueExists.
+func IsErrSubIssueExists(err error) bool {
+	_, ok := err.(ErrSubIssueExists)
+	return ok
+}
+
+func (err ErrSubIssueExists) Error() string {
+	return fmt.Sprintf("issue already has a parent [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrSubIssueExists) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrSubIssueNotExists represents an error where the issue is not a sub-issue of the given parent.
+type ErrSubIssueNotExists struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrSubIssueNotExists checks if an error is a ErrSubIssueNotExists.
+func IsErrSubIssueNotExists(err error) bool {
+	_, ok := err.(ErrSubIssueNotExists)
+	return ok
+}
+
+func (err ErrSubIssueNotExists) Error() string {
+	return fmt.Sprintf("sub-issue does not exist [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrSubIssueNotExists) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrCircularSubIssue represents an error where adding a sub-issue would create a cycle in the hierarchy.
+type ErrCircularSubIssue struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("sub-issue would create a circular hierarchy [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSubIssueNotSameOwner represents an error where the parent and the sub-issue belong to repositories of different owners.
+type ErrSubIssueNotSameOwner struct {
+	ParentID int64
+	IssueID  int64
+}
+
+// IsErrSubIssueNotSameOwner checks if an error is a ErrSubIssueNotSameOwner.
+func IsErrSubIssueNotSameOwner(err error) bool {
+	_, ok := err.(ErrSubIssueNotSameOwner)
+	return ok
+}
+
+func (err ErrSubIssueNotSameOwner) Error() string {
+	return fmt.Sprintf("sub-issue and parent must belong to the same owner [parent id: %d, issue id: %d]", err.ParentID, err.IssueID)
+}
+
+func (err ErrSubIssueNotSameOwner) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// SubIssue represents a parent/child relation between two issues. An issue
+// has at most one parent, the parent may live in another repository of the
+// same owner.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL REFERENCES(issue, id)"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL REFERENCES(issue, id)"`
+	UserID      int64              `xorm:"NOT NULL"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// SubIssueProgress holds the completion progress of the sub-issues of an issue.
+type SubIssueProgress struct {
+	Total  int64
+	Closed int64
+}
+
+// Percent returns the percentage of closed sub-issues.
+func (p *SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return int(p.Closed * 100 / p.Total)
+}
+
+// IsDone returns true if there is at least one sub-issue and all of them are closed.
+func (p *SubIssueProgress) IsDone() bool {
+	return p.Total > 0 && p.Closed == p.Total
+}
+
+// AddSubIssue makes issue a sub-issue of parent.
+func AddSubIssue(ctx context.Context, doerID int64, parent, issue *Issue) error {
+	if parent.ID == issue.ID {
+		return ErrCircularSubIssue{parent.ID, issue.ID}
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return ErrSubIssueNotSameOwner{parent.ID, issue.ID}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := getSubIssueByIssueID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrSubIssueExists{existing.ParentID, issue.ID}
+		}
+
+		// Walk up from the new parent, the hierarchy must not already contain issue.
+		ancestors, err := GetIssueAncestorIDs(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ancestors {
+			if id == issue.ID {
+				return ErrCircularSubIssue{parent.ID, issue.ID}
+			}
+		}
+
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("sub_issue").Select("COALESCE(MAX(sorting), 0)").
+			Where("parent_id = ?", parent.ID).Get(&maxSorting); err != nil {
+			return err
+		}
+
+		return db.Insert(ctx, &SubIssue{
+			ParentID: parent.ID,
+			IssueID:  issue.ID,
+			UserID:   doerID,
+			Sorting:  maxSorting + 1,
+		})
+	})
+}
+
+// RemoveSubIssue detaches issue from parent.
+func RemoveSubIssue(ctx context.Context, parent, issue *Issue) error {
+	affected, err := db.GetEngine(ctx).Delete(&SubIssue{ParentID: parent.ID, IssueID: issue.ID})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSubIssueNotExists{parent.ID, issue.ID}
+	}
+	return nil
+}
+
+func getSubIssueByIssueID(ctx context.Context, issueID int64) (*SubIssue, error) {
+	subIssue := &SubIssue{}
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(subIssue)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return subIssue, nil
+}
+
+// GetParentIssueID returns the ID of the parent of the issue, or 0 if it has none.
+func GetParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	subIssue, err := getSubIssueByIssueID(ctx, issueID)
+	if err != nil || subIssue == nil {
+		return 0, err
+	}
+	return subIssue.ParentID, nil
+}
+
+// GetParentIssue returns the parent of the issue, or nil if it has none.
+func (issue *Issue) GetParentIssue(ctx context.Context) (*Issue, error) {
+	parentID, err := GetParentIssueID(ctx, issue.ID)
+	if err != nil || parentID == 0 {
+		return nil, err
+	}
+	parent, err := GetIssueByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return parent, parent.LoadRepo(ctx)
+}
+
+// GetIssueAncestorIDs returns the IDs of the issue and of all its ancestors,
+// starting with the issue itself and ending with the root of the hierarchy.
+func GetIssueAncestorIDs(ctx context.Context, issueID int64) ([]int64, error) {
+	ids := []int64{issueID}
+	for {
+		parentID, err := GetParentIssueID(ctx, ids[len(ids)-1])
+		if err != nil {
+			return nil, err
+		}
+		if parentID == 0 {
+			return ids, nil
+		}
+		for _, id := range ids {
+			if id == parentID {
+				// should not happen as AddSubIssue prevents cycles, but never loop forever
+				return ids, nil
+			}
+		}
+		ids = append(ids, parentID)
+	}
+}
+
+// SubIssues returns the sub-issues of the issue in their configured order, along with their repository.
+func (issue *Issue) SubIssues(ctx context.Context) (subIssues []*DependencyInfo, err error) {
+	err = db.GetEngine(ctx).
+		Table("issue").
+		Join("INNER", "repository", "repository.id = issue.repo_id").
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", issue.ID).
+		OrderBy("sub_issue.sorting, sub_issue.id").
+		Find(&subIssues)
+
+	for _, subIssue := range subIssues {
+		subIssue.Repo = &subIssue.Repository
+	}
+
+	return subIssues, err
+}
+
+// GetSubIssueProgress returns how many sub-issues the issue has and how many of them are closed,
+// counting only the sub-issues in repositories where the doer can read them.
+func GetSubIssueProgress(ctx context.Context, issueID int64, doer *user_model.User) (*SubIssueProgress, error) {
+	progresses, err := GetSubIssueProgresses(ctx, []int64{issueID}, doer)
+	if err != nil {
+		return nil, err
+	}
+	if progress, ok := progresses[issueID]; ok {
+		return progress, nil
+	}
+	return &SubIssueProgress{}, nil
+}
+
+// GetSubIssueProgresses returns the sub-issue progress for each of the given issues
+// which has at least one sub-issue the doer can read.
+func GetSubIssueProgresses(ctx context.Context, issueIDs []int64, doer *user_model.User) (map[int64]*SubIssueProgress, error) {
+	type progressRow struct {
+		ParentID int64
+		IsClosed bool
+		Count    int64
+	}
+	rows := make([]progressRow, 0, len(issueIDs))
+	if len(issueIDs) > 0 {
+		if err := db.GetEngine(ctx).
+			Table("sub_issue").
+			Join("INNER", "issue", "issue.id = sub_issue.issue_id").
+			Where(builder.In("sub_issue.parent_id", issueIDs)).
+			And(readableIssueCond(doer)).
+			Select("sub_issue.parent_id AS parent_id, issue.is_closed AS is_closed, COUNT(*) AS count").
+			GroupBy("sub_issue.parent_id, issue.is_closed").
+			Find(&rows); err != nil {
+			return nil, err
+		}
+	}
+
+	progresses := make(map[int64]*SubIssueProgress, len(rows))
+	for _, row := range rows {
+		progress, ok := progresses[row.ParentID]
+		if !ok {
+			progress = &SubIssueProgress{}
+			progresses[row.ParentID] = progress
+		}
+		progress.Total += row.Count
+		if row.IsClosed {
+			progress.Closed += row.Count
+		}
+	}
+	return progresses, nil
+}
+
+// readableIssueCond returns the condition for the issues and pull requests in repositories
+// where the doer can read them
+func readableIssueCond(doer *user_model.User) builder.Cond {
+	return builder.Or(
+		builder.And(
+			builder.Eq{"issue.is_pull": false},
+			builder.In("issue.repo_id", builder.Select("id").From("repository").Where(repo_model.AccessibleRepositoryCondition(doer, unit.TypeIssues))),
+		),
+		builder.And(
+			builder.Eq{"issue.is_pull": true},
+			builder.In("issue.repo_id", builder.Select("id").From("repository").Where(repo_model.AccessibleRepositoryCondition(doer, unit.TypePullRequests))),
+		),
+	)
+}
+
+// HasOpenSubIssues returns whether any sub-issue of the issue is open, regardless of who can read it.
+func HasOpenSubIssues(ctx context.Context, issueID int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Table("sub_issue").
+		Join("INNER", "issue", "issue.id = sub_issue.issue_id").
+		Where("sub_issue.parent_id = ? AND issue.is_closed = ?", issueID, false).
+		Exist()
+}
+
+// GetSubIssueIDs returns the IDs of the direct sub-issues of the issue.
+func GetSubIssueIDs(ctx context.Context, issueID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("sub_issue").
+		Where("parent_id = ?", issueID).
+		OrderBy("sorting, id").
+		Cols("issue_id").
+		Find(&ids)
+}
+
+// deleteSubIssuesByIssueIDs removes all hierarchy relations which involve the given issues.
+func deleteSubIssuesByIssueIDs(ctx context.Context, issueIDs []int64) error {
+	_, err := db.GetEngine(ctx).
+		Where(builder.Or(builder.In("issue_id", issueIDs), builder.In("parent_id", issueIDs))).
+		Delete(&SubIssue{})
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue 1 and 5 are in repo1, issue 4 and 7 in repo2, all owned by user2
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	closedChild := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	crossRepoChild := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+	grandChild := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, parent, closedChild))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, parent, crossRepoChild))
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, crossRepoChild, grandChild))
+
+	t.Run("AlreadyHasParent", func(t *testing.T) {
+		err := issues_model.AddSubIssue(db.DefaultContext, 2, crossRepoChild, closedChild)
+		assert.True(t, issues_model.IsErrSubIssueExists(err))
+	})
+
+	t.Run("Circular", func(t *testing.T) {
+		err := issues_model.AddSubIssue(db.DefaultContext, 2, parent, parent)
+		assert.True(t, issues_model.IsErrCircularSubIssue(err))
+
+		err = issues_model.AddSubIssue(db.DefaultContext, 2, grandChild, parent)
+		assert.True(t, issues_model.IsErrCircularSubIssue(err))
+	})
+
+	t.Run("NotSameOwner", func(t *testing.T) {
+		// issue 6 is in repo3, owned by org3
+		otherOwner := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+		err := issues_model.AddSubIssue(db.DefaultContext, 2, parent, otherOwner)
+		assert.True(t, issues_model.IsErrSubIssueNotSameOwner(err))
+	})
+
+	t.Run("Hierarchy", func(t *testing.T) {
+		subIssues, err := parent.SubIssues(db.DefaultContext)
+		require.NoError(t, err)
+		if assert.Len(t, subIssues, 2) {
+			assert.EqualValues(t, 5, subIssues[0].ID)
+			assert.EqualValues(t, 7, subIssues[1].ID)
+			assert.EqualValues(t, 2, subIssues[1].Repo.ID)
+		}
+
+		ids, err := issues_model.GetSubIssueIDs(db.DefaultContext, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{5, 7}, ids)
+
+		ancestors, err := issues_model.GetIssueAncestorIDs(db.DefaultContext, grandChild.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{4, 7, 1}, ancestors)
+
+		p, err := grandChild.GetParentIssue(db.DefaultContext)
+		require.NoError(t, err)
+		assert.EqualValues(t, 7, p.ID)
+
+		p, err = parent.GetParentIssue(db.DefaultContext)
+		require.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		progress, err := issues_model.GetSubIssueProgress(db.DefaultContext, parent.ID, owner)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, progress.Total)
+		assert.EqualValues(t, 1, progress.Closed)
+		assert.Equal(t, 50, progress.Percent())
+		assert.False(t, progress.IsDone())
+
+		progress, err = issues_model.GetSubIssueProgress(db.DefaultContext, crossRepoChild.ID, owner)
+		require.NoError(t, err)
+		assert.True(t, progress.IsDone())
+
+		progress, err = issues_model.GetSubIssueProgress(db.DefaultContext, grandChild.ID, owner)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, progress.Total)
+		assert.False(t, progress.IsDone())
+
+		// repo2 is private, anonymous users only see the closed sub-issue in repo1
+		progress, err = issues_model.GetSubIssueProgress(db.DefaultContext, parent.ID, nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, progress.Total)
+		assert.True(t, progress.IsDone())
+
+		hasOpen, err := issues_model.HasOpenSubIssues(db.DefaultContext, parent.ID)
+		require.NoError(t, err)
+		assert.True(t, hasOpen)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, parent, closedChild))
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: closedChild.ID})
+
+		err := issues_model.RemoveSubIssue(db.DefaultContext, parent, closedChild)
+		assert.True(t, issues_model.IsErrSubIssueNotExists(err))
+	})
+}
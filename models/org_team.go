@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"strings"
 
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
+	packages_model "forgejo.org/models/packages"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
@@ -336,6 +338,9 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		&organization.TeamUnit{TeamID: t.ID},
 		&organization.TeamInvite{TeamID: t.ID},
 		&issues_model.Review{Type: issues_model.ReviewTypeRequest, ReviewerTeamID: t.ID}, // batch delete the binding relationship between team and PR (request review from team)
+		&issues_model.SavedSearch{TeamID: t.ID},
+		&packages_model.PackageAccess{TeamID: t.ID},
+		&auth_model.SCIMExternalID{ResourceType: auth_model.SCIMResourceGroup, ResourceID: t.ID},
 	); err != nil {
 		return err
 	}
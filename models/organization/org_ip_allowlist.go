@@ -0,0 +1,84 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(IPAllowlist))
+}
+
+// IPAllowlist restricts the access to the resources of an organization to clients from a list of IP networks
+type IPAllowlist struct {
+	ID    int64 `xorm:"pk autoincr"`
+	OrgID int64 `xorm:"UNIQUE NOT NULL"`
+	// AllowedIPs is the normalized list of networks in CIDR notation, separated by commas
+	AllowedIPs  string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the IP allowlist
+func (IPAllowlist) TableName() string {
+	return "org_ip_allowlist"
+}
+
+// AllowsRemoteHost returns whether a client with the given address may access the resources of the organization
+func (a *IPAllowlist) AllowsRemoteHost(host string) bool {
+	hl, err := hostmatcher.ParseIPNetList("", a.AllowedIPs)
+	if err != nil {
+		// the list is validated before it is stored
+		log.Error("Invalid IP allowlist of organization %d: %v", a.OrgID, err)
+		return false
+	}
+	return hl.MatchHostName(host)
+}
+
+// GetIPAllowlist returns the IP allowlist of an organization, it returns nil if there is none
+func GetIPAllowlist(ctx context.Context, orgID int64) (*IPAllowlist, error) {
+	a, has, err := db.Get[IPAllowlist](ctx, builder.Eq{"org_id": orgID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// GetIPAllowlists returns the IP allowlists of all organizations
+func GetIPAllowlists(ctx context.Context) ([]*IPAllowlist, error) {
+	return db.Find[IPAllowlist](ctx, db.ListOptionsAll)
+}
+
+// SetIPAllowlist replaces the IP allowlist of an organization, the networks must have been normalized
+// by hostmatcher.ParseIPNetList
+func SetIPAllowlist(ctx context.Context, orgID int64, allowedIPs string) (*IPAllowlist, error) {
+	a, err := GetIPAllowlist(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if a != nil {
+		a.AllowedIPs = allowedIPs
+		_, err = db.GetEngine(ctx).ID(a.ID).Cols("allowed_ips").Update(a)
+		return a, err
+	}
+
+	a = &IPAllowlist{OrgID: orgID, AllowedIPs: allowedIPs}
+	return a, db.Insert(ctx, a)
+}
+
+// RemoveIPAllowlist no longer restricts the access to the resources of an organization
+func RemoveIPAllowlist(ctx context.Context, orgID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"org_id": orgID}).Delete(new(IPAllowlist))
+	return err
+}
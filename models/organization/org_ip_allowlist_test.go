@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	a, err := organization.GetIPAllowlist(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	a, err = organization.SetIPAllowlist(db.DefaultContext, 3, "10.0.0.0/8")
+	require.NoError(t, err)
+	assert.True(t, a.AllowsRemoteHost("10.1.2.3:4567"))
+	assert.False(t, a.AllowsRemoteHost("192.0.2.1:4567"))
+
+	// the networks of the existing allowlist are replaced
+	_, err = organization.SetIPAllowlist(db.DefaultContext, 3, "192.0.2.0/24, 2001:db8::/32")
+	require.NoError(t, err)
+	a, err = organization.GetIPAllowlist(db.DefaultContext, 3)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.False(t, a.AllowsRemoteHost("10.1.2.3:4567"))
+	assert.True(t, a.AllowsRemoteHost("192.0.2.1:4567"))
+	assert.True(t, a.AllowsRemoteHost("[2001:db8::1]:22"))
+	unittest.AssertCount(t, &organization.IPAllowlist{}, 1)
+
+	require.NoError(t, organization.RemoveIPAllowlist(db.DefaultContext, 3))
+	a, err = organization.GetIPAllowlist(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+}
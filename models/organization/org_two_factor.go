@@ -0,0 +1,142 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/cache"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(TwoFactorRequirement))
+}
+
+// TwoFactorRequirement requires the members and outside collaborators of an organization to enable two-factor authentication.
+// Users who have not enabled it when the grace period ends lose their access to the resources of the organization.
+type TwoFactorRequirement struct {
+	ID           int64              `xorm:"pk autoincr"`
+	OrgID        int64              `xorm:"UNIQUE NOT NULL"`
+	DeadlineUnix timeutil.TimeStamp `xorm:"NOT NULL"`
+	// RemindedUnix is when the users were reminded that the grace period is about to end, zero if they were not yet
+	RemindedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+// IsEnforced returns whether the grace period has ended
+func (r *TwoFactorRequirement) IsEnforced() bool {
+	return timeutil.TimeStampNow() >= r.DeadlineUnix
+}
+
+// GetTwoFactorRequirement returns the two-factor requirement of an organization, it returns nil if there is none
+func GetTwoFactorRequirement(ctx context.Context, orgID int64) (*TwoFactorRequirement, error) {
+	r, has, err := db.Get[TwoFactorRequirement](ctx, builder.Eq{"org_id": orgID})
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// SetTwoFactorRequirement requires two-factor authentication from the given deadline on,
+// the deadline of an existing requirement is replaced. The users are reminded again before the new deadline,
+// unless reminded is set because they were just notified.
+func SetTwoFactorRequirement(ctx context.Context, orgID int64, deadline, reminded timeutil.TimeStamp) (*TwoFactorRequirement, error) {
+	r, err := GetTwoFactorRequirement(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		r.DeadlineUnix = deadline
+		r.RemindedUnix = reminded
+		_, err = db.GetEngine(ctx).ID(r.ID).Cols("deadline_unix", "reminded_unix").Update(r)
+		return r, err
+	}
+
+	r = &TwoFactorRequirement{OrgID: orgID, DeadlineUnix: deadline, RemindedUnix: reminded}
+	return r, db.Insert(ctx, r)
+}
+
+// FindTwoFactorRequirementsToRemind returns the requirements whose grace period ends before the given time
+// and whose users have not been reminded yet
+func FindTwoFactorRequirementsToRemind(ctx context.Context, before timeutil.TimeStamp) ([]*TwoFactorRequirement, error) {
+	requirements := make([]*TwoFactorRequirement, 0, 10)
+	return requirements, db.GetEngine(ctx).
+		Where(builder.Eq{"reminded_unix": 0}).
+		And(builder.Gt{"deadline_unix": timeutil.TimeStampNow()}).
+		And(builder.Lte{"deadline_unix": before}).
+		Find(&requirements)
+}
+
+// SetTwoFactorRequirementReminded records that the users have been reminded that the grace period is about to end
+func SetTwoFactorRequirementReminded(ctx context.Context, r *TwoFactorRequirement) error {
+	r.RemindedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("reminded_unix").Update(r)
+	return err
+}
+
+// IsBlockedByTwoFactorRequirement returns whether the organization requires two-factor authentication,
+// its grace period has ended and the user has not enabled it. The callers only apply it to the access the user
+// has as a member or collaborator. Site administrators and the Actions user are never blocked, their access
+// does not depend on the organization. The result is cached for the duration of the request.
+func IsBlockedByTwoFactorRequirement(ctx context.Context, orgID int64, user *user_model.User) (bool, error) {
+	if user == nil || user.IsAdmin || user.IsActions() || !user.IsIndividual() {
+		return false, nil
+	}
+
+	return cache.GetWithContextCache(ctx, "org_two_factor_blocked", fmt.Sprintf("%d_%d", orgID, user.ID), func() (bool, error) {
+		requirement, err := GetTwoFactorRequirement(ctx, orgID)
+		if err != nil || requirement == nil || !requirement.IsEnforced() {
+			return false, err
+		}
+
+		hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, user.ID)
+		if err != nil {
+			return false, err
+		}
+		return !hasTwoFactor, nil
+	})
+}
+
+// RemoveTwoFactorRequirement no longer requires two-factor authentication in an organization
+func RemoveTwoFactorRequirement(ctx context.Context, orgID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"org_id": orgID}).Delete(new(TwoFactorRequirement))
+	return err
+}
+
+// usersWithoutTwoFactor returns the individual users matching the condition who have neither TOTP nor a security key
+func usersWithoutTwoFactor(ctx context.Context, cond builder.Cond) ([]*user_model.User, error) {
+	users := make([]*user_model.User, 0, 10)
+	return users, db.GetEngine(ctx).
+		Where(cond).
+		And(builder.Eq{"`user`.type": user_model.UserTypeIndividual}).
+		And(builder.NotIn("`user`.id", builder.Select("uid").From("two_factor"))).
+		And(builder.NotIn("`user`.id", builder.Select("user_id").From("webauthn_credential"))).
+		OrderBy("`user`.name").
+		Find(&users)
+}
+
+// GetMembersWithoutTwoFactor returns the members of an organization who have not enabled two-factor authentication
+func GetMembersWithoutTwoFactor(ctx context.Context, orgID int64) ([]*user_model.User, error) {
+	return usersWithoutTwoFactor(ctx, builder.In("`user`.id", builder.Select("uid").From("org_user").Where(builder.Eq{"org_id": orgID})))
+}
+
+// GetOutsideCollaboratorsWithoutTwoFactor returns the collaborators on repositories of an organization
+// who are not members and have not enabled two-factor authentication
+func GetOutsideCollaboratorsWithoutTwoFactor(ctx context.Context, orgID int64) ([]*user_model.User, error) {
+	return usersWithoutTwoFactor(ctx, builder.And(
+		builder.In("`user`.id", builder.Select("collaboration.user_id").From("collaboration").
+			Join("INNER", "repository", "repository.id = collaboration.repo_id").
+			Where(builder.Eq{"repository.owner_id": orgID})),
+		builder.NotIn("`user`.id", builder.Select("uid").From("org_user").Where(builder.Eq{"org_id": orgID})),
+	))
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization_test
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorRequirement(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	r, err := organization.GetTwoFactorRequirement(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+
+	r, err = organization.SetTwoFactorRequirement(db.DefaultContext, 3, timeutil.TimeStampNow().Add(3600), 0)
+	require.NoError(t, err)
+	assert.False(t, r.IsEnforced())
+
+	// the deadline of the existing requirement is replaced
+	_, err = organization.SetTwoFactorRequirement(db.DefaultContext, 3, timeutil.TimeStampNow(), 0)
+	require.NoError(t, err)
+	r, err = organization.GetTwoFactorRequirement(db.DefaultContext, 3)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	assert.True(t, r.IsEnforced())
+	unittest.AssertCount(t, &organization.TwoFactorRequirement{}, 1)
+
+	require.NoError(t, organization.RemoveTwoFactorRequirement(db.DefaultContext, 3))
+	r, err = organization.GetTwoFactorRequirement(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestUsersWithoutTwoFactor(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	names := func(users []*user_model.User) []string {
+		names := make([]string, 0, len(users))
+		for _, u := range users {
+			names = append(names, u.Name)
+		}
+		return names
+	}
+
+	members, err := organization.GetMembersWithoutTwoFactor(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2", "user28", "user4"}, names(members))
+
+	// a passkey satisfies the requirement like TOTP or a security key
+	_, err = auth_model.CreatePasskey(db.DefaultContext, 4, "Passkey", &webauthn.Credential{ID: []byte("Passkey")})
+	require.NoError(t, err)
+	members, err = organization.GetMembersWithoutTwoFactor(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2", "user28"}, names(members))
+
+	// user24 has enabled TOTP, user5 has not, user2 is a member
+	for _, uid := range []int64{2, 5, 24} {
+		require.NoError(t, db.Insert(db.DefaultContext, &repo_model.Collaboration{RepoID: 5, UserID: uid}))
+	}
+	collaborators, err := organization.GetOutsideCollaboratorsWithoutTwoFactor(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user5"}, names(collaborators))
+}
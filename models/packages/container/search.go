@@ -26,6 +26,7 @@ type BlobSearchOptions struct {
 	Tag        string
 	IsManifest bool
 	Repository string
+	Subject    string
 }
 
 func (opts *BlobSearchOptions) toConds() builder.Cond {
@@ -54,6 +55,15 @@ func (opts *BlobSearchOptions) toConds() builder.Cond {
 
 		cond = cond.And(builder.In("package_file.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
 	}
+	if opts.Subject != "" {
+		var propsCond builder.Cond = builder.Eq{
+			"package_property.ref_type": packages.PropertyTypeVersion,
+			"package_property.name":     container_module.PropertyManifestSubject,
+			"package_property.value":    opts.Subject,
+		}
+
+		cond = cond.And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
+	}
 	if opts.Repository != "" {
 		var propsCond builder.Cond = builder.Eq{
 			"package_property.ref_type": packages.PropertyTypePackage,
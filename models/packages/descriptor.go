@@ -23,6 +23,7 @@ import (
 	"forgejo.org/modules/packages/cran"
 	"forgejo.org/modules/packages/debian"
 	"forgejo.org/modules/packages/helm"
+	"forgejo.org/modules/packages/hex"
 	"forgejo.org/modules/packages/maven"
 	"forgejo.org/modules/packages/npm"
 	"forgejo.org/modules/packages/nuget"
@@ -31,6 +32,7 @@ import (
 	"forgejo.org/modules/packages/rpm"
 	"forgejo.org/modules/packages/rubygems"
 	"forgejo.org/modules/packages/swift"
+	"forgejo.org/modules/packages/terraform"
 	"forgejo.org/modules/packages/vagrant"
 	"forgejo.org/modules/util"
 
@@ -117,7 +119,7 @@ func GetPackageDescriptor(ctx context.Context, pv *PackageVersion) (*PackageDesc
 			return nil, err
 		}
 	}
-	creator, err := user_model.GetUserByID(ctx, pv.CreatorID)
+	creator, err := user_model.GetPossibleUserByID(ctx, pv.CreatorID)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			creator = user_model.NewGhostUser()
@@ -178,6 +180,8 @@ func GetPackageDescriptor(ctx context.Context, pv *PackageVersion) (*PackageDesc
 		// go packages have no metadata
 	case TypeHelm:
 		metadata = &helm.Metadata{}
+	case TypeHex:
+		metadata = &hex.Metadata{}
 	case TypeNuGet:
 		metadata = &nuget.Metadata{}
 	case TypeNpm:
@@ -196,6 +200,8 @@ func GetPackageDescriptor(ctx context.Context, pv *PackageVersion) (*PackageDesc
 		metadata = &rubygems.Metadata{}
 	case TypeSwift:
 		metadata = &swift.Metadata{}
+	case TypeTerraform:
+		metadata = &terraform.Metadata{}
 	case TypeVagrant:
 		metadata = &vagrant.Metadata{}
 	default:
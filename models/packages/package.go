@@ -45,6 +45,7 @@ const (
 	TypeGeneric   Type = "generic"
 	TypeGo        Type = "go"
 	TypeHelm      Type = "helm"
+	TypeHex       Type = "hex"
 	TypeMaven     Type = "maven"
 	TypeNpm       Type = "npm"
 	TypeNuGet     Type = "nuget"
@@ -54,6 +55,7 @@ const (
 	TypeAlt       Type = "alt"
 	TypeRubyGems  Type = "rubygems"
 	TypeSwift     Type = "swift"
+	TypeTerraform Type = "terraform"
 	TypeVagrant   Type = "vagrant"
 )
 
@@ -71,6 +73,7 @@ var TypeList = []Type{
 	TypeGeneric,
 	TypeGo,
 	TypeHelm,
+	TypeHex,
 	TypeMaven,
 	TypeNpm,
 	TypeNuGet,
@@ -80,6 +83,7 @@ var TypeList = []Type{
 	TypeAlt,
 	TypeRubyGems,
 	TypeSwift,
+	TypeTerraform,
 	TypeVagrant,
 }
 
@@ -112,6 +116,8 @@ func (pt Type) Name() string {
 		return "Go"
 	case TypeHelm:
 		return "Helm"
+	case TypeHex:
+		return "Hex"
 	case TypeMaven:
 		return "Maven"
 	case TypeNpm:
@@ -130,6 +136,8 @@ func (pt Type) Name() string {
 		return "RubyGems"
 	case TypeSwift:
 		return "Swift"
+	case TypeTerraform:
+		return "Terraform"
 	case TypeVagrant:
 		return "Vagrant"
 	}
@@ -165,6 +173,8 @@ func (pt Type) SVGName() string {
 		return "gitea-go"
 	case TypeHelm:
 		return "gitea-helm"
+	case TypeHex:
+		return "gitea-hex"
 	case TypeMaven:
 		return "gitea-maven"
 	case TypeNpm:
@@ -183,6 +193,8 @@ func (pt Type) SVGName() string {
 		return "gitea-rubygems"
 	case TypeSwift:
 		return "gitea-swift"
+	case TypeTerraform:
+		return "gitea-terraform"
 	case TypeVagrant:
 		return "gitea-vagrant"
 	}
@@ -313,6 +325,15 @@ func GetPackagesByType(ctx context.Context, ownerID int64, packageType Type) ([]
 		Find(&ps)
 }
 
+// GetAllPackagesByType gets the packages of a specific type of all owners
+func GetAllPackagesByType(ctx context.Context, packageType Type) ([]*Package, error) {
+	ps := make([]*Package, 0, 10)
+	return ps, db.GetEngine(ctx).
+		Where(builder.Eq{"package.type": packageType}).
+		OrderBy("package.id").
+		Find(&ps)
+}
+
 // FindUnreferencedPackages gets all packages without associated versions
 func FindUnreferencedPackages(ctx context.Context) ([]int64, error) {
 	var pIDs []int64
@@ -0,0 +1,135 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageAccessNotExist = util.NewNotExistErrorf("package access does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageAccess))
+}
+
+// PackageAccess grants a team or the Actions tokens of a repository access to a single package of an owner.
+// The package is referenced by type and name because the access can be granted before the package is published.
+type PackageAccess struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type        Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	LowerName   string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	TeamID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	AccessMode  perm.AccessMode    `xorm:"NOT NULL DEFAULT 1"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+}
+
+// SetAccess inserts the access or updates the access mode of an existing one
+func SetAccess(ctx context.Context, pa *PackageAccess) (*PackageAccess, error) {
+	pa.LowerName = ResolvePackageName(pa.LowerName, pa.Type)
+
+	existing := &PackageAccess{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{
+		"owner_id":   pa.OwnerID,
+		"type":       pa.Type,
+		"lower_name": pa.LowerName,
+		"team_id":    pa.TeamID,
+		"repo_id":    pa.RepoID,
+	}).Get(existing)
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		existing.AccessMode = pa.AccessMode
+		_, err := db.GetEngine(ctx).ID(existing.ID).Cols("access_mode").Update(existing)
+		return existing, err
+	}
+	return pa, db.Insert(ctx, pa)
+}
+
+// GetAccessByID gets an access of the owner by id
+func GetAccessByID(ctx context.Context, ownerID, id int64) (*PackageAccess, error) {
+	pa := &PackageAccess{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ?", id, ownerID).Get(pa)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageAccessNotExist
+	}
+	return pa, nil
+}
+
+// DeleteAccessByID deletes an access
+func DeleteAccessByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&PackageAccess{})
+	return err
+}
+
+// GetAccessesByOwner gets all accesses granted by the owner
+func GetAccessesByOwner(ctx context.Context, ownerID int64) ([]*PackageAccess, error) {
+	pas := make([]*PackageAccess, 0, 10)
+	return pas, db.GetEngine(ctx).
+		Where("owner_id = ?", ownerID).
+		OrderBy("type, lower_name, id").
+		Find(&pas)
+}
+
+// GetAccessesByPackage gets the accesses granted to a package
+func GetAccessesByPackage(ctx context.Context, ownerID int64, packageType Type, name string) ([]*PackageAccess, error) {
+	pas := make([]*PackageAccess, 0, 10)
+	return pas, db.GetEngine(ctx).
+		Where(builder.Eq{
+			"owner_id":   ownerID,
+			"type":       packageType,
+			"lower_name": ResolvePackageName(name, packageType),
+		}).
+		OrderBy("id").
+		Find(&pas)
+}
+
+// GetGrantedAccesses gets the accesses of the owner granted to any of the teams or to the repository
+func GetGrantedAccesses(ctx context.Context, ownerID int64, teamIDs []int64, repoID int64) ([]*PackageAccess, error) {
+	cond := builder.NewCond()
+	if len(teamIDs) > 0 {
+		cond = cond.Or(builder.In("team_id", teamIDs))
+	}
+	if repoID > 0 {
+		cond = cond.Or(builder.Eq{"repo_id": repoID})
+	}
+	if !cond.IsValid() {
+		return nil, nil
+	}
+
+	pas := make([]*PackageAccess, 0, 10)
+	return pas, db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID}.And(cond)).
+		Find(&pas)
+}
+
+// DeleteAccessesByTeamID deletes all accesses granted to a team
+func DeleteAccessesByTeamID(ctx context.Context, teamID int64) error {
+	_, err := db.GetEngine(ctx).Where("team_id = ?", teamID).Delete(&PackageAccess{})
+	return err
+}
+
+// DeleteAccessesByRepoID deletes all accesses granted to a repository
+func DeleteAccessesByRepoID(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&PackageAccess{})
+	return err
+}
+
+// DeleteAccessesByOwnerID deletes all accesses granted by an owner
+func DeleteAccessesByOwnerID(ctx context.Context, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id = ?", ownerID).Delete(&PackageAccess{})
+	return err
+}
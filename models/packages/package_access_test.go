@@ -0,0 +1,88 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAccess(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := db.DefaultContext
+
+	pa, err := packages_model.SetAccess(ctx, &packages_model.PackageAccess{
+		OwnerID:    3,
+		Type:       packages_model.TypeNpm,
+		LowerName:  "@Scope/Package",
+		TeamID:     2,
+		AccessMode: perm.AccessModeRead,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "@scope/package", pa.LowerName)
+
+	// Setting the access again updates the access mode
+	updated, err := packages_model.SetAccess(ctx, &packages_model.PackageAccess{
+		OwnerID:    3,
+		Type:       packages_model.TypeNpm,
+		LowerName:  "@scope/package",
+		TeamID:     2,
+		AccessMode: perm.AccessModeWrite,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pa.ID, updated.ID)
+
+	_, err = packages_model.SetAccess(ctx, &packages_model.PackageAccess{
+		OwnerID:    3,
+		Type:       packages_model.TypeGeneric,
+		LowerName:  "generic",
+		RepoID:     3,
+		AccessMode: perm.AccessModeWrite,
+	})
+	require.NoError(t, err)
+
+	pas, err := packages_model.GetAccessesByOwner(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, pas, 2)
+
+	pas, err = packages_model.GetAccessesByPackage(ctx, 3, packages_model.TypeNpm, "@SCOPE/package")
+	require.NoError(t, err)
+	require.Len(t, pas, 1)
+	assert.Equal(t, perm.AccessModeWrite, pas[0].AccessMode)
+
+	pas, err = packages_model.GetGrantedAccesses(ctx, 3, []int64{2, 5}, 0)
+	require.NoError(t, err)
+	require.Len(t, pas, 1)
+	assert.Equal(t, packages_model.TypeNpm, pas[0].Type)
+
+	pas, err = packages_model.GetGrantedAccesses(ctx, 3, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, pas, 1)
+	assert.Equal(t, packages_model.TypeGeneric, pas[0].Type)
+
+	pas, err = packages_model.GetGrantedAccesses(ctx, 3, nil, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pas)
+
+	_, err = packages_model.GetAccessByID(ctx, 2, pa.ID)
+	require.ErrorIs(t, err, packages_model.ErrPackageAccessNotExist)
+
+	require.NoError(t, packages_model.DeleteAccessesByTeamID(ctx, 2))
+	pas, err = packages_model.GetAccessesByOwner(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, pas, 1)
+
+	require.NoError(t, packages_model.DeleteAccessesByOwnerID(ctx, 3))
+	pas, err = packages_model.GetAccessesByOwner(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, pas)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrPackageAttestationNotExist indicates a package attestation not exist error
+var ErrPackageAttestationNotExist = util.NewNotExistErrorf("package attestation does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageAttestation))
+}
+
+// AttestationType is the kind of a package attestation
+type AttestationType string
+
+const (
+	AttestationTypeGPG        AttestationType = "gpg"
+	AttestationTypeSSH        AttestationType = "ssh"
+	AttestationTypeSigstore   AttestationType = "sigstore"
+	AttestationTypeProvenance AttestationType = "provenance"
+)
+
+// IsSignature checks if the attestation is a detached signature of a file
+func (t AttestationType) IsSignature() bool {
+	return t == AttestationTypeGPG || t == AttestationTypeSSH || t == AttestationTypeSigstore
+}
+
+// PackageAttestation is a detached signature of a package file or a provenance statement of a package version
+type PackageAttestation struct {
+	ID        int64 `xorm:"pk autoincr"`
+	VersionID int64 `xorm:"INDEX NOT NULL"`
+	// FileID is the signed file, or the first file named by the subjects of a provenance statement
+	FileID  int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Type    AttestationType `xorm:"VARCHAR(20) NOT NULL"`
+	Content string          `xorm:"LONGTEXT NOT NULL"`
+	// Verified is set if the signature was made by a known key or the provenance was generated from a verified build
+	Verified bool `xorm:"NOT NULL DEFAULT false"`
+	// KeyID is the id or fingerprint of the key which made the signature
+	KeyID string `xorm:"VARCHAR(255)"`
+	// SignerID is the user the signing key belongs to
+	SignerID int64 `xorm:"NOT NULL DEFAULT 0"`
+	// BuilderID is the builder named by a provenance statement
+	BuilderID string `xorm:"TEXT"`
+	// RunID is the Actions run which built the package
+	RunID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// InsertAttestation inserts an attestation
+func InsertAttestation(ctx context.Context, pa *PackageAttestation) error {
+	_, err := db.GetEngine(ctx).Insert(pa)
+	return err
+}
+
+// GetAttestationByID gets an attestation of a package version
+func GetAttestationByID(ctx context.Context, versionID, attestationID int64) (*PackageAttestation, error) {
+	pa := &PackageAttestation{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND version_id = ?", attestationID, versionID).Get(pa)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageAttestationNotExist
+	}
+	return pa, nil
+}
+
+// GetAttestationsByVersionID gets all attestations of a package version
+func GetAttestationsByVersionID(ctx context.Context, versionID int64) ([]*PackageAttestation, error) {
+	pas := make([]*PackageAttestation, 0, 5)
+	return pas, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("id").Find(&pas)
+}
+
+// HasVerifiedSignature checks if a file has a signature made by a known key
+func HasVerifiedSignature(ctx context.Context, fileID int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("file_id = ? AND verified = ?", fileID, true).
+		In("type", AttestationTypeGPG, AttestationTypeSSH, AttestationTypeSigstore).
+		Exist(&PackageAttestation{})
+}
+
+// DeleteAttestationByID deletes an attestation
+func DeleteAttestationByID(ctx context.Context, attestationID int64) error {
+	_, err := db.GetEngine(ctx).ID(attestationID).NoAutoCondition().Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByVersionID deletes all attestations of a package version
+func DeleteAttestationsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageAttestation{})
+	return err
+}
+
+// DeleteAttestationsByFileID deletes all signatures of a package file
+func DeleteAttestationsByFileID(ctx context.Context, fileID int64) error {
+	_, err := db.GetEngine(ctx).Where("file_id = ?", fileID).Delete(&PackageAttestation{})
+	return err
+}
@@ -100,6 +100,11 @@ func FindExpiredUnreferencedBlobs(ctx context.Context, olderThan time.Duration)
 		Find(&pbs)
 }
 
+// CountBlobReferences counts the package files referencing the blob
+func CountBlobReferences(ctx context.Context, blobID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("blob_id = ?", blobID).Count(&PackageFile{})
+}
+
 // DeleteBlobByID deletes a blob by id
 func DeleteBlobByID(ctx context.Context, blobID int64) error {
 	_, err := db.GetEngine(ctx).ID(blobID).Delete(&PackageBlob{})
@@ -31,9 +31,11 @@ type PackageCleanupRule struct {
 	KeepPattern          string             `xorm:"NOT NULL DEFAULT ''"`
 	KeepPatternMatcher   *regexp.Regexp     `xorm:"-"`
 	RemoveDays           int                `xorm:"NOT NULL DEFAULT 0"`
+	RemoveUnusedDays     int                `xorm:"NOT NULL DEFAULT 0"` // remove versions which were not downloaded for this many days
 	RemovePattern        string             `xorm:"NOT NULL DEFAULT ''"`
 	RemovePatternMatcher *regexp.Regexp     `xorm:"-"`
 	MatchFullName        bool               `xorm:"NOT NULL DEFAULT false"`
+	UpstreamOnly         bool               `xorm:"NOT NULL DEFAULT false"` // only remove versions cached from an upstream remote
 	CreatedUnix          timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
 	UpdatedUnix          timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
 }
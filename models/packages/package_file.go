@@ -32,14 +32,15 @@ const EmptyFileKey = ""
 
 // PackageFile represents a package file
 type PackageFile struct {
-	ID           int64              `xorm:"pk autoincr"`
-	VersionID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	BlobID       int64              `xorm:"INDEX NOT NULL"`
-	Name         string             `xorm:"NOT NULL"`
-	LowerName    string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	CompositeKey string             `xorm:"UNIQUE(s) INDEX"`
-	IsLead       bool               `xorm:"NOT NULL DEFAULT false"`
-	CreatedUnix  timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	ID               int64              `xorm:"pk autoincr"`
+	VersionID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	BlobID           int64              `xorm:"INDEX NOT NULL"`
+	Name             string             `xorm:"NOT NULL"`
+	LowerName        string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CompositeKey     string             `xorm:"UNIQUE(s) INDEX"`
+	IsLead           bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	LastDownloadUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
 }
 
 // TryInsertFile inserts a file. If the file exists already ErrDuplicatePackageFile is returned
@@ -120,6 +121,23 @@ func DeleteFileByID(ctx context.Context, fileID int64) error {
 	return err
 }
 
+// UpdateFileLastDownload sets the last download time of a file to now
+func UpdateFileLastDownload(ctx context.Context, fileID int64) error {
+	_, err := db.GetEngine(ctx).ID(fileID).Cols("last_download_unix").NoAutoTime().Update(&PackageFile{LastDownloadUnix: timeutil.TimeStampNow()})
+	return err
+}
+
+// GetVersionLastDownload gets the most recent download time of all files of a version
+func GetVersionLastDownload(ctx context.Context, versionID int64) (timeutil.TimeStamp, error) {
+	var last int64
+	_, err := db.GetEngine(ctx).
+		Table("package_file").
+		Select("COALESCE(MAX(last_download_unix), 0)").
+		Where("version_id = ?", versionID).
+		Get(&last)
+	return timeutil.TimeStamp(last), err
+}
+
 // PackageFileSearchOptions are options for SearchXXX methods
 type PackageFileSearchOptions struct {
 	OwnerID       int64
@@ -0,0 +1,207 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/keying"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+var (
+	// ErrPackageRemoteNotExist indicates a package remote not exist error
+	ErrPackageRemoteNotExist = util.NewNotExistErrorf("package remote does not exist")
+	// ErrDuplicatePackageRemote indicates a duplicated package remote error
+	ErrDuplicatePackageRemote = util.NewAlreadyExistErrorf("package remote already exists")
+)
+
+// DefaultRemoteMetadataTTL is how long the metadata fetched from an upstream remote is cached by default
+const DefaultRemoteMetadataTTL = time.Hour
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+	db.RegisterModel(new(PackageRemoteMetadata))
+}
+
+// RemoteTypeList are the package types which can be proxied from an upstream registry
+var RemoteTypeList = []Type{
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// SupportsRemote checks if the packages of the type can be proxied from an upstream registry
+func (pt Type) SupportsRemote() bool {
+	return slices.Contains(RemoteTypeList, pt)
+}
+
+// PackageRemote is an upstream registry the packages of a type are fetched from when they
+// are missing, and cached as package versions of the owner
+type PackageRemote struct {
+	ID       int64  `xorm:"pk autoincr"`
+	OwnerID  int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type     Type   `xorm:"UNIQUE(s) NOT NULL"`
+	URL      string `xorm:"TEXT NOT NULL"`
+	Username string `xorm:"VARCHAR(255)"`
+	// PasswordEncrypted should be accessed using Password() and SetPassword()
+	PasswordEncrypted []byte `xorm:"BLOB"`
+	// MetadataTTL is how long the package indexes fetched from the remote are served before being fetched again
+	MetadataTTL int64              `xorm:"'metadata_ttl' NOT NULL DEFAULT 3600"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// Password returns the decrypted password of the remote
+func (r *PackageRemote) Password() (string, error) {
+	if len(r.PasswordEncrypted) == 0 {
+		return "", nil
+	}
+	password, err := keying.PackageRemote.Decrypt(r.PasswordEncrypted, keying.ColumnAndID("password_encrypted", r.ID))
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// SetPassword encrypts and sets the password of the remote, it must have been inserted already
+func (r *PackageRemote) SetPassword(password string) {
+	if password == "" {
+		r.PasswordEncrypted = nil
+		return
+	}
+	r.PasswordEncrypted = keying.PackageRemote.Encrypt([]byte(password), keying.ColumnAndID("password_encrypted", r.ID))
+}
+
+// TTL returns how long the metadata fetched from the remote is fresh
+func (r *PackageRemote) TTL() time.Duration {
+	if r.MetadataTTL <= 0 {
+		return DefaultRemoteMetadataTTL
+	}
+	return time.Duration(r.MetadataTTL) * time.Second
+}
+
+// InsertRemote inserts a remote, the password being encrypted with the ID of the remote
+func InsertRemote(ctx context.Context, r *PackageRemote, password string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ?", r.OwnerID, r.Type).Exist(&PackageRemote{})
+		if err != nil {
+			return err
+		}
+		if has {
+			return ErrDuplicatePackageRemote
+		}
+		if err := db.Insert(ctx, r); err != nil {
+			return err
+		}
+		if password == "" {
+			return nil
+		}
+		r.SetPassword(password)
+		_, err = db.GetEngine(ctx).ID(r.ID).Cols("password_encrypted").Update(r)
+		return err
+	})
+}
+
+// UpdateRemote updates a remote
+func UpdateRemote(ctx context.Context, r *PackageRemote) error {
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("url", "username", "password_encrypted", "metadata_ttl").Update(r)
+	return err
+}
+
+// GetRemoteByID gets a remote by its id
+func GetRemoteByID(ctx context.Context, id int64) (*PackageRemote, error) {
+	r := &PackageRemote{}
+	has, err := db.GetEngine(ctx).ID(id).Get(r)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return r, nil
+}
+
+// GetRemoteByOwnerAndType gets the remote of the packages of a type of an owner
+func GetRemoteByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	r := &PackageRemote{}
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ?", ownerID, packageType).Get(r)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return r, nil
+}
+
+// GetRemotesByOwner gets the remotes of an owner
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	rs := make([]*PackageRemote, 0, len(RemoteTypeList))
+	return rs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).OrderBy("type").Find(&rs)
+}
+
+// DeleteRemoteByID deletes a remote and the metadata cached from it.
+// The package versions cached from it are kept.
+func DeleteRemoteByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("remote_id = ?", id).Delete(&PackageRemoteMetadata{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(&PackageRemote{})
+		return err
+	})
+}
+
+// PackageRemoteMetadata is a package index fetched from an upstream remote, like the npm
+// package document or the PyPI simple page, cached for the TTL of the remote
+type PackageRemoteMetadata struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RemoteID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path        string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+	Content     string             `xorm:"LONGTEXT"`
+	FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// IsFresh checks if the metadata has been fetched less than the TTL ago
+func (m *PackageRemoteMetadata) IsFresh(ttl time.Duration) bool {
+	return time.Since(m.FetchedUnix.AsTime()) < ttl
+}
+
+// GetRemoteMetadata gets the metadata cached from the path of a remote, nil if none has been
+func GetRemoteMetadata(ctx context.Context, remoteID int64, path string) (*PackageRemoteMetadata, error) {
+	m := &PackageRemoteMetadata{}
+	has, err := db.GetEngine(ctx).Where("remote_id = ? AND path = ?", remoteID, path).Get(m)
+	if err != nil || !has {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetRemoteMetadata caches the metadata fetched from the path of a remote
+func SetRemoteMetadata(ctx context.Context, remoteID int64, path, content string) (*PackageRemoteMetadata, error) {
+	m := &PackageRemoteMetadata{
+		RemoteID:    remoteID,
+		Path:        path,
+		Content:     content,
+		FetchedUnix: timeutil.TimeStampNow(),
+	}
+	return m, db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetRemoteMetadata(ctx, remoteID, path)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, m)
+		}
+		m.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(m.ID).Cols("content", "fetched_unix").Update(m)
+		return err
+	})
+}
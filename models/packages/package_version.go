@@ -34,6 +34,8 @@ type PackageVersion struct {
 	IsInternal    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
 	MetadataJSON  string             `xorm:"metadata_json LONGTEXT"`
 	DownloadCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	// UpstreamOnly is set on versions cached from the upstream remote of the package type, which were never published here
+	UpstreamOnly bool `xorm:"INDEX NOT NULL DEFAULT false"`
 }
 
 // GetOrInsertVersion inserts a version. If the same version exist already ErrDuplicatePackageVersion is returned
@@ -184,6 +186,7 @@ type PackageSearchOptions struct {
 	Version         SearchValue       // only results with the specific version are found
 	Properties      map[string]string // only results are found which contain all listed version properties with the specific value
 	IsInternal      optional.Option[bool]
+	UpstreamOnly    optional.Option[bool] // only results are found which were (not) cached from an upstream remote
 	HasFileWithName string                // only results are found which are associated with a file with the specific name
 	HasFiles        optional.Option[bool] // only results are found which have associated files
 	Sort            VersionSort
@@ -198,6 +201,10 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 		}
 	}
 
+	if has, value := opts.UpstreamOnly.Get(); has {
+		cond = cond.And(builder.Eq{"package_version.upstream_only": value})
+	}
+
 	if opts.OwnerID != 0 {
 		cond = cond.And(builder.Eq{"package.owner_id": opts.OwnerID})
 	}
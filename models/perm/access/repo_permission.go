@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	actions_model "forgejo.org/models/actions"
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	perm_model "forgejo.org/models/perm"
@@ -113,6 +114,28 @@ func (p *Permission) CanWriteIssuesOrPulls(isPull bool) bool {
 	return p.CanWrite(unit.TypeIssues)
 }
 
+// RestrictToAccessToken limits the permission to the repositories and permissions of a fine-grained access token.
+// The token never grants administrative access.
+func (p *Permission) RestrictToAccessToken(t *auth_model.AccessToken, repo *repo_model.Repository) {
+	if t == nil || !t.IsFineGrained() {
+		return
+	}
+
+	unitsMode := make(map[unit.Type]perm_model.AccessMode, len(p.Units))
+	if t.AllowsRepository(repo.ID, repo.OwnerID) {
+		for _, u := range p.Units {
+			if mode := min(p.UnitAccessMode(u.Type), t.Permissions.UnitAccessMode(u.Type)); mode > perm_model.AccessModeNone {
+				unitsMode[u.Type] = mode
+			}
+		}
+	}
+	p.UnitsMode = unitsMode
+	p.AccessMode = min(p.AccessMode, perm_model.AccessModeRead)
+	if len(unitsMode) == 0 {
+		p.AccessMode = perm_model.AccessModeNone
+	}
+}
+
 func (p *Permission) LogString() string {
 	format := "<Permission AccessMode=%s, %d Units, %d UnitsMode(s): [ "
 	args := []any{p.AccessMode.String(), len(p.Units), len(p.UnitsMode)}
@@ -202,6 +225,18 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 		return perm, err
 	}
 
+	if user != nil {
+		blocked, err := isBlockedByTwoFactorRequirement(ctx, repo, user)
+		if err != nil {
+			return perm, err
+		}
+		if blocked {
+			// the user keeps only the access of anonymous users
+			perm, err = GetUserRepoPermission(ctx, repo, nil)
+			return perm, err
+		}
+	}
+
 	// Prevent strangers from checking out public repo of private organization/users
 	// Allow user if they are collaborator of a repo within a private user or a private organization but not a member of the organization itself
 	if !organization.HasOrgOrUserVisible(ctx, repo.Owner, user) && !isCollaborator {
@@ -312,6 +347,15 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 	return perm, err
 }
 
+// isBlockedByTwoFactorRequirement returns whether the organization owning the repository requires
+// two-factor authentication and its grace period has ended, but the user has not enabled it.
+func isBlockedByTwoFactorRequirement(ctx context.Context, repo *repo_model.Repository, user *user_model.User) (bool, error) {
+	if !repo.Owner.IsOrganization() {
+		return false, nil
+	}
+	return organization.IsBlockedByTwoFactorRequirement(ctx, repo.OwnerID, user)
+}
+
 // IsUserRealRepoAdmin check if this user is real repo admin
 func IsUserRealRepoAdmin(ctx context.Context, repo *repo_model.Repository, user *user_model.User) (bool, error) {
 	if repo.OwnerID == user.ID {
@@ -4,12 +4,17 @@ import (
 	"testing"
 
 	actions_model "forgejo.org/models/actions"
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
 	perm_model "forgejo.org/models/perm"
 	"forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
 
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -76,3 +81,44 @@ func TestActionTaskNoAccessPrivateRepo(t *testing.T) {
 	require.NoError(t, err)
 	assertAccess(t, perm_model.AccessModeNone, &perm)
 }
+
+func TestTwoFactorRequirementBlocksAccess(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	privateRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	publicRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 32})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	getPermission := func(t *testing.T, repo *repo_model.Repository, user *user_model.User) access.Permission {
+		t.Helper()
+		perm, err := access.GetUserRepoPermission(db.DefaultContext, repo, user)
+		require.NoError(t, err)
+		return perm
+	}
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		_, err := organization.SetTwoFactorRequirement(db.DefaultContext, 3, timeutil.TimeStampNow().Add(3600), 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, perm_model.AccessModeOwner, getPermission(t, privateRepo, owner).AccessMode)
+	})
+
+	t.Run("Enforced", func(t *testing.T) {
+		_, err := organization.SetTwoFactorRequirement(db.DefaultContext, 3, timeutil.TimeStampNow(), 0)
+		require.NoError(t, err)
+
+		// the owner without 2fa only keeps the access of anonymous users
+		assert.Equal(t, perm_model.AccessModeNone, getPermission(t, privateRepo, owner).AccessMode)
+		assert.Equal(t, perm_model.AccessModeRead, getPermission(t, publicRepo, owner).AccessMode)
+		// site admins are not blocked
+		assert.Equal(t, perm_model.AccessModeOwner, getPermission(t, privateRepo, admin).AccessMode)
+	})
+
+	t.Run("Compliant", func(t *testing.T) {
+		_, err := auth_model.CreateCredential(db.DefaultContext, owner.ID, "Security key", &webauthn.Credential{ID: []byte("Security key")})
+		require.NoError(t, err)
+
+		assert.Equal(t, perm_model.AccessModeOwner, getPermission(t, privateRepo, owner).AccessMode)
+	})
+}
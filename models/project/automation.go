@@ -0,0 +1,186 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationEvent is the event of an issue or pull request which triggers an automation rule
+type AutomationEvent uint8
+
+const (
+	// AutomationEventItemClosed moves an issue or pull request of the project when it is closed
+	AutomationEventItemClosed AutomationEvent = iota + 1
+
+	// AutomationEventItemReopened moves an issue or pull request of the project when it is reopened
+	AutomationEventItemReopened
+
+	// AutomationEventPullRequestOpened moves a pull request of the project once it is opened
+	AutomationEventPullRequestOpened
+
+	// AutomationEventPullRequestMerged moves a pull request of the project when it is merged
+	AutomationEventPullRequestMerged
+
+	// AutomationEventReviewRequested moves a pull request of the project when a review is requested
+	AutomationEventReviewRequested
+
+	// AutomationEventAutoAdd adds the issues and pull requests matching the repository and label of the rule
+	// to the project when they are opened or labeled
+	AutomationEventAutoAdd
+)
+
+// AutomationEvents lists the events in the order they are offered to users
+var AutomationEvents = []AutomationEvent{
+	AutomationEventItemClosed,
+	AutomationEventItemReopened,
+	AutomationEventPullRequestOpened,
+	AutomationEventPullRequestMerged,
+	AutomationEventReviewRequested,
+	AutomationEventAutoAdd,
+}
+
+// Name returns the name of the event, used for translations
+func (e AutomationEvent) Name() string {
+	switch e {
+	case AutomationEventItemClosed:
+		return "item_closed"
+	case AutomationEventItemReopened:
+		return "item_reopened"
+	case AutomationEventPullRequestOpened:
+		return "pull_request_opened"
+	case AutomationEventPullRequestMerged:
+		return "pull_request_merged"
+	case AutomationEventReviewRequested:
+		return "review_requested"
+	case AutomationEventAutoAdd:
+		return "auto_add"
+	default:
+		return ""
+	}
+}
+
+// IsAutomationEventValid checks if the automation event is valid
+func IsAutomationEventValid(e AutomationEvent) bool {
+	return e.Name() != ""
+}
+
+// ErrProjectAutomationRuleNotExist represents a "ErrProjectAutomationRuleNotExist" kind of error.
+type ErrProjectAutomationRuleNotExist struct {
+	RuleID int64
+}
+
+// IsErrProjectAutomationRuleNotExist checks if an error is a ErrProjectAutomationRuleNotExist
+func IsErrProjectAutomationRuleNotExist(err error) bool {
+	_, ok := err.(ErrProjectAutomationRuleNotExist)
+	return ok
+}
+
+func (err ErrProjectAutomationRuleNotExist) Error() string {
+	return fmt.Sprintf("project automation rule does not exist [id: %d]", err.RuleID)
+}
+
+func (err ErrProjectAutomationRuleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// AutomationRule moves or adds issues of a project when an event happens.
+// Rules adding issues are filtered by RepoID and LabelID, zero matching any.
+type AutomationRule struct {
+	ID        int64           `xorm:"pk autoincr"`
+	ProjectID int64           `xorm:"INDEX NOT NULL"`
+	CreatorID int64           `xorm:"NOT NULL"`
+	Event     AutomationEvent `xorm:"INDEX NOT NULL"`
+	// ColumnID is the column the issue is moved to, the default column of the project if zero
+	ColumnID int64 `xorm:"NOT NULL DEFAULT 0"`
+	RepoID   int64 `xorm:"NOT NULL DEFAULT 0"`
+	LabelID  int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TableName return the real table name
+func (AutomationRule) TableName() string {
+	return "project_automation_rule"
+}
+
+func init() {
+	db.RegisterModel(new(AutomationRule))
+}
+
+// NewAutomationRule creates a new automation rule for a project
+func NewAutomationRule(ctx context.Context, rule *AutomationRule) error {
+	if !IsAutomationEventValid(rule.Event) {
+		return util.NewInvalidArgumentErrorf("project automation event is not valid")
+	}
+	if rule.Event != AutomationEventAutoAdd {
+		if rule.ColumnID == 0 {
+			return util.NewInvalidArgumentErrorf("project automation rule needs a column")
+		}
+		// filters only apply to the issues added to a project
+		rule.RepoID, rule.LabelID = 0, 0
+	}
+	if rule.ColumnID > 0 {
+		column, err := GetColumn(ctx, rule.ColumnID)
+		if err != nil {
+			return err
+		}
+		if column.ProjectID != rule.ProjectID {
+			return util.NewInvalidArgumentErrorf("column %d does not belong to project %d", rule.ColumnID, rule.ProjectID)
+		}
+	}
+	return db.Insert(ctx, rule)
+}
+
+// GetAutomationRules returns the automation rules of the project
+func (p *Project) GetAutomationRules(ctx context.Context) ([]*AutomationRule, error) {
+	rules := make([]*AutomationRule, 0, 5)
+	return rules, db.GetEngine(ctx).Where("project_id = ?", p.ID).OrderBy("event, id").Find(&rules)
+}
+
+// GetAutomationRulesByEvent returns the automation rules of the project triggered by the event
+func GetAutomationRulesByEvent(ctx context.Context, projectID int64, event AutomationEvent) ([]*AutomationRule, error) {
+	rules := make([]*AutomationRule, 0, 2)
+	return rules, db.GetEngine(ctx).Where("project_id = ? AND event = ?", projectID, event).OrderBy("id").Find(&rules)
+}
+
+// GetAutoAddRules returns the rules of the open projects an issue of the repository can be added to.
+// The label filter of the rules is left to the caller.
+func GetAutoAddRules(ctx context.Context, ownerID, repoID int64) ([]*AutomationRule, error) {
+	rules := make([]*AutomationRule, 0, 2)
+	return rules, db.GetEngine(ctx).Select("`project_automation_rule`.*").
+		Join("INNER", "project", "project.id = project_automation_rule.project_id").
+		Where(builder.Eq{
+			"project_automation_rule.event": AutomationEventAutoAdd,
+			"project.is_closed":             false,
+		}).
+		And(builder.Eq{"project.repo_id": repoID}.Or(builder.Eq{"project.owner_id": ownerID, "project.repo_id": 0})).
+		And(builder.Eq{"project_automation_rule.repo_id": 0}.Or(builder.Eq{"project_automation_rule.repo_id": repoID})).
+		OrderBy("project_automation_rule.id").
+		Find(&rules)
+}
+
+// DeleteAutomationRuleByID deletes an automation rule of the project
+func DeleteAutomationRuleByID(ctx context.Context, projectID, ruleID int64) error {
+	affected, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", ruleID, projectID).Delete(&AutomationRule{})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProjectAutomationRuleNotExist{RuleID: ruleID}
+	}
+	return nil
+}
+
+func deleteAutomationRulesByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&AutomationRule{})
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAutomationRule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	rule := &AutomationRule{ProjectID: 1, Event: AutomationEventItemClosed, ColumnID: 3, RepoID: 1, LabelID: 1}
+	require.NoError(t, NewAutomationRule(db.DefaultContext, rule))
+	// filters are dropped from rules which do not add issues
+	assert.Zero(t, rule.RepoID)
+	assert.Zero(t, rule.LabelID)
+
+	// moving rules need a column of the project
+	require.ErrorIs(t, NewAutomationRule(db.DefaultContext, &AutomationRule{ProjectID: 1, Event: AutomationEventItemClosed}), util.ErrInvalidArgument)
+	require.ErrorIs(t, NewAutomationRule(db.DefaultContext, &AutomationRule{ProjectID: 1, Event: AutomationEventItemClosed, ColumnID: 5}), util.ErrInvalidArgument)
+	require.ErrorIs(t, NewAutomationRule(db.DefaultContext, &AutomationRule{ProjectID: 1, Event: 42, ColumnID: 3}), util.ErrInvalidArgument)
+
+	rules, err := GetAutomationRulesByEvent(db.DefaultContext, 1, AutomationEventItemClosed)
+	require.NoError(t, err)
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, rule.ID, rules[0].ID)
+	}
+
+	// deleting the column of a rule makes it use the default column
+	require.NoError(t, DeleteColumnByID(db.DefaultContext, 3))
+	rule = unittest.AssertExistsAndLoadBean(t, &AutomationRule{ID: rule.ID})
+	assert.EqualValues(t, 1, rule.ColumnID)
+
+	require.NoError(t, DeleteAutomationRuleByID(db.DefaultContext, 1, rule.ID))
+	assert.True(t, IsErrProjectAutomationRuleNotExist(DeleteAutomationRuleByID(db.DefaultContext, 1, rule.ID)))
+}
+
+func TestGetAutoAddRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repoRule := &AutomationRule{ProjectID: 1, Event: AutomationEventAutoAdd}
+	require.NoError(t, NewAutomationRule(db.DefaultContext, repoRule))
+	userRule := &AutomationRule{ProjectID: 4, Event: AutomationEventAutoAdd, RepoID: 2, LabelID: 1}
+	require.NoError(t, NewAutomationRule(db.DefaultContext, userRule))
+	closedRule := &AutomationRule{ProjectID: 3, Event: AutomationEventAutoAdd}
+	require.NoError(t, NewAutomationRule(db.DefaultContext, closedRule))
+
+	ruleIDs := func(ownerID, repoID int64) []int64 {
+		rules, err := GetAutoAddRules(db.DefaultContext, ownerID, repoID)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(rules))
+		for _, rule := range rules {
+			ids = append(ids, rule.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []int64{repoRule.ID}, ruleIDs(2, 1))
+	assert.Equal(t, []int64{userRule.ID}, ruleIDs(2, 2))
+	// the rules of closed projects are ignored
+	assert.Empty(t, ruleIDs(5, 4))
+}
+
+func TestMoveIssueToColumn(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	column, err := GetColumn(db.DefaultContext, 2)
+	require.NoError(t, err)
+
+	moved, err := MoveIssueToColumn(db.DefaultContext, column, 1)
+	require.NoError(t, err)
+	assert.True(t, moved)
+	projectIssue := unittest.AssertExistsAndLoadBean(t, &ProjectIssue{ProjectID: 1, IssueID: 1})
+	assert.Equal(t, column.ID, projectIssue.ProjectColumnID)
+
+	// the issue is already in the column
+	moved, err = MoveIssueToColumn(db.DefaultContext, column, 1)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	// the issue is not in the project
+	moved, err = MoveIssueToColumn(db.DefaultContext, column, 4)
+	require.NoError(t, err)
+	assert.False(t, moved)
+}
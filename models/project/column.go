@@ -187,6 +187,12 @@ func deleteColumnByID(ctx context.Context, columnID int64) error {
 		return err
 	}
 
+	// rules moving issues to the column now move them to the default column
+	if _, err := db.GetEngine(ctx).Where("column_id = ?", column.ID).Cols("column_id").
+		Update(&AutomationRule{ColumnID: defaultColumn.ID}); err != nil {
+		return err
+	}
+
 	if _, err := db.GetEngine(ctx).ID(column.ID).NoAutoCondition().Delete(column); err != nil {
 		return err
 	}
@@ -0,0 +1,254 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FieldType is used to identify the kind of values a custom field holds
+type FieldType uint8
+
+const (
+	// FieldTypeText is a custom field holding free text
+	FieldTypeText FieldType = iota + 1
+
+	// FieldTypeNumber is a custom field holding a number
+	FieldTypeNumber
+
+	// FieldTypeDate is a custom field holding a date formatted as YYYY-MM-DD
+	FieldTypeDate
+)
+
+// ErrProjectFieldNotExist represents a "ErrProjectFieldNotExist" kind of error.
+type ErrProjectFieldNotExist struct {
+	FieldID int64
+}
+
+// IsErrProjectFieldNotExist checks if an error is a ErrProjectFieldNotExist
+func IsErrProjectFieldNotExist(err error) bool {
+	_, ok := err.(ErrProjectFieldNotExist)
+	return ok
+}
+
+func (err ErrProjectFieldNotExist) Error() string {
+	return fmt.Sprintf("project field does not exist [id: %d]", err.FieldID)
+}
+
+func (err ErrProjectFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// Field is a custom attribute the issues of a project can be given
+type Field struct {
+	ID        int64     `xorm:"pk autoincr"`
+	ProjectID int64     `xorm:"INDEX NOT NULL"`
+	CreatorID int64     `xorm:"NOT NULL"`
+	Name      string    `xorm:"NOT NULL"`
+	Type      FieldType `xorm:"NOT NULL DEFAULT 1"`
+	Sorting   int64     `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// TableName return the real table name
+func (Field) TableName() string {
+	return "project_field"
+}
+
+// FieldValue is the value of a custom field for an issue of the project
+type FieldValue struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	FieldID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string `xorm:"TEXT"`
+}
+
+// TableName return the real table name
+func (FieldValue) TableName() string {
+	return "project_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(Field))
+	db.RegisterModel(new(FieldValue))
+}
+
+// IsFieldTypeValid checks if the field type is valid
+func IsFieldTypeValid(t FieldType) bool {
+	switch t {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Name returns the name of the field type as used by the API
+func (t FieldType) Name() string {
+	switch t {
+	case FieldTypeText:
+		return "text"
+	case FieldTypeNumber:
+		return "number"
+	case FieldTypeDate:
+		return "date"
+	default:
+		return ""
+	}
+}
+
+// FieldTypeFromName returns the field type matching the name, or 0 if there is none
+func FieldTypeFromName(name string) FieldType {
+	switch name {
+	case "text":
+		return FieldTypeText
+	case "number":
+		return FieldTypeNumber
+	case "date":
+		return FieldTypeDate
+	default:
+		return 0
+	}
+}
+
+// ValidateValue checks if value can be stored in the field. An empty value clears the field.
+func (f *Field) ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	switch f.Type {
+	case FieldTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return util.NewInvalidArgumentErrorf("value of field %q is not a number", f.Name)
+		}
+	case FieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return util.NewInvalidArgumentErrorf("value of field %q is not a date", f.Name)
+		}
+	}
+	return nil
+}
+
+// NewField creates a new custom field for a project
+func NewField(ctx context.Context, field *Field) error {
+	if !IsFieldTypeValid(field.Type) {
+		return util.NewInvalidArgumentErrorf("project field type is not valid")
+	}
+	field.Name, _ = util.SplitStringAtByteN(field.Name, 255)
+	if field.Name == "" {
+		return util.NewInvalidArgumentErrorf("project field name is empty")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_field").Select("COALESCE(MAX(sorting), 0)").
+			Where("project_id = ?", field.ProjectID).Get(&maxSorting); err != nil {
+			return err
+		}
+		field.Sorting = maxSorting + 1
+		return db.Insert(ctx, field)
+	})
+}
+
+// GetFieldByID returns the custom field of the project by its ID
+func GetFieldByID(ctx context.Context, projectID, fieldID int64) (*Field, error) {
+	field := new(Field)
+	has, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", fieldID, projectID).Get(field)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectFieldNotExist{FieldID: fieldID}
+	}
+	return field, nil
+}
+
+// GetFields returns the custom fields of the project in their configured order
+func (p *Project) GetFields(ctx context.Context) ([]*Field, error) {
+	fields := make([]*Field, 0, 5)
+	return fields, db.GetEngine(ctx).Where("project_id = ?", p.ID).OrderBy("sorting, id").Find(&fields)
+}
+
+// DeleteFieldByID deletes a custom field of the project along with its values
+func DeleteFieldByID(ctx context.Context, projectID, fieldID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		affected, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", fieldID, projectID).Delete(&Field{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrProjectFieldNotExist{FieldID: fieldID}
+		}
+		_, err = db.GetEngine(ctx).Where("field_id = ?", fieldID).Delete(&FieldValue{})
+		return err
+	})
+}
+
+// SetFieldValue sets the value of a custom field for an issue of the project. An empty value clears the field.
+func SetFieldValue(ctx context.Context, field *Field, issueID int64, value string) error {
+	if err := field.ValidateValue(value); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id = ? AND issue_id = ?", field.ID, issueID).Delete(&FieldValue{}); err != nil {
+			return err
+		}
+		if value == "" {
+			return nil
+		}
+		return db.Insert(ctx, &FieldValue{
+			ProjectID: field.ProjectID,
+			FieldID:   field.ID,
+			IssueID:   issueID,
+			Value:     value,
+		})
+	})
+}
+
+// GetFieldValues returns the values of the custom fields of the project, indexed by issue ID and field ID
+func (p *Project) GetFieldValues(ctx context.Context, issueIDs []int64) (map[int64]map[int64]string, error) {
+	values := make([]*FieldValue, 0, len(issueIDs))
+	if len(issueIDs) > 0 {
+		if err := db.GetEngine(ctx).Where("project_id = ?", p.ID).
+			And(builder.In("issue_id", issueIDs)).
+			Find(&values); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[int64]map[int64]string, len(issueIDs))
+	for _, value := range values {
+		if result[value.IssueID] == nil {
+			result[value.IssueID] = make(map[int64]string)
+		}
+		result[value.IssueID][value.FieldID] = value.Value
+	}
+	return result, nil
+}
+
+// DeleteIssueFieldValues removes the values of all custom fields of the project for the issue
+func DeleteIssueFieldValues(ctx context.Context, projectID, issueID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ? AND issue_id = ?", projectID, issueID).Delete(&FieldValue{})
+	return err
+}
+
+func deleteFieldsByProjectID(ctx context.Context, projectID int64) error {
+	if _, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&FieldValue{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&Field{})
+	return err
+}
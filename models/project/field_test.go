@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldValidateValue(t *testing.T) {
+	number := &Field{Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, number.ValidateValue("3.5"))
+	require.NoError(t, number.ValidateValue(""))
+	require.ErrorIs(t, number.ValidateValue("three"), util.ErrInvalidArgument)
+
+	date := &Field{Name: "Due", Type: FieldTypeDate}
+	require.NoError(t, date.ValidateValue("2026-03-01"))
+	require.ErrorIs(t, date.ValidateValue("01/03/2026"), util.ErrInvalidArgument)
+
+	text := &Field{Name: "Notes", Type: FieldTypeText}
+	require.NoError(t, text.ValidateValue("anything"))
+}
+
+func TestProjectFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project, err := GetProjectByID(db.DefaultContext, 1)
+	require.NoError(t, err)
+
+	estimate := &Field{ProjectID: project.ID, CreatorID: 2, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, NewField(db.DefaultContext, estimate))
+	team := &Field{ProjectID: project.ID, CreatorID: 2, Name: "Team", Type: FieldTypeText}
+	require.NoError(t, NewField(db.DefaultContext, team))
+	require.ErrorIs(t, NewField(db.DefaultContext, &Field{ProjectID: project.ID, Name: "Bad"}), util.ErrInvalidArgument)
+
+	fields, err := project.GetFields(db.DefaultContext)
+	require.NoError(t, err)
+	assert.Len(t, fields, 2)
+
+	require.NoError(t, SetFieldValue(db.DefaultContext, estimate, 1, "5"))
+	require.NoError(t, SetFieldValue(db.DefaultContext, estimate, 1, "8"))
+	require.NoError(t, SetFieldValue(db.DefaultContext, team, 1, "Backend"))
+	require.NoError(t, SetFieldValue(db.DefaultContext, estimate, 3, "2"))
+	require.ErrorIs(t, SetFieldValue(db.DefaultContext, estimate, 3, "many"), util.ErrInvalidArgument)
+
+	values, err := project.GetFieldValues(db.DefaultContext, []int64{1, 3, 5})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]map[int64]string{
+		1: {estimate.ID: "8", team.ID: "Backend"},
+		3: {estimate.ID: "2"},
+	}, values)
+
+	// an empty value clears the field
+	require.NoError(t, SetFieldValue(db.DefaultContext, team, 1, ""))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: team.ID, IssueID: 1})
+
+	require.NoError(t, DeleteFieldByID(db.DefaultContext, project.ID, estimate.ID))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: estimate.ID})
+	assert.True(t, IsErrProjectFieldNotExist(DeleteFieldByID(db.DefaultContext, project.ID, estimate.ID)))
+}
@@ -9,7 +9,10 @@ import (
 
 	"forgejo.org/models/db"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
 )
 
 // ProjectIssue saves relation from issue to a project
@@ -23,6 +26,10 @@ type ProjectIssue struct { //revive:disable-line:exported
 
 	// the sorting order on the column
 	Sorting int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	// the planned start and target dates of the issue, shown by roadmap views
+	StartDateUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	TargetDateUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
 }
 
 func init() {
@@ -34,6 +41,49 @@ func deleteProjectIssuesByProjectID(ctx context.Context, projectID int64) error
 	return err
 }
 
+// GetProjectIssues returns the project relation of the given issues, indexed by issue ID
+func (p *Project) GetProjectIssues(ctx context.Context, issueIDs []int64) (map[int64]*ProjectIssue, error) {
+	projectIssues := make([]*ProjectIssue, 0, len(issueIDs))
+	if len(issueIDs) > 0 {
+		if err := db.GetEngine(ctx).Where("project_id = ?", p.ID).
+			And(builder.In("issue_id", issueIDs)).
+			Find(&projectIssues); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[int64]*ProjectIssue, len(projectIssues))
+	for _, projectIssue := range projectIssues {
+		result[projectIssue.IssueID] = projectIssue
+	}
+	return result, nil
+}
+
+// SetIssueDates sets the planned start and target dates of an issue of the project.
+// A zero timestamp clears the date.
+func SetIssueDates(ctx context.Context, projectID, issueID int64, start, target timeutil.TimeStamp) error {
+	if start > 0 && target > 0 && target < start {
+		return util.NewInvalidArgumentErrorf("target date is before start date")
+	}
+	affected, err := db.GetEngine(ctx).Where("project_id = ? AND issue_id = ?", projectID, issueID).
+		Cols("start_date_unix", "target_date_unix").
+		Update(&ProjectIssue{StartDateUnix: start, TargetDateUnix: target})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// xorm reports no affected rows if the values did not change, so check the issue is in the project
+		has, err := db.GetEngine(ctx).Where("project_id = ? AND issue_id = ?", projectID, issueID).Exist(&ProjectIssue{})
+		if err != nil {
+			return err
+		}
+		if !has {
+			return util.NewNotExistErrorf("issue %d is not in project %d", issueID, projectID)
+		}
+	}
+	return nil
+}
+
 // NumClosedIssues return counter of closed issues assigned to a project
 func (p *Project) NumClosedIssues(ctx context.Context) int {
 	c, err := db.GetEngine(ctx).Table("project_issue").
@@ -86,6 +136,32 @@ func MoveIssuesOnProjectColumn(ctx context.Context, column *Column, sortedIssueI
 	})
 }
 
+// MoveIssueToColumn moves an issue of the project to the end of the column.
+// It returns false if the issue is not in the project of the column or already in the column.
+func MoveIssueToColumn(ctx context.Context, column *Column, issueID int64) (bool, error) {
+	var moved bool
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		projectIssue := new(ProjectIssue)
+		has, err := db.GetEngine(ctx).Where("project_id = ? AND issue_id = ?", column.ProjectID, issueID).Get(projectIssue)
+		if err != nil || !has || projectIssue.ProjectColumnID == column.ID {
+			return err
+		}
+
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_issue").Select("COALESCE(MAX(sorting), -1)").
+			Where("project_id = ? AND project_board_id = ?", column.ProjectID, column.ID).Get(&maxSorting); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(projectIssue.ID).Cols("project_board_id", "sorting").
+			Update(&ProjectIssue{ProjectColumnID: column.ID, Sorting: maxSorting + 1}); err != nil {
+			return err
+		}
+		moved = true
+		return nil
+	})
+	return moved, err
+}
+
 func (c *Column) moveIssuesToAnotherColumn(ctx context.Context, newColumn *Column) error {
 	if c.ProjectID != newColumn.ProjectID {
 		return errors.New("columns have to be in the same project")
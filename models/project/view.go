@@ -0,0 +1,260 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+type (
+	// ViewType is used to identify how a saved view renders the issues of a project
+	ViewType uint8
+
+	// ViewGroupBy is the attribute the issues of a saved view are grouped by
+	ViewGroupBy string
+)
+
+const (
+	// ViewTypeTable renders the issues as a table with sortable columns
+	ViewTypeTable ViewType = iota + 1
+
+	// ViewTypeRoadmap renders the issues on a timeline based on their start and target dates
+	ViewTypeRoadmap
+)
+
+const (
+	ViewGroupByNone      ViewGroupBy = ""
+	ViewGroupByAssignee  ViewGroupBy = "assignee"
+	ViewGroupByLabel     ViewGroupBy = "label"
+	ViewGroupByMilestone ViewGroupBy = "milestone"
+	ViewGroupByColumn    ViewGroupBy = "column"
+)
+
+// The built-in attributes a view can be sorted by. Custom fields are referenced
+// as ViewSortFieldPrefix followed by the ID of the field.
+const (
+	ViewSortTitle      = "title"
+	ViewSortAssignee   = "assignee"
+	ViewSortMilestone  = "milestone"
+	ViewSortLabels     = "labels"
+	ViewSortCreated    = "created"
+	ViewSortUpdated    = "updated"
+	ViewSortStartDate  = "start_date"
+	ViewSortTargetDate = "target_date"
+
+	ViewSortFieldPrefix = "field:"
+)
+
+// ErrProjectViewNotExist represents a "ErrProjectViewNotExist" kind of error.
+type ErrProjectViewNotExist struct {
+	ViewID int64
+}
+
+// IsErrProjectViewNotExist checks if an error is a ErrProjectViewNotExist
+func IsErrProjectViewNotExist(err error) bool {
+	_, ok := err.(ErrProjectViewNotExist)
+	return ok
+}
+
+func (err ErrProjectViewNotExist) Error() string {
+	return fmt.Sprintf("project view does not exist [id: %d]", err.ViewID)
+}
+
+func (err ErrProjectViewNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// View is a saved way of looking at the issues of a project
+type View struct {
+	ID        int64    `xorm:"pk autoincr"`
+	ProjectID int64    `xorm:"INDEX NOT NULL"`
+	CreatorID int64    `xorm:"NOT NULL"`
+	Title     string   `xorm:"NOT NULL"`
+	Type      ViewType `xorm:"NOT NULL DEFAULT 1"`
+
+	// Filter is an issue search query, using the same syntax as the issue list
+	Filter   string      `xorm:"TEXT"`
+	GroupBy  ViewGroupBy `xorm:"VARCHAR(20)"`
+	SortBy   string      `xorm:"VARCHAR(50)"`
+	SortDesc bool        `xorm:"NOT NULL DEFAULT false"`
+	// Fields lists the IDs of the custom fields shown as columns of a table view
+	Fields []int64 `xorm:"JSON TEXT"`
+
+	Sorting int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// TableName return the real table name
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// IsRoadmap returns whether the view shows its issues on a timeline
+func (v *View) IsRoadmap() bool {
+	return v.Type == ViewTypeRoadmap
+}
+
+// IsViewTypeValid checks if the view type is valid
+func IsViewTypeValid(t ViewType) bool {
+	switch t {
+	case ViewTypeTable, ViewTypeRoadmap:
+		return true
+	default:
+		return false
+	}
+}
+
+// Name returns the name of the view type as used by the API
+func (t ViewType) Name() string {
+	switch t {
+	case ViewTypeTable:
+		return "table"
+	case ViewTypeRoadmap:
+		return "roadmap"
+	default:
+		return ""
+	}
+}
+
+// ViewTypeFromName returns the view type matching the name, or 0 if there is none
+func ViewTypeFromName(name string) ViewType {
+	switch name {
+	case "table":
+		return ViewTypeTable
+	case "roadmap":
+		return ViewTypeRoadmap
+	default:
+		return 0
+	}
+}
+
+// IsViewGroupByValid checks if the group-by attribute is valid
+func IsViewGroupByValid(g ViewGroupBy) bool {
+	switch g {
+	case ViewGroupByNone, ViewGroupByAssignee, ViewGroupByLabel, ViewGroupByMilestone, ViewGroupByColumn:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsViewSortValid checks if the sort attribute is valid
+func IsViewSortValid(sortBy string) bool {
+	switch sortBy {
+	case "", ViewSortTitle, ViewSortAssignee, ViewSortMilestone, ViewSortLabels,
+		ViewSortCreated, ViewSortUpdated, ViewSortStartDate, ViewSortTargetDate:
+		return true
+	}
+	_, ok := ViewSortFieldID(sortBy)
+	return ok
+}
+
+// ViewSortFieldID returns the ID of the custom field a sort attribute refers to
+func ViewSortFieldID(sortBy string) (int64, bool) {
+	idStr, ok := strings.CutPrefix(sortBy, ViewSortFieldPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	return id, err == nil && id > 0
+}
+
+func (v *View) validate() error {
+	if !IsViewTypeValid(v.Type) {
+		return util.NewInvalidArgumentErrorf("project view type is not valid")
+	}
+	if !IsViewGroupByValid(v.GroupBy) {
+		return util.NewInvalidArgumentErrorf("project view group by is not valid")
+	}
+	if !IsViewSortValid(v.SortBy) {
+		return util.NewInvalidArgumentErrorf("project view sort is not valid")
+	}
+	v.Title, _ = util.SplitStringAtByteN(v.Title, 255)
+	if v.Title == "" {
+		return util.NewInvalidArgumentErrorf("project view title is empty")
+	}
+	return nil
+}
+
+// NewView creates a new saved view of a project
+func NewView(ctx context.Context, view *View) error {
+	if err := view.validate(); err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var maxSorting int64
+		if _, err := db.GetEngine(ctx).Table("project_view").Select("COALESCE(MAX(sorting), 0)").
+			Where("project_id = ?", view.ProjectID).Get(&maxSorting); err != nil {
+			return err
+		}
+		view.Sorting = maxSorting + 1
+		return db.Insert(ctx, view)
+	})
+}
+
+// GetViewByID returns the saved view of the project by its ID
+func GetViewByID(ctx context.Context, projectID, viewID int64) (*View, error) {
+	view := new(View)
+	has, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", viewID, projectID).Get(view)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectViewNotExist{ViewID: viewID}
+	}
+	return view, nil
+}
+
+// GetViews returns the saved views of the project in their configured order
+func (p *Project) GetViews(ctx context.Context) ([]*View, error) {
+	views := make([]*View, 0, 5)
+	return views, db.GetEngine(ctx).Where("project_id = ?", p.ID).OrderBy("sorting, id").Find(&views)
+}
+
+// UpdateView updates the definition of a saved view
+func UpdateView(ctx context.Context, view *View) error {
+	if err := view.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(view.ID).Cols(
+		"title",
+		"type",
+		"filter",
+		"group_by",
+		"sort_by",
+		"sort_desc",
+		"fields",
+	).Update(view)
+	return err
+}
+
+// DeleteViewByID deletes a saved view of the project
+func DeleteViewByID(ctx context.Context, projectID, viewID int64) error {
+	affected, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", viewID, projectID).Delete(&View{})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrProjectViewNotExist{ViewID: viewID}
+	}
+	return nil
+}
+
+func deleteViewsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&View{})
+	return err
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsViewSortValid(t *testing.T) {
+	cases := []struct {
+		sortBy string
+		valid  bool
+	}{
+		{"", true},
+		{ViewSortTitle, true},
+		{ViewSortTargetDate, true},
+		{"field:3", true},
+		{"field:", false},
+		{"field:0", false},
+		{"field:abc", false},
+		{"unknown", false},
+	}
+
+	for _, v := range cases {
+		assert.Equal(t, v.valid, IsViewSortValid(v.sortBy), v.sortBy)
+	}
+}
+
+func TestProjectView(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	project, err := GetProjectByID(db.DefaultContext, 1)
+	require.NoError(t, err)
+
+	view := &View{
+		ProjectID: project.ID,
+		CreatorID: 2,
+		Title:     "Bugs by assignee",
+		Type:      ViewTypeTable,
+		Filter:    "label:bug",
+		GroupBy:   ViewGroupByAssignee,
+		SortBy:    ViewSortTitle,
+	}
+	require.NoError(t, NewView(db.DefaultContext, view))
+
+	second := &View{ProjectID: project.ID, CreatorID: 2, Title: "Roadmap", Type: ViewTypeRoadmap}
+	require.NoError(t, NewView(db.DefaultContext, second))
+	assert.Equal(t, view.Sorting+1, second.Sorting)
+
+	views, err := project.GetViews(db.DefaultContext)
+	require.NoError(t, err)
+	if assert.Len(t, views, 2) {
+		assert.Equal(t, view.ID, views[0].ID)
+		assert.Equal(t, second.ID, views[1].ID)
+	}
+
+	view.Title = "Bugs by label"
+	view.GroupBy = ViewGroupByLabel
+	view.Fields = []int64{1, 2}
+	require.NoError(t, UpdateView(db.DefaultContext, view))
+
+	viewFromDB, err := GetViewByID(db.DefaultContext, project.ID, view.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Bugs by label", viewFromDB.Title)
+	assert.Equal(t, ViewGroupByLabel, viewFromDB.GroupBy)
+	assert.Equal(t, []int64{1, 2}, viewFromDB.Fields)
+
+	// a view can only be found through its own project
+	_, err = GetViewByID(db.DefaultContext, project.ID+1, view.ID)
+	assert.True(t, IsErrProjectViewNotExist(err))
+
+	invalid := &View{ProjectID: project.ID, Title: "Invalid", Type: ViewTypeTable, GroupBy: "poster"}
+	require.ErrorIs(t, NewView(db.DefaultContext, invalid), util.ErrInvalidArgument)
+	invalid = &View{ProjectID: project.ID, Title: "", Type: ViewTypeTable}
+	require.ErrorIs(t, NewView(db.DefaultContext, invalid), util.ErrInvalidArgument)
+
+	require.NoError(t, DeleteViewByID(db.DefaultContext, project.ID, view.ID))
+	assert.True(t, IsErrProjectViewNotExist(DeleteViewByID(db.DefaultContext, project.ID, view.ID)))
+
+	require.NoError(t, DeleteProjectByID(db.DefaultContext, project.ID))
+	unittest.AssertNotExistsBean(t, &View{ID: second.ID})
+}
+
+func TestSetIssueDates(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	start := timeutil.TimeStamp(1700000000)
+	target := start + 14*24*3600
+
+	require.NoError(t, SetIssueDates(db.DefaultContext, 1, 1, start, target))
+	projectIssue := unittest.AssertExistsAndLoadBean(t, &ProjectIssue{ProjectID: 1, IssueID: 1})
+	assert.Equal(t, start, projectIssue.StartDateUnix)
+	assert.Equal(t, target, projectIssue.TargetDateUnix)
+
+	// setting the same dates again is not an error
+	require.NoError(t, SetIssueDates(db.DefaultContext, 1, 1, start, target))
+
+	require.ErrorIs(t, SetIssueDates(db.DefaultContext, 1, 1, target, start), util.ErrInvalidArgument)
+	require.ErrorIs(t, SetIssueDates(db.DefaultContext, 1, 4, start, target), util.ErrNotExist)
+
+	require.NoError(t, SetIssueDates(db.DefaultContext, 1, 1, 0, 0))
+	projectIssue = unittest.AssertExistsAndLoadBean(t, &ProjectIssue{ProjectID: 1, IssueID: 1})
+	assert.Zero(t, projectIssue.StartDateUnix)
+	assert.Zero(t, projectIssue.TargetDateUnix)
+}
@@ -25,9 +25,10 @@ const (
 	LimitSubjectSizeAssetsArtifacts
 	LimitSubjectSizeAssetsPackagesAll
 	LimitSubjectSizeWiki
+	LimitSubjectSizeAssetsTerraformStates
 
 	LimitSubjectFirst = LimitSubjectSizeAll
-	LimitSubjectLast  = LimitSubjectSizeWiki
+	LimitSubjectLast  = LimitSubjectSizeAssetsTerraformStates
 )
 
 var limitSubjectRepr = map[string]LimitSubject{
@@ -45,6 +46,7 @@ var limitSubjectRepr = map[string]LimitSubject{
 	"size:assets:artifacts":            LimitSubjectSizeAssetsArtifacts,
 	"size:assets:packages:all":         LimitSubjectSizeAssetsPackagesAll,
 	"size:assets:wiki":                 LimitSubjectSizeWiki,
+	"size:assets:terraform":            LimitSubjectSizeAssetsTerraformStates,
 }
 
 func (subject LimitSubject) String() string {
@@ -74,6 +74,9 @@ func setUsed(used quota_model.Used, subject quota_model.LimitSubject, value int6
 		used.Size.Assets.Packages.All = value
 		return &used
 	case quota_model.LimitSubjectSizeWiki:
+	case quota_model.LimitSubjectSizeAssetsTerraformStates:
+		used.Size.Assets.TerraformStates = value
+		return &used
 	}
 
 	return nil
@@ -29,6 +29,7 @@ var subjectToParent = map[LimitSubject]LimitSubject{
 	LimitSubjectSizeAssetsArtifacts:           LimitSubjectSizeAssetsAll,
 	LimitSubjectSizeAssetsPackagesAll:         LimitSubjectSizeAssetsAll,
 	LimitSubjectSizeWiki:                      LimitSubjectSizeAssetsAll,
+	LimitSubjectSizeAssetsTerraformStates:     LimitSubjectSizeAssetsAll,
 }
 
 func (r *Rule) TableName() string {
@@ -46,13 +46,14 @@ func (u UsedSizeGit) All(r UsedSizeRepos) int64 {
 }
 
 type UsedSizeAssets struct {
-	Attachments UsedSizeAssetsAttachments
-	Artifacts   int64
-	Packages    UsedSizeAssetsPackages
+	Attachments     UsedSizeAssetsAttachments
+	Artifacts       int64
+	Packages        UsedSizeAssetsPackages
+	TerraformStates int64
 }
 
 func (u UsedSizeAssets) All() int64 {
-	return u.Attachments.All() + u.Artifacts + u.Packages.All
+	return u.Attachments.All() + u.Artifacts + u.Packages.All + u.TerraformStates
 }
 
 type UsedSizeAssetsAttachments struct {
@@ -98,13 +99,15 @@ func (u Used) CalculateFor(subject LimitSubject) int64 {
 		return u.Size.Assets.Packages.All
 	case LimitSubjectSizeWiki:
 		return 0
+	case LimitSubjectSizeAssetsTerraformStates:
+		return u.Size.Assets.TerraformStates
 	}
 	return 0
 }
 
 func makeUserOwnedCondition(q string, userID int64) builder.Cond {
 	switch q {
-	case "repositories", "attachments", "artifacts":
+	case "repositories", "attachments", "artifacts", "terraform_states":
 		return builder.Eq{"`repository`.owner_id": userID}
 	case "packages":
 		return builder.Or(
@@ -140,6 +143,11 @@ func createQueryFor(ctx context.Context, userID int64, q string) db.Engine {
 			Join("INNER", "`package_blob`", "`package_file`.blob_id = `package_blob`.id").
 			Join("INNER", "`package`", "`package_version`.package_id = `package`.id").
 			Join("LEFT OUTER", "`repository`", "`package`.repo_id = `repository`.id")
+	case "terraform_states":
+		session = session.
+			Table("terraform_state_version").
+			Join("INNER", "`terraform_state`", "`terraform_state_version`.state_id = `terraform_state`.id").
+			Join("INNER", "`repository`", "`terraform_state`.repo_id = `repository`.id")
 	}
 
 	return session.Where(makeUserOwnedCondition(q, userID))
@@ -249,5 +257,12 @@ func GetUsedForUser(ctx context.Context, userID int64) (*Used, error) {
 		return nil, err
 	}
 
+	_, err = createQueryFor(ctx, userID, "terraform_states").
+		Select("SUM(`terraform_state_version`.size) AS size").
+		Get(&used.Size.Assets.TerraformStates)
+	if err != nil {
+		return nil, err
+	}
+
 	return &used, nil
 }
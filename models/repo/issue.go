@@ -58,3 +58,13 @@ func (repo *Repository) IsDependenciesEnabled(ctx context.Context) bool {
 	}
 	return u.IssuesConfig().EnableDependencies
 }
+
+// IsCloseParentWithSubIssuesEnabled returns whether a parent issue is closed once its last sub-issue is closed.
+func (repo *Repository) IsCloseParentWithSubIssuesEnabled(ctx context.Context) bool {
+	u, err := repo.GetUnit(ctx, unit.TypeIssues)
+	if err != nil {
+		log.Trace("IsCloseParentWithSubIssuesEnabled: %v", err)
+		return false
+	}
+	return u.IssuesConfig().CloseParentWithSubIssues
+}
@@ -708,6 +708,11 @@ func FindUserCodeAccessibleOwnerRepoIDs(ctx context.Context, ownerID int64, user
 	))
 }
 
+// FindRepoIDsByOwnerIDs finds all repository IDs of the given owners.
+func FindRepoIDsByOwnerIDs(ctx context.Context, ownerIDs []int64) ([]int64, error) {
+	return SearchRepositoryIDsByCondition(ctx, builder.In("owner_id", ownerIDs))
+}
+
 // GetUserRepositories returns a list of repositories of given user.
 func GetUserRepositories(ctx context.Context, opts *SearchRepoOptions) (RepositoryList, int64, error) {
 	if len(opts.OrderBy) == 0 {
@@ -137,6 +137,7 @@ type IssuesConfig struct {
 	EnableTimetracker                bool
 	AllowOnlyContributorsToTrackTime bool
 	EnableDependencies               bool
+	CloseParentWithSubIssues         bool
 }
 
 // FromDB fills up a IssuesConfig from serialized format.
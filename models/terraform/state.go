@@ -0,0 +1,312 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(State))
+	db.RegisterModel(new(StateVersion))
+}
+
+var (
+	ErrStateNotExist        = util.NewNotExistErrorf("terraform state does not exist")
+	ErrStateVersionNotExist = util.NewNotExistErrorf("terraform state version does not exist")
+	ErrInvalidStateName     = util.NewInvalidArgumentErrorf("terraform state name is invalid")
+
+	namePattern = regexp.MustCompile(`\A[A-Za-z0-9][A-Za-z0-9._-]{0,254}\z`)
+)
+
+// IsValidStateName checks the name of a state, it is part of the address of the backend
+func IsValidStateName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// LockInfo is the lock information sent by Terraform, it is shown to clients which fail to acquire the lock
+// https://developer.hashicorp.com/terraform/language/backend/http
+type LockInfo struct {
+	ID        string    `json:"ID"`
+	Operation string    `json:"Operation"`
+	Info      string    `json:"Info"`
+	Who       string    `json:"Who"`
+	Version   string    `json:"Version"`
+	Created   time.Time `json:"Created"`
+	Path      string    `json:"Path"`
+}
+
+// State is a named Terraform state of a repository
+type State struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name        string             `xorm:"UNIQUE(s) NOT NULL"`
+	LockID      string             `xorm:"NOT NULL DEFAULT ''"`
+	LockInfo    string             `xorm:"TEXT"`
+	LockerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	LockedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// TableName sets the table name of the state
+func (State) TableName() string {
+	return "terraform_state"
+}
+
+// IsLocked checks if the state is locked
+func (s *State) IsLocked() bool {
+	return s.LockID != ""
+}
+
+// GetLockInfo gets the lock information of a locked state, nil if the state is not locked
+func (s *State) GetLockInfo() *LockInfo {
+	if !s.IsLocked() {
+		return nil
+	}
+	info := &LockInfo{}
+	if err := json.Unmarshal([]byte(s.LockInfo), info); err != nil || info.ID != s.LockID {
+		// only the id is required to release the lock
+		return &LockInfo{ID: s.LockID}
+	}
+	return info
+}
+
+// StateVersion is a version of a state, the content is stored encrypted in the storage
+type StateVersion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	StateID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Lineage     string             `xorm:"VARCHAR(255)"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	HashSHA256  string             `xorm:"hash_sha256 CHAR(64) NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the state version
+func (StateVersion) TableName() string {
+	return "terraform_state_version"
+}
+
+// StoragePath gets the path of the encrypted content of the version in the storage
+func (v *StateVersion) StoragePath() string {
+	return fmt.Sprintf("%d/%d", v.StateID, v.ID)
+}
+
+// GetStateByName gets a state of a repository by its name
+func GetStateByName(ctx context.Context, repoID int64, name string) (*State, error) {
+	s := &State{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND name = ?", repoID, name).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetStateByID gets a state of a repository by its id
+func GetStateByID(ctx context.Context, repoID, id int64) (*State, error) {
+	s := &State{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND id = ?", repoID, id).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetStatesByRepoID gets all states of a repository
+func GetStatesByRepoID(ctx context.Context, repoID int64) ([]*State, error) {
+	states := make([]*State, 0, 5)
+	return states, db.GetEngine(ctx).Where("repo_id = ?", repoID).OrderBy("name ASC").Find(&states)
+}
+
+// GetOrCreateState gets a state of a repository or creates it if it does not exist
+func GetOrCreateState(ctx context.Context, repoID int64, name string) (*State, error) {
+	if !IsValidStateName(name) {
+		return nil, ErrInvalidStateName
+	}
+
+	s, err := GetStateByName(ctx, repoID, name)
+	if err == nil {
+		return s, nil
+	}
+	if !errors.Is(err, ErrStateNotExist) {
+		return nil, err
+	}
+
+	s = &State{
+		RepoID: repoID,
+		Name:   name,
+	}
+	if _, err := db.GetEngine(ctx).Insert(s); err != nil {
+		// the state may have been created by a concurrent request
+		if s, err := GetStateByName(ctx, repoID, name); err == nil {
+			return s, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// TryLockState locks a state if it is not locked yet and returns false if it is locked already
+func TryLockState(ctx context.Context, s *State, info *LockInfo, lockerID int64) (bool, error) {
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := db.GetEngine(ctx).
+		Where("id = ? AND lock_id = ?", s.ID, "").
+		Cols("lock_id", "lock_info", "locker_id", "locked_unix").
+		NoAutoTime().
+		Update(&State{
+			LockID:     info.ID,
+			LockInfo:   string(raw),
+			LockerID:   lockerID,
+			LockedUnix: timeutil.TimeStampNow(),
+		})
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// UnlockState releases the lock of a state if it is held with the given id and returns false otherwise.
+// An empty id releases any lock.
+func UnlockState(ctx context.Context, s *State, lockID string) (bool, error) {
+	var cond builder.Cond = builder.Eq{"id": s.ID}
+	if lockID != "" {
+		cond = cond.And(builder.Eq{"lock_id": lockID})
+	}
+
+	n, err := db.GetEngine(ctx).
+		Where(cond).
+		Cols("lock_id", "lock_info", "locker_id", "locked_unix").
+		NoAutoTime().
+		Update(&State{})
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// DeleteStateByID deletes a state and its versions
+func DeleteStateByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("state_id = ?", id).Delete(&StateVersion{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(&State{})
+		return err
+	})
+}
+
+// DeleteStatesByRepoID deletes all states of a repository and their versions
+func DeleteStatesByRepoID(ctx context.Context, repoID int64) error {
+	if _, err := db.GetEngine(ctx).
+		Where(builder.In("state_id", builder.Select("id").From("terraform_state").Where(builder.Eq{"repo_id": repoID}))).
+		Delete(&StateVersion{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(&State{})
+	return err
+}
+
+// InsertStateVersion adds a version to a state, the version number is the successor of the latest version
+func InsertStateVersion(ctx context.Context, v *StateVersion) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		var latest int64
+		if _, err := db.GetEngine(ctx).Table("terraform_state_version").Where("state_id = ?", v.StateID).Select("COALESCE(MAX(version), 0)").Get(&latest); err != nil {
+			return err
+		}
+		v.Version = latest + 1
+
+		if _, err := db.GetEngine(ctx).Insert(v); err != nil {
+			return err
+		}
+
+		// the updated time of the state is the time of the latest version
+		_, err := db.GetEngine(ctx).ID(v.StateID).Cols("updated_unix").Update(&State{})
+		return err
+	})
+}
+
+// GetLatestStateVersion gets the latest version of a state
+func GetLatestStateVersion(ctx context.Context, stateID int64) (*StateVersion, error) {
+	v := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ?", stateID).OrderBy("version DESC").Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return v, nil
+}
+
+// GetStateVersion gets a specific version of a state
+func GetStateVersion(ctx context.Context, stateID, version int64) (*StateVersion, error) {
+	v := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ? AND version = ?", stateID, version).Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return v, nil
+}
+
+// GetStateVersions gets the versions of a state, the latest first
+func GetStateVersions(ctx context.Context, stateID int64, opts db.ListOptions) ([]*StateVersion, int64, error) {
+	sess := db.GetEngine(ctx).Where("state_id = ?", stateID).OrderBy("version DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	versions := make([]*StateVersion, 0, 10)
+	count, err := sess.FindAndCount(&versions)
+	return versions, count, err
+}
+
+// GetStateVersionsByRepoID gets the versions of all states of a repository
+func GetStateVersionsByRepoID(ctx context.Context, repoID int64) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).
+		Where(builder.In("state_id", builder.Select("id").From("terraform_state").Where(builder.Eq{"repo_id": repoID}))).
+		Find(&versions)
+}
+
+// GetStateVersionsBeyondLimit gets the versions of a state which exceed the number of versions to keep
+func GetStateVersionsBeyondLimit(ctx context.Context, stateID int64, keep int) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).
+		Where("state_id = ?", stateID).
+		OrderBy("version DESC").
+		Limit(1000, keep).
+		Find(&versions)
+}
+
+// DeleteStateVersionByID deletes a version of a state
+func DeleteStateVersionByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&StateVersion{})
+	return err
+}
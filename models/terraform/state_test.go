@@ -0,0 +1,87 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidStateName(t *testing.T) {
+	for _, name := range []string{"default", "prod.eu-west_1", "A1"} {
+		assert.True(t, IsValidStateName(name), name)
+	}
+	for _, name := range []string{"", ".hidden", "a/b", "a b"} {
+		assert.False(t, IsValidStateName(name), name)
+	}
+}
+
+func TestStateLock(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	s, err := GetOrCreateState(ctx, 1, "default")
+	require.NoError(t, err)
+	assert.False(t, s.IsLocked())
+	assert.Nil(t, s.GetLockInfo())
+
+	locked, err := TryLockState(ctx, s, &LockInfo{ID: "first", Who: "someone"}, 2)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = TryLockState(ctx, s, &LockInfo{ID: "second"}, 2)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	s, err = GetOrCreateState(ctx, 1, "default")
+	require.NoError(t, err)
+	assert.True(t, s.IsLocked())
+	assert.Equal(t, "someone", s.GetLockInfo().Who)
+	assert.EqualValues(t, 2, s.LockerID)
+
+	unlocked, err := UnlockState(ctx, s, "second")
+	require.NoError(t, err)
+	assert.False(t, unlocked)
+
+	unlocked, err = UnlockState(ctx, s, "first")
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+
+	s, err = GetStateByName(ctx, 1, "default")
+	require.NoError(t, err)
+	assert.False(t, s.IsLocked())
+}
+
+func TestStateVersions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	s, err := GetOrCreateState(ctx, 1, "versions")
+	require.NoError(t, err)
+
+	for serial := int64(1); serial <= 3; serial++ {
+		require.NoError(t, InsertStateVersion(ctx, &StateVersion{StateID: s.ID, Serial: serial, HashSHA256: "0"}))
+	}
+
+	latest, err := GetLatestStateVersion(ctx, s.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, latest.Version)
+	assert.EqualValues(t, 3, latest.Serial)
+
+	pruned, err := GetStateVersionsBeyondLimit(ctx, s.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.EqualValues(t, 1, pruned[0].Version)
+
+	require.NoError(t, DeleteStatesByRepoID(ctx, 1))
+	_, err = GetStateByName(ctx, 1, "versions")
+	require.ErrorIs(t, err, ErrStateNotExist)
+	_, err = GetLatestStateVersion(ctx, s.ID)
+	require.ErrorIs(t, err, ErrStateVersionNotExist)
+}
@@ -10,6 +10,10 @@ const (
 	SettingsKeyDiffWhitespaceBehavior = "diff.whitespace_behaviour"
 	// SettingsKeyShowOutdatedComments is the setting key whether or not to show outdated comments in PRs
 	SettingsKeyShowOutdatedComments = "comment_code.show_outdated"
+	// SettingsKeyPackagesRequireSignature is the setting key whether the packages of an owner must be signed
+	SettingsKeyPackagesRequireSignature = "packages.require_signature"
+	// SettingsKeyPackagesImmutableTypes is the setting key for the package types whose release versions are immutable
+	SettingsKeyPackagesImmutableTypes = "packages.immutable_types"
 	// UserActivityPubPrivPem is user's private key
 	UserActivityPubPrivPem = "activitypub.priv_pem"
 	// UserActivityPubPubPem is user's public key
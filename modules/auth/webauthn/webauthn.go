@@ -75,3 +75,12 @@ func (u *User) WebAuthnCredentials() []webauthn.Credential {
 
 	return dbCreds.ToCredentials()
 }
+
+// UserIDFromWebAuthnID returns the ID of the user with the given WebAuthnID, the user handle of discoverable credentials
+func UserIDFromWebAuthnID(id []byte) (int64, bool) {
+	userID, n := binary.Varint(id)
+	if n <= 0 || userID <= 0 {
+		return 0, false
+	}
+	return userID, true
+}
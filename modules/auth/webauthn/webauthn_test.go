@@ -23,3 +23,16 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, setting.AppName, WebAuthn.Config.RPDisplayName)
 	assert.Equal(t, []string{"https://domain"}, WebAuthn.Config.RPOrigins)
 }
+
+func TestUserIDFromWebAuthnID(t *testing.T) {
+	for _, id := range []int64{1, 127, 128, 1 << 40} {
+		userID, ok := UserIDFromWebAuthnID((&User{ID: id}).WebAuthnID())
+		assert.True(t, ok)
+		assert.Equal(t, id, userID)
+	}
+
+	_, ok := UserIDFromWebAuthnID(nil)
+	assert.False(t, ok)
+	_, ok = UserIDFromWebAuthnID(make([]byte, 8))
+	assert.False(t, ok)
+}
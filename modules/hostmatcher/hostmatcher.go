@@ -7,6 +7,9 @@ import (
 	"net"
 	"path/filepath"
 	"strings"
+	"unicode"
+
+	"forgejo.org/modules/util"
 )
 
 // HostMatchList is used to check if a host or IP is in a list.
@@ -55,6 +58,32 @@ func ParseHostMatchList(settingKeyHint, hostList string) *HostMatchList {
 	return hl
 }
 
+// ParseIPNetList parses a list of IP networks in CIDR notation separated by commas or whitespace, single IP addresses
+// are handled as networks of one address. Unlike ParseHostMatchList, it fails for anything else, so the list can be used
+// to restrict the addresses of clients. The SettingValue of the result is the normalized list.
+func ParseIPNetList(settingKeyHint, list string) (*HostMatchList, error) {
+	hl := &HostMatchList{SettingKeyHint: settingKeyHint}
+	normalized := make([]string, 0, 4)
+	for _, s := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, util.NewInvalidArgumentErrorf("invalid IP network: %s", s)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		}
+		hl.ipNets = append(hl.ipNets, ipNet)
+		normalized = append(normalized, ipNet.String())
+	}
+	hl.SettingValue = strings.Join(normalized, ", ")
+	return hl, nil
+}
+
 // ParseSimpleMatchList parse a simple matchlist (no built-in networks, no CIDR support, only wildcard pattern match)
 func ParseSimpleMatchList(settingKeyHint, matchList string) *HostMatchList {
 	hl := &HostMatchList{
@@ -7,7 +7,10 @@ import (
 	"net"
 	"testing"
 
+	"forgejo.org/modules/util"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHostOrIPMatchesList(t *testing.T) {
@@ -159,3 +162,26 @@ func TestHostOrIPMatchesList(t *testing.T) {
 	}
 	test(cases)
 }
+
+func TestParseIPNetList(t *testing.T) {
+	hl, err := ParseIPNetList("", " 10.0.0.0/8,192.168.1.7\n2001:db8::/32 ")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8, 192.168.1.7/32, 2001:db8::/32", hl.SettingValue)
+
+	assert.True(t, hl.MatchHostName("10.1.2.3"))
+	assert.True(t, hl.MatchHostName("192.168.1.7:22"))
+	assert.True(t, hl.MatchHostName("::ffff:192.168.1.7"))
+	assert.True(t, hl.MatchHostName("2001:db8::1"))
+	assert.False(t, hl.MatchHostName("192.168.1.8"))
+	assert.False(t, hl.MatchHostName("127.0.0.1"))
+	assert.False(t, hl.MatchHostName(""))
+
+	hl, err = ParseIPNetList("", "")
+	require.NoError(t, err)
+	assert.True(t, hl.IsEmpty())
+
+	for _, list := range []string{"private", "*.example.com", "10.0.0.0/33", "10.0.0"} {
+		_, err := ParseIPNetList("", list)
+		require.ErrorIs(t, err, util.ErrInvalidArgument, list)
+	}
+}
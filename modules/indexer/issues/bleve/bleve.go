@@ -23,7 +23,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 7
+	issueIndexerLatestVersion = 8
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -87,6 +87,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("reviewed_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("review_requested_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("subscriber_ids", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("parent_id", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("updated_unix", numberFieldMapping)
 
 	docMapping.AddFieldMappingsAt("created_unix", numberFieldMapping)
@@ -194,6 +195,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 		filters = append(filters, bleve.NewDisjunctionQuery(repoQueries...))
 	}
+	for _, repoID := range options.ExcludedRepoIDs {
+		q.AddMustNot(inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+	}
 
 	if has, value := options.PriorityRepoID.Get(); has {
 		eq := inner_bleve.NumericEqualityQuery(value, "repo_id")
@@ -250,12 +254,21 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		"reviewed_ids":         options.ReviewedID,
 		"review_requested_ids": options.ReviewRequestedID,
 		"subscriber_ids":       options.SubscriberID,
+		"parent_id":            options.ParentID,
 	} {
 		if has, value := val.Get(); has {
 			filters = append(filters, inner_bleve.NumericEqualityQuery(value, key))
 		}
 	}
 
+	if has, value := options.HasParent.Get(); has {
+		if value {
+			filters = append(filters, inner_bleve.NumericRangeInclusiveQuery(optional.Some[int64](1), optional.None[int64](), "parent_id"))
+		} else {
+			filters = append(filters, inner_bleve.NumericEqualityQuery(0, "parent_id"))
+		}
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		filters = append(filters, inner_bleve.NumericRangeInclusiveQuery(
 			options.UpdatedAfterUnix,
@@ -53,6 +53,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		Paginator:          options.Paginator,
 		RepoIDs:            options.RepoIDs,
 		AllPublic:          options.AllPublic,
+		ExcludedRepoIDs:    options.ExcludedRepoIDs,
 		RepoCond:           nil,
 		AssigneeID:         convertID(options.AssigneeID),
 		PosterID:           convertID(options.PosterID),
@@ -62,6 +63,8 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		SubscriberID:       convertID(options.SubscriberID),
 		ProjectID:          convertID(options.ProjectID),
 		ProjectColumnID:    convertID(options.ProjectColumnID),
+		ParentID:           convertID(options.ParentID),
+		HasParent:          options.HasParent,
 		IsClosed:           options.IsClosed,
 		IsPull:             options.IsPull,
 		IncludedLabelNames: nil,
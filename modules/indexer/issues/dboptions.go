@@ -13,10 +13,11 @@ import (
 
 func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.IssuesOptions) *SearchOptions {
 	searchOpt := &SearchOptions{
-		RepoIDs:   opts.RepoIDs,
-		AllPublic: opts.AllPublic,
-		IsPull:    opts.IsPull,
-		IsClosed:  opts.IsClosed,
+		RepoIDs:         opts.RepoIDs,
+		AllPublic:       opts.AllPublic,
+		ExcludedRepoIDs: opts.ExcludedRepoIDs,
+		IsPull:          opts.IsPull,
+		IsClosed:        opts.IsClosed,
 	}
 
 	if len(opts.LabelIDs) == 1 && opts.LabelIDs[0] == 0 {
@@ -68,6 +69,8 @@ func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.Iss
 	searchOpt.ReviewedID = convertID(opts.ReviewedID)
 	searchOpt.ReviewRequestedID = convertID(opts.ReviewRequestedID)
 	searchOpt.SubscriberID = convertID(opts.SubscriberID)
+	searchOpt.ParentID = convertID(opts.ParentID)
+	searchOpt.HasParent = opts.HasParent
 
 	if opts.UpdatedAfterUnix > 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(opts.UpdatedAfterUnix)
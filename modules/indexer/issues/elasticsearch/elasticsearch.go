@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 3
+	issueIndexerLatestVersion = 4
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -74,6 +74,7 @@ const (
 			"reviewed_ids": { "type": "long", "index": true },
 			"review_requested_ids": { "type": "long", "index": true },
 			"subscriber_ids": { "type": "long", "index": true },
+			"parent_id": { "type": "long", "index": true },
 			"updated_unix": { "type": "long", "index": true },
 
 			"created_unix": { "type": "long", "index": true },
@@ -184,6 +185,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 		query.Must(q)
 	}
+	if len(options.ExcludedRepoIDs) > 0 {
+		query.MustNot(elastic.NewTermsQuery("repo_id", toAnySlice(options.ExcludedRepoIDs)...))
+	}
 	if has, value := options.PriorityRepoID.Get(); has {
 		q := elastic.NewTermQuery("repo_id", value).Boost(10)
 		query.Should(q)
@@ -251,6 +255,17 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(elastic.NewTermQuery("subscriber_ids", value))
 	}
 
+	if has, value := options.ParentID.Get(); has {
+		query.Must(elastic.NewTermQuery("parent_id", value))
+	}
+	if has, value := options.HasParent.Get(); has {
+		if value {
+			query.Must(elastic.NewRangeQuery("parent_id").Gte(1))
+		} else {
+			query.Must(elastic.NewTermQuery("parent_id", 0))
+		}
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		q := elastic.NewRangeQuery("updated_unix")
 		if has, value := options.UpdatedAfterUnix.Get(); has {
@@ -35,6 +35,7 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	ParentID           int64              `json:"parent_id"` // zero if the issue is not a sub-issue
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -75,9 +76,10 @@ type SearchResult struct {
 type SearchOptions struct {
 	Tokens []Token
 
-	RepoIDs        []int64                // repository IDs which the issues belong to
-	AllPublic      bool                   // if include all public repositories
-	PriorityRepoID optional.Option[int64] // issues from this repository will be prioritized when SortByScore
+	RepoIDs         []int64                // repository IDs which the issues belong to
+	AllPublic       bool                   // if include all public repositories
+	ExcludedRepoIDs []int64                // repository IDs which the issues don't belong to, even if they are public
+	PriorityRepoID  optional.Option[int64] // issues from this repository will be prioritized when SortByScore
 
 	IsPull   optional.Option[bool] // if the issues is a pull request
 	IsClosed optional.Option[bool] // if the issues is closed
@@ -103,6 +105,9 @@ type SearchOptions struct {
 
 	SubscriberID optional.Option[int64] // subscriber of the issues
 
+	ParentID  optional.Option[int64] // parent issue of the issues, zero means no parent
+	HasParent optional.Option[bool]  // if the issues are sub-issues of another issue
+
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
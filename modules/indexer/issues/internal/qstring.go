@@ -5,14 +5,19 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
 )
 
 type BoolOpt int
@@ -135,6 +140,7 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 		tokens     []Token
 		userNames  []string
 		userFilter []userFilter
+		parentErr  error
 	)
 
 	for token, err := it.next(); err == nil; token, err = it.next() {
@@ -159,6 +165,10 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 		case token.Term == "is:closed":
 			o.IsClosed = optional.Some(token.Kind != BoolOptNot)
 
+		// has:parent => sub-issues only & -has:parent => top-level issues only
+		case token.Term == "has:parent":
+			o.HasParent = optional.Some(token.Kind != BoolOptNot)
+
 		// The rest of the presets MUST NOT be a negation.
 		case token.Kind == BoolOptNot:
 			tokens = append(tokens, token)
@@ -200,6 +210,19 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 			userNames = append(userNames, token.Term[9:])
 			userFilter = append(userFilter, userFilterMention)
 
+		// parent:<owner>/<repo>#<index>, or parent:<index> in the single repository searched.
+		case token.IsOf("parent:"):
+			parentID, err := o.resolveParent(ctx, token.Term[7:])
+			if err != nil {
+				if !errors.Is(err, util.ErrInvalidArgument) {
+					return err
+				}
+				// apply the other filters, but never search for the reference as a keyword
+				parentErr = err
+				continue
+			}
+			o.ParentID = optional.Some(parentID)
+
 		default:
 			tokens = append(tokens, token)
 		}
@@ -234,7 +257,45 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 		}
 	}
 
-	return nil
+	return parentErr
+}
+
+// resolveParent returns the ID of the issue referenced by the parent: qualifier.
+// A reference without a repository can only be resolved if a single repository is searched.
+func (o *SearchOptions) resolveParent(ctx context.Context, ref string) (int64, error) {
+	repoID, term := int64(0), ref
+	if pos := strings.LastIndexAny(ref, "#!"); pos > 0 {
+		ownerName, repoName, ok := strings.Cut(ref[:pos], "/")
+		if !ok {
+			return 0, util.NewInvalidArgumentErrorf("invalid parent reference %q", ref)
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil && !repo_model.IsErrRepoNotExist(err) {
+			return 0, err
+		}
+		// do not reveal the private repositories which are not searched
+		if repo == nil || repo.IsPrivate && !slices.Contains(o.RepoIDs, repo.ID) {
+			return 0, util.NewInvalidArgumentErrorf("parent issue %s does not exist", ref)
+		}
+		repoID, term = repo.ID, ref[pos:]
+	} else if len(o.RepoIDs) == 1 {
+		repoID = o.RepoIDs[0]
+	} else {
+		return 0, util.NewInvalidArgumentErrorf("parent %q must be referenced as owner/repo#index when searching several repositories", ref)
+	}
+
+	index, err := (&Token{Term: term}).ParseIssueReference()
+	if err != nil {
+		return 0, util.NewInvalidArgumentErrorf("invalid parent reference %q", ref)
+	}
+	parent, err := issues_model.GetIssueByIndex(ctx, repoID, index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			return 0, util.NewInvalidArgumentErrorf("parent issue %s does not exist", ref)
+		}
+		return 0, err
+	}
+	return parent.ID, nil
 }
 
 func toUnix(value string) optional.Option[int64] {
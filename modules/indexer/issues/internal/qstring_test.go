@@ -10,6 +10,7 @@ import (
 	"forgejo.org/models/unittest"
 	"forgejo.org/models/user"
 	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -376,6 +377,18 @@ func TestIssueQueryStringWithFilters(t *testing.T) {
 				SortBy:            SortByCommentsDesc,
 			},
 		},
+		{
+			Keyword: "has:parent",
+			Opts: &SearchOptions{
+				HasParent: optional.Some(true),
+			},
+		},
+		{
+			Keyword: "-has:parent",
+			Opts: &SearchOptions{
+				HasParent: optional.Some(false),
+			},
+		},
 
 		// Edge Cases
 		{
@@ -463,3 +476,50 @@ func TestToken_ParseIssueReference(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestIssueQueryStringParent(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue 1 is user2/repo1#1, issue 4 is user2/repo2#1 of the private repo2
+	for _, c := range []struct {
+		Keyword  string
+		RepoIDs  []int64
+		ParentID int64
+	}{
+		{Keyword: "parent:#1", RepoIDs: []int64{1}, ParentID: 1},
+		{Keyword: "parent:1", RepoIDs: []int64{2}, ParentID: 4},
+		{Keyword: "parent:user2/repo1#1", RepoIDs: []int64{1, 2}, ParentID: 1},
+		{Keyword: "parent:user2/repo1#1", ParentID: 1},
+		{Keyword: "parent:user2/repo2#1", RepoIDs: []int64{1, 2}, ParentID: 4},
+	} {
+		t.Run(c.Keyword, func(t *testing.T) {
+			opts := &SearchOptions{RepoIDs: c.RepoIDs}
+			require.NoError(t, opts.WithKeyword(t.Context(), c.Keyword))
+			assert.Equal(t, optional.Some(c.ParentID), opts.ParentID)
+			assert.Empty(t, opts.Tokens)
+		})
+	}
+
+	// the reference is rejected instead of being searched as a keyword
+	for _, c := range []struct {
+		Keyword string
+		RepoIDs []int64
+	}{
+		{Keyword: "parent:#1", RepoIDs: []int64{1, 2}},
+		{Keyword: "parent:#1"},
+		{Keyword: "parent:#999", RepoIDs: []int64{1}},
+		{Keyword: "parent:user2/repo1#999", RepoIDs: []int64{1, 2}},
+		{Keyword: "parent:user2/unknown#1", RepoIDs: []int64{1, 2}},
+		{Keyword: "parent:repo1#1", RepoIDs: []int64{1, 2}},
+		{Keyword: "parent:user2/repo2#1", RepoIDs: []int64{1}},
+	} {
+		t.Run(c.Keyword, func(t *testing.T) {
+			opts := &SearchOptions{RepoIDs: c.RepoIDs}
+			err := opts.WithKeyword(t.Context(), c.Keyword+" is:closed")
+			require.ErrorIs(t, err, util.ErrInvalidArgument)
+			assert.False(t, opts.ParentID.Has())
+			assert.Empty(t, opts.Tokens)
+			assert.Equal(t, optional.Some(true), opts.IsClosed)
+		})
+	}
+}
@@ -558,6 +558,60 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "ParentID",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			ParentID: optional.Some(int64(1)),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 4)
+			for _, v := range result.Hits {
+				assert.Equal(t, int64(1), data[v.ID].ParentID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ParentID == 1
+			}), result.Total)
+		},
+	},
+	{
+		Name: "HasParent",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			HasParent: optional.Some(true),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.NotZero(t, data[v.ID].ParentID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ParentID != 0
+			}), result.Total)
+		},
+	},
+	{
+		Name: "no parent",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			HasParent: optional.Some(false),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Zero(t, data[v.ID].ParentID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ParentID == 0
+			}), result.Total)
+		},
+	},
 	{
 		Name: "updated",
 		SearchOptions: &internal.SearchOptions{
@@ -848,6 +902,11 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				assigneeIDs = append(assigneeIDs, issueIndex%10)
 			}
 
+			var parentID int64
+			if issueIndex%5 == 0 { // every fifth issue is a sub-issue of the first issue of its repository
+				parentID = id - issueIndex + 1
+			}
+
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
 				Index:              issueIndex,
@@ -869,6 +928,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				ParentID:           parentID,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 4
+	issueIndexerLatestVersion = 5
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -72,6 +72,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"reviewed_ids",
 			"review_requested_ids",
 			"subscriber_ids",
+			"parent_id",
 			"updated_unix",
 		},
 		SortableAttributes: []string{
@@ -138,6 +139,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		}
 		query.And(q)
 	}
+	if len(options.ExcludedRepoIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterNot(inner_meilisearch.NewFilterIn("repo_id", options.ExcludedRepoIDs...)))
+	}
 
 	if has, value := options.IsPull.Get(); has {
 		query.And(inner_meilisearch.NewFilterEq("is_pull", value))
@@ -201,6 +205,17 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterEq("subscriber_ids", value))
 	}
 
+	if has, value := options.ParentID.Get(); has {
+		query.And(inner_meilisearch.NewFilterEq("parent_id", value))
+	}
+	if has, value := options.HasParent.Get(); has {
+		if value {
+			query.And(inner_meilisearch.NewFilterGte("parent_id", int64(1)))
+		} else {
+			query.And(inner_meilisearch.NewFilterEq("parent_id", int64(0)))
+		}
+	}
+
 	if has, value := options.UpdatedAfterUnix.Get(); has {
 		query.And(inner_meilisearch.NewFilterGte("updated_unix", value))
 	}
@@ -101,6 +101,11 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		projectID = issue.Project.ID
 	}
 
+	parentID, err := issues_model.GetParentIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
 	return &internal.IndexerData{
 		ID:                 issue.ID,
 		RepoID:             issue.RepoID,
@@ -122,6 +127,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		ParentID:           parentID,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
@@ -41,6 +41,12 @@ var (
 	MigrateTask = deriveKey("migrate_repo_task")
 	// Used for the `webhook` table.
 	Webhook = deriveKey("webhook")
+	// Used for the `package_remote` table.
+	PackageRemote = deriveKey("package_remote")
+	// Used for the content of the `terraform_state_version` table.
+	TerraformState = deriveKey("terraform_state")
+	// Used for the `ssh_certificate_authority` table.
+	SSHCertificateAuthority = deriveKey("ssh_certificate_authority")
 )
 
 var (
@@ -0,0 +1,242 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package lockfile extracts the pinned dependencies from the lockfiles of package managers
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/util"
+)
+
+// MaxSize is the maximum size of a lockfile which gets parsed
+const MaxSize = 32 * 1024 * 1024
+
+var ErrInvalidLockfile = util.NewInvalidArgumentErrorf("invalid lockfile")
+
+// Dependency is a package version a lockfile pins
+type Dependency struct {
+	Ecosystem string
+	Name      string
+	Version   string
+}
+
+type parser func(content []byte) ([]*Dependency, error)
+
+var parsers = map[string]parser{
+	"go.sum":            parseGoSum,
+	"package-lock.json": parsePackageLock,
+	"Cargo.lock":        parseCargoLock,
+	"requirements.txt":  parseRequirements,
+}
+
+// IsLockfile checks if the file at the path is a supported lockfile
+func IsLockfile(filePath string) bool {
+	_, ok := parsers[path.Base(filePath)]
+	return ok
+}
+
+// Parse returns the dependencies pinned by the lockfile at the path, sorted by their names
+func Parse(filePath string, content []byte) ([]*Dependency, error) {
+	p, ok := parsers[path.Base(filePath)]
+	if !ok {
+		return nil, ErrInvalidLockfile
+	}
+
+	deps, err := p(content)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Dependency]bool, len(deps))
+	unique := make([]*Dependency, 0, len(deps))
+	for _, d := range deps {
+		if d.Name == "" || d.Version == "" || seen[*d] {
+			continue
+		}
+		seen[*d] = true
+		unique = append(unique, d)
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Name != unique[j].Name {
+			return unique[i].Name < unique[j].Name
+		}
+		return unique[i].Version < unique[j].Version
+	})
+	return unique, nil
+}
+
+// parseGoSum parses the module versions of a go.sum file
+func parseGoSum(content []byte) ([]*Dependency, error) {
+	deps := make([]*Dependency, 0, 50)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, ErrInvalidLockfile
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: osv.EcosystemGo,
+			Name:      fields[0],
+			Version:   strings.TrimSuffix(fields[1], "/go.mod"),
+		})
+	}
+	return deps, scanner.Err()
+}
+
+type npmLockDependency struct {
+	Version      string                        `json:"version"`
+	Link         bool                          `json:"link"`
+	Dependencies map[string]*npmLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock parses the installed packages of a package-lock.json file of any version
+func parsePackageLock(content []byte) ([]*Dependency, error) {
+	var lock struct {
+		Packages     map[string]*npmLockDependency `json:"packages"`
+		Dependencies map[string]*npmLockDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, ErrInvalidLockfile
+	}
+
+	deps := make([]*Dependency, 0, len(lock.Packages)+len(lock.Dependencies))
+	add := func(name string, d *npmLockDependency) {
+		// linked packages are part of the project and aliases or local paths have no registry version
+		if d == nil || d.Link || strings.Contains(d.Version, ":") {
+			return
+		}
+		deps = append(deps, &Dependency{Ecosystem: osv.EcosystemNpm, Name: name, Version: d.Version})
+	}
+
+	if len(lock.Packages) > 0 {
+		for key, d := range lock.Packages {
+			_, name, ok := cutLast(key, "node_modules/")
+			if !ok {
+				continue
+			}
+			add(name, d)
+		}
+		return deps, nil
+	}
+
+	var walk func(map[string]*npmLockDependency)
+	walk = func(m map[string]*npmLockDependency) {
+		for name, d := range m {
+			add(name, d)
+			if d != nil {
+				walk(d.Dependencies)
+			}
+		}
+	}
+	walk(lock.Dependencies)
+	return deps, nil
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// parseCargoLock parses the crates of the registry in a Cargo.lock file
+func parseCargoLock(content []byte) ([]*Dependency, error) {
+	deps := make([]*Dependency, 0, 50)
+
+	var current *Dependency
+	var source string
+	flush := func() {
+		// crates without a source are members of the workspace
+		if current != nil && strings.HasPrefix(source, "registry+") {
+			deps = append(deps, current)
+		}
+		current = nil
+		source = ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			if line == "[[package]]" {
+				current = &Dependency{Ecosystem: osv.EcosystemCargo}
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if !strings.HasPrefix(value, `"`) {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+
+		switch strings.TrimSpace(key) {
+		case "name":
+			current.Name = value
+		case "version":
+			current.Version = value
+		case "source":
+			source = value
+		}
+	}
+	flush()
+	return deps, scanner.Err()
+}
+
+// parseRequirements parses the pinned packages of a pip requirements file
+func parseRequirements(content []byte) ([]*Dependency, error) {
+	deps := make([]*Dependency, 0, 50)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line, _, _ = strings.Cut(line, ";")
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), `\`))
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		name, version, ok := strings.Cut(line, "==")
+		if !ok {
+			continue
+		}
+		version = strings.TrimPrefix(version, "=")
+		if fields := strings.Fields(version); len(fields) > 0 {
+			version = fields[0]
+		}
+		if strings.Contains(version, "*") {
+			continue
+		}
+		name, _, _ = strings.Cut(name, "[")
+
+		deps = append(deps, &Dependency{
+			Ecosystem: osv.EcosystemPyPI,
+			Name:      strings.TrimSpace(name),
+			Version:   strings.TrimSpace(version),
+		})
+	}
+	return deps, scanner.Err()
+}
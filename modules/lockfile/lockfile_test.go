@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package lockfile
+
+import (
+	"testing"
+
+	"forgejo.org/modules/osv"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLockfile(t *testing.T) {
+	assert.True(t, IsLockfile("go.sum"))
+	assert.True(t, IsLockfile("web/package-lock.json"))
+	assert.True(t, IsLockfile("Cargo.lock"))
+	assert.True(t, IsLockfile("requirements.txt"))
+	assert.False(t, IsLockfile("package.json"))
+	assert.False(t, IsLockfile("go.mod"))
+}
+
+func TestParse(t *testing.T) {
+	t.Run("GoSum", func(t *testing.T) {
+		deps, err := Parse("go.sum", []byte(`example.com/a v1.2.3 h1:abc=
+example.com/a v1.2.3/go.mod h1:def=
+example.com/b v0.0.0-20240101000000-abcdef123456/go.mod h1:ghi=
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: osv.EcosystemGo, Name: "example.com/a", Version: "v1.2.3"},
+			{Ecosystem: osv.EcosystemGo, Name: "example.com/b", Version: "v0.0.0-20240101000000-abcdef123456"},
+		}, deps)
+
+		_, err = Parse("go.sum", []byte("invalid"))
+		require.ErrorIs(t, err, ErrInvalidLockfile)
+	})
+
+	t.Run("PackageLock", func(t *testing.T) {
+		deps, err := Parse("package-lock.json", []byte(`{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "project", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.20"},
+    "node_modules/@scope/pkg": {"version": "2.0.0"},
+    "node_modules/a/node_modules/lodash": {"version": "3.10.1"},
+    "node_modules/local": {"resolved": "packages/local", "link": true},
+    "packages/local": {"version": "0.1.0"}
+  }
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: osv.EcosystemNpm, Name: "@scope/pkg", Version: "2.0.0"},
+			{Ecosystem: osv.EcosystemNpm, Name: "lodash", Version: "3.10.1"},
+			{Ecosystem: osv.EcosystemNpm, Name: "lodash", Version: "4.17.20"},
+		}, deps)
+
+		deps, err = Parse("package-lock.json", []byte(`{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}},
+    "c": {"version": "file:../c"}
+  }
+}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: osv.EcosystemNpm, Name: "a", Version: "1.0.0"},
+			{Ecosystem: osv.EcosystemNpm, Name: "b", Version: "2.0.0"},
+		}, deps)
+	})
+
+	t.Run("CargoLock", func(t *testing.T) {
+		deps, err := Parse("Cargo.lock", []byte(`# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "project"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.100"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "abc"
+
+[metadata]
+name = "ignored"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: osv.EcosystemCargo, Name: "serde", Version: "1.0.100"},
+		}, deps)
+	})
+
+	t.Run("Requirements", func(t *testing.T) {
+		deps, err := Parse("requirements.txt", []byte(`# comment
+-r other.txt
+--index-url https://pypi.example.com
+Django==3.2.1 # pinned
+requests[security]==2.25.0 ; python_version > "3.6"
+flask>=2.0
+numpy==1.*
+urllib3===1.26.0 \
+    --hash=sha256:abc
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{
+			{Ecosystem: osv.EcosystemPyPI, Name: "Django", Version: "3.2.1"},
+			{Ecosystem: osv.EcosystemPyPI, Name: "requests", Version: "2.25.0"},
+			{Ecosystem: osv.EcosystemPyPI, Name: "urllib3", Version: "1.26.0"},
+		}, deps)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package lockfile
+
+import (
+	"io"
+	"strings"
+
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/log"
+)
+
+// Manifest is a lockfile in a tree and the dependencies it pins
+type Manifest struct {
+	Path         string
+	Dependencies []*Dependency
+}
+
+// IsVendored checks if the path is inside a directory of installed dependencies,
+// whose lockfiles are no part of the project
+func IsVendored(p string) bool {
+	for _, dir := range []string{"node_modules/", "vendor/"} {
+		if strings.HasPrefix(p, dir) || strings.Contains(p, "/"+dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindManifests parses all supported lockfiles in the tree of the commit.
+// Lockfiles which cannot be parsed are skipped.
+func FindManifests(commit *git.Commit) ([]*Manifest, error) {
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*Manifest
+	for _, te := range entries {
+		p := te.Name()
+		if !te.IsRegular() || te.Size() > MaxSize || !IsLockfile(p) || IsVendored(p) {
+			continue
+		}
+
+		content, err := readBlob(te)
+		if err != nil {
+			return nil, err
+		}
+		deps, err := Parse(p, content)
+		if err != nil {
+			log.Debug("Unable to parse lockfile %s of commit %s: %v", p, commit.ID, err)
+			continue
+		}
+		manifests = append(manifests, &Manifest{Path: p, Dependencies: deps})
+	}
+	return manifests, nil
+}
+
+func readBlob(te *git.TreeEntry) ([]byte, error) {
+	r, err := te.Blob().DataAsync()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package osv
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"forgejo.org/modules/util"
+)
+
+// MaxEntrySize is the maximum size of a file containing entries
+const MaxEntrySize = 16 * 1024 * 1024
+
+var ErrUnsupportedArchive = util.NewInvalidArgumentErrorf("the archive must be a zip or a gzip compressed tar archive")
+
+func isEntryFile(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".json")
+}
+
+func readEntries(r io.Reader, fn func(*Entry) error) error {
+	entries, err := Read(io.LimitReader(r, MaxEntrySize))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkDirectory calls fn for the entries of all JSON files in the directory and its subdirectories
+func WalkDirectory(dir string, fn func(*Entry) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isEntryFile(d.Name()) {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return readEntries(f, fn)
+	})
+}
+
+// WalkArchive calls fn for the entries of all JSON files in a zip or a gzip compressed tar archive,
+// like the exports of the OSV databases
+func WalkArchive(r io.ReaderAt, size int64, fn func(*Entry) error) error {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")):
+		return walkZip(r, size, fn)
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return walkTarGz(io.NewSectionReader(r, 0, size), fn)
+	}
+	return ErrUnsupportedArchive
+}
+
+func walkZip(r io.ReaderAt, size int64, fn func(*Entry) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return errors.Join(ErrUnsupportedArchive, err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isEntryFile(f.Name) {
+			continue
+		}
+		if err := func() error {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+
+			return readEntries(rc, fn)
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTarGz(r io.Reader, fn func(*Entry) error) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Join(ErrUnsupportedArchive, err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Join(ErrUnsupportedArchive, err)
+		}
+		if hd.Typeflag != tar.TypeReg || !isEntryFile(hd.Name) {
+			continue
+		}
+		if err := readEntries(tr, fn); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package osv reads vulnerability advisories in the Open Source Vulnerability format
+// and matches them against package versions, see https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+)
+
+// Ecosystems of the packages the advisories are matched against
+const (
+	EcosystemCargo     = "crates.io"
+	EcosystemCRAN      = "CRAN"
+	EcosystemGo        = "Go"
+	EcosystemHex       = "Hex"
+	EcosystemMaven     = "Maven"
+	EcosystemNpm       = "npm"
+	EcosystemNuGet     = "NuGet"
+	EcosystemPackagist = "Packagist"
+	EcosystemPub       = "Pub"
+	EcosystemPyPI      = "PyPI"
+	EcosystemRubyGems  = "RubyGems"
+)
+
+// Range types of the affected versions
+const (
+	RangeTypeSemver    = "SEMVER"
+	RangeTypeEcosystem = "ECOSYSTEM"
+	RangeTypeGit       = "GIT"
+)
+
+var ErrInvalidEntry = util.NewInvalidArgumentErrorf("invalid OSV entry")
+
+// Entry is a vulnerability advisory
+type Entry struct {
+	SchemaVersion    string         `json:"schema_version,omitempty"`
+	ID               string         `json:"id"`
+	Modified         time.Time      `json:"modified"`
+	Published        *time.Time     `json:"published,omitempty"`
+	Withdrawn        *time.Time     `json:"withdrawn,omitempty"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Summary          string         `json:"summary,omitempty"`
+	Details          string         `json:"details,omitempty"`
+	Severity         []Severity     `json:"severity,omitempty"`
+	Affected         []Affected     `json:"affected,omitempty"`
+	References       []Reference    `json:"references,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+// Severity is a quantitative severity score of a vulnerability
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected lists the affected versions of a package
+type Affected struct {
+	Package           Package        `json:"package"`
+	Severity          []Severity     `json:"severity,omitempty"`
+	Ranges            []Range        `json:"ranges,omitempty"`
+	Versions          []string       `json:"versions,omitempty"`
+	EcosystemSpecific map[string]any `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]any `json:"database_specific,omitempty"`
+}
+
+// Package identifies a package of an ecosystem
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a range of affected versions, described by the versions introducing and fixing the vulnerability
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Event is a version introducing or fixing a vulnerability
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Reference is a link to more information about a vulnerability
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Parse parses an entry
+func Parse(content []byte) (*Entry, error) {
+	var e Entry
+	if err := json.Unmarshal(content, &e); err != nil {
+		return nil, errors.Join(ErrInvalidEntry, err)
+	}
+	if e.ID == "" {
+		return nil, ErrInvalidEntry
+	}
+	return &e, nil
+}
+
+// Read reads a file containing an entry or a list of entries
+func Read(r io.Reader) ([]*Entry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "[") {
+		var entries []*Entry
+		if err := json.Unmarshal(content, &entries); err != nil {
+			return nil, errors.Join(ErrInvalidEntry, err)
+		}
+		for _, e := range entries {
+			if e == nil || e.ID == "" {
+				return nil, ErrInvalidEntry
+			}
+		}
+		return entries, nil
+	}
+
+	e, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	return []*Entry{e}, nil
+}
+
+// SeverityLevel returns the severity of the vulnerability, preferring the rating of the
+// advisory database over a rating computed from a CVSS score
+func (e *Entry) SeverityLevel() SeverityLevel {
+	if level := severityFromDatabase(e.DatabaseSpecific); level != SeverityUnknown {
+		return level
+	}
+	for _, a := range e.Affected {
+		if level := severityFromDatabase(a.DatabaseSpecific); level != SeverityUnknown {
+			return level
+		}
+	}
+
+	scores := e.Severity
+	for _, a := range e.Affected {
+		scores = append(scores, a.Severity...)
+	}
+	level := SeverityUnknown
+	for _, s := range scores {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if score, err := CVSS3BaseScore(s.Score); err == nil {
+			level = max(level, SeverityFromScore(score))
+		}
+	}
+	return level
+}
+
+func severityFromDatabase(m map[string]any) SeverityLevel {
+	if s, ok := m["severity"].(string); ok {
+		return ParseSeverity(s)
+	}
+	return SeverityUnknown
+}
+
+var pypiNameReplacer = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName returns the name of a package in the form used to compare it
+func NormalizeName(ecosystem, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if ecosystem == EcosystemPyPI {
+		name = pypiNameReplacer.ReplaceAllString(name, "-")
+	}
+	return name
+}
+
+// NormalizeEcosystem strips the release suffix of an ecosystem like "Debian:12"
+func NormalizeEcosystem(ecosystem string) string {
+	ecosystem, _, _ = strings.Cut(ecosystem, ":")
+	return ecosystem
+}
+
+// Matches checks if the affected package is the given package
+func (a *Affected) Matches(ecosystem, name string) bool {
+	return NormalizeEcosystem(a.Package.Ecosystem) == ecosystem &&
+		NormalizeName(ecosystem, a.Package.Name) == NormalizeName(ecosystem, name)
+}
+
+// IsAffected checks if the version of the package is affected
+func (a *Affected) IsAffected(version string) bool {
+	ecosystem := NormalizeEcosystem(a.Package.Ecosystem)
+
+	for _, v := range a.Versions {
+		if CompareVersions(ecosystem, v, version) == 0 {
+			return true
+		}
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type == RangeTypeGit {
+			continue
+		}
+		if r.isAffected(ecosystem, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// FixedVersion returns the lowest version fixing the vulnerability in the given version
+func (a *Affected) FixedVersion(version string) string {
+	ecosystem := NormalizeEcosystem(a.Package.Ecosystem)
+
+	fixed := ""
+	for _, r := range a.Ranges {
+		if r.Type == RangeTypeGit {
+			continue
+		}
+		for _, e := range r.Events {
+			if e.Fixed == "" || r.compare(ecosystem, e.Fixed, version) <= 0 {
+				continue
+			}
+			if fixed == "" || r.compare(ecosystem, e.Fixed, fixed) < 0 {
+				fixed = e.Fixed
+			}
+		}
+	}
+	return fixed
+}
+
+func (r *Range) compare(ecosystem, a, b string) int {
+	if r.Type == RangeTypeSemver {
+		return CompareVersions("", a, b)
+	}
+	return CompareVersions(ecosystem, a, b)
+}
+
+func (e *Event) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	}
+	return e.Limit
+}
+
+// isAffected evaluates the events of the range in the order of their versions
+func (r *Range) isAffected(ecosystem, version string) bool {
+	events := make([]Event, 0, len(r.Events))
+	for _, e := range r.Events {
+		if e.Limit == "" {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		vi, vj := events[i].version(), events[j].version()
+		if vi == "0" || vj == "0" {
+			return vi == "0" && vj != "0"
+		}
+		return r.compare(ecosystem, vi, vj) < 0
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || r.compare(ecosystem, version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if r.compare(ecosystem, version, e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if r.compare(ecosystem, version, e.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
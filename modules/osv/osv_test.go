@@ -0,0 +1,205 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package osv
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEntry = `{
+  "schema_version": "1.6.0",
+  "id": "GHSA-xxxx-yyyy-zzzz",
+  "modified": "2026-01-02T03:04:05Z",
+  "aliases": ["CVE-2026-0001"],
+  "summary": "Remote code execution",
+  "affected": [{
+    "package": {"ecosystem": "PyPI", "name": "Some_Package"},
+    "ranges": [{
+      "type": "ECOSYSTEM",
+      "events": [{"introduced": "0"}, {"fixed": "1.2.0"}, {"introduced": "2.0.0a1"}, {"last_affected": "2.1.0"}]
+    }],
+    "versions": ["3.0.0"]
+  }, {
+    "package": {"ecosystem": "Go", "name": "example.com/module"},
+    "ranges": [{
+      "type": "SEMVER",
+      "events": [{"introduced": "1.1.0"}, {"fixed": "1.4.2"}]
+    }]
+  }],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+func TestParse(t *testing.T) {
+	e, err := Parse([]byte(testEntry))
+	require.NoError(t, err)
+	assert.Equal(t, "GHSA-xxxx-yyyy-zzzz", e.ID)
+	assert.Equal(t, []string{"CVE-2026-0001"}, e.Aliases)
+	assert.Equal(t, SeverityHigh, e.SeverityLevel())
+	require.Len(t, e.Affected, 2)
+
+	_, err = Parse([]byte(`{"summary":"missing id"}`))
+	require.ErrorIs(t, err, ErrInvalidEntry)
+
+	entries, err := Read(strings.NewReader("[" + testEntry + "," + testEntry + "]"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestAffected(t *testing.T) {
+	e, err := Parse([]byte(testEntry))
+	require.NoError(t, err)
+
+	pypi := &e.Affected[0]
+	assert.True(t, pypi.Matches(EcosystemPyPI, "some-package"))
+	assert.True(t, pypi.Matches(EcosystemPyPI, "some.package"))
+	assert.False(t, pypi.Matches(EcosystemNpm, "some-package"))
+
+	cases := map[string]bool{
+		"0.1":      true,
+		"1.1.9":    true,
+		"1.2.0":    false,
+		"1.2.1":    false,
+		"2.0.0.a0": false,
+		"2.0.0a1":  true,
+		"2.0.0":    true,
+		"2.1.0":    true,
+		"2.1.1":    false,
+		"3.0":      true,
+	}
+	for version, expected := range cases {
+		assert.Equal(t, expected, pypi.IsAffected(version), version)
+	}
+	assert.Equal(t, "1.2.0", pypi.FixedVersion("1.0"))
+	assert.Empty(t, pypi.FixedVersion("2.0.0"))
+
+	golang := &e.Affected[1]
+	assert.False(t, golang.IsAffected("v1.0.0"))
+	assert.True(t, golang.IsAffected("v1.1.0"))
+	assert.True(t, golang.IsAffected("v1.4.2-rc.1"))
+	assert.False(t, golang.IsAffected("v1.4.2"))
+	assert.Equal(t, "1.4.2", golang.FixedVersion("v1.3.0"))
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		ecosystem string
+		a, b      string
+		expected  int
+	}{
+		{"", "1.0.0", "1.0.0", 0},
+		{"", "1.0.0", "v1.0.0", 0},
+		{"", "1.2.10", "1.2.9", 1},
+		{"", "1.0.0-alpha", "1.0.0", -1},
+		{"", "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"", "1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{"", "1.0.0+build", "1.0.0", 0},
+		{EcosystemGo, "v0.0.0-20240101000000-abcdef123456", "v0.1.0", -1},
+		{EcosystemPyPI, "1.0", "1.0.0", 0},
+		{EcosystemPyPI, "1.0.dev1", "1.0a1", -1},
+		{EcosystemPyPI, "1.0a1", "1.0b1", -1},
+		{EcosystemPyPI, "1.0rc1", "1.0", -1},
+		{EcosystemPyPI, "1.0.post1", "1.0", 1},
+		{EcosystemPyPI, "1.0.post1", "1.0.1", -1},
+		{EcosystemMaven, "1.0-SNAPSHOT", "1.0", -1},
+		{EcosystemMaven, "1.0.Final", "1.0", 0},
+		{EcosystemRubyGems, "2.0.0.pre", "2.0.0", -1},
+		{EcosystemRubyGems, "10.0", "9.9", 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, CompareVersions(c.ecosystem, c.a, c.b), "%s %s %s", c.ecosystem, c.a, c.b)
+		assert.Equal(t, -c.expected, CompareVersions(c.ecosystem, c.b, c.a), "%s %s %s", c.ecosystem, c.b, c.a)
+	}
+}
+
+func TestCVSS3BaseScore(t *testing.T) {
+	cases := map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N": 6.1,
+		"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N": 1.8,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:H/I:H/A:H": 9.9,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N": 0,
+	}
+	for vector, expected := range cases {
+		score, err := CVSS3BaseScore(vector)
+		require.NoError(t, err)
+		assert.InDelta(t, expected, score, 0.001, vector)
+	}
+
+	_, err := CVSS3BaseScore("CVSS:4.0/AV:N")
+	require.ErrorIs(t, err, ErrInvalidCVSSVector)
+
+	assert.Equal(t, SeverityCritical, SeverityFromScore(9.8))
+	assert.Equal(t, SeverityModerate, SeverityFromScore(6.1))
+	assert.Equal(t, SeverityLow, SeverityFromScore(1.8))
+	assert.Equal(t, SeverityUnknown, SeverityFromScore(0))
+
+	e := &Entry{Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N"}}}
+	assert.Equal(t, SeverityModerate, e.SeverityLevel())
+}
+
+func TestWalk(t *testing.T) {
+	collect := func(walk func(fn func(*Entry) error) error) []string {
+		ids := []string{}
+		require.NoError(t, walk(func(e *Entry) error {
+			ids = append(ids, e.ID)
+			return nil
+		}))
+		return ids
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "PyPI"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "PyPI", "entry.json"), []byte(testEntry), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0o644))
+
+		assert.Equal(t, []string{"GHSA-xxxx-yyyy-zzzz"}, collect(func(fn func(*Entry) error) error {
+			return WalkDirectory(dir, fn)
+		}))
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("GHSA-xxxx-yyyy-zzzz.json")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(testEntry))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		assert.Equal(t, []string{"GHSA-xxxx-yyyy-zzzz"}, collect(func(fn func(*Entry) error) error {
+			return WalkArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), fn)
+		}))
+	})
+
+	t.Run("TarGz", func(t *testing.T) {
+		var buf bytes.Buffer
+		gzw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gzw)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "osv/entry.json", Mode: 0o644, Size: int64(len(testEntry)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(testEntry))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, gzw.Close())
+
+		assert.Equal(t, []string{"GHSA-xxxx-yyyy-zzzz"}, collect(func(fn func(*Entry) error) error {
+			return WalkArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), fn)
+		}))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		err := WalkArchive(strings.NewReader("plain text"), 10, func(*Entry) error { return nil })
+		require.ErrorIs(t, err, ErrUnsupportedArchive)
+	})
+}
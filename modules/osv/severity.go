@@ -0,0 +1,159 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package osv
+
+import (
+	"math"
+	"strings"
+
+	"forgejo.org/modules/util"
+)
+
+// SeverityLevel is the qualitative severity rating of a vulnerability
+type SeverityLevel int
+
+const (
+	SeverityUnknown SeverityLevel = iota
+	SeverityLow
+	SeverityModerate
+	SeverityHigh
+	SeverityCritical
+)
+
+// SeverityLevels are the known severity levels, from the highest to the lowest
+var SeverityLevels = []SeverityLevel{SeverityCritical, SeverityHigh, SeverityModerate, SeverityLow}
+
+func (s SeverityLevel) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityModerate:
+		return "moderate"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// ParseSeverity parses a severity rating like "HIGH" or "medium"
+func ParseSeverity(s string) SeverityLevel {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return SeverityLow
+	case "moderate", "medium":
+		return SeverityModerate
+	case "high", "important":
+		return SeverityHigh
+	case "critical":
+		return SeverityCritical
+	}
+	return SeverityUnknown
+}
+
+// SeverityFromScore returns the rating of a CVSS score
+func SeverityFromScore(score float64) SeverityLevel {
+	switch {
+	case score >= 9:
+		return SeverityCritical
+	case score >= 7:
+		return SeverityHigh
+	case score >= 4:
+		return SeverityModerate
+	case score > 0:
+		return SeverityLow
+	}
+	return SeverityUnknown
+}
+
+var ErrInvalidCVSSVector = util.NewInvalidArgumentErrorf("invalid CVSS vector")
+
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// CVSS3BaseScore computes the base score of a CVSS v3 vector like "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+func CVSS3BaseScore(vector string) (float64, error) {
+	parts := strings.Split(vector, "/")
+	if len(parts) == 0 || !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, ErrInvalidCVSSVector
+	}
+
+	metrics := make(map[string]string, len(parts)-1)
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return 0, ErrInvalidCVSSVector
+		}
+		metrics[name] = value
+	}
+
+	weights := make(map[string]float64, len(cvss3Weights))
+	for name, values := range cvss3Weights {
+		w, ok := values[metrics[name]]
+		if !ok {
+			return 0, ErrInvalidCVSSVector
+		}
+		weights[name] = w
+	}
+
+	changed := false
+	switch metrics["S"] {
+	case "U":
+	case "C":
+		changed = true
+	default:
+		return 0, ErrInvalidCVSSVector
+	}
+
+	var privileges float64
+	switch metrics["PR"] {
+	case "N":
+		privileges = 0.85
+	case "L":
+		privileges = 0.62
+		if changed {
+			privileges = 0.68
+		}
+	case "H":
+		privileges = 0.27
+		if changed {
+			privileges = 0.5
+		}
+	default:
+		return 0, ErrInvalidCVSSVector
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0, nil
+	}
+
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * privileges * weights["UI"]
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp rounds up to one decimal as defined by CVSS v3.1
+func roundUp(value float64) float64 {
+	i := int64(math.Round(value * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package osv
+
+import (
+	"strings"
+)
+
+// CompareVersions compares two versions of a package of the ecosystem. Versions of ecosystems
+// using semantic versioning are compared by its rules, an empty ecosystem compares versions of
+// SEMVER ranges. The versions of other ecosystems are compared by their numeric and textual
+// parts, where known pre-release words sort before and post-release words after the release.
+func CompareVersions(ecosystem, a, b string) int {
+	switch ecosystem {
+	case "", EcosystemGo, EcosystemNpm, EcosystemCargo, EcosystemPub, EcosystemHex:
+		va, okA := parseSemver(a)
+		vb, okB := parseSemver(b)
+		if okA && okB {
+			return compareSemver(va, vb)
+		}
+	}
+	return compareGeneric(a, b)
+}
+
+type semver struct {
+	core       []string
+	prerelease []string
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSemver(v string) (semver, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	core, prerelease, hasPrerelease := strings.Cut(v, "-")
+
+	sv := semver{core: strings.Split(core, ".")}
+	if len(sv.core) > 3 {
+		return sv, false
+	}
+	for _, part := range sv.core {
+		if !isNumeric(part) {
+			return sv, false
+		}
+	}
+	for len(sv.core) < 3 {
+		sv.core = append(sv.core, "0")
+	}
+	if hasPrerelease {
+		sv.prerelease = strings.Split(prerelease, ".")
+	}
+	return sv, true
+}
+
+// compareNumeric compares two strings of digits of any length
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareSemver(a, b semver) int {
+	for i := range a.core {
+		if c := compareNumeric(a.core[i], b.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		pa, pb := a.prerelease[i], b.prerelease[i]
+		numA, numB := isNumeric(pa), isNumeric(pb)
+		var c int
+		switch {
+		case numA && numB:
+			c = compareNumeric(pa, pb)
+		case numA:
+			c = -1
+		case numB:
+			c = 1
+		default:
+			c = strings.Compare(pa, pb)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a.prerelease), len(b.prerelease))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// wordRanks orders the words of versions relative to the release, which has rank 0
+var wordRanks = map[string]int{
+	"dev":       -5,
+	"snapshot":  -5,
+	"alpha":     -4,
+	"a":         -4,
+	"beta":      -3,
+	"b":         -3,
+	"milestone": -2,
+	"m":         -2,
+	"rc":        -1,
+	"c":         -1,
+	"cr":        -1,
+	"pre":       -1,
+	"preview":   -1,
+	"final":     0,
+	"ga":        0,
+	"release":   0,
+	"post":      1,
+	"p":         1,
+	"pl":        1,
+	"patch":     1,
+	"r":         1,
+	"rev":       1,
+	"sp":        1,
+}
+
+func wordRank(word string) int {
+	if rank, ok := wordRanks[word]; ok {
+		return rank
+	}
+	// unknown qualifiers like "canary" or "nightly" are pre-releases in most ecosystems
+	return -1
+}
+
+type versionToken struct {
+	value   string
+	numeric bool
+}
+
+func tokenizeVersion(v string) []versionToken {
+	v = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), "v"))
+
+	tokens := make([]versionToken, 0, 8)
+	start := -1
+	numeric := false
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, versionToken{value: v[start:end], numeric: numeric})
+			start = -1
+		}
+	}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		isDigit := c >= '0' && c <= '9'
+		isLetter := c >= 'a' && c <= 'z'
+		if !isDigit && !isLetter {
+			flush(i)
+			continue
+		}
+		if start >= 0 && isDigit != numeric {
+			flush(i)
+		}
+		if start < 0 {
+			start = i
+			numeric = isDigit
+		}
+	}
+	flush(len(v))
+	return tokens
+}
+
+// compareTokens compares two tokens, a missing token stands for the release itself
+func compareTokens(a, b *versionToken) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -compareTokens(b, nil)
+	case b == nil:
+		if a.numeric {
+			return compareNumeric(a.value, "0")
+		}
+		return compareInt(wordRank(a.value), 0)
+	case a.numeric && b.numeric:
+		return compareNumeric(a.value, b.value)
+	case a.numeric:
+		return 1
+	case b.numeric:
+		return -1
+	}
+	if c := compareInt(wordRank(a.value), wordRank(b.value)); c != 0 {
+		return c
+	}
+	if _, ok := wordRanks[a.value]; ok {
+		// synonyms like "a" and "alpha" are equal
+		return 0
+	}
+	return strings.Compare(a.value, b.value)
+}
+
+func compareGeneric(a, b string) int {
+	ta, tb := tokenizeVersion(a), tokenizeVersion(b)
+	for i := 0; i < len(ta) || i < len(tb); i++ {
+		var tokenA, tokenB *versionToken
+		if i < len(ta) {
+			tokenA = &ta[i]
+		}
+		if i < len(tb) {
+			tokenB = &tb[i]
+		}
+		if c := compareTokens(tokenA, tokenB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+)
+
+// Formats of detached signatures
+const (
+	FormatGPG      = "gpg"
+	FormatSSH      = "ssh"
+	FormatSigstore = "sigstore"
+)
+
+const (
+	// StatementType is the type of an in-toto statement
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PayloadType is the payload type of a DSSE envelope wrapping an in-toto statement
+	PayloadType = "application/vnd.in-toto+json"
+	// ProvenancePredicateType is the predicate type of SLSA provenance
+	ProvenancePredicateType = "https://slsa.dev/provenance/v1"
+)
+
+var (
+	ErrUnknownFormat      = util.NewInvalidArgumentErrorf("unknown signature format")
+	ErrInvalidBundle      = util.NewInvalidArgumentErrorf("invalid sigstore bundle")
+	ErrInvalidStatement   = util.NewInvalidArgumentErrorf("invalid in-toto statement")
+	ErrDigestMismatch     = util.NewInvalidArgumentErrorf("the digest does not match the content")
+	ErrNoMatchingKey      = util.NewInvalidArgumentErrorf("the signature does not match any trusted key")
+	ErrUnsupportedKeyType = util.NewInvalidArgumentErrorf("unsupported key type")
+)
+
+// DetectFormat returns the format of a detached signature
+func DetectFormat(signature []byte) (string, error) {
+	s := bytes.TrimSpace(signature)
+	switch {
+	case bytes.HasPrefix(s, []byte("-----BEGIN PGP SIGNATURE-----")):
+		return FormatGPG, nil
+	case bytes.HasPrefix(s, []byte("-----BEGIN SSH SIGNATURE-----")):
+		return FormatSSH, nil
+	case bytes.HasPrefix(s, []byte("{")):
+		return FormatSigstore, nil
+	case len(s) > 0 && s[0]&0x80 != 0:
+		// binary OpenPGP packets always have the high bit of the tag set
+		return FormatGPG, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// ParsePublicKeys parses all PEM encoded public keys of the content
+func ParsePublicKeys(content []byte) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, 1)
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// KeyFingerprint returns the hex encoded SHA256 hash of the DER encoded public key
+func KeyFingerprint(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// verifyDigest checks a signature made over the SHA256 digest of a message.
+// Ed25519 keys sign the message itself and can't be checked with its digest.
+func verifyDigest(key crypto.PublicKey, digest, signature []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest, signature) {
+			return ErrNoMatchingKey
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, signature); err != nil {
+			return ErrNoMatchingKey
+		}
+		return nil
+	}
+	return ErrUnsupportedKeyType
+}
+
+func verifyMessage(key crypto.PublicKey, message, signature []byte) error {
+	if k, ok := key.(ed25519.PublicKey); ok {
+		if !ed25519.Verify(k, message, signature) {
+			return ErrNoMatchingKey
+		}
+		return nil
+	}
+	digest := sha256.Sum256(message)
+	return verifyDigest(key, digest[:], signature)
+}
+
+// findKey returns the first of the keys the verify function accepts
+func findKey(keys []crypto.PublicKey, verify func(crypto.PublicKey) error) (crypto.PublicKey, error) {
+	for _, key := range keys {
+		if err := verify(key); err == nil {
+			return key, nil
+		}
+	}
+	return nil, ErrNoMatchingKey
+}
+
+// Envelope is a DSSE envelope, https://github.com/secure-systems-lab/dsse
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// pae is the pre-authentication encoding the signatures of an envelope are made over
+func pae(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
+
+// Verify checks the signatures of the envelope and returns the first key which made one of them
+func (e *Envelope) Verify(keys []crypto.PublicKey) (crypto.PublicKey, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, ErrInvalidStatement
+	}
+	message := pae(e.PayloadType, payload)
+
+	for _, s := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if key, err := findKey(keys, func(key crypto.PublicKey) error { return verifyMessage(key, message, sig) }); err == nil {
+			return key, nil
+		}
+	}
+	return nil, ErrNoMatchingKey
+}
+
+// Bundle is the subset of a sigstore bundle which can be verified offline,
+// https://github.com/sigstore/protobuf-specs
+type Bundle struct {
+	MediaType        string            `json:"mediaType"`
+	MessageSignature *MessageSignature `json:"messageSignature,omitempty"`
+	DSSEEnvelope     *Envelope         `json:"dsseEnvelope,omitempty"`
+}
+
+type MessageSignature struct {
+	MessageDigest struct {
+		Algorithm string `json:"algorithm"`
+		Digest    string `json:"digest"`
+	} `json:"messageDigest"`
+	Signature string `json:"signature"`
+}
+
+// ParseBundle parses a sigstore bundle
+func ParseBundle(content []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(content, &b); err != nil {
+		return nil, ErrInvalidBundle
+	}
+	if b.MessageSignature == nil && b.DSSEEnvelope == nil {
+		return nil, ErrInvalidBundle
+	}
+	return &b, nil
+}
+
+// VerifyBlob checks that the bundle signs the blob with the SHA256 digest and returns the key which made the signature
+func (b *Bundle) VerifyBlob(digest []byte, keys []crypto.PublicKey) (crypto.PublicKey, error) {
+	ms := b.MessageSignature
+	if ms == nil {
+		return nil, ErrInvalidBundle
+	}
+	if ms.MessageDigest.Algorithm != "" && ms.MessageDigest.Algorithm != "SHA2_256" {
+		return nil, ErrInvalidBundle
+	}
+	if ms.MessageDigest.Digest != "" {
+		bundleDigest, err := base64.StdEncoding.DecodeString(ms.MessageDigest.Digest)
+		if err != nil || !bytes.Equal(bundleDigest, digest) {
+			return nil, ErrDigestMismatch
+		}
+	}
+	sig, err := base64.StdEncoding.DecodeString(ms.Signature)
+	if err != nil {
+		return nil, ErrInvalidBundle
+	}
+	return findKey(keys, func(key crypto.PublicKey) error { return verifyDigest(key, digest, sig) })
+}
+
+// Statement is an in-toto statement, https://github.com/in-toto/attestation
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     any       `json:"predicate,omitempty"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// HasSubjectDigest checks if one of the subjects has the hex encoded SHA256 digest
+func (s *Statement) HasSubjectDigest(sha256 string) bool {
+	for _, subject := range s.Subject {
+		if subject.Digest["sha256"] == sha256 {
+			return true
+		}
+	}
+	return false
+}
+
+// BuilderID returns the id of the builder named in the SLSA provenance predicate
+func (s *Statement) BuilderID() string {
+	var p struct {
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+		// SLSA v0.2
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+	}
+	raw, err := json.Marshal(s.Predicate)
+	if err != nil {
+		return ""
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return ""
+	}
+	if p.RunDetails.Builder.ID != "" {
+		return p.RunDetails.Builder.ID
+	}
+	return p.Builder.ID
+}
+
+// ParseStatement parses an in-toto statement. The statement may be wrapped in a DSSE envelope
+// or a sigstore bundle, which is returned to verify its signatures.
+func ParseStatement(content []byte) (*Statement, *Envelope, error) {
+	var probe struct {
+		Type         string    `json:"_type"`
+		PayloadType  string    `json:"payloadType"`
+		DSSEEnvelope *Envelope `json:"dsseEnvelope"`
+	}
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return nil, nil, ErrInvalidStatement
+	}
+
+	var envelope *Envelope
+	switch {
+	case probe.DSSEEnvelope != nil:
+		envelope = probe.DSSEEnvelope
+	case probe.PayloadType != "":
+		envelope = &Envelope{}
+		if err := json.Unmarshal(content, envelope); err != nil {
+			return nil, nil, ErrInvalidStatement
+		}
+	}
+
+	if envelope != nil {
+		if envelope.PayloadType != PayloadType {
+			return nil, nil, ErrInvalidStatement
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return nil, nil, ErrInvalidStatement
+		}
+		content = payload
+	}
+
+	var s Statement
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, nil, ErrInvalidStatement
+	}
+	if s.Type != StatementType && s.Type != "https://in-toto.io/Statement/v0.1" {
+		return nil, nil, ErrInvalidStatement
+	}
+	if len(s.Subject) == 0 {
+		return nil, nil, ErrInvalidStatement
+	}
+	return &s, envelope, nil
+}
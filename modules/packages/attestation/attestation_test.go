@@ -0,0 +1,163 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+
+	"forgejo.org/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	cases := map[string]string{
+		"-----BEGIN PGP SIGNATURE-----\n\n-----END PGP SIGNATURE-----":  FormatGPG,
+		"\n-----BEGIN SSH SIGNATURE-----\n-----END SSH SIGNATURE-----":  FormatSSH,
+		`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}`: FormatSigstore,
+		"\x89\x01\x33": FormatGPG,
+	}
+	for content, expected := range cases {
+		format, err := DetectFormat([]byte(content))
+		require.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := DetectFormat([]byte("signature"))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	content := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	content = append(content, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}})...)
+
+	keys, err := ParsePublicKeys(content)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+
+	fingerprint, err := KeyFingerprint(keys[0])
+	require.NoError(t, err)
+	sum := sha256.Sum256(der)
+	assert.Equal(t, hex.EncodeToString(sum[:]), fingerprint)
+}
+
+func TestBundleVerifyBlob(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("package content"))
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+
+	content := []byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","messageSignature":{"messageDigest":{"algorithm":"SHA2_256","digest":"` +
+		base64.StdEncoding.EncodeToString(digest[:]) + `"},"signature":"` + base64.StdEncoding.EncodeToString(sig) + `"}}`)
+
+	b, err := ParseBundle(content)
+	require.NoError(t, err)
+
+	key, err := b.VerifyBlob(digest[:], []crypto.PublicKey{&other.PublicKey, &priv.PublicKey})
+	require.NoError(t, err)
+	assert.Equal(t, &priv.PublicKey, key)
+
+	_, err = b.VerifyBlob(digest[:], []crypto.PublicKey{&other.PublicKey})
+	require.ErrorIs(t, err, ErrNoMatchingKey)
+
+	otherDigest := sha256.Sum256([]byte("other content"))
+	_, err = b.VerifyBlob(otherDigest[:], []crypto.PublicKey{&priv.PublicKey})
+	require.ErrorIs(t, err, ErrDigestMismatch)
+
+	_, err = ParseBundle([]byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}`))
+	require.ErrorIs(t, err, ErrInvalidBundle)
+}
+
+func TestProvenance(t *testing.T) {
+	digest := sha256.Sum256([]byte("package content"))
+	sha := hex.EncodeToString(digest[:])
+
+	content, err := NewProvenance([]Subject{{Name: "package.tar.gz", Digest: map[string]string{"sha256": sha}}}, &BuildInfo{
+		BuilderID:    "https://forgejo.example/api/actions",
+		BuildType:    "https://forgejo.example/api/actions/workflow/v1",
+		InvocationID: "https://forgejo.example/user2/repo1/actions/runs/3",
+		Parameters:   map[string]string{"workflow": "build.yml"},
+		SourceURI:    "git+https://forgejo.example/user2/repo1@refs/heads/main",
+		SourceCommit: "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+	})
+	require.NoError(t, err)
+
+	t.Run("Statement", func(t *testing.T) {
+		s, envelope, err := ParseStatement(content)
+		require.NoError(t, err)
+		assert.Nil(t, envelope)
+		assert.Equal(t, ProvenancePredicateType, s.PredicateType)
+		assert.True(t, s.HasSubjectDigest(sha))
+		assert.False(t, s.HasSubjectDigest("0000"))
+		assert.Equal(t, "https://forgejo.example/api/actions", s.BuilderID())
+	})
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	envelope := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(content),
+		Signatures: []EnvelopeSignature{
+			{Sig: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, pae(PayloadType, content)))},
+		},
+	}
+
+	t.Run("Envelope", func(t *testing.T) {
+		raw, err := json.Marshal(envelope)
+		require.NoError(t, err)
+
+		s, e, err := ParseStatement(raw)
+		require.NoError(t, err)
+		require.NotNil(t, e)
+		assert.True(t, s.HasSubjectDigest(sha))
+
+		key, err := e.Verify([]crypto.PublicKey{pub})
+		require.NoError(t, err)
+		assert.Equal(t, pub, key)
+
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		_, err = e.Verify([]crypto.PublicKey{otherPub})
+		require.ErrorIs(t, err, ErrNoMatchingKey)
+	})
+
+	t.Run("Bundle", func(t *testing.T) {
+		raw, err := json.Marshal(&Bundle{MediaType: "application/vnd.dev.sigstore.bundle.v0.3+json", DSSEEnvelope: envelope})
+		require.NoError(t, err)
+
+		s, e, err := ParseStatement(raw)
+		require.NoError(t, err)
+		require.NotNil(t, e)
+		assert.True(t, s.HasSubjectDigest(sha))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, _, err := ParseStatement([]byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[]}`))
+		require.ErrorIs(t, err, ErrInvalidStatement)
+
+		_, _, err = ParseStatement([]byte(`not json`))
+		require.ErrorIs(t, err, ErrInvalidStatement)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package attestation
+
+import (
+	"forgejo.org/modules/json"
+)
+
+// BuildInfo describes how the subjects of a provenance statement were built
+type BuildInfo struct {
+	BuilderID    string
+	BuildType    string
+	InvocationID string
+	// Parameters are the external parameters of the build, e.g. the workflow which was run
+	Parameters map[string]string
+	// Internal are the parameters set by the builder, e.g. the triggering event
+	Internal     map[string]string
+	SourceURI    string
+	SourceCommit string
+}
+
+type resourceDescriptor struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type provenancePredicate struct {
+	BuildDefinition struct {
+		BuildType            string               `json:"buildType"`
+		ExternalParameters   map[string]string    `json:"externalParameters"`
+		InternalParameters   map[string]string    `json:"internalParameters,omitempty"`
+		ResolvedDependencies []resourceDescriptor `json:"resolvedDependencies,omitempty"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Metadata struct {
+			InvocationID string `json:"invocationId,omitempty"`
+		} `json:"metadata"`
+	} `json:"runDetails"`
+}
+
+// NewProvenance creates a SLSA provenance statement for the subjects
+func NewProvenance(subjects []Subject, info *BuildInfo) ([]byte, error) {
+	var p provenancePredicate
+	p.BuildDefinition.BuildType = info.BuildType
+	p.BuildDefinition.ExternalParameters = info.Parameters
+	p.BuildDefinition.InternalParameters = info.Internal
+	if info.SourceURI != "" {
+		dep := resourceDescriptor{URI: info.SourceURI}
+		if info.SourceCommit != "" {
+			dep.Digest = map[string]string{"gitCommit": info.SourceCommit}
+		}
+		p.BuildDefinition.ResolvedDependencies = []resourceDescriptor{dep}
+	}
+	p.RunDetails.Builder.ID = info.BuilderID
+	p.RunDetails.Metadata.InvocationID = info.InvocationID
+
+	return json.Marshal(&Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: ProvenancePredicateType,
+		Predicate:     p,
+	})
+}
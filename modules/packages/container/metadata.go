@@ -22,6 +22,7 @@ const (
 	PropertyMediaType                    = "container.mediatype"
 	PropertyManifestTagged               = "container.manifest.tagged"
 	PropertyManifestReference            = "container.manifest.reference"
+	PropertyManifestSubject              = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -65,6 +66,7 @@ type Metadata struct {
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
 	Annotations      map[string]string `json:"annotations,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
 }
 
 type Manifest struct {
@@ -0,0 +1,279 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+
+	// tarballVersion is the version of the package tarball format
+	// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+	tarballVersion = "3"
+
+	maxMetadataSize = 128 * 1024
+	maxReadmeSize   = 1 * 1024 * 1024
+)
+
+var (
+	ErrInvalidTarball   = util.NewInvalidArgumentErrorf("package tarball is invalid")
+	ErrInvalidVersion   = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidName      = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidChecksum  = util.NewInvalidArgumentErrorf("package checksum does not match")
+	ErrMissingMetadata  = util.NewInvalidArgumentErrorf("metadata.config is missing")
+	ErrMetadataTooLarge = util.NewInvalidArgumentErrorf("metadata.config is too large")
+
+	// https://github.com/hexpm/hexpm/blob/main/lib/hexpm/repository/package.ex
+	namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App           string            `json:"app,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Licenses      []string          `json:"licenses,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+	BuildTools    []string          `json:"build_tools,omitempty"`
+	Elixir        string            `json:"elixir,omitempty"`
+	Requirements  []*Requirement    `json:"requirements,omitempty"`
+	InnerChecksum string            `json:"inner_checksum"`
+	Readme        string            `json:"readme,omitempty"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// ParsePackage parses the package tarball created by mix hex.build.
+// The inner checksum is computed over the VERSION, metadata.config and contents.tar.gz files
+// and is validated against the CHECKSUM file if present.
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionFile, checksumFile, metadataFile []byte
+	var readme string
+	hasContents := false
+	h := sha256.New()
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			if versionFile, err = readFile(tr, hd, 16); err != nil {
+				return nil, err
+			}
+		case "CHECKSUM":
+			if checksumFile, err = readFile(tr, hd, 128); err != nil {
+				return nil, err
+			}
+		case "metadata.config":
+			if metadataFile, err = readFile(tr, hd, maxMetadataSize); err != nil {
+				return nil, err
+			}
+		case "contents.tar.gz":
+			// The files are written in this order by hex_tarball
+			if versionFile == nil || metadataFile == nil {
+				return nil, ErrInvalidTarball
+			}
+			h.Write(versionFile)
+			h.Write(metadataFile)
+			if readme, err = readContents(io.TeeReader(tr, h)); err != nil {
+				return nil, err
+			}
+			hasContents = true
+		}
+	}
+
+	if metadataFile == nil {
+		return nil, ErrMissingMetadata
+	}
+	if !hasContents || strings.TrimSpace(string(versionFile)) != tarballVersion {
+		return nil, ErrInvalidTarball
+	}
+
+	innerChecksum := hex.EncodeToString(h.Sum(nil))
+	if checksumFile != nil && !strings.EqualFold(strings.TrimSpace(string(checksumFile)), innerChecksum) {
+		return nil, ErrInvalidChecksum
+	}
+
+	p, err := ParseMetadata(metadataFile)
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.InnerChecksum = innerChecksum
+	p.Metadata.Readme = readme
+
+	return p, nil
+}
+
+func readFile(r io.Reader, hd *tar.Header, limit int64) ([]byte, error) {
+	if hd.Size > limit {
+		if hd.Name == "metadata.config" {
+			return nil, ErrMetadataTooLarge
+		}
+		return nil, ErrInvalidTarball
+	}
+	return io.ReadAll(io.LimitReader(r, limit))
+}
+
+// readContents reads the README from contents.tar.gz and consumes the rest of the archive
+func readContents(r io.Reader) (string, error) {
+	var readme string
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return "", ErrInvalidTarball
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", ErrInvalidTarball
+		}
+
+		if hd.Typeflag == tar.TypeReg && strings.EqualFold(hd.Name, "README.md") {
+			data, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return "", err
+			}
+			readme = string(data)
+		}
+	}
+
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return "", err
+	}
+	return readme, nil
+}
+
+// ParseMetadata parses the metadata.config file of a package
+// https://github.com/hexpm/specifications/blob/main/package_metadata.md
+func ParseMetadata(data []byte) (*Package, error) {
+	terms, err := ParseTerms(data)
+	if err != nil {
+		return nil, err
+	}
+	meta := proplist(terms)
+
+	name := termString(meta["name"])
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	v, err := version.NewSemver(termString(meta["version"]))
+	if err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	links := make(map[string]string)
+	for k, v := range proplist(meta["links"]) {
+		if u := termString(v); validation.IsValidURL(u) {
+			links[k] = u
+		}
+	}
+
+	app := termString(meta["app"])
+	if app == "" {
+		app = name
+	}
+
+	return &Package{
+		Name:    name,
+		Version: v.String(),
+		Metadata: &Metadata{
+			App:          app,
+			Description:  termString(meta["description"]),
+			Licenses:     termStringList(meta["licenses"]),
+			Links:        links,
+			BuildTools:   termStringList(meta["build_tools"]),
+			Elixir:       termString(meta["elixir"]),
+			Requirements: parseRequirements(meta["requirements"]),
+		},
+	}, nil
+}
+
+// parseRequirements supports the list of proplists written by current clients
+// and the {Name, Proplist} tuples written by older ones.
+func parseRequirements(v any) []*Requirement {
+	list, _ := v.([]any)
+
+	reqs := make([]*Requirement, 0, len(list))
+	for _, e := range list {
+		var name string
+		var props map[string]any
+		switch e := e.(type) {
+		case Tuple:
+			if len(e) != 2 {
+				continue
+			}
+			name = termString(e[0])
+			props = proplist(e[1])
+		case []any:
+			props = proplist(e)
+			name = termString(props["name"])
+		}
+		if name == "" {
+			continue
+		}
+
+		app := termString(props["app"])
+		if app == "" {
+			app = name
+		}
+		reqs = append(reqs, &Requirement{
+			Name:        name,
+			App:         app,
+			Requirement: termString(props["requirement"]),
+			Optional:    termBool(props["optional"]),
+			Repository:  termString(props["repository"]),
+		})
+	}
+
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Name < reqs[j].Name
+	})
+	return reqs
+}
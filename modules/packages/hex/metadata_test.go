@@ -0,0 +1,197 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "my_package"
+	packageVersion = "1.0.0"
+	description    = "Test Description"
+	readme         = "# My Package"
+)
+
+const metadataConfig = `{<<"app">>,<<"my_package">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"Test Description">>}.
+{<<"elixir">>,<<"~> 1.14">>}.
+{<<"files">>,[<<"lib">>,<<"lib/my_package.ex">>,<<"mix.exs">>]}.
+{<<"licenses">>,[<<"Apache-2.0">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://example.com/my_package">>},{<<"Invalid">>,<<"not an url">>}]}.
+{<<"name">>,<<"my_package">>}.
+{<<"requirements">>,
+ [[{<<"name">>,<<"jason">>},
+   {<<"app">>,<<"jason">>},
+   {<<"optional">>,false},
+   {<<"requirement">>,<<"~> 1.4">>},
+   {<<"repository">>,<<"hexpm">>}],
+  [{<<"name">>,<<"decimal">>},
+   {<<"app">>,<<"decimal">>},
+   {<<"optional">>,true},
+   {<<"requirement">>,<<"~> 2.0">>},
+   {<<"repository">>,<<"hexpm">>}]]}.
+{<<"version">>,<<"1.0.0">>}.
+`
+
+func createContents(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range map[string]string{"README.md": readme, "lib/my_package.ex": "defmodule MyPackage do\nend\n"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func createTarball(t *testing.T, metadata, checksum string) []byte {
+	contents := createContents(t)
+
+	if checksum == "" {
+		h := sha256.New()
+		h.Write([]byte(tarballVersion))
+		h.Write([]byte(metadata))
+		h.Write(contents)
+		checksum = strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []struct {
+		Name    string
+		Content []byte
+	}{
+		{"VERSION", []byte(tarballVersion)},
+		{"CHECKSUM", []byte(checksum)},
+		{"metadata.config", []byte(metadata)},
+		{"contents.tar.gz", contents},
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.Name, Mode: 0o600, Size: int64(len(f.Content))}))
+		_, err := tw.Write(f.Content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestParseTerms(t *testing.T) {
+	terms, err := ParseTerms([]byte(`% comment
+{<<"a">>, [1, -2, atom, 'quoted atom', "str\n"]}.
+{<<"Über"/utf8>>, <<"caf\351">>, <<104,105>>, <<>>, {}}.
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		Tuple{"a", []any{int64(1), int64(-2), Atom("atom"), Atom("quoted atom"), "str\n"}},
+		Tuple{"Über", "caf\xe9", "hi", "", Tuple{}},
+	}, terms)
+
+	for _, invalid := range []string{`{<<"a">>}`, `{<<"a">>.`, `<<"a>>.`, `[1 2].`, `{X}.`} {
+		_, err := ParseTerms([]byte(invalid))
+		require.ErrorIs(t, err, ErrInvalidTerm, invalid)
+	}
+}
+
+func TestMarshalTerm(t *testing.T) {
+	b, err := MarshalTerm(map[string]any{"url": "u", "ok": true, "n": 300, "list": []string{}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		131, 116, 0, 0, 0, 4,
+		109, 0, 0, 0, 4, 'l', 'i', 's', 't', 106,
+		109, 0, 0, 0, 1, 'n', 98, 0, 0, 1, 44,
+		109, 0, 0, 0, 2, 'o', 'k', 119, 4, 't', 'r', 'u', 'e',
+		109, 0, 0, 0, 3, 'u', 'r', 'l', 109, 0, 0, 0, 1, 'u',
+	}, b)
+
+	_, err = MarshalTerm(1.5)
+	require.Error(t, err)
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(metadataConfig))
+		require.NoError(t, err)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "my_package", p.Metadata.App)
+		assert.Equal(t, []string{"Apache-2.0"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, "~> 1.14", p.Metadata.Elixir)
+		assert.Equal(t, map[string]string{"GitHub": "https://example.com/my_package"}, p.Metadata.Links)
+		assert.Equal(t, []*Requirement{
+			{Name: "decimal", App: "decimal", Requirement: "~> 2.0", Optional: true, Repository: "hexpm"},
+			{Name: "jason", App: "jason", Requirement: "~> 1.4", Repository: "hexpm"},
+		}, p.Metadata.Requirements)
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"my_package">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"requirements">>,[{<<"plug">>,[{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.0">>}]}]}.
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Requirement{{Name: "plug", App: "plug", Requirement: "~> 1.0"}}, p.Metadata.Requirements)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := ParseMetadata([]byte(`{<<"name">>,<<"My-Package">>}.
+{<<"version">>,<<"1.0.0">>}.
+`))
+		require.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		_, err := ParseMetadata([]byte(`{<<"name">>,<<"my_package">>}.
+{<<"version">>,<<"1.x">>}.
+`))
+		require.ErrorIs(t, err, ErrInvalidVersion)
+	})
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(bytes.NewReader(createTarball(t, metadataConfig, "")))
+		require.NoError(t, err)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, readme, p.Metadata.Readme)
+		assert.Len(t, p.Metadata.InnerChecksum, 64)
+	})
+
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		_, err := ParsePackage(bytes.NewReader(createTarball(t, metadataConfig, strings.Repeat("A", 64))))
+		require.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("InvalidTarball", func(t *testing.T) {
+		_, err := ParsePackage(bytes.NewReader([]byte("not a tarball")))
+		require.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("MissingMetadata", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "VERSION", Mode: 0o600, Size: 1}))
+		_, err := tw.Write([]byte(tarballVersion))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+
+		_, err = ParsePackage(&buf)
+		require.ErrorIs(t, err, ErrMissingMetadata)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages wrapped in a signed message and gzip compressed.
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// NamePackage is an entry of the /names resource
+type NamePackage struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// VersionsPackage is an entry of the /versions resource
+type VersionsPackage struct {
+	Name     string
+	Versions []string
+}
+
+// Release is a version of the /packages/:name resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Requirement
+}
+
+// EncodeNames encodes the Names message
+func EncodeNames(repository string, packages []*NamePackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var ts []byte
+		ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+		ts = protowire.AppendVarint(ts, uint64(p.UpdatedAt.Unix()))
+		if nanos := p.UpdatedAt.Nanosecond(); nanos != 0 {
+			ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+			ts = protowire.AppendVarint(ts, uint64(nanos))
+		}
+
+		var m []byte
+		m = appendString(m, 1, p.Name)
+		m = appendBytes(m, 2, ts)
+
+		b = appendBytes(b, 1, m)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodeVersions encodes the Versions message
+func EncodeVersions(repository string, packages []*VersionsPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var m []byte
+		m = appendString(m, 1, p.Name)
+		for _, v := range p.Versions {
+			m = appendString(m, 2, v)
+		}
+
+		b = appendBytes(b, 1, m)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodePackage encodes the Package message.
+// Dependencies from the repository itself must have an empty repository.
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var m []byte
+		m = appendString(m, 1, r.Version)
+		m = appendBytes(m, 2, r.InnerChecksum)
+		for _, d := range r.Dependencies {
+			var dep []byte
+			dep = appendString(dep, 1, d.Name)
+			dep = appendString(dep, 2, d.Requirement)
+			if d.Optional {
+				dep = protowire.AppendTag(dep, 3, protowire.VarintType)
+				dep = protowire.AppendVarint(dep, 1)
+			}
+			if d.App != "" && d.App != d.Name {
+				dep = appendString(dep, 4, d.App)
+			}
+			if d.Repository != "" {
+				dep = appendString(dep, 5, d.Repository)
+			}
+
+			m = appendBytes(m, 3, dep)
+		}
+		m = appendBytes(m, 5, r.OuterChecksum)
+
+		b = appendBytes(b, 1, m)
+	}
+	b = appendString(b, 2, name)
+	return appendString(b, 3, repository)
+}
+
+// SignResource wraps the payload in a Signed message with a RSA SHA-512 signature and compresses it
+func SignResource(payload []byte, key *rsa.PrivateKey) ([]byte, error) {
+	h := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []byte
+	signed = appendBytes(signed, 1, payload)
+	signed = appendBytes(signed, 2, signature)
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(signed); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
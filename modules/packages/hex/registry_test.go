@@ -0,0 +1,125 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeFields decodes a protobuf message into its raw field values
+func decodeFields(t *testing.T, b []byte) map[protowire.Number][]any {
+	fields := make(map[protowire.Number][]any)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+	return fields
+}
+
+func TestEncodeNames(t *testing.T) {
+	b := EncodeNames("owner", []*NamePackage{{Name: "a", UpdatedAt: time.Unix(1700000000, 5)}})
+
+	names := decodeFields(t, b)
+	assert.Equal(t, []any{[]byte("owner")}, names[2])
+	require.Len(t, names[1], 1)
+
+	pkg := decodeFields(t, names[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("a")}, pkg[1])
+	ts := decodeFields(t, pkg[2][0].([]byte))
+	assert.Equal(t, []any{uint64(1700000000)}, ts[1])
+	assert.Equal(t, []any{uint64(5)}, ts[2])
+}
+
+func TestEncodeVersions(t *testing.T) {
+	b := EncodeVersions("owner", []*VersionsPackage{{Name: "a", Versions: []string{"1.0.0", "1.1.0"}}})
+
+	versions := decodeFields(t, b)
+	assert.Equal(t, []any{[]byte("owner")}, versions[2])
+	pkg := decodeFields(t, versions[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("1.0.0"), []byte("1.1.0")}, pkg[2])
+}
+
+func TestEncodePackage(t *testing.T) {
+	b := EncodePackage("owner", "a", []*Release{
+		{
+			Version:       "1.0.0",
+			InnerChecksum: []byte{1},
+			OuterChecksum: []byte{2},
+			Dependencies: []*Requirement{
+				{Name: "b", App: "b", Requirement: "~> 1.0", Optional: true, Repository: "hexpm"},
+				{Name: "c", App: "c_app", Requirement: ">= 0.0.0"},
+			},
+		},
+	})
+
+	p := decodeFields(t, b)
+	assert.Equal(t, []any{[]byte("a")}, p[2])
+	assert.Equal(t, []any{[]byte("owner")}, p[3])
+
+	release := decodeFields(t, p[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("1.0.0")}, release[1])
+	assert.Equal(t, []any{[]byte{1}}, release[2])
+	assert.Equal(t, []any{[]byte{2}}, release[5])
+	require.Len(t, release[3], 2)
+
+	dep := decodeFields(t, release[3][0].([]byte))
+	assert.Equal(t, []any{[]byte("b")}, dep[1])
+	assert.Equal(t, []any{[]byte("~> 1.0")}, dep[2])
+	assert.Equal(t, []any{uint64(1)}, dep[3])
+	assert.Nil(t, dep[4])
+	assert.Equal(t, []any{[]byte("hexpm")}, dep[5])
+
+	dep = decodeFields(t, release[3][1].([]byte))
+	assert.Nil(t, dep[3])
+	assert.Equal(t, []any{[]byte("c_app")}, dep[4])
+	assert.Nil(t, dep[5])
+}
+
+func TestSignResource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	payload := EncodeVersions("owner", nil)
+	b, err := SignResource(payload, key)
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	signed, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	fields := decodeFields(t, signed)
+	assert.Equal(t, []any{payload}, fields[1])
+
+	h := sha512.Sum512(payload)
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA512, h[:], fields[2][0].([]byte)))
+}
@@ -0,0 +1,453 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"forgejo.org/modules/util"
+)
+
+// Atom is an Erlang atom
+type Atom string
+
+// Tuple is an Erlang tuple
+type Tuple []any
+
+var ErrInvalidTerm = util.NewInvalidArgumentErrorf("invalid erlang term")
+
+// ParseTerms parses a file of Erlang terms each terminated by a dot like it is read by file:consult/1.
+// Binaries and strings are returned as string, lists as []any and integers as int64.
+func ParseTerms(data []byte) ([]any, error) {
+	p := &termParser{data: data}
+
+	terms := make([]any, 0, 10)
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return terms, nil
+		}
+		t, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(".") {
+			return nil, p.errorf("expected '.'")
+		}
+		terms = append(terms, t)
+	}
+}
+
+type termParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *termParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w at offset %d: %s", ErrInvalidTerm, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *termParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == ' ', c == '\t', c == '\r', c == '\n':
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) consume(s string) bool {
+	if bytes.HasPrefix(p.data[p.pos:], []byte(s)) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end")
+	}
+
+	switch c := p.data[p.pos]; {
+	case p.consume("<<"):
+		return p.parseBinary()
+	case c == '{':
+		p.pos++
+		elems, err := p.parseSequence('}')
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elems), nil
+	case c == '[':
+		p.pos++
+		return p.parseSequence(']')
+	case c == '"':
+		s, err := p.parseQuoted('"')
+		if err != nil {
+			return nil, err
+		}
+		return string(runesToUTF8(s)), nil
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(runesToUTF8(s)), nil
+	case c == '-' || isDigit(c):
+		return p.parseInteger()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.data) && isAtomChar(p.data[p.pos]) {
+			p.pos++
+		}
+		return Atom(p.data[start:p.pos]), nil
+	default:
+		return nil, p.errorf("unexpected character %q", c)
+	}
+}
+
+func (p *termParser) parseSequence(end byte) ([]any, error) {
+	elems := make([]any, 0, 4)
+
+	p.skipSpace()
+	if p.consume(string(end)) {
+		return elems, nil
+	}
+	for {
+		t, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, t)
+
+		p.skipSpace()
+		if p.consume(string(end)) {
+			return elems, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or '%c'", end)
+		}
+	}
+}
+
+// parseBinary parses the segments of a binary which are either strings or bytes
+func (p *termParser) parseBinary() (string, error) {
+	var buf []byte
+
+	p.skipSpace()
+	if p.consume(">>") {
+		return "", nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos < len(p.data) && p.data[p.pos] == '"' {
+			s, err := p.parseQuoted('"')
+			if err != nil {
+				return "", err
+			}
+			if p.consume("/utf8") {
+				buf = append(buf, runesToUTF8(s)...)
+			} else {
+				for _, r := range s {
+					buf = append(buf, byte(r))
+				}
+			}
+		} else {
+			i, err := p.parseInteger()
+			if err != nil {
+				return "", err
+			}
+			buf = append(buf, byte(i))
+		}
+
+		p.skipSpace()
+		if p.consume(">>") {
+			return string(buf), nil
+		}
+		if !p.consume(",") {
+			return "", p.errorf("expected ',' or '>>'")
+		}
+	}
+}
+
+// parseQuoted parses a string or quoted atom and returns its characters
+func (p *termParser) parseQuoted(quote byte) ([]rune, error) {
+	p.pos++
+
+	var s []rune
+	for p.pos < len(p.data) {
+		r, size := utf8.DecodeRune(p.data[p.pos:])
+		p.pos += size
+
+		switch {
+		case r == rune(quote):
+			return s, nil
+		case r != '\\':
+			s = append(s, r)
+		default:
+			r, err := p.parseEscape()
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, r)
+		}
+	}
+	return nil, p.errorf("unterminated string")
+}
+
+// parseEscape parses the escape sequence following a backslash
+func (p *termParser) parseEscape() (rune, error) {
+	if p.pos >= len(p.data) {
+		return 0, p.errorf("unterminated escape sequence")
+	}
+
+	c := p.data[p.pos]
+	p.pos++
+	switch c {
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'v':
+		return '\v', nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'e':
+		return 0x1b, nil
+	case 's':
+		return ' ', nil
+	case 'd':
+		return 0x7f, nil
+	case 'x':
+		var digits string
+		if p.consume("{") {
+			end := bytes.IndexByte(p.data[p.pos:], '}')
+			if end == -1 {
+				return 0, p.errorf("unterminated escape sequence")
+			}
+			digits = string(p.data[p.pos : p.pos+end])
+			p.pos += end + 1
+		} else {
+			if p.pos+2 > len(p.data) {
+				return 0, p.errorf("unterminated escape sequence")
+			}
+			digits = string(p.data[p.pos : p.pos+2])
+			p.pos += 2
+		}
+		v, err := strconv.ParseUint(digits, 16, 32)
+		if err != nil {
+			return 0, p.errorf("invalid escape sequence")
+		}
+		return rune(v), nil
+	}
+	if c >= '0' && c <= '7' {
+		v := rune(c - '0')
+		for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+			v = v*8 + rune(p.data[p.pos]-'0')
+			p.pos++
+		}
+		return v, nil
+	}
+	return rune(c), nil
+}
+
+func (p *termParser) parseInteger() (int64, error) {
+	start := p.pos
+	if p.pos < len(p.data) && p.data[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+		p.pos++
+	}
+	i, err := strconv.ParseInt(string(p.data[start:p.pos]), 10, 64)
+	if err != nil {
+		return 0, p.errorf("invalid integer")
+	}
+	return i, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAtomChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_' || c == '@'
+}
+
+func runesToUTF8(s []rune) []byte {
+	buf := make([]byte, 0, len(s))
+	for _, r := range s {
+		buf = utf8.AppendRune(buf, r)
+	}
+	return buf
+}
+
+// External term format tags
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion      = 131
+	etfSmallInteger = 97
+	etfInteger      = 98
+	etfNil          = 106
+	etfList         = 108
+	etfBinary       = 109
+	etfSmallBigInt  = 110
+	etfMap          = 116
+	etfSmallAtom    = 119
+)
+
+// MarshalTerm encodes the value in the Erlang external term format like term_to_binary/1.
+// Strings are encoded as binaries, booleans and nil as atoms and string keyed maps as maps.
+func MarshalTerm(v any) ([]byte, error) {
+	return appendTerm([]byte{etfVersion}, v)
+}
+
+func appendTerm(buf []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return appendAtom(buf, "nil"), nil
+	case bool:
+		return appendAtom(buf, strconv.FormatBool(v)), nil
+	case Atom:
+		return appendAtom(buf, string(v)), nil
+	case string:
+		buf = append(buf, etfBinary)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v)))
+		return append(buf, v...), nil
+	case int:
+		return appendInteger(buf, int64(v)), nil
+	case int64:
+		return appendInteger(buf, v), nil
+	case []string:
+		list := make([]any, 0, len(v))
+		for _, s := range v {
+			list = append(list, s)
+		}
+		return appendTerm(buf, list)
+	case []any:
+		if len(v) == 0 {
+			return append(buf, etfNil), nil
+		}
+		buf = append(buf, etfList)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v)))
+		var err error
+		for _, e := range v {
+			if buf, err = appendTerm(buf, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, etfNil), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf = append(buf, etfMap)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v)))
+		var err error
+		for _, k := range keys {
+			if buf, err = appendTerm(buf, k); err != nil {
+				return nil, err
+			}
+			if buf, err = appendTerm(buf, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+func appendAtom(buf []byte, name string) []byte {
+	buf = append(buf, etfSmallAtom, byte(len(name)))
+	return append(buf, name...)
+}
+
+func appendInteger(buf []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= 255:
+		return append(buf, etfSmallInteger, byte(i))
+	case i >= -1<<31 && i < 1<<31:
+		buf = append(buf, etfInteger)
+		return binary.BigEndian.AppendUint32(buf, uint32(int32(i)))
+	}
+
+	sign := byte(0)
+	u := uint64(i)
+	if i < 0 {
+		sign = 1
+		u = uint64(-i)
+	}
+	digits := make([]byte, 0, 8)
+	for ; u > 0; u >>= 8 {
+		digits = append(digits, byte(u))
+	}
+	buf = append(buf, etfSmallBigInt, byte(len(digits)), sign)
+	return append(buf, digits...)
+}
+
+// proplist converts a list of two element tuples with binary or atom keys to a map
+func proplist(v any) map[string]any {
+	m := make(map[string]any)
+	list, _ := v.([]any)
+	for _, e := range list {
+		if t, ok := e.(Tuple); ok && len(t) == 2 {
+			if k := termString(t[0]); k != "" {
+				m[k] = t[1]
+			}
+		}
+	}
+	return m
+}
+
+func termString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case Atom:
+		if v == "nil" || v == "undefined" {
+			return ""
+		}
+		return string(v)
+	}
+	return ""
+}
+
+func termStringList(v any) []string {
+	list, _ := v.([]any)
+	s := make([]string, 0, len(list))
+	for _, e := range list {
+		if str := strings.TrimSpace(termString(e)); str != "" {
+			s = append(s, str)
+		}
+	}
+	return s
+}
+
+func termBool(v any) bool {
+	a, ok := v.(Atom)
+	return ok && a == "true"
+}
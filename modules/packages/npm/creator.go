@@ -8,6 +8,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"regexp"
@@ -194,46 +195,18 @@ func ParsePackage(r io.Reader) (*Package, error) {
 			return nil, ErrInvalidPackageVersion
 		}
 
-		scope := ""
-		name := meta.Name
-		nameParts := strings.SplitN(meta.Name, "/", 2)
-		if len(nameParts) == 2 {
-			scope = nameParts[0]
-			name = nameParts[1]
-		}
-
-		if !validation.IsValidURL(meta.Homepage) {
-			meta.Homepage = ""
-		}
-
 		p := &Package{
 			Name:     meta.Name,
 			Version:  v.String(),
 			DistTags: make([]string, 0, 1),
-			Metadata: Metadata{
-				Scope:                   scope,
-				Name:                    name,
-				Description:             meta.Description,
-				Author:                  meta.Author.Name,
-				License:                 meta.License,
-				ProjectURL:              meta.Homepage,
-				Keywords:                meta.Keywords,
-				Dependencies:            meta.Dependencies,
-				BundleDependencies:      meta.BundleDependencies,
-				DevelopmentDependencies: meta.DevDependencies,
-				PeerDependencies:        meta.PeerDependencies,
-				OptionalDependencies:    meta.OptionalDependencies,
-				Bin:                     meta.Bin,
-				Readme:                  meta.Readme,
-				Repository:              meta.Repository,
-			},
+			Metadata: NewMetadata(meta),
 		}
 
 		for tag := range upload.DistTags {
 			p.DistTags = append(p.DistTags, tag)
 		}
 
-		p.Filename = strings.ToLower(fmt.Sprintf("%s-%s.tgz", name, p.Version))
+		p.Filename = Filename(meta.Name, p.Version)
 
 		attachment := func() *PackageAttachment {
 			for _, a := range upload.Attachments {
@@ -278,6 +251,65 @@ func ParsePackage(r io.Reader) (*Package, error) {
 	return nil, ErrInvalidPackage
 }
 
+// NewMetadata creates the metadata stored for a version of a package
+func NewMetadata(meta *PackageMetadataVersion) Metadata {
+	scope := ""
+	name := meta.Name
+	nameParts := strings.SplitN(meta.Name, "/", 2)
+	if len(nameParts) == 2 {
+		scope = nameParts[0]
+		name = nameParts[1]
+	}
+
+	projectURL := meta.Homepage
+	if !validation.IsValidURL(projectURL) {
+		projectURL = ""
+	}
+
+	return Metadata{
+		Scope:                   scope,
+		Name:                    name,
+		Description:             meta.Description,
+		Author:                  meta.Author.Name,
+		License:                 meta.License,
+		ProjectURL:              projectURL,
+		Keywords:                meta.Keywords,
+		Dependencies:            meta.Dependencies,
+		BundleDependencies:      meta.BundleDependencies,
+		DevelopmentDependencies: meta.DevDependencies,
+		PeerDependencies:        meta.PeerDependencies,
+		OptionalDependencies:    meta.OptionalDependencies,
+		Bin:                     meta.Bin,
+		Readme:                  meta.Readme,
+		Repository:              meta.Repository,
+	}
+}
+
+// Filename gets the name of the tarball of a version of a package
+func Filename(packageName, packageVersion string) string {
+	if _, name, ok := strings.Cut(packageName, "/"); ok {
+		packageName = name
+	}
+	return strings.ToLower(fmt.Sprintf("%s-%s.tgz", packageName, packageVersion))
+}
+
+// VerifyIntegrity checks the hashes of the distribution of a version against its tarball
+func VerifyIntegrity(dist *PackageDistribution, hashSHA1, hashSHA512 []byte) bool {
+	if algorithm, value, ok := strings.Cut(dist.Integrity, "-"); ok {
+		integrityHash, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return false
+		}
+		switch algorithm {
+		case "sha1":
+			return bytes.Equal(integrityHash, hashSHA1)
+		case "sha512":
+			return bytes.Equal(integrityHash, hashSHA512)
+		}
+	}
+	return dist.Shasum != "" && strings.EqualFold(dist.Shasum, hex.EncodeToString(hashSHA1))
+}
+
 func validateName(name string) bool {
 	if strings.TrimSpace(name) != name {
 		return false
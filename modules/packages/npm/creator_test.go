@@ -5,7 +5,10 @@ package npm
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"testing"
@@ -300,3 +303,19 @@ func TestParsePackage(t *testing.T) {
 		assert.Equal(t, repository.URL, p.Metadata.Repository.URL)
 	})
 }
+
+func TestFilename(t *testing.T) {
+	assert.Equal(t, "test-package-1.0.1-pre.tgz", Filename("@scope/test-package", "1.0.1-pre"))
+	assert.Equal(t, "test-package-1.0.1.tgz", Filename("Test-Package", "1.0.1"))
+}
+
+func TestVerifyIntegrity(t *testing.T) {
+	data := []byte("npm tarball")
+	hashSHA1 := sha1.Sum(data)
+	hashSHA512 := sha512.Sum512(data)
+
+	assert.True(t, VerifyIntegrity(&PackageDistribution{Integrity: "sha512-" + base64.StdEncoding.EncodeToString(hashSHA512[:])}, hashSHA1[:], hashSHA512[:]))
+	assert.True(t, VerifyIntegrity(&PackageDistribution{Shasum: hex.EncodeToString(hashSHA1[:])}, hashSHA1[:], hashSHA512[:]))
+	assert.False(t, VerifyIntegrity(&PackageDistribution{Integrity: "sha512-" + base64.StdEncoding.EncodeToString(hashSHA1[:])}, hashSHA1[:], hashSHA512[:]))
+	assert.False(t, VerifyIntegrity(&PackageDistribution{}, hashSHA1[:], hashSHA512[:]))
+}
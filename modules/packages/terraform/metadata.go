@@ -0,0 +1,244 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	PropertyOS   = "terraform.os"
+	PropertyArch = "terraform.arch"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+
+	// DefaultProtocol is the plugin protocol assumed for providers published without a manifest
+	DefaultProtocol = "5.0"
+
+	maxReadmeSize   = 1 * 1024 * 1024
+	maxManifestSize = 64 * 1024
+)
+
+var (
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidFilename      = util.NewInvalidArgumentErrorf("filename is invalid")
+	ErrMissingConfiguration = util.NewInvalidArgumentErrorf("module archive contains no configuration file")
+	ErrMissingExecutable    = util.NewInvalidArgumentErrorf("provider archive contains no executable")
+
+	// https://developer.hashicorp.com/terraform/internals/module-registry-protocol
+	namePattern   = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?\z`)
+	systemPattern = regexp.MustCompile(`\A[0-9a-z]{1,64}\z`)
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+	providerPattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern = regexp.MustCompile(`\A[0-9a-z]+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider version
+type Metadata struct {
+	Kind      string   `json:"kind"`
+	Readme    string   `json:"readme,omitempty"`
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// ProviderFile is a file of a provider version
+type ProviderFile struct {
+	Provider string
+	Version  string
+	OS       string
+	Arch     string
+}
+
+// IsValidModuleName checks the name and the target system of a module
+func IsValidModuleName(name, system string) bool {
+	return namePattern.MatchString(name) && systemPattern.MatchString(system)
+}
+
+// IsValidProviderName checks the type of a provider
+func IsValidProviderName(provider string) bool {
+	return providerPattern.MatchString(provider)
+}
+
+// ModulePackageName gets the name of the package which stores the versions of a module
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// IsModulePackageName checks if the package stores a module, providers have no system part
+func IsModulePackageName(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// ModuleFilename gets the name of the archive of a module version
+func ModuleFilename(name, system, v string) string {
+	return strings.ToLower(name + "-" + system + "-" + v + ".tar.gz")
+}
+
+// ProviderFilename gets the name of the archive of a provider version for a platform
+func ProviderFilename(provider, v, os, arch string) string {
+	return "terraform-provider-" + provider + "_" + v + "_" + os + "_" + arch + ".zip"
+}
+
+// ShasumsFilename gets the name of the checksum file of a provider version
+func ShasumsFilename(provider, v string) string {
+	return "terraform-provider-" + provider + "_" + v + "_SHA256SUMS"
+}
+
+// ShasumsSignatureFilename gets the name of the detached signature of the checksum file
+func ShasumsSignatureFilename(provider, v string) string {
+	return ShasumsFilename(provider, v) + ".sig"
+}
+
+// ManifestFilename gets the name of the registry manifest of a provider version
+func ManifestFilename(provider, v string) string {
+	return "terraform-provider-" + provider + "_" + v + "_manifest.json"
+}
+
+// IsValidVersion checks a version, it must be a semantic version without a leading "v"
+func IsValidVersion(v string) bool {
+	if strings.HasPrefix(v, "v") {
+		return false
+	}
+	_, err := version.NewSemver(v)
+	return err == nil
+}
+
+// ParseProviderFilename parses the name of a provider archive
+// terraform-provider-{type}_{version}_{os}_{arch}.zip
+func ParseProviderFilename(filename string) (*ProviderFile, error) {
+	base, ok := strings.CutSuffix(filename, ".zip")
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+	base, ok = strings.CutPrefix(base, "terraform-provider-")
+	if !ok {
+		return nil, ErrInvalidFilename
+	}
+
+	parts := strings.Split(base, "_")
+	if len(parts) != 4 {
+		return nil, ErrInvalidFilename
+	}
+	if !IsValidProviderName(parts[0]) || !platformPattern.MatchString(parts[2]) || !platformPattern.MatchString(parts[3]) {
+		return nil, ErrInvalidFilename
+	}
+	if !IsValidVersion(parts[1]) {
+		return nil, ErrInvalidVersion
+	}
+
+	return &ProviderFile{
+		Provider: parts[0],
+		Version:  parts[1],
+		OS:       parts[2],
+		Arch:     parts[3],
+	}, nil
+}
+
+// ParseModule parses a module archive (.tar.gz), the configuration files are expected in the root directory
+func ParseModule(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+
+	hasConfiguration := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean(hd.Name), "/")
+		if strings.Contains(name, "/") {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(name, ".tf"), strings.HasSuffix(name, ".tf.json"):
+			hasConfiguration = true
+		case strings.EqualFold(name, "README.md"):
+			readme, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(readme)
+		}
+	}
+
+	if !hasConfiguration {
+		return nil, ErrMissingConfiguration
+	}
+
+	return m, nil
+}
+
+// ParseProvider checks that a provider archive (.zip) contains the executable of the provider
+func ParseProvider(r io.ReaderAt, size int64, provider string) error {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range archive.File {
+		if !strings.Contains(file.Name, "/") && strings.HasPrefix(file.Name, "terraform-provider-"+provider) {
+			return nil
+		}
+	}
+	return ErrMissingExecutable
+}
+
+// ParseManifest parses the registry manifest (terraform-registry-manifest.json) of a provider version
+// and returns the supported plugin protocols
+func ParseManifest(r io.Reader) ([]string, error) {
+	var manifest struct {
+		Version  int `json:"version"`
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, maxManifestSize)).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != 1 {
+		return nil, util.NewInvalidArgumentErrorf("unsupported manifest version %d", manifest.Version)
+	}
+	if len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("manifest contains no protocol versions")
+	}
+	for _, p := range manifest.Metadata.ProtocolVersions {
+		if _, err := version.NewVersion(p); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid protocol version %q", p)
+		}
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModule(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("MissingConfiguration", func(t *testing.T) {
+		data := createArchive(map[string]string{"README.md": "", "modules/nested/main.tf": ""})
+
+		metadata, err := ParseModule(data)
+		assert.Nil(t, metadata)
+		require.ErrorIs(t, err, ErrMissingConfiguration)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createArchive(map[string]string{"./main.tf": "", "./README.md": "# Module"})
+
+		metadata, err := ParseModule(data)
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, "# Module", metadata.Readme)
+	})
+}
+
+func TestParseProviderFilename(t *testing.T) {
+	for _, filename := range []string{
+		"terraform-provider-test_1.0.0_linux_amd64.tar.gz",
+		"provider-test_1.0.0_linux_amd64.zip",
+		"terraform-provider-test_1.0.0_linux.zip",
+		"terraform-provider-Test_1.0.0_linux_amd64.zip",
+		"terraform-provider-test_v1.0.0_linux_amd64.zip",
+		"terraform-provider-test_1.0.0_linux_amd-64.zip",
+	} {
+		_, err := ParseProviderFilename(filename)
+		require.Error(t, err, filename)
+	}
+
+	pf, err := ParseProviderFilename("terraform-provider-test-name_1.2.3-beta.1_linux_amd64.zip")
+	require.NoError(t, err)
+	assert.Equal(t, "test-name", pf.Provider)
+	assert.Equal(t, "1.2.3-beta.1", pf.Version)
+	assert.Equal(t, "linux", pf.OS)
+	assert.Equal(t, "amd64", pf.Arch)
+	assert.Equal(t, "terraform-provider-test-name_1.2.3-beta.1_linux_amd64.zip", ProviderFilename(pf.Provider, pf.Version, pf.OS, pf.Arch))
+}
+
+func TestParseProvider(t *testing.T) {
+	createArchive := func(filename string) *bytes.Reader {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create(filename)
+		w.Write([]byte("binary"))
+		zw.Close()
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	data := createArchive("terraform-provider-test_v1.0.0")
+	require.NoError(t, ParseProvider(data, data.Size(), "test"))
+
+	data = createArchive("bin/terraform-provider-test_v1.0.0")
+	require.ErrorIs(t, ParseProvider(data, data.Size(), "test"), ErrMissingExecutable)
+
+	data = createArchive("terraform-provider-other_v1.0.0")
+	require.ErrorIs(t, ParseProvider(data, data.Size(), "test"), ErrMissingExecutable)
+}
+
+func TestParseManifest(t *testing.T) {
+	protocols, err := ParseManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6.0"}, protocols)
+
+	_, err = ParseManifest(strings.NewReader(`{"version":2,"metadata":{"protocol_versions":["6.0"]}}`))
+	require.Error(t, err)
+
+	_, err = ParseManifest(strings.NewReader(`{"version":1,"metadata":{}}`))
+	require.Error(t, err)
+}
@@ -22,7 +22,15 @@ type KeyAndOwner struct {
 
 // ServNoCommand returns information about the provided key
 func ServNoCommand(ctx context.Context, keyID int64) (*asymkey_model.PublicKey, *user_model.User, error) {
-	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/none/%d", keyID)
+	return servNoCommand(ctx, setting.LocalURL+fmt.Sprintf("api/internal/serv/none/%d", keyID))
+}
+
+// ServNoCommandForCertificate returns information about the provided SSH certificate issued by this instance
+func ServNoCommandForCertificate(ctx context.Context, certID int64) (*asymkey_model.PublicKey, *user_model.User, error) {
+	return servNoCommand(ctx, setting.LocalURL+fmt.Sprintf("api/internal/serv/none/%d?cert=true", certID))
+}
+
+func servNoCommand(ctx context.Context, reqURL string) (*asymkey_model.PublicKey, *user_model.User, error) {
 	req := newInternalRequest(ctx, reqURL, "GET")
 	keyAndOwner, extra := requestJSONResp(req, &KeyAndOwner{})
 	if extra.HasError() {
@@ -47,12 +55,24 @@ type ServCommandResults struct {
 
 // ServCommand preps for a serv call
 func ServCommand(ctx context.Context, keyID int64, ownerName, repoName string, mode perm.AccessMode, verbs ...string) (*ServCommandResults, ResponseExtra) {
+	return servCommand(ctx, keyID, false, ownerName, repoName, mode, verbs...)
+}
+
+// ServCommandForCertificate preps for a serv call of a connection authenticated with a SSH certificate issued by this instance
+func ServCommandForCertificate(ctx context.Context, certID int64, ownerName, repoName string, mode perm.AccessMode, verbs ...string) (*ServCommandResults, ResponseExtra) {
+	return servCommand(ctx, certID, true, ownerName, repoName, mode, verbs...)
+}
+
+func servCommand(ctx context.Context, keyID int64, isCert bool, ownerName, repoName string, mode perm.AccessMode, verbs ...string) (*ServCommandResults, ResponseExtra) {
 	reqURL := setting.LocalURL + fmt.Sprintf("api/internal/serv/command/%d/%s/%s?mode=%d",
 		keyID,
 		url.PathEscape(ownerName),
 		url.PathEscape(repoName),
 		mode,
 	)
+	if isCert {
+		reqURL += "&cert=true"
+	}
 	for _, verb := range verbs {
 		if verb != "" {
 			reqURL += fmt.Sprintf("&verb=%s", url.QueryEscape(verb))
@@ -0,0 +1,122 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"strconv"
+	"time"
+)
+
+// CycloneDX is a CycloneDX 1.5 JSON document
+// https://cyclonedx.org/docs/1.5/json/
+type CycloneDX struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDXMetadata     `json:"metadata"`
+	Components   []CycloneDXComponent  `json:"components"`
+	Dependencies []CycloneDXDependency `json:"dependencies"`
+}
+
+type CycloneDXMetadata struct {
+	Timestamp  string              `json:"timestamp"`
+	Tools      CycloneDXTools      `json:"tools"`
+	Component  CycloneDXComponent  `json:"component"`
+	Properties []CycloneDXProperty `json:"properties,omitempty"`
+}
+
+type CycloneDXTools struct {
+	Components []CycloneDXComponent `json:"components"`
+}
+
+type CycloneDXComponent struct {
+	Type               string                       `json:"type"`
+	BOMRef             string                       `json:"bom-ref,omitempty"`
+	Name               string                       `json:"name"`
+	Version            string                       `json:"version,omitempty"`
+	PackageURL         string                       `json:"purl,omitempty"`
+	ExternalReferences []CycloneDXExternalReference `json:"externalReferences,omitempty"`
+	Properties         []CycloneDXProperty          `json:"properties,omitempty"`
+}
+
+type CycloneDXExternalReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// NewCycloneDX creates the CycloneDX document of the source.
+// The languages are added as properties of the metadata.
+func NewCycloneDX(s *Source) *CycloneDX {
+	rootRef := s.URL + "@" + s.CommitID
+
+	root := CycloneDXComponent{
+		Type:    "application",
+		BOMRef:  rootRef,
+		Name:    s.Name,
+		Version: s.Version,
+		ExternalReferences: []CycloneDXExternalReference{
+			{Type: "vcs", URL: s.URL},
+		},
+		Properties: []CycloneDXProperty{
+			{Name: "forgejo:commit", Value: s.CommitID},
+		},
+	}
+
+	var properties []CycloneDXProperty
+	for _, lang := range s.languages() {
+		properties = append(properties, CycloneDXProperty{
+			Name:  "forgejo:language:" + lang,
+			Value: strconv.FormatInt(s.Languages[lang], 10),
+		})
+	}
+
+	components := s.components()
+	doc := &CycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + s.serialNumber(),
+		Version:      1,
+		Metadata: CycloneDXMetadata{
+			Timestamp: s.Created.UTC().Format(time.RFC3339),
+			Tools: CycloneDXTools{
+				Components: []CycloneDXComponent{
+					{Type: "application", Name: s.ToolName, Version: s.ToolVersion},
+				},
+			},
+			Component:  root,
+			Properties: properties,
+		},
+		Components: make([]CycloneDXComponent, 0, len(components)),
+	}
+
+	dependsOn := make([]string, 0, len(components))
+	for _, c := range components {
+		component := CycloneDXComponent{
+			Type:       "library",
+			BOMRef:     c.PackageURL,
+			Name:       c.Dependency.Name,
+			Version:    c.Dependency.Version,
+			PackageURL: c.PackageURL,
+		}
+		for _, m := range c.Manifests {
+			component.Properties = append(component.Properties, CycloneDXProperty{Name: "forgejo:manifest", Value: m})
+		}
+		doc.Components = append(doc.Components, component)
+		dependsOn = append(dependsOn, c.PackageURL)
+	}
+	doc.Dependencies = []CycloneDXDependency{{Ref: rootRef, DependsOn: dependsOn}}
+
+	return doc
+}
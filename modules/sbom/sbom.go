@@ -0,0 +1,182 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package sbom creates software bills of materials in the CycloneDX and the SPDX formats
+// from the dependencies pinned by the lockfiles of a repository
+package sbom
+
+import (
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/lockfile"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/util"
+
+	"github.com/google/uuid"
+)
+
+// Format is the format of a software bill of materials
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// Formats are all supported formats
+var Formats = []Format{FormatCycloneDX, FormatSPDX}
+
+var ErrUnsupportedFormat = util.NewInvalidArgumentErrorf("unsupported SBOM format")
+
+// ParseFormat parses the name of a format
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatCycloneDX:
+		return FormatCycloneDX, nil
+	case FormatSPDX:
+		return FormatSPDX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// FileName returns the conventional name of a document in the format
+func (f Format) FileName() string {
+	if f == FormatSPDX {
+		return "sbom.spdx.json"
+	}
+	return "sbom.cdx.json"
+}
+
+// Source describes the repository tree a bill of materials is created for
+type Source struct {
+	// Name is the full name of the repository
+	Name string
+	// Version is the name of the ref or the tag of the tree
+	Version  string
+	CommitID string
+	// URL is the web link of the repository
+	URL       string
+	Manifests []*lockfile.Manifest
+	// Languages are the sizes of the files per language in bytes
+	Languages   map[string]int64
+	Created     time.Time
+	ToolName    string
+	ToolVersion string
+}
+
+// component is a dependency pinned by one or more lockfiles
+type component struct {
+	Dependency *lockfile.Dependency
+	PackageURL string
+	Manifests  []string
+}
+
+// components returns the unique dependencies of all manifests, sorted by their package URLs
+func (s *Source) components() []*component {
+	byURL := make(map[string]*component)
+	for _, m := range s.Manifests {
+		for _, d := range m.Dependencies {
+			purl := PackageURL(d)
+			c, ok := byURL[purl]
+			if !ok {
+				c = &component{Dependency: d, PackageURL: purl}
+				byURL[purl] = c
+			}
+			if len(c.Manifests) == 0 || c.Manifests[len(c.Manifests)-1] != m.Path {
+				c.Manifests = append(c.Manifests, m.Path)
+			}
+		}
+	}
+
+	components := make([]*component, 0, len(byURL))
+	for _, c := range byURL {
+		components = append(components, c)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].PackageURL < components[j].PackageURL
+	})
+	return components
+}
+
+// languages returns the names of the languages sorted by their size
+func (s *Source) languages() []string {
+	names := make([]string, 0, len(s.Languages))
+	for name := range s.Languages {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if s.Languages[names[i]] != s.Languages[names[j]] {
+			return s.Languages[names[i]] > s.Languages[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// serialNumber is a stable UUID of the document, so documents of the same commit have the same id
+func (s *Source) serialNumber() string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(s.URL+"@"+s.CommitID)).String()
+}
+
+// PackageURL returns the package URL (purl) of a dependency
+func PackageURL(d *lockfile.Dependency) string {
+	var typ, name string
+	switch d.Ecosystem {
+	case osv.EcosystemGo:
+		typ, name = "golang", escapePath(d.Name)
+	case osv.EcosystemNpm:
+		typ, name = "npm", escapePath(d.Name)
+	case osv.EcosystemCargo:
+		typ, name = "cargo", escapeSegment(d.Name)
+	case osv.EcosystemPyPI:
+		typ, name = "pypi", escapeSegment(osv.NormalizeName(osv.EcosystemPyPI, d.Name))
+	default:
+		typ, name = "generic", escapeSegment(d.Name)
+	}
+	return "pkg:" + typ + "/" + name + "@" + escapeSegment(d.Version)
+}
+
+// escapeSegment percent-encodes a segment of a package URL, which must not contain an unescaped "@"
+func escapeSegment(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+}
+
+// escapePath escapes the segments of a namespaced package name
+func escapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = escapeSegment(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// New creates the bill of materials of the source in the format
+func New(format Format, s *Source) (any, error) {
+	switch format {
+	case FormatCycloneDX:
+		return NewCycloneDX(s), nil
+	case FormatSPDX:
+		return NewSPDX(s), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// Encode writes the bill of materials of the source in the format
+func Encode(w io.Writer, format Format, s *Source) error {
+	doc, err := New(format, s)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
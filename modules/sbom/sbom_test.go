@@ -0,0 +1,136 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/lockfile"
+	"forgejo.org/modules/osv"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSource() *Source {
+	return &Source{
+		Name:     "owner/repo",
+		Version:  "v1.0.0",
+		CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		URL:      "https://forgejo.example.com/owner/repo",
+		Manifests: []*lockfile.Manifest{
+			{
+				Path: "go.sum",
+				Dependencies: []*lockfile.Dependency{
+					{Ecosystem: osv.EcosystemGo, Name: "example.com/a", Version: "v1.2.3"},
+				},
+			},
+			{
+				Path: "web/package-lock.json",
+				Dependencies: []*lockfile.Dependency{
+					{Ecosystem: osv.EcosystemNpm, Name: "@scope/pkg", Version: "2.0.0"},
+				},
+			},
+			{
+				Path: "tools/go.sum",
+				Dependencies: []*lockfile.Dependency{
+					{Ecosystem: osv.EcosystemGo, Name: "example.com/a", Version: "v1.2.3"},
+				},
+			},
+		},
+		Languages:   map[string]int64{"Go": 1000, "JavaScript": 200},
+		Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		ToolName:    "Forgejo",
+		ToolVersion: "1.0",
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("CycloneDX")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCycloneDX, f)
+	assert.Equal(t, "sbom.cdx.json", f.FileName())
+
+	f, err = ParseFormat("spdx")
+	require.NoError(t, err)
+	assert.Equal(t, FormatSPDX, f)
+	assert.Equal(t, "sbom.spdx.json", f.FileName())
+
+	_, err = ParseFormat("xml")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestPackageURL(t *testing.T) {
+	assert.Equal(t, "pkg:golang/example.com/a@v1.2.3", PackageURL(&lockfile.Dependency{Ecosystem: osv.EcosystemGo, Name: "example.com/a", Version: "v1.2.3"}))
+	assert.Equal(t, "pkg:npm/%40scope/pkg@2.0.0", PackageURL(&lockfile.Dependency{Ecosystem: osv.EcosystemNpm, Name: "@scope/pkg", Version: "2.0.0"}))
+	assert.Equal(t, "pkg:cargo/serde@1.0.100", PackageURL(&lockfile.Dependency{Ecosystem: osv.EcosystemCargo, Name: "serde", Version: "1.0.100"}))
+	assert.Equal(t, "pkg:pypi/zope-interface@5.0", PackageURL(&lockfile.Dependency{Ecosystem: osv.EcosystemPyPI, Name: "Zope_Interface", Version: "5.0"}))
+}
+
+func TestCycloneDX(t *testing.T) {
+	doc := NewCycloneDX(testSource())
+
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Equal(t, "urn:uuid:"+testSource().serialNumber(), doc.SerialNumber)
+	assert.Equal(t, "2026-01-02T03:04:05Z", doc.Metadata.Timestamp)
+	assert.Equal(t, "owner/repo", doc.Metadata.Component.Name)
+	assert.Equal(t, []CycloneDXProperty{
+		{Name: "forgejo:language:Go", Value: "1000"},
+		{Name: "forgejo:language:JavaScript", Value: "200"},
+	}, doc.Metadata.Properties)
+
+	require.Len(t, doc.Components, 2)
+	assert.Equal(t, "pkg:golang/example.com/a@v1.2.3", doc.Components[0].PackageURL)
+	assert.Equal(t, []CycloneDXProperty{
+		{Name: "forgejo:manifest", Value: "go.sum"},
+		{Name: "forgejo:manifest", Value: "tools/go.sum"},
+	}, doc.Components[0].Properties)
+	assert.Equal(t, "@scope/pkg", doc.Components[1].Name)
+
+	require.Len(t, doc.Dependencies, 1)
+	assert.Equal(t, doc.Metadata.Component.BOMRef, doc.Dependencies[0].Ref)
+	assert.Equal(t, []string{"pkg:golang/example.com/a@v1.2.3", "pkg:npm/%40scope/pkg@2.0.0"}, doc.Dependencies[0].DependsOn)
+}
+
+func TestSPDX(t *testing.T) {
+	doc := NewSPDX(testSource())
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "owner/repo@v1.0.0", doc.Name)
+	assert.Equal(t, []string{"Tool: Forgejo-1.0"}, doc.CreationInfo.Creators)
+
+	require.Len(t, doc.Packages, 3)
+	root := doc.Packages[0]
+	assert.Equal(t, "SPDXRef-Repository", root.SPDXID)
+	assert.Equal(t, "git+https://forgejo.example.com/owner/repo@65f1bf27bc3bf70f64657658635e66094edbcb4d", root.DownloadLocation)
+	require.Len(t, root.Annotations, 2)
+	assert.Equal(t, "language Go: 1000 bytes", root.Annotations[0].Comment)
+
+	assert.Equal(t, "SPDXRef-Package-1-example.com-a", doc.Packages[1].SPDXID)
+	assert.Equal(t, "pinned in go.sum, tools/go.sum", doc.Packages[1].SourceInfo)
+	assert.Equal(t, "SPDXRef-Package-2--scope-pkg", doc.Packages[2].SPDXID)
+	assert.Equal(t, "pkg:npm/%40scope/pkg@2.0.0", doc.Packages[2].ExternalRefs[0].ReferenceLocator)
+
+	assert.Equal(t, []SPDXRelationship{
+		{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Repository"},
+		{SPDXElementID: "SPDXRef-Repository", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-1-example.com-a"},
+		{SPDXElementID: "SPDXRef-Repository", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-2--scope-pkg"},
+	}, doc.Relationships)
+}
+
+func TestEncode(t *testing.T) {
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		require.NoError(t, Encode(&buf, format, testSource()))
+
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	}
+
+	require.ErrorIs(t, Encode(&bytes.Buffer{}, "xml", testSource()), ErrUnsupportedFormat)
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SPDX is a SPDX 2.3 JSON document
+// https://spdx.github.io/spdx-spec/v2.3/
+type SPDX struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo   `json:"creationInfo"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs,omitempty"`
+	Annotations      []SPDXAnnotation  `json:"annotations,omitempty"`
+}
+
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SPDXAnnotation struct {
+	Annotator      string `json:"annotator"`
+	AnnotationDate string `json:"annotationDate"`
+	AnnotationType string `json:"annotationType"`
+	Comment        string `json:"comment"`
+}
+
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const (
+	spdxDocumentID   = "SPDXRef-DOCUMENT"
+	spdxRepositoryID = "SPDXRef-Repository"
+)
+
+var spdxInvalidIDChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// NewSPDX creates the SPDX document of the source.
+// The languages are added as annotations of the package of the repository.
+func NewSPDX(s *Source) *SPDX {
+	created := s.Created.UTC().Format(time.RFC3339)
+	tool := "Tool: " + s.ToolName + "-" + s.ToolVersion
+
+	root := SPDXPackage{
+		SPDXID:           spdxRepositoryID,
+		Name:             s.Name,
+		VersionInfo:      s.Version,
+		DownloadLocation: "git+" + s.URL + "@" + s.CommitID,
+		FilesAnalyzed:    false,
+	}
+	for _, lang := range s.languages() {
+		root.Annotations = append(root.Annotations, SPDXAnnotation{
+			Annotator:      tool,
+			AnnotationDate: created,
+			AnnotationType: "OTHER",
+			Comment:        fmt.Sprintf("language %s: %d bytes", lang, s.Languages[lang]),
+		})
+	}
+
+	components := s.components()
+	doc := &SPDX{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentID,
+		Name:              s.Name + "@" + s.Version,
+		DocumentNamespace: s.URL + "/spdx/" + s.serialNumber(),
+		CreationInfo: SPDXCreationInfo{
+			Created:  created,
+			Creators: []string{tool},
+		},
+		Packages: append(make([]SPDXPackage, 0, len(components)+1), root),
+		Relationships: append(make([]SPDXRelationship, 0, len(components)+1), SPDXRelationship{
+			SPDXElementID:      spdxDocumentID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: spdxRepositoryID,
+		}),
+	}
+
+	for i, c := range components {
+		id := fmt.Sprintf("SPDXRef-Package-%d-%s", i+1, spdxInvalidIDChars.ReplaceAllString(c.Dependency.Name, "-"))
+		pkg := SPDXPackage{
+			SPDXID:           id,
+			Name:             c.Dependency.Name,
+			VersionInfo:      c.Dependency.Version,
+			DownloadLocation: "NOASSERTION",
+			FilesAnalyzed:    false,
+			ExternalRefs: []SPDXExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PackageURL},
+			},
+		}
+		if len(c.Manifests) > 0 {
+			pkg.SourceInfo = "pinned in " + strings.Join(c.Manifests, ", ")
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, SPDXRelationship{
+			SPDXElementID:      spdxRepositoryID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	return doc
+}
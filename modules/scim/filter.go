@@ -0,0 +1,413 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"forgejo.org/modules/json"
+)
+
+// Filter selects resources by their attributes (RFC 7644 section 3.4.2.2).
+// Resources are matched in their JSON representation.
+type Filter interface {
+	Match(resource map[string]any) bool
+}
+
+type logicalFilter struct {
+	and         bool
+	left, right Filter
+}
+
+func (f *logicalFilter) Match(resource map[string]any) bool {
+	if f.and {
+		return f.left.Match(resource) && f.right.Match(resource)
+	}
+	return f.left.Match(resource) || f.right.Match(resource)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f *notFilter) Match(resource map[string]any) bool {
+	return !f.filter.Match(resource)
+}
+
+// valuePathFilter matches if any value of a multi-valued attribute matches the filter
+type valuePathFilter struct {
+	attribute string
+	filter    Filter
+}
+
+func (f *valuePathFilter) Match(resource map[string]any) bool {
+	for _, value := range asSlice(getAttribute(resource, f.attribute)) {
+		if element, ok := value.(map[string]any); ok && f.filter.Match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+type attributeFilter struct {
+	attribute    string
+	subAttribute string
+	operator     string
+	value        any
+}
+
+func (f *attributeFilter) Match(resource map[string]any) bool {
+	values := f.values(resource)
+	if f.operator == "pr" {
+		return len(values) > 0
+	}
+	if f.value == nil {
+		return (f.operator == "eq") == (len(values) == 0)
+	}
+	if f.operator == "ne" {
+		for _, value := range values {
+			if compare("eq", value, f.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, value := range values {
+		if compare(f.operator, value, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// values returns the non-empty values of the attribute. The "value" sub-attribute
+// is used for multi-valued complex attributes if no sub-attribute is given.
+func (f *attributeFilter) values(resource map[string]any) []any {
+	var values []any
+	for _, value := range asSlice(getAttribute(resource, f.attribute)) {
+		if element, ok := value.(map[string]any); ok {
+			subAttribute := f.subAttribute
+			if subAttribute == "" {
+				subAttribute = "value"
+			}
+			value = getAttribute(element, subAttribute)
+		}
+		if value != nil && value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func compare(operator string, actual, expected any) bool {
+	switch expected := expected.(type) {
+	case string:
+		actual, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		actual, expected = strings.ToLower(actual), strings.ToLower(expected)
+		switch operator {
+		case "eq":
+			return actual == expected
+		case "co":
+			return strings.Contains(actual, expected)
+		case "sw":
+			return strings.HasPrefix(actual, expected)
+		case "ew":
+			return strings.HasSuffix(actual, expected)
+		case "gt":
+			return actual > expected
+		case "ge":
+			return actual >= expected
+		case "lt":
+			return actual < expected
+		case "le":
+			return actual <= expected
+		}
+	case bool:
+		actual, ok := actual.(bool)
+		return ok && operator == "eq" && actual == expected
+	case float64:
+		actual, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "eq":
+			return actual == expected
+		case "gt":
+			return actual > expected
+		case "ge":
+			return actual >= expected
+		case "lt":
+			return actual < expected
+		case "le":
+			return actual <= expected
+		}
+	}
+	return false
+}
+
+var comparisonOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// ParseFilter parses a filter expression like `userName eq "jdoe" and active eq true`
+func ParseFilter(expression string) (Filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilter("unexpected %q", p.tokens[p.pos].text)
+	}
+	return filter, nil
+}
+
+func invalidFilter(format string, args ...any) *Error {
+	return NewError(http.StatusBadRequest, ErrorTypeInvalidFilter, "invalid filter: "+format, args...)
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expression) && expression[end] != '"'; end++ {
+				if expression[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expression) {
+				return nil, invalidFilter("unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(expression[i:end+1]), &value); err != nil {
+				return nil, invalidFilter("invalid string %s", expression[i:end+1])
+			}
+			tokens = append(tokens, filterToken{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := strings.IndexFunc(expression[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(`()[]"`, r)
+			})
+			if end < 0 {
+				end = len(expression) - i
+			}
+			tokens = append(tokens, filterToken{text: expression[i : i+end]})
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) expect(text string) error {
+	if !p.peekKeyword(text) {
+		return invalidFilter("expected %q", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseExpression() (Filter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		filter, err := p.parseGroup(")")
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: filter}, nil
+	}
+	if p.peekKeyword("(") {
+		p.pos++
+		return p.parseGroup(")")
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, invalidFilter("expected an attribute")
+	}
+	attribute, subAttribute := splitAttributePath(p.tokens[p.pos].text)
+	p.pos++
+
+	if p.peekKeyword("[") {
+		p.pos++
+		if subAttribute != "" {
+			return nil, invalidFilter("unexpected sub-attribute %q", subAttribute)
+		}
+		filter, err := p.parseGroup("]")
+		if err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{attribute: attribute, filter: filter}, nil
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, invalidFilter("expected an operator after %q", attribute)
+	}
+	operator := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+	filter := &attributeFilter{attribute: attribute, subAttribute: subAttribute, operator: operator}
+	if operator == "pr" {
+		return filter, nil
+	}
+	if !comparisonOperators[operator] {
+		return nil, invalidFilter("unknown operator %q", operator)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, invalidFilter("expected a value after %q", operator)
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	if token.quoted {
+		filter.value = token.text
+		return filter, nil
+	}
+	switch strings.ToLower(token.text) {
+	case "true":
+		filter.value = true
+	case "false":
+		filter.value = false
+	case "null":
+		if operator != "eq" && operator != "ne" {
+			return nil, invalidFilter("null can only be compared with eq or ne")
+		}
+	default:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, invalidFilter("invalid value %q", token.text)
+		}
+		filter.value = number
+	}
+	return filter, nil
+}
+
+func (p *filterParser) parseGroup(closing string) (Filter, error) {
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(closing); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// splitAttributePath removes the schema URN of an attribute path and splits it into the attribute and the sub-attribute
+func splitAttributePath(path string) (attribute, subAttribute string) {
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		path = path[strings.LastIndex(path, ":")+1:]
+	}
+	attribute, subAttribute, _ = strings.Cut(path, ".")
+	return attribute, subAttribute
+}
+
+// attributeKey returns the key of the attribute in the resource, which is matched case-insensitively
+func attributeKey(resource map[string]any, attribute string) (string, bool) {
+	if _, ok := resource[attribute]; ok {
+		return attribute, true
+	}
+	for key := range resource {
+		if strings.EqualFold(key, attribute) {
+			return key, true
+		}
+	}
+	return attribute, false
+}
+
+func getAttribute(resource map[string]any, attribute string) any {
+	key, ok := attributeKey(resource, attribute)
+	if !ok {
+		return nil
+	}
+	return resource[key]
+}
+
+func asSlice(value any) []any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return value
+	default:
+		return []any{value}
+	}
+}
+
+// EqualityValue returns the value if the filter only compares the attribute for equality with a string.
+// It allows to look up resources by indexed attributes instead of matching all of them.
+func EqualityValue(filter Filter, attribute string) (string, bool) {
+	f, ok := filter.(*attributeFilter)
+	if !ok || f.operator != "eq" || f.subAttribute != "" || !strings.EqualFold(f.attribute, attribute) {
+		return "", false
+	}
+	value, ok := f.value.(string)
+	return value, ok
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	active := true
+	user, err := ToMap(&User{
+		Schemas:    []string{UserSchema},
+		ID:         "2",
+		ExternalID: "00u1",
+		UserName:   "Jane.Doe",
+		Name:       &Name{GivenName: "Jane", FamilyName: "Doe"},
+		Emails: []Email{
+			{Value: "jane@example.com", Type: "work", Primary: true},
+			{Value: "jane@home.example.org", Type: "home"},
+		},
+		Active: &active,
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		filter  string
+		matches bool
+	}{
+		{`userName eq "jane.doe"`, true},
+		{`USERNAME Eq "Jane.Doe"`, true},
+		{`userName eq "john"`, false},
+		{`userName ne "john"`, true},
+		{`userName sw "jane"`, true},
+		{`userName ew ".doe"`, true},
+		{`userName co "e.d"`, true},
+		{`externalId eq "00u1"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jane.doe"`, true},
+		{`name.givenName eq "Jane"`, true},
+		{`name.familyName eq "Roe"`, false},
+		{`emails eq "jane@home.example.org"`, true},
+		{`emails.value eq "jane@example.com"`, true},
+		{`emails[type eq "work" and value co "@example.com"]`, true},
+		{`emails[type eq "work" and value co "@home"]`, false},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`displayName pr`, false},
+		{`displayName eq null`, true},
+		{`title pr or userName eq "jane.doe"`, true},
+		{`title pr and userName eq "jane.doe"`, false},
+		{`not (userName eq "jane.doe")`, false},
+		{`(userName eq "john" or externalId eq "00u1") and active eq true`, true},
+		{`userName eq "with \"quotes\""`, false},
+	}
+	for _, c := range cases {
+		t.Run(c.filter, func(t *testing.T) {
+			filter, err := ParseFilter(c.filter)
+			require.NoError(t, err)
+			assert.Equal(t, c.matches, filter.Match(user))
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName equals "jane"`,
+		`userName eq "jane`,
+		`userName eq jane`,
+		`(userName eq "jane"`,
+		`userName eq "jane" and`,
+		`emails[type eq "work"`,
+		`userName gt null`,
+		`"userName" eq "jane"`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			_, err := ParseFilter(filter)
+			var scimErr *Error
+			require.ErrorAs(t, err, &scimErr)
+			assert.Equal(t, ErrorTypeInvalidFilter, scimErr.Type)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"forgejo.org/modules/json"
+)
+
+// Patch applies the operations of a patch request to a resource like *User or *Group
+func Patch(resource any, operations []PatchOperation) error {
+	object, err := ToMap(resource)
+	if err != nil {
+		return err
+	}
+	if err := ApplyPatch(object, operations); err != nil {
+		return err
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	// attributes which were removed must not keep their previous value
+	value := reflect.ValueOf(resource).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	if err := json.Unmarshal(data, resource); err != nil {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "invalid value: %v", err)
+	}
+	return nil
+}
+
+// ToMap returns the JSON representation of a resource, which filters and patch operations work on
+func ToMap(resource any) (map[string]any, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]any{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// ApplyPatch applies the operations of a patch request (RFC 7644 section 3.5.2)
+// to the JSON representation of a resource
+func ApplyPatch(resource map[string]any, operations []PatchOperation) error {
+	for _, operation := range operations {
+		if err := applyOperation(resource, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]any, operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	switch op {
+	case "add", "replace":
+		if operation.Path != "" {
+			return applyPath(resource, op, operation.Path, operation.Value)
+		}
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "the value of an operation without path must be an object")
+		}
+		for path, value := range values {
+			if err := applyPath(resource, op, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "remove":
+		if operation.Path == "" {
+			return NewError(http.StatusBadRequest, ErrorTypeNoTarget, "a remove operation requires a path")
+		}
+		return applyPath(resource, op, operation.Path, operation.Value)
+	default:
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "unknown operation %q", operation.Op)
+	}
+}
+
+func applyPath(resource map[string]any, op, path string, value any) error {
+	attribute, filter, subAttribute, err := parsePatchPath(path)
+	if err != nil {
+		return err
+	}
+
+	if filter == nil {
+		target := resource
+		if subAttribute != "" {
+			key, _ := attributeKey(resource, attribute)
+			child, ok := resource[key].(map[string]any)
+			if !ok {
+				if op == "remove" {
+					return nil
+				}
+				child = map[string]any{}
+				resource[key] = child
+			}
+			target, attribute = child, subAttribute
+		}
+		if op == "remove" {
+			removeAttribute(target, attribute, value)
+		} else {
+			setAttribute(target, op, attribute, value)
+		}
+		return nil
+	}
+
+	key, _ := attributeKey(resource, attribute)
+	elements := asSlice(resource[key])
+	kept := make([]any, 0, len(elements))
+	matched := false
+	for _, element := range elements {
+		object, ok := element.(map[string]any)
+		if !ok || !filter.Match(object) {
+			kept = append(kept, element)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && subAttribute == "":
+			continue
+		case op == "remove":
+			removeAttribute(object, subAttribute, nil)
+		case subAttribute != "":
+			setAttribute(object, op, subAttribute, value)
+		default:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "the value for %q must be an object", path)
+			}
+			for name, value := range values {
+				setAttribute(object, "replace", name, value)
+			}
+		}
+		kept = append(kept, object)
+	}
+
+	if !matched && op != "remove" {
+		// Clients set a value like `emails[type eq "work"].value` even if there is no such element yet
+		equality, ok := filter.(*attributeFilter)
+		if !ok || equality.operator != "eq" || equality.subAttribute != "" || subAttribute == "" {
+			return NewError(http.StatusBadRequest, ErrorTypeNoTarget, "no value matches %q", path)
+		}
+		element := map[string]any{equality.attribute: equality.value}
+		setAttribute(element, op, subAttribute, value)
+		kept = append(kept, element)
+	}
+	resource[key] = kept
+	return nil
+}
+
+// parsePatchPath splits a path like `emails[type eq "work"].value` into its attribute, value filter and sub-attribute
+func parsePatchPath(path string) (attribute string, filter Filter, subAttribute string, err error) {
+	start := strings.IndexByte(path, '[')
+	if start < 0 {
+		attribute, subAttribute = splitAttributePath(path)
+		return attribute, nil, subAttribute, nil
+	}
+
+	end := strings.LastIndexByte(path, ']')
+	if end < start {
+		return "", nil, "", NewError(http.StatusBadRequest, ErrorTypeInvalidPath, "invalid path %q", path)
+	}
+	attribute, nested := splitAttributePath(path[:start])
+	if nested != "" {
+		return "", nil, "", NewError(http.StatusBadRequest, ErrorTypeInvalidPath, "invalid path %q", path)
+	}
+	rest := path[end+1:]
+	if rest != "" && !strings.HasPrefix(rest, ".") {
+		return "", nil, "", NewError(http.StatusBadRequest, ErrorTypeInvalidPath, "invalid path %q", path)
+	}
+	if filter, err = ParseFilter(path[start+1 : end]); err != nil {
+		return "", nil, "", NewError(http.StatusBadRequest, ErrorTypeInvalidPath, "invalid path %q: %v", path, err)
+	}
+	return attribute, filter, strings.TrimPrefix(rest, "."), nil
+}
+
+func setAttribute(resource map[string]any, op, attribute string, value any) {
+	key, exists := attributeKey(resource, attribute)
+	value = normalizeValue(key, value)
+
+	existing := resource[key]
+	switch {
+	case !exists || existing == nil:
+		resource[key] = value
+	case op == "add" && isSlice(existing) && isSlice(value):
+		values := asSlice(existing)
+		for _, item := range asSlice(value) {
+			if !containsValue(values, item) {
+				values = append(values, item)
+			}
+		}
+		resource[key] = values
+	case isMap(existing) && isMap(value):
+		// sub-attributes which are not given are left unchanged
+		object := existing.(map[string]any)
+		for name, value := range value.(map[string]any) {
+			setAttribute(object, "replace", name, value)
+		}
+	default:
+		resource[key] = value
+	}
+}
+
+// removeAttribute removes an attribute, or only the given values of a multi-valued attribute
+func removeAttribute(resource map[string]any, attribute string, value any) {
+	key, exists := attributeKey(resource, attribute)
+	if !exists {
+		return
+	}
+	if value == nil || !isSlice(resource[key]) {
+		delete(resource, key)
+		return
+	}
+	removed := asSlice(value)
+	kept := make([]any, 0)
+	for _, item := range asSlice(resource[key]) {
+		if !containsValue(removed, item) {
+			kept = append(kept, item)
+		}
+	}
+	resource[key] = kept
+}
+
+// containsValue reports whether values contains the item, comparing complex values by their "value" sub-attribute
+func containsValue(values []any, item any) bool {
+	for _, value := range values {
+		a, aOK := value.(map[string]any)
+		b, bOK := item.(map[string]any)
+		if aOK && bOK && getAttribute(a, "value") != nil {
+			if reflect.DeepEqual(getAttribute(a, "value"), getAttribute(b, "value")) {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(value, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeValue accepts booleans sent as strings, which some identity providers do for "active"
+func normalizeValue(key string, value any) any {
+	if s, ok := value.(string); ok && strings.EqualFold(key, "active") {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func isSlice(value any) bool {
+	_, ok := value.([]any)
+	return ok
+}
+
+func isMap(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
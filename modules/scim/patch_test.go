@@ -0,0 +1,111 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUser(t *testing.T) {
+	newUser := func() *User {
+		return &User{
+			Schemas:    []string{UserSchema},
+			ExternalID: "00u1",
+			UserName:   "jane",
+			Name:       &Name{GivenName: "Jane", FamilyName: "Doe"},
+			Emails:     []Email{{Value: "jane@example.com", Type: "work", Primary: true}},
+		}
+	}
+
+	t.Run("Replace without path", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, Patch(user, []PatchOperation{
+			{Op: "Replace", Value: map[string]any{"active": "False", "name.familyName": "Roe"}},
+		}))
+		assert.False(t, user.IsActive())
+		assert.Equal(t, &Name{GivenName: "Jane", FamilyName: "Roe"}, user.Name)
+	})
+
+	t.Run("Replace sub-attributes", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, Patch(user, []PatchOperation{
+			{Op: "replace", Path: "name", Value: map[string]any{"givenName": "Janet"}},
+			{Op: "replace", Path: "userName", Value: "janet"},
+		}))
+		assert.Equal(t, "janet", user.UserName)
+		assert.Equal(t, &Name{GivenName: "Janet", FamilyName: "Doe"}, user.Name)
+	})
+
+	t.Run("Replace filtered value", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, Patch(user, []PatchOperation{
+			{Op: "replace", Path: `emails[type eq "work"].value`, Value: "jane.doe@example.com"},
+			{Op: "add", Path: `emails[type eq "home"].value`, Value: "jane@home.example.org"},
+		}))
+		assert.Equal(t, []Email{
+			{Value: "jane.doe@example.com", Type: "work", Primary: true},
+			{Value: "jane@home.example.org", Type: "home"},
+		}, user.Emails)
+		assert.Equal(t, "jane.doe@example.com", user.PrimaryEmail())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, Patch(user, []PatchOperation{
+			{Op: "remove", Path: "externalId"},
+			{Op: "remove", Path: "name.givenName"},
+			{Op: "remove", Path: `emails[type eq "work"]`},
+		}))
+		assert.Empty(t, user.ExternalID)
+		assert.Equal(t, &Name{FamilyName: "Doe"}, user.Name)
+		assert.Empty(t, user.Emails)
+	})
+
+	t.Run("Invalid operations", func(t *testing.T) {
+		for _, operation := range []PatchOperation{
+			{Op: "move", Path: "userName"},
+			{Op: "remove"},
+			{Op: "replace", Value: "jane"},
+			{Op: "replace", Path: `emails[type eq "work"`, Value: "jane"},
+			{Op: "replace", Path: `emails[type co "other"].value`, Value: "jane"},
+			{Op: "replace", Path: "active", Value: "maybe"},
+		} {
+			var scimErr *Error
+			require.ErrorAs(t, Patch(newUser(), []PatchOperation{operation}), &scimErr, "%+v", operation)
+			assert.Equal(t, 400, scimErr.Status)
+		}
+	})
+}
+
+func TestPatchGroupMembers(t *testing.T) {
+	group := &Group{
+		Schemas:     []string{GroupSchema},
+		DisplayName: "org/team",
+		Members:     []Member{{Value: "1"}, {Value: "2"}},
+	}
+
+	require.NoError(t, Patch(group, []PatchOperation{
+		{Op: "add", Path: "members", Value: []any{map[string]any{"value": "2"}, map[string]any{"value": "3"}}},
+	}))
+	assert.Equal(t, []Member{{Value: "1"}, {Value: "2"}, {Value: "3"}}, group.Members)
+
+	require.NoError(t, Patch(group, []PatchOperation{
+		{Op: "remove", Path: `members[value eq "1"]`},
+		{Op: "Remove", Path: "members", Value: []any{map[string]any{"value": "3"}}},
+	}))
+	assert.Equal(t, []Member{{Value: "2"}}, group.Members)
+
+	require.NoError(t, Patch(group, []PatchOperation{
+		{Op: "replace", Path: "members", Value: []any{map[string]any{"value": "4"}}},
+		{Op: "replace", Path: "displayName", Value: "org/other"},
+	}))
+	assert.Equal(t, []Member{{Value: "4"}}, group.Members)
+	assert.Equal(t, "org/other", group.DisplayName)
+
+	require.NoError(t, Patch(group, []PatchOperation{{Op: "remove", Path: "members"}}))
+	assert.Empty(t, group.Members)
+}
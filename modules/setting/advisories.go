@@ -0,0 +1,22 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import "path/filepath"
+
+// Advisories settings of the vulnerability advisory database
+var Advisories = struct {
+	Enabled   bool   `ini:"ENABLED"`
+	Directory string `ini:"DIRECTORY"`
+}{
+	Enabled: false,
+}
+
+func loadAdvisoriesFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "advisories", &Advisories)
+
+	if Advisories.Directory != "" && !filepath.IsAbs(Advisories.Directory) {
+		Advisories.Directory = filepath.Join(AppDataPath, Advisories.Directory)
+	}
+}
@@ -159,12 +159,12 @@ func loadLogModeByName(rootCfg ConfigProvider, loggerName, modeName string) (wri
 	writerName = modeName
 	defaultFlags := "stdflags"
 	defaultFilaName := "gitea.log"
-	if loggerName == "access" {
-		// "access" logger is special, by default it doesn't have output flags, so it also needs a new writer name to avoid conflicting with other writers.
+	if loggerName == "access" || loggerName == "audit" {
+		// "access" and "audit" loggers are special, by default they don't have output flags, so they also need a new writer name to avoid conflicting with other writers.
 		// so "access" logger's writer name is usually "file.access" or "console.access"
-		writerName += ".access"
+		writerName += "." + loggerName
 		defaultFlags = "none"
-		defaultFilaName = "access.log"
+		defaultFilaName = loggerName + ".log"
 	}
 
 	writerMode.Level = log.LevelFromString(ConfigInheritedKeyString(sec, "LEVEL", Log.Level.String()))
@@ -252,6 +252,7 @@ func initManagedLoggers(manager *log.LoggerManager, cfg ConfigProvider) {
 	initLoggerByName(manager, cfg, "router")
 	initLoggerByName(manager, cfg, "xorm")
 	initLoggerByName(manager, cfg, "ssh")
+	initLoggerByName(manager, cfg, "audit")
 }
 
 func initLoggerByName(manager *log.LoggerManager, rootCfg ConfigProvider, loggerName string) {
@@ -305,6 +306,10 @@ func IsAccessLogEnabled() bool {
 	return log.IsLoggerEnabled("access")
 }
 
+func IsAuditLogEnabled() bool {
+	return log.IsLoggerEnabled("audit")
+}
+
 func IsRouteLogEnabled() bool {
 	return log.IsLoggerEnabled("router")
 }
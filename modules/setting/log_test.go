@@ -231,6 +231,52 @@ ACCESS = file
 	require.JSONEq(t, strings.ReplaceAll(writerDump, "$FILENAME", tempPath("gitea.log")), toJSON(dump))
 }
 
+func TestLogConfigAudit(t *testing.T) {
+	tempDir := t.TempDir()
+
+	manager, managerClose := initLoggersByConfig(t, `
+[log]
+ROOT_PATH = `+tempDir+`
+`)
+	dump := manager.GetLogger("audit").DumpWriters()
+	require.JSONEq(t, "{}", toJSON(dump))
+	managerClose()
+
+	manager, managerClose = initLoggersByConfig(t, `
+[log]
+ROOT_PATH = `+tempDir+`
+LOGGER_AUDIT_MODE = file
+`)
+	defer managerClose()
+
+	writerDumpAudit := `
+{
+	"file.audit": {
+		"BufferLen": 10000,
+		"Colorize": false,
+		"Expression": "",
+		"Exclusion": "",
+		"Flags": "none",
+		"Level": "info",
+		"Prefix": "",
+		"StacktraceLevel": "none",
+		"WriterOption": {
+			"Compress": true,
+			"CompressionLevel": -1,
+			"DailyRotate": true,
+			"FileName": "$FILENAME",
+			"LogRotate": true,
+			"MaxDays": 7,
+			"MaxSize": 268435456
+		},
+		"WriterType": "file"
+	}
+}
+`
+	dump = manager.GetLogger("audit").DumpWriters()
+	require.JSONEq(t, strings.ReplaceAll(writerDumpAudit, "$FILENAME", filepath.Join(tempDir, "audit.log")), toJSON(dump))
+}
+
 func TestLogConfigLegacyModeDisable(t *testing.T) {
 	manager, managerClose := initLoggersByConfig(t, `
 [log]
@@ -101,6 +101,8 @@ var OAuth2 = struct {
 	MaxTokenLength              int
 	DefaultApplications         []string
 	EnableAdditionalGrantScopes bool
+	DeviceCodeExpirationTime    int64
+	DeviceCodePollingInterval   int64
 }{
 	Enabled:                     true,
 	AccessTokenExpirationTime:   3600,
@@ -111,6 +113,8 @@ var OAuth2 = struct {
 	MaxTokenLength:              math.MaxInt16,
 	DefaultApplications:         []string{"git-credential-oauth", "git-credential-manager", "tea"},
 	EnableAdditionalGrantScopes: false,
+	DeviceCodeExpirationTime:    900,
+	DeviceCodePollingInterval:   5,
 }
 
 func loadOAuth2From(rootCfg ConfigProvider) {
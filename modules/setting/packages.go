@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Package registry settings
@@ -33,6 +34,7 @@ var (
 		LimitSizeGeneric      int64
 		LimitSizeGo           int64
 		LimitSizeHelm         int64
+		LimitSizeHex          int64
 		LimitSizeMaven        int64
 		LimitSizeNpm          int64
 		LimitSizeNuGet        int64
@@ -42,11 +44,21 @@ var (
 		LimitSizeAlt          int64
 		LimitSizeRubyGems     int64
 		LimitSizeSwift        int64
+		LimitSizeTerraform    int64
 		LimitSizeVagrant      int64
 		DefaultRPMSignEnabled bool
+
+		RemoteAllowedHostList string
+		RemoteTimeout         time.Duration
+
+		RequireSignature bool
+		TrustedKeysFile  string
+
+		ImmutableTypes []string
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
+		RemoteTimeout:        5 * time.Minute,
 	}
 )
 
@@ -94,6 +106,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
@@ -102,8 +115,20 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeRpm = mustBytes(sec, "LIMIT_SIZE_RPM")
 	Packages.LimitSizeRubyGems = mustBytes(sec, "LIMIT_SIZE_RUBYGEMS")
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
+	Packages.LimitSizeTerraform = mustBytes(sec, "LIMIT_SIZE_TERRAFORM")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
 	Packages.LimitSizeAlt = mustBytes(sec, "LIMIT_SIZE_ALT")
+
+	Packages.RemoteAllowedHostList = sec.Key("REMOTE_ALLOWED_HOST_LIST").MustString("")
+	Packages.RemoteTimeout = sec.Key("REMOTE_TIMEOUT").MustDuration(5 * time.Minute)
+
+	Packages.RequireSignature = sec.Key("REQUIRE_SIGNATURE").MustBool(false)
+	Packages.TrustedKeysFile = sec.Key("TRUSTED_KEYS_FILE").MustString("")
+	if Packages.TrustedKeysFile != "" && !filepath.IsAbs(Packages.TrustedKeysFile) {
+		Packages.TrustedKeysFile = filepath.Join(CustomPath, Packages.TrustedKeysFile)
+	}
+
+	Packages.ImmutableTypes = sec.Key("IMMUTABLE_TYPES").Strings(",")
 	return nil
 }
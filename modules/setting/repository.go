@@ -108,6 +108,7 @@ var (
 		Release struct {
 			AllowedTypes     string
 			DefaultPagingNum int
+			AttachSBOM       bool `ini:"ATTACH_SBOM"`
 		} `ini:"repository.release"`
 
 		Signing struct {
@@ -256,9 +257,11 @@ var (
 		Release: struct {
 			AllowedTypes     string
 			DefaultPagingNum int
+			AttachSBOM       bool `ini:"ATTACH_SBOM"`
 		}{
 			AllowedTypes:     "",
 			DefaultPagingNum: 10,
+			AttachSBOM:       true,
 		},
 
 		// Signing settings
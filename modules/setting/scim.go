@@ -0,0 +1,17 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+// SCIM settings
+var SCIM = struct {
+	Enabled bool `ini:"ENABLED"`
+	// AuthSource is the name of the authentication source provisioned users sign in with
+	AuthSource string `ini:"AUTH_SOURCE"`
+}{
+	Enabled: false,
+}
+
+func loadSCIMFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "scim", &SCIM)
+}
@@ -141,6 +141,9 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	if err := loadActionsFrom(cfg); err != nil {
 		return err
 	}
+	if err := loadTerraformFrom(cfg); err != nil {
+		return err
+	}
 	if err := loadModerationFrom(cfg); err != nil {
 		return err
 	}
@@ -148,7 +151,9 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadUIFrom(cfg)
 	loadAdminFrom(cfg)
 	loadAPIFrom(cfg)
+	loadSCIMFrom(cfg)
 	loadBadgesFrom(cfg)
+	loadAdvisoriesFrom(cfg)
 	loadMetricsFrom(cfg)
 	loadCamoFrom(cfg)
 	loadI18nFrom(cfg)
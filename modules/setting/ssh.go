@@ -49,6 +49,8 @@ var SSH = struct {
 	TrustedUserCAKeys                     []string           `ini:"SSH_TRUSTED_USER_CA_KEYS"`
 	TrustedUserCAKeysFile                 string             `ini:"SSH_TRUSTED_USER_CA_KEYS_FILENAME"`
 	TrustedUserCAKeysParsed               []gossh.PublicKey  `ini:"-"`
+	CertificateAuthorityEnabled           bool               `ini:"SSH_CERTIFICATE_AUTHORITY_ENABLED"`
+	CertificateValidity                   time.Duration      `ini:"SSH_CERTIFICATE_VALIDITY"`
 	PerWriteTimeout                       time.Duration      `ini:"SSH_PER_WRITE_TIMEOUT"`
 	PerWritePerKbTimeout                  time.Duration      `ini:"SSH_PER_WRITE_PER_KB_TIMEOUT"`
 }{
@@ -64,6 +66,7 @@ var SSH = struct {
 	MinimumKeySizes:               map[string]int{"ed25519": 256, "ed25519-sk": 256, "ecdsa": 256, "ecdsa-sk": 256, "rsa": 3071},
 	ServerHostKeys:                []string{"ssh/gitea.rsa", "ssh/gogs.rsa"},
 	AuthorizedKeysCommandTemplate: "{{.AppPath}} --config={{.CustomConf}} serv key-{{.Key.ID}}",
+	CertificateValidity:           16 * time.Hour,
 	PerWriteTimeout:               PerWriteTimeout,
 	PerWritePerKbTimeout:          PerWritePerKbTimeout,
 }
@@ -164,6 +167,12 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 
 	SSH.AuthorizedPrincipalsAllow, SSH.AuthorizedPrincipalsEnabled = parseAuthorizedPrincipalsAllow(sec.Key("SSH_AUTHORIZED_PRINCIPALS_ALLOW").Strings(","))
 
+	SSH.CertificateAuthorityEnabled = sec.Key("SSH_CERTIFICATE_AUTHORITY_ENABLED").MustBool(false) && !SSH.Disabled
+	SSH.CertificateValidity = sec.Key("SSH_CERTIFICATE_VALIDITY").MustDuration(16 * time.Hour)
+	if SSH.CertificateValidity <= 0 {
+		log.Fatal("SSH_CERTIFICATE_VALIDITY must be positive")
+	}
+
 	SSH.MinimumKeySizeCheck = sec.Key("MINIMUM_KEY_SIZE_CHECK").MustBool(SSH.MinimumKeySizeCheck)
 	minimumKeySizes := rootCfg.Section("ssh.minimum_key_sizes").Keys()
 	for _, key := range minimumKeySizes {
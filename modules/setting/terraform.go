@@ -0,0 +1,38 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import "fmt"
+
+// Terraform state backend settings
+var Terraform = struct {
+	Storage          *Storage
+	Enabled          bool
+	LimitStateSize   int64
+	MaxStateVersions int
+}{
+	Enabled:          true,
+	LimitStateSize:   -1,
+	MaxStateVersions: 100,
+}
+
+func loadTerraformFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("terraform")
+	if sec == nil {
+		Terraform.Storage, err = getStorage(rootCfg, "terraform", "", nil)
+		return err
+	}
+
+	if err := sec.MapTo(&Terraform); err != nil {
+		return fmt.Errorf("failed to map Terraform settings: %v", err)
+	}
+
+	Terraform.Storage, err = getStorage(rootCfg, "terraform", "", sec)
+	if err != nil {
+		return err
+	}
+
+	Terraform.LimitStateSize = mustBytes(sec, "LIMIT_STATE_SIZE")
+	return nil
+}
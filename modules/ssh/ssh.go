@@ -56,14 +56,25 @@ func getExitStatusFromError(err error) int {
 	return waitStatus.ExitStatus()
 }
 
+// sshConnection formats the addresses of a connection like the SSH_CONNECTION variable of OpenSSH:
+// client address, client port, server address and server port separated by spaces
+func sshConnection(remote, local net.Addr) string {
+	remoteHost, remotePort, _ := net.SplitHostPort(remote.String())
+	localHost, localPort, _ := net.SplitHostPort(local.String())
+	return strings.Join([]string{remoteHost, remotePort, localHost, localPort}, " ")
+}
+
 func sessionHandler(session ssh.Session) {
-	keyID := session.ConnPermissions().Extensions["forgejo-key-id"]
+	keyArg := "key-" + session.ConnPermissions().Extensions["forgejo-key-id"]
+	if certID, ok := session.ConnPermissions().Extensions["forgejo-cert-id"]; ok {
+		keyArg = "cert-" + certID
+	}
 
 	command := session.RawCommand()
 
 	logger.Trace("SSH: Payload: %v", command)
 
-	args := []string{"--config=" + setting.CustomConf, "serv", "key-" + keyID}
+	args := []string{"--config=" + setting.CustomConf, "serv", keyArg}
 	logger.Trace("SSH: Arguments: %v", args)
 
 	ctx, cancel := context.WithCancel(session.Context())
@@ -83,6 +94,8 @@ func sessionHandler(session ssh.Session) {
 		"SSH_ORIGINAL_COMMAND="+command,
 		"SKIP_MINWINSVC=1",
 		"GIT_PROTOCOL="+gitProtocol,
+		// like OpenSSH, so that the serv command can send the address of the client to the private API
+		"SSH_CONNECTION="+sshConnection(session.RemoteAddr(), session.LocalAddr()),
 	)
 
 	stdout, err := cmd.StdoutPipe()
@@ -175,6 +188,27 @@ func publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 			logger.Debug("Handle Certificate: %s Fingerprint: %s is a certificate", ctx.RemoteAddr(), gossh.FingerprintSHA256(key))
 		}
 
+		// certificates issued by this instance are checked against their records, which may have been revoked
+		if setting.SSH.CertificateAuthorityEnabled {
+			c, err := asymkey_model.VerifySSHCertificate(ctx, cert)
+			if err != nil {
+				logger.Warn("Certificate Rejected: %v", err)
+				logger.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
+				return false
+			}
+			if c != nil {
+				if logger.LevelEnabled(log.DEBUG) { // <- FingerprintSHA256 is kinda expensive so only calculate it if necessary
+					logger.Debug("Successfully authenticated: %s Certificate Fingerprint: %s KeyID: %s", ctx.RemoteAddr(), gossh.FingerprintSHA256(key), c.KeyID())
+				}
+				if ctx.Permissions().Extensions == nil {
+					ctx.Permissions().Extensions = map[string]string{}
+				}
+				ctx.Permissions().Extensions["forgejo-cert-id"] = strconv.FormatInt(c.ID, 10)
+
+				return true
+			}
+		}
+
 		if len(setting.SSH.TrustedUserCAKeys) == 0 {
 			logger.Warn("Certificate Rejected: No trusted certificate authorities for this server")
 			logger.Warn("Failed authentication attempt from %s", ctx.RemoteAddr())
@@ -106,6 +106,9 @@ var (
 	// Packages represents packages storage
 	Packages ObjectStorage = UninitializedStorage
 
+	// TerraformStates represents Terraform states storage
+	TerraformStates ObjectStorage = UninitializedStorage
+
 	// Actions represents actions storage
 	Actions ObjectStorage = UninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
@@ -121,6 +124,7 @@ func Init() error {
 		initLFS,
 		initRepoArchives,
 		initPackages,
+		initTerraformStates,
 		initActions,
 	} {
 		if err := f(); err != nil {
@@ -191,6 +195,16 @@ func initPackages() (err error) {
 	return err
 }
 
+func initTerraformStates() (err error) {
+	if !setting.Terraform.Enabled {
+		TerraformStates = DiscardStorage("Terraform isn't enabled")
+		return nil
+	}
+	log.Info("Initialising Terraform state storage with type: %s", setting.Terraform.Storage.Type)
+	TerraformStates, err = NewStorage(setting.Terraform.Storage.Type, setting.Terraform.Storage)
+	return err
+}
+
 func initActions() (err error) {
 	if !setting.Actions.Enabled {
 		Actions = DiscardStorage("Actions isn't enabled")
@@ -473,11 +473,15 @@ const (
 
 // PackagePayload represents a package payload
 type PackagePayload struct {
-	Action       HookPackageAction `json:"action"`
-	Repository   *Repository       `json:"repository"`
-	Package      *Package          `json:"package"`
-	Organization *User             `json:"organization"`
-	Sender       *User             `json:"sender"`
+	Action     HookPackageAction `json:"action"`
+	Repository *Repository       `json:"repository"`
+	Package    *Package          `json:"package"`
+	// Files are the files of the package version with their checksums
+	Files []*PackageFile `json:"files"`
+	// Uploader is the user who published the package version, the Actions user for versions published by a workflow
+	Uploader     *User `json:"uploader"`
+	Organization *User `json:"organization"`
+	Sender       *User `json:"sender"`
 }
 
 // JSONPayload implements Payload
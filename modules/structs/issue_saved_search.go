@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// SavedSearch is a named search of the issues or pull requests dashboard of a user or an organization
+type SavedSearch struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// the user owning the search, or the organization sharing it with its members
+	Owner *User `json:"owner"`
+	// the team of the organization the search is restricted to, zero for all its members
+	TeamID int64  `json:"team_id"`
+	IsPull bool   `json:"is_pull"`
+	Pinned bool   `json:"pinned"`
+	Query  string `json:"query"`
+	// enum: ["created_by", "your_repositories", "assigned", "review_requested", "reviewed_by", "mentioned"]
+	Type string `json:"type"`
+	// enum: ["open", "closed"]
+	State StateType `json:"state"`
+	// IDs of the labels the issues must have, negative IDs excluding a label
+	Labels []int64 `json:"labels"`
+	Sort   string  `json:"sort"`
+	// URL of the feed of the search, append .rss or .atom to choose its format
+	FeedURL string `json:"feed_url"`
+	HTMLURL string `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateSavedSearchOption options for saving a search of issues or pull requests
+type CreateSavedSearchOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// the team of the organization to share the search with, only for the searches of an organization
+	TeamID int64  `json:"team_id"`
+	IsPull bool   `json:"is_pull"`
+	Pinned bool   `json:"pinned"`
+	Query  string `json:"query"`
+	// enum: ["created_by", "your_repositories", "assigned", "review_requested", "reviewed_by", "mentioned"]
+	Type string `json:"type"`
+	// enum: ["open", "closed"]
+	State  string  `json:"state"`
+	Labels []int64 `json:"labels"`
+	// enum: ["recentupdate", "leastupdate", "latest", "oldest", "mostcomment", "leastcomment", "nearduedate", "farduedate"]
+	Sort string `json:"sort"`
+}
+
+// EditSavedSearchOption options for editing a saved search
+type EditSavedSearchOption struct {
+	Name   *string `json:"name" binding:"MaxSize(255)"`
+	Pinned *bool   `json:"pinned"`
+	Query  *string `json:"query"`
+	// enum: ["created_by", "your_repositories", "assigned", "review_requested", "reviewed_by", "mentioned"]
+	Type *string `json:"type"`
+	// enum: ["open", "closed"]
+	State  *string  `json:"state"`
+	Labels *[]int64 `json:"labels"`
+	// enum: ["recentupdate", "leastupdate", "latest", "oldest", "mostcomment", "leastcomment", "nearduedate", "farduedate"]
+	Sort *string `json:"sort"`
+}
@@ -31,3 +31,47 @@ type PackageFile struct {
 	HashSHA256 string `json:"sha256"`
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageAttestation represents a verified signature of a package file or a provenance statement of a package
+type PackageAttestation struct {
+	ID int64 `json:"id"`
+	// enum: gpg,ssh,sigstore,provenance
+	Type string `json:"type"`
+	// name of the signed file, or of the first file named by the provenance statement
+	File string `json:"file"`
+	// whether the signature was made by a known key or the provenance was generated from a verified build
+	Verified bool `json:"verified"`
+	// id or fingerprint of the signing key
+	KeyID string `json:"key_id,omitempty"`
+	// user the signing key belongs to
+	Signer *User `json:"signer,omitempty"`
+	// builder named by the provenance statement
+	BuilderID string `json:"builder_id,omitempty"`
+	// number of the Actions run which built the package
+	RunNumber int64 `json:"run_number,omitempty"`
+	// URL of the Actions run which built the package
+	RunURL  string `json:"run_url,omitempty"`
+	Content string `json:"content"`
+	Creator *User  `json:"creator"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreatePackageAttestationOption options for adding a signature or a provenance statement to a package
+type CreatePackageAttestationOption struct {
+	// enum: signature,provenance
+	// required: true
+	Type string `json:"type" binding:"Required;In(signature,provenance)"`
+	// name of the signed file, required for signatures
+	File string `json:"file"`
+	// armored GPG or SSH signature or sigstore bundle, or in-toto provenance statement
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
+
+// CreatePackageActionsProvenanceOption options for generating the provenance of a package built by an Actions run
+type CreatePackageActionsProvenanceOption struct {
+	// ID token of the run, issued for the default audience of the package owner
+	// required: true
+	IDToken string `json:"id_token" binding:"Required"`
+}
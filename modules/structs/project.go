@@ -0,0 +1,101 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// ProjectView is a saved table or roadmap view of the issues of a project
+type ProjectView struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Title     string `json:"title"`
+	// enum: ["table", "roadmap"]
+	Type string `json:"type"`
+	// issue search query selecting the issues shown by the view
+	Filter string `json:"filter"`
+	// enum: ["", "assignee", "label", "milestone", "column"]
+	GroupBy string `json:"group_by"`
+	// one of title, assignee, milestone, labels, created, updated, start_date, target_date,
+	// or field:{id} to sort by a custom field. Empty to keep the board order.
+	SortBy   string `json:"sort_by"`
+	SortDesc bool   `json:"sort_desc"`
+	// IDs of the custom fields shown as columns of a table view
+	Fields []int64 `json:"fields"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateProjectViewOption options for creating a project view
+type CreateProjectViewOption struct {
+	// required: true
+	Title string `json:"title" binding:"Required;MaxSize(100)"`
+	// enum: ["table", "roadmap"]
+	Type     string  `json:"type"`
+	Filter   string  `json:"filter"`
+	GroupBy  string  `json:"group_by"`
+	SortBy   string  `json:"sort_by"`
+	SortDesc bool    `json:"sort_desc"`
+	Fields   []int64 `json:"fields"`
+}
+
+// EditProjectViewOption options for editing a project view
+type EditProjectViewOption struct {
+	Title *string `json:"title" binding:"MaxSize(100)"`
+	// enum: ["table", "roadmap"]
+	Type     *string  `json:"type"`
+	Filter   *string  `json:"filter"`
+	GroupBy  *string  `json:"group_by"`
+	SortBy   *string  `json:"sort_by"`
+	SortDesc *bool    `json:"sort_desc"`
+	Fields   *[]int64 `json:"fields"`
+}
+
+// ProjectField is a custom attribute the issues of a project can be given
+type ProjectField struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Name      string `json:"name"`
+	// enum: ["text", "number", "date"]
+	Type string `json:"type"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateProjectFieldOption options for creating a project field
+type CreateProjectFieldOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(100)"`
+	// enum: ["text", "number", "date"]
+	Type string `json:"type"`
+}
+
+// ProjectFieldValue is the value of a custom field for an issue
+type ProjectFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Value   string `json:"value"`
+}
+
+// IssueProjectAttributes are the roadmap dates and custom field values of an issue in its project
+type IssueProjectAttributes struct {
+	ProjectID int64 `json:"project_id"`
+	// start date formatted as YYYY-MM-DD, empty if not set
+	StartDate string `json:"start_date"`
+	// target date formatted as YYYY-MM-DD, empty if not set
+	TargetDate string               `json:"target_date"`
+	Fields     []*ProjectFieldValue `json:"fields"`
+}
+
+// EditIssueProjectAttributesOption options for editing the roadmap dates and custom field values of an issue
+type EditIssueProjectAttributesOption struct {
+	// start date formatted as YYYY-MM-DD, empty to clear it
+	StartDate *string `json:"start_date"`
+	// target date formatted as YYYY-MM-DD, empty to clear it
+	TargetDate *string `json:"target_date"`
+	// values to set, an empty value clears the field
+	Fields []*ProjectFieldValue `json:"fields"`
+}
@@ -41,6 +41,8 @@ type QuotaUsedSizeAssets struct {
 	// Storage size used for the user's artifacts
 	Artifacts int64                       `json:"artifacts"`
 	Packages  QuotaUsedSizeAssetsPackages `json:"packages"`
+	// Storage size used for the Terraform states of the user's repositories
+	TerraformStates int64 `json:"terraform_states"`
 }
 
 // QuotaUsedSizeAssetsAttachments represents the size-based attachment quota usage of a user
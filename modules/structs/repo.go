@@ -24,6 +24,8 @@ type InternalTracker struct {
 	AllowOnlyContributorsToTrackTime bool `json:"allow_only_contributors_to_track_time"`
 	// Enable dependencies for issues and pull requests (Built-in issue tracker)
 	EnableIssueDependencies bool `json:"enable_issue_dependencies"`
+	// Close a parent issue when its last open sub-issue is closed (Built-in issue tracker)
+	CloseParentWithSubIssues bool `json:"close_parent_with_sub_issues"`
 }
 
 // ExternalTracker represents settings for external tracker
@@ -16,6 +16,28 @@ type AccessToken struct {
 	Token          string   `json:"sha1"`
 	TokenLastEight string   `json:"token_last_eight"`
 	Scopes         []string `json:"scopes"`
+	// swagger:strfmt date-time
+	Expires *time.Time `json:"expires_at,omitempty"`
+	// Permissions of a fine-grained token
+	Permissions *AccessTokenPermissions `json:"permissions,omitempty"`
+	// Organization a fine-grained token is restricted to
+	Organization string `json:"organization,omitempty"`
+	// Repositories a fine-grained token is restricted to
+	Repositories []string `json:"repositories,omitempty"`
+	// IP networks in CIDR notation the token is restricted to
+	AllowedIPs []string `json:"allowed_ips,omitempty"`
+}
+
+// AccessTokenPermissions are the permissions of a fine-grained access token
+type AccessTokenPermissions struct {
+	// enum: none,read,write
+	Contents string `json:"contents"`
+	// enum: none,read,write
+	Issues string `json:"issues"`
+	// enum: none,read,write
+	Packages string `json:"packages"`
+	// enum: none,read,write
+	Actions string `json:"actions"`
 }
 
 // AccessTokenList represents a list of API access token.
@@ -28,12 +50,24 @@ type CreateAccessTokenOption struct {
 	Name string `json:"name" binding:"Required"`
 	// example: ["all", "read:activitypub","read:issue", "write:misc", "read:notification", "read:organization", "read:package", "read:repository", "read:user"]
 	Scopes []string `json:"scopes"`
+	// Expiration date of the token, required for fine-grained tokens
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Permissions make the token fine-grained, the scopes are derived from them
+	Permissions *AccessTokenPermissions `json:"permissions"`
+	// Organization a fine-grained token is restricted to
+	Organization string `json:"organization"`
+	// Repositories a fine-grained token is restricted to, as owner/name
+	Repositories []string `json:"repositories"`
+	// IP addresses or networks in CIDR notation the token is restricted to, it can be used from everywhere if empty
+	AllowedIPs []string `json:"allowed_ips"`
 }
 
 // CreateOAuth2ApplicationOptions holds options to create an oauth2 application
 type CreateOAuth2ApplicationOptions struct {
 	Name               string   `json:"name" binding:"Required"`
 	ConfidentialClient bool     `json:"confidential_client"`
+	EnableDeviceFlow   bool     `json:"enable_device_flow"`
 	RedirectURIs       []string `json:"redirect_uris" binding:"Required"`
 }
 
@@ -45,6 +79,7 @@ type OAuth2Application struct {
 	ClientID           string    `json:"client_id"`
 	ClientSecret       string    `json:"client_secret"`
 	ConfidentialClient bool      `json:"confidential_client"`
+	EnableDeviceFlow   bool      `json:"enable_device_flow"`
 	RedirectURIs       []string  `json:"redirect_uris"`
 	Created            time.Time `json:"created"`
 }
@@ -20,3 +20,26 @@ type PublicKey struct {
 	ReadOnly bool      `json:"read_only,omitempty"`
 	KeyType  string    `json:"key_type,omitempty"`
 }
+
+// SSHCertificate is a short-lived SSH certificate issued to a user
+type SSHCertificate struct {
+	// The serial of the certificate
+	ID          int64  `json:"id"`
+	KeyID       string `json:"key_id"`
+	Principal   string `json:"principal"`
+	Fingerprint string `json:"fingerprint"`
+	// swagger:strfmt date-time
+	ValidAfter time.Time `json:"valid_after"`
+	// swagger:strfmt date-time
+	ValidBefore time.Time `json:"valid_before"`
+	// The certificate in the authorized keys format, it is only returned when it is issued
+	Certificate string `json:"certificate,omitempty"`
+}
+
+// CreateSSHCertificateOption options when requesting a SSH certificate
+type CreateSSHCertificateOption struct {
+	// The public key to certify in the authorized keys format
+	//
+	// required: true
+	Key string `json:"key" binding:"Required"`
+}
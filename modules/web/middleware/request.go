@@ -4,11 +4,28 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"strings"
 )
 
+type remoteAddrContextKeyType struct{}
+
+// RemoteAddrContextKey is the context key of the address of the client which sent the request
+var RemoteAddrContextKey remoteAddrContextKeyType
+
 // IsAPIPath returns true if the specified URL is an API path
 func IsAPIPath(req *http.Request) bool {
 	return strings.HasPrefix(req.URL.Path, "/api/")
 }
+
+// GetRemoteHost returns the host of the client which sent the request the context belongs to, if any
+func GetRemoteHost(ctx context.Context) string {
+	addr, _ := ctx.Value(RemoteAddrContextKey).(string)
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
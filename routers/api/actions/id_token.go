@@ -4,6 +4,7 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -129,7 +130,7 @@ func generateIDToken(ctx *IDTokenContext) {
 	claims["exp"] = jwt.NewNumericDate(expirationDate.AsTime())
 	claims["iat"] = jwt.NewNumericDate(now)
 	claims["nbf"] = jwt.NewNumericDate(now)
-	claims["iss"] = strings.TrimSuffix(setting.AppURL, "/") + "/api/actions"
+	claims["iss"] = idTokenIssuer()
 
 	signedToken, err := jwtSigningKey.JWT(claims)
 	if err != nil {
@@ -146,3 +147,33 @@ func generateIDToken(ctx *IDTokenContext) {
 type IDTokenResponse struct {
 	Value string `json:"value"`
 }
+
+func idTokenIssuer() string {
+	return strings.TrimSuffix(setting.AppURL, "/") + "/api/actions"
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	actions_service.IDTokenCustomClaims
+}
+
+// VerifyIDToken verifies an ID token issued to an Actions run for the audience and returns its custom claims
+func VerifyIDToken(token, audience string) (*actions_service.IDTokenCustomClaims, error) {
+	if jwtSigningKey == nil {
+		return nil, errors.New("ID tokens are not enabled")
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return jwtSigningKey.VerifyKey(), nil
+	},
+		jwt.WithValidMethods([]string{jwtSigningKey.SigningMethod().Alg()}),
+		jwt.WithIssuer(idTokenIssuer()),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &claims.IDTokenCustomClaims, nil
+}
@@ -147,7 +147,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion, packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -210,6 +210,9 @@ func DeletePackageFile(webctx *context.Context) {
 		if err != nil {
 			return err
 		}
+		if err := packages_service.CheckVersionMutable(ctx, pv); err != nil {
+			return err
+		}
 
 		pf, err := packages_model.GetFileForVersionByName(
 			ctx,
@@ -245,6 +248,8 @@ func DeletePackageFile(webctx *context.Context) {
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(webctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(webctx, http.StatusForbidden, err)
 		} else {
 			apiError(webctx, http.StatusInternalServerError, err)
 		}
@@ -4,6 +4,7 @@
 package packages
 
 import (
+	"encoding/base64"
 	"net/http"
 	"regexp"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	"forgejo.org/routers/api/packages/generic"
 	"forgejo.org/routers/api/packages/goproxy"
 	"forgejo.org/routers/api/packages/helm"
+	"forgejo.org/routers/api/packages/hex"
 	"forgejo.org/routers/api/packages/maven"
 	"forgejo.org/routers/api/packages/npm"
 	"forgejo.org/routers/api/packages/nuget"
@@ -36,9 +38,11 @@ import (
 	"forgejo.org/routers/api/packages/rpm"
 	"forgejo.org/routers/api/packages/rubygems"
 	"forgejo.org/routers/api/packages/swift"
+	"forgejo.org/routers/api/packages/terraform"
 	"forgejo.org/routers/api/packages/vagrant"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
 )
 
 func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
@@ -84,6 +88,21 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 			}
 		}
 
+		if ctx.Package.AccessMode < accessMode && ctx.Package.AccessMode >= perm.AccessModeRead && ctx.Doer != nil && !ctx.IsUserSiteAdmin() {
+			// The package is not known yet, so the request continues if any package is granted.
+			// The package services deny writes to the packages which are not granted.
+			taskID, _ := ctx.Data["ActionsTaskID"].(int64)
+			grants, err := packages_service.GetGrants(ctx, ctx.Package.Owner, ctx.Doer, taskID)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetGrants", err.Error())
+				return
+			}
+			if t := ctx.AccessToken(); grants.MaxAccessMode() >= accessMode && (t == nil || t.PackageAccessMode(ctx.Package.Owner.ID) >= accessMode) {
+				ctx.AppendContextValue(packages_service.GrantsContextKey, grants)
+				return
+			}
+		}
+
 		if ctx.Package.AccessMode < accessMode && !ctx.IsUserSiteAdmin() {
 			ctx.Resp.Header().Set("WWW-Authenticate", `Basic realm="Gitea Package API"`)
 			ctx.Error(http.StatusUnauthorized, "reqPackageAccess", "user should have specific permission or be a site admin")
@@ -125,6 +144,23 @@ func verifyAuth(r *web.Route, authMethods []auth.Method) {
 	})
 }
 
+// uploadSignature passes the detached signature sent with an upload to the package service,
+// which verifies it against the main file of the package version.
+func uploadSignature() func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		header := ctx.Req.Header.Get("X-Package-Signature")
+		if header == "" {
+			return
+		}
+		signature, err := base64.StdEncoding.DecodeString(header)
+		if err != nil {
+			ctx.Error(http.StatusBadRequest, "uploadSignature", "the X-Package-Signature header must be base64 encoded")
+			return
+		}
+		ctx.AppendContextValue(packages_service.UploadSignatureContextKey, signature)
+	}
+}
+
 // CommonRoutes provide endpoints for most package managers (except containers - see below)
 // These are mounted on `/api/packages` (not `/api/v1/packages`)
 func CommonRoutes() *web.Route {
@@ -138,8 +174,33 @@ func CommonRoutes() *web.Route {
 		&nuget.Auth{},
 		&conan.Auth{},
 		&chef.Auth{},
+		&hex.Auth{},
 	})
 
+	r.Use(uploadSignature())
+
+	// The Terraform registry protocols address packages by {namespace}/..., the namespace is the owner.
+	// The paths are announced by the service discovery at /.well-known/terraform.json.
+	r.Group("/terraform/v1", func() {
+		r.Group("/modules/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Group("/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadModule)
+				r.Get("/download", terraform.DownloadModule)
+				r.Get("/archive.tar.gz", terraform.DownloadModuleArchive)
+			})
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+		r.Group("/providers/{username}/{provider}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Group("/{version}", func() {
+				r.Get("/download/{os}/{arch}", terraform.DownloadProvider)
+				r.Group("/{filename}", func() {
+					r.Get("", terraform.DownloadProviderFile)
+					r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadProviderFile)
+				})
+			})
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+	})
 	r.Group("/{username}", func() {
 		r.Group("/alpine", func() {
 			r.Get("/key", alpine.GetRepositoryKey)
@@ -445,6 +506,14 @@ func CommonRoutes() *web.Route {
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", hex.PackageReleases)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Post("/api/publish", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), hex.UploadPackage)
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
@@ -778,6 +847,8 @@ func ContainerRoutes() *web.Route {
 		&container.Auth{},
 	})
 
+	r.Use(uploadSignature())
+
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Group("/token", func() {
 		r.Get("", container.Authenticate)
@@ -794,25 +865,27 @@ func ContainerRoutes() *web.Route {
 					r.Put("", container.EndUploadBlob)
 					r.Delete("", container.CancelUploadBlob)
 				})
-			}, reqPackageAccess(perm.AccessModeWrite))
+			}, reqPackageAccess(perm.AccessModeWrite), container.ReqImageWriteAccess)
 			r.Group("/blobs/{digest}", func() {
 				r.Head("", container.HeadBlob)
 				r.Get("", container.GetBlob)
-				r.Delete("", reqPackageAccess(perm.AccessModeWrite), container.DeleteBlob)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), container.ReqImageWriteAccess, container.DeleteBlob)
 			})
 			r.Group("/manifests/{reference}", func() {
-				r.Put("", reqPackageAccess(perm.AccessModeWrite), container.UploadManifest)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), container.ReqImageWriteAccess, container.UploadManifest)
 				r.Head("", container.HeadManifest)
 				r.Get("", container.GetManifest)
-				r.Delete("", reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), container.ReqImageWriteAccess, container.DeleteManifest)
 			})
 			r.Get("/tags/list", container.GetTagList)
+			r.Get("/referrers/{digest}", container.GetReferrers)
 		}, container.VerifyImageName)
 
 		var (
 			blobsUploadsPattern = regexp.MustCompile(`\A(.+)/blobs/uploads/([a-zA-Z0-9-_.=]+)\z`)
 			blobsPattern        = regexp.MustCompile(`\A(.+)/blobs/([^/]+)\z`)
 			manifestsPattern    = regexp.MustCompile(`\A(.+)/manifests/([^/]+)\z`)
+			referrersPattern    = regexp.MustCompile(`\A(.+)/referrers/([^/]+)\z`)
 		)
 
 		// Manual mapping of routes because {image} can contain slashes which chi does not support
@@ -836,6 +909,10 @@ func ContainerRoutes() *web.Route {
 				if ctx.Written() {
 					return
 				}
+				container.ReqImageWriteAccess(ctx)
+				if ctx.Written() {
+					return
+				}
 
 				container.InitiateUploadBlob(ctx)
 				return
@@ -850,6 +927,18 @@ func ContainerRoutes() *web.Route {
 				container.GetTagList(ctx)
 				return
 			}
+			if m := referrersPattern.FindStringSubmatch(path); len(m) == 3 && isGet {
+				ctx.SetParams("image", m[1])
+				container.VerifyImageName(ctx)
+				if ctx.Written() {
+					return
+				}
+
+				ctx.SetParams("digest", m[2])
+
+				container.GetReferrers(ctx)
+				return
+			}
 
 			m := blobsUploadsPattern.FindStringSubmatch(path)
 			if len(m) == 3 && (isGet || isPut || isPatch || isDelete) {
@@ -863,6 +952,10 @@ func ContainerRoutes() *web.Route {
 				if ctx.Written() {
 					return
 				}
+				container.ReqImageWriteAccess(ctx)
+				if ctx.Written() {
+					return
+				}
 
 				ctx.SetParams("uuid", m[2])
 
@@ -896,6 +989,10 @@ func ContainerRoutes() *web.Route {
 					if ctx.Written() {
 						return
 					}
+					container.ReqImageWriteAccess(ctx)
+					if ctx.Written() {
+						return
+					}
 					container.DeleteBlob(ctx)
 				}
 				return
@@ -919,6 +1016,10 @@ func ContainerRoutes() *web.Route {
 					if ctx.Written() {
 						return
 					}
+					container.ReqImageWriteAccess(ctx)
+					if ctx.Written() {
+						return
+					}
 					if isPut {
 						container.UploadManifest(ctx)
 					} else {
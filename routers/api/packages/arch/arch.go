@@ -152,7 +152,7 @@ func PushPackage(ctx *context.Context) {
 		switch {
 		case errors.Is(err, packages_model.ErrDuplicatePackageVersion), errors.Is(err, packages_model.ErrDuplicatePackageFile):
 			apiError(ctx, http.StatusConflict, err)
-		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize), errors.Is(err, packages_service.ErrSignatureRequired), errors.Is(err, packages_service.ErrInvalidSignature), errors.Is(err, packages_service.ErrImmutableVersion), errors.Is(err, packages_service.ErrPackageAccessDenied):
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -282,6 +282,10 @@ func RemovePackage(ctx *context.Context) {
 			deleted = true
 			err := packages_service.RemovePackageFileAndVersionIfUnreferenced(ctx, ctx.ContextUser, file)
 			if err != nil {
+				if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+					apiError(ctx, http.StatusForbidden, err)
+					return
+				}
 				apiError(ctx, http.StatusInternalServerError, err)
 				return
 			}
@@ -22,25 +22,41 @@ func (a *Auth) Name() string {
 
 // Verify extracts the user from the Bearer token
 func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
-	uid, scope, err := packages.ParseAuthorizationToken(req)
+	claims, err := packages.ParseAuthorizationToken(req)
 	if err != nil {
 		log.Trace("ParseAuthorizationToken: %v", err)
 		return nil, err
 	}
 
-	if uid == 0 {
+	if claims == nil || claims.UserID == 0 {
 		return nil, nil
 	}
 
 	// Propagate scope of the authorization token.
-	if scope != "" {
+	if claims.Scope != "" {
 		store.GetData()["IsApiToken"] = true
-		store.GetData()["ApiTokenScope"] = scope
+		store.GetData()["ApiTokenScope"] = claims.Scope
 	}
 
-	u, err := user_model.GetUserByID(req.Context(), uid)
+	// Propagate the Actions task the token was created for.
+	if claims.ActionsTaskID != 0 {
+		store.GetData()["IsActionsToken"] = true
+		store.GetData()["ActionsTaskID"] = claims.ActionsTaskID
+	}
+
+	// Propagate the restrictions of the access token the token was created with.
+	if claims.AccessTokenID != 0 {
+		t, err := auth.GetAccessTokenByID(req.Context(), claims.AccessTokenID)
+		if err != nil {
+			log.Trace("GetAccessTokenByID: %v", err)
+			return nil, err
+		}
+		store.GetData()["ApiToken"] = t
+	}
+
+	u, err := user_model.GetPossibleUserByID(req.Context(), claims.UserID)
 	if err != nil {
-		log.Error("GetUserByID:  %v", err)
+		log.Error("GetPossibleUserByID:  %v", err)
 		return nil, err
 	}
 
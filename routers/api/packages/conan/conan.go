@@ -5,6 +5,7 @@ package conan
 
 import (
 	std_ctx "context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -30,6 +31,7 @@ import (
 const (
 	conanfileFile = "conanfile.py"
 	conaninfoFile = "conaninfo.txt"
+	packageFile   = "conan_package.tgz"
 
 	recipeReferenceKey  = "RecipeReference"
 	packageReferenceKey = "PackageReference"
@@ -121,7 +123,14 @@ func Authenticate(ctx *context.Context) {
 	// If there's an API scope, ensure it propagates.
 	scope, _ := ctx.Data.GetData()["ApiTokenScope"].(auth_model.AccessTokenScope)
 
-	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope)
+	taskID, _ := ctx.Data.GetData()["ActionsTaskID"].(int64)
+
+	var accessTokenID int64
+	if t := ctx.AccessToken(); t != nil {
+		accessTokenID = t.ID
+	}
+
+	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope, taskID, accessTokenID)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -354,6 +363,8 @@ func uploadFile(ctx *context.Context, fileFilter container.Set[string], fileKey
 		Creator: ctx.Doer,
 		Data:    buf,
 		IsLead:  isConanfileFile,
+		// the binaries are built from the recipe but uploaded separately, they must be signed as well
+		RequireSignature: filename == packageFile,
 		Properties: map[string]string{
 			conan_module.PropertyRecipeUser:     rref.User,
 			conan_module.PropertyRecipeChannel:  rref.Channel,
@@ -424,7 +435,7 @@ func uploadFile(ctx *context.Context, fileFilter container.Set[string], fileKey
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature, packages_service.ErrImmutableVersion, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -490,6 +501,8 @@ func DeleteRecipeV1(ctx *context.Context) {
 	if err := deleteRecipeOrPackage(ctx, rref, true, nil, false); err != nil {
 		if err == packages_model.ErrPackageNotExist || err == conan_model.ErrPackageReferenceNotExist {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -505,6 +518,8 @@ func DeleteRecipeV2(ctx *context.Context) {
 	if err := deleteRecipeOrPackage(ctx, rref, rref.Revision == "", nil, false); err != nil {
 		if err == packages_model.ErrPackageNotExist || err == conan_model.ErrPackageReferenceNotExist {
 			apiError(ctx, http.StatusNotFound, err)
+		} else if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -571,6 +586,8 @@ func DeletePackageV2(ctx *context.Context) {
 		if err := deleteRecipeOrPackage(ctx, rref, false, pref, pref.Revision == ""); err != nil {
 			if err == packages_model.ErrPackageNotExist || err == conan_model.ErrPackageReferenceNotExist {
 				apiError(ctx, http.StatusNotFound, err)
+			} else if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
 			} else {
 				apiError(ctx, http.StatusInternalServerError, err)
 			}
@@ -596,6 +613,8 @@ func DeletePackageV2(ctx *context.Context) {
 		if err := deleteRecipeOrPackage(ctx, rref, false, pref, true); err != nil {
 			if err == packages_model.ErrPackageNotExist || err == conan_model.ErrPackageReferenceNotExist {
 				apiError(ctx, http.StatusNotFound, err)
+			} else if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
 			} else {
 				apiError(ctx, http.StatusInternalServerError, err)
 			}
@@ -615,6 +634,9 @@ func deleteRecipeOrPackage(apictx *context.Context, rref *conan_module.RecipeRef
 		if err != nil {
 			return err
 		}
+		if err := packages_service.CheckVersionMutable(ctx, pv); err != nil {
+			return err
+		}
 
 		pd, err = packages_model.GetPackageDescriptor(ctx, pv)
 		if err != nil {
@@ -19,6 +19,7 @@ import (
 	packages_model "forgejo.org/models/packages"
 	container_model "forgejo.org/models/packages/container"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/container"
 	"forgejo.org/modules/json"
 	"forgejo.org/modules/log"
 	packages_module "forgejo.org/modules/packages"
@@ -31,6 +32,8 @@ import (
 	container_service "forgejo.org/services/packages/container"
 
 	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -50,6 +53,8 @@ type containerHeaders struct {
 	Location      string
 	ContentType   string
 	ContentLength int64
+	Subject       string
+	Filters       string
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#legacy-docker-support-http-headers
@@ -70,6 +75,12 @@ func setResponseHeaders(resp http.ResponseWriter, h *containerHeaders) {
 		resp.Header().Set("Docker-Content-Digest", h.ContentDigest)
 		resp.Header().Set("ETag", fmt.Sprintf(`"%s"`, h.ContentDigest))
 	}
+	if h.Subject != "" {
+		resp.Header().Set("OCI-Subject", h.Subject)
+	}
+	if h.Filters != "" {
+		resp.Header().Set("OCI-Filters-Applied", h.Filters)
+	}
 	if h.ContentLength >= 0 {
 		resp.Header().Set("Content-Length", strconv.FormatInt(h.ContentLength, 10))
 	}
@@ -144,6 +155,14 @@ func VerifyImageName(ctx *context.Context) {
 	}
 }
 
+// ReqImageWriteAccess is a middleware which checks if the doer may write to the image if its
+// package writes are restricted to granted packages
+func ReqImageWriteAccess(ctx *context.Context) {
+	if err := packages_service.CheckWriteGranted(ctx, ctx.Package.Owner.ID, packages_model.TypeContainer, ctx.Params("image")); err != nil {
+		apiErrorDefined(ctx, errDenied.WithMessage(err.Error()))
+	}
+}
+
 // DetermineSupport is used to test if the registry supports OCI
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#determining-support
 func DetermineSupport(ctx *context.Context) {
@@ -168,7 +187,14 @@ func Authenticate(ctx *context.Context) {
 	// If there's an API scope, ensure it propagates.
 	scope, _ := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
 
-	token, err := packages_service.CreateAuthorizationToken(u, scope)
+	taskID, _ := ctx.Data["ActionsTaskID"].(int64)
+
+	var accessTokenID int64
+	if t := ctx.AccessToken(); t != nil {
+		accessTokenID = t.ID
+	}
+
+	token, err := packages_service.CreateAuthorizationToken(u, scope, taskID, accessTokenID)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -232,30 +258,23 @@ func InitiateUploadBlob(ctx *context.Context) {
 	mount := ctx.FormTrim("mount")
 	from := ctx.FormTrim("from")
 	if mount != "" {
-		blob, _ := workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
-			Repository: from,
-			Digest:     mount,
-		})
+		blob, err := getMountableBlob(ctx, mount, from)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
 		if blob != nil {
-			accessible, err := packages_model.IsBlobAccessibleForUser(ctx, blob.Blob.ID, ctx.Doer)
-			if err != nil {
+			if err := mountBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: image}, blob.Blob); err != nil {
 				apiError(ctx, http.StatusInternalServerError, err)
 				return
 			}
 
-			if accessible {
-				if err := mountBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: image}, blob.Blob); err != nil {
-					apiError(ctx, http.StatusInternalServerError, err)
-					return
-				}
-
-				setResponseHeaders(ctx.Resp, &containerHeaders{
-					Location:      fmt.Sprintf("/v2/%s/%s/blobs/%s", ctx.Package.Owner.LowerName, image, mount),
-					ContentDigest: mount,
-					Status:        http.StatusCreated,
-				})
-				return
-			}
+			setResponseHeaders(ctx.Resp, &containerHeaders{
+				Location:      fmt.Sprintf("/v2/%s/%s/blobs/%s", ctx.Package.Owner.LowerName, image, mount),
+				ContentDigest: mount,
+				Status:        http.StatusCreated,
+			})
+			return
 		}
 	}
 
@@ -577,6 +596,8 @@ func UploadManifest(ctx *context.Context) {
 			switch err {
 			case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
 				apiError(ctx, http.StatusForbidden, err)
+			case packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature:
+				apiErrorDefined(ctx, errDenied.WithMessage(err.Error()))
 			default:
 				apiError(ctx, http.StatusInternalServerError, err)
 			}
@@ -587,6 +608,7 @@ func UploadManifest(ctx *context.Context) {
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
+		Subject:       mci.Subject,
 		Status:        http.StatusCreated,
 	})
 }
@@ -677,6 +699,10 @@ func DeleteManifest(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+			if errors.Is(err, packages_service.ErrImmutableVersion) {
+				apiErrorDefined(ctx, errDenied.WithMessage(err.Error()))
+				return
+			}
 			apiError(ctx, http.StatusInternalServerError, err)
 			return
 		}
@@ -762,8 +788,124 @@ func GetTagList(ctx *context.Context) {
 	})
 }
 
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	subject := ctx.Params("digest")
+	if digest.Digest(subject).Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Image:      ctx.Params("image"),
+		Subject:    subject,
+		IsManifest: true,
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	index := oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: make([]oci.Descriptor, 0, len(pfds)),
+	}
+	seen := make(container.Set[string], len(pfds))
+	for _, pfd := range pfds {
+		manifestDigest := pfd.Properties.GetByName(container_module.PropertyDigest)
+		if !seen.Add(manifestDigest) {
+			continue
+		}
+
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		var metadata container_module.Metadata
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), &metadata); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if artifactType != "" && metadata.ArtifactType != artifactType {
+			continue
+		}
+
+		index.Manifests = append(index.Manifests, oci.Descriptor{
+			MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+			Digest:       digest.Digest(manifestDigest),
+			Size:         pfd.Blob.Size,
+			ArtifactType: metadata.ArtifactType,
+			Annotations:  metadata.Annotations,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(index); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	h := &containerHeaders{
+		Status:        http.StatusOK,
+		ContentType:   oci.MediaTypeImageIndex,
+		ContentLength: int64(buf.Len()),
+	}
+	if artifactType != "" {
+		h.Filters = "artifactType"
+	}
+	setResponseHeaders(ctx.Resp, h)
+
+	if _, err := buf.WriteTo(ctx.Resp); err != nil {
+		log.Error("JSON write: %v", err)
+	}
+}
+
 // FIXME: Workaround to be removed in v1.20
 // https://github.com/go-gitea/gitea/issues/19586
+// getMountableBlob searches the blob to mount into the current image.
+// Blobs of other images of the same owner are preferred because the doer can write to them anyway.
+// Blobs of other owners are only mounted if the doer can access them.
+// If no blob is found the client falls back to a regular upload.
+func getMountableBlob(ctx *context.Context, mount, from string) (*packages_model.PackageFileDescriptor, error) {
+	if from == "" || strings.HasPrefix(strings.ToLower(from), ctx.Package.Owner.LowerName+"/") {
+		blob, err := workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    ctx.Package.Owner.ID,
+			Repository: strings.ToLower(from),
+			Digest:     mount,
+		})
+		if err == nil {
+			return blob, nil
+		}
+		if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return nil, err
+		}
+	}
+
+	blob, err := workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		Repository: strings.ToLower(from),
+		Digest:     mount,
+	})
+	if err != nil {
+		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	accessible, err := packages_model.IsBlobAccessibleForUser(ctx, blob.Blob.ID, ctx.Doer)
+	if err != nil || !accessible {
+		return nil, err
+	}
+	return blob, nil
+}
+
 func workaroundGetContainerBlob(ctx *context.Context, opts *container_model.BlobSearchOptions) (*packages_model.PackageFileDescriptor, error) {
 	blob, err := container_model.GetContainerBlob(ctx, opts)
 	if err != nil {
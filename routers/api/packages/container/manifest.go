@@ -51,6 +51,8 @@ type manifestCreationInfo struct {
 	Reference  string
 	IsTagged   bool
 	Properties map[string]string
+	// Subject is the digest of the manifest this manifest refers to, like a signature or SBOM of an image
+	Subject string
 }
 
 func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
@@ -121,6 +123,13 @@ func processImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *p
 			return err
 		}
 		metadata.Annotations = manifest.Annotations
+		metadata.ArtifactType = manifest.ArtifactType
+		if metadata.ArtifactType == "" {
+			metadata.ArtifactType = manifest.Config.MediaType
+		}
+		if manifest.Subject != nil {
+			mci.Subject = string(manifest.Subject.Digest)
+		}
 
 		blobReferences := make([]*blobReference, 0, 1+len(manifest.Layers))
 
@@ -220,8 +229,13 @@ func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, b
 		defer committer.Close()
 
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Annotations:  index.Annotations,
+			ArtifactType: index.ArtifactType,
+		}
+		if index.Subject != nil {
+			mci.Subject = string(index.Subject.Digest)
 		}
 
 		for _, manifest := range index.Manifests {
@@ -381,6 +395,16 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 	var pv *packages_model.PackageVersion
 	if pv, err = packages_model.GetOrInsertVersion(ctx, _pv); err != nil {
 		if err == packages_model.ErrDuplicatePackageVersion {
+			// pushing the same manifest again is allowed for immutable tags
+			if pv.MetadataJSON != _pv.MetadataJSON {
+				if err := packages_service.CheckVersionMutable(ctx, pv); err != nil {
+					if errors.Is(err, packages_service.ErrImmutableVersion) {
+						return nil, errDenied.WithMessage(err.Error())
+					}
+					return nil, err
+				}
+			}
+
 			if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
 				return nil, err
 			}
@@ -414,6 +438,12 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 			return nil, err
 		}
 	}
+	if mci.Subject != "" {
+		if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, mci.Subject); err != nil {
+			log.Error("Error setting package version property: %v", err)
+			return nil, err
+		}
+	}
 
 	return pv, nil
 }
@@ -506,6 +536,16 @@ func createManifestBlob(ctx context.Context, mci *manifestCreationInfo, pv *pack
 		ExpectedSize: pb.Size,
 		IsLead:       true,
 	})
+	if err != nil {
+		return pb, !exists, manifestDigest, err
+	}
+
+	// the manifest is the main file of an image and is subject to the signature policy of the owner
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, container_model.ManifestFilename, packages_model.EmptyFileKey)
+	if err != nil {
+		return pb, !exists, manifestDigest, err
+	}
+	err = packages_service.VerifyUploadSignature(ctx, mci.Owner, mci.Creator, pv, pf, pb)
 
 	return pb, !exists, manifestDigest, err
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
 	packages_module "forgejo.org/modules/packages"
 	goproxy_module "forgejo.org/modules/packages/goproxy"
@@ -19,6 +20,7 @@ import (
 	"forgejo.org/routers/api/packages/helper"
 	"forgejo.org/services/context"
 	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
 )
 
 func apiError(ctx *context.Context, status int, obj any) {
@@ -28,7 +30,19 @@ func apiError(ctx *context.Context, status int, obj any) {
 }
 
 func EnumeratePackageVersions(ctx *context.Context) {
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeGo, ctx.Params("name"))
+	name := packageNameFromParams(ctx)
+
+	list, err := getRemoteVersionList(ctx, name)
+	if err != nil {
+		// serve the versions cached before
+		log.Warn("Unable to get the versions of the Go module %s from the upstream remote: %v", name, err)
+	} else if list != nil {
+		ctx.Resp.Header().Set("Content-Type", "text/plain;charset=utf-8")
+		_, _ = ctx.Resp.Write(list)
+		return
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeGo, name)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -50,12 +64,12 @@ func EnumeratePackageVersions(ctx *context.Context) {
 }
 
 func PackageVersionMetadata(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.Params("name"), ctx.Params("version"))
+	pv, err := resolveOrCachePackage(ctx, packageNameFromParams(ctx), ctx.Params("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
+			apiError(ctx, remote_service.ErrorStatus(err, http.StatusInternalServerError), err)
 		}
 		return
 	}
@@ -70,12 +84,12 @@ func PackageVersionMetadata(ctx *context.Context) {
 }
 
 func PackageVersionGoModContent(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.Params("name"), ctx.Params("version"))
+	pv, err := resolveOrCachePackage(ctx, packageNameFromParams(ctx), ctx.Params("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
+			apiError(ctx, remote_service.ErrorStatus(err, http.StatusInternalServerError), err)
 		}
 		return
 	}
@@ -90,12 +104,12 @@ func PackageVersionGoModContent(ctx *context.Context) {
 }
 
 func DownloadPackageFile(ctx *context.Context) {
-	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.Params("name"), ctx.Params("version"))
+	pv, err := resolveOrCachePackage(ctx, packageNameFromParams(ctx), ctx.Params("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
+			apiError(ctx, remote_service.ErrorStatus(err, http.StatusInternalServerError), err)
 		}
 		return
 	}
@@ -119,6 +133,31 @@ func DownloadPackageFile(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pfs[0])
 }
 
+// resolveOrCachePackage resolves a version, fetching it from the upstream remote if it is missing
+func resolveOrCachePackage(ctx *context.Context, name, version string) (*packages_model.PackageVersion, error) {
+	if version == "latest" {
+		latest, err := getRemoteLatestVersion(ctx, name)
+		if err != nil {
+			// resolve the latest version cached before
+			log.Warn("Unable to get the latest version of the Go module %s from the upstream remote: %v", name, err)
+		} else if latest != "" {
+			version = latest
+		}
+	}
+
+	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+	if errors.Is(err, util.ErrNotExist) && version != "latest" {
+		cached, cacheErr := cacheRemotePackage(ctx, name, version)
+		if cacheErr != nil {
+			return nil, cacheErr
+		}
+		if cached {
+			return resolvePackage(ctx, ctx.Package.Owner.ID, name, version)
+		}
+	}
+	return pv, err
+}
+
 func resolvePackage(ctx *context.Context, ownerID int64, name, version string) (*packages_model.PackageVersion, error) {
 	var pv *packages_model.PackageVersion
 
@@ -216,7 +255,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
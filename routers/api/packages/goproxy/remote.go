@@ -0,0 +1,182 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package goproxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/json"
+	goproxy_module "forgejo.org/modules/packages/goproxy"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
+)
+
+// maxGoModSize is the maximum size of a go.mod fetched from the upstream remote
+const maxGoModSize = 16 * 1024 * 1024
+
+// escapePath escapes a module path or version for the proxy protocol, upper-case letters are
+// replaced by an exclamation mark followed by the lower-case letter
+// https://go.dev/ref/mod#goproxy-protocol
+func escapePath(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			sb.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// unescapePath reverses escapePath, the input is returned unchanged if it is not escaped properly
+func unescapePath(s string) string {
+	var sb strings.Builder
+	bang := false
+	for _, r := range s {
+		switch {
+		case bang:
+			if r < 'a' || r > 'z' {
+				return s
+			}
+			sb.WriteRune(r - ('a' - 'A'))
+			bang = false
+		case r == '!':
+			bang = true
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if bang {
+		return s
+	}
+	return sb.String()
+}
+
+// packageNameFromParams gets the module path from the escaped path of the request
+func packageNameFromParams(ctx *context.Context) string {
+	return unescapePath(ctx.Params("name"))
+}
+
+func getRemote(ctx *context.Context, name string) (*packages_model.PackageRemote, error) {
+	return remote_service.GetRemoteForPackage(ctx, ctx.Package.Owner, packages_model.TypeGo, name)
+}
+
+// getRemoteVersionList gets the list of versions from the upstream remote,
+// nil if the module is not proxied or does not exist upstream
+func getRemoteVersionList(ctx *context.Context, name string) ([]byte, error) {
+	r, err := getRemote(ctx, name)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	content, err := remote_service.GetMetadata(ctx, r, escapePath(name)+"/@v/list", "")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// getRemoteLatestVersion gets the latest version from the upstream remote,
+// empty if the module is not proxied or does not exist upstream
+func getRemoteLatestVersion(ctx *context.Context, name string) (string, error) {
+	r, err := getRemote(ctx, name)
+	if err != nil || r == nil {
+		return "", err
+	}
+
+	content, err := remote_service.GetMetadata(ctx, r, escapePath(name)+"/@latest", "application/json")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+	if err := json.Unmarshal(content, &info); err != nil {
+		return "", err
+	}
+	return info.Version, nil
+}
+
+// cacheRemotePackage fetches a version of a module from the upstream remote and stores it as a
+// version of the owner. It returns false if the module is not proxied or the version does not exist upstream.
+func cacheRemotePackage(ctx *context.Context, name, version string) (bool, error) {
+	r, err := getRemote(ctx, name)
+	if err != nil || r == nil {
+		return false, err
+	}
+
+	escaped := escapePath(name) + "/@v/" + escapePath(version)
+
+	buf, err := remote_service.FetchFile(ctx, r, escaped+".zip")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer buf.Close()
+
+	pck, err := goproxy_module.ParsePackage(buf, buf.Size())
+	if err != nil {
+		return false, err
+	}
+	if pck.Name != name || pck.Version != version {
+		return false, util.NewInvalidArgumentErrorf("module zip contains %s@%s instead of %s@%s", pck.Name, pck.Version, name, version)
+	}
+
+	// modules without a go.mod file get a synthesized one from the proxy
+	modBuf, err := remote_service.FetchFile(ctx, r, escaped+".mod")
+	if err != nil {
+		return false, err
+	}
+	defer modBuf.Close()
+	goMod, err := io.ReadAll(io.LimitReader(modBuf, maxGoModSize))
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	_, _, err = remote_service.StoreFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeGo,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			VersionProperties: map[string]string{
+				goproxy_module.PropertyGoMod: string(goMod),
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%v.zip", pck.Version),
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
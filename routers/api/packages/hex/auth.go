@@ -0,0 +1,60 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/services/auth"
+)
+
+var _ auth.Method = &Auth{}
+
+type Auth struct{}
+
+func (a *Auth) Name() string {
+	return "hex"
+}
+
+// hexPathPattern matches the routes of the Hex registries, the only ones accepting an API key without a scheme
+var hexPathPattern = regexp.MustCompile(`^/api/packages/[^/]+/hex/`)
+
+// Verify extracts the user from the API key which the mix client sends as Authorization header without a scheme
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	if !hexPathPattern.MatchString(req.URL.Path) {
+		return nil, nil
+	}
+
+	key := req.Header.Get("Authorization")
+	if key == "" || strings.Contains(key, " ") {
+		return nil, nil
+	}
+
+	token, err := auth.GetAccessToken(req.Context(), key)
+	if err != nil {
+		if !auth_model.IsErrAccessTokenNotExist(err) && !auth_model.IsErrAccessTokenEmpty(err) {
+			log.Error("GetAccessTokenBySHA: %v", err)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	u, err := user_model.GetUserByID(req.Context(), token.UID)
+	if err != nil {
+		log.Error("GetUserByID:  %v", err)
+		return nil, err
+	}
+
+	if err := token.UpdateLastUsed(req.Context()); err != nil {
+		log.Error("UpdateLastUsed:  %v", err)
+	}
+
+	auth.SetAccessTokenData(store, token)
+	return u, nil
+}
@@ -0,0 +1,376 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	hex_module "forgejo.org/modules/packages/hex"
+	"forgejo.org/modules/util"
+	"forgejo.org/routers/api/packages/helper"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	hex_service "forgejo.org/services/packages/hex"
+
+	"github.com/hashicorp/go-version"
+)
+
+const erlangContentType = "application/vnd.hex+erlang"
+
+// apiResponse writes the response of the Hex API in the Erlang term format requested by the mix client
+// or as JSON for other clients
+func apiResponse(ctx *context.Context, status int, obj map[string]any) {
+	if !strings.Contains(ctx.Req.Header.Get("Accept"), erlangContentType) {
+		ctx.JSON(status, obj)
+		return
+	}
+
+	b, err := hex_module.MarshalTerm(obj)
+	if err != nil {
+		log.Error("MarshalTerm: %v", err)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", erlangContentType)
+	ctx.Resp.WriteHeader(status)
+	if _, err := ctx.Resp.Write(b); err != nil {
+		log.Error("Write: %v", err)
+	}
+}
+
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		apiResponse(ctx, status, map[string]any{
+			"status":  status,
+			"message": message,
+		})
+	})
+}
+
+// repositoryName is the name of the Hex repository of the owner.
+// Clients verify that the registry resources belong to the repository they are configured with.
+func repositoryName(ctx *context.Context) string {
+	return ctx.Package.Owner.Name
+}
+
+func serveResource(ctx *context.Context, payload []byte) {
+	b, err := hex_service.SignResource(ctx, payload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if _, err := ctx.Resp.Write(b); err != nil {
+		log.Error("Write: %v", err)
+	}
+}
+
+// GetPublicKey serves the public key used to verify the registry resources
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), &context.ServeHeaderOptions{
+		ContentType: "application/x-pem-file",
+		Filename:    repositoryName(ctx) + ".pem",
+	})
+}
+
+// packageVersions returns the names of all packages with their versions sorted by semantic version
+func packageVersions(ctx *context.Context) ([]*packages_model.Package, map[int64][]*packages_model.PackageVersion, error) {
+	ps, err := packages_model.GetPackagesByType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].Name < ps[j].Name
+	})
+
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions := make(map[int64][]*packages_model.PackageVersion)
+	for _, pv := range pvs {
+		versions[pv.PackageID] = append(versions[pv.PackageID], pv)
+	}
+	for _, list := range versions {
+		sortVersions(list)
+	}
+	return ps, versions, nil
+}
+
+func sortVersions(pvs []*packages_model.PackageVersion) {
+	sort.Slice(pvs, func(i, j int) bool {
+		vi, erri := version.NewSemver(pvs[i].Version)
+		vj, errj := version.NewSemver(pvs[j].Version)
+		if erri != nil || errj != nil {
+			return pvs[i].Version < pvs[j].Version
+		}
+		return vi.LessThan(vj)
+	})
+}
+
+// EnumeratePackageNames serves the names of all packages
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#names
+func EnumeratePackageNames(ctx *context.Context) {
+	ps, versions, err := packageVersions(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	names := make([]*hex_module.NamePackage, 0, len(ps))
+	for _, p := range ps {
+		var updated time.Time
+		for _, pv := range versions[p.ID] {
+			if t := pv.CreatedUnix.AsTime(); t.After(updated) {
+				updated = t
+			}
+		}
+		if updated.IsZero() {
+			continue
+		}
+		names = append(names, &hex_module.NamePackage{Name: p.Name, UpdatedAt: updated})
+	}
+
+	serveResource(ctx, hex_module.EncodeNames(repositoryName(ctx), names))
+}
+
+// EnumeratePackageVersions serves the versions of all packages
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#versions
+func EnumeratePackageVersions(ctx *context.Context) {
+	ps, versions, err := packageVersions(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	entries := make([]*hex_module.VersionsPackage, 0, len(ps))
+	for _, p := range ps {
+		if len(versions[p.ID]) == 0 {
+			continue
+		}
+		entry := &hex_module.VersionsPackage{Name: p.Name}
+		for _, pv := range versions[p.ID] {
+			entry.Versions = append(entry.Versions, pv.Version)
+		}
+		entries = append(entries, entry)
+	}
+
+	serveResource(ctx, hex_module.EncodeVersions(repositoryName(ctx), entries))
+}
+
+// PackageReleases serves the releases of a package
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#package
+func PackageReleases(ctx *context.Context) {
+	packageName := ctx.Params("name")
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	repository := repositoryName(ctx)
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		innerChecksum, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		outerChecksum, err := hex.DecodeString(pd.Files[0].Blob.HashSHA256)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		dependencies := make([]*hex_module.Requirement, 0, len(metadata.Requirements))
+		for _, req := range metadata.Requirements {
+			dep := *req
+			if dep.Repository == repository {
+				dep.Repository = ""
+			}
+			dependencies = append(dependencies, &dep)
+		}
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			OuterChecksum: outerChecksum,
+			Dependencies:  dependencies,
+		})
+	}
+
+	serveResource(ctx, hex_module.EncodePackage(repository, pds[0].Package.Name, releases))
+}
+
+// DownloadPackageFile serves the tarball of a package version
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.Params("filename")
+
+	// package names can't contain a dash
+	name, packageVersion, ok := strings.Cut(strings.TrimSuffix(filename, ".tar"), "-")
+	if !ok || !strings.HasSuffix(filename, ".tar") {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        name,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadPackage publishes a package tarball like mix hex.publish.
+// An existing version is replaced if the replace parameter is set.
+// https://github.com/hexpm/specifications/blob/main/apiary.apib
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		if context.IsFormError(err) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvi := packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeHex,
+		Name:        pck.Name,
+		Version:     pck.Version,
+	}
+
+	if ctx.FormBool("replace") {
+		if err := packages_service.RemovePackageVersionByNameAndVersion(ctx, ctx.Doer, &pvi); err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo:      pvi,
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: pck.Name + "-" + pck.Version + ".tar",
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion):
+			apiError(ctx, http.StatusConflict, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize), errors.Is(err, packages_service.ErrSignatureRequired), errors.Is(err, packages_service.ErrInvalidSignature), errors.Is(err, packages_service.ErrPackageAccessDenied):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	apiResponse(ctx, http.StatusCreated, map[string]any{
+		"version":  pd.Version.Version,
+		"checksum": pd.Files[0].Blob.HashSHA256,
+		"html_url": pd.VersionHTMLURL(),
+		"url":      pd.VersionHTMLURL(),
+	})
+}
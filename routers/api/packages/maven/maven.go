@@ -29,6 +29,7 @@ import (
 	"forgejo.org/routers/api/packages/helper"
 	"forgejo.org/services/context"
 	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
 )
 
 const (
@@ -85,6 +86,10 @@ func handlePackageFile(ctx *context.Context, serveContent bool) {
 		return
 	}
 
+	if params.IsMeta && serveRemoteMavenMetadata(ctx, params) {
+		return
+	}
+
 	if params.IsMeta && params.Version == "" {
 		serveMavenMetadata(ctx, params)
 	} else {
@@ -129,6 +134,11 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
 	ctx.Resp.Header().Set("Last-Modified", lastModified)
 
+	writeMavenMetadata(ctx, params, xmlMetadataWithHeader)
+}
+
+// writeMavenMetadata writes the metadata, or its checksum if requested
+func writeMavenMetadata(ctx *context.Context, params parameters, xmlMetadataWithHeader []byte) {
 	ext := strings.ToLower(filepath.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -159,16 +169,6 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	packageName := buildPackageID(params.GroupID, params.ArtifactID)
 
-	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, packageName, params.Version)
-	if err != nil {
-		if err == packages_model.ErrPackageNotExist {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
-	}
-
 	filename := params.Filename
 
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -176,9 +176,19 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByNameMatchCase(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, packageName, params.Version, filename)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		cached, cacheErr := cacheRemotePackageFile(ctx, params, filename)
+		if cacheErr != nil {
+			apiError(ctx, remote_service.ErrorStatus(cacheErr, http.StatusBadGateway), cacheErr)
+			return
+		}
+		if cached {
+			pf, err = getPackageFile(ctx, packageName, params.Version, filename)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -236,6 +246,14 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	helper.ServePackageFile(ctx, s, u, pf, opts)
 }
 
+func getPackageFile(ctx *context.Context, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, packageName, packageVersion)
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetFileForVersionByNameMatchCase(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
 var mavenUploadLock = sync.NewExclusivePool()
 
 // UploadPackageFile adds a file to the package. If the package does not exist, it gets created.
@@ -330,6 +348,7 @@ func UploadPackageFile(ctx *context.Context) {
 		Creator:           ctx.Doer,
 		Data:              buf,
 		IsLead:            false,
+		Generated:         params.IsMeta,
 		OverwriteExisting: params.IsMeta,
 	}
 
@@ -379,7 +398,7 @@ func UploadPackageFile(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageFile:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,160 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package maven
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	maven_module "forgejo.org/modules/packages/maven"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
+)
+
+// remotePath builds the path of a file in the layout of a maven repository
+func remotePath(params parameters, filename string) string {
+	return path.Join(strings.ReplaceAll(params.GroupID, ".", "/"), params.ArtifactID, params.Version, filename)
+}
+
+// serveRemoteMavenMetadata serves the maven-metadata.xml of the upstream remote.
+// It returns false if the package is not proxied.
+func serveRemoteMavenMetadata(ctx *context.Context, params parameters) bool {
+	packageName := buildPackageID(params.GroupID, params.ArtifactID)
+
+	r, err := remote_service.GetRemoteForPackage(ctx, ctx.Package.Owner, packages_model.TypeMaven, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	if r == nil {
+		return false
+	}
+
+	content, err := remote_service.GetMetadata(ctx, r, remotePath(params, mavenMetadataFile), contentTypeXML)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			// serve the versions cached before
+			log.Warn("Unable to get the maven metadata of %s from the upstream remote: %v", packageName, err)
+		}
+		return false
+	}
+
+	writeMavenMetadata(ctx, params, content)
+	return true
+}
+
+// cacheRemotePackageFile fetches a file from the upstream remote and stores it as a file of a
+// version of the owner. It returns false if the package is not proxied or the file does not exist upstream.
+func cacheRemotePackageFile(ctx *context.Context, params parameters, filename string) (bool, error) {
+	packageName := buildPackageID(params.GroupID, params.ArtifactID)
+
+	r, err := remote_service.GetRemoteForPackage(ctx, ctx.Package.Owner, packages_model.TypeMaven, packageName)
+	if err != nil || r == nil {
+		return false, err
+	}
+
+	mavenUploadLock.CheckIn(packageName)
+	defer mavenUploadLock.CheckOut(packageName)
+
+	buf, err := remote_service.FetchFile(ctx, r, remotePath(params, filename))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer buf.Close()
+
+	// verify the file against the published checksum if there is one
+	if err := verifyRemoteChecksum(ctx, r, params, filename, buf); err != nil {
+		return false, err
+	}
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeMaven,
+			Name:        packageName,
+			Version:     params.Version,
+		},
+		SemverCompatible: false,
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Data: buf,
+	}
+
+	if strings.ToLower(filepath.Ext(filename)) == extensionPom {
+		pfci.IsLead = true
+
+		pvci.Metadata, err = maven_module.ParsePackageMetaData(buf)
+		if err != nil {
+			return false, err
+		}
+
+		// the version may have been created by an artifact fetched before the pom
+		if pvci.Metadata != nil {
+			pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version)
+			if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+				return false, err
+			}
+			if pv != nil {
+				raw, err := json.Marshal(pvci.Metadata)
+				if err != nil {
+					return false, err
+				}
+				pv.MetadataJSON = string(raw)
+				if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+					return false, err
+				}
+			}
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+	}
+
+	if _, _, err := remote_service.StoreFile(ctx, pvci, pfci); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// verifyRemoteChecksum compares the file with the sha1 checksum published next to it, if there is one
+func verifyRemoteChecksum(ctx *context.Context, r *packages_model.PackageRemote, params parameters, filename string, buf *packages_module.HashedBuffer) error {
+	checksumBuf, err := remote_service.FetchFile(ctx, r, remotePath(params, filename+extensionSHA1))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer checksumBuf.Close()
+
+	checksum, err := io.ReadAll(io.LimitReader(checksumBuf, 1024))
+	if err != nil {
+		return err
+	}
+
+	_, hashSHA1, _, _, _ := buf.Sums()
+	// some repositories append the filename to the checksum
+	if fields := strings.Fields(string(checksum)); len(fields) > 0 && !strings.EqualFold(fields[0], hex.EncodeToString(hashSHA1)) {
+		return util.NewInvalidArgumentErrorf("hash mismatch of %s", filename)
+	}
+	return nil
+}
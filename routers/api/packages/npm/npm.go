@@ -25,6 +25,7 @@ import (
 	"forgejo.org/routers/api/packages/helper"
 	"forgejo.org/services/context"
 	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
 
 	"github.com/hashicorp/go-version"
 )
@@ -54,6 +55,11 @@ func packageNameFromParams(ctx *context.Context) string {
 // PackageMetadata returns the metadata for a single package
 func PackageMetadata(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
+
+	if serveRemotePackageMetadata(ctx, packageName, registryURL) {
+		return
+	}
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
@@ -71,10 +77,7 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 
-	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
-		pds,
-	)
+	resp := createPackageMetadataResponse(registryURL, pds)
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -85,18 +88,27 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.Params("version")
 	filename := ctx.Params("filename")
 
-	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-	)
+	pvi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeNpm,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+	if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
+		cached, cacheErr := cacheRemotePackageFile(ctx, packageName, packageVersion, filename)
+		if cacheErr != nil {
+			apiError(ctx, remote_service.ErrorStatus(cacheErr, http.StatusBadGateway), cacheErr)
+			return
+		}
+		if cached {
+			s, u, pf, err = packages_service.GetFileStreamByPackageNameAndVersion(ctx, pvi, pfi)
+		}
+	}
 	if err != nil {
 		if err == packages_model.ErrPackageNotExist || err == packages_model.ErrPackageFileNotExist {
 			apiError(ctx, http.StatusNotFound, err)
@@ -216,7 +228,7 @@ func UploadPackage(ctx *context.Context) {
 		switch err {
 		case packages_model.ErrDuplicatePackageVersion:
 			apiError(ctx, http.StatusConflict, err)
-		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize, packages_service.ErrSignatureRequired, packages_service.ErrInvalidSignature, packages_service.ErrPackageAccessDenied:
 			apiError(ctx, http.StatusForbidden, err)
 		default:
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -271,6 +283,10 @@ func DeletePackageVersion(ctx *context.Context) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
+		if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -295,6 +311,10 @@ func DeletePackage(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+			if errors.Is(err, packages_service.ErrImmutableVersion) || errors.Is(err, packages_service.ErrPackageAccessDenied) {
+				apiError(ctx, http.StatusForbidden, err)
+				return
+			}
 			apiError(ctx, http.StatusInternalServerError, err)
 			return
 		}
@@ -307,6 +327,10 @@ func DeletePackage(ctx *context.Context) {
 func ListPackageTags(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
 
+	if serveRemotePackageTags(ctx, packageName) {
+		return
+	}
+
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -332,6 +356,11 @@ func ListPackageTags(ctx *context.Context) {
 func AddPackageTag(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
 
+	if err := packages_service.CheckWriteGranted(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName); err != nil {
+		apiError(ctx, http.StatusForbidden, err)
+		return
+	}
+
 	body, err := io.ReadAll(ctx.Req.Body)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -363,6 +392,10 @@ func AddPackageTag(ctx *context.Context) {
 func DeletePackageTag(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
 
+	if err := packages_service.CheckWriteGranted(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName); err != nil {
+		apiError(ctx, http.StatusForbidden, err)
+		return
+	}
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,159 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package npm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	npm_module "forgejo.org/modules/packages/npm"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
+
+	"github.com/hashicorp/go-version"
+)
+
+// getRemotePackageMetadata gets the package document from the upstream remote,
+// nil if the package is not proxied or does not exist upstream
+func getRemotePackageMetadata(ctx *context.Context, packageName string) (*packages_model.PackageRemote, *npm_module.PackageMetadata, error) {
+	r, err := remote_service.GetRemoteForPackage(ctx, ctx.Package.Owner, packages_model.TypeNpm, packageName)
+	if err != nil || r == nil {
+		return nil, nil, err
+	}
+
+	// scoped packages are requested as @scope%2fname
+	content, err := remote_service.GetMetadata(ctx, r, strings.Replace(packageName, "/", "%2f", 1), "application/json")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var metadata npm_module.PackageMetadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, nil, err
+	}
+	return r, &metadata, nil
+}
+
+// serveRemotePackageMetadata serves the package document of the upstream remote with the
+// tarballs pointing to this registry. It returns false if the package is not proxied.
+func serveRemotePackageMetadata(ctx *context.Context, packageName, registryURL string) bool {
+	_, metadata, err := getRemotePackageMetadata(ctx, packageName)
+	if err != nil {
+		// serve the versions cached before
+		log.Warn("Unable to get the npm package %s from the upstream remote: %v", packageName, err)
+		return false
+	}
+	if metadata == nil {
+		return false
+	}
+
+	for v, meta := range metadata.Versions {
+		if meta == nil {
+			delete(metadata.Versions, v)
+			continue
+		}
+		meta.Dist.Tarball = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(meta.Version), url.PathEscape(npm_module.Filename(packageName, meta.Version)))
+	}
+
+	ctx.JSON(http.StatusOK, metadata)
+	return true
+}
+
+// serveRemotePackageTags serves the dist-tags of the package of the upstream remote.
+// It returns false if the package is not proxied.
+func serveRemotePackageTags(ctx *context.Context, packageName string) bool {
+	_, metadata, err := getRemotePackageMetadata(ctx, packageName)
+	if err != nil {
+		// serve the tags cached before
+		log.Warn("Unable to get the npm package %s from the upstream remote: %v", packageName, err)
+		return false
+	}
+	if metadata == nil {
+		return false
+	}
+
+	tags := metadata.DistTags
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	ctx.JSON(http.StatusOK, tags)
+	return true
+}
+
+// cacheRemotePackageFile fetches a tarball from the upstream remote and stores it as a version
+// of the owner. It returns false if the package is not proxied or the file does not exist upstream.
+func cacheRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) (bool, error) {
+	r, metadata, err := getRemotePackageMetadata(ctx, packageName)
+	if err != nil || metadata == nil {
+		return false, err
+	}
+
+	meta, ok := metadata.Versions[packageVersion]
+	if !ok || meta == nil || meta.Name != packageName || npm_module.Filename(packageName, packageVersion) != strings.ToLower(filename) {
+		return false, nil
+	}
+	v, err := version.NewSemver(meta.Version)
+	if err != nil || v.String() != packageVersion {
+		return false, nil
+	}
+
+	buf, err := remote_service.FetchFile(ctx, r, meta.Dist.Tarball)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer buf.Close()
+
+	_, hashSHA1, _, hashSHA512, _ := buf.Sums()
+	if !npm_module.VerifyIntegrity(&meta.Dist, hashSHA1, hashSHA512) {
+		return false, npm_module.ErrInvalidIntegrity
+	}
+
+	pv, _, err := remote_service.StoreFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Metadata:         npm_module.NewMetadata(meta),
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: npm_module.Filename(packageName, packageVersion),
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// keep the tags of the version to serve them if the remote becomes unreachable
+	for tag, tagged := range metadata.DistTags {
+		if tagged == packageVersion {
+			if err := setPackageTag(ctx, tag, pv, false); err != nil && !errors.Is(err, errInvalidTagName) {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
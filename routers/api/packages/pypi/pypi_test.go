@@ -46,3 +46,12 @@ func TestNormalizeLabel(t *testing.T) {
 	assert.Equal(t, "whatsnew", normalizeLabel("What's New?"))
 	assert.Equal(t, "github", normalizeLabel("github"))
 }
+
+func TestVersionFromFilename(t *testing.T) {
+	assert.Equal(t, "1.0.1", versionFromFilename("test-name", "test_name-1.0.1-py3-none-any.whl"))
+	assert.Equal(t, "1.0.1", versionFromFilename("test-name", "test_name-1.0.1-1-cp312-cp312-manylinux_2_17_x86_64.whl"))
+	assert.Equal(t, "1.0.1", versionFromFilename("test-name", "test-name-1.0.1.tar.gz"))
+	assert.Equal(t, "1.0.1", versionFromFilename("test-name", "Test.Name-1.0.1.zip"))
+	assert.Empty(t, versionFromFilename("test-name", "other-1.0.1.tar.gz"))
+	assert.Empty(t, versionFromFilename("test-name", "test_name-1.0.1.exe"))
+}
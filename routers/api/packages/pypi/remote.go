@@ -0,0 +1,215 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pypi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/log"
+	pypi_module "forgejo.org/modules/packages/pypi"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	remote_service "forgejo.org/services/packages/remote"
+
+	"golang.org/x/net/html"
+)
+
+// remoteFile is a file listed on the simple page of the upstream remote
+type remoteFile struct {
+	Filename       string
+	Version        string
+	URL            string
+	SHA256         string
+	RequiresPython string
+}
+
+var sdistExtensions = []string{".tar.gz", ".tar.bz2", ".zip"}
+
+// versionFromFilename extracts the version from the name of a wheel or a source distribution
+func versionFromFilename(packageName, filename string) string {
+	if base, ok := strings.CutSuffix(filename, ".whl"); ok {
+		// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+		parts := strings.Split(base, "-")
+		if len(parts) < 5 || normalizer.Replace(strings.ToLower(parts[0])) != packageName {
+			return ""
+		}
+		return parts[1]
+	}
+
+	for _, ext := range sdistExtensions {
+		if base, ok := strings.CutSuffix(filename, ext); ok {
+			// {name}-{version}{ext}, the name may contain dashes in legacy distributions
+			for i := strings.LastIndexByte(base, '-'); i > 0; i = strings.LastIndexByte(base[:i], '-') {
+				if normalizer.Replace(strings.ToLower(base[:i])) == packageName {
+					return base[i+1:]
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// parseSimplePage extracts the files of the package from a simple page (PEP 503)
+func parseSimplePage(packageName string, base *url.URL, content []byte) ([]*remoteFile, error) {
+	doc, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*remoteFile, 0, 10)
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			f := &remoteFile{}
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "href":
+					f.URL = attr.Val
+				case "data-requires-python":
+					f.RequiresPython = attr.Val
+				}
+			}
+			if n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
+				f.Filename = strings.TrimSpace(n.FirstChild.Data)
+			}
+
+			if href, err := base.Parse(f.URL); err == nil && f.Filename != "" && !strings.ContainsAny(f.Filename, `/\`) {
+				if algorithm, value, ok := strings.Cut(href.Fragment, "="); ok && algorithm == "sha256" {
+					f.SHA256 = strings.ToLower(value)
+				}
+				href.Fragment = ""
+				f.URL = href.String()
+				f.Version = versionFromFilename(packageName, f.Filename)
+				if f.Version != "" && isValidNameAndVersion(packageName, f.Version) {
+					files = append(files, f)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return files, nil
+}
+
+// getRemoteFiles gets the files of the package from the upstream remote,
+// nil if the package is not proxied or does not exist upstream
+func getRemoteFiles(ctx *context.Context, packageName string) (*packages_model.PackageRemote, []*remoteFile, error) {
+	r, err := remote_service.GetRemoteForPackage(ctx, ctx.Package.Owner, packages_model.TypePyPI, packageName)
+	if err != nil || r == nil {
+		return nil, nil, err
+	}
+
+	p := packageName + "/"
+	content, err := remote_service.GetMetadata(ctx, r, p, "text/html")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	base, err := url.Parse(remote_service.ResolveURL(r, p))
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := parseSimplePage(packageName, base, content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, files, nil
+}
+
+// serveRemotePackageMetadata serves the simple page of the upstream remote with the files
+// pointing to this registry. It returns false if the package is not proxied.
+func serveRemotePackageMetadata(ctx *context.Context, packageName, registryURL string) bool {
+	_, files, err := getRemoteFiles(ctx, packageName)
+	if err != nil {
+		// serve the versions cached before
+		log.Warn("Unable to get the PyPI package %s from the upstream remote: %v", packageName, err)
+		return false
+	}
+	if len(files) == 0 {
+		return false
+	}
+
+	ctx.Data["RegistryURL"] = registryURL
+	ctx.Data["PackageName"] = packageName
+	ctx.Data["RemoteFiles"] = files
+	ctx.HTML(http.StatusOK, "api/packages/pypi/simple_remote")
+	return true
+}
+
+// cacheRemotePackageFile fetches a file from the upstream remote and stores it as a file of a
+// version of the owner. It returns false if the package is not proxied or the file does not exist upstream.
+func cacheRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) (bool, error) {
+	r, files, err := getRemoteFiles(ctx, packageName)
+	if err != nil || r == nil {
+		return false, err
+	}
+
+	var file *remoteFile
+	for _, f := range files {
+		if f.Filename == filename && f.Version == packageVersion {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return false, nil
+	}
+
+	buf, err := remote_service.FetchFile(ctx, r, file.URL)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer buf.Close()
+
+	if file.SHA256 != "" {
+		_, _, hashSHA256, _, _ := buf.Sums()
+		if file.SHA256 != hex.EncodeToString(hashSHA256) {
+			return false, util.NewInvalidArgumentErrorf("hash mismatch of %s", filename)
+		}
+	}
+
+	_, _, err = remote_service.StoreFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: false,
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
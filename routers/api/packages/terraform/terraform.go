@@ -0,0 +1,553 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/sync"
+	"forgejo.org/modules/util"
+	"forgejo.org/routers/api/packages/helper"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+	terraform_service "forgejo.org/services/packages/terraform"
+)
+
+// the archives of a provider version are uploaded one by one and every upload rebuilds the checksums
+var providerUploadLock = sync.NewExclusivePool()
+
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, struct {
+			Errors []string `json:"errors"`
+		}{
+			Errors: []string{
+				message,
+			},
+		})
+	})
+}
+
+func apiErrorDefault(ctx *context.Context, err error) {
+	switch {
+	case errors.Is(err, packages_model.ErrPackageNotExist), errors.Is(err, packages_model.ErrPackageFileNotExist):
+		apiError(ctx, http.StatusNotFound, err)
+	case errors.Is(err, packages_model.ErrDuplicatePackageFile), errors.Is(err, packages_model.ErrDuplicatePackageVersion):
+		apiError(ctx, http.StatusConflict, err)
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize), errors.Is(err, packages_service.ErrSignatureRequired), errors.Is(err, packages_service.ErrInvalidSignature), errors.Is(err, packages_service.ErrPackageAccessDenied):
+		apiError(ctx, http.StatusForbidden, err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		apiError(ctx, http.StatusBadRequest, err)
+	default:
+		apiError(ctx, http.StatusInternalServerError, err)
+	}
+}
+
+func baseURL() string {
+	return setting.AppURL + "api/packages/terraform/v1"
+}
+
+// ServiceDiscovery serves the hosts the registry protocols are available at
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func ServiceDiscovery(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"modules.v1":   setting.AppSubURL + "/api/packages/terraform/v1/modules/",
+		"providers.v1": setting.AppSubURL + "/api/packages/terraform/v1/providers/",
+	}); err != nil {
+		log.Error("Unable to write the Terraform service discovery: %v", err)
+	}
+}
+
+func moduleParams(ctx *context.Context) (string, string, bool) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+	return name, system, terraform_module.IsValidModuleName(name, system)
+}
+
+// EnumerateModuleVersions lists the available versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	name, system, ok := moduleParams(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, terraform_module.ErrInvalidName)
+		return
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	type moduleVersion struct {
+		Version string `json:"version"`
+	}
+	type module struct {
+		Versions []*moduleVersion `json:"versions"`
+	}
+
+	versions := make([]*moduleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, &moduleVersion{Version: pv.Version})
+	}
+
+	ctx.JSON(http.StatusOK, map[string][]*module{
+		"modules": {{Versions: versions}},
+	})
+}
+
+// DownloadModule points Terraform to the archive of a module version
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModule(ctx *context.Context) {
+	name, system, ok := moduleParams(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, terraform_module.ErrInvalidName)
+		return
+	}
+	moduleVersion := ctx.Params("version")
+
+	if _, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system), moduleVersion); err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf("%s/modules/%s/%s/%s/%s/archive.tar.gz", baseURL(), url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(name), url.PathEscape(system), url.PathEscape(moduleVersion)))
+	ctx.Status(http.StatusNoContent)
+}
+
+// DownloadModuleArchive serves the archive of a module version
+func DownloadModuleArchive(ctx *context.Context) {
+	name, system, ok := moduleParams(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, terraform_module.ErrInvalidName)
+		return
+	}
+	moduleVersion := ctx.Params("version")
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(name, system),
+			Version:     moduleVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: terraform_module.ModuleFilename(name, system, moduleVersion),
+		},
+	)
+	if err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadModule publishes a version of a module, the body is the archive (.tar.gz) of the module
+func UploadModule(ctx *context.Context) {
+	name, system, ok := moduleParams(ctx)
+	if !ok {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	moduleVersion := ctx.Params("version")
+	if !terraform_module.IsValidVersion(moduleVersion) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+
+	buf, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModule(buf)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ModulePackageName(name, system),
+				Version:     moduleVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: terraform_module.ModuleFilename(name, system, moduleVersion),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func readUpload(ctx *context.Context) (*packages_module.HashedBuffer, bool) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		if context.IsFormError(err) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return nil, false
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return buf, true
+}
+
+func getProviderDescriptor(ctx *context.Context) (*packages_model.PackageDescriptor, error) {
+	provider := ctx.Params("provider")
+	if !terraform_module.IsValidProviderName(provider) {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, provider, ctx.Params("version"))
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageDescriptor(ctx, pv)
+}
+
+func protocols(pd *packages_model.PackageDescriptor) []string {
+	if metadata, ok := pd.Metadata.(*terraform_module.Metadata); ok && len(metadata.Protocols) > 0 {
+		return metadata.Protocols
+	}
+	return []string{terraform_module.DefaultProtocol}
+}
+
+// EnumerateProviderVersions lists the available versions and platforms of a provider
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	provider := ctx.Params("provider")
+	if !terraform_module.IsValidProviderName(provider) {
+		apiError(ctx, http.StatusNotFound, terraform_module.ErrInvalidName)
+		return
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, provider)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	type platform struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+	}
+	type providerVersion struct {
+		Version   string      `json:"version"`
+		Protocols []string    `json:"protocols"`
+		Platforms []*platform `json:"platforms"`
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*platform, 0, len(pd.Files))
+		for _, pf := range pd.Files {
+			if !pf.File.IsLead {
+				continue
+			}
+			platforms = append(platforms, &platform{
+				OS:   pf.Properties.GetByName(terraform_module.PropertyOS),
+				Arch: pf.Properties.GetByName(terraform_module.PropertyArch),
+			})
+		}
+		// versions without an archive can't be installed
+		if len(platforms) == 0 {
+			continue
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: protocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"versions": versions,
+	})
+}
+
+// DownloadProvider serves the download location and the signed checksums of a provider version for a platform
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func DownloadProvider(ctx *context.Context) {
+	pd, err := getProviderDescriptor(ctx)
+	if err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	provider := pd.Package.Name
+	providerVersion := pd.Version.Version
+	filename := terraform_module.ProviderFilename(provider, providerVersion, ctx.Params("os"), ctx.Params("arch"))
+
+	var file *packages_model.PackageFileDescriptor
+	for _, pf := range pd.Files {
+		if pf.File.Name == filename {
+			file = pf
+			break
+		}
+	}
+	if file == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	_, pub, err := terraform_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	keyID, err := terraform_service.GetPublicKeyID(pub)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s/%s", baseURL(), url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(provider), url.PathEscape(providerVersion))
+
+	type gpgPublicKey struct {
+		KeyID      string `json:"key_id"`
+		ASCIIArmor string `json:"ascii_armor"`
+	}
+	type signingKeys struct {
+		GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+	}
+
+	ctx.JSON(http.StatusOK, struct {
+		Protocols           []string    `json:"protocols"`
+		OS                  string      `json:"os"`
+		Arch                string      `json:"arch"`
+		Filename            string      `json:"filename"`
+		DownloadURL         string      `json:"download_url"`
+		ShasumsURL          string      `json:"shasums_url"`
+		ShasumsSignatureURL string      `json:"shasums_signature_url"`
+		Shasum              string      `json:"shasum"`
+		SigningKeys         signingKeys `json:"signing_keys"`
+	}{
+		Protocols:           protocols(pd),
+		OS:                  file.Properties.GetByName(terraform_module.PropertyOS),
+		Arch:                file.Properties.GetByName(terraform_module.PropertyArch),
+		Filename:            filename,
+		DownloadURL:         versionURL + "/" + url.PathEscape(filename),
+		ShasumsURL:          versionURL + "/" + url.PathEscape(terraform_module.ShasumsFilename(provider, providerVersion)),
+		ShasumsSignatureURL: versionURL + "/" + url.PathEscape(terraform_module.ShasumsSignatureFilename(provider, providerVersion)),
+		Shasum:              file.Blob.HashSHA256,
+		SigningKeys: signingKeys{
+			GPGPublicKeys: []*gpgPublicKey{
+				{
+					KeyID:      keyID,
+					ASCIIArmor: pub,
+				},
+			},
+		},
+	})
+}
+
+// DownloadProviderFile serves an archive, the checksums or the signature of a provider version
+func DownloadProviderFile(ctx *context.Context) {
+	provider := ctx.Params("provider")
+	if !terraform_module.IsValidProviderName(provider) {
+		apiError(ctx, http.StatusNotFound, terraform_module.ErrInvalidName)
+		return
+	}
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        provider,
+			Version:     ctx.Params("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.Params("filename"),
+		},
+	)
+	if err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadProviderFile publishes the archive of a provider version for a platform or the registry manifest
+// of the version. The checksums of the version are signed by the registry.
+func UploadProviderFile(ctx *context.Context) {
+	provider := ctx.Params("provider")
+	providerVersion := ctx.Params("version")
+	filename := ctx.Params("filename")
+
+	if !terraform_module.IsValidProviderName(provider) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if !terraform_module.IsValidVersion(providerVersion) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+
+	isManifest := filename == terraform_module.ManifestFilename(provider, providerVersion)
+
+	var pf *terraform_module.ProviderFile
+	if !isManifest {
+		var err error
+		pf, err = terraform_module.ParseProviderFilename(filename)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if pf.Provider != provider || pf.Version != providerVersion {
+			apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidFilename)
+			return
+		}
+	}
+
+	buf, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+	defer buf.Close()
+
+	metadata := &terraform_module.Metadata{
+		Kind: terraform_module.KindProvider,
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator: ctx.Doer,
+		Data:    buf,
+	}
+
+	if isManifest {
+		protocols, err := terraform_module.ParseManifest(buf)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		metadata.Protocols = protocols
+	} else {
+		if err := terraform_module.ParseProvider(buf, buf.Size(), provider); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		pfci.IsLead = true
+		pfci.Properties = map[string]string{
+			terraform_module.PropertyOS:   pf.OS,
+			terraform_module.PropertyArch: pf.Arch,
+		}
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	key := fmt.Sprintf("%d/%s/%s", ctx.Package.Owner.ID, provider, providerVersion)
+	providerUploadLock.CheckIn(key)
+	defer providerUploadLock.CheckOut(key)
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        provider,
+				Version:     providerVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		pfci,
+	)
+	if err != nil {
+		apiErrorDefault(ctx, err)
+		return
+	}
+
+	if isManifest {
+		// the version may have been created by an archive uploaded before the manifest
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		pv.MetadataJSON = string(raw)
+		if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	} else if err := terraform_service.BuildShasums(ctx, ctx.Package.Owner.ID, pv); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
@@ -0,0 +1,359 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package scim implements the SCIM 2.0 endpoint (RFC 7644) identity providers provision users and teams with
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	scim_module "forgejo.org/modules/scim"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/shared"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+// Routes returns the routes of the SCIM endpoint, which is mounted at /scim/v2
+func Routes() *web.Route {
+	m := web.NewRoute()
+
+	m.Use(shared.Middlewares()...)
+	m.Use(reqSCIMAccess)
+
+	m.Get("/ServiceProviderConfig", ServiceProviderConfig)
+	m.Get("/ResourceTypes", ResourceTypes)
+	m.Group("/Users", func() {
+		m.Get("", ListUsers)
+		m.Post("", CreateUser)
+		m.Get("/{id}", GetUser)
+		m.Put("/{id}", ReplaceUser)
+		m.Patch("/{id}", PatchUser)
+		m.Delete("/{id}", DeleteUser)
+	})
+	m.Group("/Groups", func() {
+		m.Get("", ListGroups)
+		m.Post("", CreateGroup)
+		m.Get("/{id}", GetGroup)
+		m.Put("/{id}", ReplaceGroup)
+		m.Patch("/{id}", PatchGroup)
+		m.Delete("/{id}", DeleteGroup)
+	})
+	return m
+}
+
+// reqSCIMAccess only allows site administrators authenticated with an access token that has the admin scope
+func reqSCIMAccess(ctx *context.APIContext) {
+	if !ctx.IsSigned {
+		ctx.Resp.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+		writeError(ctx, scim_module.NewError(http.StatusUnauthorized, "", "authentication required"))
+		return
+	}
+	if !ctx.IsUserSiteAdmin() {
+		writeError(ctx, scim_module.NewError(http.StatusForbidden, "", "only site administrators can use SCIM"))
+		return
+	}
+
+	scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	if ctx.Data["IsApiToken"] != true || !ok {
+		writeError(ctx, scim_module.NewError(http.StatusForbidden, "", "SCIM requires a personal access token"))
+		return
+	}
+	if t := ctx.AccessToken(); t != nil && t.IsFineGrained() {
+		writeError(ctx, scim_module.NewError(http.StatusForbidden, "", "fine-grained access tokens cannot be used for SCIM"))
+		return
+	}
+
+	level := auth_model.Write
+	if ctx.Req.Method == http.MethodGet {
+		level = auth_model.Read
+	}
+	allow, err := scope.HasScope(auth_model.GetRequiredScopes(level, auth_model.AccessTokenScopeCategoryAdmin)...)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	if !allow {
+		writeError(ctx, scim_module.NewError(http.StatusForbidden, "", "the access token requires the %s scope", auth_model.GetRequiredScopes(level, auth_model.AccessTokenScopeCategoryAdmin)[0]))
+		return
+	}
+}
+
+func writeJSON(ctx *context.APIContext, status int, content any) {
+	ctx.Resp.Header().Set("Content-Type", scim_module.ContentType+";charset=utf-8")
+	ctx.Resp.WriteHeader(status)
+	if err := json.NewEncoder(ctx.Resp).Encode(content); err != nil {
+		log.Error("Render SCIM response failed: %v", err)
+	}
+}
+
+func writeError(ctx *context.APIContext, err error) {
+	var scimErr *scim_module.Error
+	if !errors.As(err, &scimErr) {
+		log.Error("SCIM request %s %s failed: %v", ctx.Req.Method, ctx.Req.URL.Path, err)
+		scimErr = scim_module.NewError(http.StatusInternalServerError, "", "internal server error")
+	}
+	writeJSON(ctx, scimErr.Status, scimErr.Response())
+}
+
+func readBody(ctx *context.APIContext, v any) bool {
+	if err := json.NewDecoder(ctx.Req.Body).Decode(v); err != nil {
+		writeError(ctx, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidSyntax, "invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func readPatch(ctx *context.APIContext) ([]scim_module.PatchOperation, bool) {
+	var patch scim_module.PatchRequest
+	if !readBody(ctx, &patch) {
+		return nil, false
+	}
+	if len(patch.Operations) == 0 {
+		writeError(ctx, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidSyntax, "no operations"))
+		return nil, false
+	}
+	return patch.Operations, true
+}
+
+// queryOptions returns the filter and the 1-based page of a query
+func queryOptions(ctx *context.APIContext) (filter scim_module.Filter, startIndex, count int, ok bool) {
+	if expression := strings.TrimSpace(ctx.FormString("filter")); expression != "" {
+		var err error
+		if filter, err = scim_module.ParseFilter(expression); err != nil {
+			writeError(ctx, err)
+			return nil, 0, 0, false
+		}
+	}
+
+	startIndex, count = 1, scim_service.MaxResults()
+	if s := ctx.FormString("startIndex"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(ctx, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "invalid startIndex %q", s))
+			return nil, 0, 0, false
+		}
+		startIndex = max(n, 1)
+	}
+	if s := ctx.FormString("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(ctx, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "invalid count %q", s))
+			return nil, 0, 0, false
+		}
+		count = min(max(n, 0), count)
+	}
+	return filter, startIndex, count, true
+}
+
+// ServiceProviderConfig describes the supported features of the endpoint
+func ServiceProviderConfig(ctx *context.APIContext) {
+	writeJSON(ctx, http.StatusOK, map[string]any{
+		"schemas":        []string{scim_module.ServiceProviderConfigSchema},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scim_service.MaxResults()},
+		"changePassword": map[string]any{"supported": false},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Access token",
+			"description": "A personal access token of a site administrator with the admin scope",
+			"primary":     true,
+		}},
+		"meta": map[string]any{
+			"resourceType": "ServiceProviderConfig",
+			"location":     setting.AppURL + "scim/v2/ServiceProviderConfig",
+		},
+	})
+}
+
+// ResourceTypes lists the resource types of the endpoint
+func ResourceTypes(ctx *context.APIContext) {
+	resourceTypes := []any{
+		map[string]any{
+			"schemas":  []string{scim_module.ResourceTypeSchema},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scim_module.UserSchema,
+			"meta":     map[string]any{"resourceType": "ResourceType", "location": setting.AppURL + "scim/v2/ResourceTypes/User"},
+		},
+		map[string]any{
+			"schemas":     []string{scim_module.ResourceTypeSchema},
+			"id":          "Group",
+			"name":        "Group",
+			"endpoint":    "/Groups",
+			"description": "Organization teams, named \"organization/team\"",
+			"schema":      scim_module.GroupSchema,
+			"meta":        map[string]any{"resourceType": "ResourceType", "location": setting.AppURL + "scim/v2/ResourceTypes/Group"},
+		},
+	}
+	writeJSON(ctx, http.StatusOK, scim_module.NewListResponse(resourceTypes, len(resourceTypes), 1))
+}
+
+// ListUsers lists the users matching the filter
+func ListUsers(ctx *context.APIContext) {
+	filter, startIndex, count, ok := queryOptions(ctx)
+	if !ok {
+		return
+	}
+	resp, err := scim_service.ListUsers(ctx, filter, startIndex, count)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// GetUser returns a user
+func GetUser(ctx *context.APIContext) {
+	user, err := scim_service.GetUser(ctx, ctx.Params("id"))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// CreateUser provisions a user
+func CreateUser(ctx *context.APIContext) {
+	var in scim_module.User
+	if !readBody(ctx, &in) {
+		return
+	}
+	user, err := scim_service.CreateUser(ctx, ctx.Doer, &in)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", user.Meta.Location)
+	writeJSON(ctx, http.StatusCreated, user)
+}
+
+// ReplaceUser replaces the attributes of a user
+func ReplaceUser(ctx *context.APIContext) {
+	var in scim_module.User
+	if !readBody(ctx, &in) {
+		return
+	}
+	user, err := scim_service.ReplaceUser(ctx, ctx.Doer, ctx.Params("id"), &in)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// PatchUser modifies the attributes of a user
+func PatchUser(ctx *context.APIContext) {
+	operations, ok := readPatch(ctx)
+	if !ok {
+		return
+	}
+	user, err := scim_service.PatchUser(ctx, ctx.Doer, ctx.Params("id"), operations)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// DeleteUser deprovisions a user
+func DeleteUser(ctx *context.APIContext) {
+	if err := scim_service.DeleteUser(ctx, ctx.Doer, ctx.Params("id")); err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListGroups lists the teams matching the filter
+func ListGroups(ctx *context.APIContext) {
+	filter, startIndex, count, ok := queryOptions(ctx)
+	if !ok {
+		return
+	}
+	withMembers := true
+	for _, attribute := range strings.Split(ctx.FormString("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attribute), "members") {
+			withMembers = false
+		}
+	}
+	resp, err := scim_service.ListGroups(ctx, filter, startIndex, count, withMembers)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// GetGroup returns a team
+func GetGroup(ctx *context.APIContext) {
+	group, err := scim_service.GetGroup(ctx, ctx.Params("id"))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, group)
+}
+
+// CreateGroup creates a team
+func CreateGroup(ctx *context.APIContext) {
+	var in scim_module.Group
+	if !readBody(ctx, &in) {
+		return
+	}
+	group, err := scim_service.CreateGroup(ctx, ctx.Doer, &in)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", group.Meta.Location)
+	writeJSON(ctx, http.StatusCreated, group)
+}
+
+// ReplaceGroup replaces the name and the members of a team
+func ReplaceGroup(ctx *context.APIContext) {
+	var in scim_module.Group
+	if !readBody(ctx, &in) {
+		return
+	}
+	group, err := scim_service.ReplaceGroup(ctx, ctx.Doer, ctx.Params("id"), &in)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, group)
+}
+
+// PatchGroup modifies the name or the members of a team
+func PatchGroup(ctx *context.APIContext) {
+	operations, ok := readPatch(ctx)
+	if !ok {
+		return
+	}
+	group, err := scim_service.PatchGroup(ctx, ctx.Doer, ctx.Params("id"), operations)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, group)
+}
+
+// DeleteGroup deletes a team
+func DeleteGroup(ctx *context.APIContext) {
+	if err := scim_service.DeleteGroup(ctx, ctx.Doer, ctx.Params("id")); err != nil {
+		writeError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
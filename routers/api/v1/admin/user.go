@@ -12,6 +12,7 @@ import (
 
 	"forgejo.org/models"
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
@@ -26,6 +27,7 @@ import (
 	"forgejo.org/routers/api/v1/user"
 	"forgejo.org/routers/api/v1/utils"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/mailer"
@@ -153,6 +155,7 @@ func CreateUser(ctx *context.APIContext) {
 		ctx.Resp.Header().Add("X-Gitea-Warning", fmt.Sprintf("the domain of user email %s conflicts with EMAIL_DOMAIN_ALLOWLIST or EMAIL_DOMAIN_BLOCKLIST", u.Email))
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserCreate, audit_service.UserTarget(u), nil, audit_service.UserState(u))
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
 
 	// Send email notification.
@@ -192,6 +195,7 @@ func EditUser(ctx *context.APIContext) {
 	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditUserOption)
+	before := audit_service.UserState(ctx.ContextUser)
 
 	// If either LoginSource or LoginName is given, the other must be present too.
 	if form.SourceID != nil || form.LoginName != nil {
@@ -266,6 +270,7 @@ func EditUser(ctx *context.APIContext) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserUpdate, audit_service.UserTarget(ctx.ContextUser), before, audit_service.UserState(ctx.ContextUser))
 	log.Trace("Account profile updated by admin (%s): %s", ctx.Doer.Name, ctx.ContextUser.Name)
 
 	ctx.JSON(http.StatusOK, convert.ToUser(ctx, ctx.ContextUser, ctx.Doer))
@@ -320,6 +325,7 @@ func DeleteUser(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserDelete, audit_service.UserTarget(ctx.ContextUser), audit_service.UserState(ctx.ContextUser), nil)
 	log.Trace("Account deleted by admin(%s): %s", ctx.Doer.Name, ctx.ContextUser.Name)
 
 	ctx.Status(http.StatusNoContent)
@@ -179,6 +179,9 @@ func repoAssignment() func(ctx *context.APIContext) {
 
 		repo.Owner = owner
 		ctx.Repo.Repository = repo
+		if !ctx.CheckIPAllowlist(owner.ID) {
+			return
+		}
 
 		if ctx.Doer != nil && ctx.Doer.ID == user_model.ActionsUserID {
 			taskID := ctx.Data["ActionsTaskID"].(int64)
@@ -213,6 +216,7 @@ func repoAssignment() func(ctx *context.APIContext) {
 				ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
 				return
 			}
+			ctx.Repo.Permission.RestrictToAccessToken(ctx.AccessToken(), repo)
 		}
 
 		if !ctx.Repo.HasAccess() {
@@ -337,7 +341,7 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		// use the http method to determine the access level
 		requiredScopeLevel := auth_model.Read
-		if ctx.Req.Method == "POST" || ctx.Req.Method == "PUT" || ctx.Req.Method == "PATCH" || ctx.Req.Method == "DELETE" {
+		if ctx.Req.Method == "POST" || ctx.Req.Method == "PUT" || ctx.Req.Method == "PATCH" || ctx.Req.Method == "DELETE" || ctx.Req.Method == "LOCK" || ctx.Req.Method == "UNLOCK" {
 			requiredScopeLevel = auth_model.Write
 		}
 
@@ -363,6 +367,17 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 			return
 		}
 
+		// fine-grained tokens only act on their repositories and packages,
+		// outside of them they can only read public resources
+		if t := ctx.AccessToken(); t != nil && t.IsFineGrained() && ctx.Params("reponame") == "" &&
+			!auth_model.ContainsCategory(requiredScopeCategories, auth_model.AccessTokenScopeCategoryPackage) {
+			if requiredScopeLevel == auth_model.Write {
+				ctx.Error(http.StatusForbidden, "tokenRequiresScope", "fine-grained tokens are restricted to their repositories")
+				return
+			}
+			publicOnly = true
+		}
+
 		// assign to true so that those searching should only filter public repositories/users/organizations
 		ctx.PublicOnly = publicOnly
 	}
@@ -620,6 +635,16 @@ func reqGitHook() func(ctx *context.APIContext) {
 	}
 }
 
+// reqSSHCertificateAuthorityEnabled requires the SSH certificate authority to be enabled by admin.
+func reqSSHCertificateAuthorityEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.SSH.CertificateAuthorityEnabled {
+			ctx.NotFound()
+			return
+		}
+	}
+}
+
 // reqWebhooksEnabled requires webhooks to be enabled by admin.
 func reqWebhooksEnabled() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -663,6 +688,9 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				return
 			}
 			ctx.ContextUser = ctx.Org.Organization.AsUser()
+			if !ctx.CheckIPAllowlist(ctx.Org.Organization.ID) || !ctx.CheckTwoFactorRequirement(ctx.Org.Organization.ID) {
+				return
+			}
 		}
 
 		if assignTeam {
@@ -675,6 +703,9 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				}
 				return
 			}
+			if !ctx.CheckIPAllowlist(ctx.Org.Team.OrgID) || !ctx.CheckTwoFactorRequirement(ctx.Org.Team.OrgID) {
+				return
+			}
 		}
 	}
 }
@@ -907,6 +938,8 @@ func Routes() *web.Route {
 			m.Get("/version", misc.Version)
 			m.Get("/signing-key.gpg", misc.SigningKey)
 			m.Get("/signing-key.ssh", misc.SSHSigningKey)
+			m.Get("/ssh-certificate-authorities", misc.SSHCertificateAuthorities)
+			m.Get("/ssh-certificate-revocations", misc.SSHCertificateRevocations)
 			m.Post("/markup", reqToken(), bind(api.MarkupOption{}), misc.Markup)
 			m.Post("/markdown", reqToken(), bind(api.MarkdownOption{}), misc.Markdown)
 			m.Post("/markdown/raw", reqToken(), misc.MarkdownRaw)
@@ -1045,6 +1078,13 @@ func Routes() *web.Route {
 					Delete(user.DeletePublicKey)
 			})
 
+			// (admin:public_key scope)
+			m.Group("/ssh_certificates", func() {
+				m.Combo("").Get(user.ListMySSHCertificates).
+					Post(bind(api.CreateSSHCertificateOption{}), user.CreateSSHCertificate)
+				m.Delete("/{id}", user.RevokeSSHCertificate)
+			}, reqSSHCertificateAuthorityEnabled())
+
 			// (admin:application scope)
 			m.Group("/applications", func() {
 				m.Combo("/oauth2").
@@ -1107,6 +1147,18 @@ func Routes() *web.Route {
 			}, reqToken())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryUser), reqToken())
 
+		// Saved searches of issues (requires issue scope)
+		m.Group("/user/saved_searches", func() {
+			m.Combo("").Get(user.ListMySavedSearches).
+				Post(bind(api.CreateSavedSearchOption{}), user.CreateMySavedSearch)
+			m.Group("/{id}", func() {
+				m.Combo("").Get(user.GetSavedSearch).
+					Patch(bind(api.EditSavedSearchOption{}), user.EditSavedSearch).
+					Delete(user.DeleteSavedSearch)
+				m.Get("/issues", user.ListSavedSearchIssues)
+			})
+		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue), reqToken())
+
 		// Repositories (requires repo scope, org scope)
 		m.Post("/org/{org}/repos",
 			// FIXME: we need org in context
@@ -1304,6 +1356,15 @@ func Routes() *web.Route {
 						Delete(mustNotBeArchived, repo.DeletePushMirrorByRemoteName).
 						Get(repo.GetPushMirrorByName)
 				}, reqAdmin(), reqToken())
+				if setting.Terraform.Enabled {
+					m.Group("/terraform/state/{name}", func() {
+						m.Get("", repo.GetTerraformState)
+						m.Post("", mustNotBeArchived, context.EnforceQuotaAPI(quota_model.LimitSubjectSizeAssetsTerraformStates, context.QuotaTargetRepo), repo.UpdateTerraformState)
+						m.Delete("", mustNotBeArchived, repo.DeleteTerraformState)
+						m.Methods("LOCK", "", mustNotBeArchived, repo.LockTerraformState)
+						m.Methods("UNLOCK", "", repo.UnlockTerraformState)
+					}, reqToken(), reqRepoWriter(unit.TypeCode))
+				}
 
 				m.Get("/editorconfig/{filename}", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetEditorconfig)
 				m.Group("/pulls", func() {
@@ -1402,6 +1463,7 @@ func Routes() *web.Route {
 				m.Get("/issue_config", context.ReferencesGitRepo(), repo.GetIssueConfig)
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
+				m.Get("/sbom", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.GetSBOM)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
 				m.Group("/avatar", func() {
@@ -1517,6 +1579,13 @@ func Routes() *web.Route {
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
 							Delete(reqToken(), bind(api.IssueMeta{}), repo.RemoveIssueBlocking)
+						m.Combo("/sub_issues").
+							Get(repo.ListSubIssues).
+							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.CreateSubIssue).
+							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+						m.Get("/parent", repo.GetParentIssue)
+						m.Combo("/project").Get(repo.GetIssueProjectAttributes).
+							Patch(reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditIssueProjectAttributesOption{}), repo.EditIssueProjectAttributes)
 						m.Group("/pin", func() {
 							m.Combo("").
 								Post(reqToken(), reqAdmin(), repo.PinIssue).
@@ -1539,6 +1608,20 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Group("/projects/{id}", func() {
+					m.Group("/views", func() {
+						m.Combo("").Get(repo.ListProjectViews).
+							Post(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.CreateProjectViewOption{}), repo.CreateProjectView)
+						m.Combo("/{view_id}").Get(repo.GetProjectView).
+							Patch(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.EditProjectViewOption{}), repo.EditProjectView).
+							Delete(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, repo.DeleteProjectView)
+					})
+					m.Group("/fields", func() {
+						m.Combo("").Get(repo.ListProjectFields).
+							Post(reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, bind(api.CreateProjectFieldOption{}), repo.CreateProjectField)
+						m.Delete("/{field_id}", reqToken(), reqRepoWriter(unit.TypeProjects), mustNotBeArchived, repo.DeleteProjectField)
+					})
+				}, reqRepoReader(unit.TypeProjects))
 			}, repoAssignment(), checkTokenPublicOnly())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
 
@@ -1549,6 +1632,12 @@ func Routes() *web.Route {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Group("/attestations", func() {
+						m.Combo("").Get(packages.ListPackageAttestations).
+							Post(reqToken(), reqPackageAccess(perm.AccessModeWrite), bind(api.CreatePackageAttestationOption{}), packages.AddPackageAttestation)
+						m.Post("/actions", reqToken(), reqPackageAccess(perm.AccessModeWrite), bind(api.CreatePackageActionsProvenanceOption{}), packages.AddPackageActionsProvenance)
+						m.Delete("/{id}", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageAttestation)
+					})
 				})
 
 				m.Post("/-/link/{repo_name}", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.LinkPackage)
@@ -1601,6 +1690,22 @@ func Routes() *web.Route {
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Combo("/saved_searches", reqToken(), reqOrgMembership()).Get(org.ListSavedSearches).
+				Post(bind(api.CreateSavedSearchOption{}), org.CreateSavedSearch)
+			m.Group("/projects/{id}", func() {
+				m.Group("/views", func() {
+					m.Combo("").Get(org.ListProjectViews).
+						Post(reqToken(), bind(api.CreateProjectViewOption{}), org.CreateProjectView)
+					m.Combo("/{view_id}").Get(org.GetProjectView).
+						Patch(reqToken(), bind(api.EditProjectViewOption{}), org.EditProjectView).
+						Delete(reqToken(), org.DeleteProjectView)
+				})
+				m.Group("/fields", func() {
+					m.Combo("").Get(org.ListProjectFields).
+						Post(reqToken(), bind(api.CreateProjectFieldOption{}), org.CreateProjectField)
+					m.Delete("/{field_id}", reqToken(), org.DeleteProjectField)
+				})
+			})
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -0,0 +1,81 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package misc
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+// SSHCertificateAuthorities returns the public keys of the trusted SSH certificate authorities
+func SSHCertificateAuthorities(ctx *context.APIContext) {
+	// swagger:operation GET /ssh-certificate-authorities miscellaneous getSSHCertificateAuthorities
+	// ---
+	// summary: Get the public keys of the trusted SSH certificate authorities
+	// produces:
+	//     - text/plain
+	// responses:
+	//   "200":
+	//     description: "One SSH public key in OpenSSH authorized key format per line, the first one signs new certificates"
+	//     schema:
+	//       type: string
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.SSH.CertificateAuthorityEnabled {
+		ctx.NotFound()
+		return
+	}
+
+	authorities, err := asymkey_model.GetTrustedSSHCertificateAuthorities(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetTrustedSSHCertificateAuthorities", err)
+		return
+	}
+
+	sb := &strings.Builder{}
+	for _, ca := range authorities {
+		sb.WriteString(ca.PublicKey)
+		sb.WriteByte('\n')
+	}
+	ctx.PlainText(http.StatusOK, sb.String())
+}
+
+// SSHCertificateRevocations returns the serials of the revoked SSH certificates which have not expired yet
+func SSHCertificateRevocations(ctx *context.APIContext) {
+	// swagger:operation GET /ssh-certificate-revocations miscellaneous getSSHCertificateRevocations
+	// ---
+	// summary: Get the revoked SSH certificates which have not expired yet
+	// produces:
+	//     - text/plain
+	// responses:
+	//   "200":
+	//     description: "Key revocation list specification as understood by ssh-keygen -k"
+	//     schema:
+	//       type: string
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.SSH.CertificateAuthorityEnabled {
+		ctx.NotFound()
+		return
+	}
+
+	certs, err := asymkey_model.GetRevokedSSHCertificates(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRevokedSSHCertificates", err)
+		return
+	}
+
+	sb := &strings.Builder{}
+	for _, c := range certs {
+		fmt.Fprintf(sb, "serial: %d\n", c.ID)
+	}
+	ctx.PlainText(http.StatusOK, sb.String())
+}
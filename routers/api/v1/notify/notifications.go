@@ -41,9 +41,16 @@ func getFindNotificationOptions(ctx *context.APIContext) *activities_model.FindN
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
 		return nil
 	}
+	// the notifications of repositories which the IP allowlist of their organization blocks are hidden
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "BlockedRepoIDs", err)
+		return nil
+	}
 	opts := &activities_model.FindNotificationOptions{
 		ListOptions:       utils.GetListOptions(ctx),
 		UserID:            ctx.Doer.ID,
+		ExcludedRepoIDs:   blockedRepoIDs,
 		UpdatedBeforeUnix: before,
 		UpdatedAfterUnix:  since,
 	}
@@ -0,0 +1,352 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"net/http"
+
+	"forgejo.org/models/perm"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unit"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// getOrgProject returns the project of the organization identified by the id parameter,
+// after checking the doer has the given access to the projects of the organization
+func getOrgProject(ctx *context.APIContext, mode perm.AccessMode) *project_model.Project {
+	if ctx.Org.Organization.UnitPermission(ctx, ctx.Doer, unit.TypeProjects) < mode {
+		if mode > perm.AccessModeRead {
+			ctx.Error(http.StatusForbidden, "", "user should have write access to the projects of the organization")
+		} else {
+			ctx.NotFound()
+		}
+		return nil
+	}
+
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.Org.Organization.ID, nil) {
+		ctx.NotFound()
+		return nil
+	}
+	return project
+}
+
+// ListProjectViews list the saved views of an organization project
+func ListProjectViews(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/views organization orgListProjectViews
+	// ---
+	// summary: List the saved views of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+	shared.ListProjectViews(ctx, project)
+}
+
+// GetProjectView get a saved view of an organization project
+func GetProjectView(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/views/{view_id} organization orgGetProjectView
+	// ---
+	// summary: Get a saved view of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+	shared.GetProjectView(ctx, project)
+}
+
+// CreateProjectView create a saved view of an organization project
+func CreateProjectView(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/projects/{id}/views organization orgCreateProjectView
+	// ---
+	// summary: Create a saved view of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectViewOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getOrgProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	shared.CreateProjectView(ctx, project)
+}
+
+// EditProjectView edit a saved view of an organization project
+func EditProjectView(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/views/{view_id} organization orgEditProjectView
+	// ---
+	// summary: Edit a saved view of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectViewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getOrgProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	shared.EditProjectView(ctx, project)
+}
+
+// DeleteProjectView delete a saved view of an organization project
+func DeleteProjectView(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/views/{view_id} organization orgDeleteProjectView
+	// ---
+	// summary: Delete a saved view of a project
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	shared.DeleteProjectView(ctx, project)
+}
+
+// ListProjectFields list the custom fields of an organization project
+func ListProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/projects/{id}/fields organization orgListProjectFields
+	// ---
+	// summary: List the custom fields of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx, perm.AccessModeRead)
+	if ctx.Written() {
+		return
+	}
+	shared.ListProjectFields(ctx, project)
+}
+
+// CreateProjectField create a custom field of an organization project
+func CreateProjectField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/projects/{id}/fields organization orgCreateProjectField
+	// ---
+	// summary: Create a custom field of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getOrgProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	shared.CreateProjectField(ctx, project)
+}
+
+// DeleteProjectField delete a custom field of an organization project
+func DeleteProjectField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/fields/{field_id} organization orgDeleteProjectField
+	// ---
+	// summary: Delete a custom field of a project along with its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getOrgProject(ctx, perm.AccessModeWrite)
+	if ctx.Written() {
+		return
+	}
+	shared.DeleteProjectField(ctx, project)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// ListSavedSearches lists the saved searches an organization shares with the authenticated user
+func ListSavedSearches(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/saved_searches organization orgListSavedSearches
+	// ---
+	// summary: List the saved searches an organization shares with the authenticated user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearchList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListSavedSearches(ctx, ctx.Org.Organization.ID)
+}
+
+// CreateSavedSearch saves a search shared with the members of an organization or of one of its teams
+func CreateSavedSearch(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/saved_searches organization orgCreateSavedSearch
+	// ---
+	// summary: Save a search shared with the members of an organization or of one of its teams
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSavedSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateSavedSearch(ctx, ctx.Org.Organization.AsUser())
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/actions"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	packages_service "forgejo.org/services/packages"
+)
+
+func attestationError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.NotFound()
+	case errors.Is(err, packages_service.ErrInvalidSignature), errors.Is(err, util.ErrInvalidArgument):
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+	default:
+		ctx.InternalServerError(err)
+	}
+}
+
+// writeAttestation responds with the attestation with the given id
+func writeAttestation(ctx *context.APIContext, id int64) {
+	pads, err := packages_service.GetAttestationDescriptors(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	for _, pad := range pads {
+		if pad.Attestation.ID == id {
+			ctx.JSON(http.StatusCreated, convert.ToPackageAttestation(ctx, pad, ctx.Doer))
+			return
+		}
+	}
+	ctx.NotFound()
+}
+
+// ListPackageAttestations gets the signatures and provenance statements of a package
+func ListPackageAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations package listPackageAttestations
+	// ---
+	// summary: Gets the signatures and provenance statements of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageAttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pads, err := packages_service.GetAttestationDescriptors(ctx, ctx.Package.Descriptor)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiAttestations := make([]*api.PackageAttestation, 0, len(pads))
+	for _, pad := range pads {
+		apiAttestations = append(apiAttestations, convert.ToPackageAttestation(ctx, pad, ctx.Doer))
+	}
+
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
+
+// AddPackageAttestation adds a signature or a provenance statement to a package
+func AddPackageAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/attestations package addPackageAttestation
+	// ---
+	// summary: Add a signature of a file or a provenance statement to a package
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageAttestationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreatePackageAttestationOption)
+	pd := ctx.Package.Descriptor
+
+	var pa *packages_model.PackageAttestation
+	var err error
+	if form.Type == "provenance" {
+		pa, err = packages_service.AddProvenance(ctx, ctx.Doer, pd.Version, []byte(form.Content))
+	} else {
+		var pf *packages_model.PackageFile
+		for _, pfd := range pd.Files {
+			if pfd.File.Name == form.File {
+				pf = pfd.File
+				break
+			}
+		}
+		if pf == nil {
+			ctx.NotFound()
+			return
+		}
+		pa, err = packages_service.AddSignature(ctx, ctx.Doer, pf, []byte(form.Content))
+	}
+	if err != nil {
+		attestationError(ctx, err)
+		return
+	}
+
+	writeAttestation(ctx, pa.ID)
+}
+
+// AddPackageActionsProvenance generates the provenance of a package built by an Actions run
+func AddPackageActionsProvenance(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/attestations/actions package addPackageActionsProvenance
+	// ---
+	// summary: Generate the provenance of a package built by an Actions run from an ID token of the run
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageActionsProvenanceOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageAttestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreatePackageActionsProvenanceOption)
+
+	// the default audience of the ID tokens of a run is the owner of its repository
+	claims, err := actions.VerifyIDToken(form.IDToken, setting.AppURL+ctx.Package.Owner.Name)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+		return
+	}
+	runID, err := strconv.ParseInt(claims.RunID, 10, 64)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+		return
+	}
+
+	pa, err := packages_service.AddActionsProvenance(ctx, ctx.Doer, ctx.Package.Owner, ctx.Package.Descriptor.Version, &packages_service.ActionsBuild{
+		Repository:  claims.Repository,
+		RunID:       runID,
+		RunAttempt:  claims.RunAttempt,
+		EventName:   claims.EventName,
+		Ref:         claims.Ref,
+		Sha:         claims.Sha,
+		WorkflowRef: claims.WorkflowRef,
+	})
+	if err != nil {
+		attestationError(ctx, err)
+		return
+	}
+
+	writeAttestation(ctx, pa.ID)
+}
+
+// DeletePackageAttestation deletes a signature or a provenance statement of a package
+func DeletePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/attestations/{id} package deletePackageAttestation
+	// ---
+	// summary: Delete a signature or a provenance statement of a package
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pa, err := packages_model.GetAttestationByID(ctx, ctx.Package.Descriptor.Version.ID, ctx.ParamsInt64("id"))
+	if err != nil {
+		attestationError(ctx, err)
+		return
+	}
+
+	if err := packages_model.DeleteAttestationByID(ctx, pa.ID); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	"forgejo.org/models/organization"
@@ -20,6 +21,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	pull_service "forgejo.org/services/pull"
@@ -703,6 +705,7 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoBranchProtectionCreate, audit_service.BranchProtectionTarget(repo, protectBranch), nil, audit_service.BranchProtectionState(protectBranch))
 
 	if isBranchExist {
 		if err = pull_service.CheckPRsForBaseBranch(ctx, ctx.Repo.Repository, ruleName); err != nil {
@@ -802,6 +805,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.NotFound()
 		return
 	}
+	before := audit_service.BranchProtectionState(protectBranch)
 
 	if form.EnablePush != nil {
 		if !*form.EnablePush {
@@ -978,6 +982,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoBranchProtectionUpdate, audit_service.BranchProtectionTarget(repo, protectBranch), before, audit_service.BranchProtectionState(protectBranch))
 
 	isPlainRule := !git_model.IsRuleNameSpecial(bpName)
 	var isBranchExist bool
@@ -1079,6 +1084,7 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteProtectedBranch", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoBranchProtectionDelete, audit_service.BranchProtectionTarget(repo, bp), audit_service.BranchProtectionState(bp), nil)
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -17,6 +18,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	repo_service "forgejo.org/services/repository"
@@ -184,6 +186,12 @@ func AddCollaborator(ctx *context.APIContext) {
 		return
 	}
 
+	isCollaborator, err := repo_model.IsCollaborator(ctx, ctx.Repo.Repository.ID, collaborator.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsCollaborator", err)
+		return
+	}
+
 	if err := repo_module.AddCollaborator(ctx, ctx.Repo.Repository, collaborator); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "AddCollaborator", err)
@@ -192,9 +200,12 @@ func AddCollaborator(ctx *context.APIContext) {
 		}
 		return
 	}
+	if !isCollaborator {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoCollaboratorAdd, audit_service.CollaboratorTarget(ctx.Repo.Repository, collaborator), nil, audit_service.AccessModeState(perm.AccessModeWrite))
+	}
 
 	if form.Permission != nil {
-		if err := repo_model.ChangeCollaborationAccessMode(ctx, ctx.Repo.Repository, collaborator.ID, perm.ParseAccessMode(*form.Permission)); err != nil {
+		if err := repo_service.ChangeCollaborationAccessMode(ctx, ctx.Doer, ctx.Repo.Repository, collaborator, perm.ParseAccessMode(*form.Permission)); err != nil {
 			ctx.Error(http.StatusInternalServerError, "ChangeCollaborationAccessMode", err)
 			return
 		}
@@ -244,7 +255,7 @@ func DeleteCollaborator(ctx *context.APIContext) {
 		return
 	}
 
-	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, collaborator.ID); err != nil {
+	if err := repo_service.DeleteCollaboration(ctx, ctx.Doer, ctx.Repo.Repository, collaborator.ID); err != nil {
 		ctx.Error(http.StatusInternalServerError, "DeleteCollaboration", err)
 		return
 	}
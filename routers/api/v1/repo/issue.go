@@ -24,6 +24,7 @@ import (
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -102,6 +103,10 @@ func SearchIssues(ctx *context.APIContext) {
 	//   description: Filter pull requests reviewed by the authenticated user
 	//   type: boolean
 	//   default: false
+	// - name: has_parent
+	//   in: query
+	//   description: Filter issues by whether they are a sub-issue of another issue
+	//   type: boolean
 	// - name: owner
 	//   in: query
 	//   description: Filter by repository owner
@@ -218,6 +223,12 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "BlockedRepoIDs", err)
+		return
+	}
+
 	keyword := ctx.FormTrim("q")
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
@@ -277,6 +288,7 @@ func SearchIssues(ctx *context.APIContext) {
 		},
 		RepoIDs:             repoIDs,
 		AllPublic:           allPublic,
+		ExcludedRepoIDs:     blockedRepoIDs,
 		IsPull:              isPull,
 		IsClosed:            isClosed,
 		IncludedAnyLabelIDs: includedAnyLabels,
@@ -285,7 +297,11 @@ func SearchIssues(ctx *context.APIContext) {
 	}
 
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
-		ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "WithKeyword", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
+		}
 		return
 	}
 
@@ -315,6 +331,8 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	searchOpt.HasParent = ctx.FormOptionalBool("has_parent")
+
 	priorityRepoID := ctx.FormInt64("priority_repo_id")
 	if priorityRepoID > 0 {
 		searchOpt.PriorityRepoID = optional.Some(priorityRepoID)
@@ -400,6 +418,15 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: has_parent
+	//   in: query
+	//   description: Filter issues by whether they are a sub-issue of another issue
+	//   type: boolean
+	// - name: parent
+	//   in: query
+	//   description: Only show the sub-issues of the issue with this index
+	//   type: integer
+	//   format: int64
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -419,6 +446,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -537,7 +566,11 @@ func ListIssues(ctx *context.APIContext) {
 		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
-		ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "WithKeyword", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
+		}
 		return
 	}
 	if since != 0 {
@@ -574,6 +607,20 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	searchOpt.HasParent = ctx.FormOptionalBool("has_parent")
+	if parentIndex := ctx.FormInt64("parent"); parentIndex > 0 {
+		parent, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, parentIndex)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetIssueByIndex", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+			}
+			return
+		}
+		searchOpt.ParentID = optional.Some(parent.ID)
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
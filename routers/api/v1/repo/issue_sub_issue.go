@@ -0,0 +1,313 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListSubIssues list the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the sub-issues of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueListWithoutPagination"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	subIssuesInfo, err := issue.SubIssues(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "SubIssues", err)
+		return
+	}
+
+	// Sub-issues may live in other repositories of the owner, only list those the doer can read.
+	repoPerms := make(map[int64]access_model.Permission)
+	repoPerms[ctx.Repo.Repository.ID] = ctx.Repo.Permission
+	subIssues := make([]*issues_model.Issue, 0, len(subIssuesInfo))
+	for _, subIssue := range subIssuesInfo {
+		perm, ok := repoPerms[subIssue.RepoID]
+		if !ok {
+			perm, err = access_model.GetUserRepoPermission(ctx, &subIssue.Repository, ctx.Doer)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetUserRepoPermission", err)
+				return
+			}
+			repoPerms[subIssue.RepoID] = perm
+		}
+		if !perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+			continue
+		}
+		subIssues = append(subIssues, &subIssue.Issue)
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, subIssues))
+}
+
+// CreateSubIssue make an issue a sub-issue of another one
+func CreateSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueCreateSubIssue
+	// ---
+	// summary: Make the issue in the form a sub-issue of the issue in the url.
+	// description: The sub-issue may belong to any repository of the same owner.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: the issue already has a parent
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueRelation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueExists(err):
+			ctx.Error(http.StatusConflict, "AddSubIssue", err)
+		case issues_model.IsErrCircularSubIssue(err), issues_model.IsErrSubIssueNotSameOwner(err):
+			ctx.Error(http.StatusUnprocessableEntity, "AddSubIssue", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "AddSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// RemoveSubIssue detach a sub-issue from its parent
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Detach the issue in the form from the issue in the url.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueRelation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueNotExists(err) {
+			ctx.NotFound("IsErrSubIssueNotExists", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RemoveSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// GetParentIssue get the parent of an issue
+func GetParentIssue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/parent issue issueGetParentIssue
+	// ---
+	// summary: Get the parent of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	parent, err := issue.GetParentIssue(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetParentIssue", err)
+		return
+	}
+	if parent == nil {
+		ctx.NotFound()
+		return
+	}
+
+	parentPerm := getPermissionForRepo(ctx, parent.Repo)
+	if ctx.Written() {
+		return
+	}
+	if !parentPerm.CanReadIssuesOrPulls(parent.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, parent))
+}
+
+// getSubIssueRelation returns the parent issue from the url and the sub-issue
+// from the form after checking that the doer may change the relation.
+func getSubIssueRelation(ctx *context.APIContext) (parent, subIssue *issues_model.Issue) {
+	parent = getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+
+	if ctx.Repo.Repository.IsArchived || !ctx.Repo.CanWriteIssuesOrPulls(parent.IsPull) {
+		ctx.NotFound()
+		return nil, nil
+	}
+
+	form := web.GetForm(ctx).(*api.IssueMeta)
+	repo := ctx.Repo.Repository
+	if form.Owner != ctx.Repo.Repository.OwnerName || form.Name != ctx.Repo.Repository.Name {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, form.Owner, form.Name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("IsErrRepoNotExist", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return nil, nil
+		}
+	}
+
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, form.Index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("IsErrIssueNotExist", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil, nil
+	}
+	subIssue.Repo = repo
+
+	subIssuePerm := getPermissionForRepo(ctx, repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !subIssuePerm.CanReadIssuesOrPulls(subIssue.IsPull) {
+		ctx.NotFound()
+		return nil, nil
+	}
+
+	return parent, subIssue
+}
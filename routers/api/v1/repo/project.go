@@ -0,0 +1,591 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	stdCtx "context"
+	"errors"
+	"net/http"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/services/context"
+)
+
+// getRepoProject returns the project of the repository identified by the id parameter
+func getRepoProject(ctx *context.APIContext) *project_model.Project {
+	project, err := project_model.GetProjectForRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if project_model.IsErrProjectNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil
+	}
+	return project
+}
+
+// ListProjectViews list the saved views of a repository project
+func ListProjectViews(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/views repository repoListProjectViews
+	// ---
+	// summary: List the saved views of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectViewList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.ListProjectViews(ctx, project)
+}
+
+// GetProjectView get a saved view of a repository project
+func GetProjectView(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoGetProjectView
+	// ---
+	// summary: Get a saved view of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.GetProjectView(ctx, project)
+}
+
+// CreateProjectView create a saved view of a repository project
+func CreateProjectView(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/views repository repoCreateProjectView
+	// ---
+	// summary: Create a saved view of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectViewOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.CreateProjectView(ctx, project)
+}
+
+// EditProjectView edit a saved view of a repository project
+func EditProjectView(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoEditProjectView
+	// ---
+	// summary: Edit a saved view of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectViewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectView"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.EditProjectView(ctx, project)
+}
+
+// DeleteProjectView delete a saved view of a repository project
+func DeleteProjectView(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/views/{view_id} repository repoDeleteProjectView
+	// ---
+	// summary: Delete a saved view of a project
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: view_id
+	//   in: path
+	//   description: id of the view
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.DeleteProjectView(ctx, project)
+}
+
+// ListProjectFields list the custom fields of a repository project
+func ListProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/fields repository repoListProjectFields
+	// ---
+	// summary: List the custom fields of a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.ListProjectFields(ctx, project)
+}
+
+// CreateProjectField create a custom field of a repository project
+func CreateProjectField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/fields repository repoCreateProjectField
+	// ---
+	// summary: Create a custom field of a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.CreateProjectField(ctx, project)
+}
+
+// DeleteProjectField delete a custom field of a repository project
+func DeleteProjectField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoDeleteProjectField
+	// ---
+	// summary: Delete a custom field of a project along with its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := getRepoProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	shared.DeleteProjectField(ctx, project)
+}
+
+// GetIssueProjectAttributes get the roadmap dates and custom field values of an issue
+func GetIssueProjectAttributes(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/project issue issueGetProjectAttributes
+	// ---
+	// summary: Get the roadmap dates and custom field values of an issue in its project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueProjectAttributes"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, project := getIssueProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondIssueProjectAttributes(ctx, issue, project)
+}
+
+// EditIssueProjectAttributes edit the roadmap dates and custom field values of an issue
+func EditIssueProjectAttributes(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/project issue issueEditProjectAttributes
+	// ---
+	// summary: Edit the roadmap dates and custom field values of an issue in its project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueProjectAttributesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueProjectAttributes"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueProjectAttributesOption)
+
+	issue, project := getIssueProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	projectIssues, err := project.GetProjectIssues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	var start, target timeutil.TimeStamp
+	if projectIssue, ok := projectIssues[issue.ID]; ok {
+		start, target = projectIssue.StartDateUnix, projectIssue.TargetDateUnix
+	}
+	if form.StartDate != nil {
+		if start, err = parseProjectDate(*form.StartDate); err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "StartDate", err)
+			return
+		}
+	}
+	if form.TargetDate != nil {
+		if target, err = parseProjectDate(*form.TargetDate); err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "TargetDate", err)
+			return
+		}
+	}
+
+	fields := make([]*project_model.Field, len(form.Fields))
+	for i, value := range form.Fields {
+		field, err := project_model.GetFieldByID(ctx, project.ID, value.FieldID)
+		if err != nil {
+			if project_model.IsErrProjectFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetFieldByID", err)
+			} else {
+				ctx.InternalServerError(err)
+			}
+			return
+		}
+		if err := field.ValidateValue(value.Value); err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "ValidateValue", err)
+			return
+		}
+		fields[i] = field
+	}
+
+	if err := db.WithTx(ctx, func(ctx stdCtx.Context) error {
+		if err := project_model.SetIssueDates(ctx, project.ID, issue.ID, start, target); err != nil {
+			return err
+		}
+		for i, value := range form.Fields {
+			if err := project_model.SetFieldValue(ctx, fields[i], issue.ID, value.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetIssueDates", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	respondIssueProjectAttributes(ctx, issue, project)
+}
+
+// getIssueProject returns the issue identified by the index parameter and the project it belongs to
+func getIssueProject(ctx *context.APIContext) (*issues_model.Issue, *project_model.Project) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil, nil
+	}
+	if err := issue.LoadProject(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return nil, nil
+	}
+	if issue.Project == nil {
+		ctx.NotFound()
+		return nil, nil
+	}
+	return issue, issue.Project
+}
+
+func respondIssueProjectAttributes(ctx *context.APIContext, issue *issues_model.Issue, project *project_model.Project) {
+	projectIssues, err := project.GetProjectIssues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	values, err := project.GetFieldValues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	attrs := &api.IssueProjectAttributes{
+		ProjectID: project.ID,
+		Fields:    make([]*api.ProjectFieldValue, 0, len(values[issue.ID])),
+	}
+	if projectIssue, ok := projectIssues[issue.ID]; ok {
+		if projectIssue.StartDateUnix > 0 {
+			attrs.StartDate = projectIssue.StartDateUnix.FormatDate()
+		}
+		if projectIssue.TargetDateUnix > 0 {
+			attrs.TargetDate = projectIssue.TargetDateUnix.FormatDate()
+		}
+	}
+	for _, field := range fields {
+		if value, ok := values[issue.ID][field.ID]; ok {
+			attrs.Fields = append(attrs.Fields, &api.ProjectFieldValue{FieldID: field.ID, Value: value})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, attrs)
+}
+
+// parseProjectDate parses a date formatted as YYYY-MM-DD, an empty string is the zero timestamp
+func parseProjectDate(date string) (timeutil.TimeStamp, error) {
+	if date == "" {
+		return 0, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+	if err != nil {
+		return 0, util.NewInvalidArgumentErrorf("%q is not a date formatted as YYYY-MM-DD", date)
+	}
+	return timeutil.TimeStamp(t.Unix()), nil
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	activities_model "forgejo.org/models/activities"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -32,6 +33,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	actions_service "forgejo.org/services/actions"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/issue"
@@ -816,6 +818,9 @@ func updateBasicProperties(ctx *context.APIContext, opts api.EditRepoOption) err
 		ctx.Error(http.StatusInternalServerError, "UpdateRepository", err)
 		return err
 	}
+	if visibilityChanged {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoVisibility, audit_service.RepoTarget(repo), audit_service.VisibilityState(!repo.IsPrivate), audit_service.VisibilityState(repo.IsPrivate))
+	}
 
 	log.Trace("Repository basic settings updated: %s/%s", owner.Name, repo.Name)
 	return nil
@@ -865,6 +870,7 @@ func updateRepoUnits(ctx *context.APIContext, owner string, repo *repo_model.Rep
 					EnableTimetracker:                opts.InternalTracker.EnableTimeTracker,
 					AllowOnlyContributorsToTrackTime: opts.InternalTracker.AllowOnlyContributorsToTrackTime,
 					EnableDependencies:               opts.InternalTracker.EnableIssueDependencies,
+					CloseParentWithSubIssues:         opts.InternalTracker.CloseParentWithSubIssues,
 				}
 			} else if unit, err := repo.GetUnit(ctx, unit_model.TypeIssues); err != nil {
 				// Unit type doesn't exist so we make a new config file with default values
@@ -0,0 +1,97 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/sbom"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	sbom_service "forgejo.org/services/sbom"
+)
+
+// GetSBOM returns the software bill of materials of a repository at a ref
+func GetSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/sbom repository repoGetSBOM
+	// ---
+	// summary: Get a software bill of materials of the dependencies pinned by the lockfiles of a repository
+	// produces:
+	//   - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "branch, tag or commit sha (default: the default branch)"
+	//   type: string
+	//   required: false
+	// - name: format
+	//   in: query
+	//   description: "format of the document (default: cyclonedx)"
+	//   type: string
+	//   enum: [cyclonedx, spdx]
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "a CycloneDX 1.5 or a SPDX 2.3 JSON document"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	format := sbom.FormatCycloneDX
+	if f := ctx.FormTrim("format"); f != "" {
+		var err error
+		if format, err = sbom.ParseFormat(f); err != nil {
+			ctx.Error(http.StatusBadRequest, "ParseFormat", err)
+			return
+		}
+	}
+
+	ref := ctx.FormTrim("ref")
+	if ref == "" {
+		ref = ctx.Repo.Repository.DefaultBranch
+	}
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.NotFound()
+		return
+	}
+	sha := utils.ResolveRefOrSha(ctx, ref)
+	if ctx.Written() {
+		return
+	}
+
+	commit, err := ctx.Repo.GitRepo.GetCommit(sha)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		}
+		return
+	}
+
+	source, err := sbom_service.GetSource(ctx.Repo.Repository, ctx.Repo.GitRepo, commit, ref)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSource", err)
+		return
+	}
+
+	doc, err := sbom.New(format, source)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "New", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	terraform_model "forgejo.org/models/terraform"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	terraform_service "forgejo.org/services/terraform"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// the http backend of Terraform uses these methods to acquire and release state locks
+	chi.RegisterMethod("LOCK")
+	chi.RegisterMethod("UNLOCK")
+}
+
+func terraformStateError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.NotFound()
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.Error(http.StatusBadRequest, "", err)
+	case errors.Is(err, terraform_service.ErrStateTooLarge):
+		ctx.Error(http.StatusRequestEntityTooLarge, "", err)
+	default:
+		ctx.InternalServerError(err)
+	}
+}
+
+// writeTerraformLockInfo responds with the information of the current lock, Terraform shows it to the user
+func writeTerraformLockInfo(ctx *context.APIContext, info *terraform_model.LockInfo) {
+	ctx.JSON(http.StatusLocked, info)
+}
+
+func readTerraformLockInfo(ctx *context.APIContext) (*terraform_model.LockInfo, bool) {
+	info := &terraform_model.LockInfo{}
+	if err := json.NewDecoder(io.LimitReader(ctx.Req.Body, 64*1024)).Decode(info); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, true
+		}
+		ctx.Error(http.StatusBadRequest, "", err)
+		return nil, false
+	}
+	return info, true
+}
+
+// GetTerraformState returns the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name} repository repoGetTerraformState
+	// ---
+	// summary: Get the latest version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: Returns the Terraform state.
+	//     schema:
+	//       type: file
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	content, _, err := terraform_service.ReadState(ctx, ctx.Repo.Repository, ctx.Params("name"))
+	if err != nil {
+		terraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(content)
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/state/{name} repository repoUpdateTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: id of the lock held by the client
+	//   type: string
+	// - name: body
+	//   in: body
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/quotaExceeded"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	var r io.Reader = ctx.Req.Body
+	if setting.Terraform.LimitStateSize > -1 {
+		r = io.LimitReader(r, setting.Terraform.LimitStateSize+1)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	_, err = terraform_service.WriteState(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Params("name"), ctx.FormString("ID"), content)
+	if err != nil {
+		if errors.Is(err, terraform_service.ErrStateLocked) {
+			ctx.Error(http.StatusLocked, "", err)
+			return
+		}
+		terraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/state/{name} repository repoDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state with all its versions
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	s, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.Params("name"))
+	if err != nil {
+		terraformStateError(ctx, err)
+		return
+	}
+	if s.IsLocked() {
+		ctx.Error(http.StatusLocked, "", terraform_service.ErrStateLocked)
+		return
+	}
+
+	if err := terraform_service.DeleteState(ctx, s); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// LockTerraformState acquires the lock of a Terraform state
+func LockTerraformState(ctx *context.APIContext) {
+	info, ok := readTerraformLockInfo(ctx)
+	if !ok {
+		return
+	}
+	if info == nil {
+		ctx.Error(http.StatusBadRequest, "", terraform_service.ErrInvalidLockInfo)
+		return
+	}
+
+	current, err := terraform_service.LockState(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Params("name"), info)
+	if err != nil {
+		if errors.Is(err, terraform_service.ErrStateLocked) {
+			writeTerraformLockInfo(ctx, current)
+			return
+		}
+		terraformStateError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, current)
+}
+
+// UnlockTerraformState releases the lock of a Terraform state. Without lock information,
+// which is what `terraform force-unlock` sends, the lock is released regardless of its holder.
+func UnlockTerraformState(ctx *context.APIContext) {
+	info, ok := readTerraformLockInfo(ctx)
+	if !ok {
+		return
+	}
+
+	if info == nil {
+		s, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.Params("name"))
+		if err != nil {
+			terraformStateError(ctx, err)
+			return
+		}
+		if err := terraform_service.ForceUnlockState(ctx, s); err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		ctx.Status(http.StatusOK)
+		return
+	}
+
+	current, err := terraform_service.UnlockState(ctx, ctx.Repo.Repository, ctx.Params("name"), info)
+	if err != nil {
+		if errors.Is(err, terraform_service.ErrStateLocked) {
+			writeTerraformLockInfo(ctx, current)
+			return
+		}
+		terraformStateError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListProjectViews responds with the saved views of the project
+func ListProjectViews(ctx *context.APIContext, project *project_model.Project) {
+	views, err := project.GetViews(ctx)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(int64(len(views)))
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectViewList(views))
+}
+
+// GetProjectView responds with the saved view of the project identified by the view_id parameter
+func GetProjectView(ctx *context.APIContext, project *project_model.Project) {
+	view := getProjectView(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectView(view))
+}
+
+// CreateProjectView creates a saved view of the project
+func CreateProjectView(ctx *context.APIContext, project *project_model.Project) {
+	form := web.GetForm(ctx).(*api.CreateProjectViewOption)
+
+	view := &project_model.View{
+		ProjectID: project.ID,
+		CreatorID: ctx.Doer.ID,
+		Title:     form.Title,
+		Type:      project_model.ViewTypeTable,
+		Filter:    form.Filter,
+		GroupBy:   project_model.ViewGroupBy(form.GroupBy),
+		SortBy:    form.SortBy,
+		SortDesc:  form.SortDesc,
+		Fields:    form.Fields,
+	}
+	if form.Type != "" {
+		view.Type = project_model.ViewTypeFromName(form.Type)
+	}
+	if !checkProjectViewFields(ctx, project, view.Fields) {
+		return
+	}
+
+	if err := project_model.NewView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewView", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIProjectView(view))
+}
+
+// EditProjectView updates the saved view of the project identified by the view_id parameter
+func EditProjectView(ctx *context.APIContext, project *project_model.Project) {
+	form := web.GetForm(ctx).(*api.EditProjectViewOption)
+
+	view := getProjectView(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Title != nil {
+		view.Title = *form.Title
+	}
+	if form.Type != nil {
+		view.Type = project_model.ViewTypeFromName(*form.Type)
+	}
+	if form.Filter != nil {
+		view.Filter = *form.Filter
+	}
+	if form.GroupBy != nil {
+		view.GroupBy = project_model.ViewGroupBy(*form.GroupBy)
+	}
+	if form.SortBy != nil {
+		view.SortBy = *form.SortBy
+	}
+	if form.SortDesc != nil {
+		view.SortDesc = *form.SortDesc
+	}
+	if form.Fields != nil {
+		view.Fields = *form.Fields
+		if !checkProjectViewFields(ctx, project, view.Fields) {
+			return
+		}
+	}
+
+	if err := project_model.UpdateView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateView", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectView(view))
+}
+
+// DeleteProjectView deletes the saved view of the project identified by the view_id parameter
+func DeleteProjectView(ctx *context.APIContext, project *project_model.Project) {
+	if err := project_model.DeleteViewByID(ctx, project.ID, ctx.ParamsInt64(":view_id")); err != nil {
+		if project_model.IsErrProjectViewNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListProjectFields responds with the custom fields of the project
+func ListProjectFields(ctx *context.APIContext, project *project_model.Project) {
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(int64(len(fields)))
+	ctx.JSON(http.StatusOK, convert.ToAPIProjectFieldList(fields))
+}
+
+// CreateProjectField creates a custom field of the project
+func CreateProjectField(ctx *context.APIContext, project *project_model.Project) {
+	form := web.GetForm(ctx).(*api.CreateProjectFieldOption)
+
+	field := &project_model.Field{
+		ProjectID: project.ID,
+		CreatorID: ctx.Doer.ID,
+		Name:      form.Name,
+		Type:      project_model.FieldTypeText,
+	}
+	if form.Type != "" {
+		field.Type = project_model.FieldTypeFromName(form.Type)
+	}
+
+	if err := project_model.NewField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewField", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIProjectField(field))
+}
+
+// DeleteProjectField deletes the custom field of the project identified by the field_id parameter
+func DeleteProjectField(ctx *context.APIContext, project *project_model.Project) {
+	if err := project_model.DeleteFieldByID(ctx, project.ID, ctx.ParamsInt64(":field_id")); err != nil {
+		if project_model.IsErrProjectFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func getProjectView(ctx *context.APIContext, project *project_model.Project) *project_model.View {
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.ParamsInt64(":view_id"))
+	if err != nil {
+		if project_model.IsErrProjectViewNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil
+	}
+	return view
+}
+
+// checkProjectViewFields makes sure the fields shown by a view belong to its project
+func checkProjectViewFields(ctx *context.APIContext, project *project_model.Project, fieldIDs []int64) bool {
+	for _, fieldID := range fieldIDs {
+		if _, err := project_model.GetFieldByID(ctx, project.ID, fieldID); err != nil {
+			if project_model.IsErrProjectFieldNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetFieldByID", err)
+			} else {
+				ctx.InternalServerError(err)
+			}
+			return false
+		}
+	}
+	return true
+}
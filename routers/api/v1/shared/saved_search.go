@@ -0,0 +1,114 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListSavedSearches responds with the saved searches the doer can see, only those of the owner if not zero
+func ListSavedSearches(ctx *context.APIContext, ownerID int64) {
+	searches, total, err := db.FindAndCount[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Doer:        ctx.Doer,
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiSearches := make([]*api.SavedSearch, 0, len(searches))
+	for _, search := range searches {
+		if err := search.LoadAttributes(ctx); err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		apiSearches = append(apiSearches, convert.ToAPISavedSearch(ctx, ctx.Doer, search))
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiSearches)
+}
+
+// CreateSavedSearch saves a search of the issues or pull requests of the owner,
+// the doer or an organization the doer is a member of
+func CreateSavedSearch(ctx *context.APIContext, owner *user_model.User) {
+	form := web.GetForm(ctx).(*api.CreateSavedSearchOption)
+
+	search := &issues_model.SavedSearch{
+		OwnerID:   owner.ID,
+		Owner:     owner,
+		CreatorID: ctx.Doer.ID,
+		Name:      form.Name,
+		IsPull:    form.IsPull,
+		IsPinned:  form.Pinned,
+		Keyword:   form.Query,
+		ViewType:  form.Type,
+		State:     form.State,
+		SortType:  form.Sort,
+	}
+	search.SetLabelIDs(form.Labels)
+
+	if form.TeamID > 0 {
+		if !owner.IsOrganization() {
+			ctx.Error(http.StatusUnprocessableEntity, "TeamID", "only the searches of an organization can be shared with a team")
+			return
+		}
+		team, err := organization.GetTeamByID(ctx, form.TeamID)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "GetTeamByID", err)
+			} else {
+				ctx.InternalServerError(err)
+			}
+			return
+		}
+		if team.OrgID != owner.ID {
+			ctx.Error(http.StatusUnprocessableEntity, "TeamID", "the team does not belong to the organization")
+			return
+		}
+		isMember, err := organization.IsTeamMember(ctx, owner.ID, team.ID, ctx.Doer.ID)
+		if err != nil {
+			ctx.InternalServerError(err)
+			return
+		}
+		if !isMember {
+			isOwner, err := organization.IsOrganizationOwner(ctx, owner.ID, ctx.Doer.ID)
+			if err != nil {
+				ctx.InternalServerError(err)
+				return
+			}
+			if !isOwner {
+				ctx.Error(http.StatusForbidden, "TeamID", "only the members of the team and the owners of the organization can share a search with it")
+				return
+			}
+		}
+		search.TeamID = team.ID
+		search.Team = team
+	}
+
+	if err := issues_model.CreateSavedSearch(ctx, search); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "CreateSavedSearch", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPISavedSearch(ctx, ctx.Doer, search))
+}
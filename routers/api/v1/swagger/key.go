@@ -21,6 +21,20 @@ type swaggerResponsePublicKeyList struct {
 	Body []api.PublicKey `json:"body"`
 }
 
+// SSHCertificate
+// swagger:response SSHCertificate
+type swaggerResponseSSHCertificate struct {
+	// in:body
+	Body api.SSHCertificate `json:"body"`
+}
+
+// SSHCertificateList
+// swagger:response SSHCertificateList
+type swaggerResponseSSHCertificateList struct {
+	// in:body
+	Body []api.SSHCertificate `json:"body"`
+}
+
 // GPGKey
 // swagger:response GPGKey
 type swaggerResponseGPGKey struct {
@@ -61,6 +61,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateKeyOption api.CreateKeyOption
 
+	// in:body
+	CreateSSHCertificateOption api.CreateSSHCertificateOption
+
 	// in:body
 	RenameUserOption api.RenameUserOption
 
@@ -242,4 +245,28 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	RegisterRunnerOptions api.RegisterRunnerOptions
+
+	// in:body
+	CreateProjectViewOption api.CreateProjectViewOption
+
+	// in:body
+	EditProjectViewOption api.EditProjectViewOption
+
+	// in:body
+	CreateProjectFieldOption api.CreateProjectFieldOption
+
+	// in:body
+	EditIssueProjectAttributesOption api.EditIssueProjectAttributesOption
+
+	// in:body
+	CreateSavedSearchOption api.CreateSavedSearchOption
+
+	// in:body
+	EditSavedSearchOption api.EditSavedSearchOption
+
+	// in:body
+	CreatePackageAttestationOption api.CreatePackageAttestationOption
+
+	// in:body
+	CreatePackageActionsProvenanceOption api.CreatePackageActionsProvenanceOption
 }
@@ -0,0 +1,49 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package swagger
+
+import (
+	api "forgejo.org/modules/structs"
+)
+
+// ProjectView
+// swagger:response ProjectView
+type swaggerResponseProjectView struct {
+	// in:body
+	Body api.ProjectView `json:"body"`
+}
+
+// ProjectViewList
+// swagger:response ProjectViewList
+type swaggerResponseProjectViewList struct {
+	// in:body
+	Body []api.ProjectView `json:"body"`
+
+	// The total number of views
+	TotalCount int64 `json:"X-Total-Count"`
+}
+
+// ProjectField
+// swagger:response ProjectField
+type swaggerResponseProjectField struct {
+	// in:body
+	Body api.ProjectField `json:"body"`
+}
+
+// ProjectFieldList
+// swagger:response ProjectFieldList
+type swaggerResponseProjectFieldList struct {
+	// in:body
+	Body []api.ProjectField `json:"body"`
+
+	// The total number of fields
+	TotalCount int64 `json:"X-Total-Count"`
+}
+
+// IssueProjectAttributes
+// swagger:response IssueProjectAttributes
+type swaggerResponseIssueProjectAttributes struct {
+	// in:body
+	Body api.IssueProjectAttributes `json:"body"`
+}
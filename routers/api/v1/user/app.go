@@ -13,9 +13,12 @@ import (
 
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 )
@@ -59,11 +62,9 @@ func ListAccessTokens(ctx *context.APIContext) {
 
 	apiTokens := make([]*api.AccessToken, len(tokens))
 	for i := range tokens {
-		apiTokens[i] = &api.AccessToken{
-			ID:             tokens[i].ID,
-			Name:           tokens[i].Name,
-			TokenLastEight: tokens[i].TokenLastEight,
-			Scopes:         tokens[i].Scope.StringSlice(),
+		if apiTokens[i], err = convert.ToAccessToken(ctx, tokens[i]); err != nil {
+			ctx.InternalServerError(err)
+			return
 		}
 	}
 
@@ -102,12 +103,7 @@ func CreateAccessToken(ctx *context.APIContext) {
 
 	form := web.GetForm(ctx).(*api.CreateAccessTokenOption)
 
-	t := &auth_model.AccessToken{
-		UID:  ctx.ContextUser.ID,
-		Name: form.Name,
-	}
-
-	exist, err := auth_model.AccessTokenByNameExists(ctx, t)
+	exist, err := auth_model.AccessTokenByNameExists(ctx, &auth_model.AccessToken{UID: ctx.ContextUser.ID, Name: form.Name})
 	if err != nil {
 		ctx.InternalServerError(err)
 		return
@@ -122,23 +118,47 @@ func CreateAccessToken(ctx *context.APIContext) {
 		ctx.Error(http.StatusBadRequest, "AccessTokenScope.Normalize", fmt.Errorf("invalid access token scope provided: %w", err))
 		return
 	}
-	if scope == "" {
+	if scope == "" && form.Permissions == nil {
 		ctx.Error(http.StatusBadRequest, "AccessTokenScope", "access token must have a scope")
 		return
 	}
-	t.Scope = scope
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.Error(http.StatusInternalServerError, "NewAccessToken", err)
+	opts := &auth_service.CreateAccessTokenOptions{
+		Name:       form.Name,
+		Scope:      scope,
+		AllowedIPs: strings.Join(form.AllowedIPs, ","),
+	}
+	if form.ExpiresAt != nil {
+		opts.ExpiresUnix = timeutil.TimeStamp(form.ExpiresAt.Unix())
+	}
+	if form.Permissions != nil {
+		opts.Permissions = &auth_model.AccessTokenPermissions{
+			Contents: perm.ParseAccessMode(form.Permissions.Contents),
+			Issues:   perm.ParseAccessMode(form.Permissions.Issues),
+			Packages: perm.ParseAccessMode(form.Permissions.Packages),
+			Actions:  perm.ParseAccessMode(form.Permissions.Actions),
+		}
+		opts.Organization = form.Organization
+		opts.Repositories = form.Repositories
+	}
+
+	t, err := auth_service.CreateAccessToken(ctx, ctx.ContextUser, opts)
+	if err != nil {
+		if auth_service.IsErrAccessTokenOptions(err) {
+			ctx.Error(http.StatusBadRequest, "CreateAccessToken", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateAccessToken", err)
+		}
 		return
 	}
-	ctx.JSON(http.StatusCreated, &api.AccessToken{
-		Name:           t.Name,
-		Token:          t.Token,
-		ID:             t.ID,
-		TokenLastEight: t.TokenLastEight,
-		Scopes:         t.Scope.StringSlice(),
-	})
+
+	apiToken, err := convert.ToAccessToken(ctx, t)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	apiToken.Token = t.Token
+	ctx.JSON(http.StatusCreated, apiToken)
 }
 
 // DeleteAccessToken deletes an access token
@@ -198,7 +218,7 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		return
 	}
 
-	if err := auth_model.DeleteAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID); err != nil {
+	if err := auth_service.DeleteAccessToken(ctx, ctx.ContextUser, tokenID); err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			ctx.NotFound()
 		} else {
@@ -240,6 +260,7 @@ func CreateOauth2Application(ctx *context.APIContext) {
 		UserID:             ctx.Doer.ID,
 		RedirectURIs:       data.RedirectURIs,
 		ConfidentialClient: data.ConfidentialClient,
+		EnableDeviceFlow:   data.EnableDeviceFlow,
 	})
 	if err != nil {
 		ctx.Error(http.StatusBadRequest, "", "error creating oauth2 application")
@@ -415,6 +436,7 @@ func UpdateOauth2Application(ctx *context.APIContext) {
 		ID:                 appID,
 		RedirectURIs:       data.RedirectURIs,
 		ConfidentialClient: data.ConfidentialClient,
+		EnableDeviceFlow:   data.EnableDeviceFlow,
 	})
 	if err != nil {
 		if auth_model.IsErrOauthClientIDInvalid(err) || auth_model.IsErrOAuthApplicationNotFound(err) {
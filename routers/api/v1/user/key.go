@@ -219,11 +219,17 @@ func CreateUserPublicKey(ctx *context.APIContext, form api.CreateKeyOption, uid
 		return
 	}
 
-	key, err := asymkey_model.AddPublicKey(ctx, uid, form.Title, content, 0)
+	owner, err := user_model.GetUserByID(ctx, uid)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUserByID", err)
+		return
+	}
+	key, err := asymkey_service.AddPublicKey(ctx, ctx.Doer, owner, form.Title, content, 0)
 	if err != nil {
 		repo.HandleAddKeyError(ctx, err)
 		return
 	}
+
 	apiLink := composePublicKeysAPILink()
 	apiKey := convert.ToPublicKey(apiLink, key)
 	if ctx.Doer.IsAdmin || ctx.Doer.ID == key.OwnerID {
@@ -0,0 +1,317 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/shared"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListMySavedSearches lists the saved searches the authenticated user can see
+func ListMySavedSearches(ctx *context.APIContext) {
+	// swagger:operation GET /user/saved_searches user userListSavedSearches
+	// ---
+	// summary: List the saved searches of the authenticated user and those shared with them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearchList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	shared.ListSavedSearches(ctx, 0)
+}
+
+// CreateMySavedSearch saves a private search of the authenticated user
+func CreateMySavedSearch(ctx *context.APIContext) {
+	// swagger:operation POST /user/saved_searches user userCreateSavedSearch
+	// ---
+	// summary: Save a private search of issues or pull requests
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSavedSearchOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.CreateSavedSearch(ctx, ctx.Doer)
+}
+
+// GetSavedSearch gets a saved search the authenticated user can see
+func GetSavedSearch(ctx *context.APIContext) {
+	// swagger:operation GET /user/saved_searches/{id} user userGetSavedSearch
+	// ---
+	// summary: Get a saved search
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search := getSavedSearch(ctx, false)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPISavedSearch(ctx, ctx.Doer, search))
+}
+
+// EditSavedSearch updates a saved search
+func EditSavedSearch(ctx *context.APIContext) {
+	// swagger:operation PATCH /user/saved_searches/{id} user userEditSavedSearch
+	// ---
+	// summary: Edit a saved search
+	// description: Only the user owning the search, or the member who saved a search of an
+	//   organization and the owners of the organization can edit it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditSavedSearchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SavedSearch"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditSavedSearchOption)
+
+	search := getSavedSearch(ctx, true)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		search.Name = *form.Name
+	}
+	if form.Pinned != nil {
+		search.IsPinned = *form.Pinned
+	}
+	if form.Query != nil {
+		search.Keyword = *form.Query
+	}
+	if form.Type != nil {
+		search.ViewType = *form.Type
+	}
+	if form.State != nil {
+		search.State = *form.State
+	}
+	if form.Labels != nil {
+		search.SetLabelIDs(*form.Labels)
+	}
+	if form.Sort != nil {
+		search.SortType = *form.Sort
+	}
+
+	if err := issues_model.UpdateSavedSearch(ctx, search); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateSavedSearch", err)
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPISavedSearch(ctx, ctx.Doer, search))
+}
+
+// DeleteSavedSearch deletes a saved search
+func DeleteSavedSearch(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/saved_searches/{id} user userDeleteSavedSearch
+	// ---
+	// summary: Delete a saved search
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search := getSavedSearch(ctx, true)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.DeleteSavedSearchByID(ctx, search.ID); err != nil {
+		if issues_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListSavedSearchIssues lists the issues or pull requests matching a saved search
+func ListSavedSearchIssues(ctx *context.APIContext) {
+	// swagger:operation GET /user/saved_searches/{id}/issues user userListSavedSearchIssues
+	// ---
+	// summary: List the issues or pull requests matching a saved search
+	// description: The filters of the search referring to a user, like the issues assigned to them,
+	//   refer to the authenticated user.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the saved search
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	search := getSavedSearch(ctx, false)
+	if ctx.Written() {
+		return
+	}
+
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	issues, total, err := issue_service.SearchSavedSearchIssues(ctx, ctx.Doer, search, blockedRepoIDs, listOptions)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	ctx.SetLinkHeader(int(total), listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, issues))
+}
+
+// getSavedSearch returns the saved search identified by the id parameter if the doer can see it,
+// or change it when forChange is true
+func getSavedSearch(ctx *context.APIContext, forChange bool) *issues_model.SavedSearch {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.InternalServerError(err)
+		}
+		return nil
+	}
+
+	canSee, err := search.CanBeSeenBy(ctx, ctx.Doer)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return nil
+	}
+	if !canSee {
+		ctx.NotFound()
+		return nil
+	}
+	if forChange {
+		canChange, err := search.CanBeChangedBy(ctx, ctx.Doer)
+		if err != nil {
+			ctx.InternalServerError(err)
+			return nil
+		}
+		if !canChange {
+			ctx.Error(http.StatusForbidden, "CanBeChangedBy", "only the owner of the search can change it")
+			return nil
+		}
+	}
+
+	if err := search.LoadAttributes(ctx); err != nil {
+		ctx.InternalServerError(err)
+		return nil
+	}
+	return search
+}
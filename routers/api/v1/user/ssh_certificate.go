@@ -0,0 +1,130 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	asymkey_service "forgejo.org/services/asymkey"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListMySSHCertificates lists the valid SSH certificates of the authenticated user
+func ListMySSHCertificates(ctx *context.APIContext) {
+	// swagger:operation GET /user/ssh_certificates user userCurrentListSSHCertificates
+	// ---
+	// summary: List the SSH certificates of the authenticated user which have neither expired nor been revoked
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SSHCertificateList"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	certs, err := asymkey_model.FindValidSSHCertificates(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindValidSSHCertificates", err)
+		return
+	}
+
+	apiCerts := make([]*api.SSHCertificate, len(certs))
+	for i := range certs {
+		apiCerts[i] = convert.ToSSHCertificate(certs[i])
+	}
+	ctx.JSON(http.StatusOK, &apiCerts)
+}
+
+// CreateSSHCertificate issues a short-lived SSH certificate for a public key of the authenticated user
+func CreateSSHCertificate(ctx *context.APIContext) {
+	// swagger:operation POST /user/ssh_certificates user userCurrentPostSSHCertificate
+	// ---
+	// summary: Issue a short-lived SSH certificate for a public key, its principal is the username
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSSHCertificateOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SSHCertificate"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if user_model.IsFeatureDisabledWithLoginType(ctx.Doer, setting.UserFeatureManageSSHKeys) {
+		ctx.NotFound("Not Found", errors.New("ssh keys setting is not allowed to be visited"))
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.CreateSSHCertificateOption)
+	c, cert, err := asymkey_service.IssueSSHCertificate(ctx, ctx.Doer, form.Key)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "IssueSSHCertificate", err)
+		}
+		return
+	}
+
+	apiCert := convert.ToSSHCertificate(c)
+	apiCert.Certificate = cert
+	ctx.JSON(http.StatusCreated, apiCert)
+}
+
+// RevokeSSHCertificate revokes a SSH certificate of the authenticated user
+func RevokeSSHCertificate(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/ssh_certificates/{id} user userCurrentRevokeSSHCertificate
+	// ---
+	// summary: Revoke a SSH certificate
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: serial of the certificate to revoke
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := asymkey_service.RevokeSSHCertificate(ctx, ctx.Doer, ctx.ParamsInt64(":id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RevokeSSHCertificate", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -18,6 +18,7 @@ import (
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/validation"
 	webhook_module "forgejo.org/modules/webhook"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	webhook_service "forgejo.org/services/webhook"
 )
@@ -251,7 +252,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		return nil, false
 	}
 
-	if err := webhook.CreateWebhook(ctx, w, form.AuthorizationHeader); err != nil {
+	if err := webhook_service.CreateWebhook(ctx, ctx.Doer, w, form.AuthorizationHeader); err != nil {
 		ctx.Error(http.StatusInternalServerError, "CreateWebhook", err)
 		return nil, false
 	}
@@ -326,6 +327,7 @@ func EditRepoHook(ctx *context.APIContext, form *api.EditHookOption, hookID int6
 // editHook edit the webhook `w` according to `form`. If an error occurs, write
 // to `ctx` accordingly and return the error. Return whether successful
 func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webhook) bool {
+	before := audit_service.WebhookState(w)
 	if form.Config != nil {
 		if url, ok := form.Config["url"]; ok {
 			if !validation.IsValidURL(url) {
@@ -404,7 +406,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 		w.IsActive = *form.Active
 	}
 
-	if err := webhook.UpdateWebhook(ctx, w); err != nil {
+	if err := webhook_service.UpdateWebhook(ctx, ctx.Doer, w, before); err != nil {
 		ctx.Error(http.StatusInternalServerError, "UpdateWebhook", err)
 		return false
 	}
@@ -413,7 +415,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 
 // DeleteOwnerHook deletes the hook owned by the owner.
 func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int64) {
-	if err := webhook.DeleteWebhookByOwnerID(ctx, owner.ID, hookID); err != nil {
+	if err := webhook_service.DeleteWebhookByOwnerID(ctx, ctx.Doer, owner.ID, hookID); err != nil {
 		if webhook.IsErrWebhookNotExist(err) {
 			ctx.NotFound()
 		} else {
@@ -28,11 +28,14 @@ import (
 	actions_router "forgejo.org/routers/api/actions"
 	forgejo "forgejo.org/routers/api/forgejo/v1"
 	packages_router "forgejo.org/routers/api/packages"
+	terraform_router "forgejo.org/routers/api/packages/terraform"
+	scim_router "forgejo.org/routers/api/scim"
 	apiv1 "forgejo.org/routers/api/v1"
 	"forgejo.org/routers/common"
 	"forgejo.org/routers/private"
 	web_routers "forgejo.org/routers/web"
 	actions_service "forgejo.org/services/actions"
+	advisory_service "forgejo.org/services/advisory"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/oauth2"
 	"forgejo.org/services/automerge"
@@ -40,11 +43,13 @@ import (
 	federation_service "forgejo.org/services/federation"
 	feed_service "forgejo.org/services/feed"
 	indexer_service "forgejo.org/services/indexer"
+	issue_service "forgejo.org/services/issue"
 	"forgejo.org/services/mailer"
 	mailer_incoming "forgejo.org/services/mailer/incoming"
 	markup_service "forgejo.org/services/markup"
 	migrations_service "forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
+	project_service "forgejo.org/services/project"
 	pull_service "forgejo.org/services/pull"
 	release_service "forgejo.org/services/release"
 	repo_service "forgejo.org/services/repository"
@@ -121,6 +126,9 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(feed_service.Init)
 	mustInit(federation_service.Init)
 	mustInit(uinotification.Init)
+	mustInit(project_service.Init)
+	mustInit(issue_service.Init)
+	mustInit(advisory_service.Init)
 	mustInitCtx(ctx, archiver.Init)
 
 	highlight.NewContext()
@@ -188,10 +196,17 @@ func NormalRoutes() *web.Route {
 	if setting.Packages.Enabled {
 		// This implements package support for most package managers
 		r.Mount("/api/packages", packages_router.CommonRoutes())
+		// Terraform looks up the registry protocols on the host
+		r.Get("/.well-known/terraform.json", terraform_router.ServiceDiscovery)
 		// This implements the OCI API (Note this is not preceded by /api but is instead /v2)
 		r.Mount("/v2", packages_router.ContainerRoutes())
 	}
 
+	if setting.SCIM.Enabled {
+		// This implements user and team provisioning for identity providers
+		r.Mount("/scim/v2", scim_router.Routes())
+	}
+
 	if setting.Actions.Enabled {
 		prefix := "/api/actions"
 		r.Mount(prefix, actions_router.Routes(prefix))
@@ -8,8 +8,12 @@ import (
 
 	asymkey_model "forgejo.org/models/asymkey"
 	"forgejo.org/modules/private"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/services/context"
+
+	"golang.org/x/crypto/ssh"
 )
 
 // UpdatePublicKeyInRepo update public key and deploy key updates
@@ -50,6 +54,15 @@ func UpdatePublicKeyInRepo(ctx *context.PrivateContext) {
 func AuthorizedPublicKeyByContent(ctx *context.PrivateContext) {
 	content := ctx.FormString("content")
 
+	if setting.SSH.CertificateAuthorityEnabled {
+		if key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content)); err == nil {
+			if cert, ok := key.(*ssh.Certificate); ok {
+				authorizedCertificate(ctx, cert)
+				return
+			}
+		}
+	}
+
 	publicKey, err := asymkey_model.SearchPublicKeyByContent(ctx, content)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, private.Response{
@@ -59,3 +72,27 @@ func AuthorizedPublicKeyByContent(ctx *context.PrivateContext) {
 	}
 	ctx.PlainText(http.StatusOK, publicKey.AuthorizedString())
 }
+
+// authorizedCertificate returns the authorized keys string for a certificate issued by this instance,
+// which lets OpenSSH trust the certificate authority that signed it for its principal
+func authorizedCertificate(ctx *context.PrivateContext, cert *ssh.Certificate) {
+	c, err := asymkey_model.VerifySSHCertificate(ctx, cert)
+	if err == nil && c == nil {
+		err = util.NewNotExistErrorf("SSH certificate was not issued by this instance")
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+
+	ca, err := asymkey_model.GetSSHCertificateAuthorityByID(ctx, c.AuthorityID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	}
+	ctx.PlainText(http.StatusOK, asymkey_model.AuthorizedStringForCertificate(c, ca))
+}
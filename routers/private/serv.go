@@ -4,6 +4,7 @@
 package private
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/private"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 	"forgejo.org/services/context"
 	repo_service "forgejo.org/services/repository"
 	wiki_service "forgejo.org/services/wiki"
@@ -46,6 +48,29 @@ func checkTwoFactor(ctx *context.PrivateContext, user *user_model.User) {
 	}
 }
 
+// getServKey returns the public key a SSH connection was authenticated with.
+// For a certificate issued by this instance, the ID is its serial and the returned key of its owner is not stored,
+// it does not exist if the certificate has expired or been revoked since.
+func getServKey(ctx *context.PrivateContext, id int64) (*asymkey_model.PublicKey, error) {
+	if !ctx.FormBool("cert") {
+		return asymkey_model.GetPublicKeyByID(ctx, id)
+	}
+
+	c, err := asymkey_model.GetValidSSHCertificateByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrPermissionDenied) {
+			return nil, asymkey_model.ErrKeyNotExist{ID: id}
+		}
+		return nil, err
+	}
+	return &asymkey_model.PublicKey{
+		OwnerID: c.OwnerID,
+		Name:    c.KeyID(),
+		Mode:    perm.AccessModeWrite,
+		Type:    asymkey_model.KeyTypeUser,
+	}, nil
+}
+
 // ServNoCommand returns information about the provided keyid
 func ServNoCommand(ctx *context.PrivateContext) {
 	keyID := ctx.ParamsInt64(":keyid")
@@ -56,7 +81,7 @@ func ServNoCommand(ctx *context.PrivateContext) {
 	}
 	results := private.KeyAndOwner{}
 
-	key, err := asymkey_model.GetPublicKeyByID(ctx, keyID)
+	key, err := getServKey(ctx, keyID)
 	if err != nil {
 		if asymkey_model.IsErrKeyNotExist(err) {
 			ctx.JSON(http.StatusUnauthorized, private.Response{
@@ -213,7 +238,7 @@ func ServCommand(ctx *context.PrivateContext) {
 	}
 
 	// Get the Public Key represented by the keyID
-	key, err := asymkey_model.GetPublicKeyByID(ctx, keyID)
+	key, err := getServKey(ctx, keyID)
 	if err != nil {
 		if asymkey_model.IsErrKeyNotExist(err) {
 			ctx.JSON(http.StatusNotFound, private.Response{
@@ -307,6 +332,22 @@ func ServCommand(ctx *context.PrivateContext) {
 		}
 	}
 
+	// The serv command sends the address of the SSH client, the private API resolves it from the X-Real-IP header
+	allowed, err := context.IsRemoteHostAllowed(ctx, owner.ID, user, ctx.RemoteAddr())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check the IP allowlist of %s: %v", results.OwnerName, err),
+		})
+		return
+	}
+	if !allowed {
+		sshLogger.Warn("Access to %s/%s from %s is not allowed by the IP allowlist", results.OwnerName, results.RepoName, ctx.RemoteAddr())
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: fmt.Sprintf("Access to %s/%s is not allowed from your IP address.", results.OwnerName, results.RepoName),
+		})
+		return
+	}
+
 	// Don't allow pushing if the repo is archived
 	if repoExist && mode > perm.AccessModeRead && repo.IsArchived {
 		ctx.JSON(http.StatusUnauthorized, private.Response{
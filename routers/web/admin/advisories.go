@@ -0,0 +1,94 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	go_context "context"
+	"errors"
+	"net/http"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/setting"
+	advisory_service "forgejo.org/services/advisory"
+	"forgejo.org/services/context"
+)
+
+const (
+	tplAdvisories base.TplName = "admin/advisories"
+)
+
+// Advisories shows the state of the advisory database
+func Advisories(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.advisories")
+	ctx.Data["PageIsAdminAdvisories"] = true
+
+	count, err := advisory_model.CountAdvisories(ctx)
+	if err != nil {
+		ctx.ServerError("CountAdvisories", err)
+		return
+	}
+	ctx.Data["AdvisoryCount"] = count
+	ctx.Data["Directory"] = setting.Advisories.Directory
+
+	ctx.HTML(http.StatusOK, tplAdvisories)
+}
+
+// rescanInBackground matches all repositories and packages against the changed advisories
+func rescanInBackground() {
+	go graceful.GetManager().RunWithShutdownContext(func(ctx go_context.Context) {
+		if err := advisory_service.ScanAll(ctx); err != nil {
+			log.Error("ScanAll: %v", err)
+		}
+	})
+}
+
+// UploadAdvisories imports the advisories of an uploaded archive
+func UploadAdvisories(ctx *context.Context) {
+	file, header, err := ctx.Req.FormFile("archive")
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("admin.advisories.upload.missing"))
+		ctx.Redirect(setting.AppSubURL + "/admin/advisories")
+		return
+	}
+	defer file.Close()
+
+	count, err := advisory_service.ImportArchive(ctx, file, header.Size)
+	if err != nil {
+		if errors.Is(err, osv.ErrUnsupportedArchive) || errors.Is(err, osv.ErrInvalidEntry) {
+			ctx.Flash.Error(ctx.Tr("admin.advisories.upload.invalid", err.Error()))
+			ctx.Redirect(setting.AppSubURL + "/admin/advisories")
+			return
+		}
+		ctx.ServerError("ImportArchive", err)
+		return
+	}
+
+	rescanInBackground()
+
+	ctx.Flash.Success(ctx.Tr("admin.advisories.import.success", count))
+	ctx.Redirect(setting.AppSubURL + "/admin/advisories")
+}
+
+// UpdateAdvisories imports the advisories of the configured directory and rescans all repositories and packages
+func UpdateAdvisories(ctx *context.Context) {
+	count := 0
+	if setting.Advisories.Directory != "" {
+		var err error
+		count, err = advisory_service.ImportDirectory(ctx, setting.Advisories.Directory)
+		if err != nil {
+			ctx.Flash.Error(ctx.Tr("admin.advisories.update.failed", err.Error()))
+			ctx.Redirect(setting.AppSubURL + "/admin/advisories")
+			return
+		}
+	}
+
+	rescanInBackground()
+
+	ctx.Flash.Success(ctx.Tr("admin.advisories.import.success", count))
+	ctx.Redirect(setting.AppSubURL + "/admin/advisories")
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/base"
+	shared "forgejo.org/routers/web/shared/audit"
+	"forgejo.org/services/context"
+)
+
+const tplAudit base.TplName = "admin/audit"
+
+// Audit shows the audit log of the instance
+func Audit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.audit")
+	ctx.Data["PageIsAdminAudit"] = true
+
+	shared.SetEventsContext(ctx, 0, 0)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAudit)
+}
+
+// AuditExport exports the audit log of the instance as JSON lines
+func AuditExport(ctx *context.Context) {
+	shared.ExportEvents(ctx, 0, 0)
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/auth/pam"
@@ -17,10 +18,12 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
 	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/ldap"
 	"forgejo.org/services/auth/source/oauth2"
 	pam_service "forgejo.org/services/auth/source/pam"
+	"forgejo.org/services/auth/source/saml"
 	"forgejo.org/services/auth/source/smtp"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -61,6 +64,7 @@ var (
 			{auth.DLDAP.String(), auth.DLDAP},
 			{auth.SMTP.String(), auth.SMTP},
 			{auth.OAuth2.String(), auth.OAuth2},
+			{auth.SAML.String(), auth.SAML},
 		}
 		if pam.Supported {
 			items = append(items, dropdownItem{auth.Names[auth.PAM], auth.PAM})
@@ -197,6 +201,49 @@ func parseOAuth2Config(form forms.AuthenticationForm) *oauth2.Source {
 	}
 }
 
+func parseSAMLConfig(form forms.AuthenticationForm) *saml.Source {
+	return &saml.Source{
+		IdentityProviderMetadata:    strings.TrimSpace(form.SamlIdpMetadata),
+		IdentityProviderMetadataURL: form.SamlIdpMetadataURL,
+		ServiceProviderCertificate:  strings.TrimSpace(form.SamlSpCertificate),
+		ServiceProviderPrivateKey:   strings.TrimSpace(form.SamlSpPrivateKey),
+		SignRequests:                form.SamlSignRequests,
+		NameIDFormat:                form.SamlNameIDFormat,
+		UsernameAttribute:           form.SamlUsernameAttribute,
+		EmailAttribute:              form.SamlEmailAttribute,
+		FullNameAttribute:           form.SamlFullNameAttribute,
+		GroupAttribute:              form.SamlGroupAttribute,
+		AdminGroup:                  form.SamlAdminGroup,
+		RestrictedGroup:             form.SamlRestrictedGroup,
+		GroupTeamMap:                form.SamlGroupTeamMap,
+		GroupTeamMapRemoval:         form.SamlGroupTeamMapRemoval,
+		IconURL:                     form.SamlIconURL,
+		SkipLocalTwoFA:              form.SkipLocalTwoFA,
+	}
+}
+
+// validateSAMLConfig renders the form with an error and returns false if the identity provider metadata is missing
+func validateSAMLConfig(ctx *context.Context, config *saml.Source, tpl base.TplName, form forms.AuthenticationForm) bool {
+	if config.IdentityProviderMetadataURL != "" {
+		metadataURL, err := url.Parse(config.IdentityProviderMetadataURL)
+		if err != nil || (metadataURL.Scheme != "http" && metadataURL.Scheme != "https") {
+			ctx.Data["Err_SAMLMetadata"] = true
+			ctx.RenderWithErr(ctx.Tr("admin.auths.saml_invalid_idp_metadata_url"), tpl, form)
+			return false
+		}
+	} else if config.IdentityProviderMetadata == "" {
+		ctx.Data["Err_SAMLMetadata"] = true
+		ctx.RenderWithErr(ctx.Tr("admin.auths.saml_idp_metadata_required"), tpl, form)
+		return false
+	}
+	if config.SignRequests && config.ServiceProviderPrivateKey == "" {
+		ctx.Data["Err_SAMLKeyPair"] = true
+		ctx.RenderWithErr(ctx.Tr("admin.auths.saml_sp_key_pair_required"), tpl, form)
+		return false
+	}
+	return true
+}
+
 // NewAuthSourcePost response for adding an auth source
 func NewAuthSourcePost(ctx *context.Context) {
 	form := *web.GetForm(ctx).(*forms.AuthenticationForm)
@@ -237,6 +284,11 @@ func NewAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		config = parseSAMLConfig(form)
+		if !validateSAMLConfig(ctx, config.(*saml.Source), tplAuthNew, form) {
+			return
+		}
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
@@ -248,13 +300,14 @@ func NewAuthSourcePost(ctx *context.Context) {
 		return
 	}
 
-	if err := auth.CreateSource(ctx, &auth.Source{
+	source := &auth.Source{
 		Type:          auth.Type(form.Type),
 		Name:          form.Name,
 		IsActive:      form.IsActive,
 		IsSyncEnabled: form.IsSyncEnabled,
 		Cfg:           config,
-	}); err != nil {
+	}
+	if err := auth.CreateSource(ctx, source); err != nil {
 		if auth.IsErrSourceAlreadyExist(err) {
 			ctx.Data["Err_Name"] = true
 			ctx.RenderWithErr(ctx.Tr("admin.auths.login_source_exist", err.(auth.ErrSourceAlreadyExist).Name), tplAuthNew, form)
@@ -268,6 +321,7 @@ func NewAuthSourcePost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminAuthSourceCreate, audit_service.AuthSourceTarget(source), nil, audit_service.AuthSourceState(source))
 	log.Trace("Authentication created by admin(%s): %s", ctx.Doer.Name, form.Name)
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.new_success", form.Name))
@@ -353,11 +407,17 @@ func EditAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		config = parseSAMLConfig(form)
+		if !validateSAMLConfig(ctx, config.(*saml.Source), tplAuthEdit, form) {
+			return
+		}
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
 	}
 
+	before := audit_service.AuthSourceState(source)
 	source.Name = form.Name
 	source.IsActive = form.IsActive
 	source.IsSyncEnabled = form.IsSyncEnabled
@@ -375,6 +435,7 @@ func EditAuthSourcePost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminAuthSourceUpdate, audit_service.AuthSourceTarget(source), before, audit_service.AuthSourceState(source))
 	log.Trace("Authentication changed by admin(%s): %d", ctx.Doer.Name, source.ID)
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.update_success"))
@@ -398,6 +459,7 @@ func DeleteAuthSource(ctx *context.Context) {
 		ctx.JSONRedirect(setting.AppSubURL + "/admin/auths/" + url.PathEscape(ctx.Params(":authid")))
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminAuthSourceDelete, audit_service.AuthSourceTarget(source), audit_service.AuthSourceState(source), nil)
 	log.Trace("Authentication deleted by admin(%s): %d", ctx.Doer.Name, source.ID)
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.deletion_success"))
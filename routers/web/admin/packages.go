@@ -4,6 +4,7 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
@@ -14,12 +15,14 @@ import (
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
 	"forgejo.org/services/context"
+	"forgejo.org/services/cron"
 	packages_service "forgejo.org/services/packages"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
 )
 
 const (
-	tplPackagesList base.TplName = "admin/packages/list"
+	tplPackagesList             base.TplName = "admin/packages/list"
+	tplPackagesContainerGarbage base.TplName = "admin/packages/container_gc"
 )
 
 // Packages shows all packages
@@ -94,11 +97,14 @@ func DeletePackageVersion(ctx *context.Context) {
 	}
 
 	if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
-		ctx.ServerError("RemovePackageVersion", err)
-		return
+		if !errors.Is(err, packages_service.ErrImmutableVersion) {
+			ctx.ServerError("RemovePackageVersion", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("packages.settings.delete.immutable"))
+	} else {
+		ctx.Flash.Success(ctx.Tr("packages.settings.delete.success"))
 	}
-
-	ctx.Flash.Success(ctx.Tr("packages.settings.delete.success"))
 	ctx.JSONRedirect(setting.AppSubURL + "/admin/packages?page=" + url.QueryEscape(ctx.FormString("page")) + "&q=" + url.QueryEscape(ctx.FormString("q")) + "&type=" + url.QueryEscape(ctx.FormString("type")))
 }
 
@@ -111,3 +117,44 @@ func CleanupExpiredData(ctx *context.Context) {
 	ctx.Flash.Success(ctx.Tr("admin.packages.cleanup.success"))
 	ctx.Redirect(setting.AppSubURL + "/admin/packages")
 }
+
+// containerGarbageOlderThan returns the age of manifests protected from garbage collection, same as for the cron task
+func containerGarbageOlderThan() time.Duration {
+	if task := cron.GetTask("cleanup_packages"); task != nil {
+		if config, ok := task.GetConfig().(*cron.OlderThanConfig); ok {
+			return config.OlderThan
+		}
+	}
+	return 24 * time.Hour
+}
+
+// ContainerGarbage shows the container manifests the garbage collection would remove
+func ContainerGarbage(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.packages.container_gc")
+	ctx.Data["PageIsAdminPackages"] = true
+
+	olderThan := containerGarbageOlderThan()
+
+	report, err := packages_cleanup_service.CollectContainerGarbage(ctx, olderThan, true)
+	if err != nil {
+		ctx.ServerError("CollectContainerGarbage", err)
+		return
+	}
+
+	ctx.Data["Report"] = report
+	ctx.Data["OlderThan"] = olderThan.String()
+
+	ctx.HTML(http.StatusOK, tplPackagesContainerGarbage)
+}
+
+// CollectContainerGarbage removes the unreachable container manifests
+func CollectContainerGarbage(ctx *context.Context) {
+	report, err := packages_cleanup_service.CollectContainerGarbage(ctx, containerGarbageOlderThan(), false)
+	if err != nil {
+		ctx.ServerError("CollectContainerGarbage", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.packages.container_gc.success", len(report.Manifests), report.Blobs, ctx.Locale.TrSize(report.BlobsSize)))
+	ctx.Redirect(setting.AppSubURL + "/admin/packages/container-gc")
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	asymkey_service "forgejo.org/services/asymkey"
+	"forgejo.org/services/context"
+)
+
+const tplSSHCertificates base.TplName = "admin/ssh_certificates"
+
+// SSHCertificates shows the SSH certificate authorities and the valid certificates they issued
+func SSHCertificates(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.ssh_certificates")
+	ctx.Data["PageIsAdminSSHCertificates"] = true
+
+	authorities, err := asymkey_model.GetSSHCertificateAuthorities(ctx)
+	if err != nil {
+		ctx.ServerError("GetSSHCertificateAuthorities", err)
+		return
+	}
+	ctx.Data["Authorities"] = authorities
+
+	certs, err := asymkey_model.FindValidSSHCertificates(ctx, 0)
+	if err != nil {
+		ctx.ServerError("FindValidSSHCertificates", err)
+		return
+	}
+	ctx.Data["Certificates"] = certs
+
+	ctx.HTML(http.StatusOK, tplSSHCertificates)
+}
+
+// RotateSSHCertificateAuthority replaces the certificate authority which signs new certificates
+func RotateSSHCertificateAuthority(ctx *context.Context) {
+	if _, err := asymkey_service.RotateSSHCertificateAuthority(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("RotateSSHCertificateAuthority", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.ssh_certificates.rotate_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/ssh_certificates")
+}
+
+// DeleteSSHCertificateAuthority deletes a retired certificate authority
+func DeleteSSHCertificateAuthority(ctx *context.Context) {
+	if err := asymkey_service.DeleteSSHCertificateAuthority(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.Flash.Error(ctx.Tr("error.not_found"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("admin.ssh_certificates.delete_active"))
+		default:
+			ctx.Flash.Error(ctx.Tr("error.server_internal"))
+			log.Error("DeleteSSHCertificateAuthority: %v", err)
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("admin.ssh_certificates.delete_success"))
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/ssh_certificates")
+}
+
+// RevokeSSHCertificate revokes a SSH certificate of any user
+func RevokeSSHCertificate(ctx *context.Context) {
+	if err := asymkey_service.RevokeSSHCertificate(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("error.not_found"))
+		} else {
+			ctx.Flash.Error(ctx.Tr("error.server_internal"))
+			log.Error("RevokeSSHCertificate: %v", err)
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("settings.ssh_certificate.revoke_success"))
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/ssh_certificates")
+}
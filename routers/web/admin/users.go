@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	org_model "forgejo.org/models/organization"
@@ -26,6 +27,8 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/explore"
 	user_setting "forgejo.org/routers/web/user/setting"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -219,6 +222,7 @@ func NewUserPost(ctx *context.Context) {
 		ctx.Flash.Warning(ctx.Tr("form.email_domain_is_not_allowed", u.Email))
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserCreate, audit_service.UserTarget(u), nil, audit_service.UserState(u))
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
 
 	// Send email notification.
@@ -351,6 +355,8 @@ func EditUserPost(ctx *context.Context) {
 		return
 	}
 
+	before := audit_service.UserState(u)
+
 	form := web.GetForm(ctx).(*forms.AdminEditUserForm)
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplUserEdit)
@@ -383,8 +389,9 @@ func EditUserPost(ctx *context.Context) {
 	}
 
 	authOpts := &user_service.UpdateAuthOptions{
-		Password:  optional.FromNonDefault(form.Password),
-		LoginName: optional.Some(form.LoginName),
+		Password:    optional.FromNonDefault(form.Password),
+		LoginName:   optional.Some(form.LoginName),
+		KeepSession: keepOwnSession(ctx, u),
 	}
 
 	// skip self Prohibit Login
@@ -465,6 +472,7 @@ func EditUserPost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserUpdate, audit_service.UserTarget(u), before, audit_service.UserState(u))
 	log.Trace("Account profile updated by admin (%s): %s", ctx.Doer.Name, u.Name)
 
 	if form.Reset2FA {
@@ -490,6 +498,11 @@ func EditUserPost(ctx *context.Context) {
 				return
 			}
 		}
+
+		if err := user_service.SignOutSessions(ctx, u, keepOwnSession(ctx, u)); err != nil {
+			ctx.ServerError("SignOutSessions", err)
+			return
+		}
 	}
 
 	ctx.Flash.Success(ctx.Tr("admin.users.update_profile_success"))
@@ -530,12 +543,39 @@ func DeleteUser(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserDelete, audit_service.UserTarget(u), audit_service.UserState(u), nil)
 	log.Trace("Account deleted by admin (%s): %s", ctx.Doer.Name, u.Name)
 
 	ctx.Flash.Success(ctx.Tr("admin.users.deletion_success"))
 	ctx.Redirect(setting.AppSubURL + "/admin/users")
 }
 
+// keepOwnSession returns the session of the doer which stays signed in when the sessions of a user are signed out
+func keepOwnSession(ctx *context.Context, u *user_model.User) string {
+	if ctx.Doer.ID != u.ID {
+		return ""
+	}
+	return auth_service.UserSessionToken(ctx.Session)
+}
+
+// SignOutUser signs a user out of all sessions
+func SignOutUser(ctx *context.Context) {
+	u := prepareUserInfo(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := user_service.SignOutSessions(ctx, u, keepOwnSession(ctx, u)); err != nil {
+		ctx.ServerError("SignOutSessions", err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionAdminUserSignOut, audit_service.UserTarget(u), nil, nil)
+	log.Trace("Account signed out of all sessions by admin (%s): %s", ctx.Doer.Name, u.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.users.sign_out_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/users/" + strconv.FormatInt(u.ID, 10))
+}
+
 // AvatarPost response for change user's avatar request
 func AvatarPost(ctx *context.Context) {
 	u := prepareUserInfo(ctx)
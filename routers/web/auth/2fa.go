@@ -95,7 +95,7 @@ func TwoFactorPost(ctx *context.Context) {
 			return
 		}
 
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, twoFactorSignInMethod(ctx))
 		return
 	}
 
@@ -162,7 +162,7 @@ func TwoFactorScratchPost(ctx *context.Context) {
 			}
 		}
 
-		handleSignInFull(ctx, u, remember, false)
+		handleSignInFull(ctx, u, remember, false, twoFactorSignInMethod(ctx))
 		if ctx.Written() {
 			return
 		}
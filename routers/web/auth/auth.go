@@ -29,6 +29,7 @@ import (
 	"forgejo.org/modules/web/middleware"
 	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/oauth2"
+	"forgejo.org/services/auth/source/saml"
 	"forgejo.org/services/context"
 	"forgejo.org/services/externalaccount"
 	"forgejo.org/services/forms"
@@ -72,9 +73,10 @@ func autoSignIn(ctx *context.Context) (bool, error) {
 
 	isSucceed = true
 
-	if err := updateSession(ctx, nil, map[string]any{
+	if err := updateSession(ctx, []string{"user_session"}, map[string]any{
 		// Set session IDs
-		"uid": u.ID,
+		"uid":         u.ID,
+		"auth_method": auth.UserSessionAuthRemember,
 	}); err != nil {
 		return false, fmt.Errorf("unable to updateSession: %w", err)
 	}
@@ -159,6 +161,12 @@ func SignIn(ctx *context.Context) {
 		return
 	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	samlProviders, err := saml.GetSAMLProviders(ctx)
+	if err != nil {
+		ctx.ServerError("UserSignIn", err)
+		return
+	}
+	ctx.Data["SAMLProviders"] = samlProviders
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
 	ctx.Data["PageIsSignIn"] = true
@@ -184,6 +192,12 @@ func SignInPost(ctx *context.Context) {
 		return
 	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	samlProviders, err := saml.GetSAMLProviders(ctx)
+	if err != nil {
+		ctx.ServerError("UserSignIn", err)
+		return
+	}
+	ctx.Data["SAMLProviders"] = samlProviders
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
 	ctx.Data["PageIsSignIn"] = true
@@ -232,10 +246,16 @@ func SignInPost(ctx *context.Context) {
 	}
 
 	// Now handle 2FA:
+	handleSignInTwoFactor(ctx, source, u, form.Remember, auth.UserSessionAuthPassword)
+}
 
+// handleSignInTwoFactor signs the user in unless the user is enrolled in 2FA
+// and the source does not skip it, in which case the user is redirected to the 2FA page.
+// The method is the one the user authenticated with, it is recorded for the session once the 2FA passed.
+func handleSignInTwoFactor(ctx *context.Context, source *auth.Source, u *user_model.User, remember bool, method string) {
 	// First of all if the source can skip local two fa we're done
 	if skipper, ok := source.Cfg.(auth_service.LocalTwoFASkipper); ok && skipper.IsSkipLocalTwoFA() {
-		handleSignIn(ctx, u, form.Remember)
+		handleSignIn(ctx, u, remember, method)
 		return
 	}
 
@@ -256,14 +276,15 @@ func SignInPost(ctx *context.Context) {
 
 	if !hasTOTPtwofa && !hasWebAuthnTwofa {
 		// No two factor auth configured we can sign in the user
-		handleSignIn(ctx, u, form.Remember)
+		handleSignIn(ctx, u, remember, method)
 		return
 	}
 
 	updates := map[string]any{
 		// User will need to use 2FA TOTP or WebAuthn, save data
 		"twofaUid":      u.ID,
-		"twofaRemember": form.Remember,
+		"twofaRemember": remember,
+		"auth_method":   method,
 	}
 	if hasTOTPtwofa {
 		// User will need to use WebAuthn, save data
@@ -285,15 +306,23 @@ func SignInPost(ctx *context.Context) {
 }
 
 // This handles the final part of the sign-in process of the user.
-func handleSignIn(ctx *context.Context, u *user_model.User, remember bool) {
-	redirect := handleSignInFull(ctx, u, remember, true)
+// twoFactorSignInMethod returns the method the user authenticated with before the second factor
+func twoFactorSignInMethod(ctx *context.Context) string {
+	if method, ok := ctx.Session.Get("auth_method").(string); ok && method != "" {
+		return method
+	}
+	return auth.UserSessionAuthPassword
+}
+
+func handleSignIn(ctx *context.Context, u *user_model.User, remember bool, method string) {
+	redirect := handleSignInFull(ctx, u, remember, true, method)
 	if ctx.Written() {
 		return
 	}
 	ctx.Redirect(redirect)
 }
 
-func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRedirect bool) string {
+func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRedirect bool, method string) string {
 	if remember {
 		if err := ctx.SetLTACookie(u); err != nil {
 			ctx.ServerError("GenerateAuthToken", err)
@@ -311,8 +340,11 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 		"twofaRemember",
 		"twofaOpenID",
 		"linkAccount",
+		// a new session is tracked for the user
+		"user_session",
 	}, map[string]any{
-		"uid": u.ID,
+		"uid":         u.ID,
+		"auth_method": method,
 	}); err != nil {
 		ctx.ServerError("RegenerateSession", err)
 		return setting.AppSubURL + "/"
@@ -368,6 +400,9 @@ func getUserName(gothUser *goth.User) (string, error) {
 
 // HandleSignOut resets the session and sets the cookies
 func HandleSignOut(ctx *context.Context) {
+	if err := auth.DeleteUserSessionByToken(ctx, auth_service.UserSessionToken(ctx.Session)); err != nil {
+		log.Error("DeleteUserSessionByToken: %v", err)
+	}
 	_ = ctx.Session.Flush()
 	_ = ctx.Session.Destroy(ctx.Resp, ctx.Req)
 	ctx.DeleteSiteCookie(setting.CookieRememberName)
@@ -502,7 +537,7 @@ func SignUpPost(ctx *context.Context) {
 	}
 
 	ctx.Flash.Success(ctx.Tr("auth.sign_up_successful"))
-	handleSignIn(ctx, u, false)
+	handleSignIn(ctx, u, false, auth.UserSessionAuthPassword)
 }
 
 // createAndHandleCreatedUser calls createUserInContext and
@@ -806,8 +841,9 @@ func handleAccountActivation(ctx *context.Context, user *user_model.User) {
 
 	log.Trace("User activated: %s", user.Name)
 
-	if err := updateSession(ctx, nil, map[string]any{
-		"uid": user.ID,
+	if err := updateSession(ctx, []string{"user_session"}, map[string]any{
+		"uid":         user.ID,
+		"auth_method": auth.UserSessionAuthPassword,
 	}); err != nil {
 		log.Error("Unable to regenerate session for user: %-v with email: %s: %v", user, user.Email, err)
 		ctx.ServerError("ActivateUserEmail", err)
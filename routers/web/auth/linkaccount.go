@@ -155,7 +155,7 @@ func linkAccount(ctx *context.Context, u *user_model.User, gothUser goth.User, r
 			return
 		}
 
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth.UserSessionAuthOAuth2)
 		return
 	}
 
@@ -164,6 +164,7 @@ func linkAccount(ctx *context.Context, u *user_model.User, gothUser goth.User, r
 		"twofaUid":      u.ID,
 		"twofaRemember": remember,
 		"linkAccount":   true,
+		"auth_method":   auth.UserSessionAuthOAuth2,
 	}); err != nil {
 		ctx.ServerError("RegenerateSession", err)
 		return
@@ -280,5 +281,5 @@ func LinkAccountPostRegister(ctx *context.Context) {
 		return
 	}
 
-	handleSignIn(ctx, u, false)
+	handleSignIn(ctx, u, false, auth.UserSessionAuthOAuth2)
 }
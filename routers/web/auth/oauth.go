@@ -706,36 +706,9 @@ func OIDCKeys(ctx *context.Context) {
 // AccessTokenOAuth manages all access token requests by the client
 func AccessTokenOAuth(ctx *context.Context) {
 	form := *web.GetForm(ctx).(*forms.AccessTokenForm)
-	// if there is no ClientID or ClientSecret in the request body, fill these fields by the Authorization header and ensure the provided field matches the Authorization header
-	if form.ClientID == "" || form.ClientSecret == "" {
-		authHeader := ctx.Req.Header.Get("Authorization")
-		if authType, authData, ok := strings.Cut(authHeader, " "); ok && strings.EqualFold(authType, "Basic") {
-			clientID, clientSecret, err := base.BasicAuthDecode(authData)
-			if err != nil {
-				handleAccessTokenError(ctx, AccessTokenError{
-					ErrorCode:        AccessTokenErrorCodeInvalidRequest,
-					ErrorDescription: "cannot parse basic auth header",
-				})
-				return
-			}
-			// validate that any fields present in the form match the Basic auth header
-			if form.ClientID != "" && form.ClientID != clientID {
-				handleAccessTokenError(ctx, AccessTokenError{
-					ErrorCode:        AccessTokenErrorCodeInvalidRequest,
-					ErrorDescription: "client_id in request body inconsistent with Authorization header",
-				})
-				return
-			}
-			form.ClientID = clientID
-			if form.ClientSecret != "" && form.ClientSecret != clientSecret {
-				handleAccessTokenError(ctx, AccessTokenError{
-					ErrorCode:        AccessTokenErrorCodeInvalidRequest,
-					ErrorDescription: "client_secret in request body inconsistent with Authorization header",
-				})
-				return
-			}
-			form.ClientSecret = clientSecret
-		}
+	if acErr := fillClientCredentials(ctx, &form.ClientID, &form.ClientSecret); acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
 	}
 
 	serverKey := oauth2.DefaultSigningKey
@@ -757,14 +730,52 @@ func AccessTokenOAuth(ctx *context.Context) {
 		handleRefreshToken(ctx, form, serverKey, clientKey)
 	case "authorization_code":
 		handleAuthorizationCode(ctx, form, serverKey, clientKey)
+	case grantTypeDeviceCode:
+		handleDeviceCode(ctx, form, serverKey, clientKey)
 	default:
 		handleAccessTokenError(ctx, AccessTokenError{
 			ErrorCode:        AccessTokenErrorCodeUnsupportedGrantType,
-			ErrorDescription: "Only refresh_token or authorization_code grant type is supported",
+			ErrorDescription: "Only refresh_token, authorization_code or device_code grant type is supported",
 		})
 	}
 }
 
+// fillClientCredentials fills the client ID and secret by the Authorization header if they are not in the request body
+// and ensures the provided fields match the Authorization header
+func fillClientCredentials(ctx *context.Context, formClientID, formClientSecret *string) *AccessTokenError {
+	if *formClientID != "" && *formClientSecret != "" {
+		return nil
+	}
+	authHeader := ctx.Req.Header.Get("Authorization")
+	authType, authData, ok := strings.Cut(authHeader, " ")
+	if !ok || !strings.EqualFold(authType, "Basic") {
+		return nil
+	}
+	clientID, clientSecret, err := base.BasicAuthDecode(authData)
+	if err != nil {
+		return &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "cannot parse basic auth header",
+		}
+	}
+	// validate that any fields present in the form match the Basic auth header
+	if *formClientID != "" && *formClientID != clientID {
+		return &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "client_id in request body inconsistent with Authorization header",
+		}
+	}
+	*formClientID = clientID
+	if *formClientSecret != "" && *formClientSecret != clientSecret {
+		return &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "client_secret in request body inconsistent with Authorization header",
+		}
+	}
+	*formClientSecret = clientSecret
+	return nil
+}
+
 func handleRefreshToken(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey jwtx.SigningKey) {
 	app, err := auth.GetOAuth2ApplicationByClientID(ctx, form.ClientID)
 	if err != nil {
@@ -1306,8 +1317,9 @@ func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model
 	// If this user is enrolled in 2FA and this source doesn't override it,
 	// we can't sign the user in just yet. Instead, redirect them to the 2FA authentication page.
 	if !needs2FA {
-		if err := updateSession(ctx, nil, map[string]any{
-			"uid": u.ID,
+		if err := updateSession(ctx, []string{"user_session"}, map[string]any{
+			"uid":         u.ID,
+			"auth_method": auth.UserSessionAuthOAuth2,
 		}); err != nil {
 			ctx.ServerError("updateSession", err)
 			return
@@ -1385,6 +1397,7 @@ func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model
 		// User needs to use 2FA, save data and redirect to 2FA page.
 		"twofaUid":      u.ID,
 		"twofaRemember": false,
+		"auth_method":   auth.UserSessionAuthOAuth2,
 	}); err != nil {
 		ctx.ServerError("updateSession", err)
 		return
@@ -0,0 +1,293 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/jwtx"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const tplDeviceVerification base.TplName = "user/auth/device"
+
+// grantTypeDeviceCode is the grant type of access token requests of devices
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+const grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+const (
+	// AccessTokenErrorCodeAuthorizationPending represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAuthorizationPending AccessTokenErrorCode = "authorization_pending"
+	// AccessTokenErrorCodeSlowDown represents an error code specified in RFC 8628
+	AccessTokenErrorCodeSlowDown AccessTokenErrorCode = "slow_down"
+	// AccessTokenErrorCodeAccessDenied represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAccessDenied AccessTokenErrorCode = "access_denied"
+	// AccessTokenErrorCodeExpiredToken represents an error code specified in RFC 8628
+	AccessTokenErrorCodeExpiredToken AccessTokenErrorCode = "expired_token"
+)
+
+// DeviceAuthorizationResponse represents a successful device authorization response
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+func deviceVerificationURI() string {
+	return setting.AppURL + "login/oauth/device"
+}
+
+// loadDeviceFlowApplication loads the application of a client and authenticates it like the token endpoint does
+func loadDeviceFlowApplication(ctx *context.Context, clientID, clientSecret string) (*auth.OAuth2Application, *AccessTokenError) {
+	app, err := auth.GetOAuth2ApplicationByClientID(ctx, clientID)
+	if err != nil {
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: fmt.Sprintf("cannot load client with client id: %q", clientID),
+		}
+	}
+	if app.ConfidentialClient && !app.ValidateClientSecret([]byte(clientSecret)) {
+		errorDescription := "invalid client secret"
+		if clientSecret == "" {
+			errorDescription = "invalid empty client secret"
+		}
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: errorDescription,
+		}
+	}
+	if !app.EnableDeviceFlow {
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeUnauthorizedClient,
+			ErrorDescription: "the device authorization grant is not enabled for this client",
+		}
+	}
+	return app, nil
+}
+
+// DeviceAuthorizationOAuth starts the device authorization grant for a client
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func DeviceAuthorizationOAuth(ctx *context.Context) {
+	form := *web.GetForm(ctx).(*forms.DeviceAuthorizationForm)
+	if acErr := fillClientCredentials(ctx, &form.ClientID, &form.ClientSecret); acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	app, acErr := loadDeviceFlowApplication(ctx, form.ClientID, form.ClientSecret)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	code, err := app.CreateDeviceCode(ctx, form.Scope)
+	if err != nil {
+		log.Error("Unable to create device code: %v", err)
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "cannot proceed your request",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &DeviceAuthorizationResponse{
+		DeviceCode:              code.PlainDeviceCode,
+		UserCode:                code.UserCode,
+		VerificationURI:         deviceVerificationURI(),
+		VerificationURIComplete: deviceVerificationURI() + "?user_code=" + url.QueryEscape(code.UserCode),
+		ExpiresIn:               setting.OAuth2.DeviceCodeExpirationTime,
+		Interval:                code.Interval,
+	})
+}
+
+// handleDeviceCode answers the polling of a device for an access token
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+func handleDeviceCode(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey jwtx.SigningKey) {
+	app, acErr := loadDeviceFlowApplication(ctx, form.ClientID, form.ClientSecret)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	code, err := auth.GetOAuth2DeviceCodeByDeviceCode(ctx, app.ID, form.DeviceCode)
+	if err != nil || code == nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid device code",
+		})
+		return
+	}
+
+	switch code.Status {
+	case auth.OAuth2DeviceCodePending:
+		if code.IsExpired() {
+			if err := code.Invalidate(ctx); err != nil {
+				log.Error("Unable to invalidate device code: %v", err)
+			}
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeExpiredToken,
+				ErrorDescription: "the device code has expired",
+			})
+			return
+		}
+		inInterval, err := code.Poll(ctx)
+		if err != nil {
+			log.Error("Unable to update device code: %v", err)
+		}
+		if !inInterval {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeSlowDown,
+				ErrorDescription: fmt.Sprintf("the polling interval is now %d seconds", code.Interval),
+			})
+			return
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAuthorizationPending,
+			ErrorDescription: "the user has not yet approved the device",
+		})
+		return
+	case auth.OAuth2DeviceCodeDenied:
+		if err := code.Invalidate(ctx); err != nil {
+			log.Error("Unable to invalidate device code: %v", err)
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAccessDenied,
+			ErrorDescription: "the user has denied the device",
+		})
+		return
+	}
+
+	// remove the device code from database to deny duplicate usage
+	if err := code.Invalidate(ctx); err != nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "cannot proceed your request",
+		})
+		return
+	}
+	grant, err := app.GetGrantByUserID(ctx, code.UserID)
+	if err != nil || grant == nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "grant does not exist",
+		})
+		return
+	}
+	resp, tokenErr := newAccessTokenResponse(ctx, grant, serverKey, clientKey)
+	if tokenErr != nil {
+		handleAccessTokenError(ctx, *tokenErr)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// renderDeviceVerification shows the page to enter a user code, or to approve the device of a valid user code
+func renderDeviceVerification(ctx *context.Context, userCode string) {
+	ctx.Data["Title"] = ctx.Tr("auth.device.title")
+	ctx.Data["UserCode"] = userCode
+	if userCode == "" {
+		ctx.HTML(http.StatusOK, tplDeviceVerification)
+		return
+	}
+
+	code, err := auth.GetOAuth2DeviceCodeByUserCode(ctx, userCode)
+	if err != nil {
+		ctx.ServerError("GetOAuth2DeviceCodeByUserCode", err)
+		return
+	}
+	if code == nil {
+		ctx.Data["Err_UserCode"] = true
+		ctx.RenderWithErr(ctx.Tr("auth.device.invalid_code"), tplDeviceVerification, nil)
+		return
+	}
+
+	ctx.Data["DeviceCode"] = code
+	ctx.Data["Application"] = code.Application
+	if code.Application.UID != 0 {
+		creator, err := user_model.GetUserByID(ctx, code.Application.UID)
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">@%s</a>`, html.EscapeString(creator.HomeLink()), html.EscapeString(creator.Name)))
+	} else {
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(setting.AppSubURL+"/"), html.EscapeString(setting.AppName)))
+	}
+	ctx.HTML(http.StatusOK, tplDeviceVerification)
+}
+
+// DeviceVerification shows the page where users enter the code displayed by a device
+func DeviceVerification(ctx *context.Context) {
+	renderDeviceVerification(ctx, ctx.FormTrim("user_code"))
+}
+
+// DeviceVerificationPost approves or denies a device
+func DeviceVerificationPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceVerificationForm)
+	if ctx.HasError() {
+		renderDeviceVerification(ctx, "")
+		return
+	}
+
+	code, err := auth.GetOAuth2DeviceCodeByUserCode(ctx, form.UserCode)
+	if err != nil {
+		ctx.ServerError("GetOAuth2DeviceCodeByUserCode", err)
+		return
+	}
+	if code == nil {
+		ctx.Data["Title"] = ctx.Tr("auth.device.title")
+		ctx.Data["UserCode"] = form.UserCode
+		ctx.Data["Err_UserCode"] = true
+		ctx.RenderWithErr(ctx.Tr("auth.device.invalid_code"), tplDeviceVerification, nil)
+		return
+	}
+
+	if !form.Granted {
+		if err := code.Deny(ctx, ctx.Doer.ID); err != nil {
+			ctx.ServerError("Deny", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("auth.device.denied"))
+		ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+		return
+	}
+
+	grant, err := code.Application.GetGrantByUserID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetGrantByUserID", err)
+		return
+	}
+	if grant == nil {
+		if _, err := code.Application.CreateGrant(ctx, ctx.Doer.ID, code.Scope); err != nil {
+			ctx.ServerError("CreateGrant", err)
+			return
+		}
+	} else if grant.Scope != code.Scope {
+		ctx.Flash.Error(ctx.Tr("auth.device.scope_mismatch"))
+		ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+		return
+	}
+
+	if err := code.Approve(ctx, ctx.Doer.ID); err != nil {
+		ctx.ServerError("Approve", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("auth.device.approved"))
+	ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+}
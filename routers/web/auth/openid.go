@@ -156,7 +156,7 @@ func signInOpenIDVerify(ctx *context.Context) {
 		log.Trace("User exists, logging in")
 		remember, _ := ctx.Session.Get("openid_signin_remember").(bool)
 		log.Trace("Session stored openid-remember: %t", remember)
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth_model.UserSessionAuthOpenID)
 		return
 	}
 
@@ -295,7 +295,7 @@ func ConnectOpenIDPost(ctx *context.Context) {
 		}
 
 		ctx.Flash.Success(ctx.Tr("settings.add_openid_success"))
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth_model.UserSessionAuthOpenID)
 		return
 	}
 
@@ -310,6 +310,7 @@ func ConnectOpenIDPost(ctx *context.Context) {
 		"twofaUid":      u.ID,
 		"twofaRemember": remember,
 		"twofaOpenID":   oid,
+		"auth_method":   auth_model.UserSessionAuthOpenID,
 	}); err != nil {
 		ctx.ServerError("Unable to update session", err)
 		return
@@ -421,5 +422,5 @@ func RegisterOpenIDPost(ctx *context.Context) {
 
 	remember, _ := ctx.Session.Get("openid_signin_remember").(bool)
 	log.Trace("Session stored openid-remember: %t", remember)
-	handleSignIn(ctx, u, remember)
+	handleSignIn(ctx, u, remember, auth_model.UserSessionAuthOpenID)
 }
@@ -18,6 +18,7 @@ import (
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/web"
 	"forgejo.org/modules/web/middleware"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -253,7 +254,7 @@ func ResetPasswdPost(ctx *context.Context) {
 			return
 		}
 
-		handleSignInFull(ctx, u, remember, false)
+		handleSignInFull(ctx, u, remember, false, auth.UserSessionAuthPassword)
 		if ctx.Written() {
 			return
 		}
@@ -262,7 +263,7 @@ func ResetPasswdPost(ctx *context.Context) {
 		return
 	}
 
-	handleSignIn(ctx, u, remember)
+	handleSignIn(ctx, u, remember, auth.UserSessionAuthPassword)
 }
 
 // MustChangePassword renders the page to change a user's password
@@ -300,6 +301,7 @@ func MustChangePasswordPost(ctx *context.Context) {
 	opts := &user_service.UpdateAuthOptions{
 		Password:           optional.Some(form.Password),
 		MustChangePassword: optional.Some(false),
+		KeepSession:        auth_service.UserSessionToken(ctx.Session),
 	}
 	if err := user_service.UpdateAuth(ctx, ctx.Doer, opts); err != nil {
 		switch {
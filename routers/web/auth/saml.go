@@ -0,0 +1,157 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/auth/source/saml"
+	"forgejo.org/services/context"
+)
+
+const (
+	samlRequestCookieName = "saml_request"
+	// maxRelayStateLength is the maximum length of the relay state allowed by the SAML bindings
+	maxRelayStateLength = 80
+)
+
+func getSAMLSource(ctx *context.Context) (*auth.Source, *saml.Source) {
+	authSource, err := auth.GetActiveSAMLSourceByName(ctx, ctx.Params(":provider"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetActiveSAMLSourceByName", err)
+		} else {
+			ctx.ServerError("GetActiveSAMLSourceByName", err)
+		}
+		return nil, nil
+	}
+	return authSource, authSource.Cfg.(*saml.Source)
+}
+
+// setSAMLRequestCookie stores the ID of the pending authentication request.
+// The identity provider posts its response cross-site, so the cookie must not be restricted to same-site requests.
+func setSAMLRequestCookie(ctx *context.Context, requestID string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		Value:    requestID,
+		MaxAge:   maxAge,
+		Path:     setting.AppSubURL + "/user/saml/",
+		Domain:   setting.SessionConfig.Domain,
+		Secure:   setting.SessionConfig.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if cookie.Secure {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(ctx.Resp, cookie)
+}
+
+// SignInSAML redirects the user to the identity provider of a SAML source
+func SignInSAML(ctx *context.Context) {
+	_, source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	relayState := ctx.FormString("redirect_to")
+	if len(relayState) > maxRelayStateLength {
+		middleware.SetRedirectToCookie(ctx.Resp, relayState)
+		relayState = ""
+	}
+
+	redirectURL, requestID, err := source.AuthenticationRequest(ctx, relayState)
+	if err != nil {
+		ctx.ServerError("AuthenticationRequest", err)
+		return
+	}
+	setSAMLRequestCookie(ctx, requestID, 10*60)
+	ctx.Redirect(redirectURL)
+}
+
+// SAMLMetadata serves the service provider metadata of a SAML source
+func SAMLMetadata(ctx *context.Context) {
+	_, source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	sp, err := source.ServiceProvider(ctx)
+	if err != nil {
+		ctx.ServerError("ServiceProvider", err)
+		return
+	}
+	metadata, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if err != nil {
+		ctx.ServerError("MarshalIndent", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/samlmetadata+xml")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(metadata)
+}
+
+// SAMLAssertionConsumer handles the response the identity provider posts after the user signed in
+func SAMLAssertionConsumer(ctx *context.Context) {
+	authSource, source := getSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var requestID string
+	if cookie, err := ctx.Req.Cookie(samlRequestCookieName); err == nil {
+		requestID = cookie.Value
+	}
+	setSAMLRequestCookie(ctx, "", -1)
+	if requestID == "" {
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error.expired"))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+
+	info, err := source.ParseResponse(ctx, ctx.Req, requestID)
+	if err != nil {
+		log.Warn("Failed SAML authentication attempt with source %q from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error"))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+		return
+	}
+
+	u, err := source.Authenticate(ctx, info)
+	if err != nil {
+		switch {
+		case user_model.IsErrUserProhibitLogin(err):
+			log.Info("Failed authentication attempt for %s from %s: %v", info.NameID, ctx.RemoteAddr(), err)
+			ctx.Data["Title"] = ctx.Tr("auth.prohibit_login")
+			ctx.HTML(http.StatusOK, "user/auth/prohibit_login")
+		case user_model.IsErrUserAlreadyExist(err):
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.error.username_taken", info.Username))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+		case user_model.IsErrEmailAlreadyUsed(err):
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.error.email_used", info.Email))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+		default:
+			ctx.ServerError("Authenticate", err)
+		}
+		return
+	}
+
+	// The site cookies are not sent along with the cross-site post of the identity provider,
+	// so the page to return to travels in the relay state instead.
+	if relayState := ctx.Req.PostFormValue("RelayState"); relayState != "" {
+		ctx.Req.AddCookie(&http.Cookie{Name: "redirect_to", Value: url.QueryEscape(relayState)})
+		middleware.SetRedirectToCookie(ctx.Resp, relayState)
+	}
+
+	handleSignInTwoFactor(ctx, authSource, u, false, auth.UserSessionAuthSAML)
+}
@@ -175,7 +175,7 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 	}
 
 	remember := ctx.Session.Get("twofaRemember").(bool)
-	redirect := handleSignInFull(ctx, user, remember, false)
+	redirect := handleSignInFull(ctx, user, remember, false, twoFactorSignInMethod(ctx))
 	if redirect == "" {
 		redirect = setting.AppSubURL + "/"
 	}
@@ -183,3 +183,101 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 
 	ctx.JSONRedirect(redirect)
 }
+
+// errPasskeyNotAllowed is returned when a passkey may not be used to sign in
+var errPasskeyNotAllowed = errors.New("passkey cannot be used to sign in")
+
+// PasskeyLoginAssertion submits a challenge for a discoverable credential to the browser
+func PasskeyLoginAssertion(ctx *context.Context) {
+	assertion, sessionData, err := wa.WebAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		ctx.ServerError("webauthn.BeginDiscoverableLogin", err)
+		return
+	}
+
+	if err := ctx.Session.Set("webauthnPasskeyAssertion", sessionData); err != nil {
+		ctx.ServerError("Session.Set", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, assertion)
+}
+
+// PasskeyLoginAssertionPost validates the signature of a passkey and logs its user in without a password
+func PasskeyLoginAssertionPost(ctx *context.Context) {
+	sessionData, ok := ctx.Session.Get("webauthnPasskeyAssertion").(*webauthn.SessionData)
+	if !ok || sessionData == nil {
+		ctx.ServerError("UserSignIn", errors.New("not in passkey session"))
+		return
+	}
+	defer func() {
+		_ = ctx.Session.Delete("webauthnPasskeyAssertion")
+	}()
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponse(ctx.Req)
+	if err != nil {
+		log.Info("Failed passkey authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	var (
+		user   *user_model.User
+		dbCred *auth.WebAuthnCredential
+	)
+	// The user is identified by the user handle which the authenticator stored with the passkey.
+	loadUser := func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID, ok := wa.UserIDFromWebAuthnID(userHandle)
+		if !ok {
+			return nil, errPasskeyNotAllowed
+		}
+		u, err := user_model.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !u.IsIndividual() || !u.IsActive || u.ProhibitLogin {
+			return nil, errPasskeyNotAllowed
+		}
+		if u.LoginSource > 0 {
+			source, err := auth.GetSourceByID(ctx, u.LoginSource)
+			if err != nil {
+				return nil, err
+			}
+			if !source.IsActive {
+				return nil, errPasskeyNotAllowed
+			}
+		}
+		// Security keys which were registered only as a second factor cannot replace the password.
+		dbCred, err = auth.GetPasskeyByCredID(ctx, u.ID, rawID)
+		if err != nil {
+			return nil, err
+		}
+		user = u
+		return (*wa.User)(u), nil
+	}
+
+	_, cred, err := wa.WebAuthn.ValidatePasskeyLogin(loadUser, *sessionData, parsedResponse)
+	if err != nil {
+		log.Info("Failed passkey authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	// Ensure that the credential wasn't cloned by checking if CloneWarning is set.
+	if cred.Authenticator.CloneWarning {
+		log.Info("Failed passkey authentication attempt for %s from %s: cloned credential", user.Name, ctx.RemoteAddr())
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	dbCred.SignCount = cred.Authenticator.SignCount
+	if err := dbCred.UpdateSignCount(ctx); err != nil {
+		ctx.ServerError("UpdateSignCount", err)
+		return
+	}
+
+	redirect := handleSignInFull(ctx, user, false, false, auth.UserSessionAuthWebAuthn)
+	if redirect == "" {
+		redirect = setting.AppSubURL + "/"
+	}
+	ctx.JSONRedirect(redirect)
+}
@@ -6,10 +6,12 @@ package explore
 
 import (
 	"net/http"
+	"slices"
 
 	"forgejo.org/models/db"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/modules/base"
+	"forgejo.org/modules/container"
 	code_indexer "forgejo.org/modules/indexer/code"
 	"forgejo.org/modules/setting"
 	"forgejo.org/routers/common"
@@ -65,6 +67,16 @@ func Code(ctx *context.Context) {
 			ctx.ServerError("FindUserCodeAccessibleRepoIDs", err)
 			return
 		}
+
+		blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+		if err != nil {
+			ctx.ServerError("BlockedRepoIDs", err)
+			return
+		}
+		if len(blockedRepoIDs) > 0 {
+			blocked := container.SetOf(blockedRepoIDs...)
+			repoIDs = slices.DeleteFunc(repoIDs, blocked.Contains)
+		}
 	}
 
 	var (
@@ -0,0 +1,194 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowSavedSearchFeedRSS shows the issues of a saved search as RSS feed
+func ShowSavedSearchFeedRSS(ctx *context.Context) {
+	showSavedSearchFeed(ctx, "rss")
+}
+
+// ShowSavedSearchFeedAtom shows the issues of a saved search as Atom feed
+func ShowSavedSearchFeedAtom(ctx *context.Context) {
+	showSavedSearchFeed(ctx, "atom")
+}
+
+// showSavedSearchFeed shows the issues of a saved search as RSS / Atom feed
+func showSavedSearchFeed(ctx *context.Context, formatType string) {
+	publicOnly, ok := checkSavedSearchFeedToken(ctx)
+	if !ok {
+		return
+	}
+
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetSavedSearchByID", issues_model.IsErrSavedSearchNotExist, err)
+		return
+	}
+	if canSee, err := search.CanBeSeenBy(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("CanBeSeenBy", err)
+		return
+	} else if !canSee {
+		ctx.NotFound("CanBeSeenBy", nil)
+		return
+	}
+	if err := search.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.ServerError("BlockedRepoIDs", err)
+		return
+	}
+	issues, _, err := issue_service.SearchSavedSearchIssues(ctx, ctx.Doer, search, blockedRepoIDs, db.ListOptions{
+		Page:     1,
+		PageSize: setting.UI.FeedPagingNum,
+	})
+	if err != nil {
+		ctx.ServerError("SearchSavedSearchIssues", err)
+		return
+	}
+	if issues, err = filterIssuesForToken(ctx, issues, publicOnly); err != nil {
+		ctx.ServerError("filterIssuesForToken", err)
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:   ctx.Locale.TrString("home.saved_search.feed_of", search.Name),
+		Link:    &feeds.Link{Href: search.HTMLURL()},
+		Created: time.Now(),
+	}
+
+	feed.Items, err = issuesToFeedItems(ctx, issues)
+	if err != nil {
+		ctx.ServerError("issuesToFeedItems", err)
+		return
+	}
+
+	writeFeed(ctx, feed, formatType)
+}
+
+// checkSavedSearchFeedToken responds with an error and returns false if the token the request is authenticated with
+// cannot read issues. It returns whether the token is limited to public repositories.
+func checkSavedSearchFeedToken(ctx *context.Context) (publicOnly, ok bool) {
+	if ctx.Data["IsApiToken"] != true {
+		return false, true
+	}
+	scope, has := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	if !has {
+		return false, true
+	}
+
+	scopeMatched, err := scope.HasScope(auth_model.AccessTokenScopeReadIssue)
+	if err != nil {
+		ctx.ServerError("HasScope", err)
+		return false, false
+	}
+	if !scopeMatched {
+		ctx.Error(http.StatusForbidden)
+		return false, false
+	}
+
+	publicOnly, err = scope.PublicOnly()
+	if err != nil {
+		ctx.ServerError("PublicOnly", err)
+		return false, false
+	}
+	return publicOnly, true
+}
+
+// filterIssuesForToken removes the issues the token the request is authenticated with cannot read:
+// the ones in private repositories for public-only tokens and, for fine-grained tokens, the ones
+// outside of their repositories.
+func filterIssuesForToken(ctx *context.Context, issues issues_model.IssueList, publicOnly bool) (issues_model.IssueList, error) {
+	t := ctx.AccessToken()
+	if !publicOnly && (t == nil || !t.IsFineGrained()) {
+		return issues, nil
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+
+	filtered := make(issues_model.IssueList, 0, len(issues))
+	for _, issue := range issues {
+		if publicOnly && issue.Repo.IsPrivate {
+			continue
+		}
+		if t != nil && t.IsFineGrained() {
+			unitType := unit.TypeIssues
+			if issue.IsPull {
+				unitType = unit.TypePullRequests
+			}
+			if !t.AllowsRepository(issue.RepoID, issue.Repo.OwnerID) || t.Permissions.UnitAccessMode(unitType) < perm.AccessModeRead {
+				continue
+			}
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered, nil
+}
+
+// issuesToFeedItems converts issues and pull requests into feed items
+func issuesToFeedItems(ctx *context.Context, issues issues_model.IssueList) (items []*feeds.Item, err error) {
+	if err := issues.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+
+	composeCache := make(map[int64]map[string]string)
+	for _, issue := range issues {
+		metas, ok := composeCache[issue.RepoID]
+		if !ok {
+			metas = issue.Repo.ComposeMetas(ctx)
+			composeCache[issue.RepoID] = metas
+		}
+
+		link := &feeds.Link{Href: issue.HTMLURL()}
+		content, err := markdown.RenderString(&markup.RenderContext{
+			Ctx: ctx,
+			Links: markup.Links{
+				Base: issue.Repo.Link(),
+			},
+			Metas: metas,
+		}, issue.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, &feeds.Item{
+			Title:   fmt.Sprintf("%s#%d: %s", issue.Repo.FullName(), issue.Index, issue.Title),
+			Link:    link,
+			Created: issue.CreatedUnix.AsTime(),
+			Updated: issue.UpdatedUnix.AsTime(),
+			Author: &feeds.Author{
+				Name:  issue.Poster.GetDisplayName(),
+				Email: issue.Poster.GetEmail(),
+			},
+			Id:      fmt.Sprintf("%v: %v", strconv.FormatInt(issue.ID, 10), link.Href),
+			Content: string(content),
+		})
+	}
+
+	return items, nil
+}
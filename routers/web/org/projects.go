@@ -20,6 +20,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/templates"
 	"forgejo.org/modules/web"
+	project_shared "forgejo.org/routers/web/shared/project"
 	shared_user "forgejo.org/routers/web/shared/user"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -339,6 +340,16 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 
+	project_shared.PrepareProjectViews(ctx, project, ctx.Org.Organization)
+	if ctx.Written() {
+		return
+	}
+
+	project_shared.PrepareProjectAutomation(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	columns, err := project.GetColumns(ctx)
 	if err != nil {
 		ctx.ServerError("GetProjectColumns", err)
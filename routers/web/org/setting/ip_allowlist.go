@@ -0,0 +1,78 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	org_model "forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/context"
+	org_service "forgejo.org/services/org"
+)
+
+const tplSettingsIPAllowlist base.TplName = "org/settings/ip_allowlist"
+
+// IPAllowlist shows the IP networks the access to an organization is restricted to
+func IPAllowlist(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.ip_allowlist")
+	ctx.Data["PageIsSettingsIPAllowlist"] = true
+	ctx.Data["RemoteHost"] = middleware.GetRemoteHost(ctx)
+
+	allowlist, err := org_model.GetIPAllowlist(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetIPAllowlist", err)
+		return
+	}
+	ctx.Data["Allowlist"] = allowlist
+
+	ctx.HTML(http.StatusOK, tplSettingsIPAllowlist)
+}
+
+// IPAllowlistPost restricts the access to an organization to a list of IP networks
+func IPAllowlistPost(ctx *context.Context) {
+	link := ctx.Org.OrgLink + "/settings/ip_allowlist"
+	allowedIPs := ctx.FormString("allowed_ips")
+
+	hl, err := org_service.ParseIPAllowlist(allowedIPs)
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("org.settings.ip_allowlist.invalid", err.Error()))
+		ctx.Redirect(link)
+		return
+	}
+
+	// owners must not lock themselves out
+	if !ctx.Doer.IsAdmin && !hl.MatchHostName(ctx.RemoteAddr()) {
+		ctx.Flash.Error(ctx.Tr("org.settings.ip_allowlist.doer_not_allowed", middleware.GetRemoteHost(ctx)))
+		ctx.Redirect(link)
+		return
+	}
+
+	if err := org_service.SetIPAllowlist(ctx, ctx.Doer, ctx.Org.Organization, allowedIPs); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("org.settings.ip_allowlist.invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("SetIPAllowlist", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.ip_allowlist.update_success"))
+	ctx.Redirect(link)
+}
+
+// IPAllowlistRemove no longer restricts the access to an organization
+func IPAllowlistRemove(ctx *context.Context) {
+	if err := org_service.RemoveIPAllowlist(ctx, ctx.Doer, ctx.Org.Organization); err != nil {
+		ctx.ServerError("RemoveIPAllowlist", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.ip_allowlist.remove_success"))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/ip_allowlist")
+}
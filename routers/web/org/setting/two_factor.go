@@ -0,0 +1,96 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/http"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	org_model "forgejo.org/models/organization"
+	"forgejo.org/modules/base"
+	"forgejo.org/services/context"
+	org_service "forgejo.org/services/org"
+)
+
+const (
+	tplSettingsTwoFactor base.TplName = "org/settings/two_factor"
+
+	// maxTwoFactorGracePeriod is the longest grace period in days which owners can give
+	maxTwoFactorGracePeriod = 90
+)
+
+// TwoFactor shows the two-factor requirement of an organization and the users who do not comply with it
+func TwoFactor(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.two_factor")
+	ctx.Data["PageIsSettingsTwoFactor"] = true
+	ctx.Data["MaxGracePeriod"] = maxTwoFactorGracePeriod
+
+	org := ctx.Org.Organization
+	requirement, err := org_model.GetTwoFactorRequirement(ctx, org.ID)
+	if err != nil {
+		ctx.ServerError("GetTwoFactorRequirement", err)
+		return
+	}
+	ctx.Data["Requirement"] = requirement
+
+	members, err := org_model.GetMembersWithoutTwoFactor(ctx, org.ID)
+	if err != nil {
+		ctx.ServerError("GetMembersWithoutTwoFactor", err)
+		return
+	}
+	ctx.Data["Members"] = members
+
+	collaborators, err := org_model.GetOutsideCollaboratorsWithoutTwoFactor(ctx, org.ID)
+	if err != nil {
+		ctx.ServerError("GetOutsideCollaboratorsWithoutTwoFactor", err)
+		return
+	}
+	ctx.Data["OutsideCollaborators"] = collaborators
+
+	ctx.HTML(http.StatusOK, tplSettingsTwoFactor)
+}
+
+// TwoFactorPost requires two-factor authentication in an organization after a grace period
+func TwoFactorPost(ctx *context.Context) {
+	link := ctx.Org.OrgLink + "/settings/two_factor"
+
+	gracePeriod := ctx.FormInt("grace_period")
+	if gracePeriod < 0 || gracePeriod > maxTwoFactorGracePeriod {
+		ctx.Flash.Error(ctx.Tr("org.settings.two_factor.grace_period_invalid", maxTwoFactorGracePeriod))
+		ctx.Redirect(link)
+		return
+	}
+
+	// owners must not lock themselves out
+	hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("HasTwoFactorByUID", err)
+		return
+	}
+	if !hasTwoFactor {
+		ctx.Flash.Error(ctx.Tr("org.settings.two_factor.doer_without_two_factor"))
+		ctx.Redirect(link)
+		return
+	}
+
+	if err := org_service.RequireTwoFactor(ctx, ctx.Doer, ctx.Org.Organization, time.Duration(gracePeriod)*24*time.Hour); err != nil {
+		ctx.ServerError("RequireTwoFactor", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.two_factor.required_success"))
+	ctx.Redirect(link)
+}
+
+// TwoFactorRemove no longer requires two-factor authentication in an organization
+func TwoFactorRemove(ctx *context.Context) {
+	if err := org_service.RemoveTwoFactorRequirement(ctx, ctx.Doer, ctx.Org.Organization); err != nil {
+		ctx.ServerError("RemoveTwoFactorRequirement", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.settings.two_factor.removed_success"))
+	ctx.JSONRedirect(ctx.Org.OrgLink + "/settings/two_factor")
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/base"
+	shared "forgejo.org/routers/web/shared/audit"
+	"forgejo.org/services/context"
+)
+
+const tplSettingsAudit base.TplName = "org/settings/audit"
+
+// Audit shows the audit log of an organization
+func Audit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.audit")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	shared.SetEventsContext(ctx, ctx.Org.Organization.ID, 0)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplSettingsAudit)
+}
+
+// AuditExport exports the audit log of an organization as JSON lines
+func AuditExport(ctx *context.Context) {
+	shared.ExportEvents(ctx, ctx.Org.Organization.ID, 0)
+}
@@ -129,3 +129,57 @@ func RebuildCargoIndex(ctx *context.Context) {
 
 	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
 }
+
+func AddPackageRemote(ctx *context.Context) {
+	shared.AddRemote(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func DeletePackageRemote(ctx *context.Context) {
+	shared.DeleteRemote(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSONRedirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func AddPackageAccess(ctx *context.Context) {
+	shared.AddAccess(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func DeletePackageAccess(ctx *context.Context) {
+	shared.DeleteAccess(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSONRedirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func UpdatePackageSigningPolicy(ctx *context.Context) {
+	shared.UpdateSigningPolicy(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
+
+func UpdatePackageImmutabilityPolicy(ctx *context.Context) {
+	shared.UpdateImmutabilityPolicy(ctx, ctx.ContextUser)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s/org/%s/settings/packages", setting.AppSubURL, ctx.ContextUser.Name))
+}
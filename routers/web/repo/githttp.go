@@ -103,6 +103,9 @@ func httpBase(ctx *context.Context) *serviceHandler {
 		ctx.PlainText(http.StatusForbidden, "Repository cannot be accessed. You cannot push or open issues/pull-requests.")
 		return nil
 	}
+	if !ctx.CheckIPAllowlist(owner.ID) {
+		return nil
+	}
 
 	repoExist := true
 	repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, reponame)
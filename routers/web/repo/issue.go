@@ -2065,6 +2065,12 @@ func ViewIssue(ctx *context.Context) {
 		return
 	}
 
+	// Get sub-issues and parent
+	prepareIssueHierarchy(ctx, issue)
+	if ctx.Written() {
+		return
+	}
+
 	var pinAllowed bool
 	if !issue.IsPinned() {
 		pinAllowed, err = issues_model.IsNewPinAllowed(ctx, issue.RepoID, issue.IsPull)
@@ -2795,6 +2801,10 @@ func SearchIssues(ctx *context.Context) {
 		SortBy:              issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		log.Error("WithKeyword: %v", err)
 		ctx.Error(http.StatusInternalServerError)
 		return
@@ -2969,6 +2979,10 @@ func ListIssues(ctx *context.Context) {
 		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		log.Error("WithKeyword: %v", err)
 		ctx.Error(http.StatusInternalServerError)
 		return
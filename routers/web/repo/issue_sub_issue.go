@@ -0,0 +1,186 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// prepareIssueHierarchy loads the parent, the readable sub-issues and the
+// sub-issue progress of the issue into the template data.
+func prepareIssueHierarchy(ctx *context.Context, issue *issues_model.Issue) {
+	parent, err := issue.GetParentIssue(ctx)
+	if err != nil {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	if parent != nil {
+		perm, err := access_model.GetUserRepoPermission(ctx, parent.Repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		}
+		if perm.CanReadIssuesOrPulls(parent.IsPull) {
+			ctx.Data["ParentIssue"] = parent
+		}
+	}
+
+	subIssues, err := issue.SubIssues(ctx)
+	if err != nil {
+		ctx.ServerError("SubIssues", err)
+		return
+	}
+	repoPerms := make(map[int64]access_model.Permission)
+	repoPerms[ctx.Repo.Repository.ID] = ctx.Repo.Permission
+	readable := make([]*issues_model.DependencyInfo, 0, len(subIssues))
+	for _, subIssue := range subIssues {
+		perm, ok := repoPerms[subIssue.RepoID]
+		if !ok {
+			perm, err = access_model.GetUserRepoPermission(ctx, &subIssue.Repository, ctx.Doer)
+			if err != nil {
+				ctx.ServerError("GetUserRepoPermission", err)
+				return
+			}
+			repoPerms[subIssue.RepoID] = perm
+		}
+		if perm.CanReadIssuesOrPulls(subIssue.IsPull) {
+			readable = append(readable, subIssue)
+		}
+	}
+	ctx.Data["SubIssues"] = readable
+	ctx.Data["SubIssuesNotPermitted"] = len(subIssues) - len(readable)
+
+	// Like the list, the progress only covers the sub-issues the doer can see.
+	progress, err := issues_model.GetSubIssueProgress(ctx, issue.ID, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetSubIssueProgress", err)
+		return
+	}
+	ctx.Data["SubIssueProgress"] = progress
+	ctx.Data["CanEditSubIssues"] = !ctx.Repo.Repository.IsArchived && ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+}
+
+// getSubIssueFromReference resolves a reference like "#12", "12" or "owner/repo#12"
+// to an issue which the doer is allowed to read.
+func getSubIssueFromReference(ctx *context.Context, ref string) *issues_model.Issue {
+	repoRef, indexStr, found := strings.Cut(strings.TrimSpace(ref), "#")
+	if !found {
+		repoRef, indexStr = "", repoRef
+	}
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil || index <= 0 {
+		return nil
+	}
+
+	repo := ctx.Repo.Repository
+	if repoRef != "" {
+		ownerName, repoName, ok := strings.Cut(repoRef, "/")
+		if !ok {
+			return nil
+		}
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if !repo_model.IsErrRepoNotExist(err) {
+				ctx.ServerError("GetRepositoryByOwnerAndName", err)
+			}
+			return nil
+		}
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return nil
+	}
+	issue.Repo = repo
+
+	if repo.ID != ctx.Repo.Repository.ID {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return nil
+		}
+		if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+			return nil
+		}
+	} else if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		return nil
+	}
+	return issue
+}
+
+// AddSubIssue makes the referenced issue a sub-issue of the current one
+func AddSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	subIssue := getSubIssueFromReference(ctx, ctx.FormString("sub_issue"))
+	if ctx.Written() {
+		return
+	}
+	if subIssue == nil {
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_not_exist"))
+		ctx.Redirect(issue.Link())
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueExists(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_circular"))
+		case issues_model.IsErrSubIssueNotSameOwner(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.add_error_not_same_owner"))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+			return
+		}
+	}
+
+	ctx.Redirect(issue.Link())
+}
+
+// RemoveSubIssue detaches a sub-issue from the current issue
+func RemoveSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("sub_issue_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil && !issues_model.IsErrSubIssueNotExists(err) {
+		ctx.ServerError("RemoveSubIssue", err)
+		return
+	}
+
+	ctx.Redirect(issue.Link())
+}
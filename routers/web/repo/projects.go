@@ -23,6 +23,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	project_shared "forgejo.org/routers/web/shared/project"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -317,6 +318,16 @@ func ViewProject(ctx *context.Context) {
 		return
 	}
 
+	project_shared.PrepareProjectViews(ctx, project, nil)
+	if ctx.Written() {
+		return
+	}
+
+	project_shared.PrepareProjectAutomation(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	columns, err := project.GetColumns(ctx)
 	if err != nil {
 		ctx.ServerError("GetProjectColumns", err)
@@ -0,0 +1,130 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/setting"
+	advisory_service "forgejo.org/services/advisory"
+	"forgejo.org/services/context"
+)
+
+const tplSecurity base.TplName = "repo/security/list"
+
+// Security lists the alerts of the vulnerable dependencies of a repository
+func Security(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.security")
+	ctx.Data["PageIsSecurity"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	state := ctx.FormTrim("state")
+	status := advisory_model.ParseAlertStatus(state)
+	if !status.Has() {
+		state = "open"
+		status = optional.Some(advisory_model.AlertStatusOpen)
+	}
+
+	severityName := ctx.FormTrim("severity")
+	var severity optional.Option[osv.SeverityLevel]
+	if level := osv.ParseSeverity(severityName); level != osv.SeverityUnknown {
+		severity = optional.Some(level)
+		severityName = level.String()
+	} else {
+		severityName = ""
+	}
+
+	alerts, total, err := db.FindAndCount[advisory_model.Alert](ctx, advisory_model.FindAlertsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID:   ctx.Repo.Repository.ID,
+		Severity: severity,
+		Status:   status,
+	})
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+	if err := advisory_model.AlertList(alerts).LoadAdvisories(ctx); err != nil {
+		ctx.ServerError("LoadAdvisories", err)
+		return
+	}
+
+	counts, err := advisory_model.CountAlertsBySeverity(ctx, ctx.Repo.Repository.ID, status.Value())
+	if err != nil {
+		ctx.ServerError("CountAlertsBySeverity", err)
+		return
+	}
+
+	ctx.Data["Alerts"] = alerts
+	ctx.Data["SeverityCounts"] = counts
+	ctx.Data["SeverityLevels"] = osv.SeverityLevels
+	ctx.Data["Severity"] = severityName
+	ctx.Data["State"] = state
+	ctx.Data["States"] = []string{"open", "fixed", "dismissed"}
+
+	pager := context.NewPagination(int(total), setting.UI.IssuePagingNum, page, 5)
+	pager.AddParamString("state", state)
+	pager.AddParamString("severity", severityName)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplSecurity)
+}
+
+func setAlertStatus(ctx *context.Context, status advisory_model.AlertStatus) {
+	alert, err := advisory_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, advisory_model.ErrAlertNotExist) {
+			ctx.NotFound("GetAlertByID", err)
+		} else {
+			ctx.ServerError("GetAlertByID", err)
+		}
+		return
+	}
+
+	var dismisserID int64
+	if status == advisory_model.AlertStatusDismissed {
+		dismisserID = ctx.Doer.ID
+	}
+	if err := advisory_model.SetAlertStatus(ctx, alert, status, dismisserID); err != nil {
+		ctx.ServerError("SetAlertStatus", err)
+		return
+	}
+
+	ctx.Redirect(ctx.Repo.RepoLink + "/security?state=" + url.QueryEscape(ctx.FormString("state")) + "&severity=" + url.QueryEscape(ctx.FormString("severity")))
+}
+
+// DismissAlert dismisses an alert of a repository
+func DismissAlert(ctx *context.Context) {
+	setAlertStatus(ctx, advisory_model.AlertStatusDismissed)
+}
+
+// ReopenAlert reopens a dismissed alert of a repository
+func ReopenAlert(ctx *context.Context) {
+	setAlertStatus(ctx, advisory_model.AlertStatusOpen)
+}
+
+// SecurityScan rescans the dependencies of a repository
+func SecurityScan(ctx *context.Context) {
+	if err := advisory_service.ScanRepository(ctx, ctx.Repo.Repository); err != nil {
+		ctx.ServerError("ScanRepository", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.security.scan.success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/security")
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/base"
+	shared "forgejo.org/routers/web/shared/audit"
+	"forgejo.org/services/context"
+)
+
+const tplAudit base.TplName = "repo/settings/audit"
+
+// Audit shows the audit log of a repository
+func Audit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.audit")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	shared.SetEventsContext(ctx, 0, ctx.Repo.Repository.ID)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAudit)
+}
+
+// AuditExport exports the audit log of a repository as JSON lines
+func AuditExport(ctx *context.Context) {
+	shared.ExportEvents(ctx, 0, ctx.Repo.Repository.ID)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -17,6 +18,7 @@ import (
 	"forgejo.org/modules/log"
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/mailer"
 	org_service "forgejo.org/services/org"
@@ -117,6 +119,8 @@ func CollaborationPost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoCollaboratorAdd, audit_service.CollaboratorTarget(ctx.Repo.Repository, u), nil, audit_service.AccessModeState(perm.AccessModeWrite))
+
 	if setting.Service.EnableNotifyMail {
 		mailer.SendCollaboratorMail(u, ctx.Doer, ctx.Repo.Repository)
 	}
@@ -127,10 +131,16 @@ func CollaborationPost(ctx *context.Context) {
 
 // ChangeCollaborationAccessMode response for changing access of a collaboration
 func ChangeCollaborationAccessMode(ctx *context.Context) {
-	if err := repo_model.ChangeCollaborationAccessMode(
+	collaborator, err := user_model.GetUserByID(ctx, ctx.FormInt64("uid"))
+	if err != nil {
+		log.Error("GetUserByID: %v", err)
+		return
+	}
+	if err := repo_service.ChangeCollaborationAccessMode(
 		ctx,
+		ctx.Doer,
 		ctx.Repo.Repository,
-		ctx.FormInt64("uid"),
+		collaborator,
 		perm.AccessMode(ctx.FormInt("mode"))); err != nil {
 		log.Error("ChangeCollaborationAccessMode: %v", err)
 	}
@@ -138,7 +148,7 @@ func ChangeCollaborationAccessMode(ctx *context.Context) {
 
 // DeleteCollaboration delete a collaboration for a repository
 func DeleteCollaboration(ctx *context.Context) {
-	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
+	if err := repo_service.DeleteCollaboration(ctx, ctx.Doer, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteCollaboration: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.settings.remove_collaborator_success"))
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	audit_model "forgejo.org/models/audit"
 	git_model "forgejo.org/models/git"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -18,6 +19,7 @@ import (
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/repo"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	pull_service "forgejo.org/services/pull"
@@ -145,6 +147,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 	}
+	// the state before the update is nil if the rule is created
+	before := audit_service.BranchProtectionState(protectBranch)
 	if protectBranch == nil {
 		// No options found, create defaults.
 		protectBranch = &git_model.ProtectedBranch{
@@ -251,6 +255,11 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ctx.ServerError("UpdateProtectBranch", err)
 		return
 	}
+	action := audit_model.ActionRepoBranchProtectionUpdate
+	if before == nil {
+		action = audit_model.ActionRepoBranchProtectionCreate
+	}
+	audit_service.Record(ctx, ctx.Doer, action, audit_service.BranchProtectionTarget(ctx.Repo.Repository, protectBranch), before, audit_service.BranchProtectionState(protectBranch))
 
 	// FIXME: since we only need to recheck files protected rules, we could improve this
 	matchedBranches, err := git_model.FindAllMatchedBranches(ctx, ctx.Repo.Repository.ID, protectBranch.RuleName)
@@ -296,6 +305,7 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoBranchProtectionDelete, audit_service.BranchProtectionTarget(ctx.Repo.Repository, rule), audit_service.BranchProtectionState(rule), nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_protected_branch_success", rule.RuleName))
 	ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
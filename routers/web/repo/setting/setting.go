@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	quota_model "forgejo.org/models/quota"
@@ -35,6 +36,7 @@ import (
 	"forgejo.org/modules/web"
 	actions_service "forgejo.org/services/actions"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/federation"
 	"forgejo.org/services/forms"
@@ -204,6 +206,7 @@ func UnitsPost(ctx *context.Context) {
 				EnableTimetracker:                form.EnableTimetracker,
 				AllowOnlyContributorsToTrackTime: form.AllowOnlyContributorsToTrackTime,
 				EnableDependencies:               form.EnableIssueDependencies,
+				CloseParentWithSubIssues:         form.CloseParentWithSubIssues,
 			},
 		})
 		deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeExternalTracker)
@@ -389,6 +392,9 @@ func SettingsPost(ctx *context.Context) {
 			ctx.ServerError("UpdateRepository", err)
 			return
 		}
+		if visibilityChanged {
+			audit_service.Record(ctx, ctx.Doer, audit_model.ActionRepoVisibility, audit_service.RepoTarget(repo), audit_service.VisibilityState(!repo.IsPrivate), audit_service.VisibilityState(repo.IsPrivate))
+		}
 		log.Trace("Repository basic settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
 
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
@@ -0,0 +1,158 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const tplSLAPolicies base.TplName = "repo/settings/sla"
+
+// prepareSLAPolicies sets the SLA policies of the repository and the labels they can match
+func prepareSLAPolicies(ctx *context.Context) map[int64]*issues_model.Label {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.sla")
+	ctx.Data["PageIsSettingsSLA"] = true
+
+	policies, err := issues_model.GetSLAPoliciesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetSLAPoliciesByRepoID", err)
+		return nil
+	}
+	ctx.Data["Policies"] = policies
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByRepoID", err)
+		return nil
+	}
+	if ctx.Repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, ctx.Repo.Owner.ID, "", db.ListOptions{})
+		if err != nil {
+			ctx.ServerError("GetLabelsByOrgID", err)
+			return nil
+		}
+		labels = append(labels, orgLabels...)
+	}
+	labelsByID := make(map[int64]*issues_model.Label, len(labels))
+	for _, label := range labels {
+		labelsByID[label.ID] = label
+	}
+	ctx.Data["Labels"] = labels
+	ctx.Data["LabelsByID"] = labelsByID
+	ctx.Data["Workdays"] = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	return labelsByID
+}
+
+// SLAPolicies renders the SLA policies of a repository
+func SLAPolicies(ctx *context.Context) {
+	prepareSLAPolicies(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplSLAPolicies)
+}
+
+// parseDayMinute parses a time of the day formatted as HH:MM into minutes since midnight
+func parseDayMinute(s string) (int, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// SLAPoliciesPost creates an SLA policy of a repository
+func SLAPoliciesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SLAPolicyForm)
+	labelsByID := prepareSLAPolicies(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSLAPolicies)
+		return
+	}
+
+	policy := &issues_model.SLAPolicy{
+		RepoID:                ctx.Repo.Repository.ID,
+		CreatorID:             ctx.Doer.ID,
+		Name:                  form.Name,
+		IssueType:             form.IssueType,
+		LabelID:               form.LabelID,
+		ResponseSeconds:       form.ResponseMinutes * 60,
+		ResolveSeconds:        form.ResolveMinutes * 60,
+		BusinessHours:         form.BusinessHours,
+		Timezone:              form.Timezone,
+		EscalateBeforeSeconds: form.EscalateBeforeMinutes * 60,
+		EscalationComment:     form.EscalationComment,
+		EscalationMentions:    form.EscalationMentions,
+		EscalationLabelID:     form.EscalationLabelID,
+	}
+	for _, day := range form.Workdays {
+		if day >= int(time.Sunday) && day <= int(time.Saturday) {
+			policy.Workdays |= issues_model.NewSLAWorkdays(time.Weekday(day))
+		}
+	}
+
+	dayStart, okStart := parseDayMinute(form.DayStart)
+	dayEnd, okEnd := parseDayMinute(form.DayEnd)
+	if okStart && okEnd {
+		policy.DayStartMinute, policy.DayEndMinute = dayStart, dayEnd
+	} else if policy.BusinessHours {
+		ctx.Data["Err_BusinessHours"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.sla.invalid_business_hours"), tplSLAPolicies, form)
+		return
+	} else {
+		policy.DayStartMinute, policy.DayEndMinute = 9*60, 17*60
+	}
+	if policy.Workdays == 0 && !policy.BusinessHours {
+		policy.Workdays = issues_model.SLAWorkdaysMondayToFriday
+	}
+	if _, has := labelsByID[policy.LabelID]; policy.LabelID != 0 && !has {
+		ctx.NotFound("GetLabelByID", nil)
+		return
+	}
+	if _, has := labelsByID[policy.EscalationLabelID]; policy.EscalationLabelID != 0 && !has {
+		ctx.NotFound("GetLabelByID", nil)
+		return
+	}
+
+	if err := issues_model.CreateSLAPolicy(ctx, policy); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.settings.sla.invalid", err.Error()), tplSLAPolicies, form)
+			return
+		}
+		ctx.ServerError("CreateSLAPolicy", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.sla.created", policy.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/sla")
+}
+
+// DeleteSLAPolicy deletes an SLA policy of a repository
+func DeleteSLAPolicy(ctx *context.Context) {
+	if err := issues_model.DeleteSLAPolicy(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
+		if !issues_model.IsErrSLAPolicyNotExist(err) {
+			ctx.ServerError("DeleteSLAPolicy", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.settings.sla.deleted"))
+	}
+
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/sla")
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/db"
+	terraform_model "forgejo.org/models/terraform"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	terraform_service "forgejo.org/services/terraform"
+)
+
+const (
+	tplTerraformStates base.TplName = "repo/settings/terraform"
+	tplTerraformState  base.TplName = "repo/settings/terraform_state"
+)
+
+// terraformStateInfo is a state with its latest version and the holder of its lock
+type terraformStateInfo struct {
+	State  *terraform_model.State
+	Latest *terraform_model.StateVersion
+	Lock   *terraform_model.LockInfo
+	Locker *user_model.User
+}
+
+func loadTerraformStateInfo(ctx *context.Context, s *terraform_model.State) (*terraformStateInfo, error) {
+	info := &terraformStateInfo{State: s}
+
+	latest, err := terraform_model.GetLatestStateVersion(ctx, s.ID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+	info.Latest = latest
+
+	if s.IsLocked() {
+		info.Lock = s.GetLockInfo()
+		info.Locker, err = user_model.GetPossibleUserByID(ctx, s.LockerID)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return nil, err
+			}
+			info.Locker = user_model.NewGhostUser()
+		}
+	}
+	return info, nil
+}
+
+func getTerraformState(ctx *context.Context) *terraform_model.State {
+	s, err := terraform_model.GetStateByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetStateByID", err)
+		} else {
+			ctx.ServerError("GetStateByID", err)
+		}
+		return nil
+	}
+	return s
+}
+
+// TerraformStates renders the Terraform states of a repository
+func TerraformStates(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.terraform")
+	ctx.Data["PageIsSettingsTerraform"] = true
+
+	states, err := terraform_model.GetStatesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetStatesByRepoID", err)
+		return
+	}
+
+	infos := make([]*terraformStateInfo, 0, len(states))
+	for _, s := range states {
+		info, err := loadTerraformStateInfo(ctx, s)
+		if err != nil {
+			ctx.ServerError("loadTerraformStateInfo", err)
+			return
+		}
+		infos = append(infos, info)
+	}
+	ctx.Data["States"] = infos
+	ctx.Data["BackendAddress"] = fmt.Sprintf("%sapi/v1/repos/%s/terraform/state/", setting.AppURL, ctx.Repo.Repository.FullName())
+
+	ctx.HTML(http.StatusOK, tplTerraformStates)
+}
+
+// TerraformState renders the version history of a Terraform state
+func TerraformState(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["Title"] = s.Name
+	ctx.Data["PageIsSettingsTerraform"] = true
+	ctx.Data["TerraformLink"] = ctx.Repo.RepoLink + "/settings/terraform"
+
+	info, err := loadTerraformStateInfo(ctx, s)
+	if err != nil {
+		ctx.ServerError("loadTerraformStateInfo", err)
+		return
+	}
+	ctx.Data["StateInfo"] = info
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	versions, total, err := terraform_model.GetStateVersions(ctx, s.ID, db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.ExplorePagingNum,
+	})
+	if err != nil {
+		ctx.ServerError("GetStateVersions", err)
+		return
+	}
+
+	creatorIDs := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		creatorIDs = append(creatorIDs, v.CreatorID)
+	}
+	users, err := user_model.GetPossibleUserByIDs(ctx, creatorIDs)
+	if err != nil {
+		ctx.ServerError("GetPossibleUserByIDs", err)
+		return
+	}
+	creators := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		creators[u.ID] = u
+	}
+
+	ctx.Data["Versions"] = versions
+	ctx.Data["Creators"] = creators
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), setting.UI.ExplorePagingNum, page, 5)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplTerraformState)
+}
+
+// TerraformStateVersionDownload serves the decrypted content of a version of a Terraform state
+func TerraformStateVersionDownload(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := terraform_model.GetStateVersion(ctx, s.ID, ctx.ParamsInt64("version"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetStateVersion", err)
+		} else {
+			ctx.ServerError("GetStateVersion", err)
+		}
+		return
+	}
+
+	content, err := terraform_service.ReadStateVersion(ctx, v)
+	if err != nil {
+		ctx.ServerError("ReadStateVersion", err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.v%d.tfstate"`, s.Name, v.Version))
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(content)
+}
+
+// TerraformStateForceUnlock releases the lock of a Terraform state regardless of its holder
+func TerraformStateForceUnlock(ctx *context.Context) {
+	s := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := terraform_service.ForceUnlockState(ctx, s); err != nil {
+		ctx.ServerError("ForceUnlockState", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.terraform.unlocked", s.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/terraform")
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.Context) {
+	s, err := terraform_model.GetStateByID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id"))
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			ctx.ServerError("GetStateByID", err)
+			return
+		}
+	} else if s.IsLocked() {
+		ctx.Flash.Error(ctx.Tr("repo.settings.terraform.delete_locked", s.Name))
+	} else if err := terraform_service.DeleteState(ctx, s); err != nil {
+		ctx.ServerError("DeleteState", err)
+		return
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.settings.terraform.deleted", s.Name))
+	}
+
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/terraform")
+}
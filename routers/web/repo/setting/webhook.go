@@ -23,6 +23,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web/middleware"
 	webhook_module "forgejo.org/modules/webhook"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
@@ -257,7 +258,7 @@ func WebhookCreate(ctx *context.Context) {
 		return
 	}
 
-	if err := webhook.CreateWebhook(ctx, w, fields.AuthorizationHeader); err != nil {
+	if err := webhook_service.CreateWebhook(ctx, ctx.Doer, w, fields.AuthorizationHeader); err != nil {
 		ctx.ServerError("CreateWebhook", err)
 		return
 	}
@@ -276,6 +277,7 @@ func WebhookUpdate(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Webhook"] = w
+	before := audit_service.WebhookState(w)
 
 	handler := webhook_service.GetWebhookHandler(w.Type)
 	if handler == nil {
@@ -319,7 +321,7 @@ func WebhookUpdate(ctx *context.Context) {
 	if err := w.UpdateEvent(); err != nil {
 		ctx.ServerError("UpdateEvent", err)
 		return
-	} else if err := webhook.UpdateWebhook(ctx, w); err != nil {
+	} else if err := webhook_service.UpdateWebhook(ctx, ctx.Doer, w, before); err != nil {
 		ctx.ServerError("UpdateWebhook", err)
 		return
 	}
@@ -469,7 +471,7 @@ func WebhookReplay(ctx *context.Context) {
 
 // WebhookDelete delete a webhook
 func WebhookDelete(ctx *context.Context) {
-	if err := webhook.DeleteWebhookByRepoID(ctx, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
+	if err := webhook_service.DeleteWebhookByRepoID(ctx, ctx.Doer, ctx.Repo.Repository.ID, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteWebhookByRepoID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.settings.webhook_deletion_success"))
@@ -0,0 +1,152 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/context"
+)
+
+// exportBatchSize is the number of events which are loaded at once while exporting
+const exportBatchSize = 500
+
+// areas are the parts of the actions before the first dot, which can be used as filters like actions
+var areas = []string{"repo", "org", "webhook", "user", "admin"}
+
+var targetTypes = []audit_model.TargetType{
+	audit_model.TargetRepository,
+	audit_model.TargetBranchProtection,
+	audit_model.TargetDeployKey,
+	audit_model.TargetUser,
+	audit_model.TargetOrganization,
+	audit_model.TargetTeam,
+	audit_model.TargetWebhook,
+	audit_model.TargetAccessToken,
+	audit_model.TargetSSHKey,
+	audit_model.TargetGPGKey,
+	audit_model.TargetAuthSource,
+	audit_model.TargetSSHCertificate,
+	audit_model.TargetSSHCertificateAuthority,
+}
+
+// parseFindOptions reads the filters from the query of the request.
+// It returns false if the actor does not exist, so no events can match.
+func parseFindOptions(ctx *context.Context, ownerID, repoID int64) (*audit_model.FindOptions, bool) {
+	opts := &audit_model.FindOptions{
+		OwnerID:    ownerID,
+		RepoID:     repoID,
+		Action:     ctx.FormTrim("action"),
+		TargetType: audit_model.TargetType(ctx.FormTrim("target_type")),
+	}
+
+	if since, err := time.ParseInLocation("2006-01-02", ctx.FormTrim("since"), setting.DefaultUILocation); err == nil {
+		opts.Since = timeutil.TimeStamp(since.Unix())
+	}
+	// the day of "until" is included
+	if until, err := time.ParseInLocation("2006-01-02", ctx.FormTrim("until"), setting.DefaultUILocation); err == nil {
+		opts.Until = timeutil.TimeStamp(until.AddDate(0, 0, 1).Unix())
+	}
+
+	if actor := ctx.FormTrim("actor"); actor != "" {
+		u, err := user_model.GetUserByName(ctx, actor)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				ctx.ServerError("GetUserByName", err)
+			}
+			return nil, false
+		}
+		opts.ActorID = u.ID
+	}
+
+	return opts, true
+}
+
+// SetEventsContext loads a page of the events of an owner or a repository, or of the whole instance if both are 0
+func SetEventsContext(ctx *context.Context, ownerID, repoID int64) {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	ctx.Data["Areas"] = areas
+	ctx.Data["Actions"] = audit_model.Actions
+	ctx.Data["TargetTypes"] = targetTypes
+	ctx.Data["Action"] = ctx.FormTrim("action")
+	ctx.Data["Actor"] = ctx.FormTrim("actor")
+	ctx.Data["TargetType"] = ctx.FormTrim("target_type")
+	ctx.Data["Since"] = ctx.FormTrim("since")
+	ctx.Data["Until"] = ctx.FormTrim("until")
+
+	opts, ok := parseFindOptions(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	var events []*audit_model.Event
+	var total int64
+	if ok {
+		opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.UI.Admin.NoticePagingNum}
+		var err error
+		events, total, err = db.FindAndCount[audit_model.Event](ctx, opts)
+		if err != nil {
+			ctx.ServerError("FindAndCount", err)
+			return
+		}
+	}
+	ctx.Data["Events"] = events
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), setting.UI.Admin.NoticePagingNum, page, 5)
+	pager.AddParam(ctx, "action", "Action")
+	pager.AddParam(ctx, "actor", "Actor")
+	pager.AddParam(ctx, "target_type", "TargetType")
+	pager.AddParam(ctx, "since", "Since")
+	pager.AddParam(ctx, "until", "Until")
+	ctx.Data["Page"] = pager
+}
+
+// ExportEvents writes the events matching the filters of the request as JSON lines
+func ExportEvents(ctx *context.Context, ownerID, repoID int64) {
+	opts, ok := parseFindOptions(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.SetServeHeaders(&context.ServeHeaderOptions{
+		ContentType: "application/x-ndjson",
+		Filename:    "audit-" + time.Now().Format("2006-01-02") + ".jsonl",
+	})
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if !ok {
+		return
+	}
+
+	encoder := json.NewEncoder(ctx.Resp)
+	for page := 1; ; page++ {
+		opts.ListOptions = db.ListOptions{Page: page, PageSize: exportBatchSize}
+		events, err := db.Find[audit_model.Event](ctx, opts)
+		if err != nil {
+			// the headers have been sent already, so the error can only end the export
+			log.Error("audit: unable to load events to export: %v", err)
+			return
+		}
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+		}
+		if len(events) < exportBatchSize {
+			return
+		}
+	}
+}
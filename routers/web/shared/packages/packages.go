@@ -7,16 +7,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/perm"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+	packages_service "forgejo.org/services/packages"
 	cargo_service "forgejo.org/services/packages/cargo"
 	cleanup_service "forgejo.org/services/packages/cleanup"
 )
@@ -30,11 +34,45 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 
 	ctx.Data["CleanupRules"] = pcrs
 
+	remotes, err := packages_model.GetRemotesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetRemotesByOwner", err)
+		return
+	}
+
+	ctx.Data["PackageRemotes"] = remotes
+	ctx.Data["PackageRemoteTypes"] = packages_model.RemoteTypeList
+
 	ctx.Data["CargoIndexExists"], err = repo_model.IsRepositoryModelExist(ctx, owner, cargo_service.IndexRepositoryName)
 	if err != nil {
 		ctx.ServerError("IsRepositoryModelExist", err)
 		return
 	}
+
+	ctx.Data["RequireSignature"], err = packages_service.RequiresSignature(ctx, owner)
+	if err != nil {
+		ctx.ServerError("RequiresSignature", err)
+		return
+	}
+	ctx.Data["RequireSignatureInstance"] = setting.Packages.RequireSignature
+
+	ctx.Data["ImmutableTypes"], err = packages_service.ImmutableTypes(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("ImmutableTypes", err)
+		return
+	}
+	instanceImmutableTypes := make([]packages_model.Type, 0, len(setting.Packages.ImmutableTypes))
+	for _, t := range setting.Packages.ImmutableTypes {
+		instanceImmutableTypes = append(instanceImmutableTypes, packages_model.Type(strings.ToLower(strings.TrimSpace(t))))
+	}
+	ctx.Data["ImmutableTypesInstance"] = instanceImmutableTypes
+	ctx.Data["PackageTypes"] = packages_model.TypeList
+
+	ctx.Data["PackageAccessGrants"], err = packages_service.GetAccessGrants(ctx, owner)
+	if err != nil {
+		ctx.ServerError("GetAccessGrants", err)
+		return
+	}
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -99,8 +137,10 @@ func performRuleEditPost(ctx *context.Context, owner *user_model.User, pcr *pack
 	pcr.KeepCount = form.KeepCount
 	pcr.KeepPattern = form.KeepPattern
 	pcr.RemoveDays = form.RemoveDays
+	pcr.RemoveUnusedDays = form.RemoveUnusedDays
 	pcr.RemovePattern = form.RemovePattern
 	pcr.MatchFullName = form.MatchFullName
+	pcr.UpstreamOnly = form.UpstreamOnly
 
 	ctx.Data["IsEditRule"] = isEditRule
 	ctx.Data["CleanupRule"] = pcr
@@ -150,13 +190,17 @@ func SetRulePreviewContext(ctx *context.Context, owner *user_model.User) {
 		ctx.ServerError("GetCleanupTargets", err)
 		return
 	}
-	packageDescriptors := make([]*packages_model.PackageDescriptor, len(versionsToRemove))
-	for i := range len(versionsToRemove) {
-		packageDescriptors[i] = versionsToRemove[i].PackageDescriptor
+	immutableCount := 0
+	for _, ct := range versionsToRemove {
+		if ct.Immutable {
+			immutableCount++
+		}
 	}
 
 	ctx.Data["CleanupRule"] = pcr
-	ctx.Data["VersionsToRemove"] = packageDescriptors
+	ctx.Data["CleanupTargets"] = versionsToRemove
+	ctx.Data["RemoveCount"] = len(versionsToRemove) - immutableCount
+	ctx.Data["ImmutableCount"] = immutableCount
 }
 
 func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageCleanupRule {
@@ -207,3 +251,124 @@ func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 		ctx.Flash.Success(ctx.Tr("packages.owner.settings.cargo.rebuild.success"))
 	}
 }
+
+func AddRemote(ctx *context.Context, owner *user_model.User) {
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	r := &packages_model.PackageRemote{
+		OwnerID:     owner.ID,
+		Type:        packages_model.Type(form.Type),
+		URL:         strings.TrimSpace(form.URL),
+		Username:    form.Username,
+		MetadataTTL: form.MetadataTTLMinutes * 60,
+	}
+	if err := packages_model.InsertRemote(ctx, r, form.Password); err != nil {
+		if errors.Is(err, packages_model.ErrDuplicatePackageRemote) {
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.remotes.duplicate", r.Type.Name()))
+			return
+		}
+		ctx.ServerError("InsertRemote", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.add"))
+}
+
+func DeleteRemote(ctx *context.Context, owner *user_model.User) {
+	r, err := packages_model.GetRemoteByID(ctx, ctx.FormInt64("id"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			ctx.NotFound("", err)
+		} else {
+			ctx.ServerError("GetRemoteByID", err)
+		}
+		return
+	}
+	if r.OwnerID != owner.ID {
+		ctx.NotFound("", fmt.Errorf("PackageRemote[%v] not associated to owner %v", r.ID, owner))
+		return
+	}
+
+	if err := packages_model.DeleteRemoteByID(ctx, r.ID); err != nil {
+		ctx.ServerError("DeleteRemoteByID", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.delete"))
+}
+
+func UpdateSigningPolicy(ctx *context.Context, owner *user_model.User) {
+	if err := packages_service.SetRequiresSignature(ctx, owner, ctx.FormBool("require_signature")); err != nil {
+		ctx.ServerError("SetRequiresSignature", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.signing.success"))
+}
+
+func UpdateImmutabilityPolicy(ctx *context.Context, owner *user_model.User) {
+	values := ctx.FormStrings("immutable_types")
+	types := make([]packages_model.Type, 0, len(values))
+	for _, v := range values {
+		types = append(types, packages_model.Type(v))
+	}
+
+	if err := packages_service.SetImmutableTypes(ctx, owner, types); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.immutable.invalid"))
+			return
+		}
+		ctx.ServerError("SetImmutableTypes", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.immutable.success"))
+}
+
+func AddAccess(ctx *context.Context, owner *user_model.User) {
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageAccessForm)
+
+	_, err := packages_service.GrantAccess(ctx, owner, packages_model.Type(form.Type), form.Name, form.Team, form.Repository, perm.ParseAccessMode(form.AccessMode))
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.access.invalid"))
+		case errors.Is(err, util.ErrNotExist):
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.access.not_found"))
+		default:
+			ctx.ServerError("GrantAccess", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.access.success.add"))
+}
+
+func DeleteAccess(ctx *context.Context, owner *user_model.User) {
+	pa, err := packages_model.GetAccessByID(ctx, owner.ID, ctx.FormInt64("id"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageAccessNotExist) {
+			ctx.NotFound("", err)
+		} else {
+			ctx.ServerError("GetAccessByID", err)
+		}
+		return
+	}
+
+	if err := packages_model.DeleteAccessByID(ctx, pa.ID); err != nil {
+		ctx.ServerError("DeleteAccessByID", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.access.success.delete"))
+}
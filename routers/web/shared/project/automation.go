@@ -0,0 +1,184 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"errors"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+// AutomationRule is an automation rule of a project along with the objects it refers to
+type AutomationRule struct {
+	*project_model.AutomationRule
+	Column *project_model.Column
+	Repo   *repo_model.Repository
+	Label  *issues_model.Label
+}
+
+// PrepareProjectAutomation loads the automation rules of the project and the labels they can filter on
+func PrepareProjectAutomation(ctx *context.Context, project *project_model.Project) {
+	rules, err := project.GetAutomationRules(ctx)
+	if err != nil {
+		ctx.ServerError("GetAutomationRules", err)
+		return
+	}
+	columns, err := project.GetColumns(ctx)
+	if err != nil {
+		ctx.ServerError("GetColumns", err)
+		return
+	}
+	labels, err := getProjectLabels(ctx, project)
+	if err != nil {
+		ctx.ServerError("getProjectLabels", err)
+		return
+	}
+
+	items := make([]*AutomationRule, 0, len(rules))
+	for _, rule := range rules {
+		item := &AutomationRule{AutomationRule: rule}
+		for _, column := range columns {
+			if column.ID == rule.ColumnID {
+				item.Column = column
+			}
+		}
+		for _, label := range labels {
+			if label.ID == rule.LabelID {
+				item.Label = label
+			}
+		}
+		if rule.RepoID > 0 {
+			if item.Repo, err = repo_model.GetRepositoryByID(ctx, rule.RepoID); err != nil && !repo_model.IsErrRepoNotExist(err) {
+				ctx.ServerError("GetRepositoryByID", err)
+				return
+			}
+		}
+		items = append(items, item)
+	}
+
+	ctx.Data["AutomationRules"] = items
+	ctx.Data["AutomationEvents"] = project_model.AutomationEvents
+	ctx.Data["AutomationColumns"] = columns
+	ctx.Data["AutomationLabels"] = labels
+}
+
+// getProjectLabels returns the labels the issues of the project can have: those of its
+// repository and of the organization owning it
+func getProjectLabels(ctx *context.Context, project *project_model.Project) ([]*issues_model.Label, error) {
+	var labels []*issues_model.Label
+	var owner *user_model.User
+	if project.RepoID > 0 {
+		if err := project.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		if err := project.Repo.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+		repoLabels, err := issues_model.GetLabelsByRepoID(ctx, project.RepoID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, repoLabels...)
+		owner = project.Repo.Owner
+	} else {
+		if err := project.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+		owner = project.Owner
+	}
+
+	if owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, owner.ID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, orgLabels...)
+	}
+	return labels, nil
+}
+
+// NewAutomationRulePost creates an automation rule of a project
+func NewAutomationRulePost(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ProjectAutomationRuleForm)
+	rule := &project_model.AutomationRule{
+		ProjectID: project.ID,
+		CreatorID: ctx.Doer.ID,
+		Event:     form.Event,
+		ColumnID:  form.ColumnID,
+	}
+
+	if form.Event == project_model.AutomationEventAutoAdd {
+		// the issues of a repository project always come from its repository
+		if form.RepoName != "" && project.RepoID == 0 {
+			repo, err := repo_model.GetRepositoryByName(ctx, project.OwnerID, form.RepoName)
+			if err != nil {
+				if repo_model.IsErrRepoNotExist(err) {
+					ctx.Flash.Error(ctx.Tr("repo.projects.automation.repo_not_exist", form.RepoName))
+					ctx.Redirect(project.Link(ctx))
+					return
+				}
+				ctx.ServerError("GetRepositoryByName", err)
+				return
+			}
+			rule.RepoID = repo.ID
+		}
+		if form.LabelID > 0 {
+			labels, err := getProjectLabels(ctx, project)
+			if err != nil {
+				ctx.ServerError("getProjectLabels", err)
+				return
+			}
+			for _, label := range labels {
+				if label.ID == form.LabelID {
+					rule.LabelID = label.ID
+				}
+			}
+			if rule.LabelID == 0 {
+				ctx.NotFound("GetLabelByID", nil)
+				return
+			}
+		}
+	}
+
+	if err := project_model.NewAutomationRule(ctx, rule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || project_model.IsErrProjectColumnNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("repo.projects.automation.invalid"))
+			ctx.Redirect(project.Link(ctx))
+			return
+		}
+		ctx.ServerError("NewAutomationRule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.automation.created"))
+	ctx.Redirect(project.Link(ctx))
+}
+
+// DeleteAutomationRule deletes an automation rule of a project
+func DeleteAutomationRule(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteAutomationRuleByID(ctx, project.ID, ctx.ParamsInt64(":ruleID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteAutomationRuleByID", project_model.IsErrProjectAutomationRuleNotExist, err)
+		return
+	}
+
+	ctx.JSONRedirect(project.Link(ctx))
+}
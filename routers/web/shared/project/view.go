@@ -0,0 +1,577 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	org_model "forgejo.org/models/organization"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+// ViewRow is an issue of a project as shown by a saved view
+type ViewRow struct {
+	Issue       *issues_model.Issue
+	ColumnID    int64
+	StartDate   timeutil.TimeStamp
+	TargetDate  timeutil.TimeStamp
+	FieldValues map[int64]string
+
+	// position of the roadmap bar, in percent of the timeline
+	BarOffset float64
+	BarWidth  float64
+}
+
+// IsScheduled returns whether the row can be placed on a roadmap
+func (r *ViewRow) IsScheduled() bool {
+	return r.StartDate > 0 || r.TargetDate > 0
+}
+
+// ViewGroup is a group of rows sharing the attribute the view is grouped by.
+// At most one of Assignee, Label, Milestone and Column is set, none for the
+// group of rows which do not have the attribute.
+type ViewGroup struct {
+	Assignee  *user_model.User
+	Label     *issues_model.Label
+	Milestone *issues_model.Milestone
+	Column    *project_model.Column
+	Rows      []*ViewRow
+}
+
+// Timeline is the time range covered by a roadmap view
+type Timeline struct {
+	Start timeutil.TimeStamp
+	End   timeutil.TimeStamp
+}
+
+// PrepareProjectViews loads the saved views and custom fields of the project and, if
+// the request selects one of the views, the issues of the project as shown by that view.
+// It returns the selected view, or nil if the board should be shown.
+func PrepareProjectViews(ctx *context.Context, project *project_model.Project, org *org_model.Organization) *project_model.View {
+	views, err := project.GetViews(ctx)
+	if err != nil {
+		ctx.ServerError("GetViews", err)
+		return nil
+	}
+	fields, err := project.GetFields(ctx)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return nil
+	}
+	ctx.Data["Views"] = views
+	ctx.Data["Fields"] = fields
+	ctx.Data["ViewTypes"] = []project_model.ViewType{project_model.ViewTypeTable, project_model.ViewTypeRoadmap}
+	ctx.Data["ViewGroupBys"] = []project_model.ViewGroupBy{
+		project_model.ViewGroupByNone, project_model.ViewGroupByAssignee, project_model.ViewGroupByLabel,
+		project_model.ViewGroupByMilestone, project_model.ViewGroupByColumn,
+	}
+	ctx.Data["ViewSorts"] = []string{
+		project_model.ViewSortTitle, project_model.ViewSortAssignee, project_model.ViewSortMilestone,
+		project_model.ViewSortLabels, project_model.ViewSortCreated, project_model.ViewSortUpdated,
+		project_model.ViewSortStartDate, project_model.ViewSortTargetDate,
+	}
+	ctx.Data["FieldTypes"] = []project_model.FieldType{project_model.FieldTypeText, project_model.FieldTypeNumber, project_model.FieldTypeDate}
+
+	viewID := ctx.FormInt64("view")
+	if viewID == 0 {
+		return nil
+	}
+	var view *project_model.View
+	for _, v := range views {
+		if v.ID == viewID {
+			view = v
+			break
+		}
+	}
+	if view == nil {
+		ctx.NotFound("GetViewByID", nil)
+		return nil
+	}
+
+	issues, err := issues_model.LoadIssuesFromProject(ctx, project, ctx.Doer, org, optional.None[bool]())
+	if err != nil {
+		ctx.ServerError("LoadIssuesFromProject", err)
+		return nil
+	}
+	issues, err = filterViewIssues(ctx, project, view, issues)
+	if err != nil {
+		ctx.ServerError("filterViewIssues", err)
+		return nil
+	}
+	if err := issues.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return nil
+	}
+
+	issueIDs := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		issueIDs = append(issueIDs, issue.ID)
+	}
+	projectIssues, err := project.GetProjectIssues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetProjectIssues", err)
+		return nil
+	}
+	fieldValues, err := project.GetFieldValues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetFieldValues", err)
+		return nil
+	}
+
+	rows := make([]*ViewRow, 0, len(issues))
+	for _, issue := range issues {
+		row := &ViewRow{Issue: issue, FieldValues: fieldValues[issue.ID]}
+		if projectIssue, ok := projectIssues[issue.ID]; ok {
+			row.ColumnID = projectIssue.ProjectColumnID
+			row.StartDate = projectIssue.StartDateUnix
+			row.TargetDate = projectIssue.TargetDateUnix
+		}
+		if row.TargetDate == 0 {
+			row.TargetDate = issue.DeadlineUnix
+		}
+		rows = append(rows, row)
+	}
+
+	sortViewRows(rows, view, fields)
+
+	groups, err := groupViewRows(ctx, project, view, rows)
+	if err != nil {
+		ctx.ServerError("groupViewRows", err)
+		return nil
+	}
+
+	if view.Type == project_model.ViewTypeRoadmap {
+		ctx.Data["Timeline"] = placeOnTimeline(rows)
+	}
+
+	shownFields := make([]*project_model.Field, 0, len(view.Fields))
+	for _, field := range fields {
+		if slices.Contains(view.Fields, field.ID) {
+			shownFields = append(shownFields, field)
+		}
+	}
+
+	ctx.Data["CurrentView"] = view
+	ctx.Data["ShownFields"] = shownFields
+	ctx.Data["ViewGroups"] = groups
+	ctx.Data["NumViewRows"] = len(rows)
+	return view
+}
+
+// filterViewIssues keeps the issues matching the search query of the view
+func filterViewIssues(ctx *context.Context, project *project_model.Project, view *project_model.View, issues issues_model.IssueList) (issues_model.IssueList, error) {
+	if strings.TrimSpace(view.Filter) == "" || len(issues) == 0 {
+		return issues, nil
+	}
+
+	searchOpt := &issue_indexer.SearchOptions{
+		ProjectID: optional.Some(project.ID),
+		Paginator: &db.ListOptions{Page: 1, PageSize: len(issues)},
+	}
+	if err := searchOpt.WithKeyword(ctx, view.Filter); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			// a parent which cannot be resolved matches no issue
+			return issues_model.IssueList{}, nil
+		}
+		return nil, err
+	}
+	ids, _, err := issue_indexer.SearchIssues(ctx, searchOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make(issues_model.IssueList, 0, len(ids))
+	for _, issue := range issues {
+		if slices.Contains(ids, issue.ID) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered, nil
+}
+
+// sortViewRows sorts the rows by the attribute of the view. Rows which do not
+// have the attribute are always sorted last.
+func sortViewRows(rows []*ViewRow, view *project_model.View, fields []*project_model.Field) {
+	key := func(row *ViewRow) (string, bool) {
+		issue := row.Issue
+		switch view.SortBy {
+		case project_model.ViewSortTitle:
+			return strings.ToLower(issue.Title), true
+		case project_model.ViewSortAssignee:
+			if len(issue.Assignees) == 0 {
+				return "", false
+			}
+			return strings.ToLower(issue.Assignees[0].Name), true
+		case project_model.ViewSortMilestone:
+			if issue.Milestone == nil {
+				return "", false
+			}
+			return strings.ToLower(issue.Milestone.Name), true
+		case project_model.ViewSortLabels:
+			if len(issue.Labels) == 0 {
+				return "", false
+			}
+			return strings.ToLower(issue.Labels[0].Name), true
+		}
+		return "", false
+	}
+	timeKey := func(row *ViewRow) timeutil.TimeStamp {
+		switch view.SortBy {
+		case project_model.ViewSortCreated:
+			return row.Issue.CreatedUnix
+		case project_model.ViewSortUpdated:
+			return row.Issue.UpdatedUnix
+		case project_model.ViewSortStartDate:
+			return row.StartDate
+		case project_model.ViewSortTargetDate:
+			return row.TargetDate
+		}
+		return 0
+	}
+
+	var field *project_model.Field
+	if fieldID, ok := project_model.ViewSortFieldID(view.SortBy); ok {
+		for _, f := range fields {
+			if f.ID == fieldID {
+				field = f
+				break
+			}
+		}
+	}
+
+	compare := func(a, b *ViewRow) (int, bool) {
+		switch {
+		case field != nil:
+			va, okA := a.FieldValues[field.ID]
+			vb, okB := b.FieldValues[field.ID]
+			if !okA || !okB {
+				return cmp.Compare(btoi(okB), btoi(okA)), false
+			}
+			if field.Type == project_model.FieldTypeNumber {
+				na, _ := strconv.ParseFloat(va, 64)
+				nb, _ := strconv.ParseFloat(vb, 64)
+				return cmp.Compare(na, nb), true
+			}
+			return cmp.Compare(strings.ToLower(va), strings.ToLower(vb)), true
+		case view.SortBy == project_model.ViewSortCreated, view.SortBy == project_model.ViewSortUpdated,
+			view.SortBy == project_model.ViewSortStartDate, view.SortBy == project_model.ViewSortTargetDate:
+			ta, tb := timeKey(a), timeKey(b)
+			if ta == 0 || tb == 0 {
+				return cmp.Compare(btoi(tb != 0), btoi(ta != 0)), false
+			}
+			return cmp.Compare(ta, tb), true
+		default:
+			ka, okA := key(a)
+			kb, okB := key(b)
+			if !okA || !okB {
+				return cmp.Compare(btoi(okB), btoi(okA)), false
+			}
+			return cmp.Compare(ka, kb), true
+		}
+	}
+
+	if view.SortBy == "" {
+		return
+	}
+	slices.SortStableFunc(rows, func(a, b *ViewRow) int {
+		c, both := compare(a, b)
+		if both && view.SortDesc {
+			return -c
+		}
+		return c
+	})
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// groupViewRows splits the rows into the groups of the view. Rows keep their
+// order inside a group, the group of rows missing the attribute comes last.
+func groupViewRows(ctx *context.Context, project *project_model.Project, view *project_model.View, rows []*ViewRow) ([]*ViewGroup, error) {
+	if view.GroupBy == project_model.ViewGroupByNone {
+		return []*ViewGroup{{Rows: rows}}, nil
+	}
+
+	var groups []*ViewGroup
+	groupsByID := make(map[int64]*ViewGroup)
+	none := &ViewGroup{}
+	add := func(id int64, row *ViewRow, newGroup func() *ViewGroup) {
+		group, ok := groupsByID[id]
+		if !ok {
+			group = newGroup()
+			groupsByID[id] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	switch view.GroupBy {
+	case project_model.ViewGroupByColumn:
+		columns, err := project.GetColumns(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var defaultColumn *project_model.Column
+		for _, column := range columns {
+			groupsByID[column.ID] = &ViewGroup{Column: column}
+			groups = append(groups, groupsByID[column.ID])
+			if column.Default {
+				defaultColumn = column
+			}
+		}
+		for _, row := range rows {
+			group, ok := groupsByID[row.ColumnID]
+			if !ok && defaultColumn != nil {
+				group = groupsByID[defaultColumn.ID]
+			}
+			if group == nil {
+				none.Rows = append(none.Rows, row)
+				continue
+			}
+			group.Rows = append(group.Rows, row)
+		}
+		// hide the empty columns
+		groups = slices.DeleteFunc(groups, func(g *ViewGroup) bool { return len(g.Rows) == 0 })
+	default:
+		for _, row := range rows {
+			issue := row.Issue
+			switch view.GroupBy {
+			case project_model.ViewGroupByAssignee:
+				if len(issue.Assignees) == 0 {
+					none.Rows = append(none.Rows, row)
+				}
+				for _, assignee := range issue.Assignees {
+					add(assignee.ID, row, func() *ViewGroup { return &ViewGroup{Assignee: assignee} })
+				}
+			case project_model.ViewGroupByLabel:
+				if len(issue.Labels) == 0 {
+					none.Rows = append(none.Rows, row)
+				}
+				for _, label := range issue.Labels {
+					add(label.ID, row, func() *ViewGroup { return &ViewGroup{Label: label} })
+				}
+			case project_model.ViewGroupByMilestone:
+				if issue.Milestone == nil {
+					none.Rows = append(none.Rows, row)
+					continue
+				}
+				add(issue.Milestone.ID, row, func() *ViewGroup { return &ViewGroup{Milestone: issue.Milestone} })
+			}
+		}
+		slices.SortStableFunc(groups, func(a, b *ViewGroup) int {
+			return cmp.Compare(strings.ToLower(a.name()), strings.ToLower(b.name()))
+		})
+	}
+
+	if len(none.Rows) > 0 {
+		groups = append(groups, none)
+	}
+	return groups, nil
+}
+
+func (g *ViewGroup) name() string {
+	switch {
+	case g.Assignee != nil:
+		return g.Assignee.Name
+	case g.Label != nil:
+		return g.Label.Name
+	case g.Milestone != nil:
+		return g.Milestone.Name
+	case g.Column != nil:
+		return g.Column.Title
+	}
+	return ""
+}
+
+// placeOnTimeline computes the time range covered by the scheduled rows and
+// the position of their bar on it.
+func placeOnTimeline(rows []*ViewRow) *Timeline {
+	timeline := &Timeline{}
+	for _, row := range rows {
+		for _, t := range []timeutil.TimeStamp{row.StartDate, row.TargetDate} {
+			if t == 0 {
+				continue
+			}
+			if timeline.Start == 0 || t < timeline.Start {
+				timeline.Start = t
+			}
+			if t > timeline.End {
+				timeline.End = t
+			}
+		}
+	}
+	if timeline.Start == 0 {
+		return timeline
+	}
+
+	// show at least a day, so that single dates get a visible bar
+	const day = 24 * 60 * 60
+	timeline.End += day
+	span := float64(timeline.End - timeline.Start)
+	for _, row := range rows {
+		if !row.IsScheduled() {
+			continue
+		}
+		start, end := row.StartDate, row.TargetDate
+		if start == 0 {
+			start = end
+		}
+		if end == 0 {
+			end = start
+		}
+		end += day
+		row.BarOffset = float64(start-timeline.Start) * 100 / span
+		row.BarWidth = float64(end-start) * 100 / span
+	}
+	return timeline
+}
+
+func getProjectForChange(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound("CanBeAccessedByOwnerRepo", nil)
+		return nil
+	}
+	return project
+}
+
+func viewFromForm(view *project_model.View, form *forms.ProjectViewForm) {
+	view.Title = form.Title
+	view.Type = form.Type
+	view.Filter = form.Filter
+	view.GroupBy = form.GroupBy
+	view.SortBy = form.SortBy
+	view.SortDesc = form.SortDesc
+	view.Fields = form.Fields
+}
+
+// NewViewPost creates a saved view of a project
+func NewViewPost(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view := &project_model.View{
+		ProjectID: project.ID,
+		CreatorID: ctx.Doer.ID,
+	}
+	viewFromForm(view, web.GetForm(ctx).(*forms.ProjectViewForm))
+	if err := project_model.NewView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.projects.view.invalid"))
+			ctx.Redirect(project.Link(ctx))
+			return
+		}
+		ctx.ServerError("NewView", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.created", view.Title))
+	ctx.Redirect(fmt.Sprintf("%s?view=%d", project.Link(ctx), view.ID))
+}
+
+// EditViewPost updates the definition of a saved view of a project
+func EditViewPost(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view, err := project_model.GetViewByID(ctx, project.ID, ctx.ParamsInt64(":viewID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByID", project_model.IsErrProjectViewNotExist, err)
+		return
+	}
+	viewFromForm(view, web.GetForm(ctx).(*forms.ProjectViewForm))
+	if err := project_model.UpdateView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.projects.view.invalid"))
+		} else {
+			ctx.ServerError("UpdateView", err)
+			return
+		}
+	}
+
+	ctx.Redirect(fmt.Sprintf("%s?view=%d", project.Link(ctx), view.ID))
+}
+
+// DeleteView deletes a saved view of a project
+func DeleteView(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteViewByID(ctx, project.ID, ctx.ParamsInt64(":viewID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteViewByID", project_model.IsErrProjectViewNotExist, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.projects.view.deleted"))
+	ctx.JSONRedirect(project.Link(ctx))
+}
+
+// NewFieldPost creates a custom field of a project
+func NewFieldPost(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ProjectFieldForm)
+	if err := project_model.NewField(ctx, &project_model.Field{
+		ProjectID: project.ID,
+		CreatorID: ctx.Doer.ID,
+		Name:      form.Name,
+		Type:      form.Type,
+	}); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.projects.field.invalid"))
+		} else {
+			ctx.ServerError("NewField", err)
+			return
+		}
+	}
+
+	ctx.Redirect(ctx.Req.Referer())
+}
+
+// DeleteField deletes a custom field of a project along with its values
+func DeleteField(ctx *context.Context) {
+	project := getProjectForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteFieldByID(ctx, project.ID, ctx.ParamsInt64(":fieldID")); err != nil {
+		ctx.NotFoundOrServerError("DeleteFieldByID", project_model.IsErrProjectFieldNotExist, err)
+		return
+	}
+
+	ctx.JSONRedirect(ctx.Req.Referer())
+}
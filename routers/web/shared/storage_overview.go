@@ -54,6 +54,8 @@ func StorageOverview(ctx *context.Context, userID int64, tpl base.TplName) {
 			return ctx.Locale.Tr("settings.quota.sizes.assets.packages.all")
 		case quota_model.LimitSubjectSizeWiki:
 			return ctx.Locale.Tr("settings.quota.sizes.wiki")
+		case quota_model.LimitSubjectSizeAssetsTerraformStates:
+			return ctx.Locale.Tr("settings.quota.sizes.assets.terraform_states")
 		default:
 			panic("unrecognized subject: " + subject.String())
 		}
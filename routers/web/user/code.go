@@ -27,6 +27,9 @@ func CodeSearch(ctx *context.Context) {
 		ctx.Redirect(ctx.ContextUser.HomeLink())
 		return
 	}
+	if !ctx.CheckIPAllowlist(ctx.ContextUser.ID) {
+		return
+	}
 	shared_user.PrepareContextForProfileBigAvatar(ctx)
 	shared_user.RenderUserHeader(ctx)
 
@@ -509,6 +509,15 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		// So we need search issues in all public repos.
 		opts.AllPublic = true
 	}
+	{
+		// the IP allowlists of organizations also apply to the dashboard
+		blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+		if err != nil {
+			ctx.ServerError("BlockedRepoIDs", err)
+			return
+		}
+		opts.ExcludedRepoIDs = blockedRepoIDs
+	}
 
 	switch filterMode {
 	case issues_model.FilterModeAll:
@@ -695,6 +704,11 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	pager.AddParam(ctx, "assignee", "AssigneeID")
 	ctx.Data["Page"] = pager
 
+	prepareSavedSearches(ctx, ctxUser, isPullList)
+	if ctx.Written() {
+		return
+	}
+
 	ctx.HTML(http.StatusOK, tplIssues)
 }
 
@@ -93,9 +93,17 @@ func getNotifications(ctx *context.Context) {
 		status = activities_model.NotificationStatusUnread
 	}
 
+	// the notifications of repositories which the IP allowlist of their organization blocks are hidden
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.ServerError("BlockedRepoIDs", err)
+		return
+	}
+
 	total, err := db.Count[activities_model.Notification](ctx, activities_model.FindNotificationOptions{
-		UserID: ctx.Doer.ID,
-		Status: []activities_model.NotificationStatus{status},
+		UserID:          ctx.Doer.ID,
+		ExcludedRepoIDs: blockedRepoIDs,
+		Status:          []activities_model.NotificationStatus{status},
 	})
 	if err != nil {
 		ctx.ServerError("ErrGetNotificationCount", err)
@@ -115,8 +123,9 @@ func getNotifications(ctx *context.Context) {
 			PageSize: perPage,
 			Page:     page,
 		},
-		UserID: ctx.Doer.ID,
-		Status: statuses,
+		UserID:          ctx.Doer.ID,
+		ExcludedRepoIDs: blockedRepoIDs,
+		Status:          statuses,
 	})
 	if err != nil {
 		ctx.ServerError("db.Find[activities_model.Notification]", err)
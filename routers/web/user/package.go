@@ -4,6 +4,7 @@
 package user
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
@@ -283,6 +284,12 @@ func ViewPackageVersion(ctx *context.Context) {
 
 	ctx.Data["CanWritePackages"] = ctx.Package.AccessMode >= perm.AccessModeWrite || ctx.IsUserSiteAdmin()
 
+	ctx.Data["Attestations"], err = packages_service.GetAttestationDescriptors(ctx, pd)
+	if err != nil {
+		ctx.ServerError("GetAttestationDescriptors", err)
+		return
+	}
+
 	hasRepositoryAccess := false
 	if pd.Repository != nil {
 		permission, err := access_model.GetUserRepoPermission(ctx, pd.Repository, ctx.Doer)
@@ -473,7 +480,9 @@ func PackageSettingsPost(ctx *context.Context) {
 		return
 	case "delete":
 		err := packages_service.RemovePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version)
-		if err != nil {
+		if errors.Is(err, packages_service.ErrImmutableVersion) {
+			ctx.Flash.Error(ctx.Tr("packages.settings.delete.immutable"))
+		} else if err != nil {
 			log.Error("Error deleting package: %v", err)
 			ctx.Flash.Error(ctx.Tr("packages.settings.delete.error"))
 		} else {
@@ -0,0 +1,211 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"errors"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+// SavedSearch is a saved search listed on the issues and pull requests dashboards
+type SavedSearch struct {
+	*issues_model.SavedSearch
+	// Count is the live count of the issues of a pinned search
+	Count     int64
+	CanChange bool
+}
+
+// prepareSavedSearches lists the saved searches of the dashboard: on the dashboard of an organization
+// those it shares with the doer, on the dashboard of the doer their own and those shared with them
+func prepareSavedSearches(ctx *context.Context, ctxUser *user_model.User, isPull bool) {
+	opts := issues_model.FindSavedSearchesOptions{
+		Doer:   ctx.Doer,
+		IsPull: optional.Some(isPull),
+	}
+	if ctxUser.IsOrganization() {
+		opts.OwnerID = ctxUser.ID
+	}
+	searches, err := db.Find[issues_model.SavedSearch](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+
+	blockedRepoIDs, err := context.BlockedRepoIDs(ctx, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.ServerError("BlockedRepoIDs", err)
+		return
+	}
+
+	items := make([]*SavedSearch, 0, len(searches))
+	for _, search := range searches {
+		if err := search.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		item := &SavedSearch{SavedSearch: search}
+		if item.CanChange, err = search.CanBeChangedBy(ctx, ctx.Doer); err != nil {
+			ctx.ServerError("CanBeChangedBy", err)
+			return
+		}
+		if search.IsPinned {
+			if item.Count, err = issue_service.CountSavedSearchIssues(ctx, ctx.Doer, search, blockedRepoIDs); err != nil {
+				ctx.ServerError("CountSavedSearchIssues", err)
+				return
+			}
+		}
+		items = append(items, item)
+	}
+	ctx.Data["SavedSearches"] = items
+}
+
+// NewSavedSearchPost saves the search of the issues or pull requests dashboard, for the doer
+// or for the organization of the dashboard
+func NewSavedSearchPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchForm)
+	search := &issues_model.SavedSearch{
+		OwnerID:   ctx.Doer.ID,
+		CreatorID: ctx.Doer.ID,
+		Name:      form.Name,
+		IsPull:    form.IsPull,
+		IsPinned:  form.Pinned,
+		Keyword:   form.Keyword,
+		ViewType:  form.Type,
+		State:     form.State,
+		Labels:    form.Labels,
+		SortType:  form.Sort,
+	}
+
+	if form.OrgID > 0 {
+		org, err := organization.GetOrgByID(ctx, form.OrgID)
+		if err != nil {
+			ctx.NotFoundOrServerError("GetOrgByID", organization.IsErrOrgNotExist, err)
+			return
+		}
+		if isMember, err := organization.IsOrganizationMember(ctx, org.ID, ctx.Doer.ID); err != nil {
+			ctx.ServerError("IsOrganizationMember", err)
+			return
+		} else if !isMember {
+			ctx.NotFound("IsOrganizationMember", nil)
+			return
+		}
+		search.OwnerID = org.ID
+		search.Owner = org.AsUser()
+
+		if form.TeamID > 0 {
+			team, err := organization.GetTeamByID(ctx, form.TeamID)
+			if err != nil {
+				ctx.NotFoundOrServerError("GetTeamByID", organization.IsErrTeamNotExist, err)
+				return
+			}
+			if team.OrgID != org.ID {
+				ctx.NotFound("GetTeamByID", nil)
+				return
+			}
+			if !canShareWithTeam(ctx, team) {
+				return
+			}
+			search.TeamID = team.ID
+			search.Team = team
+		}
+	} else {
+		search.Owner = ctx.Doer
+	}
+
+	if err := issues_model.CreateSavedSearch(ctx, search); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("home.saved_search.invalid"))
+			ctx.Redirect(search.Link())
+			return
+		}
+		ctx.ServerError("CreateSavedSearch", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("home.saved_search.created", search.Name))
+	ctx.Redirect(search.Link())
+}
+
+// canShareWithTeam checks if the doer is a member of the team or an owner of its organization
+func canShareWithTeam(ctx *context.Context, team *organization.Team) bool {
+	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("IsTeamMember", err)
+		return false
+	}
+	if !isMember {
+		isOwner, err := organization.IsOrganizationOwner(ctx, team.OrgID, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("IsOrganizationOwner", err)
+			return false
+		}
+		if !isOwner {
+			ctx.NotFound("IsTeamMember", nil)
+			return false
+		}
+	}
+	return true
+}
+
+// getSavedSearchForChange returns the saved search identified by the id parameter
+// if the doer can change it
+func getSavedSearchForChange(ctx *context.Context) *issues_model.SavedSearch {
+	search, err := issues_model.GetSavedSearchByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetSavedSearchByID", issues_model.IsErrSavedSearchNotExist, err)
+		return nil
+	}
+	canChange, err := search.CanBeChangedBy(ctx, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("CanBeChangedBy", err)
+		return nil
+	}
+	if !canChange {
+		ctx.NotFound("CanBeChangedBy", nil)
+		return nil
+	}
+	return search
+}
+
+// PinSavedSearch pins a saved search to the dashboard, or unpins it
+func PinSavedSearch(ctx *context.Context) {
+	search := getSavedSearchForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	search.IsPinned = !search.IsPinned
+	if err := issues_model.UpdateSavedSearch(ctx, search); err != nil {
+		ctx.ServerError("UpdateSavedSearch", err)
+		return
+	}
+
+	ctx.JSONOK()
+}
+
+// DeleteSavedSearch deletes a saved search
+func DeleteSavedSearch(ctx *context.Context) {
+	search := getSavedSearchForChange(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.DeleteSavedSearchByID(ctx, search.ID); err != nil {
+		ctx.NotFoundOrServerError("DeleteSavedSearchByID", issues_model.IsErrSavedSearchNotExist, err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("home.saved_search.deleted", search.Name))
+	ctx.JSONOK()
+}
@@ -70,6 +70,7 @@ func AccountPost(ctx *context.Context) {
 		opts := &user.UpdateAuthOptions{
 			Password:           optional.Some(form.Password),
 			MustChangePassword: optional.Some(false),
+			KeepSession:        auth.UserSessionToken(ctx.Session),
 		}
 		if err := user.UpdateAuth(ctx, ctx.Doer, opts); err != nil {
 			switch {
@@ -5,14 +5,20 @@
 package setting
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/web"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -49,28 +55,64 @@ func ApplicationsPost(ctx *context.Context) {
 		ctx.ServerError("GetScope", err)
 		return
 	}
-	if !scope.HasPermissionScope() {
+	if !form.IsFineGrained() && !scope.HasPermissionScope() {
 		ctx.Flash.Error(ctx.Tr("settings.at_least_one_permission"), true)
 	}
-	t := &auth_model.AccessToken{
-		UID:   ctx.Doer.ID,
-		Name:  form.Name,
-		Scope: scope,
-	}
 
-	exist, err := auth_model.AccessTokenByNameExists(ctx, t)
+	exist, err := auth_model.AccessTokenByNameExists(ctx, &auth_model.AccessToken{UID: ctx.Doer.ID, Name: form.Name})
 	if err != nil {
 		ctx.ServerError("AccessTokenByNameExists", err)
 		return
 	}
 	if exist {
-		ctx.Flash.Error(ctx.Tr("settings.generate_token_name_duplicate", t.Name))
+		ctx.Flash.Error(ctx.Tr("settings.generate_token_name_duplicate", form.Name))
 		ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
 		return
 	}
 
-	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.ServerError("NewAccessToken", err)
+	opts := &auth_service.CreateAccessTokenOptions{
+		Name:       form.Name,
+		Scope:      scope,
+		AllowedIPs: form.AllowedIPs,
+	}
+	if form.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation("2006-01-02", form.ExpiresAt, time.Local)
+		if err != nil {
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_invalid"))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+			return
+		}
+		// the token is valid until the end of the selected day
+		opts.ExpiresUnix = timeutil.TimeStamp(expiresAt.Add(24*time.Hour - time.Second).Unix())
+	}
+	if form.IsFineGrained() {
+		opts.Permissions = form.GetPermissions()
+		opts.Organization = form.Organization
+		opts.Repositories = form.GetRepositories()
+	}
+
+	t, err := auth_service.CreateAccessToken(ctx, ctx.Doer, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth_service.ErrAccessTokenExpiryRequired):
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_required"))
+		case errors.Is(err, auth_service.ErrAccessTokenExpiryInvalid):
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_invalid"))
+		case errors.Is(err, auth_service.ErrAccessTokenExpiryTooLate):
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_too_late", int(setting.AccessTokenMaxLifetime.Hours()/24)))
+		case errors.Is(err, auth_service.ErrAccessTokenNoPermissions):
+			ctx.Flash.Error(ctx.Tr("settings.at_least_one_permission"))
+		case errors.Is(err, auth_service.ErrAccessTokenNoResources):
+			ctx.Flash.Error(ctx.Tr("settings.token_resources_required"))
+		case errors.Is(err, auth_service.ErrAccessTokenAllowedIPs):
+			ctx.Flash.Error(ctx.Tr("settings.token_allowed_ips_invalid"))
+		case auth_service.IsErrAccessTokenOptions(err):
+			ctx.Flash.Error(ctx.Tr("settings.token_resources_invalid"))
+		default:
+			ctx.ServerError("CreateAccessToken", err)
+			return
+		}
+		ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
 		return
 	}
 
@@ -82,7 +124,7 @@ func ApplicationsPost(ctx *context.Context) {
 
 // DeleteApplication response for delete user access token
 func DeleteApplication(ctx *context.Context) {
-	if err := auth_model.DeleteAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	if err := auth_service.DeleteAccessToken(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
@@ -116,6 +158,12 @@ func loadApplicationsData(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Tokens"] = tokens
+	ctx.Data["TokenResources"], err = loadAccessTokenResources(ctx, tokens)
+	if err != nil {
+		ctx.ServerError("loadAccessTokenResources", err)
+		return
+	}
+	ctx.Data["AccessTokenMaxLifetimeDays"] = int(setting.AccessTokenMaxLifetime.Hours() / 24)
 	ctx.Data["EnableOAuth2"] = setting.OAuth2.Enabled
 	ctx.Data["IsAdmin"] = ctx.Doer.IsAdmin
 	if setting.OAuth2.Enabled {
@@ -134,3 +182,39 @@ func loadApplicationsData(ctx *context.Context) {
 		ctx.Data["EnableAdditionalGrantScopes"] = setting.OAuth2.EnableAdditionalGrantScopes
 	}
 }
+
+// loadAccessTokenResources returns the names of the organization or repositories the fine-grained tokens are restricted to
+func loadAccessTokenResources(ctx *context.Context, tokens []*auth_model.AccessToken) (map[int64][]string, error) {
+	resources := make(map[int64][]string)
+	for _, t := range tokens {
+		if !t.IsFineGrained() {
+			continue
+		}
+		if t.ResourceOwnerID != 0 {
+			owner, err := user_model.GetUserByID(ctx, t.ResourceOwnerID)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			resources[t.ID] = []string{owner.Name}
+			continue
+		}
+		if err := t.LoadRepositories(ctx); err != nil {
+			return nil, err
+		}
+		repos, err := repo_model.GetRepositoriesMapByIDs(ctx, t.RepoIDs)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(repos))
+		for _, repoID := range t.RepoIDs {
+			if repo, ok := repos[repoID]; ok {
+				names = append(names, repo.FullName())
+			}
+		}
+		resources[t.ID] = names
+	}
+	return resources, nil
+}
@@ -63,7 +63,8 @@ func KeysPost(ctx *context.Context) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/keys")
 			return
 		}
-		if _, err = asymkey_model.AddPrincipalKey(ctx, ctx.Doer.ID, content, 0); err != nil {
+		_, err = asymkey_service.AddPrincipalKey(ctx, ctx.Doer, ctx.Doer, content, 0)
+		if err != nil {
 			ctx.Data["HasPrincipalError"] = true
 			switch {
 			case asymkey_model.IsErrKeyAlreadyExist(err), asymkey_model.IsErrKeyNameAlreadyUsed(err):
@@ -87,9 +88,9 @@ func KeysPost(ctx *context.Context) {
 		token := asymkey_model.VerificationToken(ctx.Doer, 1)
 		lastToken := asymkey_model.VerificationToken(ctx.Doer, 0)
 
-		keys, err := asymkey_model.AddGPGKey(ctx, ctx.Doer.ID, form.Content, token, form.Signature)
+		keys, err := asymkey_service.AddGPGKey(ctx, ctx.Doer, ctx.Doer, form.Content, token, form.Signature)
 		if err != nil && asymkey_model.IsErrGPGInvalidTokenSignature(err) {
-			keys, err = asymkey_model.AddGPGKey(ctx, ctx.Doer.ID, form.Content, lastToken, form.Signature)
+			keys, err = asymkey_service.AddGPGKey(ctx, ctx.Doer, ctx.Doer, form.Content, lastToken, form.Signature)
 		}
 		if err != nil {
 			ctx.Data["HasGPGError"] = true
@@ -180,7 +181,8 @@ func KeysPost(ctx *context.Context) {
 			return
 		}
 
-		if _, err = asymkey_model.AddPublicKey(ctx, ctx.Doer.ID, form.Title, content, 0); err != nil {
+		_, err = asymkey_service.AddPublicKey(ctx, ctx.Doer, ctx.Doer, form.Title, content, 0)
+		if err != nil {
 			ctx.Data["HasSSHError"] = true
 			switch {
 			case asymkey_model.IsErrKeyAlreadyExist(err):
@@ -245,7 +247,7 @@ func DeleteKey(ctx *context.Context) {
 			ctx.NotFound("Not Found", errors.New("gpg keys setting is not allowed to be visited"))
 			return
 		}
-		if err := asymkey_model.DeleteGPGKey(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
+		if err := asymkey_service.DeleteGPGKey(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
 			ctx.Flash.Error("DeleteGPGKey: " + err.Error())
 		} else {
 			ctx.Flash.Success(ctx.Tr("settings.gpg_key_deletion_success"))
@@ -332,6 +334,16 @@ func loadKeysData(ctx *context.Context) {
 	}
 	ctx.Data["Principals"] = principals
 
+	ctx.Data["SSHCertificateAuthorityEnabled"] = setting.SSH.CertificateAuthorityEnabled
+	if setting.SSH.CertificateAuthorityEnabled {
+		certs, err := asymkey_model.FindValidSSHCertificates(ctx, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("FindValidSSHCertificates", err)
+			return
+		}
+		ctx.Data["SSHCertificates"] = certs
+	}
+
 	ctx.Data["VerifyingID"] = ctx.FormString("verify_gpg")
 	ctx.Data["VerifyingFingerprint"] = ctx.FormString("verify_ssh")
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
@@ -100,6 +100,42 @@ func RebuildCargoIndex(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
 }
 
+func UpdatePackageSigningPolicy(ctx *context.Context) {
+	shared.UpdateSigningPolicy(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
+}
+
+func AddPackageAccess(ctx *context.Context) {
+	shared.AddAccess(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
+}
+
+func DeletePackageAccess(ctx *context.Context) {
+	shared.DeleteAccess(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/packages")
+}
+
+func UpdatePackageImmutabilityPolicy(ctx *context.Context) {
+	shared.UpdateImmutabilityPolicy(ctx, ctx.Doer)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
+}
+
 func RegenerateChefKeyPair(ctx *context.Context) {
 	priv, pub, err := util.GenerateKeyPair(chef_module.KeyBits)
 	if err != nil {
@@ -45,8 +45,22 @@ func WebAuthnRegister(ctx *context.Context) {
 		ctx.ServerError("Unable to set session key for webauthnName", err)
 		return
 	}
+	if err := ctx.Session.Set("webauthnPasskey", form.Passkey); err != nil {
+		ctx.ServerError("Unable to set session key for webauthnPasskey", err)
+		return
+	}
 
-	credentialOptions, sessionData, err := wa.WebAuthn.BeginRegistration((*wa.User)(ctx.Doer))
+	var opts []webauthn.RegistrationOption
+	if form.Passkey {
+		// A passkey must be discoverable and verify the user, as it is used without a password
+		opts = append(opts, webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationRequired,
+		}))
+	}
+
+	credentialOptions, sessionData, err := wa.WebAuthn.BeginRegistration((*wa.User)(ctx.Doer), opts...)
 	if err != nil {
 		ctx.ServerError("Unable to BeginRegistration", err)
 		return
@@ -100,12 +114,17 @@ func WebauthnRegisterPost(ctx *context.Context) {
 	}
 
 	// Create the credential
-	_, err = auth.CreateCredential(ctx, ctx.Doer.ID, name, cred)
+	if passkey, _ := ctx.Session.Get("webauthnPasskey").(bool); passkey {
+		_, err = auth.CreatePasskey(ctx, ctx.Doer.ID, name, cred)
+	} else {
+		_, err = auth.CreateCredential(ctx, ctx.Doer.ID, name, cred)
+	}
 	if err != nil {
 		ctx.ServerError("CreateCredential", err)
 		return
 	}
 	_ = ctx.Session.Delete("webauthnName")
+	_ = ctx.Session.Delete("webauthnPasskey")
 
 	ctx.JSON(http.StatusCreated, cred)
 }
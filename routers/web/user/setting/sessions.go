@@ -0,0 +1,70 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/http"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/auth"
+	"forgejo.org/services/context"
+	"forgejo.org/services/user"
+)
+
+const tplSettingsSessions base.TplName = "user/settings/sessions"
+
+// Sessions lists the signed-in sessions of the user
+func Sessions(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.sessions")
+	ctx.Data["PageIsSettingsSessions"] = true
+
+	sessions, err := auth_model.GetUserSessionsByUserID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetUserSessionsByUserID", err)
+		return
+	}
+	ctx.Data["Sessions"] = sessions
+	ctx.Data["CurrentSession"] = auth.UserSessionToken(ctx.Session)
+
+	ctx.HTML(http.StatusOK, tplSettingsSessions)
+}
+
+// RevokeSession signs out a session of the user
+func RevokeSession(ctx *context.Context) {
+	if err := auth_model.DeleteUserSession(ctx, ctx.Doer.ID, ctx.FormInt64("id")); err != nil {
+		if util.IsErrNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("error.not_found"))
+		} else {
+			ctx.Flash.Error(ctx.Tr("error.server_internal"))
+			log.Error("DeleteUserSession: %v", err)
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("settings.sessions.revoke_success"))
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/sessions")
+}
+
+// RevokeOtherSessions signs out all sessions of the user except the current one
+func RevokeOtherSessions(ctx *context.Context) {
+	if err := user.SignOutSessions(ctx, ctx.Doer, auth.UserSessionToken(ctx.Session)); err != nil {
+		ctx.ServerError("SignOutSessions", err)
+		return
+	}
+
+	// the current device stays remembered
+	if len(ctx.GetSiteCookie(setting.CookieRememberName)) != 0 {
+		if err := ctx.SetLTACookie(ctx.Doer); err != nil {
+			ctx.ServerError("SetLTACookie", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.sessions.revoke_others_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/sessions")
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"strings"
+
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	asymkey_service "forgejo.org/services/asymkey"
+	"forgejo.org/services/context"
+)
+
+// SSHCertificatePost issues a short-lived SSH certificate for a public key and serves it as a file,
+// which ssh picks up when it is stored next to the private key as <identity>-cert.pub
+func SSHCertificatePost(ctx *context.Context) {
+	if user_model.IsFeatureDisabledWithLoginType(ctx.Doer, setting.UserFeatureManageSSHKeys) {
+		ctx.NotFound("Not Found", errors.New("ssh keys setting is not allowed to be visited"))
+		return
+	}
+
+	c, cert, err := asymkey_service.IssueSSHCertificate(ctx, ctx.Doer, ctx.FormString("content"))
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("form.invalid_ssh_key", err.Error()))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/keys")
+			return
+		}
+		ctx.ServerError("IssueSSHCertificate", err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(cert+"\n"), &context.ServeHeaderOptions{
+		ContentType: "text/plain",
+		Filename:    c.KeyID() + "-cert.pub",
+	})
+}
+
+// RevokeSSHCertificate revokes a SSH certificate of the user
+func RevokeSSHCertificate(ctx *context.Context) {
+	if err := asymkey_service.RevokeSSHCertificate(ctx, ctx.Doer, ctx.FormInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Flash.Error(ctx.Tr("error.not_found"))
+		} else {
+			ctx.Flash.Error(ctx.Tr("error.server_internal"))
+			log.Error("RevokeSSHCertificate: %v", err)
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("settings.ssh_certificate.revoke_success"))
+	}
+
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/keys")
+}
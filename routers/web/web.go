@@ -348,6 +348,20 @@ func registerRoutes(m *web.Route) {
 		}
 	}
 
+	passkeySignInEnabled := func(ctx *context.Context) {
+		if !setting.Service.EnableInternalSignIn {
+			ctx.Error(http.StatusForbidden)
+			return
+		}
+	}
+
+	sshCertificateAuthorityEnabled := func(ctx *context.Context) {
+		if !setting.SSH.CertificateAuthorityEnabled {
+			ctx.Error(http.StatusForbidden)
+			return
+		}
+	}
+
 	openIDSignUpEnabled := func(ctx *context.Context) {
 		if !setting.Service.EnableOpenIDSignUp {
 			ctx.Error(http.StatusForbidden)
@@ -384,6 +398,13 @@ func registerRoutes(m *web.Route) {
 		}
 	}
 
+	terraformEnabled := func(ctx *context.Context) {
+		if !setting.Terraform.Enabled {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+	}
+
 	federationEnabled := func(ctx *context.Context) {
 		if !setting.Federation.Enabled {
 			ctx.Error(http.StatusNotFound)
@@ -412,6 +433,13 @@ func registerRoutes(m *web.Route) {
 		}
 	}
 
+	advisoriesEnabled := func(ctx *context.Context) {
+		if !setting.Advisories.Enabled {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+	}
+
 	feedEnabled := func(ctx *context.Context) {
 		if !setting.Other.EnableFeed {
 			ctx.Error(http.StatusNotFound)
@@ -513,6 +541,15 @@ func registerRoutes(m *web.Route) {
 	}
 
 	m.Get("/pulls", reqSignIn, user.Pulls)
+	m.Group("/saved-searches", func() {
+		m.Post("/new", web.Bind(forms.SavedSearchForm{}), user.NewSavedSearchPost)
+		m.Group("/{id}", func() {
+			m.Get(".rss", feedEnabled, feed.ShowSavedSearchFeedRSS)
+			m.Get(".atom", feedEnabled, feed.ShowSavedSearchFeedAtom)
+			m.Post("/pin", user.PinSavedSearch)
+			m.Post("/delete", user.DeleteSavedSearch)
+		})
+	}, reqSignIn)
 	m.Get("/milestones", reqSignIn, reqMilestonesDashboardPageEnabled, user.Milestones)
 
 	// ***** START: User *****
@@ -550,6 +587,10 @@ func registerRoutes(m *web.Route) {
 			m.Get("", auth.WebAuthn)
 			m.Get("/assertion", auth.WebAuthnLoginAssertion)
 			m.Post("/assertion", auth.WebAuthnLoginAssertionPost)
+			m.Group("/passkey", func() {
+				m.Get("/assertion", auth.PasskeyLoginAssertion)
+				m.Post("/assertion", auth.PasskeyLoginAssertionPost)
+			}, passkeySignInEnabled)
 		})
 	}, reqSignOut)
 
@@ -561,11 +602,14 @@ func registerRoutes(m *web.Route) {
 			m.Post("/grant", web.Bind(forms.GrantApplicationForm{}), auth.GrantApplicationOAuth)
 			// TODO manage redirection
 			m.Post("/authorize", web.Bind(forms.AuthorizationForm{}), auth.AuthorizeOAuth)
+			m.Combo("/device").Get(auth.DeviceVerification).
+				Post(web.Bind(forms.DeviceVerificationForm{}), auth.DeviceVerificationPost)
 		}, reqSignIn)
 
 		m.Group("", func() {
 			m.Methods("GET, POST, OPTIONS", "/userinfo", auth.InfoOAuth)
 			m.Methods("POST, OPTIONS", "/access_token", web.Bind(forms.AccessTokenForm{}), auth.AccessTokenOAuth)
+			m.Methods("POST, OPTIONS", "/device_authorization", web.Bind(forms.DeviceAuthorizationForm{}), auth.DeviceAuthorizationOAuth)
 			m.Methods("GET, OPTIONS", "/keys", auth.OIDCKeys)
 			m.Methods("POST, OPTIONS", "/introspect", web.Bind(forms.IntrospectTokenForm{}), auth.IntrospectOAuth)
 		}, optionsCorsHandler(), ignoreCSRF)
@@ -613,6 +657,11 @@ func registerRoutes(m *web.Route) {
 			}, openIDSignInEnabled)
 			m.Post("/account_link", linkAccountEnabled, security.DeleteAccountLink)
 		}, requiredTwoFactor)
+		m.Group("/sessions", func() {
+			m.Get("", user_setting.Sessions)
+			m.Post("/revoke", user_setting.RevokeSession)
+			m.Post("/revoke_others", user_setting.RevokeOtherSessions)
+		})
 
 		m.Group("/applications", func() {
 			// oauth2 applications
@@ -635,6 +684,10 @@ func registerRoutes(m *web.Route) {
 		m.Combo("/keys").Get(user_setting.Keys).
 			Post(web.Bind(forms.AddKeyForm{}), user_setting.KeysPost)
 		m.Post("/keys/delete", user_setting.DeleteKey)
+		m.Group("/keys/certificate", func() {
+			m.Post("", user_setting.SSHCertificatePost)
+			m.Post("/revoke", user_setting.RevokeSSHCertificate)
+		}, sshCertificateAuthorityEnabled)
 		m.Group("/packages", func() {
 			m.Get("", user_setting.Packages)
 			m.Group("/rules", func() {
@@ -653,6 +706,12 @@ func registerRoutes(m *web.Route) {
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
 			})
 			m.Post("/chef/regenerate_keypair", user_setting.RegenerateChefKeyPair)
+			m.Post("/signing", user_setting.UpdatePackageSigningPolicy)
+			m.Post("/immutability", user_setting.UpdatePackageImmutabilityPolicy)
+			m.Group("/access", func() {
+				m.Post("/add", web.Bind(forms.PackageAccessForm{}), user_setting.AddPackageAccess)
+				m.Post("/delete", user_setting.DeletePackageAccess)
+			})
 		}, packagesEnabled)
 
 		m.Group("/actions", func() {
@@ -702,6 +761,11 @@ func registerRoutes(m *web.Route) {
 			m.Get("/{provider}", auth.SignInOAuth)
 			m.Get("/{provider}/callback", auth.SignInOAuthCallback)
 		})
+		m.Group("/saml/{provider}", func() {
+			m.Get("", auth.SignInSAML)
+			m.Get("/metadata", auth.SAMLMetadata)
+			m.Post("/acs", ignoreCSRF, auth.SAMLAssertionConsumer)
+		})
 	})
 	// ***** END: User *****
 
@@ -747,6 +811,7 @@ func registerRoutes(m *web.Route) {
 			m.Get("/{userid}", admin.ViewUser)
 			m.Combo("/{userid}/edit").Get(admin.EditUser).Post(web.Bind(forms.AdminEditUserForm{}), admin.EditUserPost)
 			m.Post("/{userid}/delete", admin.DeleteUser)
+			m.Post("/{userid}/sign_out", admin.SignOutUser)
 			m.Post("/{userid}/avatar", web.Bind(forms.AvatarForm{}), admin.AvatarPost)
 			m.Post("/{userid}/avatar/delete", admin.DeleteAvatar)
 		})
@@ -771,8 +836,16 @@ func registerRoutes(m *web.Route) {
 			m.Get("", admin.Packages)
 			m.Post("/delete", admin.DeletePackageVersion)
 			m.Post("/cleanup", admin.CleanupExpiredData)
+			m.Get("/container-gc", admin.ContainerGarbage)
+			m.Post("/container-gc", admin.CollectContainerGarbage)
 		}, packagesEnabled)
 
+		m.Group("/advisories", func() {
+			m.Get("", admin.Advisories)
+			m.Post("/upload", admin.UploadAdvisories)
+			m.Post("/update", admin.UpdateAdvisories)
+		}, advisoriesEnabled)
+
 		m.Group("/hooks", func() {
 			m.Get("", admin.DefaultOrSystemWebhooks)
 			m.Post("/delete", admin.DeleteDefaultOrSystemWebhook)
@@ -796,12 +869,24 @@ func registerRoutes(m *web.Route) {
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
 		})
 
+		m.Group("/ssh_certificates", func() {
+			m.Get("", admin.SSHCertificates)
+			m.Post("/rotate", admin.RotateSSHCertificateAuthority)
+			m.Post("/authorities/delete", admin.DeleteSSHCertificateAuthority)
+			m.Post("/revoke", admin.RevokeSSHCertificate)
+		}, sshCertificateAuthorityEnabled)
+
 		m.Group("/notices", func() {
 			m.Get("", admin.Notices)
 			m.Post("/delete", admin.DeleteNotices)
 			m.Post("/empty", admin.EmptyNotices)
 		})
 
+		m.Group("/audit", func() {
+			m.Get("", admin.Audit)
+			m.Get("/export", admin.AuditExport)
+		})
+
 		m.Group("/applications", func() {
 			m.Get("", admin.Applications)
 			m.Post("/oauth2", web.Bind(forms.EditOAuth2ApplicationForm{}), admin.ApplicationsPost)
@@ -825,7 +910,7 @@ func registerRoutes(m *web.Route) {
 			})
 			m.Post("/abuse_reports/act", admin.PerformAction)
 		}
-	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableModeration", setting.Moderation.Enabled))
+	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableModeration", setting.Moderation.Enabled, "EnableAdvisories", setting.Advisories.Enabled, "EnableSSHCertificateAuthority", setting.SSH.CertificateAuthorityEnabled))
 	// ***** END: Admin *****
 
 	m.Group("", func() {
@@ -980,6 +1065,23 @@ func registerRoutes(m *web.Route) {
 				})
 				m.Get("/storage_overview", org_setting.StorageOverview)
 
+				m.Group("/two_factor", func() {
+					m.Get("", org_setting.TwoFactor)
+					m.Post("", org_setting.TwoFactorPost)
+					m.Post("/remove", org_setting.TwoFactorRemove)
+				})
+
+				m.Group("/ip_allowlist", func() {
+					m.Get("", org_setting.IPAllowlist)
+					m.Post("", org_setting.IPAllowlistPost)
+					m.Post("/remove", org_setting.IPAllowlistRemove)
+				})
+
+				m.Group("/audit", func() {
+					m.Get("", org.Audit)
+					m.Get("/export", org.AuditExport)
+				})
+
 				m.Group("/packages", func() {
 					m.Get("", org.Packages)
 					m.Group("/rules", func() {
@@ -997,6 +1099,16 @@ func registerRoutes(m *web.Route) {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
 					})
+					m.Group("/remotes", func() {
+						m.Post("/add", web.Bind(forms.PackageRemoteForm{}), org.AddPackageRemote)
+						m.Post("/delete", org.DeletePackageRemote)
+					})
+					m.Post("/signing", org.UpdatePackageSigningPolicy)
+					m.Post("/immutability", org.UpdatePackageImmutabilityPolicy)
+					m.Group("/access", func() {
+						m.Post("/add", web.Bind(forms.PackageAccessForm{}), org.AddPackageAccess)
+						m.Post("/delete", org.DeletePackageAccess)
+					})
 				}, packagesEnabled)
 			}, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableQuota", setting.Quota.Enabled, "PageIsOrgSettings", true))
 		}, context.OrgAssignment(true, true))
@@ -1046,6 +1158,13 @@ func registerRoutes(m *web.Route) {
 					m.Post("", web.Bind(forms.EditProjectColumnForm{}), org.AddColumnToProjectPost)
 					m.Post("/move", project.MoveColumns)
 					m.Post("/delete", org.DeleteProject)
+					m.Post("/views/new", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+					m.Post("/views/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+					m.Post("/views/{viewID}/delete", project.DeleteView)
+					m.Post("/fields/new", web.Bind(forms.ProjectFieldForm{}), project.NewFieldPost)
+					m.Post("/fields/{fieldID}/delete", project.DeleteField)
+					m.Post("/automation/new", web.Bind(forms.ProjectAutomationRuleForm{}), project.NewAutomationRulePost)
+					m.Post("/automation/{ruleID}/delete", project.DeleteAutomationRule)
 
 					m.Get("/edit", org.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), org.EditProjectPost)
@@ -1139,6 +1258,17 @@ func registerRoutes(m *web.Route) {
 				m.Post("/delete", repo_setting.DeleteDeployKey)
 			})
 
+			m.Group("/audit", func() {
+				m.Get("", repo_setting.Audit)
+				m.Get("/export", repo_setting.AuditExport)
+			})
+
+			m.Group("/sla", func() {
+				m.Combo("").Get(repo_setting.SLAPolicies).
+					Post(web.Bind(forms.SLAPolicyForm{}), repo_setting.SLAPoliciesPost)
+				m.Post("/delete", repo_setting.DeleteSLAPolicy)
+			})
+
 			m.Group("/lfs", func() {
 				m.Get("/", repo_setting.LFSFiles)
 				m.Get("/show/{oid}", repo_setting.LFSFileGet)
@@ -1152,6 +1282,15 @@ func registerRoutes(m *web.Route) {
 					m.Post("/{lid}/unlock", repo_setting.LFSUnlock)
 				})
 			})
+			m.Group("/terraform", func() {
+				m.Get("", repo_setting.TerraformStates)
+				m.Post("/delete", repo_setting.DeleteTerraformState)
+				m.Group("/{id}", func() {
+					m.Get("", repo_setting.TerraformState)
+					m.Get("/versions/{version}", repo_setting.TerraformStateVersionDownload)
+					m.Post("/unlock", repo_setting.TerraformStateForceUnlock)
+				})
+			}, terraformEnabled)
 			m.Group("/actions", func() {
 				m.Get("", repo_setting.RedirectToDefaultSetting)
 				addSettingsRunnersRoutes()
@@ -1163,7 +1302,7 @@ func registerRoutes(m *web.Route) {
 				m.Post("/retry", repo.MigrateRetryPost)
 				m.Post("/cancel", repo.MigrateCancelPost)
 			})
-		}, ctxDataSet("PageIsRepoSettings", true, "LFSStartServer", setting.LFS.StartServer))
+		}, ctxDataSet("PageIsRepoSettings", true, "LFSStartServer", setting.LFS.StartServer, "TerraformEnabled", setting.Terraform.Enabled))
 	}, reqSignIn, context.RepoAssignment, context.UnitTypes(), reqRepoAdmin, context.RepoRef())
 
 	m.Group("/{username}/{reponame}/action", func() {
@@ -1231,6 +1370,10 @@ func registerRoutes(m *web.Route) {
 					m.Post("/add", repo.AddDependency)
 					m.Post("/delete", repo.RemoveDependency)
 				})
+				m.Group("/sub_issue", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/delete", repo.RemoveSubIssue)
+				})
 				m.Combo("/comments").Post(repo.MustAllowUserComment, web.Bind(forms.CreateCommentForm{}), repo.NewComment)
 				m.Group("/times", func() {
 					m.Post("/add", web.Bind(forms.AddTimeManuallyForm{}), repo.AddTimeManually)
@@ -1425,6 +1568,13 @@ func registerRoutes(m *web.Route) {
 					m.Post("", web.Bind(forms.EditProjectColumnForm{}), repo.AddColumnToProjectPost)
 					m.Post("/move", project.MoveColumns)
 					m.Post("/delete", repo.DeleteProject)
+					m.Post("/views/new", web.Bind(forms.ProjectViewForm{}), project.NewViewPost)
+					m.Post("/views/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+					m.Post("/views/{viewID}/delete", project.DeleteView)
+					m.Post("/fields/new", web.Bind(forms.ProjectFieldForm{}), project.NewFieldPost)
+					m.Post("/fields/{fieldID}/delete", project.DeleteField)
+					m.Post("/automation/new", web.Bind(forms.ProjectAutomationRuleForm{}), project.NewAutomationRulePost)
+					m.Post("/automation/{ruleID}/delete", project.DeleteAutomationRule)
 
 					m.Get("/edit", repo.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), repo.EditProjectPost)
@@ -1520,6 +1670,13 @@ func registerRoutes(m *web.Route) {
 			m.Get("/{period}", repo.ActivityAuthors)
 		}, context.RepoRef(), repo.MustBeNotEmpty, context.RequireRepoReaderOr(unit.TypeCode))
 
+		m.Group("/security", func() {
+			m.Get("", repo.Security)
+			m.Post("/scan", reqRepoAdmin, repo.SecurityScan)
+			m.Post("/{id}/dismiss", repo.DismissAlert)
+			m.Post("/{id}/reopen", repo.ReopenAlert)
+		}, advisoriesEnabled, reqRepoCodeWriter)
+
 		m.Group("/archive", func() {
 			m.Get("/*", repo.Download)
 			m.Post("/*", repo.InitiateDownload)
@@ -0,0 +1,127 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	advisory_model "forgejo.org/models/advisory"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/osv"
+	"forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	notify_service "forgejo.org/services/notify"
+)
+
+// advisoryNotifier scans the repositories and the packages for vulnerable dependencies when they change
+type advisoryNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &advisoryNotifier{}
+
+// Init registers the notifier scanning for vulnerable dependencies
+func Init() error {
+	if !setting.Advisories.Enabled {
+		return nil
+	}
+	notify_service.RegisterNotifier(&advisoryNotifier{})
+	return nil
+}
+
+func (*advisoryNotifier) PushCommits(ctx context.Context, _ *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, _ *repository.PushCommits) {
+	if opts.IsDelRef() || !opts.RefFullName.IsBranch() || opts.RefFullName.BranchName() != repo.DefaultBranch {
+		return
+	}
+	if err := ScanRepository(ctx, repo); err != nil {
+		log.Error("ScanRepository [%d]: %v", repo.ID, err)
+	}
+}
+
+func (*advisoryNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
+	if err := ScanRepository(ctx, repo); err != nil {
+		log.Error("ScanRepository [%d]: %v", repo.ID, err)
+	}
+}
+
+func (*advisoryNotifier) PackageCreate(ctx context.Context, _ *user_model.User, pd *packages_model.PackageDescriptor) {
+	if err := scanPackageVersion(ctx, pd.Package, pd.Version); err != nil {
+		log.Error("ScanPackageVersion [%d]: %v", pd.Version.ID, err)
+	}
+}
+
+// importEntry saves a new or modified advisory and deletes a withdrawn one.
+// It returns true if the stored advisories changed.
+func importEntry(ctx context.Context, e *osv.Entry) (bool, error) {
+	existing, err := advisory_model.GetAdvisoryByIdentifier(ctx, e.ID)
+	if err != nil && !errors.Is(err, advisory_model.ErrAdvisoryNotExist) {
+		return false, err
+	}
+
+	if e.Withdrawn != nil {
+		if existing == nil {
+			return false, nil
+		}
+		return true, advisory_model.DeleteAdvisory(ctx, existing.ID)
+	}
+
+	if existing != nil && existing.ModifiedUnix >= timeutil.TimeStamp(e.Modified.Unix()) {
+		return false, nil
+	}
+
+	a, err := advisory_model.NewAdvisory(e)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		a.ID = existing.ID
+	}
+	return true, advisory_model.SaveAdvisory(ctx, a)
+}
+
+// ImportDirectory imports the OSV entries of the JSON files in the directory tree.
+// It returns the number of new, modified and withdrawn advisories.
+func ImportDirectory(ctx context.Context, dir string) (int, error) {
+	count := 0
+	err := osv.WalkDirectory(dir, func(e *osv.Entry) error {
+		changed, err := importEntry(ctx, e)
+		if changed {
+			count++
+		}
+		return err
+	})
+	return count, err
+}
+
+// ImportArchive imports the OSV entries of the JSON files in a zip or a gzip compressed tar archive.
+// It returns the number of new, modified and withdrawn advisories.
+func ImportArchive(ctx context.Context, r io.ReaderAt, size int64) (int, error) {
+	count := 0
+	err := osv.WalkArchive(r, size, func(e *osv.Entry) error {
+		changed, err := importEntry(ctx, e)
+		if changed {
+			count++
+		}
+		return err
+	})
+	return count, err
+}
+
+// UpdateAdvisories imports the advisories of the configured directory and rescans all repositories and packages
+func UpdateAdvisories(ctx context.Context) error {
+	if setting.Advisories.Directory != "" {
+		count, err := ImportDirectory(ctx, setting.Advisories.Directory)
+		if err != nil {
+			return err
+		}
+		log.Info("Imported %d changed advisories from %s", count, setting.Advisories.Directory)
+	}
+	return ScanAll(ctx)
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+	"fmt"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/lockfile"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/osv"
+	notify_service "forgejo.org/services/notify"
+
+	"xorm.io/builder"
+)
+
+// ecosystems maps the package types to the OSV ecosystems their packages belong to
+var ecosystems = map[packages_model.Type]string{
+	packages_model.TypeCargo:    osv.EcosystemCargo,
+	packages_model.TypeComposer: osv.EcosystemPackagist,
+	packages_model.TypeCran:     osv.EcosystemCRAN,
+	packages_model.TypeGo:       osv.EcosystemGo,
+	packages_model.TypeHex:      osv.EcosystemHex,
+	packages_model.TypeMaven:    osv.EcosystemMaven,
+	packages_model.TypeNpm:      osv.EcosystemNpm,
+	packages_model.TypeNuGet:    osv.EcosystemNuGet,
+	packages_model.TypePub:      osv.EcosystemPub,
+	packages_model.TypePyPI:     osv.EcosystemPyPI,
+	packages_model.TypeRubyGems: osv.EcosystemRubyGems,
+}
+
+// matcher finds the advisories affecting package versions and caches the advisories per package
+type matcher struct {
+	advisories map[string][]*advisory_model.Advisory
+}
+
+func newMatcher() *matcher {
+	return &matcher{advisories: make(map[string][]*advisory_model.Advisory)}
+}
+
+// match returns unsaved alerts for the advisories affecting the version of the package
+func (m *matcher) match(ctx context.Context, ecosystem, name, version string) ([]*advisory_model.Alert, error) {
+	key := ecosystem + ":" + osv.NormalizeName(ecosystem, name)
+	advisories, ok := m.advisories[key]
+	if !ok {
+		var err error
+		advisories, err = advisory_model.GetAdvisoriesForPackage(ctx, ecosystem, name)
+		if err != nil {
+			return nil, err
+		}
+		m.advisories[key] = advisories
+	}
+
+	var alerts []*advisory_model.Alert
+	for _, a := range advisories {
+		e, err := a.Entry()
+		if err != nil {
+			log.Error("Invalid content of advisory %s: %v", a.Identifier, err)
+			continue
+		}
+		for i := range e.Affected {
+			affected := &e.Affected[i]
+			if !affected.Matches(ecosystem, name) || !affected.IsAffected(version) {
+				continue
+			}
+			alerts = append(alerts, &advisory_model.Alert{
+				AdvisoryID:   a.ID,
+				Ecosystem:    ecosystem,
+				PackageName:  name,
+				Version:      version,
+				FixedVersion: affected.FixedVersion(version),
+				Severity:     a.Severity,
+				Advisory:     a,
+			})
+			break
+		}
+	}
+	return alerts, nil
+}
+
+// ScanRepository matches the dependencies pinned by the lockfiles on the default branch of the repository
+// against the advisories and updates its alerts
+func ScanRepository(ctx context.Context, repo *repo_model.Repository) error {
+	return scanRepository(ctx, newMatcher(), repo)
+}
+
+func scanRepository(ctx context.Context, m *matcher, repo *repo_model.Repository) error {
+	if repo.IsEmpty || repo.DefaultBranch == "" {
+		return nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	manifests, err := lockfile.FindManifests(commit)
+	if err != nil {
+		return err
+	}
+
+	var found []*advisory_model.Alert
+	for _, manifest := range manifests {
+		for _, d := range manifest.Dependencies {
+			alerts, err := m.match(ctx, d.Ecosystem, d.Name, d.Version)
+			if err != nil {
+				return err
+			}
+			for _, a := range alerts {
+				a.ManifestPath = manifest.Path
+			}
+			found = append(found, alerts...)
+		}
+	}
+
+	opened, err := advisory_model.SyncAlerts(ctx, repo.ID, 0, found)
+	if err != nil {
+		return err
+	}
+	if len(opened) > 0 {
+		notify_service.NewVulnerabilityAlerts(ctx, repo, opened)
+	}
+	return nil
+}
+
+// ScanPackageVersion matches the package version against the advisories and updates its alerts
+func ScanPackageVersion(ctx context.Context, pv *packages_model.PackageVersion) error {
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	return scanPackageVersion(ctx, p, pv)
+}
+
+func scanPackageVersion(ctx context.Context, p *packages_model.Package, pv *packages_model.PackageVersion) error {
+	return scanPackageVersionWithMatcher(ctx, newMatcher(), p, pv)
+}
+
+func scanPackageVersionWithMatcher(ctx context.Context, m *matcher, p *packages_model.Package, pv *packages_model.PackageVersion) error {
+	ecosystem, ok := ecosystems[p.Type]
+	if !ok || pv.IsInternal {
+		return nil
+	}
+
+	found, err := m.match(ctx, ecosystem, p.Name, pv.Version)
+	if err != nil {
+		return err
+	}
+	opened, err := advisory_model.SyncAlerts(ctx, p.RepoID, pv.ID, found)
+	if err != nil {
+		return err
+	}
+	if len(opened) == 0 || p.RepoID == 0 {
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, p.RepoID)
+	if err != nil {
+		return err
+	}
+	notify_service.NewVulnerabilityAlerts(ctx, repo, opened)
+	return nil
+}
+
+// ScanAll rescans all repositories and package versions.
+// Errors of single repositories or packages are logged and do not stop the scan.
+func ScanAll(ctx context.Context) error {
+	m := newMatcher()
+
+	if err := db.Iterate(ctx, builder.Eq{"is_empty": false}, func(ctx context.Context, repo *repo_model.Repository) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := scanRepository(ctx, m, repo); err != nil {
+			log.Error("ScanRepository [%d]: %v", repo.ID, err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("scan repositories: %w", err)
+	}
+
+	types := make([]packages_model.Type, 0, len(ecosystems))
+	for t := range ecosystems {
+		types = append(types, t)
+	}
+	packages := make(map[int64]*packages_model.Package)
+
+	cond := builder.Eq{"is_internal": false}.And(
+		builder.In("package_id", builder.Select("id").From("package").Where(builder.In("type", types))),
+	)
+	if err := db.Iterate(ctx, cond, func(ctx context.Context, pv *packages_model.PackageVersion) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p, ok := packages[pv.PackageID]
+		if !ok {
+			var err error
+			if p, err = packages_model.GetPackageByID(ctx, pv.PackageID); err != nil {
+				log.Error("GetPackageByID [%d]: %v", pv.PackageID, err)
+				return nil
+			}
+			packages[pv.PackageID] = p
+		}
+		if err := scanPackageVersionWithMatcher(ctx, m, p, pv); err != nil {
+			log.Error("ScanPackageVersion [%d]: %v", pv.ID, err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("scan package versions: %w", err)
+	}
+	return nil
+}
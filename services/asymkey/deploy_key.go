@@ -8,12 +8,31 @@ import (
 
 	"forgejo.org/models"
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
 )
 
+// AddDeployKey adds a deploy key to a repository and records it in the audit log
+func AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, name, content string, readOnly bool) (*asymkey_model.DeployKey, error) {
+	key, err := asymkey_model.AddDeployKey(ctx, repo.ID, name, content, readOnly)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionRepoDeployKeyAdd, audit_service.DeployKeyTarget(repo, key), nil, audit_service.DeployKeyState(key))
+	return key, nil
+}
+
 // DeleteDeployKey deletes deploy key from its repository authorized_keys file if needed.
 func DeleteDeployKey(ctx context.Context, doer *user_model.User, id int64) error {
+	// the key is loaded for the audit log, models.DeleteDeployKey ignores keys which do not exist
+	key, err := asymkey_model.GetDeployKeyByID(ctx, id)
+	if err != nil && !asymkey_model.IsErrDeployKeyNotExist(err) {
+		return err
+	}
+
 	dbCtx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return err
@@ -27,5 +46,13 @@ func DeleteDeployKey(ctx context.Context, doer *user_model.User, id int64) error
 		return err
 	}
 
+	if key != nil {
+		repo, err := repo_model.GetRepositoryByID(ctx, key.RepoID)
+		if err != nil {
+			return err
+		}
+		audit_service.Record(ctx, doer, audit_model.ActionRepoDeployKeyRemove, audit_service.DeployKeyTarget(repo, key), audit_service.DeployKeyState(key), nil)
+	}
+
 	return asymkey_model.RewriteAllPublicKeys(ctx)
 }
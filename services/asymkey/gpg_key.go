@@ -0,0 +1,43 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"context"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// AddGPGKey adds the GPG key and its subkeys to the owner and records it in the audit log
+func AddGPGKey(ctx context.Context, doer, owner *user_model.User, content, token, signature string) ([]*asymkey_model.GPGKey, error) {
+	keys, err := asymkey_model.AddGPGKey(ctx, owner.ID, content, token, signature)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		audit_service.Record(ctx, doer, audit_model.ActionUserGPGKeyAdd, audit_service.GPGKeyTarget(owner, key), nil, audit_service.GPGKeyState(key))
+	}
+	return keys, nil
+}
+
+// DeleteGPGKey deletes a GPG key of the doer and records it in the audit log
+func DeleteGPGKey(ctx context.Context, doer *user_model.User, id int64) error {
+	key, err := asymkey_model.GetGPGKeyForUserByID(ctx, doer.ID, id)
+	if err != nil {
+		if asymkey_model.IsErrGPGKeyNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := asymkey_model.DeleteGPGKey(ctx, doer, id); err != nil {
+		return err
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionUserGPGKeyDelete, audit_service.GPGKeyTarget(doer, key), audit_service.GPGKeyState(key), nil)
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"context"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+)
+
+// IssueSSHCertificate issues a short-lived SSH certificate for a public key of the doer,
+// it returns the certificate in the authorized keys format
+func IssueSSHCertificate(ctx context.Context, doer *user_model.User, content string) (*asymkey_model.SSHCertificate, string, error) {
+	content, err := asymkey_model.CheckPublicKeyString(content)
+	if err != nil {
+		return nil, "", util.NewInvalidArgumentErrorf("%v", err)
+	}
+
+	c, cert, err := asymkey_model.IssueSSHCertificate(ctx, doer, content)
+	if err != nil {
+		return nil, "", err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserSSHCertificateCreate, audit_service.SSHCertificateTarget(c), nil, audit_service.SSHCertificateState(c))
+	return c, cert, nil
+}
+
+// RevokeSSHCertificate revokes a certificate, users can revoke their own certificates and admins all of them
+func RevokeSSHCertificate(ctx context.Context, doer *user_model.User, id int64) error {
+	c, err := asymkey_model.GetSSHCertificateByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !doer.IsAdmin && doer.ID != c.OwnerID {
+		return util.NewNotExistErrorf("SSH certificate does not exist [id: %d]", id)
+	}
+	if c.RevokedUnix != 0 {
+		return nil
+	}
+
+	before := audit_service.SSHCertificateState(c)
+	if err := asymkey_model.RevokeSSHCertificate(ctx, c); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserSSHCertificateRevoke, audit_service.SSHCertificateTarget(c), before, audit_service.SSHCertificateState(c))
+	return nil
+}
+
+// RotateSSHCertificateAuthority creates a new certificate authority which signs the certificates from now on
+func RotateSSHCertificateAuthority(ctx context.Context, doer *user_model.User) (*asymkey_model.SSHCertificateAuthority, error) {
+	ca, err := asymkey_model.RotateSSHCertificateAuthority(ctx)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionAdminSSHCertificateAuthorityRotate, audit_service.SSHCertificateAuthorityTarget(ca), nil, audit_service.SSHCertificateAuthorityState(ca))
+	return ca, nil
+}
+
+// DeleteSSHCertificateAuthority deletes a retired certificate authority
+func DeleteSSHCertificateAuthority(ctx context.Context, doer *user_model.User, id int64) error {
+	ca, err := asymkey_model.GetSSHCertificateAuthorityByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := asymkey_model.DeleteSSHCertificateAuthority(ctx, ca.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionAdminSSHCertificateAuthorityDelete, audit_service.SSHCertificateAuthorityTarget(ca), audit_service.SSHCertificateAuthorityState(ca), nil)
+	return nil
+}
@@ -7,10 +7,32 @@ import (
 	"context"
 
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
 )
 
+// AddPublicKey adds an SSH key to the owner and records it in the audit log
+func AddPublicKey(ctx context.Context, doer, owner *user_model.User, name, content string, authSourceID int64) (*asymkey_model.PublicKey, error) {
+	key, err := asymkey_model.AddPublicKey(ctx, owner.ID, name, content, authSourceID)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserSSHKeyAdd, audit_service.PublicKeyTarget(owner, key), nil, audit_service.PublicKeyState(key))
+	return key, nil
+}
+
+// AddPrincipalKey adds an SSH principal to the owner and records it in the audit log
+func AddPrincipalKey(ctx context.Context, doer, owner *user_model.User, content string, authSourceID int64) (*asymkey_model.PublicKey, error) {
+	key, err := asymkey_model.AddPrincipalKey(ctx, owner.ID, content, authSourceID)
+	if err != nil {
+		return nil, err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserSSHKeyAdd, audit_service.PublicKeyTarget(owner, key), nil, audit_service.PublicKeyState(key))
+	return key, nil
+}
+
 // DeletePublicKey deletes SSH key information both in database and authorized_keys file.
 func DeletePublicKey(ctx context.Context, doer *user_model.User, id int64) (err error) {
 	key, err := asymkey_model.GetPublicKeyByID(ctx, id)
@@ -42,6 +64,12 @@ func DeletePublicKey(ctx context.Context, doer *user_model.User, id int64) (err
 	}
 	committer.Close()
 
+	owner, err := user_model.GetUserByID(ctx, key.OwnerID)
+	if err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionUserSSHKeyDelete, audit_service.PublicKeyTarget(owner, key), audit_service.PublicKeyState(key), nil)
+
 	if key.Type == asymkey_model.KeyTypePrincipal {
 		return asymkey_model.RewriteAllPrincipalKeys(ctx)
 	}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package audit records security-relevant changes in the audit log
+package audit
+
+import (
+	"context"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web/middleware"
+)
+
+// Target is the object an event changed. OwnerID and RepoID decide in which organization
+// and repository audit logs the event is shown, the instance-wide audit log shows all events.
+type Target struct {
+	Type    audit_model.TargetType
+	ID      int64
+	Name    string
+	OwnerID int64
+	RepoID  int64
+}
+
+// RepoTarget returns the target for a repository
+func RepoTarget(repo *repo_model.Repository) Target {
+	return Target{Type: audit_model.TargetRepository, ID: repo.ID, Name: repo.FullName(), OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// UserTarget returns the target for a user or an organization
+func UserTarget(u *user_model.User) Target {
+	if u.IsOrganization() {
+		return Target{Type: audit_model.TargetOrganization, ID: u.ID, Name: u.Name, OwnerID: u.ID}
+	}
+	return Target{Type: audit_model.TargetUser, ID: u.ID, Name: u.Name}
+}
+
+// CollaboratorTarget returns the target for a collaborator of a repository
+func CollaboratorTarget(repo *repo_model.Repository, u *user_model.User) Target {
+	return Target{Type: audit_model.TargetUser, ID: u.ID, Name: u.Name, OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// OrgMemberTarget returns the target for a member of an organization
+func OrgMemberTarget(org *organization.Organization, u *user_model.User) Target {
+	return Target{Type: audit_model.TargetUser, ID: u.ID, Name: u.Name, OwnerID: org.ID}
+}
+
+// TeamTarget returns the target for a team of an organization
+func TeamTarget(org *organization.Organization, team *organization.Team) Target {
+	return Target{Type: audit_model.TargetTeam, ID: team.ID, Name: org.Name + "/" + team.Name, OwnerID: team.OrgID}
+}
+
+// BranchProtectionTarget returns the target for a branch protection rule of a repository
+func BranchProtectionTarget(repo *repo_model.Repository, rule *git_model.ProtectedBranch) Target {
+	return Target{Type: audit_model.TargetBranchProtection, ID: rule.ID, Name: repo.FullName() + ":" + rule.RuleName, OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// DeployKeyTarget returns the target for a deploy key of a repository
+func DeployKeyTarget(repo *repo_model.Repository, key *asymkey_model.DeployKey) Target {
+	return Target{Type: audit_model.TargetDeployKey, ID: key.ID, Name: repo.FullName() + ":" + key.Name, OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// WebhookTarget returns the target for a webhook. The repository is nil for webhooks of users,
+// organizations and for system webhooks.
+func WebhookTarget(hook *webhook_model.Webhook, repo *repo_model.Repository) Target {
+	target := Target{Type: audit_model.TargetWebhook, ID: hook.ID, Name: hook.URL, OwnerID: hook.OwnerID}
+	if repo != nil {
+		target.OwnerID, target.RepoID = repo.OwnerID, repo.ID
+	}
+	return target
+}
+
+// AccessTokenTarget returns the target for a personal access token
+func AccessTokenTarget(owner *user_model.User, token *auth_model.AccessToken) Target {
+	return Target{Type: audit_model.TargetAccessToken, ID: token.ID, Name: owner.Name + ":" + token.Name}
+}
+
+// PublicKeyTarget returns the target for a SSH key of a user
+func PublicKeyTarget(owner *user_model.User, key *asymkey_model.PublicKey) Target {
+	return Target{Type: audit_model.TargetSSHKey, ID: key.ID, Name: owner.Name + ":" + key.Name}
+}
+
+// GPGKeyTarget returns the target for a GPG key of a user
+func GPGKeyTarget(owner *user_model.User, key *asymkey_model.GPGKey) Target {
+	return Target{Type: audit_model.TargetGPGKey, ID: key.ID, Name: owner.Name + ":" + key.KeyID}
+}
+
+// SSHCertificateTarget returns the target for a SSH certificate issued to a user
+func SSHCertificateTarget(c *asymkey_model.SSHCertificate) Target {
+	return Target{Type: audit_model.TargetSSHCertificate, ID: c.ID, Name: c.KeyID()}
+}
+
+// SSHCertificateAuthorityTarget returns the target for a SSH certificate authority
+func SSHCertificateAuthorityTarget(ca *asymkey_model.SSHCertificateAuthority) Target {
+	return Target{Type: audit_model.TargetSSHCertificateAuthority, ID: ca.ID, Name: ca.Fingerprint}
+}
+
+// AuthSourceTarget returns the target for an authentication source
+func AuthSourceTarget(source *auth_model.Source) Target {
+	return Target{Type: audit_model.TargetAuthSource, ID: source.ID, Name: source.Name}
+}
+
+// Record adds an event to the audit log and writes it to the "audit" logger.
+// Before and after are the states of the target which the event stores the differences of,
+// they must not contain secrets. The client address is taken from the request of the context.
+// Failures are logged but not returned, the change the event records has already been made.
+func Record(ctx context.Context, doer *user_model.User, action audit_model.Action, target Target, before, after any) {
+	changes, err := audit_model.Diff(before, after)
+	if err != nil {
+		log.Error("audit: unable to compare the states of %s %q: %v", target.Type, target.Name, err)
+	}
+
+	event := &audit_model.Event{
+		Action:     action,
+		IPAddress:  middleware.GetRemoteHost(ctx),
+		OwnerID:    target.OwnerID,
+		RepoID:     target.RepoID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Changes:    changes,
+	}
+	if doer != nil {
+		event.ActorID, event.ActorName = doer.ID, doer.Name
+	}
+
+	if err := audit_model.Insert(ctx, event); err != nil {
+		log.Error("audit: unable to record %s of %s %q by %s: %v", action, target.Type, target.Name, event.ActorName, err)
+	}
+
+	if setting.IsAuditLogEnabled() {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Error("audit: unable to marshal event: %v", err)
+			return
+		}
+		log.GetLogger("audit").Info("%s", data)
+	}
+}
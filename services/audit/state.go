@@ -0,0 +1,291 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
+	git_model "forgejo.org/models/git"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	user_model "forgejo.org/models/user"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+)
+
+// The states are the attributes of the targets which the audit log records changes of.
+// They are snapshots, so they can be taken before a target is modified in place,
+// and they leave out secrets like webhook secrets and the configuration of authentication sources.
+
+type branchProtectionState struct {
+	RuleName                      string   `json:"rule_name"`
+	CanPush                       bool     `json:"can_push"`
+	EnableWhitelist               bool     `json:"enable_push_whitelist"`
+	WhitelistUserIDs              []int64  `json:"push_whitelist_user_ids"`
+	WhitelistTeamIDs              []int64  `json:"push_whitelist_team_ids"`
+	WhitelistDeployKeys           bool     `json:"push_whitelist_deploy_keys"`
+	EnableMergeWhitelist          bool     `json:"enable_merge_whitelist"`
+	MergeWhitelistUserIDs         []int64  `json:"merge_whitelist_user_ids"`
+	MergeWhitelistTeamIDs         []int64  `json:"merge_whitelist_team_ids"`
+	EnableStatusCheck             bool     `json:"enable_status_check"`
+	StatusCheckContexts           []string `json:"status_check_contexts"`
+	EnableApprovalsWhitelist      bool     `json:"enable_approvals_whitelist"`
+	ApprovalsWhitelistUserIDs     []int64  `json:"approvals_whitelist_user_ids"`
+	ApprovalsWhitelistTeamIDs     []int64  `json:"approvals_whitelist_team_ids"`
+	RequiredApprovals             int64    `json:"required_approvals"`
+	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
+	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
+	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
+	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
+	IgnoreStaleApprovals          bool     `json:"ignore_stale_approvals"`
+	RequireSignedCommits          bool     `json:"require_signed_commits"`
+	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
+	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
+	ApplyToAdmins                 bool     `json:"apply_to_admins"`
+}
+
+// BranchProtectionState returns the state of a branch protection rule, which may be nil
+func BranchProtectionState(rule *git_model.ProtectedBranch) any {
+	if rule == nil {
+		return nil
+	}
+	return &branchProtectionState{
+		RuleName:                      rule.RuleName,
+		CanPush:                       rule.CanPush,
+		EnableWhitelist:               rule.EnableWhitelist,
+		WhitelistUserIDs:              rule.WhitelistUserIDs,
+		WhitelistTeamIDs:              rule.WhitelistTeamIDs,
+		WhitelistDeployKeys:           rule.WhitelistDeployKeys,
+		EnableMergeWhitelist:          rule.EnableMergeWhitelist,
+		MergeWhitelistUserIDs:         rule.MergeWhitelistUserIDs,
+		MergeWhitelistTeamIDs:         rule.MergeWhitelistTeamIDs,
+		EnableStatusCheck:             rule.EnableStatusCheck,
+		StatusCheckContexts:           rule.StatusCheckContexts,
+		EnableApprovalsWhitelist:      rule.EnableApprovalsWhitelist,
+		ApprovalsWhitelistUserIDs:     rule.ApprovalsWhitelistUserIDs,
+		ApprovalsWhitelistTeamIDs:     rule.ApprovalsWhitelistTeamIDs,
+		RequiredApprovals:             rule.RequiredApprovals,
+		BlockOnRejectedReviews:        rule.BlockOnRejectedReviews,
+		BlockOnOfficialReviewRequests: rule.BlockOnOfficialReviewRequests,
+		BlockOnOutdatedBranch:         rule.BlockOnOutdatedBranch,
+		DismissStaleApprovals:         rule.DismissStaleApprovals,
+		IgnoreStaleApprovals:          rule.IgnoreStaleApprovals,
+		RequireSignedCommits:          rule.RequireSignedCommits,
+		ProtectedFilePatterns:         rule.ProtectedFilePatterns,
+		UnprotectedFilePatterns:       rule.UnprotectedFilePatterns,
+		ApplyToAdmins:                 rule.ApplyToAdmins,
+	}
+}
+
+// AccessModeState returns the state of a permission like the one of a collaborator
+func AccessModeState(mode perm.AccessMode) any {
+	return map[string]string{"access_mode": mode.ToString()}
+}
+
+// VisibilityState returns the state of the visibility of a repository
+func VisibilityState(private bool) any {
+	return map[string]bool{"private": private}
+}
+
+// OwnerState returns the state of the owner of a repository
+func OwnerState(owner *user_model.User) any {
+	return map[string]string{"owner": owner.Name}
+}
+
+// DeployKeyState returns the state of a deploy key
+func DeployKeyState(key *asymkey_model.DeployKey) any {
+	return map[string]any{
+		"name":        key.Name,
+		"fingerprint": key.Fingerprint,
+		"read_only":   key.IsReadOnly(),
+	}
+}
+
+// PublicKeyState returns the state of a SSH key of a user
+func PublicKeyState(key *asymkey_model.PublicKey) any {
+	return map[string]any{
+		"name":        key.Name,
+		"fingerprint": key.Fingerprint,
+	}
+}
+
+// SSHCertificateState returns the state of a SSH certificate issued to a user
+func SSHCertificateState(c *asymkey_model.SSHCertificate) any {
+	return map[string]any{
+		"fingerprint":  c.Fingerprint,
+		"principal":    c.Principal,
+		"valid_before": c.ValidBeforeUnix,
+		"revoked":      c.RevokedUnix != 0,
+	}
+}
+
+// SSHCertificateAuthorityState returns the state of a SSH certificate authority
+func SSHCertificateAuthorityState(ca *asymkey_model.SSHCertificateAuthority) any {
+	return map[string]any{
+		"fingerprint": ca.Fingerprint,
+		"active":      ca.IsActive,
+	}
+}
+
+// GPGKeyState returns the state of a GPG key of a user
+func GPGKeyState(key *asymkey_model.GPGKey) any {
+	return map[string]any{
+		"key_id":   key.KeyID,
+		"verified": key.Verified,
+	}
+}
+
+type teamState struct {
+	Name                    string            `json:"name"`
+	AccessMode              string            `json:"access_mode"`
+	IncludesAllRepositories bool              `json:"includes_all_repositories"`
+	CanCreateOrgRepo        bool              `json:"can_create_org_repo"`
+	Units                   map[string]string `json:"units,omitempty"`
+}
+
+// TeamState returns the state of a team, the units are included if they are loaded
+func TeamState(team *organization.Team) any {
+	if team == nil {
+		return nil
+	}
+	state := &teamState{
+		Name:                    team.Name,
+		AccessMode:              team.AccessMode.ToString(),
+		IncludesAllRepositories: team.IncludesAllRepositories,
+		CanCreateOrgRepo:        team.CanCreateOrgRepo,
+	}
+	if len(team.Units) > 0 {
+		state.Units = make(map[string]string, len(team.Units))
+		for _, unit := range team.Units {
+			state.Units[unit.Unit().NameKey] = unit.AccessMode.ToString()
+		}
+	}
+	return state
+}
+
+// MemberState returns the state of the membership of a user
+func MemberState(u *user_model.User) any {
+	return map[string]any{"member": u.Name}
+}
+
+// IPAllowlistState returns the state of the IP allowlist of an organization, which may be nil
+func IPAllowlistState(a *organization.IPAllowlist) any {
+	if a == nil {
+		return nil
+	}
+	return map[string]any{"allowed_ips": a.AllowedIPs}
+}
+
+// TwoFactorRequirementState returns the state of the two-factor requirement of an organization, which may be nil
+func TwoFactorRequirementState(r *organization.TwoFactorRequirement) any {
+	if r == nil {
+		return nil
+	}
+	return map[string]any{"deadline": r.DeadlineUnix}
+}
+
+type webhookState struct {
+	URL              string `json:"url"`
+	HTTPMethod       string `json:"http_method"`
+	ContentType      string `json:"content_type"`
+	Type             string `json:"type"`
+	Events           string `json:"events"`
+	IsActive         bool   `json:"active"`
+	HasSecret        bool   `json:"has_secret"`
+	HasAuthorization bool   `json:"has_authorization_header"`
+	IsSystemWebhook  bool   `json:"system_webhook,omitempty"`
+}
+
+// WebhookState returns the state of a webhook, which may be nil
+func WebhookState(hook *webhook_model.Webhook) any {
+	if hook == nil {
+		return nil
+	}
+	return &webhookState{
+		URL:              hook.URL,
+		HTTPMethod:       hook.HTTPMethod,
+		ContentType:      hook.ContentType.Name(),
+		Type:             string(hook.Type),
+		Events:           hook.Events,
+		IsActive:         hook.IsActive,
+		HasSecret:        hook.Secret != "",
+		HasAuthorization: len(hook.HeaderAuthorizationEncrypted) > 0,
+		IsSystemWebhook:  hook.IsSystemWebhook,
+	}
+}
+
+type accessTokenState struct {
+	Name        string                             `json:"name"`
+	Scope       string                             `json:"scope"`
+	Permissions *auth_model.AccessTokenPermissions `json:"permissions,omitempty"`
+	Expires     timeutil.TimeStamp                 `json:"expires,omitempty"`
+	AllowedIPs  string                             `json:"allowed_ips,omitempty"`
+}
+
+// AccessTokenState returns the state of a personal access token without the token itself
+func AccessTokenState(token *auth_model.AccessToken) any {
+	return &accessTokenState{
+		Name:        token.Name,
+		Scope:       string(token.Scope),
+		Permissions: token.Permissions,
+		Expires:     token.ExpiresUnix,
+		AllowedIPs:  token.AllowedIPs,
+	}
+}
+
+type userState struct {
+	Name                    string              `json:"name"`
+	FullName                string              `json:"full_name"`
+	Email                   string              `json:"email"`
+	LoginSource             int64               `json:"login_source"`
+	LoginName               string              `json:"login_name"`
+	Visibility              structs.VisibleType `json:"visibility"`
+	IsActive                bool                `json:"active"`
+	IsAdmin                 bool                `json:"admin"`
+	IsRestricted            bool                `json:"restricted"`
+	ProhibitLogin           bool                `json:"prohibit_login"`
+	AllowGitHook            bool                `json:"allow_git_hook"`
+	AllowImportLocal        bool                `json:"allow_import_local"`
+	AllowCreateOrganization bool                `json:"allow_create_organization"`
+	MaxRepoCreation         int                 `json:"max_repo_creation"`
+	MustChangePassword      bool                `json:"must_change_password"`
+}
+
+// UserState returns the state of the account of a user, which may be nil
+func UserState(u *user_model.User) any {
+	if u == nil {
+		return nil
+	}
+	return &userState{
+		Name:                    u.Name,
+		FullName:                u.FullName,
+		Email:                   u.Email,
+		LoginSource:             u.LoginSource,
+		LoginName:               u.LoginName,
+		Visibility:              u.Visibility,
+		IsActive:                u.IsActive,
+		IsAdmin:                 u.IsAdmin,
+		IsRestricted:            u.IsRestricted,
+		ProhibitLogin:           u.ProhibitLogin,
+		AllowGitHook:            u.AllowGitHook,
+		AllowImportLocal:        u.AllowImportLocal,
+		AllowCreateOrganization: u.AllowCreateOrganization,
+		MaxRepoCreation:         u.MaxRepoCreation,
+		MustChangePassword:      u.MustChangePassword,
+	}
+}
+
+// AuthSourceState returns the state of an authentication source without its configuration, which may contain secrets
+func AuthSourceState(source *auth_model.Source) any {
+	if source == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":   source.Name,
+		"type":   source.Type.String(),
+		"active": source.IsActive,
+		"sync":   source.IsSyncEnabled,
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web/middleware"
+	audit_service "forgejo.org/services/audit"
+
+	"xorm.io/builder"
+)
+
+var (
+	ErrAccessTokenExpiryRequired = util.NewInvalidArgumentErrorf("fine-grained access tokens require an expiration date")
+	ErrAccessTokenExpiryInvalid  = util.NewInvalidArgumentErrorf("the expiration date must be in the future")
+	ErrAccessTokenExpiryTooLate  = util.NewInvalidArgumentErrorf("the expiration date exceeds the maximum token lifetime")
+	ErrAccessTokenNoPermissions  = util.NewInvalidArgumentErrorf("fine-grained access tokens require at least one permission")
+	ErrAccessTokenNoResources    = util.NewInvalidArgumentErrorf("fine-grained access tokens require an organization or repositories")
+	ErrAccessTokenAllowedIPs     = util.NewInvalidArgumentErrorf("the IP allowlist must contain IP addresses or networks in CIDR notation")
+)
+
+// GetAccessToken returns the access token by its value.
+// Expired tokens and tokens used from addresses outside of their IP allowlist are handled as if they did not exist.
+func GetAccessToken(ctx context.Context, sha string) (*auth_model.AccessToken, error) {
+	t, err := auth_model.GetAccessTokenBySHA(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+	if t.IsExpired() {
+		log.Debug("Access token[%d] of user[%d] has expired", t.ID, t.UID)
+		return nil, auth_model.ErrAccessTokenNotExist{Token: sha}
+	}
+	if !t.AllowsRemoteHost(middleware.GetRemoteHost(ctx)) {
+		log.Warn("Access token[%d] of user[%d] used from %s which is not in its IP allowlist", t.ID, t.UID, middleware.GetRemoteHost(ctx))
+		return nil, auth_model.ErrAccessTokenNotExist{Token: sha}
+	}
+	if err := t.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetAccessTokenByID returns the access token by its id.
+// Expired tokens and tokens used from addresses outside of their IP allowlist are handled as if they did not exist.
+func GetAccessTokenByID(ctx context.Context, id int64) (*auth_model.AccessToken, error) {
+	t, err := auth_model.GetAccessTokenByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.IsExpired() {
+		log.Debug("Access token[%d] of user[%d] has expired", t.ID, t.UID)
+		return nil, auth_model.ErrAccessTokenNotExist{}
+	}
+	if !t.AllowsRemoteHost(middleware.GetRemoteHost(ctx)) {
+		log.Warn("Access token[%d] of user[%d] used from %s which is not in its IP allowlist", t.ID, t.UID, middleware.GetRemoteHost(ctx))
+		return nil, auth_model.ErrAccessTokenNotExist{}
+	}
+	if err := t.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SetAccessTokenData stores the access token used to authenticate the request
+func SetAccessTokenData(store DataStore, t *auth_model.AccessToken) {
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiTokenScope"] = t.Scope
+	store.GetData()["ApiToken"] = t
+}
+
+// CreateAccessTokenOptions are the options to create an access token
+type CreateAccessTokenOptions struct {
+	Name string
+	// Scope is the scope of a classic token. It is derived from the permissions for fine-grained tokens.
+	Scope auth_model.AccessTokenScope
+	// Permissions makes the token fine-grained if set
+	Permissions *auth_model.AccessTokenPermissions
+	// Organization restricts a fine-grained token to the repositories of an organization
+	Organization string
+	// Repositories restricts a fine-grained token to repositories given as "owner/name"
+	Repositories []string
+	ExpiresUnix  timeutil.TimeStamp
+	// AllowedIPs restricts the token to clients from these networks, separated by commas or whitespace
+	AllowedIPs string
+}
+
+// CreateAccessToken validates the options and creates an access token for the user
+func CreateAccessToken(ctx context.Context, doer *user_model.User, opts *CreateAccessTokenOptions) (*auth_model.AccessToken, error) {
+	t := &auth_model.AccessToken{
+		UID:         doer.ID,
+		Name:        opts.Name,
+		Scope:       opts.Scope,
+		ExpiresUnix: opts.ExpiresUnix,
+	}
+
+	if err := validateAccessTokenExpiry(opts.ExpiresUnix, opts.Permissions != nil); err != nil {
+		return nil, err
+	}
+
+	allowedIPs, err := hostmatcher.ParseIPNetList("", opts.AllowedIPs)
+	if err != nil {
+		return nil, ErrAccessTokenAllowedIPs
+	}
+	t.AllowedIPs = allowedIPs.SettingValue
+
+	var repoIDs []int64
+	if opts.Permissions != nil {
+		if opts.Permissions.IsEmpty() {
+			return nil, ErrAccessTokenNoPermissions
+		}
+		scope, err := opts.Permissions.Scope()
+		if err != nil {
+			return nil, err
+		}
+		t.Scope = scope
+		t.Permissions = opts.Permissions
+
+		orgName := strings.TrimSpace(opts.Organization)
+		switch {
+		case orgName != "" && len(opts.Repositories) == 0:
+			org, err := organization.GetOrgByName(ctx, orgName)
+			if err != nil {
+				return nil, err
+			}
+			isMember, err := org.IsOrgMember(ctx, doer.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !isMember && !doer.IsAdmin {
+				return nil, util.NewPermissionDeniedErrorf("you are not a member of the organization %s", org.Name)
+			}
+			t.ResourceOwnerID = org.ID
+		case orgName == "" && len(opts.Repositories) > 0:
+			if repoIDs, err = resolveAccessTokenRepositories(ctx, doer, opts.Repositories); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrAccessTokenNoResources
+		}
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth_model.NewAccessToken(ctx, t); err != nil {
+			return err
+		}
+		if len(repoIDs) == 0 {
+			return nil
+		}
+		return auth_model.SetAccessTokenRepositories(ctx, t.ID, repoIDs)
+	}); err != nil {
+		return nil, err
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionUserAccessTokenCreate, audit_service.AccessTokenTarget(doer, t), nil, audit_service.AccessTokenState(t))
+	return t, nil
+}
+
+// DeleteAccessToken deletes an access token of the user and records it in the audit log
+func DeleteAccessToken(ctx context.Context, doer *user_model.User, id int64) error {
+	t, exist, err := db.Get[auth_model.AccessToken](ctx, builder.Eq{"id": id, "uid": doer.ID})
+	if err != nil {
+		return err
+	} else if !exist {
+		return auth_model.ErrAccessTokenNotExist{}
+	}
+
+	if err := auth_model.DeleteAccessTokenByID(ctx, id, doer.ID); err != nil {
+		return err
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionUserAccessTokenDelete, audit_service.AccessTokenTarget(doer, t), audit_service.AccessTokenState(t), nil)
+	return nil
+}
+
+func validateAccessTokenExpiry(expires timeutil.TimeStamp, fineGrained bool) error {
+	if expires == 0 {
+		if fineGrained || setting.AccessTokenMaxLifetime > 0 {
+			return ErrAccessTokenExpiryRequired
+		}
+		return nil
+	}
+	if expires <= timeutil.TimeStampNow() {
+		return ErrAccessTokenExpiryInvalid
+	}
+	if setting.AccessTokenMaxLifetime > 0 && expires.AsTime().After(time.Now().Add(setting.AccessTokenMaxLifetime)) {
+		return ErrAccessTokenExpiryTooLate
+	}
+	return nil
+}
+
+func resolveAccessTokenRepositories(ctx context.Context, doer *user_model.User, names []string) ([]int64, error) {
+	repoIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		ownerName, repoName, ok := strings.Cut(strings.TrimSpace(name), "/")
+		if !ok || ownerName == "" || repoName == "" {
+			return nil, util.NewInvalidArgumentErrorf("invalid repository name: %s", name)
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, util.NewNotExistErrorf("repository %s does not exist", name)
+			}
+			return nil, err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if !perm.HasAccess() {
+			return nil, util.NewNotExistErrorf("repository %s does not exist", name)
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	return repoIDs, nil
+}
+
+// IsErrAccessTokenOptions returns true if the error is caused by invalid options to create an access token
+func IsErrAccessTokenOptions(err error) bool {
+	return errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrPermissionDenied)
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/auth/webauthn"
 	"forgejo.org/modules/log"
@@ -40,6 +41,7 @@ var (
 	gitRawOrAttachPathRe = regexp.MustCompile(`^/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/(?:(?:git-(?:(?:upload)|(?:receive))-pack$)|(?:info/refs$)|(?:HEAD$)|(?:objects/)|(?:raw/)|(?:releases/download/)|(?:attachments/))`)
 	lfsPathRe            = regexp.MustCompile(`^/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/info/lfs/`)
 	archivePathRe        = regexp.MustCompile(`^/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/archive/`)
+	savedSearchFeedRe    = regexp.MustCompile(`^/saved-searches/[0-9]+\.(?:rss|atom)$`)
 )
 
 func isGitRawOrAttachPath(req *http.Request) bool {
@@ -60,6 +62,18 @@ func isArchivePath(req *http.Request) bool {
 	return archivePathRe.MatchString(req.URL.Path)
 }
 
+// isSCIMPath checks if the request is sent to the SCIM endpoint, which identity providers
+// can only authenticate with an access token
+func isSCIMPath(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, "/scim/v2/")
+}
+
+// isSavedSearchFeed checks if the request fetches the feed of a saved search, which feed readers
+// can only authenticate with a token or a password
+func isSavedSearchFeed(req *http.Request) bool {
+	return req.Method == "GET" && savedSearchFeedRe.MatchString(req.URL.Path)
+}
+
 // handleSignIn clears existing session variables and stores new ones for the specified user object
 func handleSignIn(resp http.ResponseWriter, req *http.Request, sess SessionStore, user *user_model.User) {
 	// We need to regenerate the session...
@@ -79,10 +93,15 @@ func handleSignIn(resp http.ResponseWriter, req *http.Request, sess SessionStore
 	_ = sess.Delete("twofaOpenID")
 	_ = sess.Delete("webauthnAssertion")
 	_ = sess.Delete("linkAccount")
+	_ = sess.Delete("user_session")
 	err = sess.Set("uid", user.ID)
 	if err != nil {
 		log.Error(fmt.Sprintf("Error setting session: %v", err))
 	}
+	err = sess.Set("auth_method", auth_model.UserSessionAuthReverseProxy)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error setting session: %v", err))
+	}
 
 	// Language setting of the user overwrites the one previously set
 	// If the user does not have a locale set, we save the current one.
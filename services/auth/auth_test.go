@@ -132,3 +132,49 @@ func Test_isGitRawOrLFSPath(t *testing.T) {
 	}
 	setting.LFS.StartServer = origLFSStartServer
 }
+
+func Test_isSavedSearchFeed(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+
+		want bool
+	}{
+		{"GET", "/saved-searches/1.rss", true},
+		{"GET", "/saved-searches/12.atom", true},
+		{"POST", "/saved-searches/1.rss", false},
+		{"GET", "/saved-searches/1", false},
+		{"GET", "/saved-searches/new.rss", false},
+		{"GET", "/saved-searches/1/delete", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "http://localhost"+tt.path, nil)
+			if got := isSavedSearchFeed(req); got != tt.want {
+				t.Errorf("isSavedSearchFeed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isSCIMPath(t *testing.T) {
+	tests := []struct {
+		path string
+
+		want bool
+	}{
+		{"/scim/v2/Users", true},
+		{"/scim/v2/Groups/1", true},
+		{"/scim/v2", false},
+		{"/user2/scim/v2/Users", false},
+		{"/api/v1/scim/v2/Users", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "http://localhost"+tt.path, nil)
+			if got := isSCIMPath(req); got != tt.want {
+				t.Errorf("isSCIMPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -174,7 +174,7 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 		}
 		return uid, nil
 	}
-	t, err := auth_model.GetAccessTokenBySHA(ctx, tokenSHA)
+	t, err := GetAccessToken(ctx, tokenSHA)
 	if err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			// check task token
@@ -198,8 +198,7 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 	if t.UID == 0 {
 		return 0, auth_model.ErrAccessTokenNotExist{}
 	}
-	store.GetData()["IsApiToken"] = true
-	store.GetData()["ApiTokenScope"] = t.Scope
+	SetAccessTokenData(store, t)
 	return t.UID, nil
 }
 
@@ -210,7 +209,7 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 func (o *OAuth2) Verify(req *http.Request, w http.ResponseWriter, store DataStore, sess SessionStore) (*user_model.User, error) {
 	// These paths are not API paths, but we still want to check for tokens because they maybe in the API returned URLs
 	if !middleware.IsAPIPath(req) && !isAttachmentDownload(req) && !isAuthenticatedTokenRequest(req) &&
-		!isGitRawOrAttachPath(req) && !isArchivePath(req) {
+		!isGitRawOrAttachPath(req) && !isArchivePath(req) && !isSavedSearchFeed(req) && !isSCIMPath(req) {
 		return nil, nil
 	}
 
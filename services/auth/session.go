@@ -55,6 +55,17 @@ func (s *Session) Verify(req *http.Request, w http.ResponseWriter, store DataSto
 		return nil, nil
 	}
 
+	tracked, err := trackUserSession(req.Context(), req, sess, user.ID)
+	if err != nil {
+		log.Error("trackUserSession: %v", err)
+		return nil, err
+	}
+	if !tracked {
+		log.Trace("Session Authorization: Session of user %-v has been revoked", user)
+		_ = sess.Delete("user_session")
+		return nil, nil
+	}
+
 	log.Trace("Session Authorization: Logged in user %-v", user)
 	return user, nil
 }
@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"context"
+	"sort"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/optional"
+)
+
+// Provider describes a SAML source on the sign-in page
+type Provider struct {
+	Name    string
+	IconURL string
+}
+
+// GetSAMLProviders returns the active SAML sources sorted by name
+func GetSAMLProviders(ctx context.Context) ([]Provider, error) {
+	authSources, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]Provider, 0, len(authSources))
+	for _, source := range authSources {
+		providers = append(providers, Provider{
+			Name:    source.Name,
+			IconURL: source.Cfg.(*Source).IconURL,
+		})
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name < providers[j].Name
+	})
+
+	return providers, nil
+}
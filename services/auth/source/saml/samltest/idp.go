@@ -0,0 +1,226 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package samltest provides a minimal SAML identity provider to test SAML sources against.
+package samltest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// IdentityProvider answers every authentication request with an assertion for Session
+type IdentityProvider struct {
+	IDP     *saml.IdentityProvider
+	Server  *httptest.Server
+	Session *saml.Session
+
+	// LastRequestSigned reports whether the last authentication request carried a valid signature
+	LastRequestSigned bool
+
+	mu               sync.Mutex
+	serviceProviders map[string]*saml.EntityDescriptor
+}
+
+// NewIdentityProvider starts an identity provider serving its metadata at MetadataURL
+func NewIdentityProvider(t testing.TB, session *saml.Session) *IdentityProvider {
+	t.Helper()
+
+	certificatePEM, keyPEM := GenerateKeyPair(t)
+	certificate, key := parseKeyPair(t, certificatePEM, keyPEM)
+
+	idp := &IdentityProvider{
+		Session:          session,
+		serviceProviders: map[string]*saml.EntityDescriptor{},
+	}
+	idp.IDP = &saml.IdentityProvider{
+		Key:                     key,
+		Certificate:             certificate,
+		Logger:                  logger.DefaultLogger,
+		ServiceProviderProvider: idp,
+		SessionProvider:         idp,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata", idp.IDP.ServeMetadata)
+	mux.HandleFunc("/sso", idp.IDP.ServeSSO)
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Server.Close)
+
+	serverURL, err := url.Parse(idp.Server.URL)
+	require.NoError(t, err)
+	idp.IDP.MetadataURL = *serverURL.JoinPath("metadata")
+	idp.IDP.SSOURL = *serverURL.JoinPath("sso")
+
+	return idp
+}
+
+// MetadataURL returns the URL the metadata of the identity provider is served at
+func (idp *IdentityProvider) MetadataURL() string {
+	return idp.IDP.MetadataURL.String()
+}
+
+// Metadata returns the XML metadata of the identity provider
+func (idp *IdentityProvider) Metadata(t testing.TB) string {
+	t.Helper()
+
+	data, err := xml.MarshalIndent(idp.IDP.Metadata(), "", "  ")
+	require.NoError(t, err)
+	return string(data)
+}
+
+// AddServiceProvider registers the metadata of a service provider with the identity provider
+func (idp *IdentityProvider) AddServiceProvider(t testing.TB, metadata []byte) {
+	t.Helper()
+
+	descriptor := &saml.EntityDescriptor{}
+	require.NoError(t, xml.Unmarshal(metadata, descriptor))
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.serviceProviders[descriptor.EntityID] = descriptor
+}
+
+// GetServiceProvider implements saml.ServiceProviderProvider
+func (idp *IdentityProvider) GetServiceProvider(_ *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	descriptor, ok := idp.serviceProviders[serviceProviderID]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return descriptor, nil
+}
+
+// GetSession implements saml.SessionProvider
+func (idp *IdentityProvider) GetSession(_ http.ResponseWriter, _ *http.Request, _ *saml.IdpAuthnRequest) *saml.Session {
+	return idp.Session
+}
+
+// Respond handles the authentication request encoded in redirectURL like a user
+// signing in at the identity provider and returns the form to post to the
+// assertion consumer service of the service provider.
+func (idp *IdentityProvider) Respond(t testing.TB, redirectURL string) (acsURL string, form url.Values) {
+	t.Helper()
+
+	httpReq := httptest.NewRequest(http.MethodGet, redirectURL, nil)
+	req, err := saml.NewIdpAuthnRequest(idp.IDP, httpReq)
+	require.NoError(t, err)
+	require.NoError(t, req.Validate())
+
+	idp.LastRequestSigned = false
+	if httpReq.URL.Query().Has("Signature") {
+		require.NoError(t, verifyRedirectSignature(httpReq.URL.RawQuery, req.ServiceProviderMetadata))
+		idp.LastRequestSigned = true
+	}
+
+	require.NoError(t, saml.DefaultAssertionMaker{}.MakeAssertion(req, idp.Session))
+	postBinding, err := req.PostBinding()
+	require.NoError(t, err)
+
+	form = url.Values{"SAMLResponse": {postBinding.SAMLResponse}}
+	if postBinding.RelayState != "" {
+		form.Set("RelayState", postBinding.RelayState)
+	}
+	return postBinding.URL, form
+}
+
+// verifyRedirectSignature checks the signature of an authentication request
+// sent with the HTTP-Redirect binding against the signing certificate of the service provider
+func verifyRedirectSignature(rawQuery string, metadata *saml.EntityDescriptor) error {
+	signed, encodedSignature, ok := strings.Cut(rawQuery, "&Signature=")
+	if !ok {
+		return errors.New("missing signature")
+	}
+	encodedSignature, err := url.QueryUnescape(encodedSignature)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return err
+	}
+
+	for _, descriptor := range metadata.SPSSODescriptors {
+		for _, keyDescriptor := range descriptor.KeyDescriptors {
+			if keyDescriptor.Use != "signing" || len(keyDescriptor.KeyInfo.X509Data.X509Certificates) == 0 {
+				continue
+			}
+			der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyDescriptor.KeyInfo.X509Data.X509Certificates[0].Data))
+			if err != nil {
+				return err
+			}
+			certificate, err := x509.ParseCertificate(der)
+			if err != nil {
+				return err
+			}
+			publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+			if !ok {
+				return errors.New("unsupported signing key")
+			}
+			digest := sha256.Sum256([]byte(signed))
+			return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature)
+		}
+	}
+	return errors.New("the service provider has no signing certificate")
+}
+
+// GenerateKeyPair returns a PEM encoded self-signed certificate and RSA private key
+func GenerateKeyPair(t testing.TB) (certificatePEM, keyPEM string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "samltest"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certificatePEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return certificatePEM, keyPEM
+}
+
+func parseKeyPair(t testing.TB, certificatePEM, keyPEM string) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+
+	certificateBlock, _ := pem.Decode([]byte(certificatePEM))
+	require.NotNil(t, certificateBlock)
+	certificate, err := x509.ParseCertificate(certificateBlock.Bytes)
+	require.NoError(t, err)
+
+	keyBlock, _ := pem.Decode([]byte(keyPEM))
+	require.NotNil(t, keyBlock)
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	require.NoError(t, err)
+
+	return certificate, key
+}
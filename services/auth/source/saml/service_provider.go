@@ -0,0 +1,186 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+
+	"github.com/crewjam/saml"
+)
+
+const (
+	// rsaSHA256SignatureMethod is the signature algorithm used for authentication requests
+	rsaSHA256SignatureMethod = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+
+	maxMetadataSize = 1 << 20
+)
+
+var (
+	serviceProvidersLock sync.RWMutex
+	serviceProviders     = map[int64]*saml.ServiceProvider{}
+)
+
+// MetadataURL returns the URL of the service provider metadata of the named source.
+// It is also used as the entity ID of the service provider.
+func MetadataURL(name string) string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(name) + "/metadata"
+}
+
+// AssertionConsumerServiceURL returns the URL the identity provider posts its responses to
+func AssertionConsumerServiceURL(name string) string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(name) + "/acs"
+}
+
+// ServiceProvider returns the SAML service provider of the source.
+// It is created on first use and cached until the source is unregistered.
+func (source *Source) ServiceProvider(ctx context.Context) (*saml.ServiceProvider, error) {
+	serviceProvidersLock.RLock()
+	sp, ok := serviceProviders[source.authSource.ID]
+	serviceProvidersLock.RUnlock()
+	if ok {
+		return sp, nil
+	}
+
+	sp, err := source.newServiceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceProvidersLock.Lock()
+	serviceProviders[source.authSource.ID] = sp
+	serviceProvidersLock.Unlock()
+	return sp, nil
+}
+
+func (source *Source) newServiceProvider(ctx context.Context) (*saml.ServiceProvider, error) {
+	idpMetadata, err := source.loadIdentityProviderMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataURL, err := url.Parse(MetadataURL(source.authSource.Name))
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(AssertionConsumerServiceURL(source.authSource.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	nameIDFormat := saml.PersistentNameIDFormat
+	if source.NameIDFormat != "" {
+		nameIDFormat = saml.NameIDFormat(source.NameIDFormat)
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          metadataURL.String(),
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		AuthnNameIDFormat: nameIDFormat,
+		HTTPClient:        newHTTPClient(),
+	}
+
+	if source.ServiceProviderCertificate != "" || source.ServiceProviderPrivateKey != "" {
+		sp.Certificate, sp.Key, err = parseKeyPair(source.ServiceProviderCertificate, source.ServiceProviderPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if source.SignRequests {
+		if sp.Key == nil {
+			return nil, errors.New("signing authentication requests requires a service provider certificate and private key")
+		}
+		sp.SignatureMethod = rsaSHA256SignatureMethod
+	}
+
+	return sp, nil
+}
+
+func (source *Source) loadIdentityProviderMetadata(ctx context.Context) (*saml.EntityDescriptor, error) {
+	data := []byte(source.IdentityProviderMetadata)
+	if len(bytes.TrimSpace(data)) == 0 {
+		if source.IdentityProviderMetadataURL == "" {
+			return nil, errors.New("no identity provider metadata configured")
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.IdentityProviderMetadataURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := newHTTPClient().Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch identity provider metadata: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch identity provider metadata: unexpected status %s", resp.Status)
+		}
+		if data, err = io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize)); err != nil {
+			return nil, err
+		}
+	}
+	return parseIdentityProviderMetadata(data)
+}
+
+// parseIdentityProviderMetadata accepts a single entity descriptor as well as
+// an entities descriptor and returns the first identity provider found.
+func parseIdentityProviderMetadata(data []byte) (*saml.EntityDescriptor, error) {
+	entity := &saml.EntityDescriptor{}
+	if err := xml.Unmarshal(data, entity); err == nil && len(entity.IDPSSODescriptors) > 0 {
+		return entity, nil
+	}
+
+	entities := &saml.EntitiesDescriptor{}
+	if err := xml.Unmarshal(data, entities); err != nil {
+		return nil, fmt.Errorf("invalid identity provider metadata: %w", err)
+	}
+	for i := range entities.EntityDescriptors {
+		if len(entities.EntityDescriptors[i].IDPSSODescriptors) > 0 {
+			return &entities.EntityDescriptors[i], nil
+		}
+	}
+	return nil, errors.New("invalid identity provider metadata: no identity provider found")
+}
+
+func parseKeyPair(certificatePEM, privateKeyPEM string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	keyPair, err := tls.X509KeyPair([]byte(certificatePEM), []byte(privateKeyPEM))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid service provider certificate or private key: %w", err)
+	}
+	key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("invalid service provider private key: only RSA keys are supported")
+	}
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return certificate, key, nil
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy: proxy.Proxy(),
+		},
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/json"
+)
+
+// Source holds configuration for the SAML login source.
+type Source struct {
+	// IdentityProviderMetadata is the XML metadata of the identity provider.
+	// It takes precedence over IdentityProviderMetadataURL.
+	IdentityProviderMetadata    string
+	IdentityProviderMetadataURL string
+
+	// ServiceProviderCertificate and ServiceProviderPrivateKey are PEM encoded.
+	// They are required to sign authentication requests and to decrypt assertions.
+	ServiceProviderCertificate string
+	ServiceProviderPrivateKey  string
+	SignRequests               bool
+	NameIDFormat               string
+
+	UsernameAttribute   string
+	EmailAttribute      string
+	FullNameAttribute   string
+	GroupAttribute      string
+	AdminGroup          string
+	RestrictedGroup     string
+	GroupTeamMap        string
+	GroupTeamMapRemoval bool
+
+	IconURL        string
+	SkipLocalTwoFA bool `json:",omitempty"`
+
+	// reference to the authSource
+	authSource *auth.Source
+}
+
+// FromDB fills up a SAMLConfig from serialized format.
+func (source *Source) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &source)
+}
+
+// ToDB exports a SAMLConfig to a serialized format.
+func (source *Source) ToDB() ([]byte, error) {
+	return json.Marshal(source)
+}
+
+// SetAuthSource sets the related AuthSource
+func (source *Source) SetAuthSource(authSource *auth.Source) {
+	source.authSource = authSource
+}
+
+// IsSkipLocalTwoFA returns if this source should skip local 2fa
+func (source *Source) IsSkipLocalTwoFA() bool {
+	return source.SkipLocalTwoFA
+}
+
+func init() {
+	auth.RegisterTypeConfig(auth.SAML, &Source{})
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	auth_module "forgejo.org/modules/auth"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/optional"
+	source_service "forgejo.org/services/auth/source"
+	user_service "forgejo.org/services/user"
+
+	"github.com/crewjam/saml"
+)
+
+// UserInfo holds the details of a user asserted by the identity provider
+type UserInfo struct {
+	NameID   string
+	Username string
+	Email    string
+	FullName string
+	Groups   container.Set[string]
+}
+
+// AuthenticationRequest creates a signed (if configured) authentication request
+// and returns the URL to redirect the user to together with the request ID.
+// The identity provider passes the relay state back unchanged with its response.
+func (source *Source) AuthenticationRequest(ctx context.Context, relayState string) (redirectURL, requestID string, err error) {
+	sp, err := source.ServiceProvider(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	ssoURL := sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if ssoURL == "" {
+		return "", "", errors.New("the identity provider does not support the HTTP-Redirect binding")
+	}
+	req, err := sp.MakeAuthenticationRequest(ssoURL, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return "", "", err
+	}
+	u, err := req.Redirect(url.QueryEscape(relayState), sp)
+	if err != nil {
+		return "", "", err
+	}
+	return u.String(), req.ID, nil
+}
+
+// ParseResponse validates the response of the identity provider to the
+// authentication request with the given ID and maps the assertion to the user details.
+func (source *Source) ParseResponse(ctx context.Context, req *http.Request, requestID string) (*UserInfo, error) {
+	sp, err := source.ServiceProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	assertion, err := sp.ParseResponse(req, []string{requestID})
+	if err != nil {
+		var invalidErr *saml.InvalidResponseError
+		if errors.As(err, &invalidErr) {
+			return nil, fmt.Errorf("invalid SAML response: %w", invalidErr.PrivateErr)
+		}
+		return nil, err
+	}
+	return source.UserInfo(assertion)
+}
+
+// UserInfo maps the attributes of a validated assertion to the user details
+func (source *Source) UserInfo(assertion *saml.Assertion) (*UserInfo, error) {
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, errors.New("the assertion does not contain a NameID")
+	}
+
+	attributes := map[string][]string{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			values := make([]string, 0, len(attribute.Values))
+			for _, value := range attribute.Values {
+				values = append(values, value.Value)
+			}
+			attributes[attribute.Name] = append(attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				attributes[attribute.FriendlyName] = append(attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+	first := func(name string) string {
+		if name == "" || len(attributes[name]) == 0 {
+			return ""
+		}
+		return strings.TrimSpace(attributes[name][0])
+	}
+
+	info := &UserInfo{
+		NameID:   assertion.Subject.NameID.Value,
+		Email:    first(source.EmailAttribute),
+		FullName: first(source.FullNameAttribute),
+		Groups:   make(container.Set[string]),
+	}
+	if info.Email == "" && strings.Contains(info.NameID, "@") {
+		info.Email = info.NameID
+	}
+	if info.Email == "" {
+		return nil, fmt.Errorf("the assertion for %q does not contain an email address", info.NameID)
+	}
+
+	username := first(source.UsernameAttribute)
+	if username == "" {
+		username, _, _ = strings.Cut(info.Email, "@")
+	}
+	var err error
+	if info.Username, err = user_model.NormalizeUserName(username); err != nil {
+		return nil, err
+	}
+
+	if source.GroupAttribute != "" {
+		info.Groups.AddMultiple(attributes[source.GroupAttribute]...)
+	}
+	return info, nil
+}
+
+// Authenticate returns the local user of the asserted identity and creates it on first sign-in.
+// The admin and restricted flags as well as the team memberships are synchronized from the asserted groups.
+func (source *Source) Authenticate(ctx context.Context, info *UserInfo) (*user_model.User, error) {
+	isAdmin, isRestricted := source.adminAndRestricted(info.Groups)
+
+	user := &user_model.User{
+		LoginType:   auth.SAML,
+		LoginSource: source.authSource.ID,
+		LoginName:   info.NameID,
+	}
+	has, err := user_model.GetUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if has {
+		if user.ProhibitLogin {
+			return nil, user_model.ErrUserProhibitLogin{UID: user.ID, Name: user.Name}
+		}
+		opts := &user_service.UpdateOptions{
+			IsAdmin:      isAdmin,
+			IsRestricted: isRestricted,
+		}
+		if info.FullName != "" && info.FullName != user.FullName {
+			opts.FullName = optional.Some(info.FullName)
+		}
+		if opts.IsAdmin.Has() || opts.IsRestricted.Has() || opts.FullName.Has() {
+			if err := user_service.UpdateUser(ctx, user, opts); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		user = &user_model.User{
+			LowerName:   strings.ToLower(info.Username),
+			Name:        info.Username,
+			FullName:    info.FullName,
+			Email:       info.Email,
+			LoginType:   auth.SAML,
+			LoginSource: source.authSource.ID,
+			LoginName:   info.NameID,
+			IsAdmin:     isAdmin.ValueOrDefault(false),
+		}
+		overwriteDefault := &user_model.CreateUserOverwriteOptions{
+			IsActive:     optional.Some(true),
+			IsRestricted: isRestricted,
+		}
+		if err := user_model.CreateUser(ctx, user, overwriteDefault); err != nil {
+			return nil, err
+		}
+	}
+
+	if source.GroupTeamMap != "" || source.GroupTeamMapRemoval {
+		groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap)
+		if err != nil {
+			return user, err
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, user, info.Groups, groupTeamMapping, source.GroupTeamMapRemoval); err != nil {
+			return user, err
+		}
+	}
+
+	return user, nil
+}
+
+func (source *Source) adminAndRestricted(groups container.Set[string]) (isAdmin, isRestricted optional.Option[bool]) {
+	if source.AdminGroup != "" {
+		isAdmin = optional.Some(groups.Contains(source.AdminGroup))
+	}
+	if source.RestrictedGroup != "" {
+		isRestricted = optional.Some(groups.Contains(source.RestrictedGroup))
+	}
+	return isAdmin, isRestricted
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+// RegisterSource drops the cached service provider so that it is rebuilt
+// from the current configuration on next use
+func (source *Source) RegisterSource() error {
+	return source.UnregisterSource()
+}
+
+// UnregisterSource causes a SAML configuration to be unregistered
+func (source *Source) UnregisterSource() error {
+	serviceProvidersLock.Lock()
+	delete(serviceProviders, source.authSource.ID)
+	serviceProvidersLock.Unlock()
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/services/auth/source/saml/samltest"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSource(t *testing.T, name string, cfg *Source) *Source {
+	t.Helper()
+
+	authSource := &auth_model.Source{
+		Type:     auth_model.SAML,
+		Name:     name,
+		IsActive: true,
+		Cfg:      cfg,
+	}
+	require.NoError(t, auth_model.CreateSource(db.DefaultContext, authSource))
+	t.Cleanup(func() {
+		_ = cfg.UnregisterSource()
+	})
+	return authSource.Cfg.(*Source)
+}
+
+func signIn(t *testing.T, source *Source, idp *samltest.IdentityProvider) (*UserInfo, error) {
+	t.Helper()
+
+	sp, err := source.ServiceProvider(db.DefaultContext)
+	require.NoError(t, err)
+	metadata, err := xml.Marshal(sp.Metadata())
+	require.NoError(t, err)
+	idp.AddServiceProvider(t, metadata)
+
+	redirectURL, requestID, err := source.AuthenticationRequest(db.DefaultContext, "")
+	require.NoError(t, err)
+
+	acsURL, form := idp.Respond(t, redirectURL)
+	assert.Equal(t, AssertionConsumerServiceURL(source.authSource.Name), acsURL)
+
+	req := httptest.NewRequest(http.MethodPost, acsURL, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return source.ParseResponse(db.DefaultContext, req, requestID)
+}
+
+func TestParseIdentityProviderMetadata(t *testing.T) {
+	idp := samltest.NewIdentityProvider(t, &saml.Session{})
+
+	descriptor, err := parseIdentityProviderMetadata([]byte(idp.Metadata(t)))
+	require.NoError(t, err)
+	assert.Equal(t, idp.MetadataURL(), descriptor.EntityID)
+
+	wrapped := `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">` + idp.Metadata(t) + `</EntitiesDescriptor>`
+	descriptor, err = parseIdentityProviderMetadata([]byte(wrapped))
+	require.NoError(t, err)
+	assert.Equal(t, idp.MetadataURL(), descriptor.EntityID)
+
+	_, err = parseIdentityProviderMetadata([]byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="sp"/>`))
+	require.Error(t, err)
+}
+
+func TestSAMLSignIn(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	idp := samltest.NewIdentityProvider(t, &saml.Session{
+		ID:             "session",
+		NameID:         "7f3c2a",
+		NameIDFormat:   string(saml.PersistentNameIDFormat),
+		UserName:       "jane.doe",
+		UserEmail:      "jane@example.com",
+		UserCommonName: "Jane Doe",
+		Groups:         []string{"developers", "forgejo-admins"},
+	})
+	certificate, key := samltest.GenerateKeyPair(t)
+
+	source := createTestSource(t, "corp-idp", &Source{
+		IdentityProviderMetadataURL: idp.MetadataURL(),
+		ServiceProviderCertificate:  certificate,
+		ServiceProviderPrivateKey:   key,
+		SignRequests:                true,
+		UsernameAttribute:           "uid",
+		EmailAttribute:              "eduPersonPrincipalName",
+		FullNameAttribute:           "cn",
+		GroupAttribute:              "eduPersonAffiliation",
+		AdminGroup:                  "forgejo-admins",
+		GroupTeamMap:                `{"developers": {"org3": ["team1"]}}`,
+	})
+
+	info, err := signIn(t, source, idp)
+	require.NoError(t, err)
+	assert.True(t, idp.LastRequestSigned)
+	assert.Equal(t, "7f3c2a", info.NameID)
+	assert.Equal(t, "jane.doe", info.Username)
+	assert.Equal(t, "jane@example.com", info.Email)
+	assert.Equal(t, "Jane Doe", info.FullName)
+	assert.True(t, info.Groups.Contains("developers"))
+
+	u, err := source.Authenticate(db.DefaultContext, info)
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe", u.Name)
+	assert.Equal(t, auth_model.SAML, u.LoginType)
+	assert.Equal(t, "7f3c2a", u.LoginName)
+	assert.True(t, u.IsAdmin)
+	assert.True(t, u.IsActive)
+
+	team, err := organization.GetTeam(db.DefaultContext, 3, "team1")
+	require.NoError(t, err)
+	isMember, err := organization.IsTeamMember(db.DefaultContext, 3, team.ID, u.ID)
+	require.NoError(t, err)
+	assert.True(t, isMember)
+
+	t.Run("Returning user", func(t *testing.T) {
+		idp.Session.Groups = []string{"developers"}
+		idp.Session.UserCommonName = "Jane Roe"
+
+		info, err := signIn(t, source, idp)
+		require.NoError(t, err)
+		again, err := source.Authenticate(db.DefaultContext, info)
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, again.ID)
+		assert.False(t, again.IsAdmin)
+		assert.Equal(t, "Jane Roe", again.FullName)
+	})
+
+	t.Run("Prohibited user", func(t *testing.T) {
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: u.ID})
+		u.ProhibitLogin = true
+		require.NoError(t, user_model.UpdateUserCols(db.DefaultContext, u, "prohibit_login"))
+
+		info, err := signIn(t, source, idp)
+		require.NoError(t, err)
+		_, err = source.Authenticate(db.DefaultContext, info)
+		assert.True(t, user_model.IsErrUserProhibitLogin(err))
+	})
+}
+
+func TestSAMLResponseValidation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	idp := samltest.NewIdentityProvider(t, &saml.Session{
+		ID:        "session",
+		NameID:    "jdoe@example.com",
+		UserEmail: "jdoe@example.com",
+	})
+	source := createTestSource(t, "unsigned-idp", &Source{
+		IdentityProviderMetadata: idp.Metadata(t),
+	})
+
+	t.Run("Email from NameID", func(t *testing.T) {
+		info, err := signIn(t, source, idp)
+		require.NoError(t, err)
+		assert.False(t, idp.LastRequestSigned)
+		assert.Equal(t, "jdoe", info.Username)
+		assert.Equal(t, "jdoe@example.com", info.Email)
+	})
+
+	t.Run("Relay state", func(t *testing.T) {
+		redirectURL, _, err := source.AuthenticationRequest(db.DefaultContext, "/org3/repo3?tab=issues")
+		require.NoError(t, err)
+		_, form := idp.Respond(t, redirectURL)
+		assert.Equal(t, "/org3/repo3?tab=issues", form.Get("RelayState"))
+	})
+
+	t.Run("Unknown request", func(t *testing.T) {
+		redirectURL, _, err := source.AuthenticationRequest(db.DefaultContext, "")
+		require.NoError(t, err)
+		acsURL, form := idp.Respond(t, redirectURL)
+
+		req := httptest.NewRequest(http.MethodPost, acsURL, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		_, err = source.ParseResponse(db.DefaultContext, req, "id-unknown")
+		require.Error(t, err)
+	})
+
+	t.Run("Untrusted identity provider", func(t *testing.T) {
+		other := samltest.NewIdentityProvider(t, idp.Session)
+		other.IDP.MetadataURL = idp.IDP.MetadataURL
+		other.IDP.SSOURL = idp.IDP.SSOURL
+
+		sp, err := source.ServiceProvider(db.DefaultContext)
+		require.NoError(t, err)
+		metadata, err := xml.Marshal(sp.Metadata())
+		require.NoError(t, err)
+		other.AddServiceProvider(t, metadata)
+
+		redirectURL, requestID, err := source.AuthenticationRequest(db.DefaultContext, "")
+		require.NoError(t, err)
+		acsURL, form := other.Respond(t, redirectURL)
+
+		req := httptest.NewRequest(http.MethodPost, acsURL, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		_, err = source.ParseResponse(db.DefaultContext, req, requestID)
+		require.Error(t, err)
+	})
+
+	t.Run("Missing email", func(t *testing.T) {
+		idp.Session.NameID = "opaque"
+		idp.Session.UserEmail = ""
+		_, err := signIn(t, source, idp)
+		require.ErrorContains(t, err, "does not contain an email address")
+	})
+}
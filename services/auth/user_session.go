@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/modules/timeutil"
+)
+
+// lastSeenUpdateInterval is the number of seconds after which a request updates the last seen time of a session,
+// so not every request writes to the database
+const lastSeenUpdateInterval = 60
+
+// UserSessionToken returns the token of the session metadata of a signed-in session, or an empty string
+func UserSessionToken(sess SessionStore) string {
+	if sess == nil {
+		return ""
+	}
+	token, _ := sess.Get("user_session").(string)
+	return token
+}
+
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// trackUserSession records the metadata of the session of a signed-in user. New sessions are tracked when they are
+// used first, which includes the sessions signed in before sessions were tracked.
+// It returns false if the session has been revoked and must be signed out.
+func trackUserSession(ctx context.Context, req *http.Request, sess SessionStore, userID int64) (bool, error) {
+	token := UserSessionToken(sess)
+	s, err := auth_model.GetUserSessionByToken(ctx, token)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case s != nil && s.UserID == userID:
+		if s.LastSeenUnix.Add(lastSeenUpdateInterval) <= timeutil.TimeStampNow() {
+			return true, s.UpdateLastSeen(ctx, remoteIP(req), req.UserAgent())
+		}
+		return true, nil
+	case s == nil && token != "":
+		// the metadata of a tracked session has been deleted
+		return false, nil
+	}
+
+	// an untracked session, or another user signed in with the session
+	authMethod, _ := sess.Get("auth_method").(string)
+	if authMethod == "" {
+		authMethod = auth_model.UserSessionAuthPassword
+	}
+	s, err = auth_model.CreateUserSession(ctx, userID, authMethod, remoteIP(req), req.UserAgent())
+	if err != nil {
+		return false, err
+	}
+	return true, sess.Set("user_session", s.Token)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package context
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	org_model "forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/cache"
+	"forgejo.org/modules/web/middleware"
+)
+
+// IsRemoteHostAllowed returns whether a client may access the resources of an owner. Organizations can restrict
+// the access to a list of IP networks, remoteAddr is the address of the request after the reverse proxy headers
+// and the PROXY protocol have been handled.
+// Site administrators and the Actions user are exempt, their access does not depend on the organization.
+func IsRemoteHostAllowed(ctx context.Context, ownerID int64, doer *user_model.User, remoteAddr string) (bool, error) {
+	if doer != nil && (doer.IsAdmin || doer.IsActions()) {
+		return true, nil
+	}
+
+	allowlist, err := org_model.GetIPAllowlist(ctx, ownerID)
+	if err != nil {
+		return false, err
+	} else if allowlist == nil {
+		return true, nil
+	}
+	return allowlist.AllowsRemoteHost(remoteAddr), nil
+}
+
+// BlockedRepoIDs returns the IDs of the repositories of the organizations whose IP allowlist does not allow the client.
+// Listings which span several owners exclude them, the result is cached for the request.
+func BlockedRepoIDs(ctx context.Context, doer *user_model.User, remoteAddr string) ([]int64, error) {
+	if doer != nil && (doer.IsAdmin || doer.IsActions()) {
+		return nil, nil
+	}
+
+	return cache.GetWithContextCache(ctx, "ip_allowlist_blocked_repos", remoteAddr, func() ([]int64, error) {
+		allowlists, err := org_model.GetIPAllowlists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var ownerIDs []int64
+		for _, allowlist := range allowlists {
+			if !allowlist.AllowsRemoteHost(remoteAddr) {
+				ownerIDs = append(ownerIDs, allowlist.OrgID)
+			}
+		}
+		if len(ownerIDs) == 0 {
+			return nil, nil
+		}
+		return repo_model.FindRepoIDsByOwnerIDs(ctx, ownerIDs)
+	})
+}
+
+// CheckIPAllowlist responds with an error and returns false if the client may not access the resources of the owner
+func (ctx *Context) CheckIPAllowlist(ownerID int64) bool {
+	allowed, err := IsRemoteHostAllowed(ctx, ownerID, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.ServerError("IsRemoteHostAllowed", err)
+		return false
+	}
+	if !allowed {
+		ctx.Error(http.StatusForbidden, ctx.Locale.TrString("org.ip_allowlist.blocked", middleware.GetRemoteHost(ctx)))
+		return false
+	}
+	return true
+}
+
+// CheckIPAllowlist responds with an error and returns false if the client may not access the resources of the owner
+func (ctx *APIContext) CheckIPAllowlist(ownerID int64) bool {
+	allowed, err := IsRemoteHostAllowed(ctx, ownerID, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsRemoteHostAllowed", err)
+		return false
+	}
+	if !allowed {
+		ctx.Error(http.StatusForbidden, "", fmt.Sprintf("access from %s is not allowed by the IP allowlist of the organization", middleware.GetRemoteHost(ctx)))
+		return false
+	}
+	return true
+}
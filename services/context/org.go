@@ -106,6 +106,9 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 	}
 
 	org := ctx.Org.Organization
+	if !ctx.CheckIPAllowlist(org.ID) {
+		return
+	}
 
 	// Handle Visibility
 	if org.Visibility != structs.VisibleTypePublic && !ctx.IsSigned {
@@ -158,6 +161,9 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 		// Fake data.
 		ctx.Data["SignedUser"] = &user_model.User{}
 	}
+	if ctx.Org.IsMember && !ctx.CheckTwoFactorRequirement(org) {
+		return
+	}
 	if (requireMember && !ctx.Org.IsMember) ||
 		(requireOwner && !ctx.Org.IsOwner) {
 		ctx.NotFound("OrgAssignment", err)
@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package context
+
+import (
+	"context"
+	"net/http"
+
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+)
+
+// isMemberBlockedByTwoFactorRequirement returns whether the doer is a member of the organization
+// who has not enabled the two-factor authentication the organization requires
+func isMemberBlockedByTwoFactorRequirement(ctx context.Context, orgID int64, doer *user_model.User) (bool, error) {
+	blocked, err := org_model.IsBlockedByTwoFactorRequirement(ctx, orgID, doer)
+	if err != nil || !blocked {
+		return false, err
+	}
+	return org_model.IsOrganizationMember(ctx, orgID, doer.ID)
+}
+
+// CheckTwoFactorRequirement responds with an error and returns false if the doer is a member of the organization
+// who has not enabled the two-factor authentication it requires
+func (ctx *Context) CheckTwoFactorRequirement(org *org_model.Organization) bool {
+	blocked, err := isMemberBlockedByTwoFactorRequirement(ctx, org.ID, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("IsBlockedByTwoFactorRequirement", err)
+		return false
+	}
+	if blocked {
+		ctx.Error(http.StatusForbidden, ctx.Locale.TrString("org.two_factor.blocked", org.DisplayName()))
+		return false
+	}
+	return true
+}
+
+// CheckTwoFactorRequirement responds with an error and returns false if the doer is a member of the organization
+// who has not enabled the two-factor authentication it requires
+func (ctx *APIContext) CheckTwoFactorRequirement(orgID int64) bool {
+	blocked, err := isMemberBlockedByTwoFactorRequirement(ctx, orgID, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsBlockedByTwoFactorRequirement", err)
+		return false
+	}
+	if blocked {
+		ctx.Error(http.StatusForbidden, "", "the organization requires two-factor authentication, enable it to access its resources")
+		return false
+	}
+	return true
+}
@@ -7,14 +7,17 @@ import (
 	"fmt"
 	"net/http"
 
+	actions_model "forgejo.org/models/actions"
 	"forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	"forgejo.org/models/perm"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
 	"forgejo.org/modules/templates"
+	packages_service "forgejo.org/services/packages"
 )
 
 // Package contains owner, access mode and optional the package descriptor
@@ -87,6 +90,26 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, string, any))
 			errCb(http.StatusInternalServerError, "GetPackageDescriptor", err)
 			return pkg
 		}
+
+		// Access granted to the package itself can exceed the access to the owner
+		if pkg.AccessMode >= perm.AccessModeRead && pkg.AccessMode < perm.AccessModeWrite && ctx.Doer != nil {
+			taskID, _ := ctx.Data["ActionsTaskID"].(int64)
+			grants, err := packages_service.GetGrants(ctx, pkg.Owner, ctx.Doer, taskID)
+			if err != nil {
+				errCb(http.StatusInternalServerError, "GetGrants", err)
+				return pkg
+			}
+			pkg.AccessMode = max(pkg.AccessMode, grants.AccessMode(pkg.Descriptor.Package.Type, pkg.Descriptor.Package.Name))
+		}
+	}
+
+	// Fine-grained access tokens limit the access to the packages of their owners, public packages stay readable
+	if t := ctx.AccessToken(); t != nil {
+		limit := t.PackageAccessMode(pkg.Owner.ID)
+		if limit == perm.AccessModeNone && pkg.Owner.Visibility.IsPublic() {
+			limit = perm.AccessModeRead
+		}
+		pkg.AccessMode = min(pkg.AccessMode, limit)
 	}
 
 	return pkg
@@ -101,11 +124,40 @@ func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.A
 		return perm.AccessModeNone, nil
 	}
 
-	// TODO: ActionUser permission check
+	// clients outside of the IP allowlist of an organization cannot access its packages at all
+	if allowed, err := IsRemoteHostAllowed(ctx, pkg.Owner.ID, doer, ctx.RemoteAddr()); err != nil || !allowed {
+		return perm.AccessModeNone, err
+	}
+
 	accessMode := perm.AccessModeNone
+	if taskID, ok := ctx.Data["ActionsTaskID"].(int64); ok && doer.IsActions() {
+		// The Actions user can read the packages of the owner of the repository the task runs in.
+		// Write access has to be granted per package.
+		task, err := actions_model.GetTaskByID(ctx, taskID)
+		if err != nil {
+			return accessMode, err
+		}
+		repo, err := repo_model.GetRepositoryByID(ctx, task.RepoID)
+		if err != nil {
+			return accessMode, err
+		}
+		if repo.OwnerID == pkg.Owner.ID {
+			return perm.AccessModeRead, nil
+		}
+	}
+
 	if pkg.Owner.IsOrganization() {
 		org := organization.OrgFromUser(pkg.Owner)
 
+		// members without the two-factor authentication the organization requires keep only the access of anonymous users
+		blocked, err := organization.IsBlockedByTwoFactorRequirement(ctx, org.ID, doer)
+		if err != nil {
+			return accessMode, err
+		}
+		if blocked {
+			doer = nil
+		}
+
 		if doer != nil && !doer.IsGhost() {
 			// 1. If user is logged in, check all team packages permissions
 			var err error
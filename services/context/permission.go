@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/perm"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	"forgejo.org/modules/log"
@@ -159,4 +160,22 @@ func CheckRepoScopedToken(ctx *Context, repo *repo_model.Repository, level auth_
 			return
 		}
 	}
+
+	// fine-grained tokens are restricted to their repositories and contents permission
+	if t := ctx.AccessToken(); t != nil && t.IsFineGrained() {
+		mode := perm.AccessModeRead
+		if level == auth_model.Write {
+			mode = perm.AccessModeWrite
+		}
+		if !t.AllowsRepository(repo.ID, repo.OwnerID) || t.Permissions.Contents < mode {
+			ctx.Error(http.StatusForbidden)
+			return
+		}
+	}
+}
+
+// AccessToken returns the personal access token the request is authenticated with
+func (b *Base) AccessToken() *auth_model.AccessToken {
+	t, _ := b.Data["ApiToken"].(*auth_model.AccessToken)
+	return t
 }
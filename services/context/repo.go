@@ -374,12 +374,16 @@ func repoAssignment(ctx *Context, repo *repo_model.Repository) {
 		ctx.ServerError("LoadOwner", err)
 		return
 	}
+	if !ctx.CheckIPAllowlist(repo.OwnerID) {
+		return
+	}
 
 	ctx.Repo.Permission, err = access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
 	if err != nil {
 		ctx.ServerError("GetUserRepoPermission", err)
 		return
 	}
+	ctx.Repo.Permission.RestrictToAccessToken(ctx.AccessToken(), repo)
 
 	// Check access.
 	if !ctx.Repo.HasAccess() {
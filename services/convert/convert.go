@@ -262,6 +262,18 @@ func ToPublicKey(apiLink string, key *asymkey_model.PublicKey) *api.PublicKey {
 	}
 }
 
+// ToSSHCertificate converts an issued SSH certificate to api.SSHCertificate
+func ToSSHCertificate(c *asymkey_model.SSHCertificate) *api.SSHCertificate {
+	return &api.SSHCertificate{
+		ID:          c.ID,
+		KeyID:       c.KeyID(),
+		Principal:   c.Principal,
+		Fingerprint: c.Fingerprint,
+		ValidAfter:  c.ValidAfterUnix.AsTime(),
+		ValidBefore: c.ValidBeforeUnix.AsTime(),
+	}
+}
+
 // ToGPGKey converts models.GPGKey to api.GPGKey
 func ToGPGKey(key *asymkey_model.GPGKey) *api.GPGKey {
 	subkeys := make([]*api.GPGKey, len(key.SubsKey))
@@ -469,11 +481,63 @@ func ToOAuth2Application(app *auth.OAuth2Application) *api.OAuth2Application {
 		ClientID:           app.ClientID,
 		ClientSecret:       app.ClientSecret,
 		ConfidentialClient: app.ConfidentialClient,
+		EnableDeviceFlow:   app.EnableDeviceFlow,
 		RedirectURIs:       app.RedirectURIs,
 		Created:            app.CreatedUnix.AsTime(),
 	}
 }
 
+// ToAccessToken converts an access token to its api format without the token itself
+func ToAccessToken(ctx context.Context, t *auth.AccessToken) (*api.AccessToken, error) {
+	apiToken := &api.AccessToken{
+		ID:             t.ID,
+		Name:           t.Name,
+		TokenLastEight: t.TokenLastEight,
+		Scopes:         t.Scope.StringSlice(),
+	}
+	if t.ExpiresUnix != 0 {
+		apiToken.Expires = t.ExpiresUnix.AsTimePtr()
+	}
+	if t.AllowedIPs != "" {
+		apiToken.AllowedIPs = strings.Split(t.AllowedIPs, ", ")
+	}
+	if !t.IsFineGrained() {
+		return apiToken, nil
+	}
+
+	apiToken.Permissions = &api.AccessTokenPermissions{
+		Contents: t.Permissions.Contents.String(),
+		Issues:   t.Permissions.Issues.String(),
+		Packages: t.Permissions.Packages.String(),
+		Actions:  t.Permissions.Actions.String(),
+	}
+	if t.ResourceOwnerID != 0 {
+		org, err := user_model.GetUserByID(ctx, t.ResourceOwnerID)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		if org != nil {
+			apiToken.Organization = org.Name
+		}
+		return apiToken, nil
+	}
+
+	if err := t.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, t.RepoIDs)
+	if err != nil {
+		return nil, err
+	}
+	apiToken.Repositories = make([]string, 0, len(repos))
+	for _, repoID := range t.RepoIDs {
+		if repo, ok := repos[repoID]; ok {
+			apiToken.Repositories = append(apiToken.Repositories, repo.FullName())
+		}
+	}
+	return apiToken, nil
+}
+
 // ToLFSLock convert a LFSLock to api.LFSLock
 func ToLFSLock(ctx context.Context, l *git_model.LFSLock) *api.LFSLock {
 	u, err := user_model.GetUserByID(ctx, l.OwnerID)
@@ -0,0 +1,38 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPISavedSearch converts an issues_model.SavedSearch to api.SavedSearch.
+// The owner and the team of the search must be loaded.
+func ToAPISavedSearch(ctx context.Context, doer *user_model.User, s *issues_model.SavedSearch) *api.SavedSearch {
+	labels, _ := s.LabelIDs()
+	if labels == nil {
+		labels = []int64{}
+	}
+	return &api.SavedSearch{
+		ID:      s.ID,
+		Name:    s.Name,
+		Owner:   ToUser(ctx, s.Owner, doer),
+		TeamID:  s.TeamID,
+		IsPull:  s.IsPull,
+		Pinned:  s.IsPinned,
+		Query:   s.Keyword,
+		Type:    s.ViewType,
+		State:   api.StateType(s.State),
+		Labels:  labels,
+		Sort:    s.SortType,
+		FeedURL: s.FeedURL(),
+		HTMLURL: s.HTMLURL(),
+		Created: s.CreatedUnix.AsTime(),
+		Updated: s.UpdatedUnix.AsTime(),
+	}
+}
@@ -10,6 +10,7 @@ import (
 	access_model "forgejo.org/models/perm/access"
 	user_model "forgejo.org/models/user"
 	api "forgejo.org/modules/structs"
+	packages_service "forgejo.org/services/packages"
 )
 
 // ToPackage convert a packages.PackageDescriptor to api.Package
@@ -51,3 +52,27 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageAttestation converts a packages_service.AttestationDescriptor to api.PackageAttestation
+func ToPackageAttestation(ctx context.Context, pad *packages_service.AttestationDescriptor, doer *user_model.User) *api.PackageAttestation {
+	pa := pad.Attestation
+	apiAttestation := &api.PackageAttestation{
+		ID:        pa.ID,
+		Type:      string(pa.Type),
+		File:      pad.FileName,
+		Verified:  pa.Verified,
+		KeyID:     pa.KeyID,
+		BuilderID: pa.BuilderID,
+		Content:   pa.Content,
+		Creator:   ToUser(ctx, pad.Creator, doer),
+		CreatedAt: pa.CreatedUnix.AsTime(),
+	}
+	if pad.Signer != nil {
+		apiAttestation.Signer = ToUser(ctx, pad.Signer, doer)
+	}
+	if pad.Run != nil {
+		apiAttestation.RunNumber = pad.Run.Index
+		apiAttestation.RunURL = pad.Run.HTMLURL()
+	}
+	return apiAttestation
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	project_model "forgejo.org/models/project"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAPIProjectView converts a project_model.View to api.ProjectView
+func ToAPIProjectView(v *project_model.View) *api.ProjectView {
+	fields := v.Fields
+	if fields == nil {
+		fields = []int64{}
+	}
+	return &api.ProjectView{
+		ID:        v.ID,
+		ProjectID: v.ProjectID,
+		Title:     v.Title,
+		Type:      v.Type.Name(),
+		Filter:    v.Filter,
+		GroupBy:   string(v.GroupBy),
+		SortBy:    v.SortBy,
+		SortDesc:  v.SortDesc,
+		Fields:    fields,
+		Created:   v.CreatedUnix.AsTime(),
+		Updated:   v.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToAPIProjectViewList converts a list of project_model.View to a list of api.ProjectView
+func ToAPIProjectViewList(views []*project_model.View) []*api.ProjectView {
+	result := make([]*api.ProjectView, len(views))
+	for i := range views {
+		result[i] = ToAPIProjectView(views[i])
+	}
+	return result
+}
+
+// ToAPIProjectField converts a project_model.Field to api.ProjectField
+func ToAPIProjectField(f *project_model.Field) *api.ProjectField {
+	return &api.ProjectField{
+		ID:        f.ID,
+		ProjectID: f.ProjectID,
+		Name:      f.Name,
+		Type:      f.Type.Name(),
+		Created:   f.CreatedUnix.AsTime(),
+	}
+}
+
+// ToAPIProjectFieldList converts a list of project_model.Field to a list of api.ProjectField
+func ToAPIProjectFieldList(fields []*project_model.Field) []*api.ProjectField {
+	result := make([]*api.ProjectField, len(fields))
+	for i := range fields {
+		result[i] = ToAPIProjectField(fields[i])
+	}
+	return result
+}
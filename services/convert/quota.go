@@ -50,6 +50,7 @@ func toQuotaInfoUsed(used *quota_model.Used) api.QuotaUsed {
 				Packages: api.QuotaUsedSizeAssetsPackages{
 					All: used.Size.Assets.Packages.All,
 				},
+				TerraformStates: used.Size.Assets.TerraformStates,
 			},
 		},
 	}
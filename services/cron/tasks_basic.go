@@ -8,14 +8,20 @@ import (
 	"time"
 
 	"forgejo.org/models"
+	auth_model "forgejo.org/models/auth"
 	git_model "forgejo.org/models/git"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/models/webhook"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	advisory_service "forgejo.org/services/advisory"
 	"forgejo.org/services/auth"
+	issue_service "forgejo.org/services/issue"
+	"forgejo.org/services/mailer"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
+	org_service "forgejo.org/services/org"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
@@ -156,6 +162,69 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerEscalateIssueSLAs() {
+	RegisterTaskFatal("escalate_issue_slas", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.EscalateSLAs(ctx)
+	})
+}
+
+func registerUpdateAdvisories() {
+	RegisterTaskFatal("update_advisories", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return advisory_service.UpdateAdvisories(ctx)
+	})
+}
+
+func registerRemindExpiringAccessTokens() {
+	RegisterTaskFatal("remind_expiring_access_tokens", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return mailer.RemindExpiringAccessTokens(ctx)
+	})
+}
+
+func registerCleanupOAuth2DeviceCodes() {
+	RegisterTaskFatal("cleanup_oauth2_device_codes", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		_, err := auth_model.DeleteExpiredOAuth2DeviceCodes(ctx, timeutil.TimeStampNow())
+		return err
+	})
+}
+
+func registerCleanupUserSessions() {
+	RegisterTaskFatal("cleanup_user_sessions", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 24h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		// the sessions themselves have expired already
+		_, err := auth_model.DeleteInactiveUserSessions(ctx, timeutil.TimeStampNow().Add(-setting.SessionConfig.Maxlifetime))
+		return err
+	})
+}
+
+func registerRemindOrgTwoFactor() {
+	RegisterTaskFatal("remind_org_two_factor", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return org_service.RemindTwoFactorRequirements(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -169,7 +238,17 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerEscalateIssueSLAs()
+	registerRemindOrgTwoFactor()
+	registerRemindExpiringAccessTokens()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
+	if setting.Advisories.Enabled {
+		registerUpdateAdvisories()
+	}
+	if setting.OAuth2.Enabled {
+		registerCleanupOAuth2DeviceCodes()
+	}
+	registerCleanupUserSessions()
 }
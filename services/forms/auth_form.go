@@ -15,7 +15,7 @@ import (
 // AuthenticationForm form for authentication
 type AuthenticationForm struct {
 	ID                            int64
-	Type                          int    `binding:"Range(2,7)"`
+	Type                          int    `binding:"Range(2,9)"`
 	Name                          string `binding:"Required;MaxSize(30)"`
 	Host                          string
 	Port                          int
@@ -79,6 +79,21 @@ type AuthenticationForm struct {
 	Oauth2QuotaGroupMap           string `binding:"ValidQuotaGroupMap"`
 	Oauth2QuotaGroupMapRemoval    bool
 	Oauth2AttributeSSHPublicKey   string
+	SamlIdpMetadata               string
+	SamlIdpMetadataURL            string
+	SamlSpCertificate             string
+	SamlSpPrivateKey              string
+	SamlSignRequests              bool
+	SamlNameIDFormat              string
+	SamlUsernameAttribute         string
+	SamlEmailAttribute            string
+	SamlFullNameAttribute         string
+	SamlGroupAttribute            string
+	SamlAdminGroup                string
+	SamlRestrictedGroup           string
+	SamlGroupTeamMap              string `binding:"ValidGroupTeamMap"`
+	SamlGroupTeamMapRemoval       bool
+	SamlIconURL                   string
 	SkipLocalTwoFA                bool
 	GroupTeamMap                  string `binding:"ValidGroupTeamMap"`
 	GroupTeamMapRemoval           bool
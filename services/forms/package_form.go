@@ -13,18 +13,48 @@ import (
 )
 
 type PackageCleanupRuleForm struct {
-	ID            int64
-	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,alt,rubygems,swift,vagrant)"`
-	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
-	KeepPattern   string `binding:"RegexPattern"`
-	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
-	RemovePattern string `binding:"RegexPattern"`
-	MatchFullName bool
-	Action        string `binding:"Required;In(save,remove)"`
+	ID               int64
+	Enabled          bool
+	Type             string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,npm,nuget,pub,pypi,rpm,alt,rubygems,swift,terraform,vagrant)"`
+	KeepCount        int    `binding:"In(0,1,5,10,25,50,100)"`
+	KeepPattern      string `binding:"RegexPattern"`
+	RemoveDays       int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemoveUnusedDays int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemovePattern    string `binding:"RegexPattern"`
+	MatchFullName    bool
+	UpstreamOnly     bool
+	Action           string `binding:"Required;In(save,remove)"`
 }
 
 func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// PackageRemoteForm form for adding an upstream remote of packages
+type PackageRemoteForm struct {
+	Type               string `binding:"Required;In(go,maven,npm,pypi)"`
+	URL                string `binding:"Required;ValidUrl;MaxSize(2048)"`
+	Username           string `binding:"MaxSize(255)"`
+	Password           string
+	MetadataTTLMinutes int64 `binding:"Range(1,10080)"`
+}
+
+func (f *PackageRemoteForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// PackageAccessForm form for granting a team or the Actions tokens of a repository access to a package
+type PackageAccessForm struct {
+	Type       string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,npm,nuget,pub,pypi,rpm,alt,rubygems,swift,terraform,vagrant)"`
+	Name       string `binding:"Required;MaxSize(255)"`
+	Team       string `binding:"MaxSize(255)"`
+	Repository string `binding:"MaxSize(100)"`
+	AccessMode string `binding:"Required;In(read,write)"`
+}
+
+func (f *PackageAccessForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -201,6 +201,7 @@ type RepoUnitSettingForm struct {
 	EnableTimetracker                     bool
 	AllowOnlyContributorsToTrackTime      bool
 	EnableIssueDependencies               bool
+	CloseParentWithSubIssues              bool
 }
 
 // Validate validates the fields
@@ -400,6 +401,63 @@ type CreateProjectForm struct {
 	CardType     project_model.CardType
 }
 
+// ProjectViewForm is a form for creating or editing a saved view of a project
+type ProjectViewForm struct {
+	Title    string `binding:"Required;MaxSize(100)"`
+	Type     project_model.ViewType
+	Filter   string
+	GroupBy  project_model.ViewGroupBy
+	SortBy   string
+	SortDesc bool
+	Fields   []int64
+}
+
+// ProjectFieldForm is a form for creating a custom field of a project
+type ProjectFieldForm struct {
+	Name string `binding:"Required;MaxSize(100)"`
+	Type project_model.FieldType
+}
+
+// ProjectAutomationRuleForm is a form for creating an automation rule of a project
+type ProjectAutomationRuleForm struct {
+	Event    project_model.AutomationEvent
+	ColumnID int64
+	RepoName string `binding:"MaxSize(100)"`
+	LabelID  int64
+}
+
+// SavedSearchForm is a form for saving a search of the issues or pull requests dashboard
+type SavedSearchForm struct {
+	Name    string `binding:"MaxSize(255)"`
+	IsPull  bool
+	OrgID   int64
+	TeamID  int64
+	Pinned  bool
+	Keyword string `form:"q"`
+	Type    string
+	State   string
+	Labels  string
+	Sort    string
+}
+
+// SLAPolicyForm is a form for creating an SLA policy of a repository
+type SLAPolicyForm struct {
+	Name                  string `binding:"Required;MaxSize(255)"`
+	IssueType             issues_model.SLAIssueType
+	LabelID               int64
+	ResponseMinutes       int64
+	ResolveMinutes        int64
+	BusinessHours         bool
+	Workdays              []int
+	DayStart              string `binding:"MaxSize(5)"`
+	DayEnd                string `binding:"MaxSize(5)"`
+	Timezone              string `binding:"MaxSize(64)"`
+	EscalateBeforeMinutes int64
+	EscalationComment     bool
+	EscalationMentions    string `binding:"MaxSize(255)"`
+	EscalationLabelID     int64
+}
+
 // EditProjectColumnForm is a form for editing a project column
 type EditProjectColumnForm struct {
 	Title   string `binding:"Required;MaxSize(100)"`
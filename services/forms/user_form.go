@@ -8,8 +8,10 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"unicode"
 
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/perm"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
 	"forgejo.org/modules/validation"
@@ -187,6 +189,9 @@ type AccessTokenForm struct {
 
 	// PKCE support
 	CodeVerifier string `json:"code_verifier"`
+
+	// device authorization grant support
+	DeviceCode string `json:"device_code"`
 }
 
 // Validate validates the fields
@@ -195,6 +200,31 @@ func (f *AccessTokenForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// DeviceAuthorizationForm for starting the device authorization grant
+type DeviceAuthorizationForm struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+}
+
+// Validate validates the fields
+func (f *DeviceAuthorizationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// DeviceVerificationForm form for approving or denying a device
+type DeviceVerificationForm struct {
+	UserCode string `binding:"Required"`
+	Granted  bool
+}
+
+// Validate validates the fields
+func (f *DeviceVerificationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // IntrospectTokenForm for introspecting tokens
 type IntrospectTokenForm struct {
 	Token string `json:"token"`
@@ -370,8 +400,16 @@ func (f *EditVariableForm) Validate(req *http.Request, errs binding.Errors) bind
 
 // NewAccessTokenForm form for creating access token
 type NewAccessTokenForm struct {
-	Name  string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
-	Scope []string
+	Name               string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
+	Scope              []string
+	ExpiresAt          string `form:"expires_at"`
+	Organization       string `binding:"MaxSize(255)"`
+	Repositories       string
+	PermissionContents string `form:"permission_contents"`
+	PermissionIssues   string `form:"permission_issues"`
+	PermissionPackages string `form:"permission_packages"`
+	PermissionActions  string `form:"permission_actions"`
+	AllowedIPs         string `form:"allowed_ips"`
 }
 
 // Validate validates the fields
@@ -386,11 +424,34 @@ func (f *NewAccessTokenForm) GetScope() (auth_model.AccessTokenScope, error) {
 	return s, err
 }
 
+// IsFineGrained returns true if the token is restricted to an organization or repositories
+func (f *NewAccessTokenForm) IsFineGrained() bool {
+	return strings.TrimSpace(f.Organization) != "" || strings.TrimSpace(f.Repositories) != ""
+}
+
+// GetPermissions returns the permissions of a fine-grained token
+func (f *NewAccessTokenForm) GetPermissions() *auth_model.AccessTokenPermissions {
+	return &auth_model.AccessTokenPermissions{
+		Contents: perm.ParseAccessMode(f.PermissionContents),
+		Issues:   perm.ParseAccessMode(f.PermissionIssues),
+		Packages: perm.ParseAccessMode(f.PermissionPackages),
+		Actions:  perm.ParseAccessMode(f.PermissionActions),
+	}
+}
+
+// GetRepositories returns the repositories a fine-grained token is restricted to
+func (f *NewAccessTokenForm) GetRepositories() []string {
+	return strings.FieldsFunc(f.Repositories, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 // EditOAuth2ApplicationForm form for editing oauth2 applications
 type EditOAuth2ApplicationForm struct {
 	Name               string `binding:"Required;MaxSize(255)" form:"application_name"`
 	RedirectURIs       string `binding:"Required;ValidUrlList" form:"redirect_uris"`
 	ConfidentialClient bool   `form:"confidential_client"`
+	EnableDeviceFlow   bool   `form:"enable_device_flow"`
 }
 
 // Validate validates the fields
@@ -423,7 +484,8 @@ func (f *TwoFactorScratchAuthForm) Validate(req *http.Request, errs binding.Erro
 
 // WebauthnRegistrationForm for reserving an WebAuthn name
 type WebauthnRegistrationForm struct {
-	Name string `binding:"Required"`
+	Name    string `binding:"Required"`
+	Passkey bool
 }
 
 // Validate validates the fields
@@ -0,0 +1,120 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+)
+
+// SavedSearchOptions returns the options to search the issues of a saved search on behalf of the doer.
+// The search is scoped the same way as the issues and pull requests dashboards of its owner,
+// the filters on assignees, posters and reviewers referring to the doer. The issues of the excluded
+// repositories, which the IP allowlists of their organizations block, are left out.
+func SavedSearchOptions(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch, excludedRepoIDs []int64) (*issue_indexer.SearchOptions, error) {
+	if err := search.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	unitType := unit.TypeIssues
+	if search.IsPull {
+		unitType = unit.TypePullRequests
+	}
+
+	// the organization dashboards have no "created by" filter
+	viewType := search.ViewType
+	if viewType == "" || (viewType == "created_by" && search.Owner.IsOrganization()) {
+		if search.Owner.IsOrganization() {
+			viewType = "your_repositories"
+		} else {
+			viewType = "created_by"
+		}
+	}
+
+	opts := &issues_model.IssuesOptions{
+		IsPull:     optional.Some(search.IsPull),
+		IsClosed:   optional.Some(search.IsClosed()),
+		SortType:   search.SortType,
+		IsArchived: optional.Some(false),
+
+		ExcludedRepoIDs: excludedRepoIDs,
+	}
+
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, &repo_model.SearchRepoOptions{
+		Actor:       doer,
+		OwnerID:     search.OwnerID,
+		Private:     true,
+		Collaborate: optional.None[bool](),
+		UnitType:    unitType,
+		Archived:    optional.Some(false),
+		TeamID:      search.TeamID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts.RepoIDs = repoIDs
+	if len(opts.RepoIDs) == 0 {
+		// no repos found, don't let the indexer return all repos
+		opts.RepoIDs = []int64{0}
+	}
+	// like on the dashboard of a user, the issues created by or involving them can be in any public repository
+	if search.OwnerID == doer.ID && viewType != "your_repositories" {
+		opts.AllPublic = true
+	}
+
+	switch viewType {
+	case "assigned":
+		opts.AssigneeID = doer.ID
+	case "created_by":
+		opts.PosterID = doer.ID
+	case "mentioned":
+		opts.MentionedID = doer.ID
+	case "review_requested":
+		opts.ReviewRequestedID = doer.ID
+	case "reviewed_by":
+		opts.ReviewedID = doer.ID
+	}
+
+	if opts.LabelIDs, err = search.LabelIDs(); err != nil {
+		return nil, err
+	}
+
+	return issue_indexer.ToSearchOptions(ctx, search.Keyword, opts), nil
+}
+
+// SearchSavedSearchIssues returns a page of the issues of the saved search on behalf of the doer,
+// along with their total count
+func SearchSavedSearchIssues(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch, excludedRepoIDs []int64, listOptions db.ListOptions) (issues_model.IssueList, int64, error) {
+	searchOpts, err := SavedSearchOptions(ctx, doer, search, excludedRepoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	searchOpts.Paginator = &listOptions
+
+	issueIDs, total, err := issue_indexer.SearchIssues(ctx, searchOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return issues, total, nil
+}
+
+// CountSavedSearchIssues counts the issues of the saved search on behalf of the doer
+func CountSavedSearchIssues(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch, excludedRepoIDs []int64) (int64, error) {
+	searchOpts, err := SavedSearchOptions(ctx, doer, search, excludedRepoIDs)
+	if err != nil {
+		return 0, err
+	}
+	return issue_indexer.CountIssues(ctx, searchOpts)
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/translation"
+	notify_service "forgejo.org/services/notify"
+)
+
+// slaNotifier applies the SLA policies of the repositories to their new issues and pull
+// requests, and records when they are responded to and closed
+type slaNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &slaNotifier{}
+
+// Init registers the notifier tracking the SLA of issues
+func Init() error {
+	notify_service.RegisterNotifier(&slaNotifier{})
+	return nil
+}
+
+type slaEscalationContextKey struct{}
+
+// isSLAEscalation checks if the context is the one of an escalation, whose comments
+// are no response to the issue
+func isSLAEscalation(ctx context.Context) bool {
+	return ctx.Value(slaEscalationContextKey{}) != nil
+}
+
+func (*slaNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	applySLAPolicy(ctx, issue)
+}
+
+func (*slaNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, _ []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	applySLAPolicy(ctx, pr.Issue)
+}
+
+func (*slaNotifier) IssueChangeLabels(ctx context.Context, _ *user_model.User, issue *issues_model.Issue, addedLabels, _ []*issues_model.Label) {
+	// a label may bring the issue under a policy, but the policy of an issue never changes
+	if len(addedLabels) > 0 && issue.SLAPolicyID == 0 && issue.SLAResponseDeadlineUnix == 0 && issue.SLAResolveDeadlineUnix == 0 {
+		applySLAPolicy(ctx, issue)
+	}
+}
+
+func (*slaNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, _ *repo_model.Repository, issue *issues_model.Issue, comment *issues_model.Comment, _ []*user_model.User) {
+	if isSLAEscalation(ctx) || doer.ID == issue.PosterID {
+		return
+	}
+	if err := issues_model.SetIssueSLAResponded(ctx, issue, comment.CreatedUnix); err != nil {
+		log.Error("SetIssueSLAResponded: %v", err)
+	}
+}
+
+func (*slaNotifier) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, review *issues_model.Review, _ *issues_model.Comment, _ []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if review.ReviewerID == pr.Issue.PosterID {
+		return
+	}
+	if err := issues_model.SetIssueSLAResponded(ctx, pr.Issue, review.CreatedUnix); err != nil {
+		log.Error("SetIssueSLAResponded: %v", err)
+	}
+}
+
+func (*slaNotifier) IssueChangeStatus(ctx context.Context, _ *user_model.User, _ string, issue *issues_model.Issue, _ *issues_model.Comment, closed bool) {
+	if !closed {
+		return
+	}
+	closedUnix := issue.ClosedUnix
+	if closedUnix == 0 {
+		closedUnix = timeutil.TimeStampNow()
+	}
+	if err := issues_model.SetIssueSLAResolved(ctx, issue, closedUnix); err != nil {
+		log.Error("SetIssueSLAResolved: %v", err)
+	}
+}
+
+// applySLAPolicy applies the first SLA policy of the repository matching the issue
+func applySLAPolicy(ctx context.Context, issue *issues_model.Issue) {
+	// the labels cached on the issue may predate the change which triggered the notification
+	labels, err := issues_model.GetLabelsByIssueID(ctx, issue.ID)
+	if err != nil {
+		log.Error("GetLabelsByIssueID: %v", err)
+		return
+	}
+	labelIDs := make([]int64, 0, len(labels))
+	for _, label := range labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	policy, err := issues_model.GetMatchingSLAPolicy(ctx, issue, labelIDs)
+	if err != nil {
+		log.Error("GetMatchingSLAPolicy: %v", err)
+		return
+	}
+	if policy == nil {
+		return
+	}
+	if err := issues_model.SetIssueSLA(ctx, issue, policy); err != nil {
+		log.Error("SetIssueSLA: %v", err)
+	}
+}
+
+// EscalateSLAs records the breaches of the SLA of the open issues, then escalates the issues
+// about to breach the policies configured to: by a comment mentioning the configured users
+// and teams, by adding a label, or both
+func EscalateSLAs(ctx context.Context) error {
+	if err := issues_model.MarkSLABreaches(ctx, timeutil.TimeStampNow()); err != nil {
+		return fmt.Errorf("MarkSLABreaches: %w", err)
+	}
+
+	policies, err := issues_model.GetEscalatingSLAPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("GetEscalatingSLAPolicies: %w", err)
+	}
+
+	ctx = context.WithValue(ctx, slaEscalationContextKey{}, true)
+	for _, policy := range policies {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		before := timeutil.TimeStamp(time.Now().Add(time.Duration(policy.EscalateBeforeSeconds) * time.Second).Unix())
+		issues, err := issues_model.GetIssuesToEscalate(ctx, policy.ID, before)
+		if err != nil {
+			return fmt.Errorf("GetIssuesToEscalate: %w", err)
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		doer, err := user_model.GetPossibleUserByID(ctx, policy.CreatorID)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return fmt.Errorf("GetPossibleUserByID: %w", err)
+			}
+			doer = user_model.NewGhostUser()
+		}
+
+		var label *issues_model.Label
+		if policy.EscalationLabelID > 0 {
+			if label, err = issues_model.GetLabelByID(ctx, policy.EscalationLabelID); err != nil && !issues_model.IsErrLabelNotExist(err) {
+				return fmt.Errorf("GetLabelByID: %w", err)
+			}
+		}
+
+		for _, issue := range issues {
+			if err := escalateSLA(ctx, doer, policy, label, issue); err != nil {
+				log.Error("Unable to escalate the SLA of issue %d: %v", issue.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func escalateSLA(ctx context.Context, doer *user_model.User, policy *issues_model.SLAPolicy, label *issues_model.Label, issue *issues_model.Issue) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	if policy.EscalationComment || policy.EscalationMentions != "" {
+		locale := translation.NewLocale(doer.Language)
+		key := "repo.issues.sla.escalation_resolve"
+		if issue.SLARespondedUnix == 0 && issue.SLAResponseDeadlineUnix > 0 {
+			key = "repo.issues.sla.escalation_response"
+		}
+		content := locale.TrString(key, policy.Name, issue.SLADeadline().AsTime().UTC().Format(time.RFC1123))
+		if mentions := strings.Fields(policy.EscalationMentions); len(mentions) > 0 {
+			content += "\n\n" + strings.Join(mentions, " ")
+		}
+		if _, err := CreateIssueComment(ctx, doer, issue.Repo, issue, content, nil); err != nil {
+			return err
+		}
+	}
+
+	if label != nil && (label.RepoID == issue.RepoID || label.OrgID == issue.Repo.OwnerID) {
+		if err := AddLabel(ctx, issue, doer, label); err != nil {
+			return err
+		}
+	}
+
+	return issues_model.SetIssueSLAEscalated(ctx, issue)
+}
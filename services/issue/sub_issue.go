@@ -0,0 +1,69 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	notify_service "forgejo.org/services/notify"
+)
+
+// AddSubIssue makes issue a sub-issue of parent.
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := issues_model.AddSubIssue(ctx, doer.ID, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeParent(ctx, doer, issue, parent, false)
+	return nil
+}
+
+// RemoveSubIssue detaches issue from parent.
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := issues_model.RemoveSubIssue(ctx, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeParent(ctx, doer, issue, parent, true)
+	return nil
+}
+
+// closeParentIfSubIssuesDone closes the parent of a just closed issue if the
+// repository of the parent asks for it, the doer may close the parent and
+// no sub-issue of the parent is open anymore.
+func closeParentIfSubIssuesDone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) error {
+	parent, err := issue.GetParentIssue(ctx)
+	if err != nil || parent == nil || parent.IsClosed {
+		return err
+	}
+	if !parent.Repo.IsCloseParentWithSubIssuesEnabled(ctx) {
+		return nil
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, parent.Repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWriteIssuesOrPulls(parent.IsPull) {
+		log.Debug("Doer[%d] cannot close parent issue[%d] of issue[%d]", doer.ID, parent.ID, issue.ID)
+		return nil
+	}
+
+	if hasOpen, err := issues_model.HasOpenSubIssues(ctx, parent.ID); err != nil || hasOpen {
+		return err
+	}
+
+	if err := ChangeStatus(ctx, parent, doer, "", true); err != nil {
+		if issues_model.IsErrDependenciesLeft(err) {
+			log.Debug("Parent issue[%d] of issue[%d] has open dependencies, not closing it", parent.ID, issue.ID)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
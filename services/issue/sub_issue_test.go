@@ -0,0 +1,50 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseParentIfSubIssuesDone(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue 7 is in the private repo2, issue 1 in the public repo1, both owned by user2
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+	subIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, 2, parent, subIssue))
+
+	issuesUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: 2, Type: unit.TypeIssues})
+	issuesUnit.Config = &repo_model.IssuesConfig{CloseParentWithSubIssues: true}
+	require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, issuesUnit))
+
+	t.Run("NoPermission", func(t *testing.T) {
+		// user5 cannot write the issues of repo2
+		doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+		require.NoError(t, ChangeStatus(db.DefaultContext, subIssue, doer, "", true))
+
+		parent = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+		assert.False(t, parent.IsClosed)
+
+		require.NoError(t, ChangeStatus(db.DefaultContext, subIssue, doer, "", false))
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		require.NoError(t, ChangeStatus(db.DefaultContext, subIssue, doer, "", true))
+
+		parent = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+		assert.True(t, parent.IsClosed)
+	})
+}
@@ -456,6 +456,16 @@ func getAuthenticatedRepository(ctx *context.Context, rc *requestContext, requir
 		return nil
 	}
 
+	allowed, err := context.IsRemoteHostAllowed(ctx, repository.OwnerID, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		log.Error("Unable to check the IP allowlist of %s: %v", rc.User, err)
+		writeStatus(ctx, http.StatusInternalServerError)
+		return nil
+	} else if !allowed {
+		writeStatusMessage(ctx, http.StatusForbidden, "Access is not allowed by the IP allowlist of the organization")
+		return nil
+	}
+
 	if requireWrite {
 		context.CheckRepoScopedToken(ctx, repository, auth_model.Write)
 	} else {
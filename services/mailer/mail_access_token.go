@@ -0,0 +1,88 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailAuthTokenExpiry base.TplName = "auth/token_expiry"
+)
+
+// RemindExpiringAccessTokens reminds the owners of the access tokens which expire soon
+func RemindExpiringAccessTokens(ctx context.Context) error {
+	if setting.MailService == nil || setting.AccessTokenExpiryReminder <= 0 {
+		return nil
+	}
+
+	tokens, err := auth_model.FindAccessTokensToRemind(ctx, timeutil.TimeStamp(time.Now().Add(setting.AccessTokenExpiryReminder).Unix()))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tokens {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		u, err := user_model.GetUserByID(ctx, t.UID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if u.IsActive && !u.ProhibitLogin && u.Email != "" {
+			if err := sendAccessTokenExpiryReminder(u, t); err != nil {
+				log.Error("sendAccessTokenExpiryReminder: %v", err)
+				continue
+			}
+		}
+		if err := auth_model.SetAccessTokenReminderSent(ctx, t.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendAccessTokenExpiryReminder(u *user_model.User, t *auth_model.AccessToken) error {
+	locale := translation.NewLocale(u.Language)
+
+	subject := locale.TrString("mail.token_expiry.subject", t.Name)
+
+	data := map[string]any{
+		"locale":      locale,
+		"Subject":     subject,
+		"DisplayName": u.DisplayName(),
+		"TokenName":   t.Name,
+		"ExpiresAt":   t.ExpiresUnix.AsTime().UTC().Format(time.RFC1123),
+		"Link":        setting.AppURL + "user/settings/applications",
+		"Language":    locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(mailAuthTokenExpiry), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), subject, content.String())
+	msg.Info = fmt.Sprintf("UID: %d, access token %d expiry reminder", u.ID, t.ID)
+
+	SendAsync(msg)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+
+	advisory_model "forgejo.org/models/advisory"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplVulnerabilityAlerts base.TplName = "notify/vulnerability_alerts"
+)
+
+// MailVulnerabilityAlerts sends the opened alerts of vulnerable dependencies to the users with write access to the repository
+func MailVulnerabilityAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*advisory_model.Alert) error {
+	if setting.MailService == nil {
+		// No mail service configured
+		return nil
+	}
+
+	if err := repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+	if err := advisory_model.AlertList(alerts).LoadAdvisories(ctx); err != nil {
+		return err
+	}
+
+	users, err := access_model.GetRepoWriters(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, to := range users {
+		if !to.IsActive || to.IsOrganization() || to.Email == "" || to.EmailNotificationsPreference == user_model.EmailNotificationsDisabled {
+			continue
+		}
+		if err := sendMailVulnerabilityAlerts(to, repo, alerts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendMailVulnerabilityAlerts(to *user_model.User, repo *repo_model.Repository, alerts []*advisory_model.Alert) error {
+	var (
+		locale  = translation.NewLocale(to.Language)
+		content bytes.Buffer
+	)
+
+	subject := locale.TrString("mail.vulnerability_alerts.subject", len(alerts), repo.FullName())
+
+	data := map[string]any{
+		"locale":   locale,
+		"Link":     repo.HTMLURL() + "/security",
+		"Subject":  subject,
+		"Language": locale.Language(),
+		"Repo":     repo,
+		"Alerts":   alerts,
+	}
+
+	if err := bodyTemplates.ExecuteTemplate(&content, string(tplVulnerabilityAlerts), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(to.EmailTo(), subject, content.String())
+	msg.Info = subject
+	SendAsync(msg)
+
+	return nil
+}
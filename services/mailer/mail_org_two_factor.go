@@ -0,0 +1,66 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	mailNotifyOrgTwoFactor base.TplName = "notify/org_two_factor"
+)
+
+// MailOrgTwoFactorRequired informs the users who have not enabled two-factor authentication
+// that an organization requires it and when they lose access without it
+func MailOrgTwoFactorRequired(org *org_model.Organization, requirement *org_model.TwoFactorRequirement, users []*user_model.User) {
+	if setting.MailService == nil {
+		return
+	}
+
+	for _, u := range users {
+		if !u.IsActive || u.ProhibitLogin || u.Email == "" {
+			continue
+		}
+		if err := sendOrgTwoFactorRequired(org, requirement, u); err != nil {
+			log.Error("sendOrgTwoFactorRequired: %v", err)
+		}
+	}
+}
+
+func sendOrgTwoFactorRequired(org *org_model.Organization, requirement *org_model.TwoFactorRequirement, u *user_model.User) error {
+	locale := translation.NewLocale(u.Language)
+
+	subject := locale.TrString("mail.org_two_factor.subject", org.DisplayName())
+
+	data := map[string]any{
+		"locale":       locale,
+		"Subject":      subject,
+		"DisplayName":  u.DisplayName(),
+		"Organization": org.DisplayName(),
+		"IsEnforced":   requirement.IsEnforced(),
+		"Deadline":     requirement.DeadlineUnix.AsTime().UTC().Format(time.RFC1123),
+		"Link":         setting.AppURL + "user/settings/security",
+		"Language":     locale.Language(),
+	}
+
+	var content bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&content, string(mailNotifyOrgTwoFactor), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), subject, content.String())
+	msg.Info = fmt.Sprintf("UID: %d, organization %d requires two-factor authentication", u.ID, org.ID)
+
+	SendAsync(msg)
+	return nil
+}
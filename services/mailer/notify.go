@@ -9,6 +9,7 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	activities_model "forgejo.org/models/activities"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
@@ -225,3 +226,9 @@ func (m *mailNotifier) ActionRunNowDone(ctx context.Context, run *actions_model.
 		log.Error("MailActionRunNowDone: %v", err)
 	}
 }
+
+func (m *mailNotifier) NewVulnerabilityAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*advisory_model.Alert) {
+	if err := MailVulnerabilityAlerts(ctx, repo, alerts); err != nil {
+		log.Error("MailVulnerabilityAlerts: %v", err)
+	}
+}
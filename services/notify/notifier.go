@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -39,6 +40,7 @@ type Notifier interface {
 	IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string)
 	IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 		addedLabels, removedLabels []*issues_model.Label)
+	IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool)
 
 	NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User)
 	MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest)
@@ -77,5 +79,7 @@ type Notifier interface {
 
 	ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository)
 
+	NewVulnerabilityAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*advisory_model.Alert)
+
 	ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorStatus actions_model.Status, lastRun *actions_model.ActionRun)
 }
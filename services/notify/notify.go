@@ -200,6 +200,13 @@ func IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *iss
 	}
 }
 
+// IssueChangeParent notifies that an issue was attached to or detached from a parent issue
+func IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeParent(ctx, doer, issue, parent, removed)
+	}
+}
+
 // IssueChangeContent notifies change content to notifiers
 func IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
 	for _, notifier := range notifiers {
@@ -377,6 +384,13 @@ func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	}
 }
 
+// NewVulnerabilityAlerts notifies opened alerts of vulnerable dependencies of a repository to notifiers
+func NewVulnerabilityAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*advisory_model.Alert) {
+	for _, notifier := range notifiers {
+		notifier.NewVulnerabilityAlerts(ctx, repo, alerts)
+	}
+}
+
 // ActionRunNowDone notifies that the old status priorStatus with (priorStatus.isDone() == false) of an ActionRun changed to run.Status with (run.Status.isDone() == true)
 // run represents the new state of the ActionRun.
 // lastRun represents the ActionRun of the same workflow that finished before run.
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -109,6 +110,10 @@ func (*NullNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, r
 func (*NullNotifier) IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
 }
 
+// IssueChangeParent places a place holder function
+func (*NullNotifier) IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool) {
+}
+
 // IssueChangeContent places a place holder function
 func (*NullNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
 }
@@ -209,6 +214,10 @@ func (*NullNotifier) PackageDelete(ctx context.Context, doer *user_model.User, p
 func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 }
 
+// NewVulnerabilityAlerts places a place holder function
+func (*NullNotifier) NewVulnerabilityAlerts(ctx context.Context, repo *repo_model.Repository, alerts []*advisory_model.Alert) {
+}
+
 // ActionRunNowDone places a place holder function
 func (*NullNotifier) ActionRunNowDone(ctx context.Context, run *actions_model.ActionRun, priorStatus actions_model.Status, lastRun *actions_model.ActionRun) {
 }
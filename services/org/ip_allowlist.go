@@ -0,0 +1,62 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+)
+
+// ParseIPAllowlist parses the IP networks an organization restricts the access to, the list must not be empty
+func ParseIPAllowlist(allowedIPs string) (*hostmatcher.HostMatchList, error) {
+	hl, err := hostmatcher.ParseIPNetList("", allowedIPs)
+	if err != nil {
+		return nil, err
+	}
+	if hl.IsEmpty() {
+		return nil, util.NewInvalidArgumentErrorf("the IP allowlist is empty")
+	}
+	return hl, nil
+}
+
+// SetIPAllowlist restricts the access to the resources of an organization to clients from a list of IP networks
+func SetIPAllowlist(ctx context.Context, doer *user_model.User, org *org_model.Organization, allowedIPs string) error {
+	hl, err := ParseIPAllowlist(allowedIPs)
+	if err != nil {
+		return err
+	}
+
+	before, err := org_model.GetIPAllowlist(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	allowlist, err := org_model.SetIPAllowlist(ctx, org.ID, hl.SettingValue)
+	if err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgIPAllowlistUpdate, audit_service.UserTarget(org.AsUser()),
+		audit_service.IPAllowlistState(before), audit_service.IPAllowlistState(allowlist))
+	return nil
+}
+
+// RemoveIPAllowlist no longer restricts the access to the resources of an organization
+func RemoveIPAllowlist(ctx context.Context, doer *user_model.User, org *org_model.Organization) error {
+	before, err := org_model.GetIPAllowlist(ctx, org.ID)
+	if err != nil || before == nil {
+		return err
+	}
+
+	if err := org_model.RemoveIPAllowlist(ctx, org.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgIPAllowlistRemove, audit_service.UserTarget(org.AsUser()),
+		audit_service.IPAllowlistState(before), nil)
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -48,6 +49,10 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return models.ErrUserOwnPackages{UID: org.ID}
 	}
 
+	if err := db.DeleteBeans(ctx, &issues_model.SavedSearch{OwnerID: org.ID}); err != nil {
+		return fmt.Errorf("DeleteBeans: %w", err)
+	}
+
 	if err := org_model.DeleteOrganization(ctx, org); err != nil {
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
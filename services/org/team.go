@@ -0,0 +1,80 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"context"
+
+	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
+)
+
+// NewTeam creates a team and records it in the audit log
+func NewTeam(ctx context.Context, doer *user_model.User, team *org_model.Team) error {
+	if err := models.NewTeam(ctx, team); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTeamCreate, audit_service.TeamTarget(team.GetOrg(ctx), team), nil, audit_service.TeamState(team))
+	return nil
+}
+
+// UpdateTeam updates a team and records the changes since before, the state of the team
+// taken with audit_service.TeamState before it was modified, in the audit log
+func UpdateTeam(ctx context.Context, doer *user_model.User, team *org_model.Team, before any, authChanged, includeAllChanged bool) error {
+	if err := models.UpdateTeam(ctx, team, authChanged, includeAllChanged); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTeamUpdate, audit_service.TeamTarget(team.GetOrg(ctx), team), before, audit_service.TeamState(team))
+	return nil
+}
+
+// DeleteTeam deletes a team and records it in the audit log
+func DeleteTeam(ctx context.Context, doer *user_model.User, team *org_model.Team) error {
+	org := team.GetOrg(ctx)
+	if err := models.DeleteTeam(ctx, team); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTeamDelete, audit_service.TeamTarget(org, team), audit_service.TeamState(team), nil)
+	return nil
+}
+
+// AddTeamMember adds a user to a team and records it in the audit log
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *org_model.Team, u *user_model.User) error {
+	if team.IsMember(ctx, u.ID) {
+		return nil
+	}
+	if err := models.AddTeamMember(ctx, team, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTeamMemberAdd, audit_service.TeamTarget(team.GetOrg(ctx), team), nil, audit_service.MemberState(u))
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team and records it in the audit log
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *org_model.Team, u *user_model.User) error {
+	if !team.IsMember(ctx, u.ID) {
+		return nil
+	}
+	if err := models.RemoveTeamMember(ctx, team, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTeamMemberRemove, audit_service.TeamTarget(team.GetOrg(ctx), team), audit_service.MemberState(u), nil)
+	return nil
+}
+
+// RemoveOrgUser removes a user from an organization and all of its teams and records it in the audit log
+func RemoveOrgUser(ctx context.Context, doer *user_model.User, org *org_model.Organization, u *user_model.User) error {
+	isMember, err := org_model.IsOrganizationMember(ctx, org.ID, u.ID)
+	if err != nil || !isMember {
+		return err
+	}
+	if err := models.RemoveOrgUser(ctx, org.ID, u.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgMemberRemove, audit_service.OrgMemberTarget(org, u), audit_service.MemberState(u), nil)
+	return nil
+}
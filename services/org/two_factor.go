@@ -0,0 +1,111 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"context"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	org_model "forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/mailer"
+)
+
+// twoFactorReminderPeriod is how long before the end of the grace period the users who have not enabled
+// two-factor authentication yet are reminded
+const twoFactorReminderPeriod = 3 * 24 * time.Hour
+
+// RequireTwoFactor requires the members and outside collaborators of an organization to enable two-factor
+// authentication before the grace period ends. The users who have not enabled it yet are notified by mail,
+// and reminded shortly before the grace period ends.
+func RequireTwoFactor(ctx context.Context, doer *user_model.User, org *org_model.Organization, gracePeriod time.Duration) error {
+	before, err := org_model.GetTwoFactorRequirement(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+
+	// a short grace period needs no reminder after the first notification
+	var reminded timeutil.TimeStamp
+	if gracePeriod <= twoFactorReminderPeriod {
+		reminded = timeutil.TimeStampNow()
+	}
+
+	requirement, err := org_model.SetTwoFactorRequirement(ctx, org.ID, timeutil.TimeStamp(time.Now().Add(gracePeriod).Unix()), reminded)
+	if err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTwoFactorRequire, audit_service.UserTarget(org.AsUser()),
+		audit_service.TwoFactorRequirementState(before), audit_service.TwoFactorRequirementState(requirement))
+
+	users, err := GetUsersWithoutTwoFactor(ctx, org)
+	if err != nil {
+		return err
+	}
+	mailer.MailOrgTwoFactorRequired(org, requirement, users)
+	return nil
+}
+
+// RemindTwoFactorRequirements reminds the users who have not enabled two-factor authentication yet
+// that the grace period of an organization ends soon
+func RemindTwoFactorRequirements(ctx context.Context) error {
+	requirements, err := org_model.FindTwoFactorRequirementsToRemind(ctx, timeutil.TimeStamp(time.Now().Add(twoFactorReminderPeriod).Unix()))
+	if err != nil {
+		return err
+	}
+
+	for _, requirement := range requirements {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		org, err := org_model.GetOrgByID(ctx, requirement.OrgID)
+		if err != nil {
+			return err
+		}
+		users, err := GetUsersWithoutTwoFactor(ctx, org)
+		if err != nil {
+			return err
+		}
+		mailer.MailOrgTwoFactorRequired(org, requirement, users)
+
+		if err := org_model.SetTwoFactorRequirementReminded(ctx, requirement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveTwoFactorRequirement no longer requires two-factor authentication in an organization
+func RemoveTwoFactorRequirement(ctx context.Context, doer *user_model.User, org *org_model.Organization) error {
+	before, err := org_model.GetTwoFactorRequirement(ctx, org.ID)
+	if err != nil || before == nil {
+		return err
+	}
+
+	if err := org_model.RemoveTwoFactorRequirement(ctx, org.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionOrgTwoFactorRemove, audit_service.UserTarget(org.AsUser()),
+		audit_service.TwoFactorRequirementState(before), nil)
+	return nil
+}
+
+// GetUsersWithoutTwoFactor returns the members and then the outside collaborators of an organization
+// who have not enabled two-factor authentication
+func GetUsersWithoutTwoFactor(ctx context.Context, org *org_model.Organization) ([]*user_model.User, error) {
+	members, err := org_model.GetMembersWithoutTwoFactor(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	collaborators, err := org_model.GetOutsideCollaboratorsWithoutTwoFactor(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(members, collaborators...), nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/organization"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/perm"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+)
+
+// AccessGrant is an access granted by an owner together with the team or repository it is granted to
+type AccessGrant struct {
+	*packages_model.PackageAccess
+	Team *organization.Team
+	Repo *repo_model.Repository
+}
+
+// GetAccessGrants gets all accesses granted by the owner
+func GetAccessGrants(ctx context.Context, owner *user_model.User) ([]*AccessGrant, error) {
+	pas, err := packages_model.GetAccessesByOwner(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*AccessGrant, 0, len(pas))
+	for _, pa := range pas {
+		g := &AccessGrant{PackageAccess: pa}
+		if pa.TeamID != 0 {
+			if g.Team, err = organization.GetTeamByID(ctx, pa.TeamID); err != nil {
+				return nil, err
+			}
+		} else {
+			if g.Repo, err = repo_model.GetRepositoryByID(ctx, pa.RepoID); err != nil {
+				return nil, err
+			}
+		}
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// GrantAccess grants a team of the owner or the Actions tokens of a repository of the owner access to a package.
+// Exactly one of teamName and repoName must be set.
+func GrantAccess(ctx context.Context, owner *user_model.User, packageType packages_model.Type, name, teamName, repoName string, mode perm.AccessMode) (*packages_model.PackageAccess, error) {
+	if !slices.Contains(packages_model.TypeList, packageType) {
+		return nil, util.NewInvalidArgumentErrorf("invalid package type: %s", packageType)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, util.NewInvalidArgumentErrorf("package name is empty")
+	}
+	if mode != perm.AccessModeRead && mode != perm.AccessModeWrite {
+		return nil, util.NewInvalidArgumentErrorf("invalid access mode: %s", mode)
+	}
+
+	pa := &packages_model.PackageAccess{
+		OwnerID:    owner.ID,
+		Type:       packageType,
+		LowerName:  name,
+		AccessMode: mode,
+	}
+
+	teamName, repoName = strings.TrimSpace(teamName), strings.TrimSpace(repoName)
+	switch {
+	case teamName != "" && repoName == "":
+		if !owner.IsOrganization() {
+			return nil, util.NewInvalidArgumentErrorf("teams only exist in organizations")
+		}
+		team, err := organization.GetTeam(ctx, owner.ID, teamName)
+		if err != nil {
+			return nil, err
+		}
+		pa.TeamID = team.ID
+	case repoName != "" && teamName == "":
+		repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, repoName)
+		if err != nil {
+			return nil, err
+		}
+		pa.RepoID = repo.ID
+	default:
+		return nil, util.NewInvalidArgumentErrorf("either a team or a repository is required")
+	}
+
+	return packages_model.SetAccess(ctx, pa)
+}
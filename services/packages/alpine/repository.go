@@ -308,6 +308,7 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 			Creator:           user_model.NewGhostUser(),
 			Data:              signedIndexContent,
 			IsLead:            false,
+			Generated:         true,
 			OverwriteExisting: true,
 		},
 	)
@@ -786,6 +786,7 @@ func addReleaseAsFileToRepo(ctx context.Context, pv *packages_model.PackageVersi
 			Creator:           user_model.NewGhostUser(),
 			Data:              content,
 			IsLead:            false,
+			Generated:         true,
 			OverwriteExisting: true,
 		},
 	)
@@ -884,6 +885,7 @@ func addPkglistAsFileToRepo(ctx context.Context, pv *packages_model.PackageVersi
 			Creator:           user_model.NewGhostUser(),
 			Data:              content,
 			IsLead:            false,
+			Generated:         true,
 			OverwriteExisting: true,
 		},
 	)
@@ -159,6 +159,7 @@ func BuildPacmanDB(ctx context.Context, ownerID int64, group, arch string) error
 			Creator:           user_model.NewGhostUser(),
 			Data:              data,
 			IsLead:            false,
+			Generated:         true,
 			OverwriteExisting: true,
 		})
 		if err != nil {
@@ -0,0 +1,441 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	actions_model "forgejo.org/models/actions"
+	asymkey_model "forgejo.org/models/asymkey"
+	packages_model "forgejo.org/models/packages"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	"forgejo.org/modules/packages/attestation"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+// SSHSignatureNamespace is the namespace SSH signatures of package files are made for, `ssh-keygen -Y sign -n file`
+const SSHSignatureNamespace = "file"
+
+var (
+	ErrSignatureRequired = errors.New("a valid signature of the package file is required")
+	ErrInvalidSignature  = errors.New("the signature of the package file could not be verified")
+	// ErrProvenanceSubject indicates that a provenance statement is not about the files of the package version
+	ErrProvenanceSubject = util.NewInvalidArgumentErrorf("no subject of the provenance statement is a file of the package version")
+	// ErrProvenanceRepository indicates that a build was run by a repository of another owner
+	ErrProvenanceRepository = util.NewInvalidArgumentErrorf("the build was not run by a repository of the package owner")
+)
+
+type uploadSignatureContextKeyType struct{}
+
+// UploadSignatureContextKey is the key of the detached signature of an uploaded file in the request context
+var UploadSignatureContextKey = uploadSignatureContextKeyType{}
+
+// ActionsBuild is an Actions run which built a package version, as attested by an ID token of the run
+type ActionsBuild struct {
+	Repository  string
+	RunID       int64
+	RunAttempt  string
+	EventName   string
+	Ref         string
+	Sha         string
+	WorkflowRef string
+}
+
+// RequiresSignature checks if the main files uploaded to the packages of the owner must be signed
+func RequiresSignature(ctx context.Context, owner *user_model.User) (bool, error) {
+	if setting.Packages.RequireSignature {
+		return true, nil
+	}
+	value, err := user_model.GetUserSetting(ctx, owner.ID, user_model.SettingsKeyPackagesRequireSignature, "false")
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// SetRequiresSignature sets if the main files uploaded to the packages of the owner must be signed
+func SetRequiresSignature(ctx context.Context, owner *user_model.User, require bool) error {
+	return user_model.SetUserSetting(ctx, owner.ID, user_model.SettingsKeyPackagesRequireSignature, strconv.FormatBool(require))
+}
+
+// trustedKeys returns the keys sigstore bundles and signed provenance statements are verified against
+func trustedKeys() ([]crypto.PublicKey, error) {
+	if setting.Packages.TrustedKeysFile == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(setting.Packages.TrustedKeysFile)
+	if err != nil {
+		return nil, err
+	}
+	return attestation.ParsePublicKeys(content)
+}
+
+// verifySignature checks a detached signature of the content of a package file
+func verifySignature(ctx context.Context, pf *packages_model.PackageFile, pb *packages_model.PackageBlob, signature []byte) (*packages_model.PackageAttestation, error) {
+	format, err := attestation.DetectFormat(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	pa := &packages_model.PackageAttestation{
+		VersionID: pf.VersionID,
+		FileID:    pf.ID,
+		Content:   string(signature),
+		Verified:  true,
+	}
+
+	switch format {
+	case attestation.FormatGPG, attestation.FormatSSH:
+		s, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(pb.HashSHA256))
+		if err != nil {
+			return nil, err
+		}
+		defer s.Close()
+
+		if format == attestation.FormatGPG {
+			key, err := asymkey_model.VerifyDetachedGPGSignature(ctx, s, signature)
+			if err != nil {
+				return nil, verificationError(err)
+			}
+			pa.Type = packages_model.AttestationTypeGPG
+			pa.KeyID = key.KeyID
+			pa.SignerID = key.OwnerID
+			if pa.Content, err = armorGPGSignature(signature); err != nil {
+				return nil, err
+			}
+		} else {
+			key, err := asymkey_model.VerifyDetachedSSHSignature(ctx, s, signature, SSHSignatureNamespace)
+			if err != nil {
+				return nil, verificationError(err)
+			}
+			pa.Type = packages_model.AttestationTypeSSH
+			pa.KeyID = key.Fingerprint
+			pa.SignerID = key.OwnerID
+		}
+	case attestation.FormatSigstore:
+		bundle, err := attestation.ParseBundle(signature)
+		if err != nil {
+			return nil, verificationError(err)
+		}
+		keys, err := trustedKeys()
+		if err != nil {
+			return nil, err
+		}
+		digest, err := hex.DecodeString(pb.HashSHA256)
+		if err != nil {
+			return nil, err
+		}
+		key, err := bundle.VerifyBlob(digest, keys)
+		if err != nil {
+			return nil, verificationError(err)
+		}
+		pa.Type = packages_model.AttestationTypeSigstore
+		if pa.KeyID, err = attestation.KeyFingerprint(key); err != nil {
+			return nil, err
+		}
+	}
+	return pa, nil
+}
+
+func verificationError(err error) error {
+	if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return err
+}
+
+// armorGPGSignature stores binary signatures in the same armored form as the uploaded ones
+func armorGPGSignature(signature []byte) (string, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		return string(signature), nil
+	}
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.SignatureType, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(signature); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// VerifyUploadSignature verifies and stores the signature sent with the upload of the main file of a package version.
+// Without a signature the upload is rejected if the owner requires signed packages.
+func VerifyUploadSignature(ctx context.Context, owner, creator *user_model.User, pv *packages_model.PackageVersion, pf *packages_model.PackageFile, pb *packages_model.PackageBlob) error {
+	signature, _ := ctx.Value(UploadSignatureContextKey).([]byte)
+	if len(signature) == 0 {
+		if pv.UpstreamOnly {
+			return nil
+		}
+		required, err := RequiresSignature(ctx, owner)
+		if err != nil {
+			return err
+		}
+		if required {
+			return ErrSignatureRequired
+		}
+		return nil
+	}
+
+	pa, err := verifySignature(ctx, pf, pb, signature)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSignature) {
+			log.Debug("Rejecting signature of package file %d: %v", pf.ID, err)
+			return ErrInvalidSignature
+		}
+		return err
+	}
+	pa.CreatorID = creator.ID
+	return packages_model.InsertAttestation(ctx, pa)
+}
+
+// AddSignature verifies and stores a detached signature of a package file
+func AddSignature(ctx context.Context, doer *user_model.User, pf *packages_model.PackageFile, signature []byte) (*packages_model.PackageAttestation, error) {
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, err
+	}
+	pa, err := verifySignature(ctx, pf, pb, signature)
+	if err != nil {
+		return nil, err
+	}
+	pa.CreatorID = doer.ID
+	if err := packages_model.InsertAttestation(ctx, pa); err != nil {
+		return nil, err
+	}
+	return pa, nil
+}
+
+// fileForDigest returns the first file of the package version which is a subject of the statement
+func fileForDigest(ctx context.Context, pv *packages_model.PackageVersion, s *attestation.Statement) (*packages_model.PackageFile, error) {
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pf := range pfs {
+		pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+		if err != nil {
+			return nil, err
+		}
+		if s.HasSubjectDigest(pb.HashSHA256) {
+			return pf, nil
+		}
+	}
+	return nil, ErrProvenanceSubject
+}
+
+// AddProvenance stores a provenance statement of a package version. The statement is verified
+// if it is wrapped in an envelope signed by a trusted key.
+func AddProvenance(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, content []byte) (*packages_model.PackageAttestation, error) {
+	s, envelope, err := attestation.ParseStatement(content)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := fileForDigest(ctx, pv, s)
+	if err != nil {
+		return nil, err
+	}
+
+	pa := &packages_model.PackageAttestation{
+		VersionID: pv.ID,
+		FileID:    pf.ID,
+		Type:      packages_model.AttestationTypeProvenance,
+		Content:   string(content),
+		BuilderID: s.BuilderID(),
+		CreatorID: doer.ID,
+	}
+
+	if envelope != nil && len(envelope.Signatures) > 0 {
+		keys, err := trustedKeys()
+		if err != nil {
+			return nil, err
+		}
+		key, err := envelope.Verify(keys)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		if pa.KeyID, err = attestation.KeyFingerprint(key); err != nil {
+			return nil, err
+		}
+		pa.Verified = true
+	}
+
+	if err := packages_model.InsertAttestation(ctx, pa); err != nil {
+		return nil, err
+	}
+	return pa, nil
+}
+
+// AddActionsProvenance generates and stores a provenance statement of a package version built by an Actions run
+func AddActionsProvenance(ctx context.Context, doer, owner *user_model.User, pv *packages_model.PackageVersion, build *ActionsBuild) (*packages_model.PackageAttestation, error) {
+	ownerName, repoName, _ := strings.Cut(build.Repository, "/")
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil, ErrProvenanceRepository
+		}
+		return nil, err
+	}
+	if repo.OwnerID != owner.ID {
+		return nil, ErrProvenanceRepository
+	}
+
+	run, err := actions_model.GetRunByID(ctx, build.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if run.RepoID != repo.ID {
+		return nil, ErrProvenanceRepository
+	}
+	run.Repo = repo
+
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pfs) == 0 {
+		return nil, ErrProvenanceSubject
+	}
+	subjects := make([]attestation.Subject, 0, len(pfs))
+	for _, pf := range pfs {
+		pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, attestation.Subject{
+			Name:   pf.Name,
+			Digest: map[string]string{"sha256": pb.HashSHA256},
+		})
+	}
+
+	builderID := strings.TrimSuffix(setting.AppURL, "/") + "/api/actions"
+	invocationID := run.HTMLURL()
+	if build.RunAttempt != "" {
+		invocationID += "/attempts/" + build.RunAttempt
+	}
+
+	content, err := attestation.NewProvenance(subjects, &attestation.BuildInfo{
+		BuilderID:    builderID,
+		BuildType:    builderID + "/workflow/v1",
+		InvocationID: invocationID,
+		Parameters: map[string]string{
+			"repository": repo.HTMLURL(),
+			"ref":        build.Ref,
+			"workflow":   build.WorkflowRef,
+		},
+		Internal: map[string]string{
+			"event_name": build.EventName,
+		},
+		SourceURI:    "git+" + repo.HTMLURL() + "@" + build.Ref,
+		SourceCommit: build.Sha,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pa := &packages_model.PackageAttestation{
+		VersionID: pv.ID,
+		FileID:    pfs[0].ID,
+		Type:      packages_model.AttestationTypeProvenance,
+		Content:   string(content),
+		Verified:  true,
+		BuilderID: builderID,
+		RunID:     run.ID,
+		CreatorID: doer.ID,
+	}
+	if err := packages_model.InsertAttestation(ctx, pa); err != nil {
+		return nil, err
+	}
+	return pa, nil
+}
+
+// AttestationDescriptor is an attestation with the file, users and Actions run it refers to
+type AttestationDescriptor struct {
+	Attestation *packages_model.PackageAttestation
+	FileName    string
+	Signer      *user_model.User
+	Creator     *user_model.User
+	Run         *actions_model.ActionRun
+}
+
+// GetAttestationDescriptors gets the attestations of a package version
+func GetAttestationDescriptors(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*AttestationDescriptor, error) {
+	pas, err := packages_model.GetAttestationsByVersionID(ctx, pd.Version.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, 0, len(pas)*2)
+	for _, pa := range pas {
+		userIDs = append(userIDs, pa.CreatorID)
+		if pa.SignerID != 0 {
+			userIDs = append(userIDs, pa.SignerID)
+		}
+	}
+	users, err := user_model.GetPossibleUserByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		userMap[u.ID] = u
+	}
+	getUser := func(id int64) *user_model.User {
+		if u, ok := userMap[id]; ok {
+			return u
+		}
+		return user_model.NewGhostUser()
+	}
+
+	pads := make([]*AttestationDescriptor, 0, len(pas))
+	for _, pa := range pas {
+		pad := &AttestationDescriptor{
+			Attestation: pa,
+			Creator:     getUser(pa.CreatorID),
+		}
+		for _, pfd := range pd.Files {
+			if pfd.File.ID == pa.FileID {
+				pad.FileName = pfd.File.Name
+				break
+			}
+		}
+		if pa.SignerID != 0 {
+			pad.Signer = getUser(pa.SignerID)
+		}
+		if pa.RunID != 0 {
+			run, has, err := actions_model.GetRunByIDWithHas(ctx, pa.RunID)
+			if err != nil {
+				return nil, err
+			}
+			if has {
+				if err := run.LoadRepo(ctx); err != nil {
+					return nil, err
+				}
+				pad.Run = run
+			}
+		}
+		pads = append(pads, pad)
+	}
+	return pads, nil
+}
@@ -4,36 +4,49 @@
 package packages
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	actions_model "forgejo.org/models/actions"
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/perm"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-type packageClaims struct {
+// AuthorizationTokenClaims are the claims of a package registry authorization token
+type AuthorizationTokenClaims struct {
 	jwt.RegisteredClaims
-	UserID int64
-	Scope  auth_model.AccessTokenScope
+	UserID        int64
+	Scope         auth_model.AccessTokenScope
+	ActionsTaskID int64 `json:",omitempty"`
+	AccessTokenID int64 `json:",omitempty"`
 }
 
-func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenScope) (string, error) {
+// CreateAuthorizationToken creates a token for the user. If the user is the Actions user, the task is kept in the token.
+// If the user authenticated with a personal access token, the access token is kept to apply its restrictions.
+func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenScope, actionsTaskID, accessTokenID int64) (string, error) {
 	now := time.Now()
 
-	claims := packageClaims{
+	claims := AuthorizationTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(24 * time.Hour)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
-		UserID: u.ID,
-		Scope:  scope,
+		UserID:        u.ID,
+		Scope:         scope,
+		ActionsTaskID: actionsTaskID,
+		AccessTokenID: accessTokenID,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -45,32 +58,139 @@ func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenSc
 	return tokenString, nil
 }
 
-func ParseAuthorizationToken(req *http.Request) (int64, auth_model.AccessTokenScope, error) {
+// ParseAuthorizationToken returns the claims of the token or nil if the request has no token
+func ParseAuthorizationToken(req *http.Request) (*AuthorizationTokenClaims, error) {
 	h := req.Header.Get("Authorization")
 	if h == "" {
-		return 0, "", nil
+		return nil, nil
 	}
 
 	parts := strings.SplitN(h, " ", 2)
 	if len(parts) != 2 {
 		log.Error("split token failed: %s", h)
-		return 0, "", errors.New("split token failed")
+		return nil, errors.New("split token failed")
 	}
 
-	token, err := jwt.ParseWithClaims(parts[1], &packageClaims{}, func(t *jwt.Token) (any, error) {
+	token, err := jwt.ParseWithClaims(parts[1], &AuthorizationTokenClaims{}, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
 		return setting.GetGeneralTokenSigningSecret(), nil
 	})
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
-	c, ok := token.Claims.(*packageClaims)
+	c, ok := token.Claims.(*AuthorizationTokenClaims)
 	if !token.Valid || !ok {
-		return 0, "", errors.New("invalid token claim")
+		return nil, errors.New("invalid token claim")
 	}
 
-	return c.UserID, c.Scope, nil
+	return c, nil
+}
+
+// ErrPackageAccessDenied indicates that the doer can only write to the packages it was granted access to
+var ErrPackageAccessDenied = util.NewPermissionDeniedErrorf("no write access to this package")
+
+type grantKey struct {
+	packageType packages_model.Type
+	lowerName   string
+}
+
+// Grants are the per package access modes of a doer on an owner
+type Grants struct {
+	OwnerID int64
+	modes   map[grantKey]perm.AccessMode
+}
+
+// GetGrants gets the per package access modes granted to the teams of the doer or,
+// if the doer is the Actions user, to the repository of the running task.
+func GetGrants(ctx context.Context, owner, doer *user_model.User, actionsTaskID int64) (*Grants, error) {
+	g := &Grants{
+		OwnerID: owner.ID,
+		modes:   make(map[grantKey]perm.AccessMode),
+	}
+	if doer == nil || doer.IsGhost() {
+		return g, nil
+	}
+
+	var teamIDs []int64
+	var repoID int64
+	if doer.IsActions() {
+		if actionsTaskID == 0 {
+			return g, nil
+		}
+		task, err := actions_model.GetTaskByID(ctx, actionsTaskID)
+		if err != nil {
+			return nil, err
+		}
+		// tasks of pull requests from forks never get write access
+		if task.IsForkPullRequest {
+			return g, nil
+		}
+		repoID = task.RepoID
+	} else if owner.IsOrganization() {
+		teams, err := organization.GetUserOrgTeams(ctx, owner.ID, doer.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			teamIDs = append(teamIDs, t.ID)
+		}
+	}
+
+	pas, err := packages_model.GetGrantedAccesses(ctx, owner.ID, teamIDs, repoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pa := range pas {
+		key := grantKey{pa.Type, pa.LowerName}
+		g.modes[key] = max(g.modes[key], pa.AccessMode)
+	}
+	return g, nil
+}
+
+// AccessMode returns the access mode granted to a package
+func (g *Grants) AccessMode(packageType packages_model.Type, name string) perm.AccessMode {
+	return g.modes[grantKey{packageType, packages_model.ResolvePackageName(name, packageType)}]
+}
+
+// MaxAccessMode returns the highest access mode granted to any package
+func (g *Grants) MaxAccessMode() perm.AccessMode {
+	mode := perm.AccessModeNone
+	for _, m := range g.modes {
+		mode = max(mode, m)
+	}
+	return mode
+}
+
+type grantsContextKeyType struct{}
+
+// GrantsContextKey is the context key of the Grants a request is restricted to.
+// Package writes of such a request are only allowed to the packages granted write access.
+var GrantsContextKey = grantsContextKeyType{}
+
+// CheckWriteGranted returns ErrPackageAccessDenied if the package writes of the request are
+// restricted to granted packages and the package is not one of them
+func CheckWriteGranted(ctx context.Context, ownerID int64, packageType packages_model.Type, name string) error {
+	g, ok := ctx.Value(GrantsContextKey).(*Grants)
+	if !ok || g == nil {
+		return nil
+	}
+	if g.OwnerID == ownerID && g.AccessMode(packageType, name) >= perm.AccessModeWrite {
+		return nil
+	}
+	return ErrPackageAccessDenied
+}
+
+// checkVersionWriteGranted is CheckWriteGranted for the package of a version
+func checkVersionWriteGranted(ctx context.Context, pv *packages_model.PackageVersion) error {
+	if _, ok := ctx.Value(GrantsContextKey).(*Grants); !ok {
+		return nil
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	return CheckWriteGranted(ctx, p.OwnerID, p.Type, p.Name)
 }
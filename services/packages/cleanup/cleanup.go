@@ -6,6 +6,7 @@ package container
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"forgejo.org/models/db"
@@ -14,6 +15,7 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
 	packages_module "forgejo.org/modules/packages"
+	"forgejo.org/modules/timeutil"
 	packages_service "forgejo.org/services/packages"
 	alpine_service "forgejo.org/services/packages/alpine"
 	alt_service "forgejo.org/services/packages/alt"
@@ -30,6 +32,10 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 		return err
 	}
 
+	if _, err := CollectContainerGarbage(ctx, olderThan, false); err != nil {
+		return err
+	}
+
 	return CleanupExpiredData(ctx, olderThan)
 }
 
@@ -55,6 +61,9 @@ func ExecuteCleanupRules(outerCtx context.Context) error {
 		anyVersionDeleted := false
 		packageWithVersionDeleted := make(map[int64]bool) // set of Package.ID's where at least one package version was removed
 		for _, ct := range versionsToRemove {
+			if ct.Immutable {
+				continue
+			}
 			if err := packages_service.DeletePackageVersionAndReferences(ctx, ct.PackageVersion); err != nil {
 				return fmt.Errorf("CleanupRule [%d]: DeletePackageVersionAndReferences failed: %w", pcr.ID, err)
 			}
@@ -111,6 +120,9 @@ type CleanupTarget struct {
 	Package           *packages_model.Package
 	PackageVersion    *packages_model.PackageVersion
 	PackageDescriptor *packages_model.PackageDescriptor
+	LastDownload      timeutil.TimeStamp
+	// Immutable is set if the rule matches a release version of an immutable package type, which is kept
+	Immutable bool
 }
 
 func GetCleanupTargets(ctx context.Context, pcr *packages_model.PackageCleanupRule, skipPackageDescriptor bool) ([]*CleanupTarget, error) {
@@ -119,6 +131,13 @@ func GetCleanupTargets(ctx context.Context, pcr *packages_model.PackageCleanupRu
 	}
 
 	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
+	unusedSince := time.Now().AddDate(0, 0, -pcr.RemoveUnusedDays)
+
+	immutableTypes, err := packages_service.ImmutableTypes(ctx, pcr.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failure to get immutable package types for package cleanup rule: %w", err)
+	}
+	immutableType := slices.Contains(immutableTypes, pcr.Type)
 
 	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
 	if err != nil {
@@ -127,11 +146,17 @@ func GetCleanupTargets(ctx context.Context, pcr *packages_model.PackageCleanupRu
 
 	versionsToRemove := make([]*CleanupTarget, 0, 10)
 
+	var upstreamOnly optional.Option[bool]
+	if pcr.UpstreamOnly {
+		upstreamOnly = optional.Some(true)
+	}
+
 	for _, p := range packages {
 		pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
-			PackageID:  p.ID,
-			IsInternal: optional.Some(false),
-			Sort:       packages_model.SortCreatedDesc,
+			PackageID:    p.ID,
+			IsInternal:   optional.Some(false),
+			UpstreamOnly: upstreamOnly,
+			Sort:         packages_model.SortCreatedDesc,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failure to SearchVersions for package cleanup rule: %w", err)
@@ -170,7 +195,27 @@ func GetCleanupTargets(ctx context.Context, pcr *packages_model.PackageCleanupRu
 				continue
 			}
 
-			log.Debug("Rule[%d]: remove '%s/%s'", pcr.ID, p.Name, pv.Version)
+			var lastDownload timeutil.TimeStamp
+			if pcr.RemoveUnusedDays > 0 || !skipPackageDescriptor {
+				lastDownload, err = packages_model.GetVersionLastDownload(ctx, pv.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failure to GetVersionLastDownload for package cleanup rule: %w", err)
+				}
+			}
+			if pcr.RemoveUnusedDays > 0 {
+				lastUsed := max(pv.CreatedUnix, lastDownload)
+				if lastUsed.AsLocalTime().After(unusedSince) {
+					log.Debug("Rule[%d]: keep '%s/%s' (remove unused days)", pcr.ID, p.Name, pv.Version)
+					continue
+				}
+			}
+
+			immutable := immutableType && packages_service.IsReleaseVersion(pv.Version)
+			if immutable {
+				log.Debug("Rule[%d]: keep '%s/%s' (immutable)", pcr.ID, p.Name, pv.Version)
+			} else {
+				log.Debug("Rule[%d]: remove '%s/%s'", pcr.ID, p.Name, pv.Version)
+			}
 
 			var pd *packages_model.PackageDescriptor
 			// GetPackageDescriptor is a bit expensive and can be skipped; only used for cleanup preview to display the package to the UI
@@ -184,6 +229,8 @@ func GetCleanupTargets(ctx context.Context, pcr *packages_model.PackageCleanupRu
 				Package:           p,
 				PackageVersion:    pv,
 				PackageDescriptor: pd,
+				LastDownload:      lastDownload,
+				Immutable:         immutable,
 			})
 		}
 	}
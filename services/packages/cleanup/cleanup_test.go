@@ -10,8 +10,10 @@ import (
 	"forgejo.org/models/db"
 	"forgejo.org/models/packages"
 	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/timeutil"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -102,3 +104,85 @@ func TestGetCleanupTargets(t *testing.T) {
 		require.Equal(t, "0.1.0", targets[0].PackageVersion.LowerVersion)
 	})
 }
+
+func TestGetCleanupTargetsUnusedAndImmutable(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := db.DefaultContext
+
+	pcr := &packages.PackageCleanupRule{
+		Enabled:          true,
+		OwnerID:          2001,
+		Type:             packages.TypeGeneric,
+		RemoveUnusedDays: 90,
+	}
+	_, err := db.GetEngine(ctx).Insert(pcr)
+	require.NoError(t, err)
+
+	p := packages.Package{
+		OwnerID:   2001,
+		Name:      "unused",
+		LowerName: "unused",
+		Type:      packages.TypeGeneric,
+	}
+	_, err = db.GetEngine(ctx).Insert(&p)
+	require.NoError(t, err)
+
+	daysAgo := func(days int) timeutil.TimeStamp {
+		return timeutil.TimeStamp(time.Now().AddDate(0, 0, -days).Unix())
+	}
+
+	for _, c := range []struct {
+		Version      string
+		Created      timeutil.TimeStamp
+		LastDownload timeutil.TimeStamp
+	}{
+		{"1.0.0", daysAgo(120), daysAgo(100)},
+		{"1.1.0", daysAgo(110), daysAgo(1)},
+		{"1.2.0-rc1", daysAgo(100), 0},
+		{"1.3.0", daysAgo(10), 0},
+	} {
+		pv := packages.PackageVersion{
+			PackageID:    p.ID,
+			Version:      c.Version,
+			LowerVersion: c.Version,
+			CreatedUnix:  c.Created,
+		}
+		_, err = db.GetEngine(ctx).NoAutoTime().Insert(&pv)
+		require.NoError(t, err)
+
+		pf := packages.PackageFile{
+			VersionID:        pv.ID,
+			Name:             "file.bin",
+			LowerName:        "file.bin",
+			IsLead:           true,
+			CreatedUnix:      c.Created,
+			LastDownloadUnix: c.LastDownload,
+		}
+		_, err = db.GetEngine(ctx).NoAutoTime().Insert(&pf)
+		require.NoError(t, err)
+	}
+
+	getTargets := func(t *testing.T) map[string]bool {
+		t.Helper()
+
+		targets, err := GetCleanupTargets(ctx, pcr, true)
+		require.NoError(t, err)
+
+		immutable := make(map[string]bool, len(targets))
+		for _, ct := range targets {
+			immutable[ct.PackageVersion.Version] = ct.Immutable
+		}
+		return immutable
+	}
+
+	t.Run("removes versions not downloaded recently", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{"1.0.0": false, "1.2.0-rc1": false}, getTargets(t))
+	})
+
+	t.Run("keeps release versions of immutable types", func(t *testing.T) {
+		require.NoError(t, user_model.SetUserSetting(ctx, 2001, user_model.SettingsKeyPackagesImmutableTypes, string(packages.TypeGeneric)))
+
+		assert.Equal(t, map[string]bool{"1.0.0": true, "1.2.0-rc1": false}, getTargets(t))
+	})
+}
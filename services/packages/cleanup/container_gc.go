@@ -0,0 +1,268 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	container_model "forgejo.org/models/packages/container"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	container_module "forgejo.org/modules/packages/container"
+	"forgejo.org/modules/timeutil"
+	packages_service "forgejo.org/services/packages"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ContainerGarbage is an untagged container manifest which is not reachable from a tag
+type ContainerGarbage struct {
+	Owner   *user_model.User
+	Package *packages_model.Package
+	Version *packages_model.PackageVersion
+	// Size is the size of the manifest itself
+	Size int64
+	// Subject is the digest of the manifest the garbage refers to, if it is a signature, SBOM or other referrer
+	Subject string
+}
+
+// ContainerGarbageReport describes the result of a container garbage collection run
+type ContainerGarbageReport struct {
+	DryRun    bool
+	Manifests []*ContainerGarbage
+	// Blobs is the number of blobs which are no longer referenced after removing the manifests
+	Blobs     int
+	BlobsSize int64
+}
+
+// CollectContainerGarbage runs a mark-and-sweep garbage collection over all container images.
+// Tags and manifests created within olderThan are the roots. Everything reachable from them through
+// index manifests or as a referrer of a reachable manifest is kept, all other manifests are removed.
+// The blobs no longer referenced afterwards are removed by CleanupExpiredData.
+// If dryRun is set nothing is removed and the report describes what would be removed.
+func CollectContainerGarbage(outerCtx context.Context, olderThan time.Duration, dryRun bool) (*ContainerGarbageReport, error) {
+	ctx, committer, err := db.TxContext(outerCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer committer.Close()
+
+	ps, err := packages_model.GetAllPackagesByType(ctx, packages_model.TypeContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	old := timeutil.TimeStamp(time.Now().Add(-olderThan).Unix())
+
+	report := &ContainerGarbageReport{DryRun: dryRun}
+	owners := make(map[int64]*user_model.User)
+	for _, p := range ps {
+		select {
+		case <-outerCtx.Done():
+			return nil, db.ErrCancelledf("While collecting container garbage")
+		default:
+		}
+
+		garbage, err := findContainerGarbage(ctx, p, old)
+		if err != nil {
+			return nil, fmt.Errorf("Image [%d]: %w", p.ID, err)
+		}
+		if len(garbage) == 0 {
+			continue
+		}
+
+		owner, ok := owners[p.OwnerID]
+		if !ok {
+			owner, err = user_model.GetPossibleUserByID(ctx, p.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			owners[p.OwnerID] = owner
+		}
+		for _, g := range garbage {
+			g.Owner = owner
+		}
+
+		report.Manifests = append(report.Manifests, garbage...)
+	}
+
+	// Count the references held by the garbage to find the blobs which become unreferenced
+	removedReferences := make(map[int64]int64)
+	blobSizes := make(map[int64]int64)
+	for _, g := range report.Manifests {
+		pfs, err := packages_model.GetFilesByVersionID(ctx, g.Version.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, pf := range pfs {
+			if _, ok := blobSizes[pf.BlobID]; !ok {
+				pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+				if err != nil {
+					return nil, err
+				}
+				blobSizes[pf.BlobID] = pb.Size
+			}
+			removedReferences[pf.BlobID]++
+		}
+	}
+	for blobID, removed := range removedReferences {
+		count, err := packages_model.CountBlobReferences(ctx, blobID)
+		if err != nil {
+			return nil, err
+		}
+		if count <= removed {
+			report.Blobs++
+			report.BlobsSize += blobSizes[blobID]
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	for _, g := range report.Manifests {
+		log.Debug("Container garbage collection: remove '%s/%s'", g.Package.Name, g.Version.Version)
+		if err := packages_service.DeletePackageVersionAndReferences(ctx, g.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := committer.Commit(); err != nil {
+		return nil, err
+	}
+
+	if len(report.Manifests) > 0 {
+		log.Info("Container garbage collection removed %d manifest(s), %d blob(s) with %d bytes are no longer referenced", len(report.Manifests), report.Blobs, report.BlobsSize)
+	}
+
+	return report, nil
+}
+
+type containerManifestNode struct {
+	version   *packages_model.PackageVersion
+	digest    string
+	size      int64
+	subject   string
+	manifests []string
+}
+
+// findContainerGarbage marks all manifests of an image reachable from the roots and returns the others
+func findContainerGarbage(ctx context.Context, p *packages_model.Package, old timeutil.TimeStamp) ([]*ContainerGarbage, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		PackageID:  p.ID,
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, nil
+	}
+
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    p.OwnerID,
+		Image:      p.LowerName,
+		IsManifest: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int64]*containerManifestNode, len(pvs))
+	for _, pv := range pvs {
+		n := &containerManifestNode{version: pv}
+
+		if pv.MetadataJSON != "" {
+			var metadata container_module.Metadata
+			if err := json.Unmarshal([]byte(pv.MetadataJSON), &metadata); err != nil {
+				// The references of the manifest are unknown, so nothing of the image can be removed safely
+				log.Error("Container garbage collection: skip image %d, package_version.id = %d has invalid metadata: %v", p.ID, pv.ID, err)
+				return nil, nil
+			}
+			for _, m := range metadata.Manifests {
+				n.manifests = append(n.manifests, m.Digest)
+			}
+		}
+
+		pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+		if err != nil {
+			return nil, err
+		}
+		if len(pps) > 0 {
+			n.subject = pps[0].Value
+		}
+
+		nodes[pv.ID] = n
+	}
+
+	byDigest := make(map[string][]*containerManifestNode, len(pfds))
+	for _, pfd := range pfds {
+		n, ok := nodes[pfd.File.VersionID]
+		if !ok {
+			continue
+		}
+		n.digest = pfd.Properties.GetByName(container_module.PropertyDigest)
+		n.size = pfd.Blob.Size
+		byDigest[n.digest] = append(byDigest[n.digest], n)
+	}
+
+	referrers := make(map[string][]*containerManifestNode)
+	for _, n := range nodes {
+		if n.subject != "" {
+			referrers[n.subject] = append(referrers[n.subject], n)
+		}
+	}
+
+	marked := make(map[int64]bool, len(nodes))
+	queue := make([]*containerManifestNode, 0, len(nodes))
+	mark := func(n *containerManifestNode) {
+		if !marked[n.version.ID] {
+			marked[n.version.ID] = true
+			queue = append(queue, n)
+		}
+	}
+
+	for _, n := range nodes {
+		// Tags, manifests without a digest and recently pushed manifests are roots.
+		// The latter are not referenced yet if an index or tag is pushed afterwards.
+		if digest.Digest(n.version.LowerVersion).Validate() != nil || n.digest == "" || n.version.CreatedUnix >= old {
+			mark(n)
+		}
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for _, d := range n.manifests {
+			for _, child := range byDigest[d] {
+				mark(child)
+			}
+		}
+		for _, referrer := range referrers[n.digest] {
+			mark(referrer)
+		}
+	}
+
+	garbage := make([]*ContainerGarbage, 0, len(nodes)-len(marked))
+	for _, pv := range pvs {
+		if marked[pv.ID] {
+			continue
+		}
+		n := nodes[pv.ID]
+		garbage = append(garbage, &ContainerGarbage{
+			Package: p,
+			Version: pv,
+			Size:    n.size,
+			Subject: n.subject,
+		})
+	}
+	return garbage, nil
+}
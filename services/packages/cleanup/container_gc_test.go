@@ -0,0 +1,155 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package container
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/packages"
+	container_model "forgejo.org/models/packages/container"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/json"
+	container_module "forgejo.org/modules/packages/container"
+	"forgejo.org/modules/timeutil"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectContainerGarbage(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	ctx := db.DefaultContext
+
+	p := &packages.Package{
+		OwnerID:   2,
+		Name:      "gc",
+		LowerName: "gc",
+		Type:      packages.TypeContainer,
+	}
+	_, err := db.GetEngine(ctx).Insert(p)
+	require.NoError(t, err)
+
+	createManifest := func(t *testing.T, version, name, subject string, manifests []string, created timeutil.TimeStamp) string {
+		t.Helper()
+
+		manifestDigest := digest.FromString(name)
+		if version == "" {
+			version = manifestDigest.String()
+		}
+
+		metadata := container_module.Metadata{}
+		for _, m := range manifests {
+			metadata.Manifests = append(metadata.Manifests, &container_module.Manifest{Digest: m})
+		}
+		metadataJSON, err := json.Marshal(metadata)
+		require.NoError(t, err)
+
+		pv := &packages.PackageVersion{
+			PackageID:    p.ID,
+			Version:      version,
+			LowerVersion: version,
+			MetadataJSON: string(metadataJSON),
+			CreatedUnix:  created,
+		}
+		_, err = db.GetEngine(ctx).NoAutoTime().Insert(pv)
+		require.NoError(t, err)
+
+		hash := manifestDigest.Encoded()
+		pb, _, err := packages.GetOrInsertBlob(ctx, &packages.PackageBlob{
+			Size:       int64(len(name)),
+			HashMD5:    hash[:32],
+			HashSHA1:   hash[:40],
+			HashSHA256: hash,
+			HashSHA512: hash + hash,
+		})
+		require.NoError(t, err)
+
+		pf, err := packages.TryInsertFile(ctx, &packages.PackageFile{
+			VersionID: pv.ID,
+			BlobID:    pb.ID,
+			Name:      container_model.ManifestFilename,
+			LowerName: container_model.ManifestFilename,
+			IsLead:    true,
+		})
+		require.NoError(t, err)
+
+		_, err = packages.InsertProperty(ctx, packages.PropertyTypeFile, pf.ID, container_module.PropertyDigest, manifestDigest.String())
+		require.NoError(t, err)
+		if subject != "" {
+			_, err = packages.InsertProperty(ctx, packages.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, subject)
+			require.NoError(t, err)
+		}
+
+		return manifestDigest.String()
+	}
+
+	olderThan := time.Hour
+	createdLongAgo := timeutil.TimeStamp(time.Now().Add(-2 * olderThan).Unix())
+	createdRecently := timeutil.TimeStamp(time.Now().Add(-olderThan / 2).Unix())
+
+	tagged := createManifest(t, "latest", "tagged", "", nil, createdLongAgo)
+	child := createManifest(t, "", "child", "", nil, createdLongAgo)
+	createManifest(t, "multi", "index", "", []string{child}, createdLongAgo)
+	orphan := createManifest(t, "", "orphan", "", nil, createdLongAgo)
+	signature := createManifest(t, "", "signature", tagged, nil, createdLongAgo)
+	orphanSignature := createManifest(t, "", "orphan-signature", orphan, nil, createdLongAgo)
+	recent := createManifest(t, "", "recent", "", nil, createdRecently)
+
+	versionExists := func(t *testing.T, version string) bool {
+		t.Helper()
+
+		has, err := db.GetEngine(ctx).Where("package_id = ? AND lower_version = ?", p.ID, version).Exist(&packages.PackageVersion{})
+		require.NoError(t, err)
+		return has
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		report, err := CollectContainerGarbage(ctx, olderThan, true)
+		require.NoError(t, err)
+
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Manifests, 2)
+		versions := []string{report.Manifests[0].Version.Version, report.Manifests[1].Version.Version}
+		assert.ElementsMatch(t, []string{orphan, orphanSignature}, versions)
+		for _, g := range report.Manifests {
+			assert.Equal(t, "user2", g.Owner.Name)
+			if g.Version.Version == orphanSignature {
+				assert.Equal(t, orphan, g.Subject)
+			}
+		}
+		assert.Equal(t, 2, report.Blobs)
+		assert.EqualValues(t, len("orphan")+len("orphan-signature"), report.BlobsSize)
+
+		assert.True(t, versionExists(t, orphan))
+		assert.True(t, versionExists(t, orphanSignature))
+	})
+
+	t.Run("Collect", func(t *testing.T) {
+		report, err := CollectContainerGarbage(ctx, olderThan, false)
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		assert.Len(t, report.Manifests, 2)
+
+		assert.False(t, versionExists(t, orphan))
+		assert.False(t, versionExists(t, orphanSignature))
+		for _, version := range []string{"latest", "multi", child, signature, recent} {
+			assert.True(t, versionExists(t, version), version)
+		}
+
+		report, err = CollectContainerGarbage(ctx, olderThan, true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Manifests)
+	})
+
+	t.Run("RecentManifestsExpire", func(t *testing.T) {
+		report, err := CollectContainerGarbage(ctx, 0, false)
+		require.NoError(t, err)
+		require.Len(t, report.Manifests, 1)
+		assert.Equal(t, recent, report.Manifests[0].Version.Version)
+	})
+}
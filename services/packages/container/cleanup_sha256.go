@@ -97,6 +97,29 @@ func cleanupSHA256(outerCtx context.Context, olderThan time.Duration) error {
 		delete(shaToPackageVersion, sha)
 	}
 
+	// Referrers (signatures, SBOMs, ...) are untagged but depend on the
+	// image they refer to and not on an index manifest. They are left to
+	// the garbage collector which removes them together with their subject.
+	var referrerIDs []int64
+	if err := db.GetEngine(ctx).
+		Select("`ref_id`").
+		Table("package_property").
+		Where("`ref_type` = ? AND `name` = ?", packages.PropertyTypeVersion, container_module.PropertyManifestSubject).
+		Find(&referrerIDs); err != nil {
+		return err
+	}
+	if len(referrerIDs) > 0 {
+		isReferrer := make(map[int64]bool, len(referrerIDs))
+		for _, id := range referrerIDs {
+			isReferrer[id] = true
+		}
+		for sha, p := range shaToPackageVersion {
+			if isReferrer[p.id] {
+				delete(shaToPackageVersion, sha)
+			}
+		}
+	}
+
 	if len(shaToPackageVersion) == 0 {
 		if foundAtLeastOneSHA256 {
 			log.Debug("All container images with a version matching sha256:* are referenced by an index manifest")
@@ -246,6 +246,7 @@ func buildPackagesIndices(ctx context.Context, ownerID int64, repoVersion *packa
 				Creator:           user_model.NewGhostUser(),
 				Data:              file.Data,
 				IsLead:            false,
+				Generated:         true,
 				OverwriteExisting: true,
 				Properties: map[string]string{
 					debian_module.PropertyRepositoryIncludeInRelease: "",
@@ -398,6 +399,7 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 				Creator:           user_model.NewGhostUser(),
 				Data:              file.Data,
 				IsLead:            false,
+				Generated:         true,
 				OverwriteExisting: true,
 				Properties: map[string]string{
 					debian_module.PropertyDistribution: distribution,
@@ -0,0 +1,66 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package hex
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	system_model "forgejo.org/models/system"
+	hex_module "forgejo.org/modules/packages/hex"
+	"forgejo.org/modules/util"
+)
+
+// GetOrCreateKeyPair gets or creates the RSA keys used to sign the registry resources.
+// The keys belong to the instance, the repositories of all owners are signed with the same key.
+func GetOrCreateKeyPair(ctx context.Context) (string, string, error) {
+	priv, err := system_model.GetAppStateContent(ctx, hex_module.SettingKeyPrivate)
+	if err != nil {
+		return "", "", err
+	}
+
+	pub, err := system_model.GetAppStateContent(ctx, hex_module.SettingKeyPublic)
+	if err != nil {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = util.GenerateKeyPair(4096)
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := system_model.SaveAppStateContent(ctx, hex_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := system_model.SaveAppStateContent(ctx, hex_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+// SignResource signs and compresses a registry resource with the key of the instance
+func SignResource(ctx context.Context, payload []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(priv))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex_module.SignResource(payload, key)
+}
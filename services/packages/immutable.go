@@ -0,0 +1,99 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ErrImmutableVersion indicates that a release version of an immutable package type should be deleted or overwritten
+var ErrImmutableVersion = util.NewPermissionDeniedErrorf("release versions of this package type are immutable")
+
+// ImmutableTypes returns the package types of the owner whose release versions can not be deleted or overwritten
+func ImmutableTypes(ctx context.Context, ownerID int64) ([]packages_model.Type, error) {
+	value, err := user_model.GetUserSetting(ctx, ownerID, user_model.SettingsKeyPackagesImmutableTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]packages_model.Type, 0, len(setting.Packages.ImmutableTypes))
+	for _, t := range append(slices.Clone(setting.Packages.ImmutableTypes), strings.Split(value, ",")...) {
+		pt := packages_model.Type(strings.ToLower(strings.TrimSpace(t)))
+		if pt == "" || slices.Contains(types, pt) {
+			continue
+		}
+		types = append(types, pt)
+	}
+	return types, nil
+}
+
+// SetImmutableTypes sets the package types of the owner whose release versions can not be deleted or overwritten
+func SetImmutableTypes(ctx context.Context, owner *user_model.User, types []packages_model.Type) error {
+	values := make([]string, 0, len(types))
+	for _, t := range types {
+		if !slices.Contains(packages_model.TypeList, t) {
+			return util.NewInvalidArgumentErrorf("invalid package type: %s", t)
+		}
+		values = append(values, string(t))
+	}
+	return user_model.SetUserSetting(ctx, owner.ID, user_model.SettingsKeyPackagesImmutableTypes, strings.Join(values, ","))
+}
+
+// IsReleaseVersion checks if a version is a release and not a prerelease or snapshot
+func IsReleaseVersion(v string) bool {
+	if strings.Contains(strings.ToLower(v), "snapshot") {
+		return false
+	}
+	if sv, err := version.NewSemver(v); err == nil {
+		return sv.Prerelease() == ""
+	}
+	return true
+}
+
+// IsImmutableVersion checks if a version of a package of the owner can not be deleted or overwritten
+func IsImmutableVersion(ctx context.Context, ownerID int64, packageType packages_model.Type, pv *packages_model.PackageVersion) (bool, error) {
+	if pv.IsInternal || !IsReleaseVersion(pv.Version) {
+		return false, nil
+	}
+	types, err := ImmutableTypes(ctx, ownerID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(types, packageType), nil
+}
+
+// CheckVersionMutable returns ErrImmutableVersion if the version can not be deleted or overwritten
+// and ErrPackageAccessDenied if the doer was not granted write access to the package
+func CheckVersionMutable(ctx context.Context, pv *packages_model.PackageVersion) error {
+	if pv.IsInternal {
+		return nil
+	}
+	if err := checkVersionWriteGranted(ctx, pv); err != nil {
+		return err
+	}
+	if !IsReleaseVersion(pv.Version) {
+		return nil
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	immutable, err := IsImmutableVersion(ctx, p.OwnerID, p.Type, pv)
+	if err != nil {
+		return err
+	}
+	if immutable {
+		return ErrImmutableVersion
+	}
+	return nil
+}
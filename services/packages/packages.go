@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 
+	advisory_model "forgejo.org/models/advisory"
 	"forgejo.org/models/db"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -47,6 +48,8 @@ type PackageCreationInfo struct {
 	Metadata          any
 	PackageProperties map[string]string
 	VersionProperties map[string]string
+	// UpstreamOnly marks a version cached from an upstream remote
+	UpstreamOnly bool
 }
 
 // PackageFileInfo describes a package file
@@ -58,9 +61,15 @@ type PackageFileInfo struct {
 // PackageFileCreationInfo describes a package file to create
 type PackageFileCreationInfo struct {
 	PackageFileInfo
-	Creator           *user_model.User
-	Data              packages_module.HashedSizeReader
-	IsLead            bool
+	Creator *user_model.User
+	Data    packages_module.HashedSizeReader
+	IsLead  bool
+	// RequireSignature subjects a file which is not the lead file to the signature policy of the owner,
+	// like the binary packages of a Conan recipe
+	RequireSignature bool
+	// Generated marks the checksum lists and indexes rebuilt by the server or the client from the other
+	// files of the version, which can still be overwritten once the version is immutable
+	Generated         bool
 	Properties        map[string]string
 	OverwriteExisting bool
 }
@@ -122,6 +131,10 @@ func createPackageAndAddFile(ctx context.Context, pvci *PackageCreationInfo, pfc
 func createPackageAndVersion(ctx context.Context, pvci *PackageCreationInfo, allowDuplicate bool) (*packages_model.PackageVersion, bool, error) {
 	log.Trace("Creating package: %v, %v, %v, %s, %s, %+v, %+v, %v", pvci.Creator.ID, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version, pvci.PackageProperties, pvci.VersionProperties, allowDuplicate)
 
+	if err := CheckWriteGranted(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name); err != nil {
+		return nil, false, err
+	}
+
 	packageCreated := true
 	p := &packages_model.Package{
 		OwnerID:          pvci.Owner.ID,
@@ -161,6 +174,7 @@ func createPackageAndVersion(ctx context.Context, pvci *PackageCreationInfo, all
 		Version:      pvci.Version,
 		LowerVersion: strings.ToLower(pvci.Version),
 		MetadataJSON: string(metadataJSON),
+		UpstreamOnly: pvci.UpstreamOnly,
 	}
 	if pv, err = packages_model.GetOrInsertVersion(ctx, pv); err != nil {
 		if err == packages_model.ErrDuplicatePackageVersion {
@@ -257,11 +271,22 @@ func NewPackageBlob(hsr packages_module.HashedSizeReader) *packages_model.Packag
 }
 
 func addFileToPackageVersion(ctx context.Context, pv *packages_model.PackageVersion, pvi *PackageInfo, pfci *PackageFileCreationInfo) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
+	if err := CheckWriteGranted(ctx, pvi.Owner.ID, pvi.PackageType, pvi.Name); err != nil {
+		return nil, nil, false, err
+	}
 	if err := CheckSizeQuotaExceeded(ctx, pfci.Creator, pvi.Owner, pvi.PackageType, pfci.Data.Size()); err != nil {
 		return nil, nil, false, err
 	}
 
-	return addFileToPackageVersionUnchecked(ctx, pv, pfci, pvi.PackageType)
+	pf, pb, blobCreated, err := addFileToPackageVersionUnchecked(ctx, pv, pfci, pvi.PackageType)
+	if err != nil || !(pfci.IsLead || pfci.RequireSignature) {
+		return pf, pb, blobCreated, err
+	}
+
+	if err := VerifyUploadSignature(ctx, pvi.Owner, pfci.Creator, pv, pf, pb); err != nil {
+		return nil, pb, blobCreated, err
+	}
+	return pf, pb, blobCreated, nil
 }
 
 func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.PackageVersion, pfci *PackageFileCreationInfo, packageType packages_model.Type) (*packages_model.PackageFile, *packages_model.PackageBlob, bool, error) {
@@ -291,9 +316,19 @@ func addFileToPackageVersionUnchecked(ctx context.Context, pv *packages_model.Pa
 				return pf, pb, !exists, nil
 			}
 
+			// generated files like checksum lists of a version are rebuilt when files are added
+			if !pfci.Generated {
+				if err := CheckVersionMutable(ctx, pv); err != nil {
+					return nil, pb, !exists, err
+				}
+			}
+
 			if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
+			if err := packages_model.DeleteAttestationsByFileID(ctx, pf.ID); err != nil {
+				return nil, pb, !exists, err
+			}
 			if err := packages_model.DeleteFileByID(ctx, pf.ID); err != nil {
 				return nil, pb, !exists, err
 			}
@@ -356,73 +391,101 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		return nil
 	}
 
-	var typeSpecificSize int64
+	typeSpecificSize := TypeSizeLimit(packageType)
+	if typeSpecificSize > -1 && typeSpecificSize < uploadSize {
+		return ErrQuotaTypeSize
+	}
+
+	if setting.Packages.LimitTotalOwnerSize > -1 {
+		totalSize, err := packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
+			OwnerID: owner.ID,
+		})
+		if err != nil {
+			log.Error("CalculateFileSize failed: %v", err)
+			return err
+		}
+		if totalSize+uploadSize > setting.Packages.LimitTotalOwnerSize {
+			return ErrQuotaTotalSize
+		}
+	}
+
+	return nil
+}
+
+// TypeSizeLimit returns the maximum size of a file of the package type, -1 if it is unlimited
+func TypeSizeLimit(packageType packages_model.Type) int64 {
 	switch packageType {
 	case packages_model.TypeAlpine:
-		typeSpecificSize = setting.Packages.LimitSizeAlpine
+		return setting.Packages.LimitSizeAlpine
 	case packages_model.TypeArch:
-		typeSpecificSize = setting.Packages.LimitSizeArch
+		return setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
-		typeSpecificSize = setting.Packages.LimitSizeCargo
+		return setting.Packages.LimitSizeCargo
 	case packages_model.TypeChef:
-		typeSpecificSize = setting.Packages.LimitSizeChef
+		return setting.Packages.LimitSizeChef
 	case packages_model.TypeComposer:
-		typeSpecificSize = setting.Packages.LimitSizeComposer
+		return setting.Packages.LimitSizeComposer
 	case packages_model.TypeConan:
-		typeSpecificSize = setting.Packages.LimitSizeConan
+		return setting.Packages.LimitSizeConan
 	case packages_model.TypeConda:
-		typeSpecificSize = setting.Packages.LimitSizeConda
+		return setting.Packages.LimitSizeConda
 	case packages_model.TypeContainer:
-		typeSpecificSize = setting.Packages.LimitSizeContainer
+		return setting.Packages.LimitSizeContainer
 	case packages_model.TypeCran:
-		typeSpecificSize = setting.Packages.LimitSizeCran
+		return setting.Packages.LimitSizeCran
 	case packages_model.TypeDebian:
-		typeSpecificSize = setting.Packages.LimitSizeDebian
+		return setting.Packages.LimitSizeDebian
 	case packages_model.TypeGeneric:
-		typeSpecificSize = setting.Packages.LimitSizeGeneric
+		return setting.Packages.LimitSizeGeneric
 	case packages_model.TypeGo:
-		typeSpecificSize = setting.Packages.LimitSizeGo
+		return setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
-		typeSpecificSize = setting.Packages.LimitSizeHelm
+		return setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		return setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
-		typeSpecificSize = setting.Packages.LimitSizeMaven
+		return setting.Packages.LimitSizeMaven
 	case packages_model.TypeNpm:
-		typeSpecificSize = setting.Packages.LimitSizeNpm
+		return setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
-		typeSpecificSize = setting.Packages.LimitSizeNuGet
+		return setting.Packages.LimitSizeNuGet
 	case packages_model.TypePub:
-		typeSpecificSize = setting.Packages.LimitSizePub
+		return setting.Packages.LimitSizePub
 	case packages_model.TypePyPI:
-		typeSpecificSize = setting.Packages.LimitSizePyPI
+		return setting.Packages.LimitSizePyPI
 	case packages_model.TypeRpm:
-		typeSpecificSize = setting.Packages.LimitSizeRpm
+		return setting.Packages.LimitSizeRpm
 	case packages_model.TypeAlt:
-		typeSpecificSize = setting.Packages.LimitSizeAlt
+		return setting.Packages.LimitSizeAlt
 	case packages_model.TypeRubyGems:
-		typeSpecificSize = setting.Packages.LimitSizeRubyGems
+		return setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
-		typeSpecificSize = setting.Packages.LimitSizeSwift
+		return setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		return setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
-		typeSpecificSize = setting.Packages.LimitSizeVagrant
-	}
-	if typeSpecificSize > -1 && typeSpecificSize < uploadSize {
-		return ErrQuotaTypeSize
+		return setting.Packages.LimitSizeVagrant
 	}
+	return 0
+}
 
+// SizeLimit returns the maximum size of a file of the package type the owner can still store,
+// -1 if it is unlimited
+func SizeLimit(ctx context.Context, ownerID int64, packageType packages_model.Type) (int64, error) {
+	limit := TypeSizeLimit(packageType)
 	if setting.Packages.LimitTotalOwnerSize > -1 {
 		totalSize, err := packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
-			OwnerID: owner.ID,
+			OwnerID: ownerID,
 		})
 		if err != nil {
-			log.Error("CalculateFileSize failed: %v", err)
-			return err
+			return 0, err
 		}
-		if totalSize+uploadSize > setting.Packages.LimitTotalOwnerSize {
-			return ErrQuotaTotalSize
+		remaining := max(setting.Packages.LimitTotalOwnerSize-totalSize, 0)
+		if limit == -1 || remaining < limit {
+			limit = remaining
 		}
 	}
-
-	return nil
+	return limit, nil
 }
 
 // GetOrCreateInternalPackageVersion gets or creates an internal package
@@ -483,6 +546,10 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 	}
 	defer committer.Close()
 
+	if err := CheckVersionMutable(dbCtx, pv); err != nil {
+		return err
+	}
+
 	pd, err := packages_model.GetPackageDescriptor(dbCtx, pv)
 	if err != nil {
 		return err
@@ -508,6 +575,14 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 	var pd *packages_model.PackageDescriptor
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+		if err != nil {
+			return err
+		}
+		if err := CheckVersionMutable(ctx, pv); err != nil {
+			return err
+		}
+
 		if err := DeletePackageFile(ctx, pf); err != nil {
 			return err
 		}
@@ -517,11 +592,6 @@ func RemovePackageFileAndVersionIfUnreferenced(ctx context.Context, doer *user_m
 			return err
 		}
 		if !has {
-			pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
-			if err != nil {
-				return err
-			}
-
 			pd, err = packages_model.GetPackageDescriptor(ctx, pv)
 			if err != nil {
 				return err
@@ -561,14 +631,25 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		}
 	}
 
+	if err := packages_model.DeleteAttestationsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
+	if err := advisory_model.DeleteAlertsByPackageVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	return packages_model.DeleteVersionByID(ctx, pv.ID)
 }
 
-// DeletePackageFile deletes the package file and its properties
+// DeletePackageFile deletes the package file, its properties and signatures
 func DeletePackageFile(ctx context.Context, pf *packages_model.PackageFile) error {
 	if err := packages_model.DeleteAllProperties(ctx, packages_model.PropertyTypeFile, pf.ID); err != nil {
 		return err
 	}
+	if err := packages_model.DeleteAttestationsByFileID(ctx, pf.ID); err != nil {
+		return err
+	}
 	return packages_model.DeleteFileByID(ctx, pf.ID)
 }
 
@@ -635,6 +716,9 @@ func GetPackageBlobStream(ctx context.Context, pf *packages_model.PackageFile, p
 				log.Error("Error incrementing download counter: %v", err)
 			}
 		}
+		if err := packages_model.UpdateFileLastDownload(ctx, pf.ID); err != nil {
+			log.Error("Error updating last download time: %v", err)
+		}
 	}
 	return s, u, pf, err
 }
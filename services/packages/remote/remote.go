@@ -0,0 +1,236 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	packages_module "forgejo.org/modules/packages"
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	packages_service "forgejo.org/services/packages"
+)
+
+// ErrNotFound is returned if the upstream remote does not have the requested file
+var ErrNotFound = util.NewNotExistErrorf("file does not exist upstream")
+
+// metadataSizeLimit is the maximum size of a package index fetched from an upstream remote
+const metadataSizeLimit = 64 * 1024 * 1024
+
+// GetRemote gets the upstream remote of the packages of a type of an owner, nil if there is none
+func GetRemote(ctx context.Context, owner *user_model.User, packageType packages_model.Type) (*packages_model.PackageRemote, error) {
+	if owner == nil || !packageType.SupportsRemote() {
+		return nil, nil
+	}
+	r, err := packages_model.GetRemoteByOwnerAndType(ctx, owner.ID, packageType)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetRemoteForPackage gets the upstream remote to fetch a package from, nil if there is none.
+// Packages published to the owner shadow the remote, a package is never mixed from both sources.
+func GetRemoteForPackage(ctx context.Context, owner *user_model.User, packageType packages_model.Type, name string) (*packages_model.PackageRemote, error) {
+	r, err := GetRemote(ctx, owner, packageType)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	published, err := packages_model.ExistVersion(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: owner.ID,
+		Type:    packageType,
+		Name: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      name,
+		},
+		IsInternal:   optional.Some(false),
+		UpstreamOnly: optional.Some(false),
+	})
+	if err != nil || published {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ResolveURL resolves a path relative to the URL of the remote
+func ResolveURL(r *packages_model.PackageRemote, p string) string {
+	return strings.TrimSuffix(r.URL, "/") + "/" + strings.TrimPrefix(p, "/")
+}
+
+func newClient() *http.Client {
+	allowedHostListValue := setting.Packages.RemoteAllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowList := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", allowedHostListValue)
+
+	return &http.Client{
+		Timeout: setting.Packages.RemoteTimeout,
+		Transport: &http.Transport{
+			Proxy:       proxy.Proxy(),
+			DialContext: hostmatcher.NewDialContext("package remote", allowList, nil, setting.Proxy.ProxyURLFixed),
+		},
+	}
+}
+
+// open requests an absolute URL, the credentials of the remote are only sent to its own host
+func open(ctx context.Context, r *packages_model.PackageRemote, rawURL, accept string) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if r.Username != "" {
+		if remoteURL, err := url.Parse(r.URL); err == nil && remoteURL.Host == u.Host {
+			password, err := r.Password()
+			if err != nil {
+				return nil, err
+			}
+			req.SetBasicAuth(r.Username, password)
+		}
+	}
+
+	resp, err := newClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code from %s: %d", u.Redacted(), resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// GetMetadata gets a package index from the path of the remote. The index is cached for the TTL
+// of the remote, and the cached index is served if the remote is not reachable.
+func GetMetadata(ctx context.Context, r *packages_model.PackageRemote, p, accept string) ([]byte, error) {
+	cached, err := packages_model.GetRemoteMetadata(ctx, r.ID, p)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && cached.IsFresh(r.TTL()) {
+		return []byte(cached.Content), nil
+	}
+
+	content, err := fetchMetadata(ctx, r, p, accept)
+	if err != nil {
+		if cached != nil && !errors.Is(err, ErrNotFound) {
+			log.Warn("Unable to refresh the metadata %s of the package remote %d, serving the cached one: %v", p, r.ID, err)
+			return []byte(cached.Content), nil
+		}
+		return nil, err
+	}
+
+	if _, err := packages_model.SetRemoteMetadata(ctx, r.ID, p, string(content)); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func fetchMetadata(ctx context.Context, r *packages_model.PackageRemote, p, accept string) ([]byte, error) {
+	body, err := open(ctx, r, ResolveURL(r, p), accept)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, metadataSizeLimit+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > metadataSizeLimit {
+		return nil, fmt.Errorf("metadata %s is bigger than %d bytes", p, metadataSizeLimit)
+	}
+	return content, nil
+}
+
+// FetchFile downloads a file from an absolute URL, or a path relative to the URL of the remote.
+// The download fails if the file is bigger than the size limit of the package type or the remaining quota of the owner.
+func FetchFile(ctx context.Context, r *packages_model.PackageRemote, fileURL string) (*packages_module.HashedBuffer, error) {
+	if !strings.Contains(fileURL, "://") {
+		fileURL = ResolveURL(r, fileURL)
+	}
+
+	limit, err := packages_service.SizeLimit(ctx, r.OwnerID, r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := open(ctx, r, fileURL, "")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var reader io.Reader = body
+	if limit > -1 {
+		reader = io.LimitReader(body, limit+1)
+	}
+	buf, err := packages_module.CreateHashedBufferFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size := buf.Size(); limit > -1 && size > limit {
+		buf.Close()
+		if typeLimit := packages_service.TypeSizeLimit(r.Type); typeLimit > -1 && size > typeLimit {
+			return nil, packages_service.ErrQuotaTypeSize
+		}
+		return nil, packages_service.ErrQuotaTotalSize
+	}
+	return buf, nil
+}
+
+// ErrorStatus returns the status code of a failure to cache a file of an upstream remote.
+// The quotas of the owner are reported like for uploads, the other errors with otherStatus.
+func ErrorStatus(err error, otherStatus int) int {
+	switch {
+	case errors.Is(err, packages_service.ErrQuotaTypeSize):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, packages_service.ErrQuotaTotalSize), errors.Is(err, packages_service.ErrQuotaTotalCount):
+		return http.StatusForbidden
+	default:
+		return otherStatus
+	}
+}
+
+// StoreFile stores a file fetched from an upstream remote as a file of a version of the owner.
+// The version is created if needed and flagged as cached from upstream.
+func StoreFile(ctx context.Context, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
+	creator := user_model.NewGhostUser()
+	pvci.Creator = creator
+	pvci.UpstreamOnly = true
+	pfci.Creator = creator
+	pfci.OverwriteExisting = true
+	return packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci)
+}
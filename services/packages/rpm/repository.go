@@ -318,6 +318,7 @@ func buildRepomd(ctx context.Context, pv *packages_model.PackageVersion, ownerID
 				Creator:           user_model.NewGhostUser(),
 				Data:              file.Data,
 				IsLead:            false,
+				Generated:         true,
 				OverwriteExisting: true,
 			},
 		)
@@ -614,6 +615,7 @@ func addDataAsFileToRepo(ctx context.Context, pv *packages_model.PackageVersion,
 			Creator:           user_model.NewGhostUser(),
 			Data:              content,
 			IsLead:            false,
+			Generated:         true,
 			OverwriteExisting: true,
 		},
 	)
@@ -0,0 +1,183 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	packages_model "forgejo.org/models/packages"
+	user_model "forgejo.org/models/user"
+	packages_module "forgejo.org/modules/packages"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/util"
+	packages_service "forgejo.org/services/packages"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// GetOrCreateKeyPair gets or creates the PGP keys used to sign the checksums of provider versions
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, ownerID, terraform_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, ownerID, terraform_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = generateKeypair()
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, terraform_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, terraform_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+func generateKeypair() (string, string, error) {
+	e, err := openpgp.NewEntity("", "Terraform Registry", "", nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	var priv strings.Builder
+	var pub strings.Builder
+
+	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.SerializePrivate(w, nil); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.Serialize(w); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	return priv.String(), pub.String(), nil
+}
+
+// GetPublicKeyID gets the id of the public key in the format expected by the provider registry protocol
+func GetPublicKeyID(pub string) (string, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(pub))
+	if err != nil {
+		return "", err
+	}
+	if len(keyring) == 0 {
+		return "", errors.New("no key found")
+	}
+	return strings.ToUpper(keyring[0].PrimaryKey.KeyIdString()), nil
+}
+
+// BuildShasums (re)builds the SHA256SUMS file of a provider version from its archives and signs it
+// https://developer.hashicorp.com/terraform/registry/providers/publishing#manually-preparing-a-release
+func BuildShasums(ctx context.Context, ownerID int64, pv *packages_model.PackageVersion) error {
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		return err
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(pfs))
+	for _, pf := range pfs {
+		if _, err := terraform_module.ParseProviderFilename(pf.Name); err != nil {
+			continue
+		}
+
+		pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s\n", pb.HashSHA256, pf.Name))
+	}
+	slices.Sort(lines)
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+	}
+
+	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+
+	block, err := armor.Decode(strings.NewReader(priv))
+	if err != nil {
+		return err
+	}
+
+	e, err := openpgp.ReadEntity(packet.NewReader(block.Body))
+	if err != nil {
+		return err
+	}
+
+	// Terraform expects a binary detached signature
+	signatureContent, _ := packages_module.NewHashedBuffer()
+	defer signatureContent.Close()
+
+	if err := openpgp.DetachSign(signatureContent, e, bytes.NewReader(buf.Bytes()), nil); err != nil {
+		return err
+	}
+
+	shasumsContent, _ := packages_module.CreateHashedBufferFromReader(&buf)
+	defer shasumsContent.Close()
+
+	for _, file := range []struct {
+		Name string
+		Data packages_module.HashedSizeReader
+	}{
+		{terraform_module.ShasumsFilename(p.Name, pv.Version), shasumsContent},
+		{terraform_module.ShasumsSignatureFilename(p.Name, pv.Version), signatureContent},
+	} {
+		_, err = packages_service.AddFileToPackageVersionInternal(
+			ctx,
+			pv,
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: file.Name,
+				},
+				Creator:           user_model.NewGhostUser(),
+				Data:              file.Data,
+				IsLead:            false,
+				Generated:         true,
+				OverwriteExisting: true,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
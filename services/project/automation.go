@@ -0,0 +1,154 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"slices"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	notify_service "forgejo.org/services/notify"
+)
+
+// automationNotifier applies the automation rules of the projects to the issues and
+// pull requests they concern, whether the change comes from the web UI or the API
+type automationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &automationNotifier{}
+
+// Init registers the notifier applying the automation rules of projects
+func Init() error {
+	notify_service.RegisterNotifier(&automationNotifier{})
+	return nil
+}
+
+func (*automationNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	autoAddIssue(ctx, issue.Poster, issue)
+}
+
+func (*automationNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, _ []*issues_model.Label) {
+	if len(addedLabels) > 0 {
+		autoAddIssue(ctx, doer, issue)
+	}
+}
+
+func (*automationNotifier) IssueChangeStatus(ctx context.Context, _ *user_model.User, _ string, issue *issues_model.Issue, _ *issues_model.Comment, closed bool) {
+	if closed {
+		moveIssue(ctx, issue, project_model.AutomationEventItemClosed)
+	} else {
+		moveIssue(ctx, issue, project_model.AutomationEventItemReopened)
+	}
+}
+
+func (*automationNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, _ []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	if err := pr.Issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	autoAddIssue(ctx, pr.Issue.Poster, pr.Issue)
+	moveIssue(ctx, pr.Issue, project_model.AutomationEventPullRequestOpened)
+}
+
+func (n *automationNotifier) MergePullRequest(ctx context.Context, _ *user_model.User, pr *issues_model.PullRequest) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	moveIssue(ctx, pr.Issue, project_model.AutomationEventPullRequestMerged)
+}
+
+func (n *automationNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	n.MergePullRequest(ctx, doer, pr)
+}
+
+func (*automationNotifier) PullRequestReviewRequest(ctx context.Context, _ *user_model.User, issue *issues_model.Issue, _ *user_model.User, isRequest bool, _ *issues_model.Comment) {
+	if isRequest {
+		moveIssue(ctx, issue, project_model.AutomationEventReviewRequested)
+	}
+}
+
+// autoAddIssue adds the issue to the first project having a matching auto-add rule.
+// Issues already belonging to a project are left where they are.
+func autoAddIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	issue.Project = nil
+	if err := issue.LoadProject(ctx); err != nil {
+		log.Error("LoadProject: %v", err)
+		return
+	}
+	if issue.Project != nil {
+		return
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+
+	rules, err := project_model.GetAutoAddRules(ctx, issue.Repo.OwnerID, issue.Repo.ID)
+	if err != nil {
+		log.Error("GetAutoAddRules: %v", err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	// the labels cached on the issue may predate the change which triggered the rules
+	labels, err := issues_model.GetLabelsByIssueID(ctx, issue.ID)
+	if err != nil {
+		log.Error("GetLabelsByIssueID: %v", err)
+		return
+	}
+	for _, rule := range rules {
+		if rule.LabelID > 0 && !slices.ContainsFunc(labels, func(l *issues_model.Label) bool { return l.ID == rule.LabelID }) {
+			continue
+		}
+		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, rule.ProjectID, rule.ColumnID); err != nil {
+			log.Error("IssueAssignOrRemoveProject: %v", err)
+		}
+		return
+	}
+}
+
+// moveIssue moves the issue to the column of the first rule of its project triggered by the event
+func moveIssue(ctx context.Context, issue *issues_model.Issue, event project_model.AutomationEvent) {
+	issue.Project = nil
+	if err := issue.LoadProject(ctx); err != nil {
+		log.Error("LoadProject: %v", err)
+		return
+	}
+	if issue.Project == nil || issue.Project.IsClosed {
+		return
+	}
+
+	rules, err := project_model.GetAutomationRulesByEvent(ctx, issue.Project.ID, event)
+	if err != nil {
+		log.Error("GetAutomationRulesByEvent: %v", err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	column, err := project_model.GetColumn(ctx, rules[0].ColumnID)
+	if err != nil {
+		log.Error("GetColumn: %v", err)
+		return
+	}
+	if _, err := project_model.MoveIssueToColumn(ctx, column, issue.ID); err != nil {
+		log.Error("MoveIssueToColumn: %v", err)
+	}
+}
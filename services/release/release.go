@@ -20,11 +20,13 @@ import (
 	"forgejo.org/modules/graceful"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/repository"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/storage"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
 	"forgejo.org/services/attachment"
 	notify_service "forgejo.org/services/notify"
+	sbom_service "forgejo.org/services/sbom"
 )
 
 type AttachmentChange struct {
@@ -197,12 +199,24 @@ func CreateRelease(gitRepo *git.Repository, rel *repo_model.Release, msg string,
 	}
 
 	if !rel.IsDraft {
+		attachSBOM(gitRepo.Ctx, gitRepo, rel)
 		notify_service.NewRelease(gitRepo.Ctx, rel)
 	}
 
 	return nil
 }
 
+// attachSBOM attaches the bills of materials to a published release.
+// Failures are only logged, as they must not prevent the release.
+func attachSBOM(ctx context.Context, gitRepo *git.Repository, rel *repo_model.Release) {
+	if !setting.Repository.Release.AttachSBOM {
+		return
+	}
+	if err := sbom_service.AttachToRelease(ctx, gitRepo, rel); err != nil {
+		log.Error("AttachToRelease [%d]: %v", rel.ID, err)
+	}
+}
+
 // CreateNewTag creates a new repository tag
 func CreateNewTag(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, commit, tagName, msg string) error {
 	has, err := repo_model.IsReleaseExist(ctx, repo.ID, tagName)
@@ -384,6 +398,7 @@ func UpdateRelease(ctx context.Context, doer *user_model.User, gitRepo *git.Repo
 
 	if !rel.IsDraft {
 		if createdFromTag || isCreated {
+			attachSBOM(gitRepo.Ctx, gitRepo, rel)
 			notify_service.NewRelease(gitRepo.Ctx, rel)
 			return nil
 		}
@@ -8,45 +8,80 @@ import (
 	"context"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
+	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
 )
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, uid int64) (err error) {
-	collaboration := &repo_model.Collaboration{
-		RepoID: repo.ID,
-		UserID: uid,
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, uid int64) (err error) {
+	collaboration, err := repo_model.GetCollaboration(ctx, repo.ID, uid)
+	if err != nil {
+		return err
+	} else if collaboration == nil {
+		return nil
+	}
+	collaborator, err := user_model.GetUserByID(ctx, uid)
+	if err != nil {
+		return err
 	}
 
-	ctx, committer, err := db.TxContext(ctx)
+	dbCtx, committer, err := db.TxContext(ctx)
 	if err != nil {
 		return err
 	}
 	defer committer.Close()
 
-	if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
+	if has, err := db.GetEngine(dbCtx).Delete(collaboration); err != nil {
 		return err
 	} else if has == 0 {
 		return committer.Commit()
 	}
-	if err = access_model.RecalculateAccesses(ctx, repo); err != nil {
+	if err = access_model.RecalculateAccesses(dbCtx, repo); err != nil {
 		return err
 	}
 
-	if err = repo_model.WatchRepo(ctx, uid, repo.ID, false); err != nil {
+	if err = repo_model.WatchRepo(dbCtx, uid, repo.ID, false); err != nil {
 		return err
 	}
 
-	if err = models.ReconsiderWatches(ctx, repo, uid); err != nil {
+	if err = models.ReconsiderWatches(dbCtx, repo, uid); err != nil {
 		return err
 	}
 
 	// Unassign a user from any issue (s)he has been assigned to in the repository
-	if err := models.ReconsiderRepoIssuesAssignee(ctx, repo, uid); err != nil {
+	if err := models.ReconsiderRepoIssuesAssignee(dbCtx, repo, uid); err != nil {
 		return err
 	}
 
-	return committer.Commit()
+	if err := committer.Commit(); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionRepoCollaboratorRemove, audit_service.CollaboratorTarget(repo, collaborator), audit_service.AccessModeState(collaboration.Mode), nil)
+	return nil
+}
+
+// ChangeCollaborationAccessMode sets a new access mode for the collaboration and records the change in the audit log
+func ChangeCollaborationAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) error {
+	collaboration, err := repo_model.GetCollaboration(ctx, repo.ID, collaborator.ID)
+	if err != nil {
+		return err
+	} else if collaboration == nil || collaboration.Mode == mode {
+		return nil
+	}
+
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, repo, collaborator.ID, mode); err != nil {
+		return err
+	}
+	// invalid modes are discarded
+	if mode <= perm.AccessModeNone || mode > perm.AccessModeOwner {
+		return nil
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionRepoCollaboratorUpdate, audit_service.CollaboratorTarget(repo, collaborator), audit_service.AccessModeState(collaboration.Mode), audit_service.AccessModeState(mode))
+	return nil
 }
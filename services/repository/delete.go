@@ -12,7 +12,9 @@ import (
 	actions_model "forgejo.org/models/actions"
 	activities_model "forgejo.org/models/activities"
 	admin_model "forgejo.org/models/admin"
+	advisory_model "forgejo.org/models/advisory"
 	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
@@ -23,6 +25,7 @@ import (
 	repo_model "forgejo.org/models/repo"
 	secret_model "forgejo.org/models/secret"
 	system_model "forgejo.org/models/system"
+	terraform_model "forgejo.org/models/terraform"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/models/webhook"
 	actions_module "forgejo.org/modules/actions"
@@ -70,6 +73,12 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the versions of the terraform states of this repo, they will be needed after they have been deleted to remove the files in ObjectStorage
+	terraformStateVersions, err := terraform_model.GetStateVersionsByRepoID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("list terraform state versions of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -163,6 +172,7 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&git_model.LFSLock{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
+		&issues_model.SLAPolicy{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
@@ -188,6 +198,8 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&actions_model.ActionUser{RepoID: repoID},
 		&repo_model.RepoArchiveDownloadCount{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
+		&packages_model.PackageAccess{RepoID: repoID},
+		&auth_model.AccessTokenRepository{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -197,6 +209,10 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return err
 	}
 
+	if err := advisory_model.DeleteAlertsByRepoID(ctx, repoID); err != nil {
+		return fmt.Errorf("DeleteAlertsByRepoID: %w", err)
+	}
+
 	if cnt, err := sess.ID(repoID).Delete(&repo_model.Repository{}); err != nil {
 		return err
 	} else if cnt != 1 {
@@ -212,6 +228,10 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return err
 	}
 
+	if err := terraform_model.DeleteStatesByRepoID(ctx, repoID); err != nil {
+		return err
+	}
+
 	// Delete Issues and related objects
 	var attachmentPaths []string
 	if attachmentPaths, err = issues_model.DeleteIssuesByRepoID(ctx, repoID); err != nil {
@@ -387,6 +407,14 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		}
 	}
 
+	// delete terraform state versions in ObjectStorage after the repo have already been deleted
+	for _, v := range terraformStateVersions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("remove terraform state file %q: %v", v.StoragePath(), err)
+			// go on
+		}
+	}
+
 	return nil
 }
 
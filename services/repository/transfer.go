@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
@@ -21,6 +22,7 @@ import (
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/sync"
 	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
 	notify_service "forgejo.org/services/notify"
 )
 
@@ -60,6 +62,7 @@ func TransferOwnership(ctx context.Context, doer, newOwner *user_model.User, rep
 	}
 
 	notify_service.TransferRepository(ctx, doer, repo, oldOwner.Name)
+	audit_service.Record(ctx, doer, audit_model.ActionRepoTransfer, audit_service.RepoTarget(newRepo), audit_service.OwnerState(oldOwner), audit_service.OwnerState(newOwner))
 
 	return nil
 }
@@ -0,0 +1,89 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/git"
+	"forgejo.org/modules/lockfile"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/sbom"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/attachment"
+)
+
+// GetSource collects the dependencies pinned by the lockfiles and the language stats of the commit
+func GetSource(repo *repo_model.Repository, gitRepo *git.Repository, commit *git.Commit, version string) (*sbom.Source, error) {
+	manifests, err := lockfile.FindManifests(commit)
+	if err != nil {
+		return nil, fmt.Errorf("FindManifests: %w", err)
+	}
+
+	languages, err := gitRepo.GetLanguageStats(commit.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("GetLanguageStats: %w", err)
+	}
+
+	return &sbom.Source{
+		Name:        repo.FullName(),
+		Version:     version,
+		CommitID:    commit.ID.String(),
+		URL:         repo.HTMLURL(),
+		Manifests:   manifests,
+		Languages:   languages,
+		Created:     commit.Committer.When,
+		ToolName:    setting.AppName,
+		ToolVersion: setting.AppVer,
+	}, nil
+}
+
+// AttachToRelease adds the bills of materials of the tagged commit as attachments to the release.
+// Nothing is attached if the commit has no lockfiles or the release already has an attachment of the same name.
+func AttachToRelease(ctx context.Context, gitRepo *git.Repository, rel *repo_model.Release) error {
+	if err := rel.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	commit, err := gitRepo.GetCommit(rel.Sha1)
+	if err != nil {
+		return fmt.Errorf("GetCommit: %w", err)
+	}
+	source, err := GetSource(rel.Repo, gitRepo, commit, rel.TagName)
+	if err != nil {
+		return err
+	}
+	if len(source.Manifests) == 0 {
+		return nil
+	}
+	source.Created = rel.CreatedUnix.AsLocalTime()
+
+	for _, format := range sbom.Formats {
+		existing, err := repo_model.GetAttachmentByReleaseIDFileName(ctx, rel.ID, format.FileName())
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := sbom.Encode(&buf, format, source); err != nil {
+			return err
+		}
+		if _, err := attachment.NewAttachment(ctx, &repo_model.Attachment{
+			Name:       format.FileName(),
+			UploaderID: rel.PublisherID,
+			RepoID:     rel.RepoID,
+			ReleaseID:  rel.ID,
+		}, &buf, int64(buf.Len())); err != nil {
+			return err
+		}
+		log.Trace("Attached %s to release %d of repository %d", format.FileName(), rel.ID, rel.RepoID)
+	}
+	return nil
+}
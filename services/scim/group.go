@@ -0,0 +1,356 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	scim_module "forgejo.org/modules/scim"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+	org_service "forgejo.org/services/org"
+)
+
+// ToGroup converts an organization team to its SCIM representation. The display name is "org/team".
+func ToGroup(org *organization.Organization, team *organization.Team, externalID string, members []*user_model.User) *scim_module.Group {
+	id := strconv.FormatInt(team.ID, 10)
+	group := &scim_module.Group{
+		Schemas:     []string{scim_module.GroupSchema},
+		ID:          id,
+		ExternalID:  externalID,
+		DisplayName: org.Name + "/" + team.Name,
+		Members:     make([]scim_module.Member, 0, len(members)),
+		Meta: &scim_module.Meta{
+			ResourceType: "Group",
+			Location:     location("Groups", id),
+		},
+	}
+	for _, member := range members {
+		memberID := strconv.FormatInt(member.ID, 10)
+		group.Members = append(group.Members, scim_module.Member{
+			Value:   memberID,
+			Ref:     location("Users", memberID),
+			Display: member.Name,
+		})
+	}
+	return group
+}
+
+func getTeam(ctx context.Context, id string) (*organization.Organization, *organization.Team, error) {
+	teamID, err := parseID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	team, err := organization.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, team, nil
+}
+
+func toGroup(ctx context.Context, org *organization.Organization, team *organization.Team, externalID string, withMembers bool) (*scim_module.Group, error) {
+	var members []*user_model.User
+	if withMembers {
+		if err := team.LoadMembers(ctx); err != nil {
+			return nil, err
+		}
+		members = team.Members
+	}
+	return ToGroup(org, team, externalID, members), nil
+}
+
+// GetGroup returns the team with the SCIM id
+func GetGroup(ctx context.Context, id string) (*scim_module.Group, error) {
+	org, team, err := getTeam(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceGroup, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toGroup(ctx, org, team, externalID, true)
+}
+
+// ListGroups returns the teams matching the filter, which may be nil
+func ListGroups(ctx context.Context, filter scim_module.Filter, startIndex, count int, withMembers bool) (*scim_module.ListResponse, error) {
+	var teams []*organization.Team
+	if displayName, ok := scim_module.EqualityValue(filter, "displayName"); ok {
+		orgName, teamName, _ := strings.Cut(displayName, "/")
+		org, err := organization.GetOrgByName(ctx, orgName)
+		if err != nil && !organization.IsErrOrgNotExist(err) {
+			return nil, err
+		} else if err == nil {
+			team, err := organization.GetTeam(ctx, org.ID, teamName)
+			if err != nil && !organization.IsErrTeamNotExist(err) {
+				return nil, err
+			} else if err == nil {
+				teams = append(teams, team)
+			}
+		}
+	} else if externalID, ok := scim_module.EqualityValue(filter, "externalId"); ok {
+		teamID, err := auth_model.GetSCIMResourceIDByExternalID(ctx, auth_model.SCIMResourceGroup, externalID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		} else if err == nil {
+			if err := db.GetEngine(ctx).Where("id = ?", teamID).Find(&teams); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := db.GetEngine(ctx).OrderBy("org_id, id").Find(&teams); err != nil {
+		return nil, err
+	}
+
+	externalIDs, err := auth_model.GetSCIMExternalIDs(ctx, auth_model.SCIMResourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	orgs := make(map[int64]*organization.Organization)
+	groups := make([]*scim_module.Group, 0, len(teams))
+	for _, team := range teams {
+		org, ok := orgs[team.OrgID]
+		if !ok {
+			if org, err = organization.GetOrgByID(ctx, team.OrgID); err != nil {
+				return nil, err
+			}
+			orgs[team.OrgID] = org
+		}
+		// members are needed to match filters on them even if they are excluded from the response
+		group, err := toGroup(ctx, org, team, externalIDs[team.ID], true)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := matches(filter, group); err != nil {
+			return nil, err
+		} else if ok {
+			if !withMembers {
+				group.Members = nil
+			}
+			groups = append(groups, group)
+		}
+	}
+	return scim_module.NewListResponse(paginate(groups, startIndex, count), len(groups), startIndex), nil
+}
+
+// parseDisplayName returns the organization and the team name of a display name like "org/team"
+func parseDisplayName(ctx context.Context, displayName string) (*organization.Organization, string, error) {
+	orgName, teamName, ok := strings.Cut(strings.TrimSpace(displayName), "/")
+	if !ok || orgName == "" || teamName == "" {
+		return nil, "", scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "displayName must be of the form \"organization/team\"")
+	}
+	org, err := organization.GetOrgByName(ctx, orgName)
+	if err != nil {
+		if organization.IsErrOrgNotExist(err) {
+			return nil, "", scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "organization %q does not exist", orgName)
+		}
+		return nil, "", err
+	}
+	return org, teamName, nil
+}
+
+// memberUsers returns the users of the members, which must be existing individual users
+func memberUsers(ctx context.Context, members []scim_module.Member) ([]*user_model.User, error) {
+	ids := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member.Value, 10, 64)
+		if err != nil {
+			return nil, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "invalid member %q", member.Value)
+		}
+		ids = append(ids, id)
+	}
+	users, err := user_model.GetUserByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]*user_model.User, len(users))
+	for _, u := range users {
+		if u.Type == user_model.UserTypeIndividual {
+			found[u.ID] = u
+		}
+	}
+	ordered := make([]*user_model.User, 0, len(ids))
+	for _, id := range ids {
+		u, ok := found[id]
+		if !ok {
+			return nil, scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "user %d does not exist", id)
+		}
+		ordered = append(ordered, u)
+	}
+	return ordered, nil
+}
+
+// syncMembers adds and removes team members to match the users, the changes are recorded in the audit log
+func syncMembers(ctx context.Context, doer *user_model.User, team *organization.Team, users []*user_model.User) error {
+	if err := team.LoadMembers(ctx); err != nil {
+		return err
+	}
+	wanted := make(map[int64]bool, len(users))
+	for _, u := range users {
+		wanted[u.ID] = true
+	}
+	for _, member := range team.Members {
+		if !wanted[member.ID] {
+			if err := org_service.RemoveTeamMember(ctx, doer, team, member); err != nil {
+				if organization.IsErrLastOrgOwner(err) {
+					return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeMutability, "the last owner of the organization cannot be removed")
+				}
+				return err
+			}
+		}
+	}
+	for _, u := range users {
+		if err := org_service.AddTeamMember(ctx, doer, team, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateGroup creates a team in an existing organization. New teams have read access to all repository units
+// and no repositories, which are assigned by the organization owners.
+func CreateGroup(ctx context.Context, doer *user_model.User, group *scim_module.Group) (*scim_module.Group, error) {
+	org, teamName, err := parseDisplayName(ctx, group.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+	users, err := memberUsers(ctx, group.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &organization.Team{
+		OrgID:      org.ID,
+		Name:       teamName,
+		AccessMode: perm.AccessModeRead,
+		Units:      make([]*organization.TeamUnit, 0, len(unit.AllRepoUnitTypes)),
+	}
+	for _, tp := range unit.AllRepoUnitTypes {
+		team.Units = append(team.Units, &organization.TeamUnit{
+			OrgID:      org.ID,
+			Type:       tp,
+			AccessMode: perm.AccessModeRead,
+		})
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if err := org_service.NewTeam(ctx, doer, team); err != nil {
+			return err
+		}
+		if err := auth_model.SetSCIMExternalID(ctx, auth_model.SCIMResourceGroup, team.ID, group.ExternalID); err != nil {
+			return err
+		}
+		return syncMembers(ctx, doer, team, users)
+	})
+	if err != nil {
+		return nil, ToError(err)
+	}
+	return toGroup(ctx, org, team, group.ExternalID, true)
+}
+
+// ReplaceGroup updates a team with all attributes of the request
+func ReplaceGroup(ctx context.Context, doer *user_model.User, id string, group *scim_module.Group) (*scim_module.Group, error) {
+	org, team, err := getTeam(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	if err := updateGroup(ctx, doer, org, team, group); err != nil {
+		return nil, ToError(err)
+	}
+	return toGroup(ctx, org, team, group.ExternalID, true)
+}
+
+// PatchGroup updates a team with the operations of a patch request
+func PatchGroup(ctx context.Context, doer *user_model.User, id string, operations []scim_module.PatchOperation) (*scim_module.Group, error) {
+	org, team, err := getTeam(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceGroup, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	group, err := toGroup(ctx, org, team, externalID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scim_module.Patch(group, operations); err != nil {
+		return nil, err
+	}
+	if err := updateGroup(ctx, doer, org, team, group); err != nil {
+		return nil, ToError(err)
+	}
+	return toGroup(ctx, org, team, group.ExternalID, true)
+}
+
+func updateGroup(ctx context.Context, doer *user_model.User, org *organization.Organization, team *organization.Team, group *scim_module.Group) error {
+	newOrg, teamName, err := parseDisplayName(ctx, group.DisplayName)
+	if err != nil {
+		return err
+	}
+	if newOrg.ID != org.ID {
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeMutability, "a team cannot be moved to another organization")
+	}
+	users, err := memberUsers(ctx, group.Members)
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if teamName != team.Name {
+			if team.IsOwnerTeam() {
+				return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeMutability, "the owners team cannot be renamed")
+			}
+			if err := organization.IsUsableTeamName(teamName); err != nil {
+				return err
+			}
+			before := audit_service.TeamState(team)
+			team.Name = teamName
+			if err := org_service.UpdateTeam(ctx, doer, team, before, false, false); err != nil {
+				return err
+			}
+		}
+
+		externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceGroup, team.ID)
+		if err != nil {
+			return err
+		}
+		if externalID != group.ExternalID {
+			if err := auth_model.SetSCIMExternalID(ctx, auth_model.SCIMResourceGroup, team.ID, group.ExternalID); err != nil {
+				return err
+			}
+		}
+
+		return syncMembers(ctx, doer, team, users)
+	})
+}
+
+// DeleteGroup deletes a team. The owners team cannot be deleted.
+func DeleteGroup(ctx context.Context, doer *user_model.User, id string) error {
+	_, team, err := getTeam(ctx, id)
+	if err != nil {
+		return ToError(err)
+	}
+	if team.IsOwnerTeam() {
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeMutability, "the owners team cannot be deleted")
+	}
+	if err := org_service.DeleteTeam(ctx, doer, team); err != nil {
+		return ToError(err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package scim provisions users and organization teams on behalf of an identity provider
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	scim_module "forgejo.org/modules/scim"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// MaxResults is the maximum number of resources returned for a query
+func MaxResults() int {
+	return setting.API.MaxResponseItems
+}
+
+// ToError converts the errors of the models to the error responses of SCIM.
+// Errors which are not caused by the request are returned unchanged.
+func ToError(err error) error {
+	var scimErr *scim_module.Error
+	switch {
+	case err == nil, errors.As(err, &scimErr):
+		return err
+	case errors.Is(err, util.ErrNotExist):
+		return scim_module.NewError(http.StatusNotFound, "", "%v", err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		return scim_module.NewError(http.StatusConflict, scim_module.ErrorTypeUniqueness, "%v", err)
+	case errors.Is(err, util.ErrInvalidArgument), errors.Is(err, util.ErrPermissionDenied):
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "%v", err)
+	}
+	return err
+}
+
+func location(resourceType, id string) string {
+	return setting.AppURL + "scim/v2/" + resourceType + "/" + id
+}
+
+func newMeta(resourceType, id string, created, updated timeutil.TimeStamp) *scim_module.Meta {
+	createdTime, updatedTime := created.AsTime(), updated.AsTime()
+	return &scim_module.Meta{
+		ResourceType: resourceType,
+		Created:      &createdTime,
+		LastModified: &updatedTime,
+		Location:     location(resourceType+"s", id),
+	}
+}
+
+func parseID(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, util.NewNotExistErrorf("resource %q does not exist", id)
+	}
+	return n, nil
+}
+
+// paginate returns the resources of the page starting at the 1-based startIndex
+func paginate[T any](resources []T, startIndex, count int) []any {
+	page := make([]any, 0, min(count, len(resources)))
+	for i := startIndex - 1; i >= 0 && i < len(resources) && len(page) < count; i++ {
+		page = append(page, resources[i])
+	}
+	return page
+}
+
+func matches(filter scim_module.Filter, resource any) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	object, err := scim_module.ToMap(resource)
+	if err != nil {
+		return false, err
+	}
+	return filter.Match(object), nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	scim_module "forgejo.org/modules/scim"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	audit_service "forgejo.org/services/audit"
+	user_service "forgejo.org/services/user"
+)
+
+// ToUser converts a user to its SCIM representation
+func ToUser(u *user_model.User, externalID string) *scim_module.User {
+	id := strconv.FormatInt(u.ID, 10)
+	active := !u.ProhibitLogin
+	user := &scim_module.User{
+		Schemas:     []string{scim_module.UserSchema},
+		ID:          id,
+		ExternalID:  externalID,
+		UserName:    u.Name,
+		DisplayName: u.FullName,
+		Active:      &active,
+		Meta:        newMeta("User", id, u.CreatedUnix, u.UpdatedUnix),
+	}
+	if u.FullName != "" {
+		user.Name = &scim_module.Name{Formatted: u.FullName}
+	}
+	if u.Email != "" {
+		user.Emails = []scim_module.Email{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	return user
+}
+
+func getUser(ctx context.Context, id string) (*user_model.User, error) {
+	userID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := user_model.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.Type != user_model.UserTypeIndividual {
+		return nil, util.NewNotExistErrorf("user %q does not exist", id)
+	}
+	return u, nil
+}
+
+// GetUser returns the user with the SCIM id
+func GetUser(ctx context.Context, id string) (*scim_module.User, error) {
+	u, err := getUser(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceUser, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ToUser(u, externalID), nil
+}
+
+// ListUsers returns the users matching the filter, which may be nil
+func ListUsers(ctx context.Context, filter scim_module.Filter, startIndex, count int) (*scim_module.ListResponse, error) {
+	var candidates []*user_model.User
+	if name, ok := scim_module.EqualityValue(filter, "userName"); ok {
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		} else if err == nil {
+			candidates = append(candidates, u)
+		}
+	} else if externalID, ok := scim_module.EqualityValue(filter, "externalId"); ok {
+		userID, err := auth_model.GetSCIMResourceIDByExternalID(ctx, auth_model.SCIMResourceUser, externalID)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		} else if err == nil {
+			if candidates, err = user_model.GetUserByIDs(ctx, []int64{userID}); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := db.GetEngine(ctx).Where("type = ?", user_model.UserTypeIndividual).OrderBy("id").Find(&candidates); err != nil {
+		return nil, err
+	}
+
+	externalIDs, err := auth_model.GetSCIMExternalIDs(ctx, auth_model.SCIMResourceUser)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*scim_module.User, 0, len(candidates))
+	for _, u := range candidates {
+		if u.Type != user_model.UserTypeIndividual {
+			continue
+		}
+		user := ToUser(u, externalIDs[u.ID])
+		if ok, err := matches(filter, user); err != nil {
+			return nil, err
+		} else if ok {
+			users = append(users, user)
+		}
+	}
+	return scim_module.NewListResponse(paginate(users, startIndex, count), len(users), startIndex), nil
+}
+
+// loginSource returns the authentication source provisioned users sign in with, or nil for local users
+func loginSource(ctx context.Context) (*auth_model.Source, error) {
+	if setting.SCIM.AuthSource == "" {
+		return nil, nil
+	}
+	return auth_model.GetSourceByName(ctx, setting.SCIM.AuthSource)
+}
+
+func loginName(user *scim_module.User) string {
+	if user.ExternalID != "" {
+		return user.ExternalID
+	}
+	return user.UserName
+}
+
+func validateUser(user *scim_module.User) error {
+	if strings.TrimSpace(user.UserName) == "" {
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "userName is required")
+	}
+	if user.PrimaryEmail() == "" {
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeInvalidValue, "an email address is required")
+	}
+	return nil
+}
+
+// CreateUser creates a user which is active unless the request explicitly deactivates it
+func CreateUser(ctx context.Context, doer *user_model.User, user *scim_module.User) (*scim_module.User, error) {
+	if err := validateUser(user); err != nil {
+		return nil, err
+	}
+	source, err := loginSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &user_model.User{
+		Name:          strings.TrimSpace(user.UserName),
+		FullName:      user.FullName(),
+		Email:         user.PrimaryEmail(),
+		ProhibitLogin: !user.IsActive(),
+	}
+	if source != nil {
+		u.LoginType = source.Type
+		u.LoginSource = source.ID
+		u.LoginName = loginName(user)
+	}
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if err := user_model.AdminCreateUser(ctx, u, &user_model.CreateUserOverwriteOptions{
+			IsActive: optional.Some(true),
+		}); err != nil {
+			return err
+		}
+		return auth_model.SetSCIMExternalID(ctx, auth_model.SCIMResourceUser, u.ID, user.ExternalID)
+	})
+	if err != nil {
+		return nil, ToError(err)
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionAdminUserCreate, audit_service.UserTarget(u), nil, audit_service.UserState(u))
+	return ToUser(u, user.ExternalID), nil
+}
+
+// ReplaceUser updates a user with all attributes of the request
+func ReplaceUser(ctx context.Context, doer *user_model.User, id string, user *scim_module.User) (*scim_module.User, error) {
+	u, err := getUser(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	if err := updateUser(ctx, doer, u, user); err != nil {
+		return nil, ToError(err)
+	}
+	return ToUser(u, user.ExternalID), nil
+}
+
+// PatchUser updates a user with the operations of a patch request
+func PatchUser(ctx context.Context, doer *user_model.User, id string, operations []scim_module.PatchOperation) (*scim_module.User, error) {
+	u, err := getUser(ctx, id)
+	if err != nil {
+		return nil, ToError(err)
+	}
+	externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceUser, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := ToUser(u, externalID)
+	if err := scim_module.Patch(user, operations); err != nil {
+		return nil, err
+	}
+	if err := updateUser(ctx, doer, u, user); err != nil {
+		return nil, ToError(err)
+	}
+	return ToUser(u, user.ExternalID), nil
+}
+
+func updateUser(ctx context.Context, doer *user_model.User, u *user_model.User, user *scim_module.User) error {
+	if err := validateUser(user); err != nil {
+		return err
+	}
+	source, err := loginSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	before := audit_service.UserState(u)
+	changed := false
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if name := strings.TrimSpace(user.UserName); name != u.Name {
+			changed = true
+			if err := user_service.AdminRenameUser(ctx, u, name); err != nil {
+				return err
+			}
+		}
+
+		if email := user.PrimaryEmail(); !strings.EqualFold(email, u.Email) {
+			changed = true
+			if err := user_service.AdminAddOrSetPrimaryEmailAddress(ctx, u, email); err != nil {
+				return err
+			}
+		}
+
+		if fullName := user.FullName(); fullName != u.FullName {
+			changed = true
+			if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{FullName: optional.Some(fullName)}); err != nil {
+				return err
+			}
+		}
+
+		authOpts := &user_service.UpdateAuthOptions{}
+		if prohibitLogin := !user.IsActive(); prohibitLogin != u.ProhibitLogin {
+			authOpts.ProhibitLogin = optional.Some(prohibitLogin)
+		}
+		if source != nil && u.LoginSource == source.ID && u.LoginName != loginName(user) {
+			authOpts.LoginName = optional.Some(loginName(user))
+		}
+		if authOpts.ProhibitLogin.Has() || authOpts.LoginName.Has() {
+			changed = true
+			if err := user_service.UpdateAuth(ctx, u, authOpts); err != nil {
+				return err
+			}
+		}
+
+		externalID, err := auth_model.GetSCIMExternalID(ctx, auth_model.SCIMResourceUser, u.ID)
+		if err != nil {
+			return err
+		}
+		if externalID != user.ExternalID {
+			return auth_model.SetSCIMExternalID(ctx, auth_model.SCIMResourceUser, u.ID, user.ExternalID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		audit_service.Record(ctx, doer, audit_model.ActionAdminUserUpdate, audit_service.UserTarget(u), before, audit_service.UserState(u))
+	}
+	return nil
+}
+
+// DeleteUser deletes a user. Users which still own repositories, organizations or packages can only be deactivated.
+func DeleteUser(ctx context.Context, doer *user_model.User, id string) error {
+	u, err := getUser(ctx, id)
+	if err != nil {
+		return ToError(err)
+	}
+	if u.ID == doer.ID {
+		return scim_module.NewError(http.StatusBadRequest, scim_module.ErrorTypeMutability, "the user of the access token cannot be deleted")
+	}
+
+	if err := user_service.DeleteUser(ctx, u, false); err != nil {
+		if models.IsErrUserOwnRepos(err) || models.IsErrUserHasOrgs(err) || models.IsErrUserOwnPackages(err) || models.IsErrDeleteLastAdminUser(err) {
+			return scim_module.NewError(http.StatusConflict, "", "%v", err)
+		}
+		return ToError(err)
+	}
+
+	audit_service.Record(ctx, doer, audit_model.ActionAdminUserDelete, audit_service.UserTarget(u), audit_service.UserState(u), nil)
+	return nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	terraform_model "forgejo.org/models/terraform"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/keying"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/util"
+)
+
+var (
+	// ErrStateLocked is returned if the state is locked by a different lock id
+	ErrStateLocked = errors.New("terraform state is locked")
+	// ErrStateTooLarge is returned if the state exceeds the configured size limit
+	ErrStateTooLarge = errors.New("terraform state is too large")
+	// ErrInvalidState is returned if the content is not a Terraform state
+	ErrInvalidState = util.NewInvalidArgumentErrorf("terraform state is invalid")
+	// ErrInvalidLockInfo is returned if the lock information has no id
+	ErrInvalidLockInfo = util.NewInvalidArgumentErrorf("terraform lock information is invalid")
+)
+
+// stateHeader contains the fields of a Terraform state which are stored with the version
+type stateHeader struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// ReadState reads the content of the latest version of a state
+func ReadState(ctx context.Context, repo *repo_model.Repository, name string) ([]byte, *terraform_model.StateVersion, error) {
+	s, err := terraform_model.GetStateByName(ctx, repo.ID, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := terraform_model.GetLatestStateVersion(ctx, s.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := ReadStateVersion(ctx, v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, v, nil
+}
+
+// ReadStateVersion reads and decrypts the content of a version of a state
+func ReadStateVersion(ctx context.Context, v *terraform_model.StateVersion) ([]byte, error) {
+	f, err := storage.TerraformStates.Open(v.StoragePath())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ciphertext, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return keying.TerraformState.Decrypt(ciphertext, keying.ColumnAndID("content", v.ID))
+}
+
+// WriteState stores the content as new version of a state. If the state is locked, lockID must match the lock.
+func WriteState(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, name, lockID string, content []byte) (*terraform_model.StateVersion, error) {
+	if setting.Terraform.LimitStateSize > -1 && int64(len(content)) > setting.Terraform.LimitStateSize {
+		return nil, ErrStateTooLarge
+	}
+
+	var header stateHeader
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	s, err := terraform_model.GetOrCreateState(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsLocked() && s.LockID != lockID {
+		return nil, ErrStateLocked
+	}
+
+	hash := sha256.Sum256(content)
+
+	v := &terraform_model.StateVersion{
+		StateID:    s.ID,
+		Serial:     header.Serial,
+		Lineage:    header.Lineage,
+		Size:       int64(len(content)),
+		HashSHA256: hex.EncodeToString(hash[:]),
+		CreatorID:  doer.ID,
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := terraform_model.InsertStateVersion(ctx, v); err != nil {
+			return err
+		}
+
+		// the id of the version binds the ciphertext to its row
+		ciphertext := keying.TerraformState.Encrypt(content, keying.ColumnAndID("content", v.ID))
+		_, err := storage.TerraformStates.Save(v.StoragePath(), bytes.NewReader(ciphertext), int64(len(ciphertext)))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := pruneStateVersions(ctx, s); err != nil {
+		log.Error("Error pruning versions of terraform state %d: %v", s.ID, err)
+	}
+
+	return v, nil
+}
+
+// pruneStateVersions deletes the oldest versions of a state which exceed the configured number of versions
+func pruneStateVersions(ctx context.Context, s *terraform_model.State) error {
+	if setting.Terraform.MaxStateVersions <= 0 {
+		return nil
+	}
+
+	versions, err := terraform_model.GetStateVersionsBeyondLimit(ctx, s.ID, setting.Terraform.MaxStateVersions)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := terraform_model.DeleteStateVersionByID(ctx, v.ID); err != nil {
+			return err
+		}
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state version %s from storage: %v", v.StoragePath(), err)
+		}
+	}
+	return nil
+}
+
+// LockState locks a state, creating it if it does not exist. If the state is locked already,
+// the information of the current lock is returned with ErrStateLocked.
+func LockState(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, name string, info *terraform_model.LockInfo) (*terraform_model.LockInfo, error) {
+	if info.ID == "" {
+		return nil, ErrInvalidLockInfo
+	}
+
+	s, err := terraform_model.GetOrCreateState(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := terraform_model.TryLockState(ctx, s, info, doer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return info, nil
+	}
+
+	s, err = terraform_model.GetStateByID(ctx, repo.ID, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetLockInfo(), ErrStateLocked
+}
+
+// UnlockState releases the lock of a state. If the state is locked with a different id,
+// the information of the current lock is returned with ErrStateLocked.
+func UnlockState(ctx context.Context, repo *repo_model.Repository, name string, info *terraform_model.LockInfo) (*terraform_model.LockInfo, error) {
+	if info.ID == "" {
+		return nil, ErrInvalidLockInfo
+	}
+
+	s, err := terraform_model.GetStateByName(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsLocked() {
+		return nil, nil
+	}
+
+	unlocked, err := terraform_model.UnlockState(ctx, s, info.ID)
+	if err != nil {
+		return nil, err
+	}
+	if unlocked {
+		return nil, nil
+	}
+
+	s, err = terraform_model.GetStateByID(ctx, repo.ID, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsLocked() {
+		return nil, nil
+	}
+	return s.GetLockInfo(), ErrStateLocked
+}
+
+// ForceUnlockState releases the lock of a state regardless of its holder
+func ForceUnlockState(ctx context.Context, s *terraform_model.State) error {
+	_, err := terraform_model.UnlockState(ctx, s, "")
+	return err
+}
+
+// DeleteState deletes a state with all its versions
+func DeleteState(ctx context.Context, s *terraform_model.State) error {
+	versions, _, err := terraform_model.GetStateVersions(ctx, s.ID, db.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err := terraform_model.DeleteStateByID(ctx, s.ID); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state version %s from storage: %v", v.StoragePath(), err)
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
 	"forgejo.org/models/organization"
+	packages_model "forgejo.org/models/packages"
 	access_model "forgejo.org/models/perm/access"
 	pull_model "forgejo.org/models/pull"
 	repo_model "forgejo.org/models/repo"
@@ -73,6 +74,10 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 	}
 	// ***** END: Follow *****
 
+	if err = auth_model.DeleteAccessTokenRepositoriesByUID(ctx, u.ID); err != nil {
+		return fmt.Errorf("DeleteAccessTokenRepositoriesByUID: %w", err)
+	}
+
 	if err = db.DeleteBeans(ctx,
 		&auth_model.AccessToken{UID: u.ID},
 		&repo_model.Collaboration{UserID: u.ID},
@@ -88,6 +93,7 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&issues_model.Reaction{UserID: u.ID},
 		&organization.TeamUser{UID: u.ID},
 		&issues_model.Stopwatch{UserID: u.ID},
+		&issues_model.SavedSearch{OwnerID: u.ID},
 		&user_model.Setting{UserID: u.ID},
 		&user_model.UserBadge{UserID: u.ID},
 		&pull_model.AutoMerge{DoerID: u.ID},
@@ -99,6 +105,9 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&user_model.BlockedUser{UserID: u.ID},
 		&actions_model.ActionRunnerToken{OwnerID: u.ID},
 		&auth_model.AuthorizationToken{UID: u.ID},
+		&auth_model.UserSession{UserID: u.ID},
+		&packages_model.PackageAccess{OwnerID: u.ID},
+		&auth_model.SCIMExternalID{ResourceType: auth_model.SCIMResourceUser, ResourceID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -184,6 +193,9 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 	if _, err = db.DeleteByBean(ctx, &asymkey_model.PublicKey{OwnerID: u.ID}); err != nil {
 		return fmt.Errorf("deletePublicKeys: %w", err)
 	}
+	if _, err = db.DeleteByBean(ctx, &asymkey_model.SSHCertificate{OwnerID: u.ID}); err != nil {
+		return fmt.Errorf("deleteSSHCertificates: %w", err)
+	}
 	// ***** END: PublicKey *****
 
 	// ***** START: GPGPublicKey *****
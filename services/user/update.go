@@ -154,6 +154,9 @@ type UpdateAuthOptions struct {
 	Password           optional.Option[string]
 	MustChangePassword optional.Option[bool]
 	ProhibitLogin      optional.Option[bool]
+	// KeepSession is the token of the session which stays signed in when the password changes,
+	// all other sessions of the user are signed out
+	KeepSession string
 }
 
 func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions) error {
@@ -186,6 +189,9 @@ func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions
 		if err := u.SetPassword(password); err != nil {
 			return err
 		}
+		if err := auth_model.DeleteUserSessions(ctx, u.ID, opts.KeepSession); err != nil {
+			return err
+		}
 	}
 
 	if has, value := opts.MustChangePassword.Get(); has {
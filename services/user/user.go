@@ -388,3 +388,14 @@ func DeleteInactiveUsers(ctx context.Context, olderThan time.Duration) error {
 
 	return nil
 }
+
+// SignOutSessions signs a user out of all sessions except the one with the given token, which may be empty.
+// The devices remembered at sign-in are forgotten as well, as they would sign in again.
+func SignOutSessions(ctx context.Context, u *user_model.User, keepSession string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth.DeleteUserSessions(ctx, u.ID, keepSession); err != nil {
+			return err
+		}
+		return auth.DeleteAuthTokenByUser(ctx, u.ID)
+	})
+}
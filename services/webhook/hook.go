@@ -0,0 +1,87 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package webhook
+
+import (
+	"context"
+
+	audit_model "forgejo.org/models/audit"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	webhook_model "forgejo.org/models/webhook"
+	"forgejo.org/modules/log"
+	audit_service "forgejo.org/services/audit"
+)
+
+// CreateWebhook creates a webhook and records it in the audit log
+func CreateWebhook(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook, authorizationHeader string) error {
+	if err := webhook_model.CreateWebhook(ctx, w, authorizationHeader); err != nil {
+		return err
+	}
+	recordChange(ctx, doer, audit_model.ActionWebhookCreate, w, nil, audit_service.WebhookState(w))
+	return nil
+}
+
+// UpdateWebhook updates a webhook and records the changes since before, the state of the webhook
+// taken with audit_service.WebhookState before it was modified, in the audit log
+func UpdateWebhook(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook, before any) error {
+	if err := webhook_model.UpdateWebhook(ctx, w); err != nil {
+		return err
+	}
+	recordChange(ctx, doer, audit_model.ActionWebhookUpdate, w, before, audit_service.WebhookState(w))
+	return nil
+}
+
+// DeleteWebhookByRepoID deletes a webhook of a repository and records it in the audit log
+func DeleteWebhookByRepoID(ctx context.Context, doer *user_model.User, repoID, id int64) error {
+	w, err := webhook_model.GetWebhookByRepoID(ctx, repoID, id)
+	if err != nil {
+		return err
+	}
+	if err := webhook_model.DeleteWebhookByID(ctx, id); err != nil {
+		return err
+	}
+	recordChange(ctx, doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookState(w), nil)
+	return nil
+}
+
+// DeleteWebhookByOwnerID deletes a webhook of a user or an organization and records it in the audit log
+func DeleteWebhookByOwnerID(ctx context.Context, doer *user_model.User, ownerID, id int64) error {
+	w, err := webhook_model.GetWebhookByOwnerID(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	if err := webhook_model.DeleteWebhookByID(ctx, id); err != nil {
+		return err
+	}
+	recordChange(ctx, doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookState(w), nil)
+	return nil
+}
+
+// DeleteDefaultSystemWebhook deletes a default or system webhook and records it in the audit log
+func DeleteDefaultSystemWebhook(ctx context.Context, doer *user_model.User, id int64) error {
+	w, err := webhook_model.GetSystemOrDefaultWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := webhook_model.DeleteDefaultSystemWebhook(ctx, id); err != nil {
+		return err
+	}
+	recordChange(ctx, doer, audit_model.ActionWebhookDelete, w, audit_service.WebhookState(w), nil)
+	return nil
+}
+
+// recordChange adds the change of a webhook to the audit log. Webhooks of repositories
+// are shown in the audit logs of the repository and of its owner.
+func recordChange(ctx context.Context, doer *user_model.User, action audit_model.Action, w *webhook_model.Webhook, before, after any) {
+	var repo *repo_model.Repository
+	if w.RepoID > 0 {
+		var err error
+		if repo, err = repo_model.GetRepositoryByID(ctx, w.RepoID); err != nil {
+			log.Error("GetRepositoryByID[%d]: %v", w.RepoID, err)
+			return
+		}
+	}
+	audit_service.Record(ctx, doer, action, audit_service.WebhookTarget(w, repo), before, after)
+}
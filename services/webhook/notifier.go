@@ -939,11 +939,24 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 		return
 	}
 
-	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventPackage, &api.PackagePayload{
-		Action:  action,
-		Package: apiPackage,
-		Sender:  convert.ToUser(ctx, sender, nil),
-	}); err != nil {
+	files := make([]*api.PackageFile, 0, len(pd.Files))
+	for _, pfd := range pd.Files {
+		files = append(files, convert.ToPackageFile(pfd))
+	}
+
+	payload := &api.PackagePayload{
+		Action:     action,
+		Repository: apiPackage.Repository,
+		Package:    apiPackage,
+		Files:      files,
+		Uploader:   convert.ToUser(ctx, pd.Creator, nil),
+		Sender:     convert.ToUser(ctx, sender, nil),
+	}
+	if pd.Owner.IsOrganization() {
+		payload.Organization = convert.ToUser(ctx, pd.Owner, nil)
+	}
+
+	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventPackage, payload); err != nil {
 		log.Error("PrepareWebhooks: %v", err)
 	}
 }
@@ -52,7 +52,7 @@ test('WebAuthn register & login flow', async ({browser, request}, workerInfo) =>
 
   await page.getByLabel('Username or email address').fill(username);
   await page.getByLabel('Password').fill('password');
-  await page.getByRole('button', {name: 'Sign in'}).click();
+  await page.getByRole('button', {name: 'Sign in', exact: true}).click();
   await page.waitForURL(`${workerInfo.project.use.baseURL}/user/webauthn`);
   await page.waitForURL(`${workerInfo.project.use.baseURL}/`);
 
@@ -68,3 +68,46 @@ test('WebAuthn register & login flow', async ({browser, request}, workerInfo) =>
   // verify the user can login without a key
   await login_user(browser, workerInfo, username);
 });
+
+test('Passkey register & passwordless login flow', async ({browser, request}, workerInfo) => {
+  test.skip(workerInfo.project.name !== 'chromium', 'Uses Chrome protocol');
+  const {context} = await create_temp_user(browser, workerInfo, request);
+  const page = await context.newPage();
+
+  // Register a passkey.
+  let response = await page.goto('/user/settings/security');
+  expect(response?.status()).toBe(200);
+
+  const cdpSession = await page.context().newCDPSession(page);
+  await cdpSession.send('WebAuthn.enable');
+  await cdpSession.send('WebAuthn.addVirtualAuthenticator', {
+    options: {
+      protocol: 'ctap2',
+      ctap2Version: 'ctap2_1',
+      hasResidentKey: true,
+      hasUserVerification: true,
+      transport: 'internal',
+      automaticPresenceSimulation: true,
+      isUserVerified: true,
+    },
+  });
+
+  await page.locator('input#nickname').fill('Testing Passkey');
+  await page.getByText('Use as a passkey').click();
+  await page.getByText('Add security key').click();
+  await expect(page.locator('.flex-item-title', {hasText: 'Passkey'})).toBeVisible();
+
+  // Logout.
+  await page.locator('summary[aria-label="Profile and settings…"]').click();
+  await page.getByText('Sign out').click();
+  await expect(async () => {
+    await page.waitForURL(`${workerInfo.project.use.baseURL}/`);
+  }).toPass();
+
+  // Login without username and password.
+  response = await page.goto('/user/login');
+  expect(response?.status()).toBe(200);
+  await page.getByRole('button', {name: 'Sign in with a passkey'}).click();
+  await page.waitForURL(`${workerInfo.project.use.baseURL}/`);
+  await expect(page.locator('summary[aria-label="Profile and settings…"]')).toBeVisible();
+});
@@ -0,0 +1,70 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIOrgProjectViewPermissions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// project 7 belongs to org3, project 4 to user2
+	viewsURL := "/api/v1/orgs/org3/projects/7/views"
+	ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+	otherToken := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteOrganization)
+
+	req := NewRequestWithJSON(t, "POST", viewsURL, &api.CreateProjectViewOption{
+		Title: "Roadmap",
+		Type:  "roadmap",
+	}).AddTokenAuth(ownerToken)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var view api.ProjectView
+	DecodeJSON(t, resp, &view)
+	assert.Equal(t, "roadmap", view.Type)
+	unittest.AssertExistsAndLoadBean(t, &project_model.View{ID: view.ID, ProjectID: 7, Type: project_model.ViewTypeRoadmap})
+	viewURL := fmt.Sprintf("%s/%d", viewsURL, view.ID)
+
+	t.Run("Read", func(t *testing.T) {
+		// org3 is public, its projects can be read by anyone
+		MakeRequest(t, NewRequest(t, "GET", viewsURL), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", viewURL).AddTokenAuth(otherToken), http.StatusOK)
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		// user5 is not a member of org3
+		req := NewRequestWithJSON(t, "POST", viewsURL, &api.CreateProjectViewOption{Title: "Table", Type: "table"}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		unittest.AssertNotExistsBean(t, &project_model.View{ProjectID: 7, Title: "Table"})
+
+		title := "Renamed"
+		req = NewRequestWithJSON(t, "PATCH", viewURL, &api.EditProjectViewOption{Title: &title}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", viewURL).AddTokenAuth(otherToken), http.StatusForbidden)
+		unittest.AssertExistsAndLoadBean(t, &project_model.View{ID: view.ID, Title: "Roadmap"})
+	})
+
+	t.Run("OtherOwner", func(t *testing.T) {
+		// the project of user2 cannot be reached through org3, even by its owner
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/projects/4/views").AddTokenAuth(ownerToken), http.StatusNotFound)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/projects/4/views", &api.CreateProjectViewOption{Title: "Table"}).AddTokenAuth(ownerToken)
+		MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertNotExistsBean(t, &project_model.View{ProjectID: 4})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "DELETE", viewURL).AddTokenAuth(ownerToken), http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &project_model.View{ID: view.ID})
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	cargo_module "forgejo.org/modules/packages/cargo"
+	npm_module "forgejo.org/modules/packages/npm"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAccessActions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// task 47 is running in user5/repo4
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	token := "8061e833a55f6fc0157c98b883e91fcfeeb1a71a"
+
+	content := []byte{1, 2, 3}
+	upload := func(t *testing.T, name string, expectedStatus int) {
+		t.Helper()
+
+		url := fmt.Sprintf("/api/packages/%s/generic/%s/1.0.0/file.bin", owner.Name, name)
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	t.Run("NoGrant", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		upload(t, "granted", http.StatusUnauthorized)
+	})
+
+	t.Run("Grant", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, owner.Name)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/access/add", map[string]string{
+			"type":        "generic",
+			"name":        "Granted",
+			"repository":  "repo4",
+			"access_mode": "write",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		pas, err := packages_model.GetAccessesByPackage(db.DefaultContext, owner.ID, packages_model.TypeGeneric, "granted")
+		require.NoError(t, err)
+		require.Len(t, pas, 1)
+		assert.EqualValues(t, 4, pas[0].RepoID)
+		assert.Equal(t, perm.AccessModeWrite, pas[0].AccessMode)
+
+		req = NewRequest(t, "GET", "/user/settings/packages")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, fmt.Sprintf(`button[data-url="/user/settings/packages/access/delete"][data-id="%d"]`, pas[0].ID), true)
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		upload(t, "granted", http.StatusCreated)
+		upload(t, "other", http.StatusForbidden)
+
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, owner.ID, packages_model.TypeGeneric, "granted", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, user_model.ActionsUserID, pv.CreatorID)
+
+		pd, err := packages_model.GetPackageDescriptor(db.DefaultContext, pv)
+		require.NoError(t, err)
+		assert.True(t, pd.Creator.IsActions())
+
+		_, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, owner.ID, packages_model.TypeGeneric, "other", "1.0.0")
+		require.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/other/1.0.0/file.bin", owner.Name), bytes.NewReader(content)).
+			AddBasicAuth(owner.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/other/1.0.0", owner.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/other/1.0.0/file.bin", owner.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/packages/%s/generic/granted/1.0.0", owner.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+
+	insertVersion := func(t *testing.T, packageType packages_model.Type, name string) *packages_model.PackageVersion {
+		t.Helper()
+
+		p, err := packages_model.TryInsertPackage(db.DefaultContext, &packages_model.Package{
+			OwnerID:   owner.ID,
+			Type:      packageType,
+			Name:      name,
+			LowerName: name,
+		})
+		require.NoError(t, err)
+		pv, err := packages_model.GetOrInsertVersion(db.DefaultContext, &packages_model.PackageVersion{
+			PackageID:    p.ID,
+			CreatorID:    owner.ID,
+			Version:      "1.0.0",
+			LowerVersion: "1.0.0",
+			MetadataJSON: "{}",
+		})
+		require.NoError(t, err)
+		return pv
+	}
+
+	t.Run("Tags", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pv := insertVersion(t, packages_model.TypeNpm, "other")
+		_, err := packages_model.InsertProperty(db.DefaultContext, packages_model.PropertyTypeVersion, pv.ID, npm_module.TagProperty, "latest")
+		require.NoError(t, err)
+
+		tagURL := fmt.Sprintf("/api/packages/%s/npm/-/package/other/dist-tags", owner.Name)
+		req := NewRequestWithBody(t, "PUT", tagURL+"/beta", strings.NewReader(`"1.0.0"`)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+		req = NewRequest(t, "DELETE", tagURL+"/latest").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		pvps, err := packages_model.GetPropertiesByName(db.DefaultContext, packages_model.PropertyTypeVersion, pv.ID, npm_module.TagProperty)
+		require.NoError(t, err)
+		require.Len(t, pvps, 1)
+		assert.Equal(t, "latest", pvps[0].Value)
+	})
+
+	t.Run("Yank", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pv := insertVersion(t, packages_model.TypeCargo, "other")
+		_, err := packages_model.InsertProperty(db.DefaultContext, packages_model.PropertyTypeVersion, pv.ID, cargo_module.PropertyYanked, "false")
+		require.NoError(t, err)
+
+		crateURL := fmt.Sprintf("/api/packages/%s/cargo/api/v1/crates/other/1.0.0", owner.Name)
+		req := NewRequest(t, "DELETE", crateURL+"/yank").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+		req = NewRequest(t, "PUT", crateURL+"/unyank").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		pps, err := packages_model.GetPropertiesByName(db.DefaultContext, packages_model.PropertyTypeVersion, pv.ID, cargo_module.PropertyYanked)
+		require.NoError(t, err)
+		require.Len(t, pps, 1)
+		assert.Equal(t, "false", pps[0].Value)
+	})
+
+	t.Run("RemoveGrant", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pas, err := packages_model.GetAccessesByOwner(db.DefaultContext, owner.ID)
+		require.NoError(t, err)
+		require.Len(t, pas, 1)
+
+		session := loginUser(t, owner.Name)
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/access/delete", map[string]string{
+			"id": fmt.Sprint(pas[0].ID),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		upload(t, "granted", http.StatusUnauthorized)
+	})
+}
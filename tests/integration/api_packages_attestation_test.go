@@ -0,0 +1,233 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/packages/attestation"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	packages_service "forgejo.org/services/packages"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAttestation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user.Name)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWritePackage)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	keysFile := filepath.Join(t.TempDir(), "trusted.pem")
+	require.NoError(t, os.WriteFile(keysFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	defer test.MockVariableValue(&setting.Packages.TrustedKeysFile, keysFile)()
+
+	sign := func(t *testing.T, content []byte) string {
+		digest := sha256.Sum256(content)
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+		require.NoError(t, err)
+
+		bundle, err := json.Marshal(map[string]any{
+			"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+			"messageSignature": map[string]any{
+				"messageDigest": map[string]string{
+					"algorithm": "SHA2_256",
+					"digest":    base64.StdEncoding.EncodeToString(digest[:]),
+				},
+				"signature": base64.StdEncoding.EncodeToString(sig),
+			},
+		})
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(bundle)
+	}
+
+	packageName := "signed-package"
+	content := []byte{1, 2, 3}
+
+	uploadURL := func(version string) string {
+		return fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, version)
+	}
+	apiURL := func(version string) string {
+		return fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/attestations", user.Name, packageName, version)
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", uploadURL("1.0.0"), bytes.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("X-Package-Signature", "not base64")
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", uploadURL("1.0.0"), bytes.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("X-Package-Signature", sign(t, []byte{4, 5, 6}))
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", uploadURL("1.0.0"), bytes.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("X-Package-Signature", sign(t, content))
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", apiURL("1.0.0")).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var attestations []*api.PackageAttestation
+		DecodeJSON(t, resp, &attestations)
+		require.Len(t, attestations, 1)
+		assert.Equal(t, "sigstore", attestations[0].Type)
+		assert.Equal(t, "file.bin", attestations[0].File)
+		assert.True(t, attestations[0].Verified)
+
+		fingerprint, err := attestation.KeyFingerprint(&priv.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, attestations[0].KeyID)
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, packages_service.SetRequiresSignature(db.DefaultContext, user, true))
+		defer func() {
+			require.NoError(t, packages_service.SetRequiresSignature(db.DefaultContext, user, false))
+		}()
+
+		req := NewRequestWithBody(t, "PUT", uploadURL("1.0.1"), bytes.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", uploadURL("1.0.1"), bytes.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("X-Package-Signature", sign(t, content))
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("ContainerPolicy", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, packages_service.SetRequiresSignature(db.DefaultContext, user, true))
+		defer func() {
+			require.NoError(t, packages_service.SetRequiresSignature(db.DefaultContext, user, false))
+		}()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%sv2/token", setting.AppURL)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var tokenResponse struct {
+			Token string `json:"token"`
+		}
+		DecodeJSON(t, resp, &tokenResponse)
+		containerToken := tokenResponse.Token
+
+		url := fmt.Sprintf("%sv2/%s/signed-image", setting.AppURL, user.Name)
+		configContent := []byte("{}")
+		configDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(configContent))
+		req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, configDigest), bytes.NewReader(configContent)).
+			AddTokenAuth(containerToken)
+		MakeRequest(t, req, http.StatusCreated)
+
+		manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.example.signed","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"%s","size":%d},"layers":[]}`, configDigest, len(configContent)))
+
+		req = NewRequestWithBody(t, "PUT", url+"/manifests/v1", bytes.NewReader(manifest)).
+			AddTokenAuth(containerToken).
+			SetHeader("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", url+"/manifests/v1", bytes.NewReader(manifest)).
+			AddTokenAuth(containerToken).
+			SetHeader("Content-Type", "application/vnd.oci.image.manifest.v1+json").
+			SetHeader("X-Package-Signature", sign(t, []byte{4, 5, 6}))
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", url+"/manifests/v1", bytes.NewReader(manifest)).
+			AddTokenAuth(containerToken).
+			SetHeader("Content-Type", "application/vnd.oci.image.manifest.v1+json").
+			SetHeader("X-Package-Signature", sign(t, manifest))
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("Provenance", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		digest := sha256.Sum256(content)
+		statement, err := attestation.NewProvenance([]attestation.Subject{
+			{Name: "file.bin", Digest: map[string]string{"sha256": fmt.Sprintf("%x", digest)}},
+		}, &attestation.BuildInfo{BuilderID: "https://builder.example.com"})
+		require.NoError(t, err)
+
+		req := NewRequestWithJSON(t, "POST", apiURL("1.0.0"), &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Content: string(statement),
+		})
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithJSON(t, "POST", apiURL("1.0.0"), &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Content: string(statement),
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var pa *api.PackageAttestation
+		DecodeJSON(t, resp, &pa)
+		assert.Equal(t, "provenance", pa.Type)
+		assert.Equal(t, "https://builder.example.com", pa.BuilderID)
+		assert.False(t, pa.Verified)
+
+		other, err := attestation.NewProvenance([]attestation.Subject{
+			{Name: "other.bin", Digest: map[string]string{"sha256": fmt.Sprintf("%x", sha256.Sum256([]byte{7}))}},
+		}, &attestation.BuildInfo{BuilderID: "https://builder.example.com"})
+		require.NoError(t, err)
+
+		req = NewRequestWithJSON(t, "POST", apiURL("1.0.0"), &api.CreatePackageAttestationOption{
+			Type:    "provenance",
+			Content: string(other),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", apiURL("1.0.0"), pa.ID)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", apiURL("1.0.0"), pa.ID)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("ActionsProvenance", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", apiURL("1.0.0")+"/actions", &api.CreatePackageActionsProvenanceOption{
+			IDToken: "invalid",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+}
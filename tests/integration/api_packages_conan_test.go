@@ -20,6 +20,7 @@ import (
 	conan_module "forgejo.org/modules/packages/conan"
 	"forgejo.org/modules/setting"
 	conan_router "forgejo.org/routers/api/packages/conan"
+	packages_service "forgejo.org/services/packages"
 	"forgejo.org/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -796,3 +797,40 @@ func TestPackageConan(t *testing.T) {
 		})
 	})
 }
+
+func TestPackageConanImmutableVersion(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	url := fmt.Sprintf("%sapi/packages/%s/conan", setting.AppURL, user.Name)
+
+	req := NewRequest(t, "GET", fmt.Sprintf("%s/v2/users/authenticate", url)).
+		AddBasicAuth(user.Name)
+	token := MakeRequest(t, req, http.StatusOK).Body.String()
+
+	uploadConanPackageV2(t, url, token, "ImmutablePackage", "1.0", "dummy", "test", "rev1", "rev1")
+	packageURL := fmt.Sprintf("%s/v2/conans/ImmutablePackage/1.0/dummy/test/revisions/rev1/packages/%s/revisions/rev1", url, conanPackageReference)
+
+	uploadFile := func(t *testing.T, filename, content string, expectedStatus int) {
+		t.Helper()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/files/%s", packageURL, filename), strings.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	uploadFile(t, "conan_package.tgz", "binaries", http.StatusCreated)
+	uploadFile(t, "conanmanifest.txt", "manifest", http.StatusCreated)
+
+	require.NoError(t, packages_service.SetImmutableTypes(db.DefaultContext, user, []packages.Type{packages.TypeConan}))
+
+	// the files of the version can be uploaded again, but not replaced
+	uploadFile(t, "conan_package.tgz", "binaries", http.StatusCreated)
+	uploadFile(t, "conan_package.tgz", "other binaries", http.StatusForbidden)
+	uploadFile(t, "conanmanifest.txt", "other manifest", http.StatusForbidden)
+	uploadFile(t, conaninfoName, contentConaninfo+"\n", http.StatusForbidden)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("%s/files/conan_package.tgz", packageURL)).
+		AddTokenAuth(token)
+	assert.Equal(t, "binaries", MakeRequest(t, req, http.StatusOK).Body.String())
+}
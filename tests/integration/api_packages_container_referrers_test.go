@@ -0,0 +1,176 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	packages_cleanup "forgejo.org/services/packages/cleanup"
+	"forgejo.org/tests"
+
+	digest "github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageContainerReferrers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Packages.Storage.Type, setting.LocalStorageType)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	image := "referrers"
+	url := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	uploadBlob := func(t *testing.T, url, content string) string {
+		t.Helper()
+
+		blobDigest := digest.FromString(content).String()
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, blobDigest), strings.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+		return blobDigest
+	}
+
+	uploadManifest := func(t *testing.T, reference, content string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, reference), strings.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		return MakeRequest(t, req, http.StatusCreated)
+	}
+
+	getReferrers := func(t *testing.T, subject, query string) (*oci.Index, *httptest.ResponseRecorder) {
+		t.Helper()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s%s", url, subject, query)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+		var index oci.Index
+		DecodeJSON(t, resp, &index)
+		assert.Equal(t, 2, index.SchemaVersion)
+		assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+		return &index, resp
+	}
+
+	configContent := `{"architecture":"amd64","os":"linux"}`
+	configDigest := uploadBlob(t, url, configContent)
+	layerDigest := uploadBlob(t, url, "layer")
+	emptyDigest := uploadBlob(t, url, "{}")
+	signatureDigest := uploadBlob(t, url, "signature")
+
+	imageContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","config":{"mediaType":"` + oci.MediaTypeImageConfig + `","digest":"` + configDigest + `","size":` + fmt.Sprint(len(configContent)) + `},"layers":[{"mediaType":"` + oci.MediaTypeImageLayerGzip + `","digest":"` + layerDigest + `","size":5}]}`
+	imageDigest := digest.FromString(imageContent).String()
+
+	artifactType := "application/vnd.dev.cosign.artifact.sig.v1+json"
+	referrerContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","artifactType":"` + artifactType + `","config":{"mediaType":"` + oci.MediaTypeEmptyJSON + `","digest":"` + emptyDigest + `","size":2},"layers":[{"mediaType":"application/octet-stream","digest":"` + signatureDigest + `","size":9}],"subject":{"mediaType":"` + oci.MediaTypeImageManifest + `","digest":"` + imageDigest + `","size":` + fmt.Sprint(len(imageContent)) + `},"annotations":{"dev.cosignproject.cosign/signature":"sig"}}`
+	referrerDigest := digest.FromString(referrerContent).String()
+
+	t.Run("UploadReferrer", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := uploadManifest(t, "latest", imageContent)
+		assert.Empty(t, resp.Header().Get("OCI-Subject"))
+
+		// The subject does not need to exist when the referrer is pushed
+		resp = uploadManifest(t, referrerDigest, referrerContent)
+		assert.Equal(t, referrerDigest, resp.Header().Get("Docker-Content-Digest"))
+		assert.Equal(t, imageDigest, resp.Header().Get("OCI-Subject"))
+	})
+
+	t.Run("GetReferrers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		index, resp := getReferrers(t, imageDigest, "")
+		assert.Empty(t, resp.Header().Get("OCI-Filters-Applied"))
+		require.Len(t, index.Manifests, 1)
+		assert.Equal(t, oci.MediaTypeImageManifest, index.Manifests[0].MediaType)
+		assert.Equal(t, referrerDigest, index.Manifests[0].Digest.String())
+		assert.EqualValues(t, len(referrerContent), index.Manifests[0].Size)
+		assert.Equal(t, artifactType, index.Manifests[0].ArtifactType)
+		assert.Equal(t, "sig", index.Manifests[0].Annotations["dev.cosignproject.cosign/signature"])
+
+		index, resp = getReferrers(t, imageDigest, "?artifactType="+artifactType)
+		assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+		assert.Len(t, index.Manifests, 1)
+
+		index, resp = getReferrers(t, imageDigest, "?artifactType=application/vnd.example.sbom")
+		assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+		assert.Empty(t, index.Manifests)
+
+		index, _ = getReferrers(t, referrerDigest, "")
+		assert.Empty(t, index.Manifests)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/invalid", url)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("MountWithinOwner", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		otherURL := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, "referrers-other")
+
+		req := NewRequest(t, "POST", fmt.Sprintf("%s/blobs/uploads?mount=%s", otherURL, layerDigest)).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		assert.Equal(t, fmt.Sprintf("/v2/%s/%s/blobs/%s", user.Name, "referrers-other", layerDigest), resp.Header().Get("Location"))
+
+		req = NewRequest(t, "POST", fmt.Sprintf("%s/blobs/uploads?mount=%s&from=%s/%s", otherURL, configDigest, user.Name, image)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "HEAD", fmt.Sprintf("%s/blobs/%s", otherURL, layerDigest)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("GarbageCollection", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		ctx := db.DefaultContext
+
+		// The referrer of a tagged image is neither removed by the sha256 cleanup nor the garbage collection
+		require.NoError(t, packages_cleanup.CleanupExpiredData(ctx, -time.Hour))
+		report, err := packages_cleanup.CollectContainerGarbage(ctx, -time.Hour, true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Manifests)
+
+		index, _ := getReferrers(t, imageDigest, "")
+		assert.Len(t, index.Manifests, 1)
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/manifests/%s", url, "latest")).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusAccepted)
+
+		report, err = packages_cleanup.CollectContainerGarbage(ctx, -time.Hour, true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		require.Len(t, report.Manifests, 1)
+		assert.Equal(t, referrerDigest, report.Manifests[0].Version.Version)
+		assert.Equal(t, imageDigest, report.Manifests[0].Subject)
+
+		report, err = packages_cleanup.CollectContainerGarbage(ctx, -time.Hour, false)
+		require.NoError(t, err)
+		assert.Len(t, report.Manifests, 1)
+
+		index, _ = getReferrers(t, imageDigest, "")
+		assert.Empty(t, index.Manifests)
+	})
+}
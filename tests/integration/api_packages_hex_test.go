@@ -0,0 +1,223 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	hex_module "forgejo.org/modules/packages/hex"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "test_package"
+	packageVersion := "1.0.1"
+	packageDescription := "Test Description"
+
+	createTarball := func(t *testing.T, description string) []byte {
+		metadata := fmt.Sprintf(`{<<"name">>,<<"%s">>}.
+{<<"version">>,<<"%s">>}.
+{<<"description">>,<<"%s">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>},{<<"repository">>,<<"hexpm">>}]]}.
+`, packageName, packageVersion, description)
+
+		var contents bytes.Buffer
+		zw := gzip.NewWriter(&contents)
+		tw := tar.NewWriter(zw)
+		readme := "# " + packageName
+		tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o600, Size: int64(len(readme))})
+		tw.Write([]byte(readme))
+		tw.Close()
+		zw.Close()
+
+		h := sha256.New()
+		h.Write([]byte("3"))
+		h.Write([]byte(metadata))
+		h.Write(contents.Bytes())
+
+		var buf bytes.Buffer
+		tw = tar.NewWriter(&buf)
+		for _, f := range [][2]string{
+			{"VERSION", "3"},
+			{"CHECKSUM", hex.EncodeToString(h.Sum(nil))},
+			{"metadata.config", metadata},
+			{"contents.tar.gz", contents.String()},
+		} {
+			tw.WriteHeader(&tar.Header{Name: f[0], Mode: 0o600, Size: int64(len(f[1]))})
+			tw.Write([]byte(f[1]))
+		}
+		tw.Close()
+		return buf.Bytes()
+	}
+
+	content := createTarball(t, packageDescription)
+	filename := fmt.Sprintf("%s-%s.tar", packageName, packageVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	publish := func(t *testing.T, query string, content []byte, expectedStatus int) {
+		t.Helper()
+
+		req := NewRequestWithBody(t, "POST", root+"/api/publish"+query, bytes.NewReader(content)).
+			SetHeader("Authorization", token).
+			SetHeader("Accept", "application/vnd.hex+erlang")
+		resp := MakeRequest(t, req, expectedStatus)
+		assert.Equal(t, "application/vnd.hex+erlang", resp.Header().Get("Content-Type"))
+		assert.EqualValues(t, 131, resp.Body.Bytes()[0])
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", root+"/api/publish", bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		publish(t, "", []byte("invalid"), http.StatusUnprocessableEntity)
+		publish(t, "", content, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*hex_module.Metadata).Description)
+		assert.Equal(t, "# "+packageName, pd.Metadata.(*hex_module.Metadata).Readme)
+
+		pfs, err := packages.GetFilesByVersionID(db.DefaultContext, pvs[0].ID)
+		require.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, filename, pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		publish(t, "", content, http.StatusConflict)
+	})
+
+	t.Run("Auth", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the API key without a scheme is only accepted by the Hex registries
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/hex-auth/1.0/file.bin", user.Name), bytes.NewReader([]byte("content"))).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		content = createTarball(t, "Replaced")
+		publish(t, "?replace=true", content, http.StatusCreated)
+
+		pv, err := packages.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages.TypeHex, packageName, packageVersion)
+		require.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(db.DefaultContext, pv)
+		require.NoError(t, err)
+		assert.Equal(t, "Replaced", pd.Metadata.(*hex_module.Metadata).Description)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/tarballs/"+filename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/tarballs/"+packageName+"-0.0.0.tar")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+
+		// decodes the signed resource and returns its verified payload
+		fetch := func(t *testing.T, url string) []byte {
+			t.Helper()
+
+			resp := MakeRequest(t, NewRequest(t, "GET", url), http.StatusOK)
+
+			zr, err := gzip.NewReader(resp.Body)
+			require.NoError(t, err)
+			signed, err := io.ReadAll(zr)
+			require.NoError(t, err)
+
+			var payload, signature []byte
+			for len(signed) > 0 {
+				num, _, n := protowire.ConsumeTag(signed)
+				require.Positive(t, n)
+				signed = signed[n:]
+				v, n := protowire.ConsumeBytes(signed)
+				require.Positive(t, n)
+				signed = signed[n:]
+				if num == 1 {
+					payload = v
+				} else {
+					signature = v
+				}
+			}
+
+			h := sha512.Sum512(payload)
+			require.NoError(t, rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA512, h[:], signature))
+			return payload
+		}
+
+		for _, url := range []string{root + "/names", root + "/versions", root + "/packages/" + packageName} {
+			payload := fetch(t, url)
+			assert.Contains(t, string(payload), packageName)
+			assert.Contains(t, string(payload), user.Name)
+		}
+
+		payload := fetch(t, root+"/packages/"+packageName)
+		assert.Contains(t, string(payload), packageVersion)
+		assert.Contains(t, string(payload), "jason")
+		outer := sha256.Sum256(content)
+		assert.Contains(t, string(payload), string(outer[:]))
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/packages/unknown"), http.StatusNotFound)
+
+		// the resources of all owners are signed with the key of the instance
+		other := MakeRequest(t, NewRequest(t, "GET", "/api/packages/user4/hex/public_key"), http.StatusOK)
+		assert.Equal(t, resp.Body.Bytes(), other.Body.Bytes())
+	})
+}
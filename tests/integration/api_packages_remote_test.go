@@ -0,0 +1,318 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/hostmatcher"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	cleanup_service "forgejo.org/services/packages/cleanup"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Packages.RemoteAllowedHostList, hostmatcher.MatchBuiltinLoopback)()
+
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	session := loginUser(t, "user2")
+
+	npmTarball := []byte("npm tarball content")
+	npmSHA1 := sha1.Sum(npmTarball)
+	npmSHA512 := sha512.Sum512(npmTarball)
+	pypiWheel := []byte("pypi wheel content")
+	pypiSHA256 := sha256.Sum256(pypiWheel)
+	mavenJar := []byte("maven jar content")
+	mavenJarSHA1 := sha1.Sum(mavenJar)
+	mavenPom := []byte(`<?xml version="1.0"?><project><groupId>com.example</groupId><artifactId>lib</artifactId><version>1.0</version><description>Upstream library</description></project>`)
+	goModContent := "module example.com/Upstream/mod\n"
+
+	var goZip bytes.Buffer
+	zw := zip.NewWriter(&goZip)
+	w, _ := zw.Create("example.com/Upstream/mod@v1.0.0/go.mod")
+	_, _ = w.Write([]byte(goModContent))
+	require.NoError(t, zw.Close())
+
+	var offline atomic.Bool
+	var hits atomic.Int64
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if offline.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/npm/@scope%2fupstream", "/npm/@scope/upstream":
+			_, _ = fmt.Fprintf(w, `{"name":"@scope/upstream","dist-tags":{"latest":"1.2.3"},"versions":{"1.2.3":{"name":"@scope/upstream","version":"1.2.3","description":"Upstream package","dist":{"integrity":"sha512-%s","shasum":"%s","tarball":"%s/npm/tarballs/upstream-1.2.3.tgz"}}}}`,
+				base64.StdEncoding.EncodeToString(npmSHA512[:]), hex.EncodeToString(npmSHA1[:]), upstreamURL)
+		case "/npm/tarballs/upstream-1.2.3.tgz":
+			_, _ = w.Write(npmTarball)
+		case "/pypi/upstream-pkg/":
+			_, _ = fmt.Fprintf(w, `<html><body><a href="../../files/upstream_pkg-2.0-py3-none-any.whl#sha256=%s" data-requires-python="&gt;=3.8">upstream_pkg-2.0-py3-none-any.whl</a></body></html>`, hex.EncodeToString(pypiSHA256[:]))
+		case "/files/upstream_pkg-2.0-py3-none-any.whl":
+			_, _ = w.Write(pypiWheel)
+		case "/pypi/large-pkg/":
+			_, _ = w.Write([]byte(`<html><body><a href="../../files/large_pkg-1.0-py3-none-any.whl">large_pkg-1.0-py3-none-any.whl</a></body></html>`))
+		case "/files/large_pkg-1.0-py3-none-any.whl":
+			_, _ = w.Write(bytes.Repeat([]byte("x"), 1024))
+		case "/maven/com/example/lib/maven-metadata.xml":
+			_, _ = w.Write([]byte(`<?xml version="1.0"?><metadata><groupId>com.example</groupId><artifactId>lib</artifactId><versioning><latest>1.0</latest><versions><version>1.0</version></versions></versioning></metadata>`))
+		case "/maven/com/example/lib/1.0/lib-1.0.jar":
+			_, _ = w.Write(mavenJar)
+		case "/maven/com/example/lib/1.0/lib-1.0.jar.sha1":
+			_, _ = w.Write([]byte(hex.EncodeToString(mavenJarSHA1[:]) + "  lib-1.0.jar"))
+		case "/maven/com/example/lib/1.0/lib-1.0.pom":
+			_, _ = w.Write(mavenPom)
+		case "/go/example.com/!upstream/mod/@v/list":
+			_, _ = w.Write([]byte("v1.0.0\n"))
+		case "/go/example.com/!upstream/mod/@latest":
+			_, _ = w.Write([]byte(`{"Version":"v1.0.0"}`))
+		case "/go/example.com/!upstream/mod/@v/v1.0.0.zip":
+			_, _ = w.Write(goZip.Bytes())
+		case "/go/example.com/!upstream/mod/@v/v1.0.0.mod":
+			_, _ = w.Write([]byte(goModContent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	root := fmt.Sprintf("/api/packages/%s", org.Name)
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		for _, pt := range []string{"npm", "pypi", "maven", "go"} {
+			req := NewRequestWithValues(t, "POST", fmt.Sprintf("/org/%s/settings/packages/remotes/add", org.Name), map[string]string{
+				"type":                 pt,
+				"url":                  upstream.URL + "/" + pt,
+				"metadata_ttl_minutes": "60",
+			})
+			session.MakeRequest(t, req, http.StatusSeeOther)
+		}
+
+		remotes, err := packages_model.GetRemotesByOwner(db.DefaultContext, org.ID)
+		require.NoError(t, err)
+		assert.Len(t, remotes, 4)
+		assert.EqualValues(t, 3600, remotes[0].MetadataTTL)
+
+		// only one remote per package type
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/org/%s/settings/packages/remotes/add", org.Name), map[string]string{
+			"type":                 "npm",
+			"url":                  upstream.URL + "/other",
+			"metadata_ttl_minutes": "60",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		remotes, err = packages_model.GetRemotesByOwner(db.DefaultContext, org.ID)
+		require.NoError(t, err)
+		assert.Len(t, remotes, 4)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/org/%s/settings/packages", org.Name))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), upstream.URL+"/pypi")
+	})
+
+	assertCached := func(t *testing.T, packageType packages_model.Type, name, version string) {
+		t.Helper()
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packageType, name, version)
+		require.NoError(t, err)
+		assert.True(t, pv.UpstreamOnly)
+	}
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/npm/@scope%2fupstream").AddBasicAuth("user2")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var metadata struct {
+			Versions map[string]struct {
+				Dist struct {
+					Tarball string `json:"tarball"`
+				} `json:"dist"`
+			} `json:"versions"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &metadata))
+		tarball := metadata.Versions["1.2.3"].Dist.Tarball
+		assert.Equal(t, setting.AppURL+"api/packages/org3/npm/%40scope%2Fupstream/-/1.2.3/upstream-1.2.3.tgz", tarball)
+
+		req = NewRequest(t, "GET", strings.TrimPrefix(tarball, setting.AppURL[:len(setting.AppURL)-1])).AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, npmTarball, resp.Body.Bytes())
+		assertCached(t, packages_model.TypeNpm, "@scope/upstream", "1.2.3")
+
+		req = NewRequest(t, "GET", root+"/npm/-/package/@scope%2fupstream/dist-tags").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		var tags map[string]string
+		DecodeJSON(t, resp, &tags)
+		assert.Equal(t, map[string]string{"latest": "1.2.3"}, tags)
+
+		// the metadata and the cached tarball are served while the remote is unreachable
+		offline.Store(true)
+		defer offline.Store(false)
+		before := hits.Load()
+
+		req = NewRequest(t, "GET", root+"/npm/@scope%2fupstream").AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequest(t, "GET", strings.TrimPrefix(tarball, setting.AppURL[:len(setting.AppURL)-1])).AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, npmTarball, resp.Body.Bytes())
+		assert.Equal(t, before, hits.Load())
+
+		req = NewRequest(t, "GET", root+"/npm/@scope%2fupstream/-/9.9.9/upstream-9.9.9.tgz").AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/pypi/simple/upstream_pkg").AddBasicAuth("user2")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		link := htmlDoc.doc.Find("a")
+		href, _ := link.Attr("href")
+		assert.Equal(t, fmt.Sprintf("%sapi/packages/org3/pypi/files/upstream-pkg/2.0/upstream_pkg-2.0-py3-none-any.whl#sha256=%s", setting.AppURL, hex.EncodeToString(pypiSHA256[:])), href)
+		requiresPython, _ := link.Attr("data-requires-python")
+		assert.Equal(t, ">=3.8", requiresPython)
+
+		req = NewRequest(t, "GET", root+"/pypi/files/upstream-pkg/2.0/upstream_pkg-2.0-py3-none-any.whl").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, pypiWheel, resp.Body.Bytes())
+		assertCached(t, packages_model.TypePyPI, "upstream-pkg", "2.0")
+	})
+
+	t.Run("SizeLimit", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		fileURL := root + "/pypi/files/large-pkg/1.0/large_pkg-1.0-py3-none-any.whl"
+
+		t.Run("Type", func(t *testing.T) {
+			defer test.MockVariableValue(&setting.Packages.LimitSizePyPI, 512)()
+
+			MakeRequest(t, NewRequest(t, "GET", fileURL).AddBasicAuth("user2"), http.StatusRequestEntityTooLarge)
+			_, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypePyPI, "large-pkg", "1.0")
+			require.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+		})
+
+		t.Run("Owner", func(t *testing.T) {
+			totalSize, err := packages_model.CalculateFileSize(db.DefaultContext, &packages_model.PackageFileSearchOptions{OwnerID: org.ID})
+			require.NoError(t, err)
+			defer test.MockVariableValue(&setting.Packages.LimitTotalOwnerSize, totalSize+512)()
+
+			MakeRequest(t, NewRequest(t, "GET", fileURL).AddBasicAuth("user2"), http.StatusForbidden)
+			_, err = packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypePyPI, "large-pkg", "1.0")
+			require.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+		})
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/maven/com/example/lib/maven-metadata.xml").AddBasicAuth("user2")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<latest>1.0</latest>")
+
+		req = NewRequest(t, "GET", root+"/maven/com/example/lib/1.0/lib-1.0.jar").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, mavenJar, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/maven/com/example/lib/1.0/lib-1.0.pom.sha1").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		pomSHA1 := sha1.Sum(mavenPom)
+		assert.Equal(t, hex.EncodeToString(pomSHA1[:]), resp.Body.String())
+
+		assertCached(t, packages_model.TypeMaven, "com.example:lib", "1.0")
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, org.ID, packages_model.TypeMaven, "com.example:lib", "1.0")
+		require.NoError(t, err)
+		assert.Contains(t, pv.MetadataJSON, "Upstream library")
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/go/example.com/!upstream/mod/@v/list").AddBasicAuth("user2")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "v1.0.0\n", resp.Body.String())
+
+		req = NewRequest(t, "GET", root+"/go/example.com/!upstream/mod/@latest").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"Version":"v1.0.0"`)
+
+		req = NewRequest(t, "GET", root+"/go/example.com/!upstream/mod/@v/v1.0.0.mod").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, goModContent, resp.Body.String())
+
+		req = NewRequest(t, "GET", root+"/go/example.com/!upstream/mod/@v/v1.0.0.zip").AddBasicAuth("user2")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, goZip.Bytes(), resp.Body.Bytes())
+		assertCached(t, packages_model.TypeGo, "example.com/Upstream/mod", "v1.0.0")
+	})
+
+	t.Run("PublishedShadowsRemote", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		content := []byte("published content")
+		req := NewRequestWithBody(t, "PUT", root+"/maven/com/example/lib/2.0/lib-2.0.jar", bytes.NewReader(content)).AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the package is published, the remote is not used anymore
+		req = NewRequest(t, "GET", root+"/maven/com/example/lib/maven-metadata.xml").AddBasicAuth("user2")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<latest>2.0</latest>")
+
+		before := hits.Load()
+		req = NewRequest(t, "GET", root+"/maven/com/example/lib/3.0/lib-3.0.jar").AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusNotFound)
+		assert.Equal(t, before, hits.Load())
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pcr := &packages_model.PackageCleanupRule{
+			Enabled:       true,
+			OwnerID:       org.ID,
+			Type:          packages_model.TypeMaven,
+			RemovePattern: ".*",
+			UpstreamOnly:  true,
+		}
+		targets, err := cleanup_service.GetCleanupTargets(db.DefaultContext, pcr, true)
+		require.NoError(t, err)
+		require.Len(t, targets, 1)
+		assert.Equal(t, "1.0", targets[0].PackageVersion.Version)
+
+		count, err := packages_model.CountVersions(db.DefaultContext, &packages_model.PackageSearchOptions{
+			OwnerID:      org.ID,
+			UpstreamOnly: optional.Some(true),
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, count)
+	})
+}
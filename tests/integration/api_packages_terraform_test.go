@@ -0,0 +1,310 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	root := "/api/packages/terraform/v1"
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, setting.AppSubURL+root+"/modules/", result["modules.v1"])
+		assert.Equal(t, setting.AppSubURL+root+"/providers/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "network"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Network"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": `variable "cidr" {}`, "README.md": readme} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		moduleURL := fmt.Sprintf("%s/modules/%s/%s/%s", root, user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			uploadURL := fmt.Sprintf("%s/%s", moduleURL, moduleVersion)
+
+			req := NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/v%s", moduleURL, moduleVersion), bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader([]byte("invalid"))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			assert.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			require.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			metadata, ok := pd.Metadata.(*terraform_module.Metadata)
+			require.True(t, ok)
+			assert.Equal(t, terraform_module.KindModule, metadata.Kind)
+			assert.Equal(t, readme, metadata.Readme)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", moduleURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/modules/%s/%s/gcp/versions", root, user.Name, moduleName))
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/%s/download", moduleURL, moduleVersion))
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			archiveURL := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, fmt.Sprintf("%s%s/%s/archive.tar.gz", setting.AppURL, strings.TrimPrefix(moduleURL, "/"), moduleVersion), archiveURL)
+
+			req = NewRequest(t, "GET", archiveURL)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/1.0.0/download", moduleURL))
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "0.3.1"
+
+		createArchive := func(executable string) []byte {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			w, _ := zw.Create(executable)
+			w.Write([]byte(executable))
+			zw.Close()
+			return buf.Bytes()
+		}
+
+		linuxContent := createArchive(fmt.Sprintf("terraform-provider-%s_v%s", providerType, providerVersion))
+		darwinContent := createArchive(fmt.Sprintf("terraform-provider-%s_v%s_x5", providerType, providerVersion))
+
+		providerURL := fmt.Sprintf("%s/providers/%s/%s", root, user.Name, providerType)
+		versionURL := fmt.Sprintf("%s/%s", providerURL, providerVersion)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			linuxFilename := terraform_module.ProviderFilename(providerType, providerVersion, "linux", "amd64")
+
+			req := NewRequestWithBody(t, "PUT", versionURL+"/"+linuxFilename, bytes.NewReader(linuxContent))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			for _, filename := range []string{
+				terraform_module.ProviderFilename("other", providerVersion, "linux", "amd64"),
+				terraform_module.ProviderFilename(providerType, "1.0.0", "linux", "amd64"),
+				terraform_module.ShasumsFilename(providerType, providerVersion),
+			} {
+				req = NewRequestWithBody(t, "PUT", versionURL+"/"+filename, bytes.NewReader(linuxContent)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusBadRequest)
+			}
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+linuxFilename, bytes.NewReader(createArchive("README.md"))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+linuxFilename, bytes.NewReader(linuxContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+terraform_module.ProviderFilename(providerType, providerVersion, "darwin", "arm64"), bytes.NewReader(darwinContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+terraform_module.ManifestFilename(providerType, providerVersion), strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", versionURL+"/"+linuxFilename, bytes.NewReader(linuxContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+
+			pv, err := packages.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pv)
+			require.NoError(t, err)
+			metadata, ok := pd.Metadata.(*terraform_module.Metadata)
+			require.True(t, ok)
+			assert.Equal(t, terraform_module.KindProvider, metadata.Kind)
+			assert.Equal(t, []string{"6.0"}, metadata.Protocols)
+			// two archives, the manifest, the checksums and the signature
+			assert.Len(t, pd.Files, 5)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", providerURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			assert.Len(t, result.Versions[0].Platforms, 2)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", versionURL+"/download/windows/amd64")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", versionURL+"/download/linux/amd64")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				ShasumsURL          string   `json:"shasums_url"`
+				ShasumsSignatureURL string   `json:"shasums_signature_url"`
+				Shasum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			hash := sha256.Sum256(linuxContent)
+
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, terraform_module.ProviderFilename(providerType, providerVersion, "linux", "amd64"), result.Filename)
+			assert.Equal(t, hex.EncodeToString(hash[:]), result.Shasum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			req = NewRequest(t, "GET", result.DownloadURL)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, linuxContent, resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", result.ShasumsURL)
+			resp = MakeRequest(t, req, http.StatusOK)
+			shasums := resp.Body.Bytes()
+			assert.Contains(t, string(shasums), fmt.Sprintf("%s  %s\n", result.Shasum, result.Filename))
+			assert.Len(t, strings.Split(strings.TrimSpace(string(shasums)), "\n"), 2)
+
+			req = NewRequest(t, "GET", result.ShasumsSignatureURL)
+			resp = MakeRequest(t, req, http.StatusOK)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(shasums), bytes.NewReader(resp.Body.Bytes()), nil)
+			require.NoError(t, err)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].KeyID, strings.ToUpper(signer.PrimaryKey.KeyIdString()))
+		})
+	})
+}
@@ -711,6 +711,94 @@ func TestPackageCleanup(t *testing.T) {
 	})
 }
 
+func TestPackageImmutableVersions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	root := fmt.Sprintf("/api/packages/%s/generic/immutable-package", user.Name)
+
+	uploadFile := func(t *testing.T, version, filename string) {
+		t.Helper()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/%s/%s", root, version, filename), bytes.NewReader([]byte{1})).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	uploadFile(t, "1.0.0", "file.bin")
+	uploadFile(t, "1.0.0", "other.bin")
+	uploadFile(t, "1.1.0-rc1", "file.bin")
+
+	t.Run("LastDownload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, "immutable-package", "1.0.0")
+		require.NoError(t, err)
+
+		last, err := packages_model.GetVersionLastDownload(db.DefaultContext, pv.ID)
+		require.NoError(t, err)
+		assert.Zero(t, last)
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/1.0.0/file.bin"), http.StatusOK)
+
+		last, err = packages_model.GetVersionLastDownload(db.DefaultContext, pv.ID)
+		require.NoError(t, err)
+		assert.NotZero(t, last)
+	})
+
+	require.NoError(t, packages_service.SetImmutableTypes(db.DefaultContext, user, []packages_model.Type{packages_model.TypeGeneric}))
+
+	t.Run("DeleteRelease", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", root+"/1.0.0/other.bin").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", root+"/1.0.0").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/packages/%s/generic/immutable-package/1.0.0", user.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		_, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, "immutable-package", "1.0.0")
+		require.NoError(t, err)
+	})
+
+	t.Run("DeletePrerelease", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", root+"/1.1.0-rc1").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+
+	require.NoError(t, packages_service.SetImmutableTypes(db.DefaultContext, user, nil))
+
+	t.Run("DeleteMutable", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", root+"/1.0.0").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+
+	t.Run("Instance", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.Packages.ImmutableTypes, []string{"generic"})()
+
+		uploadFile(t, "2.0.0", "file.bin")
+
+		req := NewRequest(t, "DELETE", root+"/2.0.0").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
+
 func TestPackageWithTwoFactor(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
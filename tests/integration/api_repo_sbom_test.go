@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/sbom"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoSBOM(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", nil, nil,
+			[]*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  "go.sum",
+					ContentReader: strings.NewReader(`example.com/a v1.2.3 h1:abc=
+example.com/a v1.2.3/go.mod h1:def=
+`),
+				},
+				{
+					Operation: "create",
+					TreePath:  "web/package-lock.json",
+					ContentReader: strings.NewReader(`{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web"},
+    "node_modules/lodash": {"version": "4.17.21"}
+  }
+}`),
+				},
+				{
+					Operation:     "create",
+					TreePath:      "main.go",
+					ContentReader: strings.NewReader("package main\n\nfunc main() {}\n"),
+				},
+			},
+		)
+		defer f()
+
+		session := loginUser(t, user2.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		link := fmt.Sprintf("/api/v1/repos/%s/sbom", repo.FullName())
+
+		t.Run("CycloneDX", func(t *testing.T) {
+			resp := MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), http.StatusOK)
+
+			var doc sbom.CycloneDX
+			DecodeJSON(t, resp, &doc)
+			assert.Equal(t, "CycloneDX", doc.BOMFormat)
+			assert.Equal(t, repo.FullName(), doc.Metadata.Component.Name)
+			assert.Equal(t, repo.DefaultBranch, doc.Metadata.Component.Version)
+			hasGo := false
+			for _, p := range doc.Metadata.Properties {
+				hasGo = hasGo || p.Name == "forgejo:language:Go"
+			}
+			assert.True(t, hasGo)
+			require.Len(t, doc.Components, 2)
+			assert.Equal(t, "pkg:golang/example.com/a@v1.2.3", doc.Components[0].PackageURL)
+			assert.Equal(t, "pkg:npm/lodash@4.17.21", doc.Components[1].PackageURL)
+		})
+
+		t.Run("SPDX", func(t *testing.T) {
+			resp := MakeRequest(t, NewRequest(t, "GET", link+"?format=spdx&ref="+repo.DefaultBranch).AddTokenAuth(token), http.StatusOK)
+
+			var doc sbom.SPDX
+			DecodeJSON(t, resp, &doc)
+			assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+			assert.Len(t, doc.Packages, 3)
+			assert.Len(t, doc.Relationships, 3)
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			MakeRequest(t, NewRequest(t, "GET", link+"?format=xml").AddTokenAuth(token), http.StatusBadRequest)
+			MakeRequest(t, NewRequest(t, "GET", link+"?ref=unknown-branch").AddTokenAuth(token), http.StatusNotFound)
+		})
+
+		t.Run("Release", func(t *testing.T) {
+			defer test.MockVariableValue(&setting.Repository.Release.AttachSBOM, true)()
+
+			release := createNewReleaseUsingAPI(t, token, user2, repo, "v1.0.0", repo.DefaultBranch, "v1.0.0", "")
+			for _, format := range sbom.Formats {
+				unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ReleaseID: release.ID, Name: format.FileName()})
+			}
+		})
+	})
+}
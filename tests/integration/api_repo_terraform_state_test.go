@@ -0,0 +1,171 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	quota_model "forgejo.org/models/quota"
+	repo_model "forgejo.org/models/repo"
+	terraform_model "forgejo.org/models/terraform"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/storage"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadRepository)
+
+	stateName := "production"
+	stateURL := fmt.Sprintf("/api/v1/repos/%s/terraform/state/%s", repo.FullName(), stateName)
+
+	content := `{"version":4,"terraform_version":"1.9.0","serial":3,"lineage":"5f4e3d2c","outputs":{"secret":{"value":"hunter2","type":"string"}},"resources":[]}`
+
+	lockBody := func(id string) io.Reader {
+		raw, _ := json.Marshal(&terraform_model.LockInfo{ID: id, Operation: "OperationTypeApply", Who: "user2@workstation"})
+		return bytes.NewReader(raw)
+	}
+
+	t.Run("NotExist", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", stateURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("ReadOnlyToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, lockBody("read-only")).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, lockBody("lock-1")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, lockBody("lock-2")).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusLocked)
+
+		var info terraform_model.LockInfo
+		DecodeJSON(t, resp, &info)
+		assert.Equal(t, "lock-1", info.ID)
+		assert.Equal(t, "user2@workstation", info.Who)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", stateURL, strings.NewReader(content)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=lock-2", strings.NewReader(content)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=lock-1", strings.NewReader("not a state")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=lock-1", strings.NewReader(content)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", stateURL).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.String())
+
+		s := unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: repo.ID, Name: stateName})
+		v := unittest.AssertExistsAndLoadBean(t, &terraform_model.StateVersion{StateID: s.ID, Version: 1})
+		assert.EqualValues(t, 3, v.Serial)
+		assert.Equal(t, "5f4e3d2c", v.Lineage)
+		assert.EqualValues(t, len(content), v.Size)
+
+		// the content is stored encrypted
+		f, err := storage.TerraformStates.Open(v.StoragePath())
+		require.NoError(t, err)
+		stored, err := io.ReadAll(f)
+		f.Close()
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), "hunter2")
+
+		used, err := quota_model.GetUsedForUser(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(content), used.Size.Assets.TerraformStates)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "UNLOCK", stateURL, lockBody("lock-2")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "UNLOCK", stateURL, lockBody("lock-1")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(content)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		s := unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: repo.ID, Name: stateName})
+		assert.False(t, s.IsLocked())
+		unittest.AssertCount(t, &terraform_model.StateVersion{StateID: s.ID}, 2)
+	})
+
+	t.Run("ForceUnlock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, lockBody("lock-3")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		s := unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: repo.ID, Name: stateName})
+		assert.True(t, s.IsLocked())
+
+		session := loginUser(t, user.Name)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/settings/terraform", repo.FullName()))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "lock-3")
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/settings/terraform/%d", repo.FullName(), s.ID))
+		session.MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/settings/terraform/%d/versions/1", repo.FullName(), s.ID))
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.String())
+
+		req = NewRequest(t, "POST", fmt.Sprintf("/%s/settings/terraform/%d/unlock", repo.FullName(), s.ID))
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		s = unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: repo.ID, Name: stateName})
+		assert.False(t, s.IsLocked())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", stateURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", stateURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		unittest.AssertNotExistsBean(t, &terraform_model.State{RepoID: repo.ID, Name: stateName})
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIFineGrainedAccessToken(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+
+	createToken := func(t *testing.T, opts api.CreateAccessTokenOption, expectedStatus int) *api.AccessToken {
+		t.Helper()
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/users/user2/tokens", opts).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, expectedStatus)
+		if expectedStatus != http.StatusCreated {
+			return nil
+		}
+		var token api.AccessToken
+		DecodeJSON(t, resp, &token)
+		return &token
+	}
+
+	permissions := &api.AccessTokenPermissions{
+		Contents: "read",
+		Issues:   "write",
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// an expiration date is required
+		createToken(t, api.CreateAccessTokenOption{
+			Name:         "no-expiry",
+			Permissions:  permissions,
+			Repositories: []string{"user2/repo1"},
+		}, http.StatusBadRequest)
+
+		// the repositories or an organization are required
+		createToken(t, api.CreateAccessTokenOption{
+			Name:        "no-resources",
+			Permissions: permissions,
+			ExpiresAt:   &expiresAt,
+		}, http.StatusBadRequest)
+
+		// the repositories must be accessible by the user
+		createToken(t, api.CreateAccessTokenOption{
+			Name:         "no-access",
+			Permissions:  permissions,
+			Repositories: []string{"user15/big_test_private_1"},
+			ExpiresAt:    &expiresAt,
+		}, http.StatusBadRequest)
+
+		// the expiry must not exceed the maximum lifetime
+		defer test.MockVariableValue(&setting.AccessTokenMaxLifetime, 24*time.Hour)()
+		createToken(t, api.CreateAccessTokenOption{
+			Name:         "too-late",
+			Permissions:  permissions,
+			Repositories: []string{"user2/repo1"},
+			ExpiresAt:    &expiresAt,
+		}, http.StatusBadRequest)
+		createToken(t, api.CreateAccessTokenOption{
+			Name:   "classic-no-expiry",
+			Scopes: []string{"read:repository"},
+		}, http.StatusBadRequest)
+	})
+
+	token := createToken(t, api.CreateAccessTokenOption{
+		Name:         "fine-grained",
+		Permissions:  permissions,
+		Repositories: []string{"user2/repo1"},
+		ExpiresAt:    &expiresAt,
+	}, http.StatusCreated)
+
+	t.Run("Created", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		assert.Equal(t, []string{"user2/repo1"}, token.Repositories)
+		require.NotNil(t, token.Permissions)
+		assert.Equal(t, "read", token.Permissions.Contents)
+		assert.Equal(t, "write", token.Permissions.Issues)
+		assert.Equal(t, "none", token.Permissions.Packages)
+		require.NotNil(t, token.Expires)
+		assert.Equal(t, expiresAt.Unix(), token.Expires.Unix())
+		assert.ElementsMatch(t, []string{"write:issue", "read:repository"}, token.Scopes)
+	})
+
+	t.Run("Restrictions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1").
+			AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/raw/README.md").
+			AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{
+			Title: "issue created by a fine-grained token",
+		}).AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the token cannot administrate the repository
+		req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1", &api.EditRepoOption{
+			Description: new(string),
+		}).AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		// other repositories are not accessible
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo2").
+			AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		// nothing can be written outside of the repositories
+		req = NewRequestWithJSON(t, "POST", "/api/v1/user/repos", &api.CreateRepoOption{
+			Name: "created-by-fine-grained-token",
+		}).AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		_, err := db.GetEngine(db.DefaultContext).ID(token.ID).Cols("expires_unix").
+			Update(&auth_model.AccessToken{ExpiresUnix: timeutil.TimeStampNow().Add(-60)})
+		require.NoError(t, err)
+
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1").
+			AddTokenAuth(token.Token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/util"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAPIUserSSHCertificate(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteUser)
+	publicKey, _, err := util.GenerateSSHKeypair()
+	require.NoError(t, err)
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.SSH.CertificateAuthorityEnabled, false)()
+
+		req := NewRequest(t, "GET", "/api/v1/user/ssh_certificates").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/ssh-certificate-authorities"), http.StatusNotFound)
+	})
+
+	defer test.MockVariableValue(&setting.SSH.CertificateAuthorityEnabled, true)()
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", api.CreateSSHCertificateOption{Key: "not a key"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", api.CreateSSHCertificateOption{Key: string(publicKey)}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var issued api.SSHCertificate
+	DecodeJSON(t, resp, &issued)
+	assert.Equal(t, "user2", issued.Principal)
+	assert.Equal(t, fmt.Sprintf("user2-%d", issued.ID), issued.KeyID)
+
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(issued.Certificate))
+	require.NoError(t, err)
+	cert, ok := parsed.(*ssh.Certificate)
+	require.True(t, ok)
+	assert.EqualValues(t, issued.ID, cert.Serial)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/ssh-certificate-authorities"), http.StatusOK)
+	assert.Equal(t, string(ssh.MarshalAuthorizedKey(cert.SignatureKey)), resp.Body.String())
+
+	req = NewRequest(t, "GET", "/api/v1/user/ssh_certificates").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var certs []*api.SSHCertificate
+	DecodeJSON(t, resp, &certs)
+	require.Len(t, certs, 1)
+	assert.Equal(t, issued.ID, certs[0].ID)
+	assert.Empty(t, certs[0].Certificate)
+
+	// other users cannot revoke the certificate
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteUser)
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/user/ssh_certificates/%d", issued.ID)).AddTokenAuth(otherToken)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/user/ssh_certificates/%d", issued.ID)).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	c := unittest.AssertExistsAndLoadBean(t, &asymkey_model.SSHCertificate{ID: issued.ID})
+	assert.NotZero(t, c.RevokedUnix)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/ssh-certificate-revocations"), http.StatusOK)
+	assert.Equal(t, fmt.Sprintf("serial: %d\n", issued.ID), resp.Body.String())
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"bufio"
+	"net/http"
+	"testing"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditAdminUserUpdate(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	session := loginUser(t, admin.Name)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteAdmin)
+	fullName := "Audited User"
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/admin/users/"+user.Name, api.EditUserOption{
+		FullName: &fullName,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+
+	event := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{
+		Action:     audit_model.ActionAdminUserUpdate,
+		ActorID:    admin.ID,
+		TargetType: audit_model.TargetUser,
+		TargetID:   user.ID,
+	})
+	assert.Equal(t, admin.Name, event.ActorName)
+	assert.Equal(t, user.Name, event.TargetName)
+	require.Contains(t, event.Changes, "full_name")
+	assert.Equal(t, user.FullName, event.Changes["full_name"].Before)
+	assert.Equal(t, "Audited User", event.Changes["full_name"].After)
+	assert.NotContains(t, event.Changes, "email")
+
+	t.Run("List", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/admin/audit?action=admin&actor="+admin.Name)
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), string(audit_model.ActionAdminUserUpdate))
+
+		req = NewRequest(t, "GET", "/admin/audit?action=repo")
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), string(audit_model.ActionAdminUserUpdate))
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/admin/audit/export?action="+string(audit_model.ActionAdminUserUpdate))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "application/x-ndjson", resp.Header().Get("Content-Type"))
+
+		var events []*audit_model.Event
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var e audit_model.Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			events = append(events, &e)
+		}
+		require.Len(t, events, 1)
+		assert.Equal(t, event.ID, events[0].ID)
+		assert.Equal(t, "Audited User", events[0].Changes["full_name"].After)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		session := loginUser(t, user.Name)
+		session.MakeRequest(t, NewRequest(t, "GET", "/admin/audit"), http.StatusForbidden)
+	})
+}
+
+func TestAuditRepoCollaborator(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/settings/collaboration", map[string]string{
+		"collaborator": "user4",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{
+		Action:     audit_model.ActionRepoCollaboratorAdd,
+		ActorID:    2,
+		OwnerID:    2,
+		RepoID:     1,
+		TargetType: audit_model.TargetUser,
+		TargetID:   4,
+	})
+
+	req = NewRequest(t, "GET", "/user2/repo1/settings/audit")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), string(audit_model.ActionRepoCollaboratorAdd))
+
+	// the events of other repositories are not shown
+	req = NewRequest(t, "GET", "/user2/repo2/settings/audit")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), string(audit_model.ActionRepoCollaboratorAdd))
+}
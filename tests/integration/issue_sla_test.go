@@ -0,0 +1,95 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	issue_service "forgejo.org/services/issue"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueSLAEscalation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the escalation is due as soon as the issue is opened
+	policy := &issues_model.SLAPolicy{
+		RepoID:                1,
+		CreatorID:             2,
+		Name:                  "Support",
+		ResponseSeconds:       3600,
+		ResolveSeconds:        7 * 24 * 3600,
+		EscalateBeforeSeconds: 2 * 3600,
+		EscalationComment:     true,
+		EscalationMentions:    "@user5",
+		EscalationLabelID:     2,
+	}
+	require.NoError(t, issues_model.CreateSLAPolicy(db.DefaultContext, policy))
+
+	token := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteIssue)
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{Title: "needs support"}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var apiIssue api.Issue
+	DecodeJSON(t, resp, &apiIssue)
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: apiIssue.ID, SLAPolicyID: policy.ID})
+	assert.NotZero(t, issue.SLAResponseDeadlineUnix)
+	assert.NotZero(t, issue.SLAResolveDeadlineUnix)
+
+	countComments := func() int {
+		return unittest.GetCount(t, &issues_model.Comment{IssueID: issue.ID, Type: issues_model.CommentTypeComment})
+	}
+
+	t.Run("Escalate", func(t *testing.T) {
+		require.NoError(t, issue_service.EscalateSLAs(db.DefaultContext))
+
+		comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, Type: issues_model.CommentTypeComment, PosterID: 2})
+		assert.Contains(t, comment.Content, "@user5")
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 2})
+
+		// the escalation comment is no response to the issue
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+		assert.True(t, issue.SLAResponseEscalated)
+		assert.Zero(t, issue.SLARespondedUnix)
+		assert.False(t, issue.SLAResponseBreached)
+	})
+
+	t.Run("Once", func(t *testing.T) {
+		count := countComments()
+		require.NoError(t, issue_service.EscalateSLAs(db.DefaultContext))
+		assert.Equal(t, count, countComments())
+	})
+
+	t.Run("Breach", func(t *testing.T) {
+		_, err := db.GetEngine(db.DefaultContext).ID(issue.ID).Cols("sla_response_deadline_unix").
+			Update(&issues_model.Issue{SLAResponseDeadlineUnix: timeutil.TimeStampNow().Add(-60)})
+		require.NoError(t, err)
+
+		require.NoError(t, issue_service.EscalateSLAs(db.DefaultContext))
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+		assert.True(t, issue.SLAResponseBreached)
+		assert.False(t, issue.SLAResolveBreached)
+	})
+
+	t.Run("Respond", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d/comments", issue.Index), &api.CreateIssueCommentOption{Body: "looking into it"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+		assert.NotZero(t, issue.SLARespondedUnix)
+		assert.True(t, issue.SLAResponseBreached)
+	})
+}
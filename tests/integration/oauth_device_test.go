@@ -0,0 +1,141 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/routers/web/auth"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthDeviceFlow(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the native app of user2 is a public client
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 2})
+
+	authorizeDevice := func(t *testing.T, status int) *auth.DeviceAuthorizationResponse {
+		t.Helper()
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+			"client_id": app.ClientID,
+			"scope":     "read:user",
+		})
+		resp := MakeRequest(t, req, status)
+		if status != http.StatusOK {
+			return nil
+		}
+		parsed := new(auth.DeviceAuthorizationResponse)
+		DecodeJSON(t, resp, parsed)
+		return parsed
+	}
+	pollToken := func(t *testing.T, deviceCode string, status int) *httptest.ResponseRecorder {
+		t.Helper()
+		req := NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+			"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+			"client_id":   app.ClientID,
+			"device_code": deviceCode,
+		})
+		return MakeRequest(t, req, status)
+	}
+	assertTokenError := func(t *testing.T, deviceCode string, code auth.AccessTokenErrorCode) {
+		t.Helper()
+		parsed := new(auth.AccessTokenError)
+		DecodeJSON(t, pollToken(t, deviceCode, http.StatusBadRequest), parsed)
+		assert.Equal(t, code, parsed.ErrorCode)
+	}
+
+	t.Run("Not enabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+			"client_id": app.ClientID,
+		})
+		parsed := new(auth.AccessTokenError)
+		DecodeJSON(t, MakeRequest(t, req, http.StatusBadRequest), parsed)
+		assert.Equal(t, auth.AccessTokenErrorCodeUnauthorizedClient, parsed.ErrorCode)
+	})
+
+	app.EnableDeviceFlow = true
+	_, err := db.GetEngine(db.DefaultContext).ID(app.ID).Cols("enable_device_flow").Update(app)
+	require.NoError(t, err)
+
+	session := loginUser(t, "user2")
+
+	t.Run("Approve", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		device := authorizeDevice(t, http.StatusOK)
+		assert.Equal(t, setting.AppURL+"login/oauth/device", device.VerificationURI)
+		assert.Equal(t, setting.OAuth2.DeviceCodePollingInterval, device.Interval)
+
+		assertTokenError(t, device.DeviceCode, auth.AccessTokenErrorCodeAuthorizationPending)
+		// polling again right away is too fast
+		assertTokenError(t, device.DeviceCode, auth.AccessTokenErrorCodeSlowDown)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", device.VerificationURIComplete), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), app.Name)
+
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+			"user_code": device.UserCode,
+			"granted":   "true",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		type response struct {
+			AccessToken  string `json:"access_token"`
+			RefreshToken string `json:"refresh_token"`
+		}
+		parsed := new(response)
+		DecodeJSON(t, pollToken(t, device.DeviceCode, http.StatusOK), parsed)
+		assert.Greater(t, len(parsed.AccessToken), 10)
+		assert.Greater(t, len(parsed.RefreshToken), 10)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Grant{UserID: 2, ApplicationID: app.ID, Scope: "read:user"})
+
+		// the device code can only be used once
+		assertTokenError(t, device.DeviceCode, auth.AccessTokenErrorCodeInvalidGrant)
+	})
+
+	t.Run("Deny", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		device := authorizeDevice(t, http.StatusOK)
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+			"user_code": device.UserCode,
+			"granted":   "false",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		assertTokenError(t, device.DeviceCode, auth.AccessTokenErrorCodeAccessDenied)
+		assertTokenError(t, device.DeviceCode, auth.AccessTokenErrorCodeInvalidGrant)
+	})
+
+	t.Run("Invalid user code", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/login/oauth/device?user_code=BCDF-GHJK")
+		session.MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+			"user_code": "BCDF-GHJK",
+			"granted":   "true",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("Sign in required", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", "/login/oauth/device"), http.StatusSeeOther)
+	})
+}
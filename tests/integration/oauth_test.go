@@ -328,7 +328,7 @@ func TestAccessTokenExchangeWithInvalidCredentials(t *testing.T) {
 	parsedError = new(auth.AccessTokenError)
 	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), parsedError))
 	assert.Equal(t, "unsupported_grant_type", string(parsedError.ErrorCode))
-	assert.Equal(t, "Only refresh_token or authorization_code grant type is supported", parsedError.ErrorDescription)
+	assert.Equal(t, "Only refresh_token, authorization_code or device_code grant type is supported", parsedError.ErrorDescription)
 }
 
 func TestAccessTokenExchangeWithBasicAuth(t *testing.T) {
@@ -0,0 +1,141 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	allowedRemoteAddr = "192.0.2.10:1234"
+	blockedRemoteAddr = "198.51.100.10:1234"
+)
+
+func fromRemoteAddr(req *RequestWrapper, remoteAddr string) *RequestWrapper {
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func TestOrgIPAllowlist(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const settingsURL = "/org/org3/settings/ip_allowlist"
+	owner := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, owner, auth_model.AccessTokenScopeReadRepository)
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{"allowed_ips": "not a network"})
+		owner.MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &organization.IPAllowlist{OrgID: 3})
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{"allowed_ips": "192.0.2.0/24"})
+		owner.MakeRequest(t, fromRemoteAddr(req, blockedRemoteAddr), http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &organization.IPAllowlist{OrgID: 3})
+	})
+
+	req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{"allowed_ips": "192.0.2.0/24 2001:db8::1"})
+	owner.MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusSeeOther)
+	allowlist := unittest.AssertExistsAndLoadBean(t, &organization.IPAllowlist{OrgID: 3})
+	assert.Equal(t, "192.0.2.0/24, 2001:db8::1/128", allowlist.AllowedIPs)
+
+	t.Run("Web", func(t *testing.T) {
+		owner.MakeRequest(t, fromRemoteAddr(NewRequest(t, "GET", "/org3/repo3"), allowedRemoteAddr), http.StatusOK)
+		owner.MakeRequest(t, fromRemoteAddr(NewRequest(t, "GET", "/org3/repo3"), blockedRemoteAddr), http.StatusForbidden)
+		owner.MakeRequest(t, fromRemoteAddr(NewRequest(t, "GET", "/org/org3/settings"), blockedRemoteAddr), http.StatusForbidden)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/org3/repo3").AddTokenAuth(token)
+		MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusOK)
+		req = NewRequest(t, "GET", "/api/v1/repos/org3/repo3").AddTokenAuth(token)
+		MakeRequest(t, fromRemoteAddr(req, blockedRemoteAddr), http.StatusForbidden)
+	})
+
+	t.Run("Git", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/org3/repo3.git/info/refs").AddBasicAuth("user2")
+		MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusOK)
+		req = NewRequest(t, "GET", "/org3/repo3.git/info/refs").AddBasicAuth("user2")
+		MakeRequest(t, fromRemoteAddr(req, blockedRemoteAddr), http.StatusForbidden)
+	})
+
+	t.Run("IssueSearch", func(t *testing.T) {
+		token := getTokenForLoggedInUser(t, owner, auth_model.AccessTokenScopeReadIssue, auth_model.AccessTokenScopeReadRepository)
+		searchIssues := func(t *testing.T, remoteAddr string) []*api.Issue {
+			t.Helper()
+			req := NewRequest(t, "GET", "/api/v1/repos/issues/search?type=issues&limit=50").AddTokenAuth(token)
+			resp := MakeRequest(t, fromRemoteAddr(req, remoteAddr), http.StatusOK)
+			var issues []*api.Issue
+			DecodeJSON(t, resp, &issues)
+			return issues
+		}
+		hasOrgIssue := func(issues []*api.Issue) bool {
+			for _, issue := range issues {
+				if issue.Repo.Owner == "org3" {
+					return true
+				}
+			}
+			return false
+		}
+
+		assert.True(t, hasOrgIssue(searchIssues(t, allowedRemoteAddr)))
+		issues := searchIssues(t, blockedRemoteAddr)
+		assert.NotEmpty(t, issues)
+		assert.False(t, hasOrgIssue(issues))
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		admin := loginUser(t, "user1")
+		admin.MakeRequest(t, fromRemoteAddr(NewRequest(t, "GET", "/org3/repo3"), blockedRemoteAddr), http.StatusOK)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		req := NewRequest(t, "POST", settingsURL+"/remove")
+		owner.MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusOK)
+		unittest.AssertNotExistsBean(t, &organization.IPAllowlist{OrgID: 3})
+
+		owner.MakeRequest(t, fromRemoteAddr(NewRequest(t, "GET", "/org3/repo3"), blockedRemoteAddr), http.StatusOK)
+	})
+}
+
+func TestAPIAccessTokenAllowedIPs(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/users/user2/tokens", api.CreateAccessTokenOption{
+		Name:       "allowed-ips",
+		Scopes:     []string{string(auth_model.AccessTokenScopeReadUser)},
+		AllowedIPs: []string{"192.0.2.0/24"},
+	}).AddBasicAuth("user2")
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var token api.AccessToken
+	DecodeJSON(t, resp, &token)
+	require.NotEmpty(t, token.Token)
+	assert.Equal(t, []string{"192.0.2.0/24"}, token.AllowedIPs)
+
+	req = NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.Token)
+	MakeRequest(t, fromRemoteAddr(req, allowedRemoteAddr), http.StatusOK)
+	req = NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.Token)
+	MakeRequest(t, fromRemoteAddr(req, blockedRemoteAddr), http.StatusUnauthorized)
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/users/user2/tokens", api.CreateAccessTokenOption{
+			Name:       "invalid-allowed-ips",
+			Scopes:     []string{string(auth_model.AccessTokenScopeReadUser)},
+			AllowedIPs: []string{"not a network"},
+		}).AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusBadRequest)
+		unittest.AssertNotExistsBean(t, &auth_model.AccessToken{Name: "invalid-allowed-ips"})
+	})
+}
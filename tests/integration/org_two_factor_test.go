@@ -0,0 +1,106 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/mailer"
+	org_service "forgejo.org/services/org"
+	"forgejo.org/tests"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgTwoFactorRequirement(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const settingsURL = "/org/org3/settings/two_factor"
+	owner := loginUser(t, "user2")
+	member := loginUser(t, "user4")
+	memberToken := getTokenForLoggedInUser(t, member, auth_model.AccessTokenScopeReadOrganization)
+
+	t.Run("Report", func(t *testing.T) {
+		resp := owner.MakeRequest(t, NewRequest(t, "GET", settingsURL), http.StatusOK)
+		doc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 3, doc.Find(".flex-list .flex-item-title").Length())
+
+		member.MakeRequest(t, NewRequest(t, "GET", settingsURL), http.StatusNotFound)
+	})
+
+	t.Run("OwnerWithoutTwoFactor", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{"grace_period": "0"})
+		owner.MakeRequest(t, req, http.StatusSeeOther)
+
+		unittest.AssertNotExistsBean(t, &organization.TwoFactorRequirement{OrgID: 3})
+	})
+
+	_, err := auth_model.CreateCredential(db.DefaultContext, 2, "Security key", &webauthn.Credential{ID: []byte("Security key")})
+	require.NoError(t, err)
+
+	t.Run("Remind", func(t *testing.T) {
+		var recipients []string
+		defer test.MockVariableValue(&mailer.SendAsync, func(msgs ...*mailer.Message) {
+			for _, msg := range msgs {
+				recipients = append(recipients, msg.To)
+			}
+		})()
+
+		_, err := organization.SetTwoFactorRequirement(db.DefaultContext, 3, timeutil.TimeStampNow().Add(24*3600), 0)
+		require.NoError(t, err)
+
+		require.NoError(t, org_service.RemindTwoFactorRequirements(db.DefaultContext))
+		assert.Len(t, recipients, 2)
+		requirement := unittest.AssertExistsAndLoadBean(t, &organization.TwoFactorRequirement{OrgID: 3})
+		assert.NotZero(t, requirement.RemindedUnix)
+
+		// the users are reminded only once
+		require.NoError(t, org_service.RemindTwoFactorRequirements(db.DefaultContext))
+		assert.Len(t, recipients, 2)
+	})
+
+	t.Run("Require", func(t *testing.T) {
+		var recipients []string
+		defer test.MockVariableValue(&mailer.SendAsync, func(msgs ...*mailer.Message) {
+			for _, msg := range msgs {
+				recipients = append(recipients, msg.To)
+			}
+		})()
+
+		req := NewRequestWithValues(t, "POST", settingsURL, map[string]string{"grace_period": "0"})
+		owner.MakeRequest(t, req, http.StatusSeeOther)
+
+		requirement := unittest.AssertExistsAndLoadBean(t, &organization.TwoFactorRequirement{OrgID: 3})
+		assert.True(t, requirement.IsEnforced())
+		// the owner has enabled 2fa, the other members are notified
+		assert.Len(t, recipients, 2)
+	})
+
+	t.Run("Blocked", func(t *testing.T) {
+		member.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3"), http.StatusNotFound)
+		owner.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3"), http.StatusOK)
+
+		member.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusForbidden)
+		owner.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/teams").AddTokenAuth(memberToken), http.StatusForbidden)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		owner.MakeRequest(t, NewRequest(t, "POST", settingsURL+"/remove"), http.StatusOK)
+
+		unittest.AssertNotExistsBean(t, &organization.TwoFactorRequirement{OrgID: 3})
+		member.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3"), http.StatusOK)
+		member.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/tests"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasskeySignIn(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	t.Run("Button", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user/login"), http.StatusOK)
+		NewHTMLParser(t, resp.Body).AssertElement(t, "#signin-passkey", true)
+	})
+
+	t.Run("Assertion", func(t *testing.T) {
+		session := emptyTestSession(t)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/webauthn/passkey/assertion"), http.StatusOK)
+
+		var assertion protocol.CredentialAssertion
+		DecodeJSON(t, resp, &assertion)
+		assert.NotEmpty(t, assertion.Response.Challenge)
+		assert.Empty(t, assertion.Response.AllowedCredentials)
+		assert.Equal(t, protocol.VerificationRequired, assertion.Response.UserVerification)
+
+		// an invalid response does not sign in
+		req := NewRequestWithJSON(t, "POST", "/user/webauthn/passkey/assertion", map[string]string{"id": "invalid"})
+		session.MakeRequest(t, req, http.StatusForbidden)
+		session.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+	})
+
+	t.Run("DisabledInternalSignIn", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Service.EnableInternalSignIn, false)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user/login"), http.StatusOK)
+		NewHTMLParser(t, resp.Body).AssertElement(t, "#signin-passkey", false)
+		MakeRequest(t, NewRequest(t, "GET", "/user/webauthn/passkey/assertion"), http.StatusForbidden)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectAutomationRules(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// project 1 of user2/repo1 has the columns To Do (1), In Progress (2) and Done (3),
+	// issue 1 is in To Do
+	for _, rule := range []*project_model.AutomationRule{
+		{ProjectID: 1, CreatorID: 2, Event: project_model.AutomationEventItemClosed, ColumnID: 3},
+		{ProjectID: 1, CreatorID: 2, Event: project_model.AutomationEventItemReopened, ColumnID: 2},
+		{ProjectID: 1, CreatorID: 2, Event: project_model.AutomationEventAutoAdd, ColumnID: 2, LabelID: 1},
+	} {
+		require.NoError(t, project_model.NewAutomationRule(db.DefaultContext, rule))
+	}
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	issueURL := "/api/v1/repos/user2/repo1/issues"
+
+	t.Run("Close", func(t *testing.T) {
+		state := "closed"
+		req := NewRequestWithJSON(t, "PATCH", issueURL+"/1", &api.EditIssueOption{State: &state}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 3})
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		state := "open"
+		req := NewRequestWithJSON(t, "PATCH", issueURL+"/1", &api.EditIssueOption{State: &state}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: 1, ProjectID: 1, ProjectColumnID: 2})
+	})
+
+	t.Run("AutoAdd", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", issueURL, &api.CreateIssueOption{Title: "unlabeled"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var issue api.Issue
+		DecodeJSON(t, resp, &issue)
+		// the rule only adds the issues with label1
+		unittest.AssertNotExistsBean(t, &project_model.ProjectIssue{IssueID: issue.ID})
+
+		req = NewRequestWithJSON(t, "POST", fmt.Sprintf("%s/%d/labels", issueURL, issue.Index), &api.IssueLabelsOption{Labels: []any{1}}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: issue.ID, ProjectID: 1, ProjectColumnID: 2})
+
+		req = NewRequestWithJSON(t, "POST", issueURL, &api.CreateIssueOption{Title: "labeled", Labels: []int64{1}}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &issue)
+		unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: issue.ID, ProjectID: 1, ProjectColumnID: 2})
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	advisory_service "forgejo.org/services/advisory"
+	files_service "forgejo.org/services/repository/files"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoSecurity(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		defer test.MockVariableValue(&setting.Advisories.Enabled, true)()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "GHSA-test-0001.json"), []byte(`{
+  "id": "GHSA-test-0001",
+  "modified": "2026-01-02T00:00:00Z",
+  "summary": "Remote code execution in example module",
+  "database_specific": {"severity": "HIGH"},
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "example.com/vulnerable"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.2.0"}]}]
+  }]
+}`), 0o644))
+
+		count, err := advisory_service.ImportDirectory(db.DefaultContext, dir)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		advisory := unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{Identifier: "GHSA-test-0001"})
+		defer func() {
+			require.NoError(t, advisory_model.DeleteAdvisory(db.DefaultContext, advisory.ID))
+		}()
+
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo, _, f := tests.CreateDeclarativeRepo(t, user2, "", nil, nil,
+			[]*files_service.ChangeRepoFile{
+				{
+					Operation: "create",
+					TreePath:  "go.sum",
+					ContentReader: strings.NewReader(`example.com/vulnerable v1.1.0 h1:abc=
+example.com/vulnerable v1.1.0/go.mod h1:def=
+example.com/safe v1.0.0 h1:ghi=
+`),
+				},
+			},
+		)
+		defer f()
+
+		require.NoError(t, advisory_service.ScanRepository(db.DefaultContext, repo))
+		alert := unittest.AssertExistsAndLoadBean(t, &advisory_model.Alert{RepoID: repo.ID, AdvisoryID: advisory.ID})
+		assert.Equal(t, "go.sum", alert.ManifestPath)
+		assert.Equal(t, "example.com/vulnerable", alert.PackageName)
+		assert.Equal(t, "v1.1.0", alert.Version)
+		assert.Equal(t, "1.2.0", alert.FixedVersion)
+		assert.Equal(t, advisory_model.AlertStatusOpen, alert.Status)
+		unittest.AssertCount(t, &advisory_model.Alert{RepoID: repo.ID}, 1)
+
+		link := fmt.Sprintf("/%s/security", repo.FullName())
+
+		t.Run("Reader", func(t *testing.T) {
+			MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+		})
+
+		session := loginUser(t, user2.Name)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "GHSA-test-0001")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", link+"?severity=low"), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "GHSA-test-0001")
+
+		session.MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("%s/%d/dismiss", link, alert.ID)), http.StatusSeeOther)
+		alert = unittest.AssertExistsAndLoadBean(t, &advisory_model.Alert{ID: alert.ID})
+		assert.Equal(t, advisory_model.AlertStatusDismissed, alert.Status)
+		assert.Equal(t, user2.ID, alert.DismisserID)
+
+		// dismissed alerts stay dismissed on a rescan
+		require.NoError(t, advisory_service.ScanRepository(db.DefaultContext, repo))
+		alert = unittest.AssertExistsAndLoadBean(t, &advisory_model.Alert{ID: alert.ID})
+		assert.Equal(t, advisory_model.AlertStatusDismissed, alert.Status)
+
+		session.MakeRequest(t, NewRequest(t, "POST", fmt.Sprintf("%s/%d/reopen", link, alert.ID)), http.StatusSeeOther)
+		alert = unittest.AssertExistsAndLoadBean(t, &advisory_model.Alert{ID: alert.ID})
+		assert.Equal(t, advisory_model.AlertStatusOpen, alert.Status)
+	})
+}
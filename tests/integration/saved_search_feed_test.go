@@ -0,0 +1,64 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/perm"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	auth_service "forgejo.org/services/auth"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchFeedTokenScope(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	search := &issues_model.SavedSearch{OwnerID: 2, CreatorID: 2, Name: "Created by me", ViewType: "created_by"}
+	require.NoError(t, issues_model.CreateSavedSearch(db.DefaultContext, search))
+	feedURL := fmt.Sprintf("/saved-searches/%d.rss", search.ID)
+
+	// issue7 is in the private repository user2/repo2
+	const privateIssue = "user2/repo2#2: issue7"
+
+	t.Run("Issue scope", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadIssue)
+		resp := MakeRequest(t, NewRequest(t, "GET", feedURL).AddTokenAuth(token), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), privateIssue)
+	})
+
+	t.Run("Wrong scope", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadPackage)
+		MakeRequest(t, NewRequest(t, "GET", feedURL).AddTokenAuth(token), http.StatusForbidden)
+	})
+
+	t.Run("Public only", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadIssue, auth_model.AccessTokenScopePublicOnly)
+		resp := MakeRequest(t, NewRequest(t, "GET", feedURL).AddTokenAuth(token), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), privateIssue)
+	})
+
+	t.Run("Fine-grained", func(t *testing.T) {
+		token, err := auth_service.CreateAccessToken(db.DefaultContext, user2, &auth_service.CreateAccessTokenOptions{
+			Name:         "saved-search-feed",
+			Permissions:  &auth_model.AccessTokenPermissions{Issues: perm.AccessModeRead},
+			Repositories: []string{"user2/repo1"},
+			ExpiresUnix:  timeutil.TimeStampNow().Add(3600),
+		})
+		require.NoError(t, err)
+		resp := MakeRequest(t, NewRequest(t, "GET", feedURL).AddTokenAuth(token.Token), http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), privateIssue)
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/scim"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/routers"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIM(t *testing.T) {
+	defer test.MockVariableValue(&setting.SCIM.Enabled, true)()
+	defer test.MockVariableValue(&testWebRoutes, routers.NormalRoutes())()
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+
+	t.Run("Access", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", "/scim/v2/Users"), http.StatusUnauthorized)
+		// only access tokens are accepted, not passwords
+		MakeRequest(t, NewRequest(t, "GET", "/scim/v2/Users").AddBasicAuth("user1"), http.StatusUnauthorized)
+
+		req := NewRequest(t, "GET", "/scim/v2/Users").AddTokenAuth(getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin))
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/scim/v2/Users").AddTokenAuth(getUserToken(t, "user1", auth_model.AccessTokenScopeReadUser))
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", "/scim/v2/ServiceProviderConfig").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), scim.ContentType)
+	})
+
+	var userID string
+	t.Run("Users", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", "/scim/v2/Users", map[string]any{
+			"schemas":    []string{scim.UserSchema},
+			"externalId": "ext-jdoe",
+			"userName":   "jdoe",
+			"name":       map[string]any{"givenName": "Jane", "familyName": "Doe"},
+			"emails":     []map[string]any{{"value": "jdoe@example.com", "primary": true}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var user scim.User
+		DecodeJSON(t, resp, &user)
+		userID = user.ID
+		assert.Equal(t, "jdoe", user.UserName)
+		assert.Equal(t, "ext-jdoe", user.ExternalID)
+		assert.True(t, user.IsActive())
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "jdoe"})
+		assert.Equal(t, "Jane Doe", u.FullName)
+		assert.Equal(t, "jdoe@example.com", u.Email)
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionAdminUserCreate, ActorID: 1, TargetID: u.ID})
+
+		// the user name is unique
+		req = NewRequestWithJSON(t, "POST", "/scim/v2/Users", map[string]any{
+			"userName": "jdoe",
+			"emails":   []map[string]any{{"value": "other@example.com"}},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusConflict)
+		var scimErr scim.ErrorResponse
+		DecodeJSON(t, resp, &scimErr)
+		assert.Equal(t, scim.ErrorTypeUniqueness, scimErr.ScimType)
+
+		req = NewRequest(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`externalId eq "ext-jdoe"`)).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var list scim.ListResponse
+		DecodeJSON(t, resp, &list)
+		assert.Equal(t, 1, list.TotalResults)
+
+		req = NewRequest(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "does-not-exist"`)).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &list)
+		assert.Equal(t, 0, list.TotalResults)
+
+		req = NewRequest(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape("userName eq")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// deactivation
+		req = NewRequestWithJSON(t, "PATCH", "/scim/v2/Users/"+userID, map[string]any{
+			"schemas":    []string{scim.PatchOpSchema},
+			"Operations": []map[string]any{{"op": "replace", "path": "active", "value": "False"}},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &user)
+		assert.False(t, user.IsActive())
+		u = unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "jdoe"})
+		assert.True(t, u.ProhibitLogin)
+
+		req = NewRequestWithJSON(t, "PUT", "/scim/v2/Users/"+userID, map[string]any{
+			"externalId": "ext-jdoe",
+			"userName":   "jane",
+			"emails":     []map[string]any{{"value": "jdoe@example.com", "primary": true}},
+			"active":     true,
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &user)
+		assert.Equal(t, "jane", user.UserName)
+		assert.True(t, user.IsActive())
+
+		MakeRequest(t, NewRequest(t, "GET", "/scim/v2/Users/999999").AddTokenAuth(token), http.StatusNotFound)
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", "/scim/v2/Groups", map[string]any{
+			"schemas":     []string{scim.GroupSchema},
+			"displayName": "org3/engineering",
+			"members":     []map[string]any{{"value": userID}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var group scim.Group
+		DecodeJSON(t, resp, &group)
+		assert.Equal(t, "org3/engineering", group.DisplayName)
+		require.Len(t, group.Members, 1)
+		assert.Equal(t, userID, group.Members[0].Value)
+
+		team := unittest.AssertExistsAndLoadBean(t, &organization.Team{OrgID: 3, LowerName: "engineering"})
+		uid, err := strconv.ParseInt(userID, 10, 64)
+		require.NoError(t, err)
+		unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: team.ID, UID: uid})
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionOrgTeamCreate, ActorID: 1, TargetID: team.ID})
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionOrgTeamMemberAdd, ActorID: 1, TargetID: team.ID})
+
+		req = NewRequestWithJSON(t, "POST", "/scim/v2/Groups", map[string]any{
+			"displayName": "does-not-exist/engineering",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithJSON(t, "PATCH", "/scim/v2/Groups/"+group.ID, map[string]any{
+			"schemas": []string{scim.PatchOpSchema},
+			"Operations": []map[string]any{
+				{"op": "remove", "path": fmt.Sprintf(`members[value eq "%s"]`, userID)},
+				{"op": "add", "path": "members", "value": []map[string]any{{"value": "2"}}},
+			},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &group)
+		require.Len(t, group.Members, 1)
+		assert.Equal(t, "2", group.Members[0].Value)
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionOrgTeamMemberRemove, ActorID: 1, TargetID: team.ID})
+
+		req = NewRequest(t, "GET", "/scim/v2/Groups?excludedAttributes=members&filter="+url.QueryEscape(`displayName eq "org3/engineering"`)).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var list scim.ListResponse
+		DecodeJSON(t, resp, &list)
+		assert.Equal(t, 1, list.TotalResults)
+
+		// the owners team cannot be deleted
+		MakeRequest(t, NewRequest(t, "DELETE", "/scim/v2/Groups/1").AddTokenAuth(token), http.StatusBadRequest)
+
+		MakeRequest(t, NewRequest(t, "DELETE", "/scim/v2/Groups/"+group.ID).AddTokenAuth(token), http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &organization.Team{ID: team.ID})
+		unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionOrgTeamDelete, ActorID: 1, TargetID: team.ID})
+		unittest.AssertNotExistsBean(t, &auth_model.SCIMExternalID{ResourceType: auth_model.SCIMResourceGroup, ResourceID: team.ID})
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "DELETE", "/scim/v2/Users/"+userID).AddTokenAuth(token), http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &user_model.User{Name: "jane"})
+		unittest.AssertNotExistsBean(t, &auth_model.SCIMExternalID{ResourceType: auth_model.SCIMResourceUser, ExternalID: "ext-jdoe"})
+
+		// users owning repositories are not deleted
+		MakeRequest(t, NewRequest(t, "DELETE", "/scim/v2/Users/2").AddTokenAuth(token), http.StatusConflict)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertSignedIn checks whether a session of user2 can view the private repository of user2
+func assertSignedIn(t *testing.T, session *TestSession, signedIn bool) {
+	t.Helper()
+	if signedIn {
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2"), http.StatusOK)
+	} else {
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2"), http.StatusNotFound)
+	}
+}
+
+func TestUserSessions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	before, err := auth_model.GetUserSessionsByUserID(db.DefaultContext, 2)
+	require.NoError(t, err)
+
+	current := loginUser(t, "user2")
+	other := loginUser(t, "user2")
+	assertSignedIn(t, current, true)
+	assertSignedIn(t, other, true)
+
+	sessions, err := auth_model.GetUserSessionsByUserID(db.DefaultContext, 2)
+	require.NoError(t, err)
+	require.Len(t, sessions, len(before)+2)
+	assert.Equal(t, auth_model.UserSessionAuthPassword, sessions[0].AuthMethod)
+
+	t.Run("List", func(t *testing.T) {
+		resp := current.MakeRequest(t, NewRequest(t, "GET", "/user/settings/sessions"), http.StatusOK)
+		doc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, doc.Find(".flex-item .label").Length())
+		// all sessions but the current one can be revoked
+		assert.Equal(t, len(before)+1, doc.Find(`.flex-item-trailing button[data-url*="/revoke?id="]`).Length())
+	})
+
+	t.Run("RevokeOthers", func(t *testing.T) {
+		current.MakeRequest(t, NewRequest(t, "POST", "/user/settings/sessions/revoke_others"), http.StatusOK)
+
+		assertSignedIn(t, current, true)
+		assertSignedIn(t, other, false)
+
+		sessions, err := auth_model.GetUserSessionsByUserID(db.DefaultContext, 2)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1)
+	})
+
+	t.Run("AdminSignOut", func(t *testing.T) {
+		admin := loginUser(t, "user1")
+		admin.MakeRequest(t, NewRequest(t, "POST", "/admin/users/2/sign_out"), http.StatusOK)
+
+		assertSignedIn(t, current, false)
+		sessions, err := auth_model.GetUserSessionsByUserID(db.DefaultContext, 2)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestUserSessionsPasswordChange(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	current := loginUser(t, "user2")
+	other := loginUser(t, "user2")
+	assertSignedIn(t, other, true)
+
+	req := NewRequestWithValues(t, "POST", "/user/settings/account", map[string]string{
+		"old_password": userPassword,
+		"password":     "password2",
+		"retype":       "password2",
+	})
+	current.MakeRequest(t, req, http.StatusSeeOther)
+
+	assertSignedIn(t, current, true)
+	assertSignedIn(t, other, false)
+}
@@ -1,5 +1,5 @@
 import {encodeURLEncodedBase64, decodeURLEncodedBase64} from '../utils.js';
-import {showElem} from '../utils/dom.js';
+import {hideElem, showElem} from '../utils/dom.js';
 import {GET, POST} from '../modules/fetch.js';
 
 const {appSubUrl} = window.config;
@@ -28,7 +28,7 @@ export async function initUserAuthWebAuthn() {
     const credential = await navigator.credentials.get({
       publicKey: options.publicKey,
     });
-    await verifyAssertion(credential);
+    await verifyAssertion(credential, `${appSubUrl}/user/webauthn/assertion`);
   } catch (err) {
     if (!options.publicKey.extensions?.appid) {
       webAuthnError('general', err.message);
@@ -39,14 +39,48 @@ export async function initUserAuthWebAuthn() {
       const credential = await navigator.credentials.get({
         publicKey: options.publicKey,
       });
-      await verifyAssertion(credential);
+      await verifyAssertion(credential, `${appSubUrl}/user/webauthn/assertion`);
     } catch (err) {
       webAuthnError('general', err.message);
     }
   }
 }
 
-async function verifyAssertion(assertedCredential) {
+export function initUserAuthPasskey() {
+  const elPasskey = document.getElementById('signin-passkey');
+  if (!elPasskey) {
+    return;
+  }
+  // don't show an error on every sign-in page, the button is only hidden
+  if (!window.isSecureContext || typeof window.PublicKeyCredential !== 'function') {
+    hideElem(elPasskey);
+    return;
+  }
+  elPasskey.addEventListener('click', async (e) => {
+    e.preventDefault();
+    await passkeyLoginRequest();
+  });
+}
+
+async function passkeyLoginRequest() {
+  const res = await GET(`${appSubUrl}/user/webauthn/passkey/assertion`);
+  if (res.status !== 200) {
+    webAuthnError('unknown');
+    return;
+  }
+  const options = await res.json();
+  options.publicKey.challenge = decodeURLEncodedBase64(options.publicKey.challenge);
+  try {
+    const credential = await navigator.credentials.get({
+      publicKey: options.publicKey,
+    });
+    await verifyAssertion(credential, `${appSubUrl}/user/webauthn/passkey/assertion`);
+  } catch (err) {
+    webAuthnError('general', err.message);
+  }
+}
+
+async function verifyAssertion(assertedCredential, url) {
   // Move data into Arrays in case it is super long
   const authData = new Uint8Array(assertedCredential.response.authenticatorData);
   const clientDataJSON = new Uint8Array(assertedCredential.response.clientDataJSON);
@@ -54,7 +88,7 @@ async function verifyAssertion(assertedCredential) {
   const sig = new Uint8Array(assertedCredential.response.signature);
   const userHandle = new Uint8Array(assertedCredential.response.userHandle);
 
-  const res = await POST(`${appSubUrl}/user/webauthn/assertion`, {
+  const res = await POST(url, {
     data: {
       id: assertedCredential.id,
       rawId: encodeURLEncodedBase64(rawId),
@@ -159,6 +193,9 @@ async function webAuthnRegisterRequest() {
 
   const formData = new FormData();
   formData.append('name', elNickname.value);
+  if (document.getElementById('passkey')?.checked) {
+    formData.append('passkey', 'on');
+  }
 
   const res = await POST(`${appSubUrl}/user/settings/security/webauthn/request_register`, {
     data: formData,
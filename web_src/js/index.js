@@ -62,7 +62,7 @@ import {
 } from './features/repo-settings.js';
 import {initRepoDiffView} from './features/repo-diff.js';
 import {initOrgTeamSearchRepoBox} from './features/org-team.js';
-import {initUserAuthWebAuthn, initUserAuthWebAuthnRegister} from './features/user-auth-webauthn.js';
+import {initUserAuthPasskey, initUserAuthWebAuthn, initUserAuthWebAuthnRegister} from './features/user-auth-webauthn.js';
 import {initRepoRelease, initRepoReleaseNew} from './features/repo-release.js';
 import {initRepoEditor} from './features/repo-editor.js';
 import {initCompSearchUserBox} from './features/comp/SearchUserBox.js';
@@ -189,6 +189,7 @@ onDomReady(() => {
 
   initUserAuthOauth2();
   initUserAuthWebAuthn();
+  initUserAuthPasskey();
   initUserAuthWebAuthnRegister();
   initUserAuth();
   initRepoDiffView();